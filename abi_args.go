@@ -0,0 +1,185 @@
+package etherkit
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+//############ ABI Arguments ############
+
+// bigIntType *big.Int 的反射类型
+var bigIntType = reflect.TypeOf(&big.Int{})
+
+// normalizeABIArgs 将调用参数转换为 go-ethereum 打包所需的 Go 类型
+// 在 go-ethereum 原生支持的基础上（字段名或 abi 标签与元组组件对应的结构体）额外支持：
+//   - 结构体指针、结构体指针切片，以及按组件名（不区分大小写）匹配的结构体字段
+//   - map[string]interface{}（键为组件名）和按顺序排列的 []interface{} 作为元组
+//   - 任意整数类型作为 uintN/intN 参数（超出范围时返回错误）
+//
+// 参数数量不匹配或无法转换的值原样返回，由 Pack 报告错误
+func normalizeABIArgs(inputs abi.Arguments, args []interface{}) ([]interface{}, error) {
+	if len(inputs) != len(args) {
+		return args, nil
+	}
+	out := make([]interface{}, len(args))
+	for i, arg := range args {
+		if arg == nil {
+			out[i] = arg
+			continue
+		}
+		v, err := normalizeABIValue(inputs[i].Type, reflect.ValueOf(arg))
+		if err != nil {
+			name := inputs[i].Name
+			if name == "" {
+				name = fmt.Sprintf("#%d", i)
+			}
+			return nil, fmt.Errorf("argument %s (%s): %w", name, inputs[i].Type, err)
+		}
+		out[i] = v.Interface()
+	}
+	return out, nil
+}
+
+// normalizeABIValue 将值转换为 typ 对应的 Go 类型（无法转换时原样返回）
+func normalizeABIValue(typ abi.Type, v reflect.Value) (reflect.Value, error) {
+	target := typ.GetType()
+	if v.Type().AssignableTo(target) {
+		return v, nil
+	}
+	if v.Kind() == reflect.Interface || (v.Kind() == reflect.Ptr && v.Type() != bigIntType) {
+		if v.IsNil() {
+			return v, nil
+		}
+		return normalizeABIValue(typ, v.Elem())
+	}
+
+	switch typ.T {
+	case abi.TupleTy:
+		return normalizeTuple(typ, target, v)
+	case abi.SliceTy, abi.ArrayTy:
+		if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+			return v, nil
+		}
+		var out reflect.Value
+		if typ.T == abi.SliceTy {
+			out = reflect.MakeSlice(target, v.Len(), v.Len())
+		} else {
+			if v.Len() != typ.Size {
+				return v, fmt.Errorf("expected %d elements, got %d", typ.Size, v.Len())
+			}
+			out = reflect.New(target).Elem()
+		}
+		for i := 0; i < v.Len(); i++ {
+			elem, err := normalizeABIValue(*typ.Elem, v.Index(i))
+			if err != nil {
+				return v, fmt.Errorf("element %d: %w", i, err)
+			}
+			if !elem.Type().AssignableTo(target.Elem()) {
+				return v, nil
+			}
+			out.Index(i).Set(elem)
+		}
+		return out, nil
+	case abi.IntTy, abi.UintTy:
+		return normalizeInteger(typ, target, v)
+	}
+	return v, nil
+}
+
+// normalizeTuple 将结构体、map 或切片转换为元组对应的结构体类型
+func normalizeTuple(typ abi.Type, target reflect.Type, v reflect.Value) (reflect.Value, error) {
+	out := reflect.New(target).Elem()
+	for i, raw := range typ.TupleRawNames {
+		var field reflect.Value
+		switch v.Kind() {
+		case reflect.Struct:
+			field = findTupleField(v, raw)
+		case reflect.Map:
+			if v.Type().Key().Kind() != reflect.String {
+				return v, nil
+			}
+			field = v.MapIndex(reflect.ValueOf(raw).Convert(v.Type().Key()))
+		case reflect.Slice, reflect.Array:
+			if v.Len() != len(typ.TupleElems) {
+				return v, fmt.Errorf("expected %d tuple components, got %d", len(typ.TupleElems), v.Len())
+			}
+			field = v.Index(i)
+		default:
+			return v, nil
+		}
+		if field.IsValid() && field.Kind() == reflect.Interface {
+			field = field.Elem()
+		}
+		if !field.IsValid() {
+			return v, fmt.Errorf("missing tuple component %q", raw)
+		}
+		elem, err := normalizeABIValue(*typ.TupleElems[i], field)
+		if err != nil {
+			return v, fmt.Errorf("%s: %w", raw, err)
+		}
+		dst := out.Field(i)
+		if !elem.Type().AssignableTo(dst.Type()) {
+			return v, fmt.Errorf("%s: cannot use %s as %s", raw, elem.Type(), typ.TupleElems[i])
+		}
+		dst.Set(elem)
+	}
+	return out, nil
+}
+
+// findTupleField 按 abi 标签、字段名（不区分大小写）查找元组组件对应的结构体字段
+func findTupleField(v reflect.Value, raw string) reflect.Value {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() && t.Field(i).Tag.Get("abi") == raw {
+			return v.Field(i)
+		}
+	}
+	name := strings.ReplaceAll(abi.ToCamelCase(raw), "_", "")
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() && t.Field(i).Tag.Get("abi") == "" && strings.EqualFold(t.Field(i).Name, name) {
+			return v.Field(i)
+		}
+	}
+	return reflect.Value{}
+}
+
+// normalizeInteger 将任意整数类型转换为 intN/uintN 对应的 Go 类型（*big.Int 或定长整数）
+func normalizeInteger(typ abi.Type, target reflect.Type, v reflect.Value) (reflect.Value, error) {
+	n := new(big.Int)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n.SetInt64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n.SetUint64(v.Uint())
+	default:
+		if v.Type() != bigIntType || v.IsNil() {
+			return v, nil
+		}
+		n.Set(v.Interface().(*big.Int))
+	}
+
+	if typ.T == abi.UintTy && (n.Sign() < 0 || n.BitLen() > typ.Size) {
+		return v, fmt.Errorf("value %s out of range for %s", n, typ)
+	}
+	if typ.T == abi.IntTy {
+		magnitude := n
+		if n.Sign() < 0 {
+			magnitude = new(big.Int).Sub(new(big.Int).Neg(n), big.NewInt(1))
+		}
+		if magnitude.BitLen() > typ.Size-1 {
+			return v, fmt.Errorf("value %s out of range for %s", n, typ)
+		}
+	}
+
+	if target == bigIntType {
+		return reflect.ValueOf(n), nil
+	}
+	if typ.T == abi.UintTy {
+		return reflect.ValueOf(n.Uint64()).Convert(target), nil
+	}
+	return reflect.ValueOf(n.Int64()).Convert(target), nil
+}
@@ -0,0 +1,88 @@
+package etherkit
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestBuildContractInputDataTuples(t *testing.T) {
+	parsed, err := ParseABIFragments(
+		"function fill((address token, uint256 amount, (uint8 kind, bytes32 salt) meta) order, (address token, uint256 amount, (uint8 kind, bytes32 salt) meta)[] items)",
+		"function setLimit(uint8 level, int16 delta)",
+	)
+	if err != nil {
+		t.Fatalf("ParseABIFragments() failed: %v", err)
+	}
+	token := common.HexToAddress("0x1c7D4B196Cb0C7B01d743Fbc6116a902379C7238")
+
+	// 与组件名对应的匿名结构体（go-ethereum 原生支持的形式），作为期望结果
+	type meta struct {
+		Kind uint8
+		Salt [32]byte
+	}
+	type order struct {
+		Token  common.Address
+		Amount *big.Int
+		Meta   meta
+	}
+	native := order{token, big.NewInt(100), meta{1, [32]byte{0xaa}}}
+	want, err := parsed.Pack("fill", native, []order{native})
+	if err != nil {
+		t.Fatalf("Pack() failed: %v", err)
+	}
+
+	// 使用 abi 标签和不同字段名的结构体
+	type taggedMeta struct {
+		K uint8    `abi:"kind"`
+		S [32]byte `abi:"salt"`
+	}
+	type tagged struct {
+		Asset  common.Address `abi:"token"`
+		Amount uint64         // 整数类型自动转换为 uint256
+		Meta   *taggedMeta
+	}
+	t1 := &tagged{token, 100, &taggedMeta{1, [32]byte{0xaa}}}
+	asMap := map[string]interface{}{
+		"token":  token,
+		"amount": 100,
+		"meta":   []interface{}{uint8(1), [32]byte{0xaa}},
+	}
+
+	tests := []struct {
+		name    string
+		fn      string
+		args    []interface{}
+		want    []byte
+		wantErr bool
+	}{
+		{"native structs", "fill", []interface{}{native, []order{native}}, want, false},
+		{"tagged struct pointers", "fill", []interface{}{t1, []*tagged{t1}}, want, false},
+		{"map and positional tuple", "fill", []interface{}{asMap, []interface{}{asMap}}, want, false},
+		{"missing component", "fill", []interface{}{map[string]interface{}{"token": token}, []order{}}, nil, true},
+		{"small integers", "setLimit", []interface{}{1, -2}, nil, false},
+		{"uint8 overflow", "setLimit", []interface{}{256, 0}, nil, true},
+		{"int16 overflow", "setLimit", []interface{}{1, -32769}, nil, true},
+		{"unknown method", "missing", nil, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BuildContractInputData(parsed, tt.fn, tt.args...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BuildContractInputData() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.want != nil && !bytes.Equal(got, tt.want) {
+				t.Errorf("BuildContractInputData() = %x, expected %x", got, tt.want)
+			}
+		})
+	}
+
+	// 签名编码同样支持结构体参数
+	got, err := EncodeFunctionCall("function fill((address token, uint256 amount, (uint8 kind, bytes32 salt) meta) order, (address token, uint256 amount, (uint8 kind, bytes32 salt) meta)[] items)", t1, []*tagged{t1})
+	if err != nil || !bytes.Equal(got, want) {
+		t.Errorf("EncodeFunctionCall() = %x, %v, expected %x", got, err, want)
+	}
+}
@@ -0,0 +1,100 @@
+package etherkit
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+//############ ABI Cache ############
+
+// DefaultABICacheSize ABI 解析缓存的容量（按 ABI JSON 区分）
+const DefaultABICacheSize = 128
+
+// abiCache 以 ABI JSON 的哈希为键缓存解析结果
+var abiCache = newLRUCache[common.Hash, abi.ABI](DefaultABICacheSize)
+
+// getABICached 解析 ABI JSON，相同的 ABI 只解析一次
+// 返回的 ABI 在调用方之间共享，不应修改
+func getABICached(abiJSON string) (abi.ABI, error) {
+	key := crypto.Keccak256Hash([]byte(abiJSON))
+	if parsed, ok := abiCache.Get(key); ok {
+		return parsed, nil
+	}
+	parsed, err := GetABI(abiJSON)
+	if err != nil {
+		return abi.ABI{}, err
+	}
+	abiCache.Add(key, parsed)
+	return parsed, nil
+}
+
+// PreparsedContract 预先解析好 ABI 并绑定到 Kit 的合约句柄
+// 适用于在循环中反复调用同一合约的场景，避免每次调用都解析 ABI JSON
+type PreparsedContract struct {
+	Address common.Address // 合约地址
+	ABI     abi.ABI        // 解析后的 ABI
+	kit     *Kit
+}
+
+// PrepareContract 解析 ABI 并创建合约句柄
+// 参数说明：
+//   - address: 合约地址
+//   - abiJSON: ABI JSON 字符串
+//
+// 返回：
+//   - *PreparsedContract: 合约句柄
+//   - error: 如果 ABI 无效则返回错误
+//
+// 使用示例：
+//
+//	pool, err := kit.PrepareContract(poolAddr, poolABI)
+//	for _, user := range users {
+//	    out, err := pool.Call(ctx, "balanceOf", user)
+//	}
+func (k *Kit) PrepareContract(address common.Address, abiJSON string) (*PreparsedContract, error) {
+	if abiJSON == "" {
+		return nil, errors.New("ABI JSON string cannot be empty")
+	}
+	parsed, err := getABICached(abiJSON)
+	if err != nil {
+		return nil, err
+	}
+	return &PreparsedContract{Address: address, ABI: parsed, kit: k}, nil
+}
+
+// Pack 构建方法调用数据
+func (c *PreparsedContract) Pack(functionName string, params ...interface{}) ([]byte, error) {
+	return BuildContractInputData(c.ABI, functionName, params...)
+}
+
+// Call 在最新区块上只读调用合约方法（调用者为 Kit 地址）
+// 参数说明：
+//   - ctx: 上下文对象
+//   - functionName: 函数名
+//   - params: 函数参数
+//
+// 返回：
+//   - []interface{}: 函数返回值
+//   - error: 如果调用失败则返回错误
+func (c *PreparsedContract) Call(ctx context.Context, functionName string, params ...interface{}) ([]interface{}, error) {
+	return c.kit.StaticCall(ctx, c.Address, c.ABI, functionName, nil, nil, nil, params...)
+}
+
+// Invoke 发送交易调用合约方法（nonce、gas 自动计算，走 Kit 的审核与审计流程）
+// 参数说明：
+//   - ctx: 上下文对象
+//   - value: 附带的本位币金额（nil 表示不转账）
+//   - functionName: 函数名
+//   - params: 函数参数
+//
+// 返回：
+//   - common.Hash: 交易哈希
+//   - error: 如果发送失败则返回错误
+func (c *PreparsedContract) Invoke(ctx context.Context, value *big.Int, functionName string, params ...interface{}) (common.Hash, error) {
+	return c.kit.InvokeContract(ctx, c.Address, c.ABI, functionName, 0, 0, nil, value, params...)
+}
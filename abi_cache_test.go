@@ -0,0 +1,57 @@
+package etherkit
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/guanzhenxing/go-evm-kit/contracts/erc20"
+)
+
+func TestABICache(t *testing.T) {
+	abiJSON := erc20.IERC20MetaData.ABI
+	first, err := getABICached(abiJSON)
+	if err != nil {
+		t.Fatalf("getABICached() failed: %v", err)
+	}
+	hits, _ := abiCache.stats()
+	second, err := getABICached(abiJSON)
+	if err != nil {
+		t.Fatalf("getABICached() failed: %v", err)
+	}
+	if after, _ := abiCache.stats(); after != hits+1 {
+		t.Errorf("second parse should hit the cache")
+	}
+	if len(first.Methods) != len(second.Methods) {
+		t.Errorf("cached ABI differs from parsed ABI")
+	}
+	if _, err := getABICached("not json"); err == nil {
+		t.Error("Expected error for invalid ABI")
+	}
+}
+
+func TestPreparsedContract(t *testing.T) {
+	server := newMockSendServer(t)
+	server.handlers["eth_call"] = mockERC20Call(big.NewInt(2500000), 6, "USDC")
+	kit := newMockKit(t, server)
+	token := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+
+	contract, err := kit.PrepareContract(token, erc20.IERC20MetaData.ABI)
+	if err != nil {
+		t.Fatalf("PrepareContract() failed: %v", err)
+	}
+	out, err := contract.Call(context.Background(), "balanceOf", kit.GetAddress())
+	if err != nil || out[0].(*big.Int).Int64() != 2500000 {
+		t.Errorf("Call() = %v, %v", out, err)
+	}
+	if _, err := contract.Invoke(context.Background(), nil, "transfer", token, big.NewInt(1)); err != nil {
+		t.Errorf("Invoke() failed: %v", err)
+	}
+	if server.callCount("eth_sendRawTransaction") != 1 {
+		t.Errorf("eth_sendRawTransaction count = %d, expected 1", server.callCount("eth_sendRawTransaction"))
+	}
+	if _, err := kit.PrepareContract(token, ""); err == nil {
+		t.Error("Expected error for empty ABI")
+	}
+}
@@ -0,0 +1,101 @@
+package etherkit
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+//############ ABI Codec ############
+
+// parseSignatureMethod 将函数签名解析为 abi.Method
+// 支持可读 ABI 片段（"balanceOf(address) view returns (uint256)"）和 cast 风格的输出声明（"balanceOf(address)(uint256)"）
+func parseSignatureMethod(signature string) (abi.Method, error) {
+	s := strings.TrimSpace(signature)
+	if open := strings.IndexByte(s, '('); open >= 0 {
+		if _, rest, err := matchParen(s[open:]); err == nil && strings.HasPrefix(strings.TrimSpace(rest), "(") {
+			s = s[:len(s)-len(rest)] + " returns " + strings.TrimSpace(rest)
+		}
+	}
+	parsed, err := ParseABIFragments(s)
+	if err != nil {
+		return abi.Method{}, err
+	}
+	if len(parsed.Methods) != 1 {
+		return abi.Method{}, fmt.Errorf("%w: %q is not a function signature", ErrInvalidABI, signature)
+	}
+	for _, method := range parsed.Methods {
+		return method, nil
+	}
+	return abi.Method{}, nil
+}
+
+// EncodeFunctionCall 根据函数签名编码调用数据，无需构造完整的 ABI
+// 参数说明：
+//   - signature: 函数签名（如 "transfer(address,uint256)" 或 "function transfer(address to, uint256 amount)"）
+//   - args: 函数参数（按签名顺序传入，类型需与 ABI 类型对应，如 address 传 common.Address、uint256 传 *big.Int）
+//
+// 返回：
+//   - []byte: 调用数据（函数选择器 + 编码后的参数）
+//   - error: 如果签名无效或参数与签名不匹配则返回错误
+//
+// 示例：
+//   - EncodeFunctionCall("transfer(address,uint256)", to, big.NewInt(1e6))
+//   - EncodeFunctionCall("approve(address spender, uint256 amount)", router, abi.MaxUint256)
+func EncodeFunctionCall(signature string, args ...interface{}) ([]byte, error) {
+	method, err := parseSignatureMethod(signature)
+	if err != nil {
+		return nil, err
+	}
+	if args, err = normalizeABIArgs(method.Inputs, args); err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", method.Sig, err)
+	}
+	packed, err := method.Inputs.Pack(args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", method.Sig, err)
+	}
+	return append(bytes.Clone(method.ID), packed...), nil
+}
+
+// DecodeFunctionCall 根据函数签名解码调用数据中的参数（用于调试交易输入）
+// 参数说明：
+//   - signature: 函数签名
+//   - data: 调用数据（包含函数选择器）
+//
+// 返回：
+//   - []interface{}: 解码后的参数
+//   - error: 如果签名无效、选择器不匹配或数据格式错误则返回错误
+func DecodeFunctionCall(signature string, data []byte) ([]interface{}, error) {
+	method, err := parseSignatureMethod(signature)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 4 || !bytes.Equal(data[:4], method.ID) {
+		return nil, fmt.Errorf("calldata selector does not match %s (0x%x)", method.Sig, method.ID)
+	}
+	return method.Inputs.Unpack(data[4:])
+}
+
+// DecodeFunctionResult 根据函数签名解码 eth_call 的返回数据，无需构造完整的 ABI
+// 参数说明：
+//   - signature: 带返回类型的函数签名（如 "balanceOf(address) returns (uint256)" 或 "balanceOf(address)(uint256)"）
+//   - data: 返回数据
+//
+// 返回：
+//   - []interface{}: 解码后的返回值
+//   - error: 如果签名无效、未声明返回类型或数据格式错误则返回错误
+//
+// 示例：
+//   - out, err := DecodeFunctionResult("getReserves()(uint112,uint112,uint32)", data)
+func DecodeFunctionResult(signature string, data []byte) ([]interface{}, error) {
+	method, err := parseSignatureMethod(signature)
+	if err != nil {
+		return nil, err
+	}
+	if len(method.Outputs) == 0 {
+		return nil, fmt.Errorf("%w: %q declares no return types", ErrInvalidABI, signature)
+	}
+	return method.Outputs.Unpack(data)
+}
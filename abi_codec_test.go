@@ -0,0 +1,93 @@
+package etherkit
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestEncodeFunctionCall(t *testing.T) {
+	to := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	want, _ := erc20ABI.Pack("transfer", to, big.NewInt(1000000))
+
+	tests := []struct {
+		name      string
+		signature string
+		args      []interface{}
+		wantErr   bool
+	}{
+		{"bare signature", "transfer(address,uint256)", []interface{}{to, big.NewInt(1000000)}, false},
+		{"fragment", "function transfer(address to, uint amount) returns (bool)", []interface{}{to, big.NewInt(1000000)}, false},
+		{"wrong arg count", "transfer(address,uint256)", []interface{}{to}, true},
+		{"wrong arg type", "transfer(address,uint256)", []interface{}{"0x1234", big.NewInt(1)}, true},
+		{"event", "event Transfer(address,address,uint256)", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EncodeFunctionCall(tt.signature, tt.args...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("EncodeFunctionCall() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && common.Bytes2Hex(got) != common.Bytes2Hex(want) {
+				t.Errorf("EncodeFunctionCall() = %x, expected %x", got, want)
+			}
+		})
+	}
+
+	// 解码调用数据
+	args, err := DecodeFunctionCall("transfer(address,uint256)", want)
+	if err != nil || len(args) != 2 || args[0].(common.Address) != to || args[1].(*big.Int).Int64() != 1000000 {
+		t.Errorf("DecodeFunctionCall() = %v, %v", args, err)
+	}
+	if _, err := DecodeFunctionCall("approve(address,uint256)", want); err == nil {
+		t.Error("Expected error for selector mismatch")
+	}
+}
+
+func TestDecodeFunctionResult(t *testing.T) {
+	balance, _ := erc20ABI.Methods["balanceOf"].Outputs.Pack(big.NewInt(42))
+	tuple := append(common.LeftPadBytes(big.NewInt(1).Bytes(), 32), append(common.LeftPadBytes(big.NewInt(2).Bytes(), 32), common.LeftPadBytes(big.NewInt(3).Bytes(), 32)...)...)
+
+	tests := []struct {
+		name      string
+		signature string
+		data      []byte
+		want      []int64
+		wantErr   bool
+	}{
+		{"cast style", "balanceOf(address)(uint256)", balance, []int64{42}, false},
+		{"returns keyword", "function balanceOf(address owner) view returns (uint256)", balance, []int64{42}, false},
+		{"multiple outputs", "getReserves()(uint112,uint112,uint32)", tuple, []int64{1, 2, 3}, false},
+		{"no outputs", "balanceOf(address)", balance, nil, true},
+		{"short data", "getReserves()(uint112,uint112,uint32)", balance, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeFunctionResult(tt.signature, tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DecodeFunctionResult() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("len(result) = %d, expected %d", len(got), len(tt.want))
+			}
+			for i, w := range tt.want {
+				var v int64
+				switch n := got[i].(type) {
+				case *big.Int:
+					v = n.Int64()
+				case uint32:
+					v = int64(n)
+				}
+				if v != w {
+					t.Errorf("result[%d] = %v, expected %d", i, got[i], w)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,243 @@
+package etherkit
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+//############ Human-Readable ABI ############
+
+// abiFragmentArg ABI JSON 中的参数
+type abiFragmentArg struct {
+	Name       string           `json:"name"`
+	Type       string           `json:"type"`
+	Indexed    bool             `json:"indexed,omitempty"`
+	Components []abiFragmentArg `json:"components,omitempty"`
+}
+
+// abiFragmentEntry ABI JSON 中的一项（函数、事件、错误或构造函数）
+type abiFragmentEntry struct {
+	Type            string           `json:"type"`
+	Name            string           `json:"name,omitempty"`
+	Inputs          []abiFragmentArg `json:"inputs"`
+	Outputs         []abiFragmentArg `json:"outputs,omitempty"`
+	StateMutability string           `json:"stateMutability,omitempty"`
+	Anonymous       bool             `json:"anonymous,omitempty"`
+}
+
+// abiIntAlias 匹配不带位数的 int/uint（Solidity 中等同于 int256/uint256）
+var abiIntAlias = regexp.MustCompile(`^(u?int)(\[|$)`)
+
+// ParseABIFragments 解析 ethers 风格的可读 ABI 片段，省去粘贴完整 ABI JSON 的步骤
+// 支持 function、event、error、constructor、fallback、receive 片段，参数可以带名称、indexed、数据位置（memory/calldata）和元组；
+// 省略关键字时按 function 处理
+// 参数说明：
+//   - fragments: ABI 片段（每个字符串一个片段，结尾的分号可选）
+//
+// 返回：
+//   - abi.ABI: 解析后的 ABI 对象
+//   - error: 如果片段格式无效则返回错误（可用 errors.Is(err, ErrInvalidABI) 判断）
+//
+// 示例：
+//   - ParseABIFragments("function transfer(address to, uint256 amount) returns (bool)")
+//   - ParseABIFragments("event Transfer(address indexed from, address indexed to, uint256 value)")
+//   - ParseABIFragments("function getReserves() view returns (uint112, uint112, uint32)", "error InsufficientBalance(uint256 available, uint256 required)")
+func ParseABIFragments(fragments ...string) (abi.ABI, error) {
+	abiJSON, err := ABIFragmentsToJSON(fragments...)
+	if err != nil {
+		return abi.ABI{}, err
+	}
+	parsed, err := getABICached(abiJSON)
+	if err != nil {
+		return abi.ABI{}, fmt.Errorf("%w: %w", ErrInvalidABI, err)
+	}
+	return parsed, nil
+}
+
+// ABIFragmentsToJSON 将可读 ABI 片段转换为标准 ABI JSON（可用于 PrepareContract 等接受 ABI JSON 的方法）
+// 参数说明：
+//   - fragments: ABI 片段
+//
+// 返回：
+//   - string: ABI JSON
+//   - error: 如果片段格式无效则返回错误
+func ABIFragmentsToJSON(fragments ...string) (string, error) {
+	entries := make([]abiFragmentEntry, 0, len(fragments))
+	for _, fragment := range fragments {
+		entry, err := parseABIFragment(fragment)
+		if err != nil {
+			return "", fmt.Errorf("%w: fragment %q: %w", ErrInvalidABI, fragment, err)
+		}
+		entries = append(entries, entry)
+	}
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// parseABIFragment 解析单个 ABI 片段
+func parseABIFragment(fragment string) (abiFragmentEntry, error) {
+	s := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(fragment), ";"))
+	entry := abiFragmentEntry{Type: "function"}
+	if keyword, rest, ok := strings.Cut(s, " "); ok {
+		switch keyword {
+		case "function", "event", "error":
+			entry.Type, s = keyword, strings.TrimSpace(rest)
+		}
+	}
+	for _, special := range []string{"constructor", "fallback", "receive"} {
+		if strings.HasPrefix(s, special+"(") || strings.HasPrefix(s, special+" (") {
+			entry.Type, s = special, strings.TrimSpace(strings.TrimPrefix(s, special))
+		}
+	}
+
+	open := strings.IndexByte(s, '(')
+	if open < 0 {
+		return entry, fmt.Errorf("missing parameter list")
+	}
+	entry.Name = strings.TrimSpace(s[:open])
+	if entry.Name == "" && (entry.Type == "function" || entry.Type == "event" || entry.Type == "error") {
+		return entry, fmt.Errorf("missing name")
+	}
+	inner, rest, err := matchParen(s[open:])
+	if err != nil {
+		return entry, err
+	}
+	if entry.Inputs, err = parseABIParams(inner); err != nil {
+		return entry, err
+	}
+
+	// 修饰符和 returns
+	for rest = strings.TrimSpace(rest); rest != ""; rest = strings.TrimSpace(rest) {
+		if strings.HasPrefix(rest, "returns") {
+			rest = strings.TrimSpace(strings.TrimPrefix(rest, "returns"))
+			var outputs string
+			if outputs, rest, err = matchParen(rest); err != nil {
+				return entry, fmt.Errorf("invalid returns: %w", err)
+			}
+			if entry.Outputs, err = parseABIParams(outputs); err != nil {
+				return entry, err
+			}
+			continue
+		}
+		word, remaining, _ := strings.Cut(rest, " ")
+		rest = remaining
+		switch word {
+		case "view", "pure", "payable", "nonpayable":
+			entry.StateMutability = word
+		case "constant":
+			entry.StateMutability = "view"
+		case "anonymous":
+			entry.Anonymous = true
+		case "external", "public", "virtual", "override":
+		default:
+			return entry, fmt.Errorf("unexpected modifier %q", word)
+		}
+	}
+	if entry.Type != "event" && entry.Type != "error" && entry.StateMutability == "" {
+		entry.StateMutability = "nonpayable"
+	}
+	if entry.Type == "receive" {
+		entry.StateMutability = "payable"
+	}
+	return entry, nil
+}
+
+// matchParen 返回以 "(" 开头的字符串中匹配括号内的内容和括号之后的部分
+func matchParen(s string) (inner, rest string, err error) {
+	if !strings.HasPrefix(s, "(") {
+		return "", "", fmt.Errorf("expected '(' in %q", s)
+	}
+	depth := 0
+	for i, c := range s {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return s[1:i], s[i+1:], nil
+			}
+		}
+	}
+	return "", "", fmt.Errorf("unbalanced parentheses in %q", s)
+}
+
+// parseABIParams 解析逗号分隔的参数列表
+func parseABIParams(s string) ([]abiFragmentArg, error) {
+	args := []abiFragmentArg{}
+	if strings.TrimSpace(s) == "" {
+		return args, nil
+	}
+	depth, start := 0, 0
+	for i := 0; i <= len(s); i++ {
+		if i < len(s) {
+			switch s[i] {
+			case '(':
+				depth++
+			case ')':
+				depth--
+			}
+			if s[i] != ',' || depth != 0 {
+				continue
+			}
+		}
+		arg, err := parseABIParam(strings.TrimSpace(s[start:i]))
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+		start = i + 1
+	}
+	return args, nil
+}
+
+// parseABIParam 解析单个参数，如 "address indexed from"、"(uint256 a, bool b)[] calldata items"
+func parseABIParam(s string) (abiFragmentArg, error) {
+	var arg abiFragmentArg
+	if s == "" {
+		return arg, fmt.Errorf("empty parameter")
+	}
+	var rest string
+	if strings.HasPrefix(s, "(") || strings.HasPrefix(s, "tuple(") {
+		inner, after, err := matchParen(strings.TrimPrefix(s, "tuple"))
+		if err != nil {
+			return arg, err
+		}
+		if arg.Components, err = parseABIParams(inner); err != nil {
+			return arg, err
+		}
+		// 元组的字段需要名称才能映射为 Go 结构体
+		for i := range arg.Components {
+			if arg.Components[i].Name == "" {
+				arg.Components[i].Name = fmt.Sprintf("field%d", i)
+			}
+		}
+		suffix, remaining, _ := strings.Cut(after, " ")
+		arg.Type, rest = "tuple"+suffix, remaining
+	} else {
+		var typ string
+		typ, rest, _ = strings.Cut(s, " ")
+		arg.Type = abiIntAlias.ReplaceAllString(typ, "${1}256${2}")
+	}
+
+	for _, word := range strings.Fields(rest) {
+		switch word {
+		case "indexed":
+			arg.Indexed = true
+		case "memory", "calldata", "storage", "payable":
+		default:
+			if arg.Name != "" {
+				return arg, fmt.Errorf("unexpected token %q in parameter %q", word, s)
+			}
+			arg.Name = word
+		}
+	}
+	return arg, nil
+}
@@ -0,0 +1,80 @@
+package etherkit
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestParseABIFragments(t *testing.T) {
+	parsed, err := ParseABIFragments(
+		"function transfer(address to, uint256 amount) returns (bool)",
+		"function balanceOf(address) view returns (uint)",
+		"event Transfer(address indexed from, address indexed to, uint256 value)",
+		"event Approval(address indexed,address indexed,uint256)",
+		"error InsufficientBalance(uint256 available, uint256 required);",
+		"function submit((address target, bytes data)[] calldata calls, uint256 deadline) payable",
+		"constructor(address owner)",
+		"receive() external payable",
+	)
+	if err != nil {
+		t.Fatalf("ParseABIFragments() failed: %v", err)
+	}
+
+	// 选择器和 topic 与完整 ABI 一致
+	if got := parsed.Methods["transfer"].ID; common.Bytes2Hex(got) != "a9059cbb" {
+		t.Errorf("transfer selector = %x, expected a9059cbb", got)
+	}
+	if got := parsed.Methods["balanceOf"]; got.Sig != "balanceOf(address)" || !got.IsConstant() || got.Outputs[0].Type.String() != "uint256" {
+		t.Errorf("balanceOf = %s (constant %v)", got.Sig, got.IsConstant())
+	}
+	if got := parsed.Events["Transfer"].ID; got != erc20ABI.Events["Transfer"].ID {
+		t.Errorf("Transfer topic = %s", got.Hex())
+	}
+	if got := parsed.Events["Approval"]; got.ID != erc20ABI.Events["Approval"].ID || !got.Inputs[0].Indexed || got.Inputs[2].Indexed {
+		t.Errorf("Approval = %+v", got)
+	}
+	if got := parsed.Errors["InsufficientBalance"].Sig; got != "InsufficientBalance(uint256,uint256)" {
+		t.Errorf("error sig = %s", got)
+	}
+	if got := parsed.Methods["submit"]; got.Sig != "submit((address,bytes)[],uint256)" || !got.IsPayable() {
+		t.Errorf("submit sig = %s (payable %v)", got.Sig, got.IsPayable())
+	}
+	if len(parsed.Constructor.Inputs) != 1 || !parsed.HasReceive() {
+		t.Errorf("constructor/receive not parsed: %+v", parsed.Constructor)
+	}
+
+	// 解析结果可以直接打包调用数据
+	data, err := parsed.Pack("transfer", common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8"), big.NewInt(1))
+	if err != nil {
+		t.Fatalf("Pack() failed: %v", err)
+	}
+	want, _ := erc20ABI.Pack("transfer", common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8"), big.NewInt(1))
+	if common.Bytes2Hex(data) != common.Bytes2Hex(want) {
+		t.Errorf("Pack() = %x, expected %x", data, want)
+	}
+}
+
+func TestParseABIFragmentsErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		fragment string
+	}{
+		{"missing params", "function transfer"},
+		{"missing name", "function (address)"},
+		{"unbalanced", "function f((address,uint256)"},
+		{"unknown modifier", "function f() cheap"},
+		{"unknown type", "function f(foo a)"},
+		{"extra token", "function f(address a b)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseABIFragments(tt.fragment); !errors.Is(err, ErrInvalidABI) {
+				t.Errorf("ParseABIFragments(%q) error = %v, want ErrInvalidABI", tt.fragment, err)
+			}
+		})
+	}
+}
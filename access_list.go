@@ -0,0 +1,191 @@
+package etherkit
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+//############ Access List Optimization ############
+
+// CreateAccessList 通过 eth_createAccessList 生成交易会访问的地址和存储槽位列表（EIP-2930）
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - msg: 调用参数（From、To、Value、Data 等）
+//   - block: 基准区块（零值表示最新区块）
+//
+// 返回：
+//   - types.AccessList: 访问列表（节点会排除发送地址、接收地址和预编译合约中不需要存储槽位的条目）
+//   - uint64: 附加访问列表后执行消耗的 gas
+//   - error: 如果节点不支持、执行失败（*RevertError）或查询失败则返回错误
+func CreateAccessList(ctx context.Context, ep EtherProvider, msg ethereum.CallMsg, block BlockRef) (types.AccessList, uint64, error) {
+	var result struct {
+		AccessList types.AccessList `json:"accessList"`
+		GasUsed    hexutil.Uint64   `json:"gasUsed"`
+		Error      string           `json:"error"`
+	}
+	if err := ep.GetRpcClient().CallContext(ctx, &result, "eth_createAccessList", toCallArg(msg), block.rpcArg()); err != nil {
+		return nil, 0, NormalizeError(err)
+	}
+	if result.Error != "" {
+		return nil, 0, fmt.Errorf("%w: %s", ErrTransactionFailed, result.Error)
+	}
+	return result.AccessList, uint64(result.GasUsed), nil
+}
+
+// AccessListResult 访问列表优化的结果
+type AccessListResult struct {
+	AccessList types.AccessList // eth_createAccessList 生成的访问列表
+	GasWithout uint64           // 不附加访问列表时估算的 gas
+	GasWith    uint64           // 附加访问列表后估算的 gas
+}
+
+// Saves 判断附加访问列表是否能节省 gas
+// 访问列表中每个地址需要预付 2400 gas、每个槽位 1900 gas，只有访问的冷存储足够多时才划算
+func (r *AccessListResult) Saves() bool {
+	return len(r.AccessList) > 0 && r.GasWith < r.GasWithout
+}
+
+// Saved 返回附加访问列表节省的 gas（不节省时为 0）
+func (r *AccessListResult) Saved() uint64 {
+	if !r.Saves() {
+		return 0
+	}
+	return r.GasWithout - r.GasWith
+}
+
+// OptimizeAccessList 生成访问列表，并分别估算附加和不附加时的 gas，用于判断是否值得附加
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - msg: 调用参数（其中的 AccessList 会被忽略）
+//
+// 返回：
+//   - *AccessListResult: 访问列表和两种情况下的 gas 估算（通过 Saves 判断是否附加）
+//   - error: 如果生成访问列表或估算 gas 失败则返回错误
+//
+// 使用示例：
+//
+//	result, err := OptimizeAccessList(ctx, provider, ethereum.CallMsg{From: from, To: &router, Data: data})
+//	if result.Saves() {
+//	    fmt.Printf("access list saves %d gas\n", result.Saved())
+//	}
+func OptimizeAccessList(ctx context.Context, ep EtherProvider, msg ethereum.CallMsg) (*AccessListResult, error) {
+	msg.AccessList = nil
+	gasWithout, err := providerExt[StateOverrideCaller](ep).EstimateGasWithOverrides(ctx, msg, BlockRef{}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate gas without access list: %w", err)
+	}
+	list, _, err := CreateAccessList(ctx, ep, msg, BlockRef{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create access list: %w", err)
+	}
+	result := &AccessListResult{AccessList: list, GasWithout: gasWithout, GasWith: gasWithout}
+	if len(list) == 0 {
+		return result, nil
+	}
+	msg.AccessList = list
+	if result.GasWith, err = providerExt[StateOverrideCaller](ep).EstimateGasWithOverrides(ctx, msg, BlockRef{}, nil); err != nil {
+		return nil, fmt.Errorf("failed to estimate gas with access list: %w", err)
+	}
+	return result, nil
+}
+
+// OptimizeAccessList 以 Kit 地址为发送方，判断调用附加访问列表是否能节省 gas
+// 参数说明：
+//   - ctx: 上下文对象
+//   - to: 接收地址
+//   - value: 转账金额（nil 表示不转账）
+//   - data: 调用数据
+//
+// 返回：
+//   - *AccessListResult: 访问列表和两种情况下的 gas 估算
+//   - error: 如果生成访问列表或估算 gas 失败则返回错误
+func (k *Kit) OptimizeAccessList(ctx context.Context, to common.Address, value *big.Int, data []byte) (*AccessListResult, error) {
+	return OptimizeAccessList(ctx, k.EtherProvider, ethereum.CallMsg{From: k.GetAddress(), To: &to, Value: value, Data: data})
+}
+
+// WithAccessListOptimization 启用访问列表优化
+// SendTx、InvokeContract、PrepareTx 等构建合约调用交易时先执行 OptimizeAccessList，只在能节省 gas 时附加访问列表
+// （legacy 交易转换为 EIP-2930 交易，动态费用交易直接附加）；自动估算 gas 时使用附加后的估算值
+// 优化失败（如节点不支持 eth_createAccessList）不影响发送，按原交易继续；DetectCapabilities 探测到节点不支持时直接跳过
+func WithAccessListOptimization() KitOption {
+	return func(k *Kit) {
+		k.accessListOpt = true
+	}
+}
+
+// buildTx Kit 构建交易的统一流程：NewTx 构建后按配置附加访问列表
+func (k *Kit) buildTx(ctx context.Context, to common.Address, nonce, gasLimit uint64, gasPrice, value *big.Int, data []byte) (*types.Transaction, error) {
+	tx, err := k.NewTx(ctx, to, nonce, gasLimit, gasPrice, value, data)
+	if err != nil || !k.accessListOpt || len(data) == 0 {
+		return tx, err
+	}
+	if kind := k.GetSignerKind(); kind == SignerHomestead || kind == SignerEIP155 {
+		// 链只支持 legacy 交易
+		return tx, nil
+	}
+	if k.capabilityKnownMissing(CapabilityCreateAccessList) {
+		return tx, nil
+	}
+	result, err := OptimizeAccessList(ctx, k.EtherProvider, ethereum.CallMsg{From: k.GetAddress(), To: &to, Value: value, Data: data})
+	if err != nil {
+		k.Logger().WarnContext(ctx, "access list optimization failed", "to", to.Hex(), "error", err)
+		return tx, nil
+	}
+	if !result.Saves() {
+		return tx, nil
+	}
+	if gasLimit == 0 {
+		gasLimit = result.GasWith
+	} else {
+		gasLimit = tx.Gas()
+	}
+	optimized, err := withAccessList(ctx, k.EtherProvider, tx, result.AccessList, gasLimit)
+	if err != nil {
+		return nil, err
+	}
+	k.Logger().DebugContext(ctx, "access list attached", "to", to.Hex(), "entries", len(result.AccessList), "gasSaved", result.Saved())
+	return optimized, nil
+}
+
+// withAccessList 返回附加了访问列表的交易副本（legacy 交易转换为 EIP-2930 交易）
+func withAccessList(ctx context.Context, ep EtherProvider, tx *types.Transaction, list types.AccessList, gasLimit uint64) (*types.Transaction, error) {
+	switch tx.Type() {
+	case types.LegacyTxType, types.AccessListTxType:
+		chainId, err := ep.GetChainID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return types.NewTx(&types.AccessListTx{
+			ChainID:    chainId,
+			Nonce:      tx.Nonce(),
+			GasPrice:   tx.GasPrice(),
+			Gas:        gasLimit,
+			To:         tx.To(),
+			Value:      tx.Value(),
+			Data:       tx.Data(),
+			AccessList: list,
+		}), nil
+	case types.DynamicFeeTxType:
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:    tx.ChainId(),
+			Nonce:      tx.Nonce(),
+			GasTipCap:  tx.GasTipCap(),
+			GasFeeCap:  tx.GasFeeCap(),
+			Gas:        gasLimit,
+			To:         tx.To(),
+			Value:      tx.Value(),
+			Data:       tx.Data(),
+			AccessList: list,
+		}), nil
+	default:
+		return nil, fmt.Errorf("cannot attach access list to transaction type %d", tx.Type())
+	}
+}
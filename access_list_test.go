@@ -0,0 +1,133 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// newMockAccessListServer 模拟支持 eth_createAccessList 的节点
+// 不带访问列表时估算 65000 gas，带访问列表时估算 gasWith；list 为 nil 表示节点不支持 eth_createAccessList
+func newMockAccessListServer(t *testing.T, list types.AccessList, gasWith uint64, sent **types.Transaction) *mockRPCServer {
+	t.Helper()
+	server := newMockSendServer(t)
+	server.handlers["eth_estimateGas"] = func(params []json.RawMessage) (interface{}, error) {
+		var arg struct {
+			AccessList *types.AccessList `json:"accessList"`
+		}
+		if err := json.Unmarshal(params[0], &arg); err != nil {
+			return nil, err
+		}
+		if arg.AccessList != nil {
+			return hexutil.Uint64(gasWith), nil
+		}
+		return hexutil.Uint64(65000), nil
+	}
+	if list != nil {
+		server.handlers["eth_createAccessList"] = func(params []json.RawMessage) (interface{}, error) {
+			return map[string]interface{}{"accessList": list, "gasUsed": hexutil.Uint64(gasWith)}, nil
+		}
+	}
+	server.handlers["eth_sendRawTransaction"] = func(params []json.RawMessage) (interface{}, error) {
+		var raw hexutil.Bytes
+		if err := json.Unmarshal(params[0], &raw); err != nil {
+			return nil, err
+		}
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(raw); err != nil {
+			return nil, err
+		}
+		*sent = tx
+		return tx.Hash(), nil
+	}
+	return server
+}
+
+func TestOptimizeAccessList(t *testing.T) {
+	pool := common.HexToAddress("0x00000000000000000000000000000000000000a1")
+	list := types.AccessList{{Address: pool, StorageKeys: []common.Hash{common.HexToHash("0x01"), common.HexToHash("0x02")}}}
+
+	var sent *types.Transaction
+	kit := newMockKit(t, newMockAccessListServer(t, list, 60000, &sent))
+	result, err := kit.OptimizeAccessList(context.Background(), pool, nil, []byte{0x01, 0x02, 0x03, 0x04})
+	if err != nil {
+		t.Fatalf("OptimizeAccessList() failed: %v", err)
+	}
+	if !result.Saves() || result.Saved() != 5000 || result.GasWithout != 65000 || result.GasWith != 60000 || len(result.AccessList) != 1 {
+		t.Errorf("OptimizeAccessList() = %+v", result)
+	}
+
+	kit = newMockKit(t, newMockAccessListServer(t, types.AccessList{}, 0, &sent))
+	if result, err = kit.OptimizeAccessList(context.Background(), pool, nil, []byte{0x01}); err != nil || result.Saves() || result.Saved() != 0 {
+		t.Errorf("OptimizeAccessList() with empty list = %+v, %v", result, err)
+	}
+
+	kit = newMockKit(t, newMockAccessListServer(t, nil, 0, &sent))
+	if _, err := kit.OptimizeAccessList(context.Background(), pool, nil, []byte{0x01}); err == nil {
+		t.Error("OptimizeAccessList() on node without eth_createAccessList expected error")
+	}
+}
+
+func TestAccessListOptimization(t *testing.T) {
+	pool := common.HexToAddress("0x00000000000000000000000000000000000000a1")
+	list := types.AccessList{{Address: pool, StorageKeys: []common.Hash{common.HexToHash("0x01")}}}
+	data := []byte{0xa9, 0x05, 0x9c, 0xbb}
+
+	tests := []struct {
+		name     string
+		list     types.AccessList
+		gasWith  uint64
+		gasLimit uint64
+		data     []byte
+		wantType uint8
+		wantGas  uint64
+	}{
+		{name: "saves gas", list: list, gasWith: 60000, data: data, wantType: types.AccessListTxType, wantGas: 60000},
+		{name: "explicit gas limit kept", list: list, gasWith: 60000, gasLimit: 100000, data: data, wantType: types.AccessListTxType, wantGas: 100000},
+		{name: "costs more gas", list: list, gasWith: 70000, data: data, wantType: types.LegacyTxType, wantGas: 65000},
+		{name: "node unsupported", list: nil, data: data, wantType: types.LegacyTxType, wantGas: 65000},
+		{name: "plain transfer", list: list, gasWith: 60000, data: nil, wantType: types.LegacyTxType, wantGas: 65000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent *types.Transaction
+			server := newMockAccessListServer(t, tt.list, tt.gasWith, &sent)
+			kit := newMockKit(t, server, WithAccessListOptimization())
+			if _, err := kit.SendTx(context.Background(), pool, 0, tt.gasLimit, nil, big.NewInt(0), tt.data); err != nil {
+				t.Fatalf("SendTx() failed: %v", err)
+			}
+			if sent == nil {
+				t.Fatal("no transaction sent")
+			}
+			if sent.Type() != tt.wantType || sent.Gas() != tt.wantGas {
+				t.Errorf("sent tx type %d gas %d, expected type %d gas %d", sent.Type(), sent.Gas(), tt.wantType, tt.wantGas)
+			}
+			if tt.wantType == types.AccessListTxType {
+				if len(sent.AccessList()) != 1 || sent.AccessList()[0].Address != pool || sent.ChainId().Int64() != 1 || sent.Nonce() != 5 {
+					t.Errorf("sent tx access list = %+v", sent.AccessList())
+				}
+				if from, err := txSender(sent); err != nil || from != kit.GetAddress() {
+					t.Errorf("sent tx sender = %s, %v", from.Hex(), err)
+				}
+			}
+		})
+	}
+
+	t.Run("reverting call", func(t *testing.T) {
+		var sent *types.Transaction
+		server := newMockAccessListServer(t, list, 60000, &sent)
+		server.handlers["eth_createAccessList"] = func(params []json.RawMessage) (interface{}, error) {
+			return map[string]interface{}{"accessList": types.AccessList{}, "gasUsed": "0x0", "error": "execution reverted"}, nil
+		}
+		if _, _, err := CreateAccessList(context.Background(), newMockKit(t, server).EtherProvider, ethereum.CallMsg{To: &pool, Data: data}, BlockRef{}); !errors.Is(err, ErrTransactionFailed) {
+			t.Errorf("CreateAccessList() error = %v, want ErrTransactionFailed", err)
+		}
+	})
+}
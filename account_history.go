@@ -0,0 +1,317 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//############ Account History ############
+
+// ErrNoExplorerAPI 未配置区块浏览器 API
+var ErrNoExplorerAPI = errors.New("no explorer API configured")
+
+// DefaultEtherscanAPIURL Etherscan V2 多链 API 地址（通过 chainid 参数选择链）
+const DefaultEtherscanAPIURL = "https://api.etherscan.io/v2/api"
+
+// DefaultHistoryPageSize 每页默认返回的交易数量
+const DefaultHistoryPageSize = 100
+
+// HistoryKind 账户历史记录类型
+type HistoryKind string
+
+const (
+	HistoryNormal         HistoryKind = "txlist"         // 普通交易（账户发出或接收的外部交易）
+	HistoryInternal       HistoryKind = "txlistinternal" // 内部交易（合约调用中产生的转账）
+	HistoryTokenTransfers HistoryKind = "tokentx"        // ERC20 代币转账
+)
+
+// HistoryOptions 查询账户历史记录的选项
+type HistoryOptions struct {
+	Kind       HistoryKind     // 记录类型（空表示 HistoryNormal）
+	StartBlock uint64          // 起始区块（0 表示从创世区块开始）
+	EndBlock   uint64          // 结束区块（0 表示到最新区块）
+	Page       int             // 页码（从 1 开始，<= 0 表示第 1 页）
+	PageSize   int             // 每页数量（<= 0 表示 DefaultHistoryPageSize）
+	Descending bool            // 是否按区块倒序返回（默认正序）
+	Token      *common.Address // 只返回指定代币的转账（仅 HistoryTokenTransfers 有效）
+}
+
+// HistoryTx 账户历史记录中的一条交易
+type HistoryTx struct {
+	Kind            HistoryKind    // 记录类型
+	Hash            common.Hash    // 交易哈希（内部交易为所属外部交易的哈希）
+	BlockNumber     uint64         // 区块号
+	Timestamp       time.Time      // 区块时间
+	From            common.Address // 发送地址
+	To              common.Address // 接收地址（合约创建时为空）
+	ContractAddress common.Address // 创建的合约地址；代币转账时为代币合约地址
+	Value           *big.Int       // 转账金额（最小单位）
+	Nonce           uint64         // 交易 nonce（内部交易为 0）
+	GasPrice        *big.Int       // gas 价格（内部交易为 nil）
+	GasUsed         uint64         // 实际消耗的 gas
+	Failed          bool           // 交易是否执行失败
+	TokenSymbol     string         // 代币符号（仅代币转账）
+	TokenDecimals   int            // 代币精度（仅代币转账）
+	TraceID         string         // 内部交易的调用路径（仅内部交易）
+}
+
+// ExplorerAPI Etherscan 兼容的区块浏览器 API 客户端
+// 节点无法回答"某地址的全部交易"，需要借助区块浏览器的账户索引；Blockscout 等兼容 Etherscan 接口的服务也可以使用
+type ExplorerAPI struct {
+	BaseURL    string       // API 地址（如 DefaultEtherscanAPIURL）
+	APIKey     string       // API Key
+	ChainID    int64        // 链 ID（Etherscan V2 必填，为 0 时不传 chainid 参数）
+	HTTPClient *http.Client // HTTP 客户端（nil 表示使用 http.DefaultClient）
+}
+
+// NewExplorerAPI 创建基于 Etherscan V2 多链 API 的客户端
+// 参数说明：
+//   - chainID: 链 ID（如 MainnetChainID）
+//   - apiKey: Etherscan API Key
+func NewExplorerAPI(chainID int64, apiKey string) *ExplorerAPI {
+	return &ExplorerAPI{BaseURL: DefaultEtherscanAPIURL, APIKey: apiKey, ChainID: chainID, HTTPClient: http.DefaultClient}
+}
+
+// explorerResponse Etherscan 接口的通用响应格式
+type explorerResponse struct {
+	Status  string          `json:"status"`
+	Message string          `json:"message"`
+	Result  json.RawMessage `json:"result"`
+}
+
+// explorerTx Etherscan 账户接口返回的交易（数值均为十进制字符串）
+type explorerTx struct {
+	BlockNumber     string `json:"blockNumber"`
+	TimeStamp       string `json:"timeStamp"`
+	Hash            string `json:"hash"`
+	Nonce           string `json:"nonce"`
+	From            string `json:"from"`
+	To              string `json:"to"`
+	ContractAddress string `json:"contractAddress"`
+	Value           string `json:"value"`
+	GasPrice        string `json:"gasPrice"`
+	GasUsed         string `json:"gasUsed"`
+	IsError         string `json:"isError"`
+	TokenSymbol     string `json:"tokenSymbol"`
+	TokenDecimal    string `json:"tokenDecimal"`
+	TraceID         string `json:"traceId"`
+}
+
+// GetTransactionHistory 查询地址的交易历史（单页）
+// 返回数量少于 PageSize 时表示已是最后一页；Etherscan 限制 Page × PageSize 不超过 10000，更早的记录需要通过 StartBlock/EndBlock 分段查询
+// 参数说明：
+//   - ctx: 上下文对象
+//   - address: 账户地址
+//   - opts: 查询选项
+//
+// 返回：
+//   - []HistoryTx: 交易列表（没有记录时为空）
+//   - error: 如果请求失败或 API 返回错误（如限流、API Key 无效）则返回错误
+//
+// 使用示例：
+//
+//	api := etherkit.NewExplorerAPI(etherkit.MainnetChainID, apiKey)
+//	for page := 1; ; page++ {
+//	    txs, err := api.GetTransactionHistory(ctx, addr, etherkit.HistoryOptions{Kind: etherkit.HistoryTokenTransfers, Page: page})
+//	    if err != nil || len(txs) < etherkit.DefaultHistoryPageSize {
+//	        break
+//	    }
+//	}
+func (e *ExplorerAPI) GetTransactionHistory(ctx context.Context, address common.Address, opts HistoryOptions) ([]HistoryTx, error) {
+	kind := opts.Kind
+	if kind == "" {
+		kind = HistoryNormal
+	}
+	page, pageSize := max(opts.Page, 1), opts.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultHistoryPageSize
+	}
+	sortOrder := "asc"
+	if opts.Descending {
+		sortOrder = "desc"
+	}
+
+	params := url.Values{}
+	if e.ChainID != 0 {
+		params.Set("chainid", strconv.FormatInt(e.ChainID, 10))
+	}
+	params.Set("module", "account")
+	params.Set("action", string(kind))
+	params.Set("address", address.Hex())
+	params.Set("startblock", strconv.FormatUint(opts.StartBlock, 10))
+	if opts.EndBlock > 0 {
+		params.Set("endblock", strconv.FormatUint(opts.EndBlock, 10))
+	}
+	params.Set("page", strconv.Itoa(page))
+	params.Set("offset", strconv.Itoa(pageSize))
+	params.Set("sort", sortOrder)
+	if kind == HistoryTokenTransfers && opts.Token != nil {
+		params.Set("contractaddress", opts.Token.Hex())
+	}
+	if e.APIKey != "" {
+		params.Set("apikey", e.APIKey)
+	}
+
+	var raw []explorerTx
+	if err := e.get(ctx, params, &raw); err != nil {
+		return nil, err
+	}
+	txs := make([]HistoryTx, 0, len(raw))
+	for _, r := range raw {
+		tx, err := r.toHistoryTx(kind)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, tx)
+	}
+	return txs, nil
+}
+
+// get 请求区块浏览器 API 并解析 result 字段
+func (e *ExplorerAPI) get(ctx context.Context, params url.Values, result interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, e.BaseURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	client := e.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to request explorer API: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("explorer API returned status %d", resp.StatusCode)
+	}
+
+	var res explorerResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return fmt.Errorf("failed to decode explorer API response: %w", err)
+	}
+	if res.Status != "1" {
+		// 没有记录时 status 为 0，result 为空数组
+		if strings.HasPrefix(res.Message, "No transactions found") || strings.HasPrefix(string(res.Result), "[") {
+			return nil
+		}
+		var detail string
+		_ = json.Unmarshal(res.Result, &detail)
+		return fmt.Errorf("explorer API error: %s: %s", res.Message, detail)
+	}
+	if err := json.Unmarshal(res.Result, result); err != nil {
+		return fmt.Errorf("failed to decode explorer API result: %w", err)
+	}
+	return nil
+}
+
+// toHistoryTx 转换区块浏览器返回的交易
+func (r explorerTx) toHistoryTx(kind HistoryKind) (HistoryTx, error) {
+	tx := HistoryTx{
+		Kind:            kind,
+		Hash:            common.HexToHash(r.Hash),
+		From:            common.HexToAddress(r.From),
+		To:              common.HexToAddress(r.To),
+		ContractAddress: common.HexToAddress(r.ContractAddress),
+		Failed:          r.IsError == "1",
+		TokenSymbol:     r.TokenSymbol,
+		TraceID:         r.TraceID,
+	}
+	var err error
+	if tx.BlockNumber, err = parseExplorerUint(r.BlockNumber); err != nil {
+		return HistoryTx{}, err
+	}
+	timestamp, err := parseExplorerUint(r.TimeStamp)
+	if err != nil {
+		return HistoryTx{}, err
+	}
+	tx.Timestamp = time.Unix(int64(timestamp), 0)
+	if tx.Nonce, err = parseExplorerUint(r.Nonce); err != nil {
+		return HistoryTx{}, err
+	}
+	if tx.GasUsed, err = parseExplorerUint(r.GasUsed); err != nil {
+		return HistoryTx{}, err
+	}
+	if tx.Value, err = parseExplorerBig(r.Value); err != nil {
+		return HistoryTx{}, err
+	}
+	if r.GasPrice != "" {
+		if tx.GasPrice, err = parseExplorerBig(r.GasPrice); err != nil {
+			return HistoryTx{}, err
+		}
+	}
+	if r.TokenDecimal != "" {
+		if tx.TokenDecimals, err = strconv.Atoi(r.TokenDecimal); err != nil {
+			return HistoryTx{}, fmt.Errorf("invalid token decimals %q: %w", r.TokenDecimal, err)
+		}
+	}
+	return tx, nil
+}
+
+// parseExplorerUint 解析十进制数值字符串（空字符串视为 0）
+func parseExplorerUint(s string) (uint64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid explorer number %q: %w", s, err)
+	}
+	return n, nil
+}
+
+// parseExplorerBig 解析十进制大数字符串（空字符串视为 0）
+func parseExplorerBig(s string) (*big.Int, error) {
+	if s == "" {
+		return new(big.Int), nil
+	}
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid explorer number %q", s)
+	}
+	return n, nil
+}
+
+// WithExplorerAPI 设置 Kit 使用的区块浏览器 API（用于 GetTransactionHistory）
+func WithExplorerAPI(api *ExplorerAPI) KitOption {
+	return func(k *Kit) {
+		k.explorerAPI = api
+	}
+}
+
+// GetTransactionHistory 通过配置的区块浏览器 API 查询地址的交易历史
+// ExplorerAPI 未设置 ChainID 时使用当前连接的链 ID
+// 参数说明：
+//   - ctx: 上下文对象
+//   - address: 账户地址
+//   - opts: 查询选项
+//
+// 返回：
+//   - []HistoryTx: 交易列表
+//   - error: 未配置区块浏览器 API 时返回 ErrNoExplorerAPI，查询失败时返回对应错误
+func (k *Kit) GetTransactionHistory(ctx context.Context, address common.Address, opts HistoryOptions) ([]HistoryTx, error) {
+	if k.explorerAPI == nil {
+		return nil, ErrNoExplorerAPI
+	}
+	api := k.explorerAPI
+	if api.ChainID == 0 {
+		chainId, err := k.GetChainID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		withChain := *api
+		withChain.ChainID = chainId.Int64()
+		api = &withChain
+	}
+	return api.GetTransactionHistory(ctx, address, opts)
+}
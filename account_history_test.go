@@ -0,0 +1,135 @@
+package etherkit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// newMockExplorerAPI 按 action 返回固定响应，并记录最近一次请求的参数
+func newMockExplorerAPI(t *testing.T, responses map[string]string) (*httptest.Server, *url.Values) {
+	t.Helper()
+	var last url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		last = r.URL.Query()
+		body, ok := responses[last.Get("action")]
+		if !ok {
+			http.Error(w, "unknown action", http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server, &last
+}
+
+func TestGetTransactionHistory(t *testing.T) {
+	address := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	token := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	server, last := newMockExplorerAPI(t, map[string]string{
+		"txlist":         `{"status":"1","message":"OK","result":[{"blockNumber":"100","timeStamp":"1700000000","hash":"0x01","nonce":"7","from":"0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266","to":"0x70997970c51812dc3a010c7d01b50e0d17dc79c8","value":"1000000000000000000","gasPrice":"2000000000","gasUsed":"21000","isError":"0","contractAddress":""}]}`,
+		"txlistinternal": `{"status":"1","message":"OK","result":[{"blockNumber":"101","timeStamp":"1700000012","hash":"0x02","from":"0x70997970c51812dc3a010c7d01b50e0d17dc79c8","to":"0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266","value":"5","gasUsed":"0","isError":"1","traceId":"0_1"}]}`,
+		"tokentx":        `{"status":"1","message":"OK","result":[{"blockNumber":"102","timeStamp":"1700000024","hash":"0x03","nonce":"8","from":"0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266","to":"0x70997970c51812dc3a010c7d01b50e0d17dc79c8","contractAddress":"0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48","value":"1500000","tokenSymbol":"USDC","tokenDecimal":"6","gasPrice":"1","gasUsed":"50000"}]}`,
+	})
+	api := &ExplorerAPI{BaseURL: server.URL, APIKey: "key", ChainID: 137}
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		opts  HistoryOptions
+		check func(t *testing.T, tx HistoryTx)
+	}{
+		{
+			name: "normal",
+			opts: HistoryOptions{},
+			check: func(t *testing.T, tx HistoryTx) {
+				if tx.Kind != HistoryNormal || tx.BlockNumber != 100 || tx.Nonce != 7 || tx.Value.String() != "1000000000000000000" || tx.GasPrice.Int64() != 2e9 || tx.Failed {
+					t.Errorf("tx = %+v", tx)
+				}
+				if tx.Timestamp.Unix() != 1700000000 || tx.From != address {
+					t.Errorf("tx = %+v", tx)
+				}
+			},
+		},
+		{
+			name: "internal",
+			opts: HistoryOptions{Kind: HistoryInternal},
+			check: func(t *testing.T, tx HistoryTx) {
+				if tx.Value.Int64() != 5 || !tx.Failed || tx.TraceID != "0_1" || tx.GasPrice != nil {
+					t.Errorf("tx = %+v", tx)
+				}
+			},
+		},
+		{
+			name: "token transfers",
+			opts: HistoryOptions{Kind: HistoryTokenTransfers, Token: &token, Page: 2, PageSize: 50, Descending: true},
+			check: func(t *testing.T, tx HistoryTx) {
+				if tx.ContractAddress != token || tx.TokenSymbol != "USDC" || tx.TokenDecimals != 6 || tx.Value.Int64() != 1500000 {
+					t.Errorf("tx = %+v", tx)
+				}
+				if last.Get("contractaddress") != token.Hex() || last.Get("page") != "2" || last.Get("offset") != "50" || last.Get("sort") != "desc" {
+					t.Errorf("query = %v", *last)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txs, err := api.GetTransactionHistory(ctx, address, tt.opts)
+			if err != nil {
+				t.Fatalf("GetTransactionHistory() failed: %v", err)
+			}
+			if len(txs) != 1 {
+				t.Fatalf("len(txs) = %d, expected 1", len(txs))
+			}
+			if last.Get("chainid") != "137" || last.Get("apikey") != "key" || last.Get("address") != address.Hex() {
+				t.Errorf("query = %v", *last)
+			}
+			tt.check(t, txs[0])
+		})
+	}
+}
+
+func TestGetTransactionHistoryErrors(t *testing.T) {
+	address := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	server, last := newMockExplorerAPI(t, map[string]string{
+		"txlist":         `{"status":"0","message":"No transactions found","result":[]}`,
+		"txlistinternal": `{"status":"0","message":"NOTOK","result":"Max rate limit reached"}`,
+	})
+	api := &ExplorerAPI{BaseURL: server.URL}
+	ctx := context.Background()
+
+	// 没有记录时返回空列表
+	txs, err := api.GetTransactionHistory(ctx, address, HistoryOptions{})
+	if err != nil || len(txs) != 0 {
+		t.Errorf("GetTransactionHistory() = %v, %v; expected empty result", txs, err)
+	}
+	if _, err := api.GetTransactionHistory(ctx, address, HistoryOptions{Kind: HistoryInternal}); err == nil {
+		t.Error("Expected error for rate limited response")
+	}
+	if _, err := api.GetTransactionHistory(ctx, address, HistoryOptions{Kind: HistoryTokenTransfers}); err == nil {
+		t.Error("Expected error for HTTP failure")
+	}
+
+	// Kit 未配置区块浏览器 API
+	rpc := newMockSendServer(t)
+	kit := newMockKit(t, rpc)
+	if _, err := kit.GetTransactionHistory(ctx, address, HistoryOptions{}); !errors.Is(err, ErrNoExplorerAPI) {
+		t.Errorf("GetTransactionHistory() error = %v, want ErrNoExplorerAPI", err)
+	}
+
+	// 未设置 ChainID 时使用当前链 ID
+	kit = newMockKit(t, rpc, WithExplorerAPI(api))
+	if _, err := kit.GetTransactionHistory(ctx, address, HistoryOptions{}); err != nil {
+		t.Fatalf("GetTransactionHistory() failed: %v", err)
+	}
+	if last.Get("chainid") != "1" {
+		t.Errorf("chainid = %q, expected 1", last.Get("chainid"))
+	}
+}
@@ -2,6 +2,7 @@ package etherkit
 
 import (
 	"encoding/hex"
+	"fmt"
 	"regexp"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -33,6 +34,24 @@ func IsValidAddress(iAddress interface{}) bool {
 	}
 }
 
+// ParseAddress 解析十六进制地址字符串
+// 参数说明：
+//   - s: 地址字符串（必须以 0x 开头，后跟 40 个十六进制字符）
+//
+// 返回：
+//   - common.Address: 解析出的地址
+//   - error: 格式无效时返回 ErrInvalidAddress，零地址时返回 ErrZeroAddress
+func ParseAddress(s string) (common.Address, error) {
+	if !IsValidAddress(s) {
+		return common.Address{}, fmt.Errorf("%w: %q", ErrInvalidAddress, s)
+	}
+	address := common.HexToAddress(s)
+	if address == (common.Address{}) {
+		return common.Address{}, ErrZeroAddress
+	}
+	return address, nil
+}
+
 // PublicKeyBytesToAddress 从公钥字节转换为以太坊地址
 // 以太坊地址是从公钥派生出来的：对公钥进行 Keccak256 哈希，然后取后 20 字节
 // 参数说明：
@@ -0,0 +1,126 @@
+package etherkit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+//############ Address Scan ############
+
+// AddressTx 区块扫描中找到的与地址相关的交易
+type AddressTx struct {
+	Tx          *types.Transaction // 交易
+	BlockNumber uint64             // 所在区块号
+	BlockHash   common.Hash        // 所在区块哈希
+	Timestamp   time.Time          // 区块时间
+	Index       uint               // 交易在区块中的索引
+	From        common.Address     // 发送地址
+	Outgoing    bool               // 是否由该地址发出
+	Incoming    bool               // 是否发送给该地址（包括创建该地址的合约创建交易）
+}
+
+// ScanProgress 区块扫描进度
+type ScanProgress struct {
+	Block   uint64 // 刚完成扫描的区块号
+	Scanned uint64 // 已扫描的区块数
+	Total   uint64 // 需要扫描的区块总数
+	Found   int    // 已找到的交易数
+}
+
+// AddressScanOptions 按地址扫描区块的选项
+type AddressScanOptions struct {
+	Concurrency int                // 并发查询区块的数量（<= 0 使用 DefaultBlockFetchConcurrency）
+	OnProgress  func(ScanProgress) // 每扫描完一个区块回调一次（按区块号顺序，可为 nil）
+}
+
+// GetTransactionsByAddressInRange 扫描 [from, to] 范围内的区块，提取地址发出或接收的所有交易
+// 适用于没有区块浏览器 API（见 GetTransactionHistory）的链；只能找到外部交易，合约内部转账和代币转账需要使用事件日志或 trace
+// 发送地址通过 GetFromAddress 从签名恢复，接收地址取自交易的 to 字段
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - address: 要查找的地址
+//   - from: 起始区块号（包含）
+//   - to: 结束区块号（包含）
+//   - opts: 扫描选项（并发数和进度回调）
+//
+// 返回：
+//   - []AddressTx: 按区块号和区块内索引排序的交易
+//   - error: 如果区块范围无效、任一区块查询失败或无法恢复发送地址则返回错误
+//
+// 使用示例：
+//
+//	txs, err := GetTransactionsByAddressInRange(ctx, provider, addr, 1000000, 1010000, AddressScanOptions{
+//	    Concurrency: 16,
+//	    OnProgress: func(p ScanProgress) {
+//	        if p.Scanned%1000 == 0 {
+//	            log.Printf("scanned %d/%d blocks, found %d txs", p.Scanned, p.Total, p.Found)
+//	        }
+//	    },
+//	})
+func GetTransactionsByAddressInRange(ctx context.Context, ep EtherProvider, address common.Address, from, to uint64, opts AddressScanOptions) ([]AddressTx, error) {
+	if from > to {
+		return nil, fmt.Errorf("invalid block range %d-%d", from, to)
+	}
+	var found []AddressTx
+	progress := ScanProgress{Total: to - from + 1}
+	err := StreamBlocks(ctx, ep, from, to, opts.Concurrency, func(block *types.Block) error {
+		for i, tx := range block.Transactions() {
+			sender, err := ep.GetFromAddress(tx)
+			if err != nil {
+				return fmt.Errorf("failed to recover sender of %s: %w", tx.Hash().Hex(), err)
+			}
+			outgoing := sender == address
+			incoming := false
+			if tx.To() != nil {
+				incoming = *tx.To() == address
+			} else {
+				incoming = crypto.CreateAddress(sender, tx.Nonce()) == address
+			}
+			if !outgoing && !incoming {
+				continue
+			}
+			found = append(found, AddressTx{
+				Tx:          tx,
+				BlockNumber: block.NumberU64(),
+				BlockHash:   block.Hash(),
+				Timestamp:   time.Unix(int64(block.Time()), 0),
+				Index:       uint(i),
+				From:        sender,
+				Outgoing:    outgoing,
+				Incoming:    incoming,
+			})
+		}
+		if opts.OnProgress != nil {
+			progress.Block = block.NumberU64()
+			progress.Scanned++
+			progress.Found = len(found)
+			opts.OnProgress(progress)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+// GetTransactionsByAddressInRange 扫描 [from, to] 范围内的区块，提取地址发出或接收的所有交易
+// 参数说明：
+//   - ctx: 上下文对象
+//   - address: 要查找的地址
+//   - from: 起始区块号（包含）
+//   - to: 结束区块号（包含）
+//   - opts: 扫描选项（并发数和进度回调）
+//
+// 返回：
+//   - []AddressTx: 按区块号和区块内索引排序的交易
+//   - error: 如果任一区块查询失败则返回错误
+func (k *Kit) GetTransactionsByAddressInRange(ctx context.Context, address common.Address, from, to uint64, opts AddressScanOptions) ([]AddressTx, error) {
+	return GetTransactionsByAddressInRange(ctx, k.EtherProvider, address, from, to, opts)
+}
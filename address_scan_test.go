@@ -0,0 +1,92 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"strconv"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestGetTransactionsByAddressInRange(t *testing.T) {
+	alice, _ := GetTestAccount(0)
+	bob, _ := GetTestAccount(1)
+	carol, _ := GetTestAccount(2)
+	signer := types.LatestSignerForChainID(big.NewInt(1))
+	send := func(from TestAccount, nonce uint64, to *common.Address) *types.Transaction {
+		return types.MustSignNewTx(from.PrivateKey, signer, &types.LegacyTx{Nonce: nonce, Gas: 21000, GasPrice: big.NewInt(1e9), To: to, Value: big.NewInt(1)})
+	}
+	// alice 在区块 11 部署的合约地址
+	deployed := crypto.CreateAddress(alice.Address, 1)
+
+	// 区块 10 ~ 13
+	blocks := map[uint64]types.Transactions{
+		10: {send(alice, 0, &bob.Address), send(carol, 0, &carol.Address)},
+		11: {send(carol, 1, &carol.Address), send(alice, 1, nil)},
+		12: {},
+		13: {send(bob, 0, &alice.Address), send(carol, 2, &deployed)},
+	}
+	server := newMockRPCServer(t, map[string]mockRPCHandler{
+		"eth_chainId": mockResult("0x1"),
+		"eth_getBlockByNumber": func(params []json.RawMessage) (interface{}, error) {
+			var tag string
+			_ = json.Unmarshal(params[0], &tag)
+			number, _ := strconv.ParseUint(tag[2:], 16, 64)
+			return mockBlockWithTxs(t, number, blocks[number]), nil
+		},
+	})
+	kit := newMockKit(t, server)
+
+	type want struct {
+		block    uint64
+		index    uint
+		outgoing bool
+		incoming bool
+	}
+	tests := []struct {
+		name    string
+		address common.Address
+		want    []want
+	}{
+		{name: "sender and receiver", address: alice.Address, want: []want{{10, 0, true, false}, {11, 1, true, false}, {13, 0, false, true}}},
+		{name: "self transfers", address: carol.Address, want: []want{{10, 1, true, true}, {11, 0, true, true}, {13, 1, true, false}}},
+		{name: "created contract", address: deployed, want: []want{{11, 1, false, true}, {13, 1, false, true}}},
+		{name: "unrelated", address: common.HexToAddress("0x01"), want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var progress []ScanProgress
+			txs, err := kit.GetTransactionsByAddressInRange(context.Background(), tt.address, 10, 13, AddressScanOptions{
+				Concurrency: 2,
+				OnProgress:  func(p ScanProgress) { progress = append(progress, p) },
+			})
+			if err != nil {
+				t.Fatalf("GetTransactionsByAddressInRange() failed: %v", err)
+			}
+			if len(txs) != len(tt.want) {
+				t.Fatalf("found %d transactions, expected %d", len(txs), len(tt.want))
+			}
+			for i, w := range tt.want {
+				got := txs[i]
+				if got.BlockNumber != w.block || got.Index != w.index || got.Outgoing != w.outgoing || got.Incoming != w.incoming {
+					t.Errorf("tx %d = {block %d, index %d, out %v, in %v}, expected %+v", i, got.BlockNumber, got.Index, got.Outgoing, got.Incoming, w)
+				}
+				if got.Tx.Hash() != blocks[w.block][w.index].Hash() {
+					t.Errorf("tx %d hash = %s", i, got.Tx.Hash())
+				}
+			}
+			if len(progress) != 4 || progress[3].Block != 13 || progress[3].Scanned != 4 || progress[3].Total != 4 || progress[3].Found != len(tt.want) {
+				t.Errorf("progress = %+v", progress)
+			}
+		})
+	}
+
+	if _, err := kit.GetTransactionsByAddressInRange(context.Background(), alice.Address, 13, 10, AddressScanOptions{}); err == nil {
+		t.Error("GetTransactionsByAddressInRange() with invalid range expected error")
+	}
+}
@@ -1,6 +1,7 @@
 package etherkit
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -94,3 +95,27 @@ func BenchmarkPublicKeyBytesToAddress(b *testing.B) {
 		PublicKeyBytesToAddress(publicKeyBytes)
 	}
 }
+
+func TestParseAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr error
+	}{
+		{"Valid address", "0x742F35C6dB4634C0532925a3b8D6dA2E12345678", nil},
+		{"Invalid address", "0x742F35C6dB4634C0532925a3b8D6dA2E123", ErrInvalidAddress},
+		{"Zero address", ZeroAddress, ErrZeroAddress},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			address, err := ParseAddress(tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseAddress(%q) error = %v, want %v", tt.input, err, tt.wantErr)
+			}
+			if tt.wantErr == nil && address != common.HexToAddress(tt.input) {
+				t.Errorf("ParseAddress(%q) = %s", tt.input, address.Hex())
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package etherkit
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//############ Arbitrum ############
+
+// ArbitrumNodeInterfaceAddress Arbitrum NodeInterface 虚拟合约地址
+// NodeInterface 不是真实部署的合约，只能通过 eth_call / eth_estimateGas 访问
+const ArbitrumNodeInterfaceAddress = "0x00000000000000000000000000000000000000C8"
+
+// arbitrumNodeInterfaceABI NodeInterface 中 gasEstimateComponents 方法的 ABI
+const arbitrumNodeInterfaceABI = `[{"inputs":[{"name":"to","type":"address"},{"name":"contractCreation","type":"bool"},{"name":"data","type":"bytes"}],"name":"gasEstimateComponents","outputs":[{"name":"gasEstimate","type":"uint64"},{"name":"gasEstimateForL1","type":"uint64"},{"name":"baseFee","type":"uint256"},{"name":"l1BaseFeeEstimate","type":"uint256"}],"stateMutability":"payable","type":"function"}]`
+
+// ErrNodeInterfaceUnavailable 节点上不存在 Arbitrum NodeInterface（调用返回空数据或方法不存在）
+var ErrNodeInterfaceUnavailable = fmt.Errorf("%w: Arbitrum NodeInterface", ErrUnsupportedByEndpoint)
+
+// GasEstimate Gas 估算结果（包含 L1 / L2 的费用组成）
+// 对于标准 EVM 网络，L1Gas 和 L1Fee 为 0，全部费用都属于 L2（执行）部分
+type GasEstimate struct {
+	GasLimit          uint64   // 交易总 gas limit（已包含 L1 部分）
+	L1Gas             uint64   // 用于支付 L1 数据费用的 gas 数量
+	L2Gas             uint64   // 用于 L2 执行的 gas 数量
+	BaseFee           *big.Int // L2 基础费用（单位为 Wei）
+	PriorityFee       *big.Int // 计入费用的建议小费（单位为 Wei，Arbitrum 与不支持 EIP-1559 的网络为 0）
+	L1BaseFeeEstimate *big.Int // L1 基础费用估算（单位为 Wei，标准网络为 nil）
+	L1Fee             *big.Int // L1 数据费用（L1Gas * BaseFee）
+	L2Fee             *big.Int // L2 执行费用（L2Gas * (BaseFee + PriorityFee)）
+	TotalFee          *big.Int // 总费用（L1Fee + L2Fee）
+}
+
+// IsArbitrumChain 判断链是否使用 Arbitrum 的 Gas 计费模型
+// 通过链注册表（NetworkConfigs）判断，自定义的 Arbitrum Orbit 链可以通过 RegisterNetworkConfig 注册
+// 参数说明：
+//   - chainID: 链 ID
+//
+// 返回：
+//   - bool: true 表示使用 Arbitrum 计费模型
+func IsArbitrumChain(chainID *big.Int) bool {
+	return getGasModel(chainID) == GasModelArbitrum
+}
+
+// EstimateArbitrumGasComponents 通过 Arbitrum NodeInterface 估算交易的 gas 组成
+// 调用 NodeInterface.gasEstimateComponents，返回总 gas、L1 数据部分 gas 以及相关费用
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者（必须连接到 Arbitrum 网络）
+//   - from: 发送地址
+//   - to: 接收地址（合约地址或普通地址，零地址表示合约部署，此时 data 为创建代码）
+//   - value: 转账金额（nil 表示不转账）
+//   - data: 交易数据（合约调用数据或 nil）
+//
+// 返回：
+//   - *GasEstimate: gas 估算结果（包含 L1 / L2 组成）
+//   - error: 如果调用失败则返回错误（连接的不是 Arbitrum 网络时返回 ErrNodeInterfaceUnavailable）
+func EstimateArbitrumGasComponents(ctx context.Context, ep EtherProvider, from, to common.Address, value *big.Int, data []byte) (*GasEstimate, error) {
+	nodeInterfaceAbi, err := GetABI(arbitrumNodeInterfaceABI)
+	if err != nil {
+		return nil, err
+	}
+
+	contractCreation := to == (common.Address{})
+	input, err := nodeInterfaceAbi.Pack("gasEstimateComponents", to, contractCreation, data)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeInterface := common.HexToAddress(ArbitrumNodeInterfaceAddress)
+	res, err := ep.GetEthClient().CallContract(ctx, ethereum.CallMsg{
+		From:  from,
+		To:    &nodeInterface,
+		Value: value,
+		Data:  input,
+	}, nil)
+	if err != nil {
+		if isMethodNotFound(err) {
+			return nil, fmt.Errorf("%w: %w", ErrNodeInterfaceUnavailable, err)
+		}
+		return nil, fmt.Errorf("failed to call gasEstimateComponents: %w", err)
+	}
+	if len(res) == 0 {
+		// 非 Arbitrum 节点上 0xC8 没有代码，eth_call 成功但返回空数据
+		return nil, ErrNodeInterfaceUnavailable
+	}
+
+	return unpackArbitrumGasComponents(nodeInterfaceAbi, res)
+}
+
+// unpackArbitrumGasComponents 解析 gasEstimateComponents 的返回值并计算费用组成
+func unpackArbitrumGasComponents(nodeInterfaceAbi abi.ABI, res []byte) (*GasEstimate, error) {
+	out, err := nodeInterfaceAbi.Unpack("gasEstimateComponents", res)
+	if err != nil {
+		return nil, err
+	}
+	if len(out) != 4 {
+		return nil, fmt.Errorf("unexpected gasEstimateComponents output length: %d", len(out))
+	}
+
+	gasEstimate := out[0].(uint64)
+	gasEstimateForL1 := out[1].(uint64)
+	baseFee := out[2].(*big.Int)
+	l1BaseFeeEstimate := out[3].(*big.Int)
+
+	var l2Gas uint64
+	if gasEstimate > gasEstimateForL1 {
+		l2Gas = gasEstimate - gasEstimateForL1
+	}
+
+	return &GasEstimate{
+		GasLimit:          gasEstimate,
+		L1Gas:             gasEstimateForL1,
+		L2Gas:             l2Gas,
+		BaseFee:           baseFee,
+		PriorityFee:       big.NewInt(0),
+		L1BaseFeeEstimate: l1BaseFeeEstimate,
+		L1Fee:             new(big.Int).Mul(new(big.Int).SetUint64(gasEstimateForL1), baseFee),
+		L2Fee:             new(big.Int).Mul(new(big.Int).SetUint64(l2Gas), baseFee),
+		TotalFee:          new(big.Int).Mul(new(big.Int).SetUint64(gasEstimate), baseFee),
+	}, nil
+}
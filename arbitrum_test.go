@@ -0,0 +1,194 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestIsArbitrumChain(t *testing.T) {
+	tests := []struct {
+		name     string
+		chainID  *big.Int
+		expected bool
+	}{
+		{"Arbitrum One", big.NewInt(ArbitrumChainID), true},
+		{"Arbitrum Nova", big.NewInt(ArbitrumNovaChainID), true},
+		{"Arbitrum Sepolia", big.NewInt(ArbitrumSepoliaChainID), true},
+		{"Ethereum Mainnet", big.NewInt(MainnetChainID), false},
+		{"Unknown chain", big.NewInt(999999), false},
+		{"Nil chain ID", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := IsArbitrumChain(tt.chainID); result != tt.expected {
+				t.Errorf("IsArbitrumChain(%v) = %v, expected %v", tt.chainID, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestUnpackArbitrumGasComponents(t *testing.T) {
+	nodeInterfaceAbi, err := GetABI(arbitrumNodeInterfaceABI)
+	if err != nil {
+		t.Fatalf("Failed to parse NodeInterface ABI: %v", err)
+	}
+
+	// 模拟 gasEstimateComponents 的返回值
+	baseFee := big.NewInt(100000000) // 0.1 Gwei
+	res, err := nodeInterfaceAbi.Methods["gasEstimateComponents"].Outputs.Pack(uint64(500000), uint64(200000), baseFee, big.NewInt(30000000000))
+	if err != nil {
+		t.Fatalf("Failed to pack outputs: %v", err)
+	}
+
+	estimate, err := unpackArbitrumGasComponents(nodeInterfaceAbi, res)
+	if err != nil {
+		t.Fatalf("unpackArbitrumGasComponents() failed: %v", err)
+	}
+
+	if estimate.GasLimit != 500000 {
+		t.Errorf("GasLimit = %d, expected 500000", estimate.GasLimit)
+	}
+	if estimate.L1Gas != 200000 {
+		t.Errorf("L1Gas = %d, expected 200000", estimate.L1Gas)
+	}
+	if estimate.L2Gas != 300000 {
+		t.Errorf("L2Gas = %d, expected 300000", estimate.L2Gas)
+	}
+	if estimate.L1Fee.String() != "20000000000000" {
+		t.Errorf("L1Fee = %s, expected 20000000000000", estimate.L1Fee)
+	}
+	if estimate.L2Fee.String() != "30000000000000" {
+		t.Errorf("L2Fee = %s, expected 30000000000000", estimate.L2Fee)
+	}
+	if estimate.TotalFee.String() != "50000000000000" {
+		t.Errorf("TotalFee = %s, expected 50000000000000", estimate.TotalFee)
+	}
+}
+
+func TestEstimateGasBreakdown(t *testing.T) {
+	recipient := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	tests := []struct {
+		name      string
+		baseFee   *big.Int
+		wantTip   *big.Int
+		wantTotal *big.Int
+	}{
+		// 65000 × (10 gwei + 2 gwei)
+		{"london chain", big.NewInt(10e9), big.NewInt(2e9), big.NewInt(65000 * 12e9)},
+		// 65000 × eth_gasPrice (1 gwei)
+		{"legacy chain", nil, big.NewInt(0), big.NewInt(65000 * 1e9)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newMockSendServer(t)
+			server.handlers["eth_getBlockByNumber"] = mockResult(&types.Header{Number: big.NewInt(100), Difficulty: big.NewInt(0), BaseFee: tt.baseFee})
+			server.handlers["eth_maxPriorityFeePerGas"] = mockResult("0x77359400")
+			kit := newMockKit(t, server)
+
+			estimate, err := kit.EstimateGasBreakdown(context.Background(), recipient, big.NewInt(1), nil)
+			if err != nil {
+				t.Fatalf("EstimateGasBreakdown() failed: %v", err)
+			}
+			if estimate.GasLimit != 65000 || estimate.PriorityFee.Cmp(tt.wantTip) != 0 || estimate.L1Fee.Sign() != 0 {
+				t.Errorf("EstimateGasBreakdown() = %+v", estimate)
+			}
+			if estimate.TotalFee.Cmp(tt.wantTotal) != 0 || estimate.L2Fee.Cmp(tt.wantTotal) != 0 {
+				t.Errorf("TotalFee = %v, expected %v", estimate.TotalFee, tt.wantTotal)
+			}
+		})
+	}
+}
+
+func TestEstimateGasArbitrum(t *testing.T) {
+	nodeInterfaceAbi, err := GetABI(arbitrumNodeInterfaceABI)
+	if err != nil {
+		t.Fatalf("Failed to parse NodeInterface ABI: %v", err)
+	}
+	components, err := nodeInterfaceAbi.Methods["gasEstimateComponents"].Outputs.Pack(uint64(500000), uint64(200000), big.NewInt(1e8), big.NewInt(3e10))
+	if err != nil {
+		t.Fatalf("Failed to pack outputs: %v", err)
+	}
+	from := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	recipient := common.HexToAddress("0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC")
+
+	tests := []struct {
+		name             string
+		to               common.Address
+		call             mockRPCHandler
+		cancel           bool
+		wantGas          uint64
+		wantCreation     bool
+		wantErr          bool
+		wantEstimateCall int
+	}{
+		{"node interface", recipient, mockResult(hexutil.Bytes(components)), false, 500000, false, false, 0},
+		{"contract creation", common.Address{}, mockResult(hexutil.Bytes(components)), false, 500000, true, false, 0},
+		{"no node interface falls back", recipient, mockResult("0x"), false, 21000, false, false, 1},
+		{"revert is returned", recipient, func(params []json.RawMessage) (interface{}, error) {
+			return nil, &mockRPCError{Code: 3, Message: "execution reverted"}
+		}, false, 0, false, true, 0},
+		{"cancelled context is returned", recipient, mockResult(hexutil.Bytes(components)), true, 0, false, true, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var creation bool
+			server := newMockRPCServer(t, map[string]mockRPCHandler{
+				"eth_chainId":     mockResult("0xa4b1"),
+				"eth_estimateGas": mockResult("0x5208"),
+				"eth_call": func(params []json.RawMessage) (interface{}, error) {
+					arg, err := parseMockCallArg(params)
+					if err != nil {
+						return nil, err
+					}
+					args, err := nodeInterfaceAbi.Methods["gasEstimateComponents"].Inputs.Unpack(arg.calldata()[4:])
+					if err != nil {
+						return nil, err
+					}
+					creation = args[1].(bool)
+					return tt.call(params)
+				},
+			})
+			provider, err := NewProvider(server.URL)
+			if err != nil {
+				t.Fatalf("NewProvider() failed: %v", err)
+			}
+			defer provider.Close()
+			if _, err := provider.GetChainID(context.Background()); err != nil {
+				t.Fatalf("GetChainID() failed: %v", err)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancel {
+				cancel()
+			}
+			gas, err := provider.EstimateGas(ctx, from, tt.to, 0, nil, nil, []byte{0x60, 0x80})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("EstimateGas() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.cancel && !errors.Is(err, context.Canceled) {
+				t.Errorf("EstimateGas() error = %v, want context.Canceled", err)
+			}
+			if gas != tt.wantGas {
+				t.Errorf("EstimateGas() = %d, want %d", gas, tt.wantGas)
+			}
+			if !tt.cancel && creation != tt.wantCreation {
+				t.Errorf("contractCreation = %v, want %v", creation, tt.wantCreation)
+			}
+			if n := server.callCount("eth_estimateGas"); n != tt.wantEstimateCall {
+				t.Errorf("eth_estimateGas called %d times, want %d", n, tt.wantEstimateCall)
+			}
+			if n := server.callCount("eth_chainId"); n != 1 {
+				t.Errorf("eth_chainId called %d times, want 1", n)
+			}
+		})
+	}
+}
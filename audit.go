@@ -0,0 +1,188 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+//############ Audit Trail ############
+
+// ErrAuditFailed 审计记录写入失败（签名结果不会被返回或广播）
+var ErrAuditFailed = errors.New("failed to record audit trail")
+
+// AuditKind 审计记录类型
+type AuditKind string
+
+const (
+	AuditKindTransaction   AuditKind = "transaction"    // 链上交易
+	AuditKindMessage       AuditKind = "message"        // 原始消息签名
+	AuditKindHash          AuditKind = "hash"           // 32 字节哈希直接签名
+	AuditKindTypedData     AuditKind = "typed_data"     // EIP-712 结构化数据签名
+	AuditKindUserOperation AuditKind = "user_operation" // ERC-4337 用户操作签名
+)
+
+// AuditRecord 一次签名的审计记录
+type AuditRecord struct {
+	Kind      AuditKind          `json:"kind"`      // 记录类型
+	Signer    common.Address     `json:"signer"`    // 签名地址
+	ChainID   *big.Int           `json:"chainId"`   // 链 ID（消息签名时可能为 nil）
+	Time      time.Time          `json:"time"`      // 签名时间
+	Hash      common.Hash        `json:"hash"`      // 交易哈希或被签名的摘要
+	Intent    string             `json:"intent"`    // 解码后的操作意图（可读摘要）
+	Payload   hexutil.Bytes      `json:"payload"`   // 被签名的原始内容（交易为已签名的原始交易）
+	Signature hexutil.Bytes      `json:"signature"` // 签名结果（交易记录中为空，签名已包含在 Payload 中）
+	Tx        *types.Transaction `json:"-"`         // 已签名的交易（仅交易记录）
+}
+
+// AuditSink 审计记录接收器
+// Record 在签名完成后、签名结果返回或交易广播前调用；返回错误会中止本次操作，
+// 保证每一笔对外发出的签名都有对应的审计记录
+type AuditSink interface {
+	Record(ctx context.Context, record *AuditRecord) error
+}
+
+// AuditSinkFunc 函数形式的 AuditSink
+type AuditSinkFunc func(ctx context.Context, record *AuditRecord) error
+
+// Record 实现 AuditSink 接口
+func (f AuditSinkFunc) Record(ctx context.Context, record *AuditRecord) error {
+	return f(ctx, record)
+}
+
+// jsonAuditSink 以 JSON Lines 格式写入审计记录
+type jsonAuditSink struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewJSONAuditSink 创建以 JSON Lines 格式（每行一条记录）写入 w 的审计接收器（并发安全）
+// 参数说明：
+//   - w: 输出目标（如日志文件）
+func NewJSONAuditSink(w io.Writer) AuditSink {
+	return &jsonAuditSink{enc: json.NewEncoder(w)}
+}
+
+// Record 实现 AuditSink 接口
+func (s *jsonAuditSink) Record(ctx context.Context, record *AuditRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.enc.Encode(record)
+}
+
+// WithAuditSink 注册审计接收器，记录 Kit 产生的每一笔交易签名和消息签名
+// 可以多次使用，记录按注册顺序写入，任一接收器失败即中止（错误可用 errors.Is(err, ErrAuditFailed) 判断）
+// 参数说明：
+//   - sink: 审计接收器
+//
+// 使用示例：
+//
+//	f, _ := os.OpenFile("audit.log", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+//	kit, err := NewKit(pk, url, WithAuditSink(NewJSONAuditSink(f)))
+func WithAuditSink(sink AuditSink) KitOption {
+	return func(k *Kit) {
+		if sink != nil {
+			k.auditSinks = append(k.auditSinks, sink)
+		}
+	}
+}
+
+// audit 将审计记录写入所有接收器
+func (k *Kit) audit(ctx context.Context, record *AuditRecord) error {
+	if len(k.auditSinks) == 0 {
+		return nil
+	}
+	record.Signer = k.GetAddress()
+	record.Time = time.Now()
+	for _, sink := range k.auditSinks {
+		if err := sink.Record(ctx, record); err != nil {
+			return fmt.Errorf("%w: %w", ErrAuditFailed, err)
+		}
+	}
+	return nil
+}
+
+// auditTx 记录已签名的交易
+func (k *Kit) auditTx(ctx context.Context, review *TxReview, signedTx *types.Transaction) error {
+	raw, err := signedTx.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return k.audit(ctx, &AuditRecord{
+		Kind:    AuditKindTransaction,
+		ChainID: review.ChainID,
+		Hash:    signedTx.Hash(),
+		Intent:  review.String(),
+		Payload: raw,
+		Tx:      signedTx,
+	})
+}
+
+// auditSignature 记录消息类签名（结构化数据和用户操作以 JSON 形式保存在 Payload 中）
+func (k *Kit) auditSignature(ctx context.Context, review *TxReview, signature []byte) error {
+	if len(k.auditSinks) == 0 {
+		return nil
+	}
+	var payload []byte
+	var err error
+	switch {
+	case review.TypedData != nil:
+		payload, err = json.Marshal(review.TypedData)
+	case review.UserOp != nil:
+		payload, err = json.Marshal(review.UserOp)
+	case review.Message != nil:
+		payload = review.Message
+	default:
+		payload = review.Digest.Bytes()
+	}
+	if err != nil {
+		return err
+	}
+	if review.Kind == AuditKindTransaction {
+		// 与 auditTx 一致：交易记录的签名已包含在原始交易中
+		signature = nil
+	}
+	return k.audit(ctx, &AuditRecord{
+		Kind:      review.Kind,
+		ChainID:   review.ChainID,
+		Hash:      review.Digest,
+		Intent:    review.Intent,
+		Payload:   payload,
+		Signature: signature,
+	})
+}
+
+// SignTypedData 使用 Kit 的私钥对 EIP-712 结构化数据进行签名（签名前执行审核回调，启用审计时在返回签名前写入审计记录）
+// 参数说明：
+//   - typedData: EIP-712 结构化数据
+//
+// 返回：
+//   - []byte: 签名结果（65 字节，v 为 27 或 28）
+//   - error: 如果审核被拒绝、签名或写入审计记录失败则返回错误
+func (k *Kit) SignTypedData(typedData apitypes.TypedData) ([]byte, error) {
+	return k.signTypedData(context.Background(), typedData, fmt.Sprintf("sign EIP-712 %s for %q", typedData.PrimaryType, typedData.Domain.Name), nil)
+}
+
+// signTypedData 审核并签名 EIP-712 结构化数据；prepare 用于补充可识别的资金去向
+func (k *Kit) signTypedData(ctx context.Context, typedData apitypes.TypedData, intent string, prepare func(*TxReview)) ([]byte, error) {
+	review, err := k.newTypedDataReview(typedData, intent)
+	if err != nil {
+		return nil, err
+	}
+	if prepare != nil {
+		prepare(review)
+	}
+	return k.signReviewed(ctx, review, func() ([]byte, error) {
+		return k.Wallet.SignTypedData(typedData)
+	})
+}
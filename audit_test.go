@@ -0,0 +1,113 @@
+package etherkit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+func TestAuditSink(t *testing.T) {
+	token := common.HexToAddress("0x1c7D4B196Cb0C7B01d743Fbc6116a902379C7238")
+	recipient := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	data, _ := erc20ABI.Pack("transfer", recipient, big.NewInt(1000))
+
+	t.Run("records transaction before broadcast", func(t *testing.T) {
+		server := newMockSendServer(t)
+		var records []*AuditRecord
+		kit := newMockKit(t, server, WithAuditSink(AuditSinkFunc(func(ctx context.Context, record *AuditRecord) error {
+			records = append(records, record)
+			return nil
+		})))
+
+		hash, err := kit.SendTx(context.Background(), token, 0, 0, nil, nil, data)
+		if err != nil {
+			t.Fatalf("SendTx() failed: %v", err)
+		}
+		if len(records) != 1 {
+			t.Fatalf("got %d audit records, want 1", len(records))
+		}
+		record := records[0]
+		if record.Kind != AuditKindTransaction || record.Signer != kit.GetAddress() || record.ChainID.Int64() != 1 {
+			t.Errorf("unexpected audit record: %+v", record)
+		}
+		if record.Hash != hash || record.Tx == nil || record.Tx.Hash() != hash {
+			t.Errorf("audit hash = %s, want signed tx hash", record.Hash.Hex())
+		}
+		if !bytes.Contains([]byte(record.Intent), []byte("call transfer")) {
+			t.Errorf("intent %q should contain decoded call", record.Intent)
+		}
+	})
+
+	t.Run("sink failure aborts broadcast", func(t *testing.T) {
+		server := newMockSendServer(t)
+		sent := false
+		server.handlers["eth_sendRawTransaction"] = func(params []json.RawMessage) (interface{}, error) {
+			sent = true
+			return common.Hash{}, nil
+		}
+		kit := newMockKit(t, server, WithAuditSink(AuditSinkFunc(func(ctx context.Context, record *AuditRecord) error {
+			return errors.New("disk full")
+		})))
+
+		if _, err := kit.SendTx(context.Background(), token, 0, 0, nil, nil, data); !errors.Is(err, ErrAuditFailed) {
+			t.Fatalf("SendTx() error = %v, want ErrAuditFailed", err)
+		}
+		if sent {
+			t.Error("transaction should not be broadcast when audit fails")
+		}
+	})
+
+	t.Run("records messages as json lines", func(t *testing.T) {
+		var buf bytes.Buffer
+		kit := newMockKit(t, newMockSendServer(t), WithAuditSink(NewJSONAuditSink(&buf)))
+
+		if _, err := kit.SignMessage(context.Background(), []byte("hello")); err != nil {
+			t.Fatalf("SignMessage() failed: %v", err)
+		}
+		typedData := (&TransferAuthorization{From: kit.GetAddress(), To: recipient, Value: big.NewInt(1), ValidAfter: big.NewInt(0), ValidBefore: big.NewInt(1)}).
+			TypedData(TransferWithAuthorization, apitypes.TypedDataDomain{
+				Name:              "USD Coin",
+				Version:           "2",
+				ChainId:           math.NewHexOrDecimal256(1),
+				VerifyingContract: "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48",
+			})
+		if _, err := kit.SignTypedData(typedData); err != nil {
+			t.Fatalf("SignTypedData() failed: %v", err)
+		}
+		if _, err := kit.Signature([]byte("hello")); err != nil {
+			t.Fatalf("Signature() failed: %v", err)
+		}
+		if _, err := kit.SignHash(common.HexToHash("0x01")); err != nil {
+			t.Fatalf("SignHash() failed: %v", err)
+		}
+		op := &UserOperation{Sender: common.HexToAddress("0xacc0"), Nonce: big.NewInt(0)}
+		if err := kit.SignUserOperation(context.Background(), op, common.HexToAddress(EntryPointV06Address)); err != nil {
+			t.Fatalf("SignUserOperation() failed: %v", err)
+		}
+
+		dec := json.NewDecoder(&buf)
+		var kinds []AuditKind
+		for dec.More() {
+			var record AuditRecord
+			if err := dec.Decode(&record); err != nil {
+				t.Fatalf("failed to decode audit line: %v", err)
+			}
+			if record.Signer != kit.GetAddress() || len(record.Signature) != 65 {
+				t.Errorf("unexpected audit record: %+v", record)
+			}
+			kinds = append(kinds, record.Kind)
+		}
+		want := []AuditKind{AuditKindMessage, AuditKindTypedData, AuditKindMessage, AuditKindHash, AuditKindUserOperation}
+		if fmt.Sprint(kinds) != fmt.Sprint(want) {
+			t.Errorf("audit kinds = %v, want %v", kinds, want)
+		}
+	})
+}
@@ -0,0 +1,168 @@
+package etherkit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/guanzhenxing/go-evm-kit/contracts/erc20"
+	"github.com/shopspring/decimal"
+)
+
+//############ Balances ############
+
+// ChainBalanceQuery 单条链的余额查询配置
+type ChainBalanceQuery struct {
+	Provider EtherProvider    // 该链的以太坊提供者
+	Tokens   []common.Address // 需要查询的 ERC20 代币地址（nil 表示只查询本位币）
+}
+
+// TokenBalance 单个 ERC20 代币的余额
+type TokenBalance struct {
+	Token    common.Address  // 代币地址
+	Symbol   string          // 代币符号
+	Decimals uint8           // 代币精度
+	Balance  *big.Int        // 余额（最小单位）
+	Amount   decimal.Decimal // 按精度换算后的余额
+	Err      error           // 查询失败时的错误（成功时为 nil）
+}
+
+// ChainBalances 单条链上的余额汇总
+type ChainBalances struct {
+	ChainID      *big.Int        // 链 ID
+	Symbol       string          // 本位币符号（来自链注册表，未注册时为空）
+	Native       *big.Int        // 本位币余额（单位为 Wei）
+	NativeAmount decimal.Decimal // 本位币余额（以 Ether 为单位）
+	Tokens       []TokenBalance  // 代币余额（与查询配置中的顺序一致）
+	Err          error           // 查询本链失败时的错误（成功时为 nil）
+}
+
+// GetERC20Balance 获取地址在指定 ERC20 代币上的余额及代币信息
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - token: 代币合约地址
+//   - owner: 持有人地址
+//
+// 返回：
+//   - TokenBalance: 代币余额（包含符号、精度和换算后的数值）
+//   - error: 如果查询失败则返回错误
+func GetERC20Balance(ctx context.Context, ep EtherProvider, token, owner common.Address) (TokenBalance, error) {
+	result := TokenBalance{Token: token}
+
+	// 精度和符号通过代币注册表获取（已缓存时不再查询链上）
+	meta, err := DefaultTokenRegistry.Lookup(ctx, ep, token)
+	if err != nil {
+		return result, err
+	}
+	caller, err := erc20.NewIERC20Caller(token, ep.GetEthClient())
+	if err != nil {
+		return result, err
+	}
+	balance, err := caller.BalanceOf(&bind.CallOpts{Context: ctx}, owner)
+	if err != nil {
+		return result, fmt.Errorf("failed to query balanceOf for token %s: %w", token.Hex(), err)
+	}
+
+	result.Symbol = meta.Symbol
+	result.Decimals = meta.Decimals
+	result.Balance = balance
+	result.Amount = ToDecimal(balance, int(meta.Decimals))
+	return result, nil
+}
+
+// GetBalancesAcrossChains 并发查询一个地址在多条链上的本位币和代币余额
+// 每条链在独立的 goroutine 中查询，某条链失败不会影响其他链的结果
+// 参数说明：
+//   - ctx: 上下文对象
+//   - address: 要查询的地址
+//   - chains: 每条链的查询配置（Provider + 代币列表）
+//
+// 返回：
+//   - []ChainBalances: 每条链的余额汇总（与 chains 顺序一致，失败的链 Err 字段不为 nil）
+//   - error: 所有失败的链和代币错误的合并（全部成功时为 nil）
+//
+// 使用示例：
+//
+//	results, err := GetBalancesAcrossChains(ctx, addr,
+//	    ChainBalanceQuery{Provider: mainnet, Tokens: []common.Address{usdc}},
+//	    ChainBalanceQuery{Provider: polygon},
+//	)
+func GetBalancesAcrossChains(ctx context.Context, address common.Address, chains ...ChainBalanceQuery) ([]ChainBalances, error) {
+	results := make([]ChainBalances, len(chains))
+
+	var wg sync.WaitGroup
+	for i, chain := range chains {
+		wg.Add(1)
+		go func(i int, chain ChainBalanceQuery) {
+			defer wg.Done()
+			results[i] = getChainBalances(ctx, address, chain)
+		}(i, chain)
+	}
+	wg.Wait()
+
+	var errs []error
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, result.Err)
+		}
+		for _, token := range result.Tokens {
+			if token.Err != nil {
+				errs = append(errs, token.Err)
+			}
+		}
+	}
+	return results, errors.Join(errs...)
+}
+
+// getChainBalances 查询单条链上的本位币和代币余额
+func getChainBalances(ctx context.Context, address common.Address, chain ChainBalanceQuery) ChainBalances {
+	var result ChainBalances
+	if chain.Provider == nil {
+		result.Err = errors.New("chain balance query has no provider")
+		return result
+	}
+
+	chainId, err := chain.Provider.GetChainID(ctx)
+	if err != nil {
+		result.Err = fmt.Errorf("failed to query chain id: %w", err)
+		return result
+	}
+	result.ChainID = chainId
+	if cfg, ok := NetworkConfigs[chainId.Int64()]; ok {
+		result.Symbol = cfg.Symbol
+	}
+
+	native, err := providerExt[BlockRefReader](chain.Provider).GetBalanceOf(ctx, address)
+	if err != nil {
+		result.Err = fmt.Errorf("chain %s: failed to query native balance: %w", chainId, err)
+		return result
+	}
+	result.Native = native
+	result.NativeAmount = ToDecimal(native, EthDecimals)
+
+	for _, token := range chain.Tokens {
+		balance, err := GetERC20Balance(ctx, chain.Provider, token, address)
+		if err != nil {
+			balance.Err = fmt.Errorf("chain %s: %w", chainId, err)
+		}
+		result.Tokens = append(result.Tokens, balance)
+	}
+	return result
+}
+
+// GetBalancesAcrossChains 并发查询 Kit 账户在多条链上的本位币和代币余额
+// 参数说明：
+//   - ctx: 上下文对象
+//   - chains: 每条链的查询配置（Provider + 代币列表）
+//
+// 返回：
+//   - []ChainBalances: 每条链的余额汇总
+//   - error: 所有失败的链和代币错误的合并（全部成功时为 nil）
+func (k *Kit) GetBalancesAcrossChains(ctx context.Context, chains ...ChainBalanceQuery) ([]ChainBalances, error) {
+	return GetBalancesAcrossChains(ctx, k.GetAddress(), chains...)
+}
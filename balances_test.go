@@ -0,0 +1,71 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestGetBalancesAcrossChains(t *testing.T) {
+	mainnet := newMockRPCServer(t, map[string]mockRPCHandler{
+		"eth_chainId":    mockResult("0x1"),
+		"eth_getBalance": mockResult("0xde0b6b3a7640000"), // 1 ETH
+		"eth_call":       mockERC20Call(big.NewInt(2500000), 6, "USDC"),
+	})
+	polygon := newMockRPCServer(t, map[string]mockRPCHandler{
+		"eth_chainId": mockResult("0x89"),
+		"eth_getBalance": func(params []json.RawMessage) (interface{}, error) {
+			return nil, errors.New("upstream unavailable")
+		},
+	})
+
+	mainnetProvider, err := NewProvider(mainnet.URL)
+	if err != nil {
+		t.Fatalf("NewProvider() failed: %v", err)
+	}
+	defer mainnetProvider.Close()
+	polygonProvider, err := NewProvider(polygon.URL)
+	if err != nil {
+		t.Fatalf("NewProvider() failed: %v", err)
+	}
+	defer polygonProvider.Close()
+
+	usdc := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	owner := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+
+	results, err := GetBalancesAcrossChains(context.Background(), owner,
+		ChainBalanceQuery{Provider: mainnetProvider, Tokens: []common.Address{usdc}},
+		ChainBalanceQuery{Provider: polygonProvider},
+	)
+	if err == nil {
+		t.Error("Expected joined error for failing chain")
+	}
+	if len(results) != 2 {
+		t.Fatalf("len(results) = %d, expected 2", len(results))
+	}
+
+	eth := results[0]
+	if eth.Err != nil {
+		t.Fatalf("Mainnet query failed: %v", eth.Err)
+	}
+	if eth.Symbol != "ETH" || eth.NativeAmount.String() != "1" {
+		t.Errorf("Mainnet native = %s %s, expected 1 ETH", eth.NativeAmount, eth.Symbol)
+	}
+	if len(eth.Tokens) != 1 {
+		t.Fatalf("len(Tokens) = %d, expected 1", len(eth.Tokens))
+	}
+	if eth.Tokens[0].Symbol != "USDC" || eth.Tokens[0].Amount.String() != "2.5" {
+		t.Errorf("Token balance = %s %s, expected 2.5 USDC", eth.Tokens[0].Amount, eth.Tokens[0].Symbol)
+	}
+
+	if results[1].Err == nil {
+		t.Error("Expected polygon query to fail")
+	}
+	if results[1].ChainID == nil || results[1].ChainID.Int64() != PolygonChainID {
+		t.Errorf("Polygon ChainID = %v, expected %d", results[1].ChainID, PolygonChainID)
+	}
+}
@@ -0,0 +1,150 @@
+package etherkit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+//############ Base Fee ############
+
+// GetBaseFee 获取最新区块的基础费用
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//
+// 返回：
+//   - *big.Int: 最新区块的基础费用（单位为 Wei）
+//   - error: 如果查询失败或链不支持 EIP-1559 则返回错误
+func GetBaseFee(ctx context.Context, ep EtherProvider) (*big.Int, error) {
+	header, err := providerExt[BlockRefReader](ep).GetHeaderAt(ctx, BlockAtTag(BlockTagLatest))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query latest header: %w", err)
+	}
+	if header.BaseFee == nil {
+		return nil, fmt.Errorf("block %d has no base fee (chain does not support EIP-1559)", header.Number.Uint64())
+	}
+	return new(big.Int).Set(header.BaseFee), nil
+}
+
+// PredictNextBaseFee 按 EIP-1559 公式根据父区块计算下一个区块的基础费用
+// gas 使用量高于目标时上涨、低于目标时下降；弹性系数和变化分母取自链注册表
+// （未配置时使用以太坊主网参数：弹性系数 2、变化分母 8，每个区块最多变化 12.5%）
+// 参数说明：
+//   - chainID: 链 ID（nil 表示使用以太坊主网参数）
+//   - parent: 父区块头（通常为最新区块头）
+//
+// 返回：
+//   - *big.Int: 下一个区块的基础费用（单位为 Wei，父区块没有基础费用时返回 nil）
+func PredictNextBaseFee(chainID *big.Int, parent *types.Header) *big.Int {
+	if parent.BaseFee == nil {
+		return nil
+	}
+	elasticity, denominator := getBaseFeeParams(chainID)
+	return nextBaseFee(parent.BaseFee, parent.GasUsed, parent.GasLimit, elasticity, denominator)
+}
+
+// PredictMaxBaseFee 计算之后连续 blocks 个区块都满载时的基础费用上限
+// 交易在 blocks 个区块内被打包时，基础费用不会超过该值，适合作为 maxFeePerGas 中基础费用部分的上限
+// 参数说明：
+//   - chainID: 链 ID（nil 表示使用以太坊主网参数）
+//   - parent: 父区块头（通常为最新区块头）
+//   - blocks: 交易可能等待的区块数（1 表示只考虑下一个区块）
+//
+// 返回：
+//   - *big.Int: 基础费用上限（单位为 Wei，父区块没有基础费用时返回 nil）
+//
+// 使用示例：
+//
+//	header, err := provider.GetHeaderAt(ctx, BlockAtTag(BlockTagLatest))
+//	maxFee := new(big.Int).Add(PredictMaxBaseFee(chainID, header, 6), tip) // 6 个区块内不会因基础费用上涨而卡住
+func PredictMaxBaseFee(chainID *big.Int, parent *types.Header, blocks int) *big.Int {
+	fee := PredictNextBaseFee(chainID, parent)
+	if fee == nil {
+		return nil
+	}
+	elasticity, denominator := getBaseFeeParams(chainID)
+	for i := 1; i < blocks; i++ {
+		fee = nextBaseFee(fee, parent.GasLimit, parent.GasLimit, elasticity, denominator)
+	}
+	return fee
+}
+
+// getBaseFeeParams 获取指定链的 EIP-1559 弹性系数和基础费用变化分母（未配置时返回以太坊主网参数）
+func getBaseFeeParams(chainID *big.Int) (elasticity, denominator uint64) {
+	elasticity, denominator = params.DefaultElasticityMultiplier, params.DefaultBaseFeeChangeDenominator
+	if chainID == nil || !chainID.IsInt64() {
+		return elasticity, denominator
+	}
+	if cfg, ok := NetworkConfigs[chainID.Int64()]; ok {
+		if cfg.ElasticityMultiplier > 0 {
+			elasticity = cfg.ElasticityMultiplier
+		}
+		if cfg.BaseFeeChangeDenominator > 0 {
+			denominator = cfg.BaseFeeChangeDenominator
+		}
+	}
+	return elasticity, denominator
+}
+
+// nextBaseFee 按 EIP-1559 公式计算基础费用的变化
+func nextBaseFee(baseFee *big.Int, gasUsed, gasLimit, elasticity, changeDenominator uint64) *big.Int {
+	target := gasLimit / elasticity
+	if target == 0 || gasUsed == target {
+		return new(big.Int).Set(baseFee)
+	}
+	denominator := new(big.Int).SetUint64(target * changeDenominator)
+	if gasUsed > target {
+		delta := new(big.Int).Mul(baseFee, new(big.Int).SetUint64(gasUsed-target))
+		delta.Div(delta, denominator)
+		if delta.Sign() == 0 {
+			delta.SetInt64(1)
+		}
+		return delta.Add(baseFee, delta)
+	}
+	delta := new(big.Int).Mul(baseFee, new(big.Int).SetUint64(target-gasUsed))
+	delta.Div(delta, denominator)
+	next := new(big.Int).Sub(baseFee, delta)
+	if next.Sign() < 0 {
+		next.SetInt64(0)
+	}
+	return next
+}
+
+// GetBaseFee 获取最新区块的基础费用
+// 参数说明：
+//   - ctx: 上下文对象
+//
+// 返回：
+//   - *big.Int: 最新区块的基础费用（单位为 Wei）
+//   - error: 如果查询失败或链不支持 EIP-1559 则返回错误
+func (k *Kit) GetBaseFee(ctx context.Context) (*big.Int, error) {
+	return GetBaseFee(ctx, k.EtherProvider)
+}
+
+// PredictNextBaseFee 根据最新区块预测下一个区块的基础费用（使用当前链在注册表中的 EIP-1559 参数）
+// 参数说明：
+//   - ctx: 上下文对象
+//
+// 返回：
+//   - *big.Int: 下一个区块的基础费用（单位为 Wei）
+//   - error: 如果查询失败或链不支持 EIP-1559 则返回错误
+func (k *Kit) PredictNextBaseFee(ctx context.Context) (*big.Int, error) {
+	chainID, err := k.GetChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query chain id: %w", err)
+	}
+	header, err := k.GetHeaderAt(ctx, BlockAtTag(BlockTagLatest))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query latest header: %w", err)
+	}
+	fee := PredictNextBaseFee(chainID, header)
+	if fee == nil {
+		return nil, errors.New("latest block has no base fee (chain does not support EIP-1559)")
+	}
+	return fee, nil
+}
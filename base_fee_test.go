@@ -0,0 +1,128 @@
+package etherkit
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestPredictNextBaseFee(t *testing.T) {
+	// 以 go-ethereum 的实现为基准
+	tests := []struct {
+		name     string
+		baseFee  int64
+		gasUsed  uint64
+		gasLimit uint64
+	}{
+		{name: "at target", baseFee: 10e9, gasUsed: 15000000, gasLimit: 30000000},
+		{name: "full block", baseFee: 10e9, gasUsed: 30000000, gasLimit: 30000000},
+		{name: "empty block", baseFee: 10e9, gasUsed: 0, gasLimit: 30000000},
+		{name: "slightly above target", baseFee: 7, gasUsed: 15000001, gasLimit: 30000000},
+		{name: "slightly below target", baseFee: 123456789, gasUsed: 14000000, gasLimit: 36000000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parent := &types.Header{Number: big.NewInt(20000000), BaseFee: big.NewInt(tt.baseFee), GasUsed: tt.gasUsed, GasLimit: tt.gasLimit}
+			want := eip1559.CalcBaseFee(params.MainnetChainConfig, parent)
+			if got := PredictNextBaseFee(nil, parent); got.Cmp(want) != 0 {
+				t.Errorf("PredictNextBaseFee() = %s, expected %s", got, want)
+			}
+		})
+	}
+
+	if got := PredictNextBaseFee(big.NewInt(MainnetChainID), &types.Header{Number: big.NewInt(1)}); got != nil {
+		t.Errorf("PredictNextBaseFee() on legacy header = %s, expected nil", got)
+	}
+}
+
+func TestPredictNextBaseFeeChainParams(t *testing.T) {
+	parent := &types.Header{Number: big.NewInt(100), BaseFee: big.NewInt(1e9), GasUsed: 30000000, GasLimit: 30000000}
+	const customChainID = 990001
+	RegisterNetworkConfig(NetworkConfig{ChainID: customChainID, ElasticityMultiplier: 4, BaseFeeChangeDenominator: 10})
+	defer delete(NetworkConfigs, customChainID)
+
+	tests := []struct {
+		name    string
+		chainID *big.Int
+		want    int64
+	}{
+		{name: "mainnet", chainID: big.NewInt(MainnetChainID), want: 1125000000},
+		{name: "unknown chain uses mainnet", chainID: big.NewInt(424242), want: 1125000000},
+		{name: "polygon", chainID: big.NewInt(PolygonChainID), want: 1062500000},
+		{name: "optimism", chainID: big.NewInt(OptimismChainID), want: 1020000000},
+		{name: "registered chain", chainID: big.NewInt(customChainID), want: 1300000000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PredictNextBaseFee(tt.chainID, parent); got.Int64() != tt.want {
+				t.Errorf("PredictNextBaseFee() = %s, expected %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPredictMaxBaseFee(t *testing.T) {
+	parent := &types.Header{Number: big.NewInt(100), BaseFee: big.NewInt(8e9), GasUsed: 15000000, GasLimit: 30000000}
+
+	tests := []struct {
+		name   string
+		blocks int
+		want   int64
+	}{
+		{name: "next block", blocks: 1, want: 8e9},
+		{name: "one full block", blocks: 2, want: 9e9},
+		{name: "two full blocks", blocks: 3, want: 10125000000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PredictMaxBaseFee(big.NewInt(MainnetChainID), parent, tt.blocks); got.Int64() != tt.want {
+				t.Errorf("PredictMaxBaseFee(%d) = %s, expected %d", tt.blocks, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKitBaseFee(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   *types.Header
+		wantBase int64
+		wantNext int64
+		wantErr  bool
+	}{
+		{name: "full block", header: &types.Header{Number: big.NewInt(100), Difficulty: big.NewInt(0), BaseFee: big.NewInt(8e9), GasUsed: 30000000, GasLimit: 30000000}, wantBase: 8e9, wantNext: 9e9},
+		{name: "legacy chain", header: &types.Header{Number: big.NewInt(100), Difficulty: big.NewInt(0)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newMockRPCServer(t, map[string]mockRPCHandler{
+				"eth_chainId":          mockResult("0x1"),
+				"eth_getBlockByNumber": mockResult(tt.header),
+			})
+			kit := newMockKit(t, server)
+
+			base, err := kit.GetBaseFee(context.Background())
+			next, nextErr := kit.PredictNextBaseFee(context.Background())
+			if tt.wantErr {
+				if err == nil || nextErr == nil {
+					t.Fatalf("expected errors, got %v, %v", err, nextErr)
+				}
+				return
+			}
+			if err != nil || nextErr != nil {
+				t.Fatalf("GetBaseFee() = %v, PredictNextBaseFee() = %v", err, nextErr)
+			}
+			if base.Int64() != tt.wantBase || next.Int64() != tt.wantNext {
+				t.Errorf("base = %s, next = %s, expected %d, %d", base, next, tt.wantBase, tt.wantNext)
+			}
+		})
+	}
+}
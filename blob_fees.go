@@ -0,0 +1,179 @@
+package etherkit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/params"
+)
+
+//############ Blob Fees ############
+
+// minBlobBaseFee EIP-4844 规定的最低 blob 基础费用（1 wei）
+var minBlobBaseFee = big.NewInt(1)
+
+// CalcBlobBaseFee 根据 excessBlobGas 计算 blob 基础费用（EIP-4844 的 fake_exponential）
+// 参数说明：
+//   - excessBlobGas: 区块头中的 excessBlobGas
+//   - config: blob 参数（nil 使用 params.DefaultPragueBlobConfig）
+//
+// 返回：
+//   - *big.Int: 每单位 blob gas 的基础费用（单位为 Wei）
+func CalcBlobBaseFee(excessBlobGas uint64, config *params.BlobConfig) *big.Int {
+	if config == nil {
+		config = params.DefaultPragueBlobConfig
+	}
+	return fakeExponential(minBlobBaseFee, new(big.Int).SetUint64(excessBlobGas), new(big.Int).SetUint64(config.UpdateFraction))
+}
+
+// CalcNextExcessBlobGas 根据父区块的 excessBlobGas 和 blobGasUsed 计算下一个区块的 excessBlobGas
+// 使用 EIP-4844 的原始公式，不包含 Osaka（EIP-7918）引入的执行基础费用下限调整
+// 参数说明：
+//   - parentExcessBlobGas: 父区块的 excessBlobGas
+//   - parentBlobGasUsed: 父区块的 blobGasUsed
+//   - config: blob 参数（nil 使用 params.DefaultPragueBlobConfig）
+//
+// 返回：
+//   - uint64: 下一个区块的 excessBlobGas
+func CalcNextExcessBlobGas(parentExcessBlobGas, parentBlobGasUsed uint64, config *params.BlobConfig) uint64 {
+	if config == nil {
+		config = params.DefaultPragueBlobConfig
+	}
+	target := uint64(config.Target) * params.BlobTxBlobGasPerBlob
+	if parentExcessBlobGas+parentBlobGasUsed < target {
+		return 0
+	}
+	return parentExcessBlobGas + parentBlobGasUsed - target
+}
+
+// fakeExponential 计算 factor * e^(numerator/denominator) 的整数近似（EIP-4844）
+func fakeExponential(factor, numerator, denominator *big.Int) *big.Int {
+	output := new(big.Int)
+	accum := new(big.Int).Mul(factor, denominator)
+	for i := int64(1); accum.Sign() > 0; i++ {
+		output.Add(output, accum)
+		accum.Mul(accum, numerator)
+		accum.Div(accum, new(big.Int).Mul(denominator, big.NewInt(i)))
+	}
+	return output.Div(output, denominator)
+}
+
+// GetBlobBaseFee 获取节点报告的当前 blob 基础费用（eth_blobBaseFee）
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//
+// 返回：
+//   - *big.Int: 下一个区块每单位 blob gas 的基础费用（单位为 Wei）
+//   - error: 如果节点不支持或查询失败则返回错误
+func GetBlobBaseFee(ctx context.Context, ep EtherProvider) (*big.Int, error) {
+	fee, err := ep.GetEthClient().BlobBaseFee(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob base fee: %w", err)
+	}
+	return fee, nil
+}
+
+// BlobFeeEstimate blob 费用估算结果（与执行 gas 费用分开计算）
+type BlobFeeEstimate struct {
+	Block          uint64   // 估算所基于的最新区块号
+	ExcessBlobGas  uint64   // 下一个区块的 excessBlobGas
+	BlobBaseFee    *big.Int // 下一个区块的 blob 基础费用（单位为 Wei）
+	MaxBlobBaseFee *big.Int // 之后每个区块都满载 blob 时，blocksAhead 个区块后的 blob 基础费用（建议作为 maxFeePerBlobGas）
+	BlobGas        uint64   // 交易使用的 blob gas（blob 数量 * 131072）
+	Cost           *big.Int // 按 BlobBaseFee 计算的 blob 费用（单位为 Wei）
+	MaxCost        *big.Int // 按 MaxBlobBaseFee 计算的最大 blob 费用（单位为 Wei）
+}
+
+// BlobFeeTracker 根据最新区块头的 excessBlobGas 和 blobGasUsed 预测 blob 费用
+// 用于 EIP-4844 交易发送方在执行费用之外单独规划 blob 费用
+type BlobFeeTracker struct {
+	ep     EtherProvider
+	config *params.BlobConfig
+}
+
+// NewBlobFeeTracker 创建 blob 费用跟踪器
+// 参数说明：
+//   - ep: 以太坊提供者
+//   - config: 链的 blob 参数（nil 使用 params.DefaultPragueBlobConfig，其他链或分叉传入对应参数）
+//
+// 返回：
+//   - *BlobFeeTracker: blob 费用跟踪器
+func NewBlobFeeTracker(ep EtherProvider, config *params.BlobConfig) *BlobFeeTracker {
+	if config == nil {
+		config = params.DefaultPragueBlobConfig
+	}
+	return &BlobFeeTracker{ep: ep, config: config}
+}
+
+// Estimate 估算发送指定数量 blob 的费用
+// 参数说明：
+//   - ctx: 上下文对象
+//   - blobs: 交易携带的 blob 数量
+//   - blocksAhead: 交易可能等待的区块数（用于计算最坏情况下的费用上限，0 表示只考虑下一个区块）
+//
+// 返回：
+//   - *BlobFeeEstimate: 费用估算结果
+//   - error: 如果查询失败或链尚未启用 Cancun 则返回错误
+//
+// 使用示例：
+//
+//	estimate, err := NewBlobFeeTracker(provider, nil).Estimate(ctx, 2, 5)
+//	tx := &types.BlobTx{BlobFeeCap: uint256.MustFromBig(estimate.MaxBlobBaseFee), ...}
+func (t *BlobFeeTracker) Estimate(ctx context.Context, blobs, blocksAhead int) (*BlobFeeEstimate, error) {
+	if blobs < 0 || blocksAhead < 0 {
+		return nil, errors.New("blobs and blocksAhead must not be negative")
+	}
+	header, err := providerExt[BlockRefReader](t.ep).GetHeaderAt(ctx, BlockAtTag(BlockTagLatest))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest header: %w", err)
+	}
+	if header.ExcessBlobGas == nil || header.BlobGasUsed == nil {
+		return nil, fmt.Errorf("block %d has no blob gas fields (chain is not on Cancun)", header.Number.Uint64())
+	}
+
+	excess := CalcNextExcessBlobGas(*header.ExcessBlobGas, *header.BlobGasUsed, t.config)
+	worst := excess
+	maxBlobGas := uint64(t.config.Max) * params.BlobTxBlobGasPerBlob
+	for i := 0; i < blocksAhead; i++ {
+		worst = CalcNextExcessBlobGas(worst, maxBlobGas, t.config)
+	}
+
+	blobGas := uint64(blobs) * params.BlobTxBlobGasPerBlob
+	estimate := &BlobFeeEstimate{
+		Block:          header.Number.Uint64(),
+		ExcessBlobGas:  excess,
+		BlobBaseFee:    CalcBlobBaseFee(excess, t.config),
+		MaxBlobBaseFee: CalcBlobBaseFee(worst, t.config),
+		BlobGas:        blobGas,
+	}
+	estimate.Cost = new(big.Int).Mul(estimate.BlobBaseFee, new(big.Int).SetUint64(blobGas))
+	estimate.MaxCost = new(big.Int).Mul(estimate.MaxBlobBaseFee, new(big.Int).SetUint64(blobGas))
+	return estimate, nil
+}
+
+// GetBlobBaseFee 获取节点报告的当前 blob 基础费用
+// 参数说明：
+//   - ctx: 上下文对象
+//
+// 返回：
+//   - *big.Int: 下一个区块每单位 blob gas 的基础费用（单位为 Wei）
+//   - error: 如果节点不支持或查询失败则返回错误
+func (k *Kit) GetBlobBaseFee(ctx context.Context) (*big.Int, error) {
+	return GetBlobBaseFee(ctx, k.EtherProvider)
+}
+
+// EstimateBlobFees 按 params.DefaultPragueBlobConfig 估算发送指定数量 blob 的费用（其他参数使用 NewBlobFeeTracker）
+// 参数说明：
+//   - ctx: 上下文对象
+//   - blobs: 交易携带的 blob 数量
+//   - blocksAhead: 交易可能等待的区块数
+//
+// 返回：
+//   - *BlobFeeEstimate: 费用估算结果
+//   - error: 如果查询失败或链尚未启用 Cancun 则返回错误
+func (k *Kit) EstimateBlobFees(ctx context.Context, blobs, blocksAhead int) (*BlobFeeEstimate, error) {
+	return NewBlobFeeTracker(k.EtherProvider, nil).Estimate(ctx, blobs, blocksAhead)
+}
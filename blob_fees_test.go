@@ -0,0 +1,128 @@
+package etherkit
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestCalcBlobBaseFee(t *testing.T) {
+	// 以 go-ethereum 的实现为基准，主网 Cancun 和 Prague 使用不同的 UpdateFraction
+	tests := []struct {
+		name   string
+		config *params.BlobConfig
+		time   uint64
+	}{
+		{name: "cancun", config: params.DefaultCancunBlobConfig, time: *params.MainnetChainConfig.CancunTime},
+		{name: "prague", config: params.DefaultPragueBlobConfig, time: *params.MainnetChainConfig.PragueTime},
+	}
+	excesses := []uint64{0, 1, 131072, 3338477, 10 * 3338477, 50000000}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, excess := range excesses {
+				header := &types.Header{Time: tt.time, ExcessBlobGas: &excess}
+				want := eip4844.CalcBlobFee(params.MainnetChainConfig, header)
+				if got := CalcBlobBaseFee(excess, tt.config); got.Cmp(want) != 0 {
+					t.Errorf("CalcBlobBaseFee(%d) = %s, expected %s", excess, got, want)
+				}
+			}
+		})
+	}
+
+	if got := CalcBlobBaseFee(0, nil); got.Int64() != 1 {
+		t.Errorf("CalcBlobBaseFee(0) = %s, expected minimum fee 1", got)
+	}
+}
+
+func TestCalcNextExcessBlobGas(t *testing.T) {
+	config := params.DefaultPragueBlobConfig // target 6, max 9
+	blob := uint64(params.BlobTxBlobGasPerBlob)
+
+	tests := []struct {
+		name   string
+		excess uint64
+		used   uint64
+		want   uint64
+	}{
+		{name: "below target", excess: 0, used: 2 * blob, want: 0},
+		{name: "drains excess", excess: 3 * blob, used: 0, want: 0},
+		{name: "at target", excess: 5 * blob, used: 6 * blob, want: 5 * blob},
+		{name: "full block", excess: 5 * blob, used: 9 * blob, want: 8 * blob},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CalcNextExcessBlobGas(tt.excess, tt.used, config); got != tt.want {
+				t.Errorf("CalcNextExcessBlobGas() = %d, expected %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBlobFeeTracker(t *testing.T) {
+	blob := uint64(params.BlobTxBlobGasPerBlob)
+	excess, used := 20*blob, 9*blob
+
+	tests := []struct {
+		name        string
+		header      *types.Header
+		blobs       int
+		blocksAhead int
+		wantErr     bool
+	}{
+		{name: "next block", header: &types.Header{Number: big.NewInt(100), Difficulty: big.NewInt(0), ExcessBlobGas: &excess, BlobGasUsed: &used}, blobs: 2},
+		{name: "worst case ahead", header: &types.Header{Number: big.NewInt(100), Difficulty: big.NewInt(0), ExcessBlobGas: &excess, BlobGasUsed: &used}, blobs: 2, blocksAhead: 5},
+		{name: "pre-cancun", header: &types.Header{Number: big.NewInt(100), Difficulty: big.NewInt(0)}, blobs: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newMockRPCServer(t, map[string]mockRPCHandler{
+				"eth_chainId":          mockResult("0x1"),
+				"eth_getBlockByNumber": mockResult(tt.header),
+				"eth_blobBaseFee":      mockResult("0x3b9aca00"),
+			})
+			kit := newMockKit(t, server)
+
+			estimate, err := kit.EstimateBlobFees(context.Background(), tt.blobs, tt.blocksAhead)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("EstimateBlobFees() expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("EstimateBlobFees() failed: %v", err)
+			}
+			// 父区块满载（9 个 blob），下一个区块的 excess 增加 3 个 blob
+			if estimate.ExcessBlobGas != 23*blob {
+				t.Errorf("ExcessBlobGas = %d, expected %d", estimate.ExcessBlobGas, 23*blob)
+			}
+			if want := CalcBlobBaseFee(23*blob, nil); estimate.BlobBaseFee.Cmp(want) != 0 {
+				t.Errorf("BlobBaseFee = %s, expected %s", estimate.BlobBaseFee, want)
+			}
+			if want := CalcBlobBaseFee(23*blob+uint64(3*tt.blocksAhead)*blob, nil); estimate.MaxBlobBaseFee.Cmp(want) != 0 {
+				t.Errorf("MaxBlobBaseFee = %s, expected %s", estimate.MaxBlobBaseFee, want)
+			}
+			if estimate.BlobGas != uint64(tt.blobs)*blob {
+				t.Errorf("BlobGas = %d, expected %d", estimate.BlobGas, uint64(tt.blobs)*blob)
+			}
+			if want := new(big.Int).Mul(estimate.MaxBlobBaseFee, new(big.Int).SetUint64(estimate.BlobGas)); estimate.MaxCost.Cmp(want) != 0 {
+				t.Errorf("MaxCost = %s, expected %s", estimate.MaxCost, want)
+			}
+		})
+	}
+
+	server := newMockRPCServer(t, map[string]mockRPCHandler{
+		"eth_chainId":     mockResult("0x1"),
+		"eth_blobBaseFee": mockResult("0x3b9aca00"),
+	})
+	if fee, err := newMockKit(t, server).GetBlobBaseFee(context.Background()); err != nil || fee.Int64() != 1e9 {
+		t.Errorf("GetBlobBaseFee() = %v, %v", fee, err)
+	}
+}
@@ -0,0 +1,159 @@
+package etherkit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+//############ Block ############
+
+// BlockTag 区块标签，用于选择不同的最终性保证
+type BlockTag string
+
+// 区块标签
+const (
+	BlockTagLatest    BlockTag = "latest"    // 最新区块（可能被重组）
+	BlockTagSafe      BlockTag = "safe"      // 安全区块（已获得 2/3 验证者证明，重组概率极低）
+	BlockTagFinalized BlockTag = "finalized" // 已最终确定的区块（不可被重组）
+	BlockTagPending   BlockTag = "pending"   // 待打包区块（包含 mempool 中的交易）
+	BlockTagEarliest  BlockTag = "earliest"  // 创世区块
+)
+
+// BlockNumber 将区块标签转换为 *big.Int 形式
+// 返回的负数与 go-ethereum 的约定一致，可以直接传给所有接受 *big.Int 区块号的方法
+// （如 GetBlockByNumber、StaticCall、FilterLogs 的 fromBlock/toBlock）
+//
+// 示例：
+//   - kit.GetBlockByNumber(ctx, BlockTagFinalized.BlockNumber())
+//   - kit.StaticCall(ctx, addr, abi, "balanceOf", BlockTagSafe.BlockNumber(), nil, nil, user)
+func (t BlockTag) BlockNumber() *big.Int {
+	switch t {
+	case BlockTagSafe:
+		return big.NewInt(int64(rpc.SafeBlockNumber))
+	case BlockTagFinalized:
+		return big.NewInt(int64(rpc.FinalizedBlockNumber))
+	case BlockTagPending:
+		return big.NewInt(int64(rpc.PendingBlockNumber))
+	case BlockTagEarliest:
+		return big.NewInt(int64(rpc.EarliestBlockNumber))
+	default:
+		return big.NewInt(int64(rpc.LatestBlockNumber))
+	}
+}
+
+// BlockRef 区块引用，可以是区块号、区块标签或区块哈希（EIP-1898）
+// 零值表示最新区块（latest）
+type BlockRef struct {
+	Number           *big.Int     // 区块号（负数表示区块标签，nil 表示 latest）
+	Hash             *common.Hash // 区块哈希（设置后优先于 Number）
+	RequireCanonical bool         // 使用区块哈希时，是否要求该区块必须在主链上
+}
+
+// BlockAtNumber 创建指向指定区块号的区块引用
+func BlockAtNumber(number uint64) BlockRef {
+	return BlockRef{Number: new(big.Int).SetUint64(number)}
+}
+
+// BlockAtTag 创建指向区块标签的区块引用（如 BlockTagFinalized）
+func BlockAtTag(tag BlockTag) BlockRef {
+	return BlockRef{Number: tag.BlockNumber()}
+}
+
+// BlockAtHash 创建指向指定区块哈希的区块引用（EIP-1898）
+// 参数说明：
+//   - hash: 区块哈希
+//   - requireCanonical: 是否要求该区块必须在主链上（被重组掉的区块会返回错误）
+func BlockAtHash(hash common.Hash, requireCanonical bool) BlockRef {
+	return BlockRef{Hash: &hash, RequireCanonical: requireCanonical}
+}
+
+// IsHash 判断区块引用是否为区块哈希
+func (r BlockRef) IsHash() bool {
+	return r.Hash != nil
+}
+
+// String 返回区块引用的可读形式（区块哈希、十六进制区块号或区块标签）
+func (r BlockRef) String() string {
+	if r.Hash != nil {
+		return r.Hash.Hex()
+	}
+	return toBlockNumArg(r.Number)
+}
+
+// rpcArg 返回区块引用对应的 JSON-RPC 参数
+// 区块号和标签使用字符串形式，区块哈希使用 EIP-1898 的对象形式
+func (r BlockRef) rpcArg() interface{} {
+	if r.Hash != nil {
+		return rpc.BlockNumberOrHashWithHash(*r.Hash, r.RequireCanonical)
+	}
+	return toBlockNumArg(r.Number)
+}
+
+// toBlockNumArg 将 *big.Int 区块号转换为 JSON-RPC 参数（nil 为 latest，负数为区块标签）
+func toBlockNumArg(number *big.Int) string {
+	if number == nil {
+		return string(BlockTagLatest)
+	}
+	if number.Sign() >= 0 {
+		return fmt.Sprintf("0x%x", number)
+	}
+	if number.IsInt64() {
+		return rpc.BlockNumber(number.Int64()).String()
+	}
+	return fmt.Sprintf("<invalid %d>", number)
+}
+
+// StaticCallAt 在指定区块上静态调用合约方法（不花费 gas，不发送交易）
+// 与 StaticCall 相同，但支持区块标签和 EIP-1898 区块哈希
+// 参数说明：
+//   - ctx: 上下文对象
+//   - contractAddress: 合约地址
+//   - contractAbi: 合约 ABI 对象
+//   - functionName: 函数名（如 "balanceOf", "totalSupply"）
+//   - block: 区块引用（区块号、区块标签或区块哈希）
+//   - from: 调用者地址（nil 表示使用 Kit 的地址）
+//   - value: 模拟转账金额（nil 表示不转账）
+//   - params: 函数参数（按函数定义顺序传入）
+//
+// 返回：
+//   - []interface{}: 函数返回值数组（按函数定义顺序）
+//   - error: 如果调用失败则返回错误
+//
+// 使用示例：
+//   - 读取已最终确定的状态：StaticCallAt(ctx, token, erc20Abi, "balanceOf", BlockAtTag(BlockTagFinalized), nil, nil, user)
+func (k *Kit) StaticCallAt(ctx context.Context, contractAddress common.Address, contractAbi abi.ABI, functionName string, block BlockRef, from *common.Address, value *big.Int, params ...interface{}) ([]interface{}, error) {
+	if !IsValidAddress(contractAddress) {
+		return nil, errors.New("invalid contract address")
+	}
+	if functionName == "" {
+		return nil, errors.New("function name cannot be empty")
+	}
+
+	inputData, err := BuildContractInputData(contractAbi, functionName, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	callMsg := ethereum.CallMsg{
+		From:  k.GetAddress(),
+		To:    &contractAddress,
+		Data:  inputData,
+		Value: value,
+	}
+	if from != nil {
+		callMsg.From = *from
+	}
+
+	res, err := k.CallContractAt(ctx, callMsg, block)
+	if err != nil {
+		return nil, err
+	}
+	return contractAbi.Unpack(functionName, res)
+}
@@ -0,0 +1,170 @@
+package etherkit
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+//############ Block Range ############
+
+// DefaultBlockFetchConcurrency 区块范围查询的默认并发数
+const DefaultBlockFetchConcurrency = 8
+
+// rangeResult 单个区块号的查询结果
+type rangeResult[T any] struct {
+	value T
+	err   error
+}
+
+// fetchOrdered 使用有限数量的 worker 并发查询 [from, to] 内每个区块号，并按区块号顺序交给 deliver
+// 已完成但未交付的结果最多缓存 2 × concurrency 个，避免慢消费者导致内存无限增长
+func fetchOrdered[T any](ctx context.Context, from, to uint64, concurrency int, fetch func(context.Context, uint64) (T, error), deliver func(T) error) error {
+	if from > to {
+		return fmt.Errorf("invalid block range %d-%d", from, to)
+	}
+	if concurrency <= 0 {
+		concurrency = DefaultBlockFetchConcurrency
+	}
+
+	type job struct {
+		number uint64
+		out    chan rangeResult[T]
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	jobs := make(chan job)
+	pending := make(chan chan rangeResult[T], 2*concurrency)
+
+	var wg sync.WaitGroup
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	// 生产者：按顺序登记结果槽位并分发任务
+	go func() {
+		defer close(jobs)
+		defer close(pending)
+		for n := from; ; n++ {
+			out := make(chan rangeResult[T], 1)
+			select {
+			case pending <- out:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case jobs <- job{number: n, out: out}:
+			case <-ctx.Done():
+				return
+			}
+			if n == to {
+				return
+			}
+		}
+	}()
+
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				value, err := fetch(ctx, j.number)
+				j.out <- rangeResult[T]{value: value, err: err}
+			}
+		}()
+	}
+
+	// 消费者：按区块号顺序交付
+	number := from
+	for out := range pending {
+		var result rangeResult[T]
+		select {
+		case result = <-out:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if result.err != nil {
+			return fmt.Errorf("block %d: %w", number, result.err)
+		}
+		if err := deliver(result.value); err != nil {
+			return err
+		}
+		number++
+	}
+	return ctx.Err()
+}
+
+// StreamBlocks 并发查询 [from, to] 范围内的区块，并按区块号顺序逐个回调
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - from: 起始区块号（包含）
+//   - to: 结束区块号（包含）
+//   - concurrency: 并发数（<= 0 使用 DefaultBlockFetchConcurrency）
+//   - fn: 区块回调（返回错误时停止查询）
+//
+// 返回：
+//   - error: 如果任一区块查询失败或回调返回错误则返回错误
+func StreamBlocks(ctx context.Context, ep EtherProvider, from, to uint64, concurrency int, fn func(*types.Block) error) error {
+	fetch := func(ctx context.Context, n uint64) (*types.Block, error) {
+		return ep.GetBlockByNumber(ctx, new(big.Int).SetUint64(n))
+	}
+	return fetchOrdered(ctx, from, to, concurrency, fetch, fn)
+}
+
+// StreamHeaders 并发查询 [from, to] 范围内的区块头，并按区块号顺序逐个回调（不需要交易时比 StreamBlocks 轻量得多）
+func StreamHeaders(ctx context.Context, ep EtherProvider, from, to uint64, concurrency int, fn func(*types.Header) error) error {
+	fetch := func(ctx context.Context, n uint64) (*types.Header, error) {
+		return ep.GetEthClient().HeaderByNumber(ctx, new(big.Int).SetUint64(n))
+	}
+	return fetchOrdered(ctx, from, to, concurrency, fetch, fn)
+}
+
+// GetBlocksRange 并发查询 [from, to] 范围内的区块
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - from: 起始区块号（包含）
+//   - to: 结束区块号（包含）
+//   - concurrency: 并发数（<= 0 使用 DefaultBlockFetchConcurrency）
+//
+// 返回：
+//   - []*types.Block: 按区块号顺序排列的区块
+//   - error: 如果任一区块查询失败则返回错误
+func GetBlocksRange(ctx context.Context, ep EtherProvider, from, to uint64, concurrency int) ([]*types.Block, error) {
+	var blocks []*types.Block
+	err := StreamBlocks(ctx, ep, from, to, concurrency, func(block *types.Block) error {
+		blocks = append(blocks, block)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
+
+// GetHeadersRange 并发查询 [from, to] 范围内的区块头
+func GetHeadersRange(ctx context.Context, ep EtherProvider, from, to uint64, concurrency int) ([]*types.Header, error) {
+	var headers []*types.Header
+	err := StreamHeaders(ctx, ep, from, to, concurrency, func(header *types.Header) error {
+		headers = append(headers, header)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return headers, nil
+}
+
+// GetBlocksRange 并发查询 [from, to] 范围内的区块（按区块号顺序返回）
+func (k *Kit) GetBlocksRange(ctx context.Context, from, to uint64, concurrency int) ([]*types.Block, error) {
+	return GetBlocksRange(ctx, k.EtherProvider, from, to, concurrency)
+}
+
+// GetHeadersRange 并发查询 [from, to] 范围内的区块头（按区块号顺序返回）
+func (k *Kit) GetHeadersRange(ctx context.Context, from, to uint64, concurrency int) ([]*types.Header, error) {
+	return GetHeadersRange(ctx, k.EtherProvider, from, to, concurrency)
+}
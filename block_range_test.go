@@ -0,0 +1,108 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// newMockBlockServer 按请求的区块号返回空区块（随机延迟以打乱完成顺序），failAt 区块返回错误
+func newMockBlockServer(t *testing.T, failAt uint64) *mockRPCServer {
+	t.Helper()
+	return newMockRPCServer(t, map[string]mockRPCHandler{
+		"eth_getBlockByNumber": func(params []json.RawMessage) (interface{}, error) {
+			var number hexutil.Uint64
+			if err := json.Unmarshal(params[0], &number); err != nil {
+				return nil, err
+			}
+			if uint64(number) == failAt {
+				return nil, errors.New("block unavailable")
+			}
+			time.Sleep(time.Duration(rand.Intn(3)) * time.Millisecond)
+			header := &types.Header{
+				Number:     new(big.Int).SetUint64(uint64(number)),
+				Difficulty: big.NewInt(0),
+				UncleHash:  types.EmptyUncleHash,
+				TxHash:     types.EmptyTxsHash,
+			}
+			raw, err := json.Marshal(header)
+			if err != nil {
+				return nil, err
+			}
+			var block map[string]interface{}
+			if err := json.Unmarshal(raw, &block); err != nil {
+				return nil, err
+			}
+			block["transactions"] = []interface{}{}
+			block["uncles"] = []interface{}{}
+			return block, nil
+		},
+	})
+}
+
+func TestGetBlocksRange(t *testing.T) {
+	server := newMockBlockServer(t, 0)
+	kit := newMockKit(t, server)
+
+	blocks, err := kit.GetBlocksRange(context.Background(), 100, 149, 4)
+	if err != nil {
+		t.Fatalf("GetBlocksRange() failed: %v", err)
+	}
+	if len(blocks) != 50 {
+		t.Fatalf("len(blocks) = %d, expected 50", len(blocks))
+	}
+	for i, block := range blocks {
+		if block.NumberU64() != uint64(100+i) {
+			t.Fatalf("blocks[%d] = %d, expected %d (out of order)", i, block.NumberU64(), 100+i)
+		}
+	}
+
+	headers, err := kit.GetHeadersRange(context.Background(), 7, 7, 0)
+	if err != nil || len(headers) != 1 || headers[0].Number.Uint64() != 7 {
+		t.Errorf("GetHeadersRange() = %v, %v", headers, err)
+	}
+
+	if _, err := kit.GetBlocksRange(context.Background(), 10, 5, 4); err == nil {
+		t.Error("Expected error for inverted range")
+	}
+}
+
+func TestStreamBlocksErrors(t *testing.T) {
+	server := newMockBlockServer(t, 120)
+	kit := newMockKit(t, server)
+
+	// 查询失败时停止，已交付的区块保持顺序
+	var delivered []uint64
+	err := StreamBlocks(context.Background(), kit.EtherProvider, 100, 199, 4, func(block *types.Block) error {
+		delivered = append(delivered, block.NumberU64())
+		return nil
+	})
+	if err == nil {
+		t.Fatal("Expected error for failing block")
+	}
+	if len(delivered) != 20 || delivered[19] != 119 {
+		t.Errorf("delivered %d blocks before failure, expected 20", len(delivered))
+	}
+
+	// 回调返回错误时停止
+	stop := errors.New("stop")
+	err = StreamHeaders(context.Background(), kit.EtherProvider, 1, 1000, 4, func(header *types.Header) error {
+		if header.Number.Uint64() == 5 {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) {
+		t.Errorf("StreamHeaders() error = %v, expected stop", err)
+	}
+	if calls := server.callCount("eth_getBlockByNumber"); calls > 200 {
+		t.Errorf("eth_getBlockByNumber count = %d, expected fetching to stop early", calls)
+	}
+}
@@ -0,0 +1,203 @@
+package etherkit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+//############ Block-Pinned Reads ############
+
+// BlockReader 固定在同一区块上的只读查询（多次查询之间不会跨越区块边界）
+type BlockReader struct {
+	Number *big.Int    // 固定的区块号
+	Hash   common.Hash // 固定区块的哈希
+	ep     EtherProvider
+}
+
+// NewBlockReader 解析区块引用并创建固定在该区块上的查询器
+// 区块标签（如 latest、finalized）只在创建时解析一次，之后所有查询都使用解析出的区块号
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - block: 区块引用（零值表示最新区块）
+//
+// 返回：
+//   - *BlockReader: 区块查询器
+//   - error: 如果查询区块头失败则返回错误
+func NewBlockReader(ctx context.Context, ep EtherProvider, block BlockRef) (*BlockReader, error) {
+	header, err := providerExt[BlockRefReader](ep).GetHeaderAt(ctx, block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve block %s: %w", block, err)
+	}
+	return &BlockReader{Number: header.Number, Hash: header.Hash(), ep: ep}, nil
+}
+
+// AtBlock 创建固定在指定区块上的查询器（用于获取内部一致的多项查询快照）
+// 使用示例：
+//
+//	r, err := kit.AtBlock(ctx, BlockAtTag(BlockTagFinalized))
+//	balance, err := r.BalanceOf(ctx, addr)
+//	out, err := r.StaticCall(ctx, token, erc20Abi, "totalSupply")
+func (k *Kit) AtBlock(ctx context.Context, block BlockRef) (*BlockReader, error) {
+	return NewBlockReader(ctx, k.EtherProvider, block)
+}
+
+// Ref 返回固定区块的区块引用
+func (r *BlockReader) Ref() BlockRef {
+	return BlockRef{Number: r.Number}
+}
+
+// BalanceOf 查询地址在固定区块的本位币余额
+func (r *BlockReader) BalanceOf(ctx context.Context, address common.Address) (*big.Int, error) {
+	return providerExt[BlockRefReader](r.ep).GetBalanceAt(ctx, address, r.Ref())
+}
+
+// CallContract 在固定区块上执行 eth_call
+func (r *BlockReader) CallContract(ctx context.Context, msg ethereum.CallMsg) ([]byte, error) {
+	return providerExt[BlockRefReader](r.ep).CallContractAt(ctx, msg, r.Ref())
+}
+
+// StaticCall 在固定区块上调用合约方法并解码返回值
+// 参数说明：
+//   - ctx: 上下文对象
+//   - contractAddress: 合约地址
+//   - contractAbi: 合约 ABI
+//   - functionName: 函数名
+//   - params: 函数参数
+//
+// 返回：
+//   - []interface{}: 函数返回值
+//   - error: 如果调用或解码失败则返回错误
+func (r *BlockReader) StaticCall(ctx context.Context, contractAddress common.Address, contractAbi abi.ABI, functionName string, params ...interface{}) ([]interface{}, error) {
+	data, err := BuildContractInputData(contractAbi, functionName, params...)
+	if err != nil {
+		return nil, err
+	}
+	res, err := r.CallContract(ctx, ethereum.CallMsg{To: &contractAddress, Data: data})
+	if err != nil {
+		return nil, err
+	}
+	return contractAbi.Unpack(functionName, res)
+}
+
+// StorageAt 查询合约在固定区块的存储槽
+func (r *BlockReader) StorageAt(ctx context.Context, address common.Address, slot common.Hash) (common.Hash, error) {
+	var result hexutil.Bytes
+	if err := r.ep.GetRpcClient().CallContext(ctx, &result, "eth_getStorageAt", address, slot, r.Ref().rpcArg()); err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(result), nil
+}
+
+// NonceAt 查询地址在固定区块的 nonce
+func (r *BlockReader) NonceAt(ctx context.Context, address common.Address) (uint64, error) {
+	var result hexutil.Uint64
+	if err := r.ep.GetRpcClient().CallContext(ctx, &result, "eth_getTransactionCount", address, r.Ref().rpcArg()); err != nil {
+		return 0, err
+	}
+	return uint64(result), nil
+}
+
+// Batch 创建在固定区块上执行的批量查询（所有查询在一次 JSON-RPC 批量请求中发送）
+func (r *BlockReader) Batch() *BatchedReader {
+	return &BatchedReader{reader: r}
+}
+
+// BatchedReader 固定在同一区块上的批量查询
+// 先登记查询及结果的接收位置，再调用 Execute 一次性发送
+type BatchedReader struct {
+	reader   *BlockReader
+	elems    []rpc.BatchElem
+	decoders []func() error
+}
+
+// add 登记一条查询
+func (b *BatchedReader) add(method string, result interface{}, decode func() error, args ...interface{}) *BatchedReader {
+	b.elems = append(b.elems, rpc.BatchElem{Method: method, Args: append(args, b.reader.Ref().rpcArg()), Result: result})
+	b.decoders = append(b.decoders, decode)
+	return b
+}
+
+// Balance 登记余额查询，结果写入 out
+func (b *BatchedReader) Balance(address common.Address, out *big.Int) *BatchedReader {
+	result := new(hexutil.Big)
+	return b.add("eth_getBalance", result, func() error {
+		out.Set((*big.Int)(result))
+		return nil
+	}, address)
+}
+
+// Call 登记 eth_call 查询，返回数据写入 out
+func (b *BatchedReader) Call(msg ethereum.CallMsg, out *[]byte) *BatchedReader {
+	result := new(hexutil.Bytes)
+	return b.add("eth_call", result, func() error {
+		*out = *result
+		return nil
+	}, toCallArg(msg))
+}
+
+// StaticCall 登记合约方法调用，解码后的返回值写入 out
+func (b *BatchedReader) StaticCall(contractAddress common.Address, contractAbi abi.ABI, functionName string, out *[]interface{}, params ...interface{}) *BatchedReader {
+	data, packErr := BuildContractInputData(contractAbi, functionName, params...)
+	result := new(hexutil.Bytes)
+	return b.add("eth_call", result, func() error {
+		if packErr != nil {
+			return packErr
+		}
+		values, err := contractAbi.Unpack(functionName, *result)
+		if err != nil {
+			return err
+		}
+		*out = values
+		return nil
+	}, toCallArg(ethereum.CallMsg{To: &contractAddress, Data: data}))
+}
+
+// StorageAt 登记存储槽查询，结果写入 out
+func (b *BatchedReader) StorageAt(address common.Address, slot common.Hash, out *common.Hash) *BatchedReader {
+	result := new(hexutil.Bytes)
+	return b.add("eth_getStorageAt", result, func() error {
+		*out = common.BytesToHash(*result)
+		return nil
+	}, address, slot)
+}
+
+// Nonce 登记 nonce 查询，结果写入 out
+func (b *BatchedReader) Nonce(address common.Address, out *uint64) *BatchedReader {
+	result := new(hexutil.Uint64)
+	return b.add("eth_getTransactionCount", result, func() error {
+		*out = uint64(*result)
+		return nil
+	}, address)
+}
+
+// Execute 发送所有登记的查询
+// 返回：
+//   - error: 批量请求失败时返回该错误；单条查询失败时返回所有失败查询的合并错误（成功的查询结果仍会写入）
+func (b *BatchedReader) Execute(ctx context.Context) error {
+	if len(b.elems) == 0 {
+		return nil
+	}
+	if err := b.reader.ep.GetRpcClient().BatchCallContext(ctx, b.elems); err != nil {
+		return err
+	}
+	var errs []error
+	for i, elem := range b.elems {
+		if elem.Error != nil {
+			errs = append(errs, fmt.Errorf("%s #%d: %w", elem.Method, i, elem.Error))
+			continue
+		}
+		if err := b.decoders[i](); err != nil {
+			errs = append(errs, fmt.Errorf("%s #%d: %w", elem.Method, i, err))
+		}
+	}
+	return errors.Join(errs...)
+}
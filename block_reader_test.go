@@ -0,0 +1,84 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestBlockReader(t *testing.T) {
+	holder := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	token := common.HexToAddress("0x1c7D4B196Cb0C7B01d743Fbc6116a902379C7238")
+
+	// 记录每个读请求使用的区块参数，全部应为解析出的区块 0x64
+	var mu sync.Mutex
+	var blockArgs []string
+	record := func(handler mockRPCHandler) mockRPCHandler {
+		return func(params []json.RawMessage) (interface{}, error) {
+			mu.Lock()
+			blockArgs = append(blockArgs, strings.Trim(string(params[len(params)-1]), `"`))
+			mu.Unlock()
+			return handler(params)
+		}
+	}
+	server := newMockRPCServer(t, map[string]mockRPCHandler{
+		"eth_getBlockByNumber":    mockResult(&types.Header{Number: big.NewInt(100), Difficulty: big.NewInt(0)}),
+		"eth_getBalance":          record(mockResult("0xde0b6b3a7640000")),
+		"eth_call":                record(mockERC20Call(big.NewInt(2500000), 6, "USDC")),
+		"eth_getStorageAt":        record(mockResult(common.BigToHash(big.NewInt(42)))),
+		"eth_getTransactionCount": record(mockResult("0x7")),
+	})
+	kit := newMockKit(t, server)
+
+	reader, err := kit.AtBlock(context.Background(), BlockAtTag(BlockTagFinalized))
+	if err != nil {
+		t.Fatalf("AtBlock() failed: %v", err)
+	}
+	if reader.Number.Uint64() != 100 {
+		t.Fatalf("reader pinned to block %s, want 100", reader.Number)
+	}
+
+	if balance, err := reader.BalanceOf(context.Background(), holder); err != nil || balance.Cmp(big.NewInt(1e18)) != 0 {
+		t.Errorf("BalanceOf() = %v, %v", balance, err)
+	}
+	if out, err := reader.StaticCall(context.Background(), token, erc20ABI, "balanceOf", holder); err != nil || out[0].(*big.Int).Int64() != 2500000 {
+		t.Errorf("StaticCall() = %v, %v", out, err)
+	}
+	if slot, err := reader.StorageAt(context.Background(), token, common.Hash{}); err != nil || slot.Big().Int64() != 42 {
+		t.Errorf("StorageAt() = %v, %v", slot, err)
+	}
+
+	var (
+		balance  = new(big.Int)
+		tokenOut []interface{}
+		slot     common.Hash
+		nonce    uint64
+	)
+	err = reader.Batch().
+		Balance(holder, balance).
+		StaticCall(token, erc20ABI, "balanceOf", &tokenOut, holder).
+		StorageAt(token, common.Hash{}, &slot).
+		Nonce(holder, &nonce).
+		Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute() failed: %v", err)
+	}
+	if balance.Cmp(big.NewInt(1e18)) != 0 || tokenOut[0].(*big.Int).Int64() != 2500000 || slot.Big().Int64() != 42 || nonce != 7 {
+		t.Errorf("batch results = %s, %v, %s, %d", balance, tokenOut, slot.Hex(), nonce)
+	}
+
+	for _, arg := range blockArgs {
+		if arg != "0x64" {
+			t.Errorf("read used block %s, want 0x64", arg)
+		}
+	}
+	if len(blockArgs) != 7 {
+		t.Errorf("recorded %d reads, want 7", len(blockArgs))
+	}
+}
@@ -0,0 +1,213 @@
+package etherkit
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+//############ Block Rewards ############
+
+// 工作量证明时期的静态区块奖励（单位为 Wei）
+var (
+	frontierBlockReward       = big.NewInt(5e18) // Frontier ~ Byzantium 之前
+	byzantiumBlockReward      = big.NewInt(3e18) // Byzantium ~ Constantinople 之前
+	constantinopleBlockReward = big.NewInt(2e18) // Constantinople ~ 合并之前
+)
+
+// UncleReward 叔块（ommer）矿工获得的奖励
+type UncleReward struct {
+	Number uint64         // 叔块区块号
+	Hash   common.Hash    // 叔块哈希
+	Miner  common.Address // 叔块矿工
+	Reward *big.Int       // 叔块奖励（单位为 Wei）
+}
+
+// BlockReward 区块奖励和手续费的分配
+type BlockReward struct {
+	Number               uint64         // 区块号
+	Hash                 common.Hash    // 区块哈希
+	Miner                common.Address // 矿工 / 手续费接收地址
+	PostMerge            bool           // 是否为合并（The Merge）之后的权益证明区块
+	StaticReward         *big.Int       // 静态区块奖励（合并后为 0）
+	UncleInclusionReward *big.Int       // 矿工打包叔块获得的额外奖励（每个叔块为静态奖励的 1/32）
+	Tips                 *big.Int       // 矿工获得的优先费（伦敦升级之前为全部手续费）
+	BurntFees            *big.Int       // 销毁的基础费用（baseFee × gasUsed，伦敦升级之前为 0）
+	BlobFeesBurnt        *big.Int       // 销毁的 blob 费用（blobBaseFee × blobGasUsed，Cancun 升级之前为 0）
+	Uncles               []UncleReward  // 叔块矿工的奖励
+}
+
+// MinerReward 返回矿工从该区块获得的总收入（静态奖励 + 叔块打包奖励 + 优先费）
+func (r *BlockReward) MinerReward() *big.Int {
+	total := new(big.Int).Add(r.StaticReward, r.UncleInclusionReward)
+	return total.Add(total, r.Tips)
+}
+
+// TotalBurnt 返回该区块销毁的 ETH（基础费用 + blob 费用）
+func (r *BlockReward) TotalBurnt() *big.Int {
+	return new(big.Int).Add(r.BurntFees, r.BlobFeesBurnt)
+}
+
+// StaticBlockReward 返回区块的静态奖励（Frontier 5 ETH、Byzantium 3 ETH、Constantinople 2 ETH，合并后为 0）
+// 参数说明：
+//   - config: 链配置（nil 使用 params.MainnetChainConfig）
+//   - header: 区块头（difficulty 为 0 的区块视为合并后的区块）
+//
+// 返回：
+//   - *big.Int: 静态区块奖励（单位为 Wei）
+func StaticBlockReward(config *params.ChainConfig, header *types.Header) *big.Int {
+	if config == nil {
+		config = params.MainnetChainConfig
+	}
+	switch {
+	case isPostMerge(header):
+		return new(big.Int)
+	case config.IsConstantinople(header.Number):
+		return new(big.Int).Set(constantinopleBlockReward)
+	case config.IsByzantium(header.Number):
+		return new(big.Int).Set(byzantiumBlockReward)
+	default:
+		return new(big.Int).Set(frontierBlockReward)
+	}
+}
+
+// isPostMerge 判断区块是否为合并后的权益证明区块（合并后 difficulty 固定为 0）
+func isPostMerge(header *types.Header) bool {
+	return header.Difficulty == nil || header.Difficulty.Sign() == 0
+}
+
+// CalculateBlockReward 计算区块奖励和手续费的分配（区分合并前后、伦敦升级前后）
+//   - 合并之前：静态奖励 + 每个叔块 1/32 静态奖励的打包奖励；叔块矿工获得 (叔块号 + 8 - 区块号) / 8 的静态奖励
+//   - 伦敦升级之后：基础费用被销毁，矿工只获得优先费
+//   - Cancun 升级之后：blob 费用被销毁
+//
+// 参数说明：
+//   - block: 区块（需要包含交易和叔块区块头）
+//   - receipts: 与区块交易一一对应的收据（用于获取每笔交易实际消耗的 gas）
+//   - config: 链配置（nil 使用 params.MainnetChainConfig）
+//
+// 返回：
+//   - *BlockReward: 区块奖励
+//   - error: 如果收据数量与交易数量不一致则返回错误
+func CalculateBlockReward(block *types.Block, receipts []*types.Receipt, config *params.ChainConfig) (*BlockReward, error) {
+	if config == nil {
+		config = params.MainnetChainConfig
+	}
+	txs := block.Transactions()
+	if len(receipts) != len(txs) {
+		return nil, fmt.Errorf("block %d has %d transactions but %d receipts", block.NumberU64(), len(txs), len(receipts))
+	}
+	header := block.Header()
+	reward := &BlockReward{
+		Number:               block.NumberU64(),
+		Hash:                 block.Hash(),
+		Miner:                header.Coinbase,
+		PostMerge:            isPostMerge(header),
+		StaticReward:         StaticBlockReward(config, header),
+		UncleInclusionReward: new(big.Int),
+		Tips:                 new(big.Int),
+		BurntFees:            new(big.Int),
+		BlobFeesBurnt:        new(big.Int),
+	}
+
+	if !reward.PostMerge {
+		inclusion := new(big.Int).Rsh(reward.StaticReward, 5)
+		for _, uncle := range block.Uncles() {
+			// (uncleNumber + 8 - blockNumber) * blockReward / 8
+			r := new(big.Int).Add(uncle.Number, big.NewInt(8))
+			r.Sub(r, header.Number)
+			r.Mul(r, reward.StaticReward)
+			r.Rsh(r, 3)
+			reward.Uncles = append(reward.Uncles, UncleReward{Number: uncle.Number.Uint64(), Hash: uncle.Hash(), Miner: uncle.Coinbase, Reward: r})
+			reward.UncleInclusionReward.Add(reward.UncleInclusionReward, inclusion)
+		}
+	}
+
+	for i, tx := range txs {
+		if receipts[i] == nil {
+			return nil, fmt.Errorf("missing receipt for transaction %s", tx.Hash().Hex())
+		}
+		tip := tx.GasPrice()
+		if header.BaseFee != nil {
+			var err error
+			if tip, err = tx.EffectiveGasTip(header.BaseFee); err != nil {
+				return nil, fmt.Errorf("transaction %s: %w", tx.Hash().Hex(), err)
+			}
+		}
+		reward.Tips.Add(reward.Tips, new(big.Int).Mul(tip, new(big.Int).SetUint64(receipts[i].GasUsed)))
+	}
+	if header.BaseFee != nil {
+		reward.BurntFees.Mul(header.BaseFee, new(big.Int).SetUint64(header.GasUsed))
+	}
+	if header.ExcessBlobGas != nil && header.BlobGasUsed != nil && *header.BlobGasUsed > 0 {
+		blobBaseFee := CalcBlobBaseFee(*header.ExcessBlobGas, nil)
+		if eip4844.MaxBlobsPerBlock(config, header.Time) > 0 {
+			blobBaseFee = eip4844.CalcBlobFee(config, header)
+		}
+		reward.BlobFeesBurnt.Mul(blobBaseFee, new(big.Int).SetUint64(*header.BlobGasUsed))
+	}
+	return reward, nil
+}
+
+// knownChainConfigs 内置的链配置（按链 ID 索引）
+var knownChainConfigs = map[uint64]*params.ChainConfig{
+	params.MainnetChainConfig.ChainID.Uint64(): params.MainnetChainConfig,
+	params.SepoliaChainConfig.ChainID.Uint64(): params.SepoliaChainConfig,
+	params.HoleskyChainConfig.ChainID.Uint64(): params.HoleskyChainConfig,
+	params.HoodiChainConfig.ChainID.Uint64():   params.HoodiChainConfig,
+}
+
+// GetBlockReward 查询区块、交易收据和叔块并计算区块奖励
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - block: 区块引用（区块号、区块标签或区块哈希）
+//   - config: 链配置（nil 时按链 ID 选择内置配置，未知的链使用 params.MainnetChainConfig）
+//
+// 返回：
+//   - *BlockReward: 区块奖励
+//   - error: 如果查询失败则返回错误
+//
+// 使用示例：
+//
+//	reward, err := GetBlockReward(ctx, provider, BlockAtNumber(12000000), nil)
+//	fmt.Println(ToDecimal(reward.MinerReward(), EthDecimals), len(reward.Uncles))
+func GetBlockReward(ctx context.Context, ep EtherProvider, block BlockRef, config *params.ChainConfig) (*BlockReward, error) {
+	if config == nil {
+		chainId, err := ep.GetChainID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		config = knownChainConfigs[chainId.Uint64()]
+	}
+	b, err := providerExt[BlockRefReader](ep).GetBlockAt(ctx, block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block: %w", err)
+	}
+	refs := make([]ReceiptRef, len(b.Transactions()))
+	for i, tx := range b.Transactions() {
+		refs[i] = ReceiptRef{TxHash: tx.Hash(), BlockNumber: b.NumberU64()}
+	}
+	receipts, err := GetReceiptsByRef(ctx, ep, refs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get receipts of block %d: %w", b.NumberU64(), err)
+	}
+	return CalculateBlockReward(b, receipts, config)
+}
+
+// GetBlockReward 查询并计算区块奖励（按链 ID 选择内置的链配置）
+// 参数说明：
+//   - ctx: 上下文对象
+//   - block: 区块引用（区块号、区块标签或区块哈希）
+//
+// 返回：
+//   - *BlockReward: 区块奖励
+//   - error: 如果查询失败则返回错误
+func (k *Kit) GetBlockReward(ctx context.Context, block BlockRef) (*BlockReward, error) {
+	return GetBlockReward(ctx, k.EtherProvider, block, nil)
+}
@@ -0,0 +1,212 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+func TestStaticBlockReward(t *testing.T) {
+	tests := []struct {
+		name       string
+		number     int64
+		difficulty int64
+		want       *big.Int
+	}{
+		{name: "frontier", number: 1, difficulty: 1, want: big.NewInt(5e18)},
+		{name: "byzantium", number: 4370000, difficulty: 1, want: big.NewInt(3e18)},
+		{name: "constantinople", number: 7280000, difficulty: 1, want: big.NewInt(2e18)},
+		{name: "post-merge", number: 15537394, difficulty: 0, want: big.NewInt(0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := &types.Header{Number: big.NewInt(tt.number), Difficulty: big.NewInt(tt.difficulty)}
+			if got := StaticBlockReward(nil, header); got.Cmp(tt.want) != 0 {
+				t.Errorf("StaticBlockReward() = %s, expected %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalculateBlockReward(t *testing.T) {
+	account, _ := GetTestAccount(0)
+	signer := types.LatestSignerForChainID(big.NewInt(1))
+	to := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	miner := common.HexToAddress("0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC")
+	uncleMiner := common.HexToAddress("0x90F79bf6EB2c4f870365E785982E1f101E93b906")
+	gwei := func(n int64) *big.Int { return new(big.Int).Mul(big.NewInt(n), big.NewInt(1e9)) }
+	receipt := func(gasUsed uint64) *types.Receipt { return &types.Receipt{GasUsed: gasUsed} }
+
+	legacy := types.MustSignNewTx(account.PrivateKey, signer, &types.LegacyTx{Nonce: 0, Gas: 21000, GasPrice: gwei(11), To: &to})
+	dynamic := types.MustSignNewTx(account.PrivateKey, signer, &types.DynamicFeeTx{ChainID: big.NewInt(1), Nonce: 1, Gas: 60000, GasFeeCap: gwei(30), GasTipCap: gwei(2), To: &to})
+
+	// Constantinople 之后、伦敦之前的工作量证明区块，包含两个叔块
+	powHeader := &types.Header{Number: big.NewInt(8000000), Difficulty: big.NewInt(1), Coinbase: miner, GasUsed: 21000}
+	uncles := []*types.Header{
+		{Number: big.NewInt(7999999), Difficulty: big.NewInt(1), Coinbase: uncleMiner},
+		{Number: big.NewInt(7999998), Difficulty: big.NewInt(1), Coinbase: uncleMiner},
+	}
+	powBlock := types.NewBlock(powHeader, &types.Body{Transactions: types.Transactions{legacy}, Uncles: uncles}, nil, trie.NewStackTrie(nil))
+
+	// Prague 之后的权益证明区块，基础费用 10 gwei，包含 blob
+	excess, blobUsed := uint64(10000000), uint64(2*params.BlobTxBlobGasPerBlob)
+	posHeader := &types.Header{
+		Number: big.NewInt(22500000), Difficulty: big.NewInt(0), Coinbase: miner, Time: 1750000000,
+		BaseFee: gwei(10), GasUsed: 71000, ExcessBlobGas: &excess, BlobGasUsed: &blobUsed,
+	}
+	posBlock := types.NewBlock(posHeader, &types.Body{Transactions: types.Transactions{legacy, dynamic}}, nil, trie.NewStackTrie(nil))
+
+	tests := []struct {
+		name          string
+		block         *types.Block
+		receipts      []*types.Receipt
+		wantStatic    *big.Int
+		wantInclusion *big.Int
+		wantTips      *big.Int
+		wantBurnt     *big.Int
+		wantBlobBurnt *big.Int
+		wantUncles    []*big.Int
+	}{
+		{
+			name: "proof of work with uncles", block: powBlock, receipts: []*types.Receipt{receipt(21000)},
+			wantStatic:    big.NewInt(2e18),
+			wantInclusion: big.NewInt(2 * 2e18 / 32),
+			wantTips:      new(big.Int).Mul(gwei(11), big.NewInt(21000)),
+			wantBurnt:     big.NewInt(0),
+			wantBlobBurnt: big.NewInt(0),
+			wantUncles:    []*big.Int{big.NewInt(7 * 2e18 / 8), big.NewInt(6 * 2e18 / 8)},
+		},
+		{
+			name: "proof of stake with blobs", block: posBlock, receipts: []*types.Receipt{receipt(21000), receipt(50000)},
+			wantStatic:    big.NewInt(0),
+			wantInclusion: big.NewInt(0),
+			// legacy 交易优先费为 gasPrice - baseFee = 1 gwei，动态费用交易为 2 gwei
+			wantTips:      new(big.Int).Add(new(big.Int).Mul(gwei(1), big.NewInt(21000)), new(big.Int).Mul(gwei(2), big.NewInt(50000))),
+			wantBurnt:     new(big.Int).Mul(gwei(10), big.NewInt(71000)),
+			wantBlobBurnt: new(big.Int).Mul(CalcBlobBaseFee(excess, params.DefaultPragueBlobConfig), new(big.Int).SetUint64(blobUsed)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reward, err := CalculateBlockReward(tt.block, tt.receipts, nil)
+			if err != nil {
+				t.Fatalf("CalculateBlockReward() failed: %v", err)
+			}
+			checks := []struct {
+				field     string
+				got, want *big.Int
+			}{
+				{"StaticReward", reward.StaticReward, tt.wantStatic},
+				{"UncleInclusionReward", reward.UncleInclusionReward, tt.wantInclusion},
+				{"Tips", reward.Tips, tt.wantTips},
+				{"BurntFees", reward.BurntFees, tt.wantBurnt},
+				{"BlobFeesBurnt", reward.BlobFeesBurnt, tt.wantBlobBurnt},
+			}
+			for _, c := range checks {
+				if c.got.Cmp(c.want) != 0 {
+					t.Errorf("%s = %s, expected %s", c.field, c.got, c.want)
+				}
+			}
+			if len(reward.Uncles) != len(tt.wantUncles) {
+				t.Fatalf("got %d uncle rewards, expected %d", len(reward.Uncles), len(tt.wantUncles))
+			}
+			for i, want := range tt.wantUncles {
+				if reward.Uncles[i].Reward.Cmp(want) != 0 || reward.Uncles[i].Miner != uncleMiner {
+					t.Errorf("uncle %d reward = %s, expected %s", i, reward.Uncles[i].Reward, want)
+				}
+			}
+			wantMiner := new(big.Int).Add(tt.wantStatic, tt.wantInclusion)
+			if reward.MinerReward().Cmp(wantMiner.Add(wantMiner, tt.wantTips)) != 0 || reward.Miner != miner {
+				t.Errorf("MinerReward() = %s, expected %s", reward.MinerReward(), wantMiner)
+			}
+		})
+	}
+
+	if _, err := CalculateBlockReward(posBlock, []*types.Receipt{receipt(21000)}, nil); err == nil {
+		t.Error("CalculateBlockReward() with missing receipts expected error")
+	}
+}
+
+func TestGetUncleInBlock(t *testing.T) {
+	blockHash := common.HexToHash("0x1234")
+	uncles := []*types.Header{
+		{Number: big.NewInt(99), Difficulty: big.NewInt(1), Coinbase: common.HexToAddress("0x01")},
+	}
+	// 区块 100（哈希 blockHash）包含一个叔块，其他区块不存在
+	known := func(raw json.RawMessage) bool {
+		var ref string
+		_ = json.Unmarshal(raw, &ref)
+		return ref == "0x64" || common.HexToHash(ref) == blockHash
+	}
+	byIndex := func(params []json.RawMessage) (interface{}, error) {
+		var index hexutil.Uint64
+		_ = json.Unmarshal(params[1], &index)
+		if !known(params[0]) || int(index) >= len(uncles) {
+			return nil, nil
+		}
+		return uncles[index], nil
+	}
+	count := func(params []json.RawMessage) (interface{}, error) {
+		if !known(params[0]) {
+			return nil, nil
+		}
+		return hexutil.Uint(len(uncles)), nil
+	}
+	server := newMockRPCServer(t, map[string]mockRPCHandler{
+		"eth_getUncleByBlockNumberAndIndex": byIndex,
+		"eth_getUncleByBlockHashAndIndex":   byIndex,
+		"eth_getUncleCountByBlockNumber":    count,
+		"eth_getUncleCountByBlockHash":      count,
+	})
+	provider, err := NewProviderWithChainId(server.URL, 1)
+	if err != nil {
+		t.Fatalf("NewProviderWithChainId() failed: %v", err)
+	}
+	defer provider.Close()
+
+	tests := []struct {
+		name     string
+		block    BlockRef
+		index    uint
+		wantNone bool
+	}{
+		{name: "by number", block: BlockAtNumber(100), index: 0},
+		{name: "by hash", block: BlockAtHash(blockHash, false), index: 0},
+		{name: "index out of range", block: BlockAtNumber(100), index: 1, wantNone: true},
+		{name: "unknown block", block: BlockAtNumber(101), index: 0, wantNone: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uncle, err := provider.GetUncleInBlock(context.Background(), tt.block, tt.index)
+			if tt.wantNone {
+				if !errors.Is(err, ethereum.NotFound) {
+					t.Fatalf("GetUncleInBlock() error = %v, want ethereum.NotFound", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetUncleInBlock() failed: %v", err)
+			}
+			if uncle.Hash() != uncles[tt.index].Hash() {
+				t.Errorf("GetUncleInBlock() = %s, expected %s", uncle.Hash(), uncles[tt.index].Hash())
+			}
+			n, err := provider.GetUncleCountInBlock(context.Background(), tt.block)
+			if err != nil || n != uint(len(uncles)) {
+				t.Errorf("GetUncleCountInBlock() = %d, %v, expected %d", n, err, len(uncles))
+			}
+		})
+	}
+	if _, err := provider.GetUncleCountInBlock(context.Background(), BlockAtNumber(101)); !errors.Is(err, ethereum.NotFound) {
+		t.Errorf("GetUncleCountInBlock() of unknown block error = %v, want ethereum.NotFound", err)
+	}
+}
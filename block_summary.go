@@ -0,0 +1,154 @@
+package etherkit
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+//############ Block Summary ############
+
+// BlockSummary 区块的摘要信息，包括 Shanghai 之后的提款和 Cancun 之后的 blob 字段
+// 升级前的区块中对应字段为 nil，可以用 IsShanghai / IsCancun 判断
+type BlockSummary struct {
+	Number     uint64         // 区块号
+	Hash       common.Hash    // 区块哈希
+	ParentHash common.Hash    // 父区块哈希
+	Time       uint64         // 区块时间戳（秒）
+	Miner      common.Address // 出块者（手续费接收地址）
+	GasUsed    uint64         // 已使用的 gas
+	GasLimit   uint64         // 区块 gas 上限
+	BaseFee    *big.Int       // EIP-1559 基础费用（London 之前为 nil）
+	TxCount    int            // 交易数量
+
+	WithdrawalsRoot *common.Hash      // 提款列表根哈希（Shanghai 之前为 nil）
+	Withdrawals     types.Withdrawals // 验证者提款（Shanghai 之前为 nil）
+
+	BlobGasUsed      *uint64      // 区块中 blob 交易使用的 blob gas（Cancun 之前为 nil）
+	ExcessBlobGas    *uint64      // 累计超出目标的 blob gas，决定下一个区块的 blob 基础费用（Cancun 之前为 nil）
+	ParentBeaconRoot *common.Hash // 父信标区块根（EIP-4788，Cancun 之前为 nil）
+}
+
+// SummarizeBlock 从区块中提取摘要信息
+// 参数说明：
+//   - block: 区块对象
+//
+// 返回：
+//   - *BlockSummary: 区块摘要
+func SummarizeBlock(block *types.Block) *BlockSummary {
+	header := block.Header()
+	return &BlockSummary{
+		Number:           header.Number.Uint64(),
+		Hash:             block.Hash(),
+		ParentHash:       header.ParentHash,
+		Time:             header.Time,
+		Miner:            header.Coinbase,
+		GasUsed:          header.GasUsed,
+		GasLimit:         header.GasLimit,
+		BaseFee:          header.BaseFee,
+		TxCount:          len(block.Transactions()),
+		WithdrawalsRoot:  header.WithdrawalsHash,
+		Withdrawals:      block.Withdrawals(),
+		BlobGasUsed:      header.BlobGasUsed,
+		ExcessBlobGas:    header.ExcessBlobGas,
+		ParentBeaconRoot: header.ParentBeaconRoot,
+	}
+}
+
+// IsShanghai 判断区块是否包含 Shanghai 升级引入的提款字段
+func (s *BlockSummary) IsShanghai() bool {
+	return s.WithdrawalsRoot != nil
+}
+
+// IsCancun 判断区块是否包含 Cancun 升级引入的 blob 字段
+func (s *BlockSummary) IsCancun() bool {
+	return s.BlobGasUsed != nil && s.ExcessBlobGas != nil
+}
+
+// WithdrawalsTotal 返回区块中所有提款的总额（单位为 Wei，提款金额在链上以 Gwei 记录）
+func (s *BlockSummary) WithdrawalsTotal() *big.Int {
+	total := new(big.Int)
+	for _, w := range s.Withdrawals {
+		total.Add(total, new(big.Int).SetUint64(w.Amount))
+	}
+	return total.Mul(total, big.NewInt(params.GWei))
+}
+
+// WithdrawalsTo 返回区块中所有提款到指定地址的总额（单位为 Wei）
+func (s *BlockSummary) WithdrawalsTo(address common.Address) *big.Int {
+	total := new(big.Int)
+	for _, w := range s.Withdrawals {
+		if w.Address == address {
+			total.Add(total, new(big.Int).SetUint64(w.Amount))
+		}
+	}
+	return total.Mul(total, big.NewInt(params.GWei))
+}
+
+// BlobCount 返回区块中包含的 blob 数量（Cancun 之前为 0）
+func (s *BlockSummary) BlobCount() uint64 {
+	if s.BlobGasUsed == nil {
+		return 0
+	}
+	return *s.BlobGasUsed / params.BlobTxBlobGasPerBlob
+}
+
+// String 返回区块的多行可读摘要
+func (s *BlockSummary) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "block:       %d (%s)\n", s.Number, s.Hash.Hex())
+	fmt.Fprintf(&sb, "time:        %s\n", time.Unix(int64(s.Time), 0).UTC().Format(time.RFC3339))
+	fmt.Fprintf(&sb, "miner:       %s\n", s.Miner.Hex())
+	fmt.Fprintf(&sb, "txs:         %d\n", s.TxCount)
+	fmt.Fprintf(&sb, "gas:         %d / %d\n", s.GasUsed, s.GasLimit)
+	if s.BaseFee != nil {
+		fmt.Fprintf(&sb, "base fee:    %s gwei\n", formatWei(s.BaseFee, 9))
+	}
+	if s.IsShanghai() {
+		fmt.Fprintf(&sb, "withdrawals: %d (%s ETH)\n", len(s.Withdrawals), formatWei(s.WithdrawalsTotal(), EthDecimals))
+	}
+	if s.IsCancun() {
+		fmt.Fprintf(&sb, "blobs:       %d (blob gas used %d, excess %d)\n", s.BlobCount(), *s.BlobGasUsed, *s.ExcessBlobGas)
+	}
+	return sb.String()
+}
+
+// GetBlockSummary 获取区块并生成摘要
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - block: 区块引用（区块号、区块标签或区块哈希）
+//
+// 返回：
+//   - *BlockSummary: 区块摘要
+//   - error: 如果查询失败则返回错误
+//
+// 使用示例：
+//
+//	summary, err := GetBlockSummary(ctx, provider, BlockAtTag(BlockTagFinalized))
+//	fmt.Println(summary.WithdrawalsTo(feeRecipient), summary.BlobCount())
+func GetBlockSummary(ctx context.Context, ep EtherProvider, block BlockRef) (*BlockSummary, error) {
+	b, err := providerExt[BlockRefReader](ep).GetBlockAt(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+	return SummarizeBlock(b), nil
+}
+
+// GetBlockSummary 获取区块并生成摘要
+// 参数说明：
+//   - ctx: 上下文对象
+//   - block: 区块引用（区块号、区块标签或区块哈希）
+//
+// 返回：
+//   - *BlockSummary: 区块摘要
+//   - error: 如果查询失败则返回错误
+func (k *Kit) GetBlockSummary(ctx context.Context, block BlockRef) (*BlockSummary, error) {
+	return GetBlockSummary(ctx, k.EtherProvider, block)
+}
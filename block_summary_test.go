@@ -0,0 +1,117 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// mockBlockJSON 将区块编码为 eth_getBlockByNumber 的返回格式（包含提款列表）
+func mockBlockJSON(t *testing.T, block *types.Block) map[string]interface{} {
+	t.Helper()
+	raw, err := json.Marshal(block.Header())
+	if err != nil {
+		t.Fatalf("json.Marshal() failed: %v", err)
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal(raw, &result); err != nil {
+		t.Fatalf("json.Unmarshal() failed: %v", err)
+	}
+	result["transactions"] = []interface{}{}
+	result["uncles"] = []interface{}{}
+	if block.Withdrawals() != nil {
+		result["withdrawals"] = block.Withdrawals()
+	}
+	return result
+}
+
+func TestBlockSummary(t *testing.T) {
+	validator := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	other := common.HexToAddress("0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC")
+	withdrawals := types.Withdrawals{
+		{Index: 1, Validator: 10, Address: validator, Amount: 15000000}, // 0.015 ETH
+		{Index: 2, Validator: 11, Address: other, Amount: 5000000},
+		{Index: 3, Validator: 12, Address: validator, Amount: 1000000},
+	}
+	blobGasUsed, excessBlobGas := uint64(3*131072), uint64(786432)
+	beaconRoot := common.HexToHash("0xbeac04")
+
+	tests := []struct {
+		name         string
+		header       *types.Header
+		body         *types.Body
+		wantShanghai bool
+		wantCancun   bool
+		wantBlobs    uint64
+		wantTotal    string
+	}{
+		{
+			name:      "pre-shanghai",
+			header:    &types.Header{Number: big.NewInt(100), Difficulty: big.NewInt(0), BaseFee: big.NewInt(1e9)},
+			body:      &types.Body{},
+			wantTotal: "0",
+		},
+		{
+			name:         "shanghai",
+			header:       &types.Header{Number: big.NewInt(200), Difficulty: big.NewInt(0), BaseFee: big.NewInt(1e9)},
+			body:         &types.Body{Withdrawals: withdrawals},
+			wantShanghai: true,
+			wantTotal:    "21000000000000000",
+		},
+		{
+			name:         "cancun",
+			header:       &types.Header{Number: big.NewInt(300), Difficulty: big.NewInt(0), BaseFee: big.NewInt(1e9), BlobGasUsed: &blobGasUsed, ExcessBlobGas: &excessBlobGas, ParentBeaconRoot: &beaconRoot},
+			body:         &types.Body{Withdrawals: withdrawals},
+			wantShanghai: true,
+			wantCancun:   true,
+			wantBlobs:    3,
+			wantTotal:    "21000000000000000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			block := types.NewBlock(tt.header, tt.body, nil, trie.NewStackTrie(nil))
+			server := newMockRPCServer(t, map[string]mockRPCHandler{
+				"eth_chainId":          mockResult("0x1"),
+				"eth_getBlockByNumber": mockResult(mockBlockJSON(t, block)),
+			})
+			kit := newMockKit(t, server)
+
+			summary, err := kit.GetBlockSummary(context.Background(), BlockAtNumber(tt.header.Number.Uint64()))
+			if err != nil {
+				t.Fatalf("GetBlockSummary() failed: %v", err)
+			}
+			if summary.Hash != block.Hash() {
+				t.Errorf("Hash = %s, expected %s", summary.Hash, block.Hash())
+			}
+			if summary.IsShanghai() != tt.wantShanghai || summary.IsCancun() != tt.wantCancun {
+				t.Errorf("IsShanghai() = %v, IsCancun() = %v, expected %v, %v", summary.IsShanghai(), summary.IsCancun(), tt.wantShanghai, tt.wantCancun)
+			}
+			if got := summary.WithdrawalsTotal().String(); got != tt.wantTotal {
+				t.Errorf("WithdrawalsTotal() = %s, expected %s", got, tt.wantTotal)
+			}
+			if got := summary.BlobCount(); got != tt.wantBlobs {
+				t.Errorf("BlobCount() = %d, expected %d", got, tt.wantBlobs)
+			}
+			if tt.wantCancun {
+				if *summary.ExcessBlobGas != excessBlobGas || *summary.ParentBeaconRoot != beaconRoot {
+					t.Errorf("ExcessBlobGas = %d, ParentBeaconRoot = %s", *summary.ExcessBlobGas, summary.ParentBeaconRoot)
+				}
+				if got := summary.WithdrawalsTo(validator).String(); got != "16000000000000000" {
+					t.Errorf("WithdrawalsTo() = %s, expected 16000000000000000", got)
+				}
+			}
+			text := summary.String()
+			if strings.Contains(text, "withdrawals:") != tt.wantShanghai || strings.Contains(text, "blobs:") != tt.wantCancun {
+				t.Errorf("String() = %q", text)
+			}
+		})
+	}
+}
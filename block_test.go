@@ -0,0 +1,174 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestBlockTagBlockNumber(t *testing.T) {
+	tests := []struct {
+		tag      BlockTag
+		expected string
+	}{
+		{BlockTagLatest, "latest"},
+		{BlockTagSafe, "safe"},
+		{BlockTagFinalized, "finalized"},
+		{BlockTagPending, "pending"},
+		{BlockTagEarliest, "earliest"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.tag), func(t *testing.T) {
+			// 标签转换为负数后应能还原为相同的 RPC 参数
+			if got := toBlockNumArg(tt.tag.BlockNumber()); got != tt.expected {
+				t.Errorf("toBlockNumArg(%s) = %s, expected %s", tt.tag, got, tt.expected)
+			}
+		})
+	}
+
+	if got := toBlockNumArg(big.NewInt(255)); got != "0xff" {
+		t.Errorf("toBlockNumArg(255) = %s, expected 0xff", got)
+	}
+	if got := toBlockNumArg(nil); got != "latest" {
+		t.Errorf("toBlockNumArg(nil) = %s, expected latest", got)
+	}
+}
+
+func TestProviderBlockRefParams(t *testing.T) {
+	blockHash := common.HexToHash("0x1234")
+	var balanceParams, callParams []json.RawMessage
+
+	server := newMockRPCServer(t, map[string]mockRPCHandler{
+		"eth_getBalance": func(params []json.RawMessage) (interface{}, error) {
+			balanceParams = params
+			return (*hexutil.Big)(big.NewInt(42)), nil
+		},
+		"eth_call": func(params []json.RawMessage) (interface{}, error) {
+			callParams = params
+			return hexutil.Bytes{0x01}, nil
+		},
+	})
+
+	provider, err := NewProviderWithChainId(server.URL, 1)
+	if err != nil {
+		t.Fatalf("NewProviderWithChainId() failed: %v", err)
+	}
+	defer provider.Close()
+
+	addr := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+
+	// 区块标签以字符串形式传递
+	balance, err := provider.GetBalanceAt(context.Background(), addr, BlockAtTag(BlockTagFinalized))
+	if err != nil {
+		t.Fatalf("GetBalanceAt() failed: %v", err)
+	}
+	if balance.Int64() != 42 {
+		t.Errorf("Balance = %s, expected 42", balance)
+	}
+	if string(balanceParams[1]) != `"finalized"` {
+		t.Errorf("Block param = %s, expected \"finalized\"", balanceParams[1])
+	}
+
+	// 区块哈希以 EIP-1898 对象形式传递
+	if _, err := provider.CallContractAt(context.Background(), ethereum.CallMsg{To: &addr}, BlockAtHash(blockHash, true)); err != nil {
+		t.Fatalf("CallContractAt() failed: %v", err)
+	}
+	var ref struct {
+		BlockHash        common.Hash `json:"blockHash"`
+		RequireCanonical bool        `json:"requireCanonical"`
+	}
+	if err := json.Unmarshal(callParams[1], &ref); err != nil {
+		t.Fatalf("Failed to decode block param %s: %v", callParams[1], err)
+	}
+	if ref.BlockHash != blockHash || !ref.RequireCanonical {
+		t.Errorf("Block param = %s, expected blockHash %s with requireCanonical", callParams[1], blockHash.Hex())
+	}
+}
+
+func TestGetTransactionInBlock(t *testing.T) {
+	blockHash := common.HexToHash("0x1234")
+	account, _ := GetTestAccount(0)
+	to := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	txs := []*types.Transaction{
+		types.MustSignNewTx(account.PrivateKey, types.LatestSignerForChainID(big.NewInt(1)), &types.LegacyTx{Nonce: 0, Gas: 21000, GasPrice: big.NewInt(1e9), To: &to, Value: big.NewInt(1)}),
+		types.MustSignNewTx(account.PrivateKey, types.LatestSignerForChainID(big.NewInt(1)), &types.LegacyTx{Nonce: 1, Gas: 21000, GasPrice: big.NewInt(1e9), To: &to, Value: big.NewInt(2)}),
+	}
+	// 区块 100（哈希 blockHash）包含两笔交易，其他区块不存在
+	known := func(raw json.RawMessage) bool {
+		var ref string
+		_ = json.Unmarshal(raw, &ref)
+		return ref == "0x64" || ref == "latest" || common.HexToHash(ref) == blockHash
+	}
+	byIndex := func(params []json.RawMessage) (interface{}, error) {
+		var index hexutil.Uint64
+		_ = json.Unmarshal(params[1], &index)
+		if !known(params[0]) || int(index) >= len(txs) {
+			return nil, nil
+		}
+		return txs[index], nil
+	}
+	count := func(params []json.RawMessage) (interface{}, error) {
+		if !known(params[0]) {
+			return nil, nil
+		}
+		return hexutil.Uint(len(txs)), nil
+	}
+	server := newMockRPCServer(t, map[string]mockRPCHandler{
+		"eth_getTransactionByBlockNumberAndIndex": byIndex,
+		"eth_getTransactionByBlockHashAndIndex":   byIndex,
+		"eth_getBlockTransactionCountByNumber":    count,
+		"eth_getBlockTransactionCountByHash":      count,
+	})
+	provider, err := NewProviderWithChainId(server.URL, 1)
+	if err != nil {
+		t.Fatalf("NewProviderWithChainId() failed: %v", err)
+	}
+	defer provider.Close()
+
+	tests := []struct {
+		name     string
+		block    BlockRef
+		index    uint
+		wantNone bool
+	}{
+		{name: "by number", block: BlockAtNumber(100), index: 1},
+		{name: "by hash", block: BlockAtHash(blockHash, false), index: 0},
+		{name: "by tag", block: BlockAtTag(BlockTagLatest), index: 1},
+		{name: "index out of range", block: BlockAtNumber(100), index: 2, wantNone: true},
+		{name: "unknown block", block: BlockAtNumber(101), index: 0, wantNone: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, err := provider.GetTransactionInBlock(context.Background(), tt.block, tt.index)
+			if tt.wantNone {
+				if !errors.Is(err, ethereum.NotFound) {
+					t.Fatalf("GetTransactionInBlock() error = %v, want ethereum.NotFound", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetTransactionInBlock() failed: %v", err)
+			}
+			if tx.Hash() != txs[tt.index].Hash() {
+				t.Errorf("GetTransactionInBlock() = %s, expected %s", tx.Hash(), txs[tt.index].Hash())
+			}
+			n, err := provider.GetTransactionCountInBlock(context.Background(), tt.block)
+			if err != nil || n != uint(len(txs)) {
+				t.Errorf("GetTransactionCountInBlock() = %d, %v, expected %d", n, err, len(txs))
+			}
+		})
+	}
+
+	if _, err := provider.GetTransactionCountInBlock(context.Background(), BlockAtNumber(101)); !errors.Is(err, ethereum.NotFound) {
+		t.Errorf("GetTransactionCountInBlock() on unknown block error = %v, want ethereum.NotFound", err)
+	}
+}
@@ -0,0 +1,147 @@
+package etherkit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+//############ Block Time ############
+
+// DefaultBlockTimeSample 计算平均出块时间时默认采样的区块数量
+const DefaultBlockTimeSample = 1000
+
+// AverageBlockTime 根据最近 sample 个区块计算平均出块时间
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - sample: 采样区块数量（0 表示 DefaultBlockTimeSample，超过链高度时使用全部区块）
+//
+// 返回：
+//   - time.Duration: 平均出块时间
+//   - error: 如果查询区块头失败或链上区块不足则返回错误
+func AverageBlockTime(ctx context.Context, ep EtherProvider, sample uint64) (time.Duration, error) {
+	head, err := providerExt[BlockRefReader](ep).GetHeaderAt(ctx, BlockRef{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get latest header: %w", err)
+	}
+	return averageBlockTime(ctx, ep, head, sample)
+}
+
+// averageBlockTime 计算 head 之前 sample 个区块的平均出块时间
+func averageBlockTime(ctx context.Context, ep EtherProvider, head *types.Header, sample uint64) (time.Duration, error) {
+	if sample == 0 {
+		sample = DefaultBlockTimeSample
+	}
+	sample = min(sample, head.Number.Uint64())
+	if sample == 0 {
+		return 0, fmt.Errorf("not enough blocks to estimate block time")
+	}
+	past, err := providerExt[BlockRefReader](ep).GetHeaderAt(ctx, BlockAtNumber(head.Number.Uint64()-sample))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get header %d: %w", head.Number.Uint64()-sample, err)
+	}
+	elapsed := time.Duration(head.Time-past.Time) * time.Second
+	return elapsed / time.Duration(sample), nil
+}
+
+// EstimateBlockAtTimestamp 估算指定时间对应的区块号（时间戳不晚于 ts 的最后一个区块）
+// 过去的时间通过在区块头上二分查找得到精确结果；未来的时间按最近的平均出块时间外推
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - ts: 时间点
+//
+// 返回：
+//   - uint64: 区块号（早于创世区块时返回 0）
+//   - error: 如果查询区块头失败则返回错误
+//
+// 使用示例：
+//
+//	// 扫描昨天一整天的日志
+//	from, _ := EstimateBlockAtTimestamp(ctx, provider, dayStart)
+//	to, _ := EstimateBlockAtTimestamp(ctx, provider, dayStart.Add(24*time.Hour))
+func EstimateBlockAtTimestamp(ctx context.Context, ep EtherProvider, ts time.Time) (uint64, error) {
+	reader := providerExt[BlockRefReader](ep)
+	head, err := reader.GetHeaderAt(ctx, BlockRef{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get latest header: %w", err)
+	}
+	headNumber := head.Number.Uint64()
+	target := ts.Unix()
+	if target >= int64(head.Time) {
+		average, err := averageBlockTime(ctx, ep, head, DefaultBlockTimeSample)
+		if err != nil || average <= 0 {
+			return headNumber, err
+		}
+		ahead := time.Duration(target-int64(head.Time)) * time.Second
+		return headNumber + uint64(ahead/average), nil
+	}
+
+	// 不变式：lo 区块的时间戳不晚于 target（或 lo 为 0），hi 区块的时间戳晚于 target
+	lo, hi := uint64(0), headNumber
+	for lo+1 < hi {
+		mid := lo + (hi-lo)/2
+		header, err := reader.GetHeaderAt(ctx, BlockAtNumber(mid))
+		if err != nil {
+			return 0, fmt.Errorf("failed to get header %d: %w", mid, err)
+		}
+		if int64(header.Time) <= target {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return lo, nil
+}
+
+// EstimateTimestampOfBlock 估算指定区块的出块时间
+// 已出块的区块返回区块头中的时间戳；未来的区块按最近的平均出块时间外推
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - number: 区块号
+//
+// 返回：
+//   - time.Time: 出块时间
+//   - error: 如果查询区块头失败则返回错误
+func EstimateTimestampOfBlock(ctx context.Context, ep EtherProvider, number uint64) (time.Time, error) {
+	reader := providerExt[BlockRefReader](ep)
+	head, err := reader.GetHeaderAt(ctx, BlockRef{})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get latest header: %w", err)
+	}
+	headNumber := head.Number.Uint64()
+	if number > headNumber {
+		average, err := averageBlockTime(ctx, ep, head, DefaultBlockTimeSample)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.Unix(int64(head.Time), 0).Add(time.Duration(number-headNumber) * average), nil
+	}
+	if number == headNumber {
+		return time.Unix(int64(head.Time), 0), nil
+	}
+	header, err := reader.GetHeaderAt(ctx, BlockAtNumber(number))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get header %d: %w", number, err)
+	}
+	return time.Unix(int64(header.Time), 0), nil
+}
+
+// AverageBlockTime 计算最近 sample 个区块的平均出块时间
+func (k *Kit) AverageBlockTime(ctx context.Context, sample uint64) (time.Duration, error) {
+	return AverageBlockTime(ctx, k.EtherProvider, sample)
+}
+
+// EstimateBlockAtTimestamp 估算指定时间对应的区块号
+func (k *Kit) EstimateBlockAtTimestamp(ctx context.Context, ts time.Time) (uint64, error) {
+	return EstimateBlockAtTimestamp(ctx, k.EtherProvider, ts)
+}
+
+// EstimateTimestampOfBlock 估算指定区块的出块时间
+func (k *Kit) EstimateTimestampOfBlock(ctx context.Context, number uint64) (time.Time, error) {
+	return EstimateTimestampOfBlock(ctx, k.EtherProvider, number)
+}
@@ -0,0 +1,89 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// newMockTimedChainServer 模拟高度为 head、每 blockTime 秒出一个块的链
+func newMockTimedChainServer(t *testing.T, genesis time.Time, blockTime, head uint64) *mockRPCServer {
+	t.Helper()
+	return newMockRPCServer(t, map[string]mockRPCHandler{
+		"eth_getBlockByNumber": func(params []json.RawMessage) (interface{}, error) {
+			number := head
+			var tag string
+			if err := json.Unmarshal(params[0], &tag); err == nil && tag != "latest" {
+				n, err := hexutil.DecodeUint64(tag)
+				if err != nil {
+					return nil, err
+				}
+				number = n
+			}
+			if number > head {
+				return nil, nil
+			}
+			return &types.Header{
+				Number:     new(big.Int).SetUint64(number),
+				Time:       uint64(genesis.Unix()) + number*blockTime,
+				Difficulty: big.NewInt(0),
+			}, nil
+		},
+	})
+}
+
+func TestBlockTimeEstimation(t *testing.T) {
+	genesis := time.Unix(1600000000, 0)
+	server := newMockTimedChainServer(t, genesis, 12, 10000)
+	kit := newMockKit(t, server)
+	ctx := context.Background()
+
+	average, err := kit.AverageBlockTime(ctx, 0)
+	if err != nil || average != 12*time.Second {
+		t.Errorf("AverageBlockTime() = %s, %v; expected 12s", average, err)
+	}
+
+	blockTests := []struct {
+		name string
+		ts   time.Time
+		want uint64
+	}{
+		{"exact block", genesis.Add(12 * 500 * time.Second), 500},
+		{"between blocks", genesis.Add((12*500 + 7) * time.Second), 500},
+		{"before genesis", genesis.Add(-time.Hour), 0},
+		{"head", genesis.Add(12 * 10000 * time.Second), 10000},
+		{"future", genesis.Add(12 * 10100 * time.Second), 10100},
+	}
+	for _, tt := range blockTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := kit.EstimateBlockAtTimestamp(ctx, tt.ts)
+			if err != nil {
+				t.Fatalf("EstimateBlockAtTimestamp() failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("EstimateBlockAtTimestamp() = %d, expected %d", got, tt.want)
+			}
+		})
+	}
+
+	timeTests := []struct {
+		number uint64
+		want   time.Time
+	}{
+		{0, genesis},
+		{1234, genesis.Add(12 * 1234 * time.Second)},
+		{10000, genesis.Add(12 * 10000 * time.Second)},
+		{10050, genesis.Add(12 * 10050 * time.Second)}, // 未来区块按平均出块时间外推
+	}
+	for _, tt := range timeTests {
+		got, err := kit.EstimateTimestampOfBlock(ctx, tt.number)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("EstimateTimestampOfBlock(%d) = %s, %v; expected %s", tt.number, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,308 @@
+package etherkit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+//############ Bulk Sender ############
+
+// BulkSender 默认参数
+const (
+	DefaultBulkMaxInFlight  = 16               // 默认同时未确认的交易数
+	DefaultBulkMaxAttempts  = 3                // 默认每笔交易的最大广播次数（含加价替换）
+	DefaultBulkReplaceAfter = 60 * time.Second // 默认未打包多久后加价替换
+)
+
+// BulkTx 批量发送中的单笔交易
+type BulkTx struct {
+	To       common.Address // 接收地址
+	Value    *big.Int       // 转账金额（单位为 Wei，nil 表示不转账）
+	Data     []byte         // 调用数据
+	GasLimit uint64         // Gas 限制（0 表示自动估算）
+}
+
+// BulkResult 单笔交易的发送结果
+type BulkResult struct {
+	Index    int            // 在输入列表中的位置
+	Nonce    uint64         // 分配的 nonce
+	TxHash   common.Hash    // 最终被打包（或最后一次广播）的交易哈希
+	Receipt  *types.Receipt // 交易收据（未打包时为 nil）
+	Attempts int            // 广播次数
+	Filled   bool           // 交易无法发送时，是否已用空交易填补其 nonce（保证后续交易不被阻塞）
+	Err      error          // 失败原因
+}
+
+// BulkSender 高吞吐批量发送器（用于空投、压测等场景）
+// 预先分配连续的 nonce，限制同时未确认的交易数和广播速率；交易超时未打包时加价替换，
+// 无法发送的交易用空交易填补 nonce，避免阻塞后续交易
+type BulkSender struct {
+	MaxInFlight    int           // 同时未确认的交易数上限（<= 0 使用 DefaultBulkMaxInFlight）
+	RatePerSecond  float64       // 每秒最多广播的交易数（<= 0 表示不限制）
+	MaxAttempts    int           // 每笔交易最大广播次数（<= 0 使用 DefaultBulkMaxAttempts）
+	FeeBumpPercent int           // 替换时的加价比例（低于 MinReplacementBumpPercent 时按其处理）
+	MaxTotalFee    *big.Int      // 替换交易的手续费上限 gasLimit * gasPrice（nil 表示不限制，达到上限后不再替换）
+	ReplaceAfter   time.Duration // 未打包多久后加价替换（0 使用 DefaultBulkReplaceAfter）
+	PollInterval   time.Duration // 收据轮询间隔（0 使用 DefaultWaitInterval）
+	kit            *Kit
+}
+
+// NewBulkSender 使用默认参数创建批量发送器
+// 通过 WithReplacementPolicy 设置了替换策略时，加价比例、广播次数和手续费上限取自该策略
+func (k *Kit) NewBulkSender() *BulkSender {
+	s := &BulkSender{
+		MaxInFlight:    DefaultBulkMaxInFlight,
+		MaxAttempts:    DefaultBulkMaxAttempts,
+		FeeBumpPercent: MinReplacementBumpPercent,
+		ReplaceAfter:   DefaultBulkReplaceAfter,
+		PollInterval:   DefaultWaitInterval,
+		kit:            k,
+	}
+	if k.replacement != nil {
+		s.FeeBumpPercent = k.replacement.BumpPercent
+		s.MaxTotalFee = k.replacement.MaxTotalFee
+		if k.replacement.MaxAttempts > 0 {
+			s.MaxAttempts = k.replacement.MaxAttempts + 1 // 首次广播不计入替换次数
+		}
+	}
+	return s
+}
+
+// Send 批量签名、广播交易并等待打包
+// 参数说明：
+//   - ctx: 上下文对象
+//   - txs: 待发送的交易（按顺序分配从 pending nonce 开始的连续 nonce）
+//
+// 返回：
+//   - []BulkResult: 与 txs 顺序一致的发送结果
+//   - error: 如果查询初始 nonce 或 gas 价格失败则返回错误；否则返回所有失败交易错误的合并（全部成功时为 nil）
+//
+// 使用示例：
+//
+//	sender := kit.NewBulkSender()
+//	sender.RatePerSecond = 20
+//	results, err := sender.Send(ctx, txs)
+func (s *BulkSender) Send(ctx context.Context, txs []BulkTx) ([]BulkResult, error) {
+	baseNonce, err := s.kit.GetNonce(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nonce: %w", err)
+	}
+	gasPrice, err := s.kit.Wallet.suggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gas price: %w", err)
+	}
+
+	maxInFlight := s.MaxInFlight
+	if maxInFlight <= 0 {
+		maxInFlight = DefaultBulkMaxInFlight
+	}
+	limiter := newRateLimiter(s.RatePerSecond)
+	results := make([]BulkResult, len(txs))
+	sem := make(chan struct{}, maxInFlight)
+
+	var wg sync.WaitGroup
+	for i, tx := range txs {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			for j := i; j < len(txs); j++ {
+				results[j] = BulkResult{Index: j, Err: ctx.Err()}
+			}
+			wg.Wait()
+			return results, ctx.Err()
+		}
+		wg.Add(1)
+		go func(i int, tx BulkTx) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = s.process(ctx, i, baseNonce+uint64(i), tx, gasPrice, limiter)
+		}(i, tx)
+	}
+	wg.Wait()
+
+	var errs []error
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("tx %d (nonce %d): %w", result.Index, result.Nonce, result.Err))
+		}
+	}
+	return results, errors.Join(errs...)
+}
+
+// process 发送单笔交易：广播 → 等待 → 超时加价替换，最终失败时填补 nonce
+func (s *BulkSender) process(ctx context.Context, index int, nonce uint64, tx BulkTx, gasPrice *big.Int, limiter *rateLimiter) BulkResult {
+	result := BulkResult{Index: index, Nonce: nonce}
+	maxAttempts := s.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultBulkMaxAttempts
+	}
+	policy := ReplacementPolicy{BumpPercent: s.FeeBumpPercent, MaxTotalFee: s.MaxTotalFee}
+
+	gasLimit := tx.GasLimit
+	if gasLimit == 0 {
+		estimated, err := s.kit.EstimateGas(ctx, s.kit.GetAddress(), tx.To, nonce, gasPrice, tx.Value, tx.Data)
+		if err != nil {
+			result.Err = fmt.Errorf("failed to estimate gas: %w", NormalizeError(err))
+			result.Filled = s.fill(ctx, nonce, gasPrice, limiter)
+			return result
+		}
+		gasLimit = estimated
+	}
+
+	price := gasPrice
+	replacement := 0 // 当前广播的是第几次替换（0 表示首次广播）
+	var hashes []common.Hash
+	var lastErr, limitErr error
+	for attempt := 1; attempt <= maxAttempts && ctx.Err() == nil; attempt++ {
+		result.Attempts = attempt
+		hash, err := s.broadcast(ctx, nonce, gasLimit, price, tx, limiter, replacement)
+		if err == nil {
+			hashes = append(hashes, hash)
+		} else {
+			lastErr = err
+			// nonce too low 说明之前广播的某个版本已被打包
+			if !errors.Is(err, ErrNonceTooLow) || len(hashes) == 0 {
+				if price, limitErr = s.bump(ctx, policy, nonce, price, gasLimit, &replacement); limitErr != nil {
+					break
+				}
+				continue
+			}
+		}
+		if receipt, hash := s.waitAny(ctx, hashes); receipt != nil {
+			result.TxHash, result.Receipt = hash, receipt
+			return result
+		}
+		if price, limitErr = s.bump(ctx, policy, nonce, price, gasLimit, &replacement); limitErr != nil {
+			break
+		}
+	}
+
+	if len(hashes) == 0 {
+		result.Err = lastErr
+		result.Filled = s.fill(ctx, nonce, price, limiter)
+		return result
+	}
+	result.TxHash = hashes[len(hashes)-1]
+	result.Err = fmt.Errorf("%w: not mined after %d attempts", ErrReceiptTimeout, result.Attempts)
+	if limitErr != nil {
+		result.Err = fmt.Errorf("%w: not mined after %d attempts: %w", ErrReceiptTimeout, result.Attempts, limitErr)
+	}
+	if ctx.Err() != nil {
+		result.Err = ctx.Err()
+	}
+	return result
+}
+
+// bump 按替换策略加价（替换次数按 nonce 累计），超过替换次数或手续费上限时返回原价格和错误
+func (s *BulkSender) bump(ctx context.Context, policy ReplacementPolicy, nonce uint64, price *big.Int, gasLimit uint64, replacement *int) (*big.Int, error) {
+	attempt := s.kit.replacementAttempt(ctx, nonce)
+	bumped, err := policy.Bump(price, gasLimit, attempt)
+	if err != nil {
+		return price, err
+	}
+	*replacement = attempt
+	return bumped, nil
+}
+
+// broadcast 构建、签名（经过审核回调和审计）并按速率限制广播交易
+func (s *BulkSender) broadcast(ctx context.Context, nonce, gasLimit uint64, gasPrice *big.Int, bulkTx BulkTx, limiter *rateLimiter, replacement int) (common.Hash, error) {
+	tx, err := NewTx(bulkTx.To, nonce, gasLimit, gasPrice, bulkTx.Value, bulkTx.Data)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	signedTx, err := s.kit.signReplacement(ctx, tx, replacement)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if err := limiter.Wait(ctx); err != nil {
+		return common.Hash{}, err
+	}
+	if err := s.kit.broadcastTx(ctx, signedTx); err != nil {
+		return common.Hash{}, err
+	}
+	return signedTx.Hash(), nil
+}
+
+// fill 用空交易填补无法发送的 nonce，返回是否成功
+func (s *BulkSender) fill(ctx context.Context, nonce uint64, gasPrice *big.Int, limiter *rateLimiter) bool {
+	if err := limiter.Wait(ctx); err != nil {
+		return false
+	}
+	_, err := s.kit.sendNoopTx(ctx, nonce, gasPrice, 0)
+	return err == nil
+}
+
+// waitAny 在 ReplaceAfter 时间内轮询已广播的各个版本，返回第一个被打包的收据
+func (s *BulkSender) waitAny(ctx context.Context, hashes []common.Hash) (*types.Receipt, common.Hash) {
+	replaceAfter := s.ReplaceAfter
+	if replaceAfter <= 0 {
+		replaceAfter = DefaultBulkReplaceAfter
+	}
+	interval := s.PollInterval
+	if interval <= 0 {
+		interval = DefaultWaitInterval
+	}
+	ctx, cancel := context.WithTimeout(ctx, replaceAfter)
+	defer cancel()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		for _, hash := range hashes {
+			if receipt, err := s.kit.GetTransactionReceipt(ctx, hash); err == nil && receipt != nil {
+				return receipt, hash
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil, common.Hash{}
+		case <-ticker.C:
+		}
+	}
+}
+
+// rateLimiter 按固定间隔放行请求的限速器（nil 表示不限速）
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// newRateLimiter 创建每秒最多放行 perSecond 次的限速器
+func newRateLimiter(perSecond float64) *rateLimiter {
+	if perSecond <= 0 {
+		return nil
+	}
+	return &rateLimiter{interval: time.Duration(float64(time.Second) / perSecond)}
+}
+
+// Wait 等待下一个可用的时间点
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return ctx.Err()
+	}
+	l.mu.Lock()
+	now := time.Now()
+	at := l.next
+	if at.Before(now) {
+		at = now
+	}
+	l.next = at.Add(l.interval)
+	l.mu.Unlock()
+
+	timer := time.NewTimer(time.Until(at))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,167 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// bulkMockChain 模拟节点上的交易池：记录广播的交易，按 mined 判断哪些交易被打包
+type bulkMockChain struct {
+	mu    sync.Mutex
+	sent  []*types.Transaction
+	mined func(tx *types.Transaction, attempt int) bool
+}
+
+func newBulkMockServer(t *testing.T, chain *bulkMockChain) *mockRPCServer {
+	t.Helper()
+	server := newMockSendServer(t)
+	server.handlers["eth_sendRawTransaction"] = func(params []json.RawMessage) (interface{}, error) {
+		var raw string
+		_ = json.Unmarshal(params[0], &raw)
+		tx, err := DecodeRawTxHex(raw[2:])
+		if err != nil {
+			return nil, err
+		}
+		chain.mu.Lock()
+		chain.sent = append(chain.sent, tx)
+		chain.mu.Unlock()
+		return tx.Hash(), nil
+	}
+	server.handlers["eth_getTransactionReceipt"] = func(params []json.RawMessage) (interface{}, error) {
+		var hash common.Hash
+		_ = json.Unmarshal(params[0], &hash)
+		chain.mu.Lock()
+		defer chain.mu.Unlock()
+		attempts := map[uint64]int{}
+		for _, tx := range chain.sent {
+			attempts[tx.Nonce()]++
+			if tx.Hash() == hash && chain.mined(tx, attempts[tx.Nonce()]) {
+				return &types.Receipt{Status: 1, TxHash: hash, BlockNumber: big.NewInt(1), Logs: []*types.Log{}}, nil
+			}
+		}
+		return nil, nil
+	}
+	return server
+}
+
+func TestBulkSender(t *testing.T) {
+	recipient := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	newSender := func(kit *Kit) *BulkSender {
+		sender := kit.NewBulkSender()
+		sender.MaxInFlight = 3
+		sender.ReplaceAfter = 100 * time.Millisecond
+		sender.PollInterval = 10 * time.Millisecond
+		return sender
+	}
+
+	t.Run("all mined", func(t *testing.T) {
+		chain := &bulkMockChain{mined: func(*types.Transaction, int) bool { return true }}
+		kit := newMockKit(t, newBulkMockServer(t, chain))
+
+		txs := make([]BulkTx, 10)
+		for i := range txs {
+			txs[i] = BulkTx{To: recipient, Value: big.NewInt(int64(i + 1)), GasLimit: 21000}
+		}
+		results, err := newSender(kit).Send(context.Background(), txs)
+		if err != nil {
+			t.Fatalf("Send() failed: %v", err)
+		}
+		for i, result := range results {
+			// nonce 从 pending nonce（0x5）开始连续分配
+			if result.Index != i || result.Nonce != uint64(5+i) {
+				t.Errorf("result %d: index = %d, nonce = %d", i, result.Index, result.Nonce)
+			}
+			if result.Receipt == nil || result.Attempts != 1 || result.Err != nil {
+				t.Errorf("result %d = %+v, expected mined on first attempt", i, result)
+			}
+		}
+		if len(chain.sent) != len(txs) {
+			t.Errorf("sent %d transactions, expected %d", len(chain.sent), len(txs))
+		}
+	})
+
+	t.Run("replaced when not mined", func(t *testing.T) {
+		// 只有第二次广播（加价替换后）的交易会被打包
+		chain := &bulkMockChain{mined: func(_ *types.Transaction, attempt int) bool { return attempt >= 2 }}
+		kit := newMockKit(t, newBulkMockServer(t, chain))
+
+		results, err := newSender(kit).Send(context.Background(), []BulkTx{{To: recipient, Value: big.NewInt(1), GasLimit: 21000}})
+		if err != nil {
+			t.Fatalf("Send() failed: %v", err)
+		}
+		if results[0].Attempts != 2 || results[0].Receipt == nil {
+			t.Fatalf("result = %+v, expected mined on second attempt", results[0])
+		}
+		if len(chain.sent) != 2 {
+			t.Fatalf("sent %d transactions, expected 2", len(chain.sent))
+		}
+		first, second := chain.sent[0], chain.sent[1]
+		if first.Nonce() != second.Nonce() {
+			t.Errorf("replacement nonce = %d, expected %d", second.Nonce(), first.Nonce())
+		}
+		if want := BumpGasPrice(first.GasPrice(), MinReplacementBumpPercent); second.GasPrice().Cmp(want) != 0 {
+			t.Errorf("replacement gas price = %s, expected %s", second.GasPrice(), want)
+		}
+		if results[0].TxHash != second.Hash() {
+			t.Errorf("TxHash = %s, expected %s", results[0].TxHash.Hex(), second.Hash().Hex())
+		}
+	})
+
+	t.Run("nonce filled on failure", func(t *testing.T) {
+		chain := &bulkMockChain{mined: func(*types.Transaction, int) bool { return true }}
+		server := newBulkMockServer(t, chain)
+		server.handlers["eth_estimateGas"] = func(params []json.RawMessage) (interface{}, error) {
+			return nil, &mockRPCError{Code: 3, Message: "execution reverted"}
+		}
+		kit := newMockKit(t, server)
+
+		results, err := newSender(kit).Send(context.Background(), []BulkTx{{To: recipient, Value: big.NewInt(1)}})
+		if err == nil || results[0].Err == nil {
+			t.Fatal("Send() expected error for failed estimate")
+		}
+		if !results[0].Filled {
+			t.Error("Filled = false, expected nonce gap to be filled")
+		}
+		// 填补交易为发给自己的空交易
+		if len(chain.sent) != 1 || *chain.sent[0].To() != kit.GetAddress() || chain.sent[0].Nonce() != 5 {
+			t.Errorf("unexpected fill transactions: %d", len(chain.sent))
+		}
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		chain := &bulkMockChain{mined: func(*types.Transaction, int) bool { return false }}
+		kit := newMockKit(t, newBulkMockServer(t, chain))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err := newSender(kit).Send(ctx, []BulkTx{{To: recipient, GasLimit: 21000}})
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Send() error = %v, expected context.DeadlineExceeded", err)
+		}
+	})
+}
+
+func TestRateLimiter(t *testing.T) {
+	limiter := newRateLimiter(100)
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatalf("Wait() failed: %v", err)
+		}
+	}
+	// 5 次放行至少间隔 4 个周期（10ms）
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("elapsed = %s, expected >= 40ms", elapsed)
+	}
+	if newRateLimiter(0) != nil {
+		t.Error("newRateLimiter(0) expected nil (unlimited)")
+	}
+}
@@ -0,0 +1,155 @@
+package etherkit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+//############ Bundle Simulation ############
+
+// ErrBundleSimulationUnsupported 节点既不支持 eth_callMany 也不支持 trace_callMany
+var ErrBundleSimulationUnsupported = fmt.Errorf("%w: neither eth_callMany nor trace_callMany", ErrUnsupportedByEndpoint)
+
+// BundleCallResult 模拟执行中单个调用的结果
+type BundleCallResult struct {
+	Output       []byte // 返回数据（回滚时为回滚数据，节点提供时）
+	Error        string // 执行错误（成功时为空）
+	RevertReason string // 解码后的回滚原因（Error(string) 格式时）
+}
+
+// Success 判断调用是否执行成功
+func (r *BundleCallResult) Success() bool {
+	return r.Error == ""
+}
+
+// SimulateBundle 在同一个基准区块上按顺序模拟执行一组调用，后面的调用可以看到前面调用的状态变化
+// 优先使用 eth_callMany（Erigon 等），节点不支持时使用 trace_callMany（Erigon、Reth、Nethermind 等）
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - calls: 按执行顺序排列的调用
+//   - block: 基准区块（零值表示最新区块，调用在该区块状态之上执行）
+//
+// 返回：
+//   - []BundleCallResult: 每个调用的结果（与 calls 顺序一致）
+//   - error: 节点都不支持时返回 ErrBundleSimulationUnsupported，请求失败时返回对应错误
+func SimulateBundle(ctx context.Context, ep EtherProvider, calls []ethereum.CallMsg, block BlockRef) ([]BundleCallResult, error) {
+	if len(calls) == 0 {
+		return nil, nil
+	}
+	results, err := callMany(ctx, ep, calls, block)
+	if err == nil || !isMethodNotFound(err) {
+		return results, err
+	}
+	results, err = traceCallMany(ctx, ep, calls, block)
+	if err != nil && isMethodNotFound(err) {
+		return nil, ErrBundleSimulationUnsupported
+	}
+	return results, err
+}
+
+// SimulateBundle 以 Kit 账户身份按顺序模拟执行一组调用（未设置 From 的调用使用 Kit 地址）
+func (k *Kit) SimulateBundle(ctx context.Context, calls []ethereum.CallMsg, block BlockRef) ([]BundleCallResult, error) {
+	filled := make([]ethereum.CallMsg, len(calls))
+	for i, call := range calls {
+		if call.From == (common.Address{}) {
+			call.From = k.GetAddress()
+		}
+		filled[i] = call
+	}
+	return SimulateBundle(ctx, k.EtherProvider, filled, block)
+}
+
+// bundleCallArg 模拟调用的参数（同时提供 input 和 data，兼容不同客户端）
+func bundleCallArg(msg ethereum.CallMsg) interface{} {
+	arg := toCallArg(msg).(map[string]interface{})
+	if len(msg.Data) > 0 {
+		arg["data"] = hexutil.Bytes(msg.Data)
+	}
+	return arg
+}
+
+// callMany 通过 eth_callMany 模拟执行
+func callMany(ctx context.Context, ep EtherProvider, calls []ethereum.CallMsg, block BlockRef) ([]BundleCallResult, error) {
+	txs := make([]interface{}, len(calls))
+	for i, call := range calls {
+		txs[i] = bundleCallArg(call)
+	}
+	bundles := []map[string]interface{}{{"transactions": txs}}
+	stateContext := map[string]interface{}{"blockNumber": block.rpcArg(), "transactionIndex": -1}
+
+	var raw [][]struct {
+		Value hexutil.Bytes `json:"value"`
+		Error string        `json:"error"`
+	}
+	if err := ep.GetRpcClient().CallContext(ctx, &raw, "eth_callMany", bundles, stateContext); err != nil {
+		return nil, err
+	}
+	if len(raw) != 1 || len(raw[0]) != len(calls) {
+		return nil, fmt.Errorf("eth_callMany returned unexpected result shape")
+	}
+	results := make([]BundleCallResult, len(calls))
+	for i, r := range raw[0] {
+		results[i] = newBundleCallResult(r.Value, r.Error)
+	}
+	return results, nil
+}
+
+// traceCallMany 通过 trace_callMany 模拟执行
+func traceCallMany(ctx context.Context, ep EtherProvider, calls []ethereum.CallMsg, block BlockRef) ([]BundleCallResult, error) {
+	params := make([]interface{}, len(calls))
+	for i, call := range calls {
+		params[i] = []interface{}{bundleCallArg(call), []string{"trace"}}
+	}
+
+	var raw []struct {
+		Output hexutil.Bytes `json:"output"`
+		Trace  []struct {
+			Error string `json:"error"`
+		} `json:"trace"`
+	}
+	if err := ep.GetRpcClient().CallContext(ctx, &raw, "trace_callMany", params, block.rpcArg()); err != nil {
+		return nil, err
+	}
+	if len(raw) != len(calls) {
+		return nil, fmt.Errorf("trace_callMany returned %d results for %d calls", len(raw), len(calls))
+	}
+	results := make([]BundleCallResult, len(calls))
+	for i, r := range raw {
+		var callErr string
+		if len(r.Trace) > 0 {
+			callErr = r.Trace[0].Error // 第一条 trace 是顶层调用
+		}
+		results[i] = newBundleCallResult(r.Output, callErr)
+	}
+	return results, nil
+}
+
+// newBundleCallResult 构建调用结果并尝试解码回滚原因
+func newBundleCallResult(output []byte, callErr string) BundleCallResult {
+	result := BundleCallResult{Output: output, Error: callErr}
+	if callErr != "" {
+		if reason, err := abi.UnpackRevert(output); err == nil {
+			result.RevertReason = reason
+		}
+	}
+	return result
+}
+
+// isMethodNotFound 判断错误是否表示节点不支持该 RPC 方法
+func isMethodNotFound(err error) bool {
+	var rpcErr interface{ ErrorCode() int }
+	if errors.As(err, &rpcErr) && (rpcErr.ErrorCode() == -32601 || rpcErr.ErrorCode() == -32004) {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "method not found") || strings.Contains(msg, "does not exist") ||
+		strings.Contains(msg, "not supported") || strings.Contains(msg, "unsupported method")
+}
@@ -0,0 +1,77 @@
+package etherkit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestSimulateBundle(t *testing.T) {
+	token := common.HexToAddress("0x1c7D4B196Cb0C7B01d743Fbc6116a902379C7238")
+	calls := []ethereum.CallMsg{{To: &token, Data: []byte{0x09, 0x5e, 0xa7, 0xb3}}, {To: &token, Data: []byte{0x23, 0xb8, 0x72, 0xdd}}}
+
+	// Error(string) 编码的回滚数据："insufficient allowance"
+	revertData := hexutil.MustDecode("0x08c379a000000000000000000000000000000000000000000000000000000000000000200000000000000000000000000000000000000000000000000000000000000016696e73756666696369656e7420616c6c6f77616e636500000000000000000000")
+	success := hexutil.Bytes(common.LeftPadBytes([]byte{1}, 32))
+
+	tests := []struct {
+		name     string
+		handlers map[string]mockRPCHandler
+		wantErr  error
+	}{
+		{
+			name: "eth_callMany",
+			handlers: map[string]mockRPCHandler{
+				"eth_callMany": mockResult([][]map[string]interface{}{{
+					{"value": success},
+					{"value": hexutil.Bytes(revertData), "error": "execution reverted"},
+				}}),
+			},
+		},
+		{
+			name: "falls back to trace_callMany",
+			handlers: map[string]mockRPCHandler{
+				"trace_callMany": mockResult([]map[string]interface{}{
+					{"output": success, "trace": []map[string]interface{}{{}}},
+					{"output": hexutil.Bytes(revertData), "trace": []map[string]interface{}{{"error": "Reverted"}}},
+				}),
+			},
+		},
+		{
+			name:     "unsupported",
+			handlers: map[string]mockRPCHandler{},
+			wantErr:  ErrBundleSimulationUnsupported,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newMockRPCServer(t, tt.handlers)
+			provider, _ := NewProvider(server.URL)
+			defer provider.Close()
+
+			results, err := SimulateBundle(context.Background(), provider, calls, BlockRef{})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("SimulateBundle() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SimulateBundle() failed: %v", err)
+			}
+			if len(results) != 2 {
+				t.Fatalf("got %d results, want 2", len(results))
+			}
+			if !results[0].Success() || len(results[0].Output) != 32 {
+				t.Errorf("results[0] = %+v, want success", results[0])
+			}
+			if results[1].Success() || results[1].RevertReason != "insufficient allowance" {
+				t.Errorf("results[1] = %+v, want revert with reason", results[1])
+			}
+		})
+	}
+}
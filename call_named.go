@@ -0,0 +1,51 @@
+package etherkit
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//############ Named Call Results ############
+
+// NamedOutputs 将按顺序排列的返回值转换为按 ABI 输出名称索引的 map
+// 未命名的输出使用 ret0、ret1... 作为名称（数字为输出的位置）
+// 参数说明：
+//   - outputs: 函数的输出定义（如 contractAbi.Methods["getReserves"].Outputs）
+//   - values: 返回值（如 StaticCall 的结果）
+//
+// 返回：
+//   - map[string]interface{}: 按名称索引的返回值
+func NamedOutputs(outputs abi.Arguments, values []interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(values))
+	for i, v := range values {
+		name := fmt.Sprintf("ret%d", i)
+		if i < len(outputs) && outputs[i].Name != "" {
+			name = outputs[i].Name
+		}
+		result[name] = v
+	}
+	return result
+}
+
+// StaticCallNamed 静态调用合约方法，返回按 ABI 输出名称索引的结果
+// 参数与 StaticCall 相同，适用于有多个命名返回值的函数（如 Uniswap V2 的 getReserves）
+//
+// 返回：
+//   - map[string]interface{}: 按输出名称索引的返回值（未命名的输出为 ret0、ret1...）
+//   - error: 如果调用失败则返回错误
+//
+// 使用示例：
+//
+//	res, err := kit.StaticCallNamed(ctx, pair, pairAbi, "getReserves", nil, nil, nil)
+//	reserve0 := res["reserve0"].(*big.Int)
+func (k *Kit) StaticCallNamed(ctx context.Context, contractAddress common.Address, contractAbi abi.ABI, functionName string, blockNumber *big.Int, from *common.Address, value *big.Int, params ...interface{}) (map[string]interface{}, error) {
+	values, err := k.StaticCall(ctx, contractAddress, contractAbi, functionName, blockNumber, from, value, params...)
+	if err != nil {
+		return nil, err
+	}
+	return NamedOutputs(contractAbi.Methods[functionName].Outputs, values), nil
+}
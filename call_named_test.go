@@ -0,0 +1,72 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestStaticCallNamed(t *testing.T) {
+	pairAbi, _ := abi.JSON(strings.NewReader(`[
+		{"type":"function","name":"getReserves","stateMutability":"view","inputs":[],"outputs":[{"name":"reserve0","type":"uint112"},{"name":"reserve1","type":"uint112"},{"name":"blockTimestampLast","type":"uint32"}]},
+		{"type":"function","name":"mixed","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"},{"name":"owner","type":"address"}]}
+	]`))
+	pair := common.HexToAddress("0xB4e16d0168e52d35CaCD2c6185b44281Ec28C9Dc")
+	owner := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+
+	tests := []struct {
+		name     string
+		method   string
+		returned []interface{}
+		want     map[string]string
+	}{
+		{
+			name:     "all named",
+			method:   "getReserves",
+			returned: []interface{}{big.NewInt(100), big.NewInt(200), uint32(1700000000)},
+			want:     map[string]string{"reserve0": "100", "reserve1": "200", "blockTimestampLast": "1700000000"},
+		},
+		{
+			name:     "unnamed output",
+			method:   "mixed",
+			returned: []interface{}{big.NewInt(42), owner},
+			want:     map[string]string{"ret0": "42", "owner": owner.Hex()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, _ := pairAbi.Methods[tt.method].Outputs.Pack(tt.returned...)
+			server := newMockSendServer(t)
+			server.handlers["eth_call"] = func(params []json.RawMessage) (interface{}, error) {
+				return hexutil.Encode(encoded), nil
+			}
+			kit := newMockKit(t, server)
+
+			got, err := kit.StaticCallNamed(context.Background(), pair, pairAbi, tt.method, nil, nil, nil)
+			if err != nil {
+				t.Fatalf("StaticCallNamed() failed: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("StaticCallNamed() = %v, expected %d values", got, len(tt.want))
+			}
+			for name, want := range tt.want {
+				value, ok := got[name]
+				if !ok {
+					t.Errorf("StaticCallNamed() missing %q", name)
+					continue
+				}
+				if str := fmt.Sprint(value); str != want {
+					t.Errorf("%s = %s, expected %s", name, str, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package etherkit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+//############ Provider Capabilities ############
+
+// Capability 节点可能支持的可选功能（RPC 命名空间或方法）
+type Capability string
+
+// 可探测的节点功能
+const (
+	CapabilityDebug            Capability = "debug"                // debug_* 命名空间（trace 交易、prestateTracer）
+	CapabilityTrace            Capability = "trace"                // trace_* 命名空间（Erigon、Reth、Nethermind）
+	CapabilityTxPool           Capability = "txpool"               // txpool_* 命名空间
+	CapabilityFeeHistory       Capability = "eth_feeHistory"       // eth_feeHistory
+	CapabilityBlockReceipts    Capability = "eth_getBlockReceipts" // eth_getBlockReceipts
+	CapabilityCreateAccessList Capability = "eth_createAccessList" // eth_createAccessList
+	CapabilitySubscriptions    Capability = "subscriptions"        // eth_subscribe（需要 WebSocket 或 IPC 连接）
+)
+
+// capabilityProbes 每种功能的探测调用（都是不修改状态、开销很小的调用，结果无关紧要，只看节点是否识别该方法）
+var capabilityProbes = []struct {
+	capability Capability
+	method     string
+	args       func() []interface{}
+}{
+	{CapabilityDebug, "debug_traceTransaction", func() []interface{} { return []interface{}{common.Hash{}, map[string]interface{}{}} }},
+	{CapabilityTrace, "trace_transaction", func() []interface{} { return []interface{}{common.Hash{}} }},
+	{CapabilityTxPool, "txpool_status", func() []interface{} { return nil }},
+	{CapabilityFeeHistory, "eth_feeHistory", func() []interface{} { return []interface{}{"0x1", "latest", []float64{}} }},
+	{CapabilityBlockReceipts, "eth_getBlockReceipts", func() []interface{} { return []interface{}{"0x0"} }},
+	{CapabilityCreateAccessList, "eth_createAccessList", func() []interface{} {
+		return []interface{}{map[string]interface{}{"from": common.Address{}, "to": common.Address{}}, "latest"}
+	}},
+}
+
+// Capabilities 节点功能探测结果
+type Capabilities struct {
+	supported  map[Capability]bool
+	DetectedAt time.Time // 探测时间
+}
+
+// Has 判断节点是否支持指定功能
+func (c *Capabilities) Has(capability Capability) bool {
+	return c.supported[capability]
+}
+
+// Require 节点不支持指定功能时返回包装了 ErrUnsupportedByEndpoint 的错误
+func (c *Capabilities) Require(capability Capability) error {
+	if c.Has(capability) {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrUnsupportedByEndpoint, capability)
+}
+
+// Supported 返回节点支持的全部功能（按名称排序）
+func (c *Capabilities) Supported() []Capability {
+	list := make([]Capability, 0, len(c.supported))
+	for capability, ok := range c.supported {
+		if ok {
+			list = append(list, capability)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i] < list[j] })
+	return list
+}
+
+// DetectCapabilities 逐个调用各功能的探测方法，判断节点支持哪些可选功能
+// 节点返回 JSON-RPC 错误（如交易不存在、参数无效）但不是"方法不存在"时视为支持；
+// 返回"方法不存在"、-32004 或 HTTP 4xx（许多托管节点以此拒绝未开放的方法）时视为不支持
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//
+// 返回：
+//   - *Capabilities: 探测结果
+//   - error: 如果探测过程中出现网络错误等无法判断的错误则返回错误
+//
+// 使用示例：
+//
+//	caps, err := DetectCapabilities(ctx, provider)
+//	if caps.Has(CapabilityDebug) {
+//	    diff, err := GetStateDiff(ctx, provider, txHash)
+//	}
+func DetectCapabilities(ctx context.Context, ep EtherProvider) (*Capabilities, error) {
+	caps := &Capabilities{supported: make(map[Capability]bool, len(capabilityProbes)+1), DetectedAt: time.Now()}
+	rc := ep.GetRpcClient()
+	for _, probe := range capabilityProbes {
+		var result interface{}
+		supported, err := probeResult(rc.CallContext(ctx, &result, probe.method, probe.args()...))
+		if err != nil {
+			return nil, fmt.Errorf("failed to probe %s: %w", probe.method, err)
+		}
+		caps.supported[probe.capability] = supported
+	}
+	caps.supported[CapabilitySubscriptions] = rc.SupportsSubscriptions()
+	return caps, nil
+}
+
+// probeResult 根据探测调用的错误判断方法是否被支持
+func probeResult(err error) (bool, error) {
+	if err == nil {
+		return true, nil
+	}
+	if isMethodNotFound(err) {
+		return false, nil
+	}
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		if httpErr.StatusCode >= 400 && httpErr.StatusCode < 500 && httpErr.StatusCode != http.StatusTooManyRequests {
+			return false, nil
+		}
+		return false, err
+	}
+	var rpcErr interface{ ErrorCode() int }
+	if errors.As(err, &rpcErr) {
+		// 方法存在，只是探测参数没有意义（如交易不存在）
+		return true, nil
+	}
+	return false, err
+}
+
+// DetectCapabilities 探测节点支持的可选功能，并保存结果供 GetStateDiff、访问列表优化等功能选择实现方式
+// 参数说明：
+//   - ctx: 上下文对象
+//
+// 返回：
+//   - *Capabilities: 探测结果
+//   - error: 如果探测失败则返回错误（此时保留之前的探测结果）
+func (k *Kit) DetectCapabilities(ctx context.Context) (*Capabilities, error) {
+	caps, err := DetectCapabilities(ctx, k.EtherProvider)
+	if err != nil {
+		return nil, err
+	}
+	k.capabilities.Store(caps)
+	return caps, nil
+}
+
+// Capabilities 返回最近一次 DetectCapabilities 的结果（尚未探测时返回 nil）
+func (k *Kit) Capabilities() *Capabilities {
+	return k.capabilities.Load()
+}
+
+// capabilityKnownMissing 判断节点已被探测且确定不支持指定功能
+func (k *Kit) capabilityKnownMissing(capability Capability) bool {
+	caps := k.capabilities.Load()
+	return caps != nil && !caps.Has(capability)
+}
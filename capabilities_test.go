@@ -0,0 +1,132 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+func TestProbeResult(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		wantSupported bool
+		wantErr       bool
+	}{
+		{name: "success", err: nil, wantSupported: true},
+		{name: "method not found", err: &mockDataError{code: -32601, msg: "the method debug_traceTransaction does not exist/is not available"}, wantSupported: false},
+		{name: "method not supported code", err: &mockDataError{code: -32004, msg: "method not allowed"}, wantSupported: false},
+		{name: "unsupported method message", err: &mockDataError{code: -32600, msg: "Unsupported method: trace_transaction"}, wantSupported: false},
+		{name: "invalid probe argument", err: &mockDataError{code: -32000, msg: "transaction not found"}, wantSupported: true},
+		{name: "http forbidden", err: rpc.HTTPError{StatusCode: http.StatusForbidden, Status: "403 Forbidden"}, wantSupported: false},
+		{name: "http rate limited", err: rpc.HTTPError{StatusCode: http.StatusTooManyRequests, Status: "429 Too Many Requests"}, wantErr: true},
+		{name: "network error", err: errors.New("connection refused"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			supported, err := probeResult(tt.err)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("probeResult() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if supported != tt.wantSupported {
+				t.Errorf("probeResult() = %v, expected %v", supported, tt.wantSupported)
+			}
+		})
+	}
+}
+
+func TestDetectCapabilities(t *testing.T) {
+	// 节点开放 debug 和 eth_feeHistory，trace_transaction 因交易不存在报错，其余方法不存在
+	server := newMockRPCServer(t, map[string]mockRPCHandler{
+		"eth_chainId": mockResult("0x1"),
+		"debug_traceTransaction": func(params []json.RawMessage) (interface{}, error) {
+			return nil, &mockRPCError{Code: -32000, Message: "transaction 0x0 not found"}
+		},
+		"trace_transaction": func(params []json.RawMessage) (interface{}, error) {
+			return nil, &mockRPCError{Code: -32000, Message: "transaction not found"}
+		},
+		"eth_feeHistory": mockResult(map[string]interface{}{"oldestBlock": "0x1", "baseFeePerGas": []string{"0x1", "0x1"}}),
+	})
+	kit := newMockKit(t, server, WithAccessListOptimization())
+	ctx := context.Background()
+
+	if kit.Capabilities() != nil {
+		t.Fatal("Capabilities() before detection expected nil")
+	}
+	caps, err := kit.DetectCapabilities(ctx)
+	if err != nil {
+		t.Fatalf("DetectCapabilities() failed: %v", err)
+	}
+	want := map[Capability]bool{
+		CapabilityDebug:            true,
+		CapabilityTrace:            true,
+		CapabilityTxPool:           false,
+		CapabilityFeeHistory:       true,
+		CapabilityBlockReceipts:    false,
+		CapabilityCreateAccessList: false,
+		CapabilitySubscriptions:    false, // HTTP 连接不支持订阅
+	}
+	for capability, supported := range want {
+		if caps.Has(capability) != supported {
+			t.Errorf("Has(%s) = %v, expected %v", capability, caps.Has(capability), supported)
+		}
+	}
+	if got := caps.Supported(); len(got) != 3 || got[0] != CapabilityDebug || got[1] != CapabilityFeeHistory || got[2] != CapabilityTrace {
+		t.Errorf("Supported() = %v", got)
+	}
+	if err := caps.Require(CapabilityTxPool); !errors.Is(err, ErrUnsupportedByEndpoint) {
+		t.Errorf("Require(txpool) error = %v, want ErrUnsupportedByEndpoint", err)
+	}
+	if err := caps.Require(CapabilityDebug); err != nil {
+		t.Errorf("Require(debug) failed: %v", err)
+	}
+	if kit.Capabilities() != caps {
+		t.Error("Capabilities() should return the stored detection result")
+	}
+
+	// 已知节点不支持 eth_createAccessList 时跳过访问列表优化
+	server.handlers["eth_getTransactionCount"] = mockResult("0x5")
+	server.handlers["eth_gasPrice"] = mockResult("0x3b9aca00")
+	server.handlers["eth_estimateGas"] = mockResult("0xfde8")
+	server.handlers["eth_getBalance"] = mockResult("0xde0b6b3a7640000")
+	server.handlers["eth_sendRawTransaction"] = mockResult(common.Hash{})
+	probes := server.callCount("eth_createAccessList")
+	if _, err := kit.SendTx(ctx, common.HexToAddress("0x01"), 0, 0, nil, nil, []byte{0x01}); err != nil {
+		t.Fatalf("SendTx() failed: %v", err)
+	}
+	if server.callCount("eth_createAccessList") != probes {
+		t.Error("SendTx() should skip access list optimization on endpoints without eth_createAccessList")
+	}
+}
+
+func TestStateDiffStrategySelection(t *testing.T) {
+	replay := json.RawMessage(`{"stateDiff": {}}`)
+	server := newMockRPCServer(t, map[string]mockRPCHandler{
+		"eth_chainId":             mockResult("0x1"),
+		"trace_replayTransaction": mockResult(replay),
+	})
+	kit := newMockKit(t, server)
+	ctx := context.Background()
+
+	kit.capabilities.Store(&Capabilities{supported: map[Capability]bool{CapabilityTrace: true}})
+	if _, err := kit.GetStateDiff(ctx, common.Hash{}); err != nil {
+		t.Fatalf("GetStateDiff() failed: %v", err)
+	}
+	if server.callCount("debug_traceTransaction") != 0 {
+		t.Error("GetStateDiff() should not call debug_traceTransaction when debug is unsupported")
+	}
+
+	kit.capabilities.Store(&Capabilities{supported: map[Capability]bool{}})
+	_, err := kit.GetStateDiff(ctx, common.Hash{})
+	if !errors.Is(err, ErrStateDiffUnsupported) || !errors.Is(err, ErrUnsupportedByEndpoint) {
+		t.Errorf("GetStateDiff() error = %v, want ErrStateDiffUnsupported", err)
+	}
+	if server.callCount("trace_replayTransaction") != 1 {
+		t.Errorf("trace_replayTransaction called %d times, expected 1", server.callCount("trace_replayTransaction"))
+	}
+}
@@ -0,0 +1,268 @@
+package etherkit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+//############ Code Changes ############
+
+// EIP-1967 代理合约的标准存储槽
+var (
+	// EIP1967ImplementationSlot 实现合约地址槽：bytes32(uint256(keccak256("eip1967.proxy.implementation")) - 1)
+	EIP1967ImplementationSlot = common.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+	// EIP1967BeaconSlot 信标合约地址槽：bytes32(uint256(keccak256("eip1967.proxy.beacon")) - 1)
+	EIP1967BeaconSlot = common.HexToHash("0xa3f0ad74e5423aebfd80d3ef4346578335a9a72aeaee59ff6cb3582b35133d50")
+)
+
+// DefaultCodeWatchInterval WatchCodeChanges 默认的轮询间隔
+const DefaultCodeWatchInterval = 12 * time.Second
+
+// CodeChangeKind 合约代码变化类型
+type CodeChangeKind int
+
+const (
+	CodeUnchanged         CodeChangeKind = iota // 代码和代理实现都没有变化
+	CodeDeployed                                // 地址上新部署了代码
+	CodeDestroyed                               // 代码被移除（自毁）
+	CodeReplaced                                // 代码被替换（自毁后在同一地址重新部署，即 metamorphic 合约）
+	ImplementationChanged                       // 代码不变，EIP-1967 实现或信标地址变化（代理升级）
+)
+
+// String 返回代码变化类型的名称
+func (k CodeChangeKind) String() string {
+	switch k {
+	case CodeUnchanged:
+		return "unchanged"
+	case CodeDeployed:
+		return "deployed"
+	case CodeDestroyed:
+		return "destroyed"
+	case CodeReplaced:
+		return "replaced"
+	case ImplementationChanged:
+		return "implementation-changed"
+	default:
+		return fmt.Sprintf("CodeChangeKind(%d)", int(k))
+	}
+}
+
+// CodeSnapshot 合约在某个区块的代码状态
+type CodeSnapshot struct {
+	Block          BlockRef       // 查询的区块
+	CodeHash       common.Hash    // 代码的 keccak256 哈希（没有代码时为零值）
+	CodeSize       int            // 代码长度（字节）
+	Implementation common.Address // EIP-1967 实现合约地址（非代理合约为零地址）
+	Beacon         common.Address // EIP-1967 信标合约地址（非信标代理为零地址）
+}
+
+// HasCode 判断快照时地址上是否存在代码
+func (s CodeSnapshot) HasCode() bool {
+	return s.CodeSize > 0
+}
+
+// CodeChange 合约在两个区块之间的代码变化
+type CodeChange struct {
+	Address common.Address // 合约地址
+	Kind    CodeChangeKind // 变化类型（代码变化优先于代理实现变化）
+	Before  CodeSnapshot   // 较早区块的状态
+	After   CodeSnapshot   // 较晚区块的状态
+}
+
+// SnapshotCodeAt 查询合约在指定区块的代码哈希和 EIP-1967 代理槽位
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者（查询历史区块需要归档节点）
+//   - address: 合约地址
+//   - block: 区块引用
+//
+// 返回：
+//   - CodeSnapshot: 代码状态
+//   - error: 如果查询失败则返回错误
+func SnapshotCodeAt(ctx context.Context, ep EtherProvider, address common.Address, block BlockRef) (CodeSnapshot, error) {
+	rc := ep.GetRpcClient()
+	snapshot := CodeSnapshot{Block: block}
+	var code hexutil.Bytes
+	if err := rc.CallContext(ctx, &code, "eth_getCode", address, block.rpcArg()); err != nil {
+		return CodeSnapshot{}, fmt.Errorf("failed to get code at %s: %w", block, err)
+	}
+	if len(code) == 0 {
+		return snapshot, nil
+	}
+	snapshot.CodeHash = crypto.Keccak256Hash(code)
+	snapshot.CodeSize = len(code)
+
+	for _, slot := range []struct {
+		key common.Hash
+		dst *common.Address
+	}{
+		{EIP1967ImplementationSlot, &snapshot.Implementation},
+		{EIP1967BeaconSlot, &snapshot.Beacon},
+	} {
+		var word hexutil.Bytes
+		if err := rc.CallContext(ctx, &word, "eth_getStorageAt", address, slot.key, block.rpcArg()); err != nil {
+			return CodeSnapshot{}, fmt.Errorf("failed to get storage at %s: %w", block, err)
+		}
+		*slot.dst = common.BytesToAddress(word)
+	}
+	return snapshot, nil
+}
+
+// diffCodeSnapshots 比较两个快照并确定变化类型
+func diffCodeSnapshots(address common.Address, before, after CodeSnapshot) CodeChange {
+	change := CodeChange{Address: address, Before: before, After: after}
+	switch {
+	case !before.HasCode() && after.HasCode():
+		change.Kind = CodeDeployed
+	case before.HasCode() && !after.HasCode():
+		change.Kind = CodeDestroyed
+	case before.CodeHash != after.CodeHash:
+		change.Kind = CodeReplaced
+	case before.Implementation != after.Implementation || before.Beacon != after.Beacon:
+		change.Kind = ImplementationChanged
+	default:
+		change.Kind = CodeUnchanged
+	}
+	return change
+}
+
+// CompareCodeAt 比较合约在两个区块的代码和代理实现，检测代理升级、metamorphic 重新部署或自毁
+// 只比较两个区块的状态，期间发生又恢复的变化（如自毁后以相同代码重新部署）不会被检测到
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者（查询历史区块需要归档节点）
+//   - address: 合约地址
+//   - blockA: 较早的区块
+//   - blockB: 较晚的区块
+//
+// 返回：
+//   - *CodeChange: 代码变化（没有变化时 Kind 为 CodeUnchanged）
+//   - error: 如果查询失败则返回错误
+//
+// 使用示例：
+//
+//	change, err := CompareCodeAt(ctx, provider, pool, BlockAtNumber(auditedAt), BlockAtTag(BlockTagLatest))
+//	if change.Kind != CodeUnchanged {
+//	    log.Printf("%s %s since audit: implementation %s -> %s", pool, change.Kind, change.Before.Implementation, change.After.Implementation)
+//	}
+func CompareCodeAt(ctx context.Context, ep EtherProvider, address common.Address, blockA, blockB BlockRef) (*CodeChange, error) {
+	before, err := SnapshotCodeAt(ctx, ep, address, blockA)
+	if err != nil {
+		return nil, err
+	}
+	after, err := SnapshotCodeAt(ctx, ep, address, blockB)
+	if err != nil {
+		return nil, err
+	}
+	change := diffCodeSnapshots(address, before, after)
+	return &change, nil
+}
+
+// WatchCodeChanges 轮询监控一组合约的代码和代理实现，每次出现新区块时与上一次的状态比较，将变化写入流
+// 用于监控不受自己控制的集成合约（代理升级、metamorphic 重新部署、自毁）
+// 参数说明：
+//   - ctx: 上下文对象（取消后流关闭）
+//   - ep: 以太坊提供者
+//   - addresses: 要监控的合约地址
+//   - interval: 轮询间隔（<= 0 时使用 DefaultCodeWatchInterval）
+//   - buffer: 缓冲区大小（<= 0 使用 DefaultStreamBuffer）
+//
+// 返回：
+//   - *Stream[CodeChange]: 代码变化流（只包含 Kind 不为 CodeUnchanged 的变化；查询失败时流关闭，Err 返回原因）
+//
+// 使用示例：
+//
+//	s := WatchCodeChanges(ctx, provider, []common.Address{router, pool}, 0, 0)
+//	for change := range s.C {
+//	    alert(change.Address, change.Kind)
+//	}
+func WatchCodeChanges(ctx context.Context, ep EtherProvider, addresses []common.Address, interval time.Duration, buffer int) *Stream[CodeChange] {
+	if interval <= 0 {
+		interval = DefaultCodeWatchInterval
+	}
+	return newStream(ctx, buffer, func(ctx context.Context, emit func(CodeChange) error) error {
+		snapshotAll := func(number uint64) (map[common.Address]CodeSnapshot, error) {
+			snapshots := make(map[common.Address]CodeSnapshot, len(addresses))
+			for _, address := range addresses {
+				snapshot, err := SnapshotCodeAt(ctx, ep, address, BlockAtNumber(number))
+				if err != nil {
+					return nil, err
+				}
+				snapshots[address] = snapshot
+			}
+			return snapshots, nil
+		}
+
+		last, err := ep.GetBlockNumber(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get block number: %w", err)
+		}
+		previous, err := snapshotAll(last)
+		if err != nil {
+			return err
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-ticker.C:
+			}
+			head, err := ep.GetBlockNumber(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to get block number: %w", err)
+			}
+			if head <= last {
+				continue
+			}
+			current, err := snapshotAll(head)
+			if err != nil {
+				return err
+			}
+			for _, address := range addresses {
+				change := diffCodeSnapshots(address, previous[address], current[address])
+				if change.Kind == CodeUnchanged {
+					continue
+				}
+				if err := emit(change); err != nil {
+					return err
+				}
+			}
+			last, previous = head, current
+		}
+	})
+}
+
+// CompareCodeAt 比较合约在两个区块的代码和代理实现
+// 参数说明：
+//   - ctx: 上下文对象
+//   - address: 合约地址
+//   - blockA: 较早的区块
+//   - blockB: 较晚的区块
+//
+// 返回：
+//   - *CodeChange: 代码变化（没有变化时 Kind 为 CodeUnchanged）
+//   - error: 如果查询失败则返回错误
+func (k *Kit) CompareCodeAt(ctx context.Context, address common.Address, blockA, blockB BlockRef) (*CodeChange, error) {
+	return CompareCodeAt(ctx, k.EtherProvider, address, blockA, blockB)
+}
+
+// WatchCodeChanges 轮询监控一组合约的代码和代理实现变化
+// 参数说明：
+//   - ctx: 上下文对象（取消后流关闭）
+//   - addresses: 要监控的合约地址
+//   - interval: 轮询间隔（<= 0 时使用 DefaultCodeWatchInterval）
+//   - buffer: 缓冲区大小（<= 0 使用 DefaultStreamBuffer）
+//
+// 返回：
+//   - *Stream[CodeChange]: 代码变化流
+func (k *Kit) WatchCodeChanges(ctx context.Context, addresses []common.Address, interval time.Duration, buffer int) *Stream[CodeChange] {
+	return WatchCodeChanges(ctx, k.EtherProvider, addresses, interval, buffer)
+}
@@ -0,0 +1,151 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// codeState 合约在某个区块的模拟状态
+type codeState struct {
+	code           string
+	implementation common.Address
+}
+
+// newMockCodeServer 按区块号返回 states 中对应的代码和 EIP-1967 实现槽，head 为当前区块号
+func newMockCodeServer(t *testing.T, head *atomic.Uint64, states func(number uint64) codeState) *mockRPCServer {
+	t.Helper()
+	blockNumber := func(raw json.RawMessage) uint64 {
+		var tag string
+		_ = json.Unmarshal(raw, &tag)
+		if tag == "latest" {
+			return head.Load()
+		}
+		number, _ := strconv.ParseUint(tag[2:], 16, 64)
+		return number
+	}
+	return newMockRPCServer(t, map[string]mockRPCHandler{
+		"eth_chainId": mockResult("0x1"),
+		"eth_blockNumber": func(params []json.RawMessage) (interface{}, error) {
+			return "0x" + strconv.FormatUint(head.Load(), 16), nil
+		},
+		"eth_getCode": func(params []json.RawMessage) (interface{}, error) {
+			return states(blockNumber(params[1])).code, nil
+		},
+		"eth_getStorageAt": func(params []json.RawMessage) (interface{}, error) {
+			var slot common.Hash
+			_ = json.Unmarshal(params[1], &slot)
+			if slot == EIP1967ImplementationSlot {
+				return common.BytesToHash(states(blockNumber(params[2])).implementation.Bytes()), nil
+			}
+			return common.Hash{}, nil
+		},
+	})
+}
+
+func TestCompareCodeAt(t *testing.T) {
+	contract := common.HexToAddress("0x5FC8d32690cc91D4c39d9d3abcBD16989F875707")
+	implV1 := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	implV2 := common.HexToAddress("0x2222222222222222222222222222222222222222")
+
+	tests := []struct {
+		name   string
+		before codeState
+		after  codeState
+		want   CodeChangeKind
+	}{
+		{name: "unchanged", before: codeState{code: "0x6080"}, after: codeState{code: "0x6080"}, want: CodeUnchanged},
+		{name: "deployed", before: codeState{code: "0x"}, after: codeState{code: "0x6080"}, want: CodeDeployed},
+		{name: "self-destructed", before: codeState{code: "0x6080"}, after: codeState{code: "0x"}, want: CodeDestroyed},
+		{name: "metamorphic redeploy", before: codeState{code: "0x6080"}, after: codeState{code: "0x6060"}, want: CodeReplaced},
+		{name: "proxy upgrade", before: codeState{code: "0x363d", implementation: implV1}, after: codeState{code: "0x363d", implementation: implV2}, want: ImplementationChanged},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var head atomic.Uint64
+			head.Store(200)
+			server := newMockCodeServer(t, &head, func(number uint64) codeState {
+				if number < 150 {
+					return tt.before
+				}
+				return tt.after
+			})
+			kit := newMockKit(t, server)
+
+			change, err := kit.CompareCodeAt(context.Background(), contract, BlockAtNumber(100), BlockAtTag(BlockTagLatest))
+			if err != nil {
+				t.Fatalf("CompareCodeAt() failed: %v", err)
+			}
+			if change.Kind != tt.want {
+				t.Errorf("Kind = %s, expected %s", change.Kind, tt.want)
+			}
+			if change.After.Implementation != tt.after.implementation {
+				t.Errorf("After.Implementation = %s, expected %s", change.After.Implementation, tt.after.implementation)
+			}
+		})
+	}
+}
+
+func TestWatchCodeChanges(t *testing.T) {
+	proxy := common.HexToAddress("0x5FC8d32690cc91D4c39d9d3abcBD16989F875707")
+	second := common.HexToAddress("0x9fE46736679d2D9a65F0992F2272dE9f3c7fa6e0")
+	implV1 := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	implV2 := common.HexToAddress("0x2222222222222222222222222222222222222222")
+
+	var head atomic.Uint64
+	head.Store(100)
+	// 两个合约共用同一模拟状态：第 102 块起实现升级
+	server := newMockCodeServer(t, &head, func(number uint64) codeState {
+		if number >= 102 {
+			return codeState{code: "0x363d", implementation: implV2}
+		}
+		return codeState{code: "0x363d", implementation: implV1}
+	})
+	kit := newMockKit(t, server)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	s := kit.WatchCodeChanges(ctx, []common.Address{proxy, second}, 10*time.Millisecond, 0)
+	defer s.Stop()
+
+	go func() {
+		for i := 0; i < 3; i++ {
+			time.Sleep(30 * time.Millisecond)
+			head.Add(1)
+		}
+	}()
+
+	var changes []CodeChange
+	for len(changes) < 2 {
+		select {
+		case change, ok := <-s.C:
+			if !ok {
+				t.Fatalf("stream closed early: %v", s.Err())
+			}
+			changes = append(changes, change)
+		case <-ctx.Done():
+			t.Fatalf("timed out waiting for changes, got %d", len(changes))
+		}
+	}
+	for i, want := range []common.Address{proxy, second} {
+		if changes[i].Address != want || changes[i].Kind != ImplementationChanged {
+			t.Errorf("changes[%d] = %s %s, expected %s %s", i, changes[i].Address, changes[i].Kind, want, ImplementationChanged)
+		}
+		if changes[i].Before.Implementation != implV1 || changes[i].After.Implementation != implV2 {
+			t.Errorf("changes[%d] implementation %s -> %s", i, changes[i].Before.Implementation, changes[i].After.Implementation)
+		}
+	}
+
+	// 之后的区块没有变化，不应再有事件
+	select {
+	case change := <-s.C:
+		t.Errorf("unexpected change after upgrade: %+v", change)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
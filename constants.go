@@ -13,6 +13,12 @@ const (
 	OptimismChainID  = 10
 	AvalancheChainID = 43114
 	FantomChainID    = 250
+
+	ArbitrumNovaChainID    = 42170
+	ArbitrumSepoliaChainID = 421614
+	PolygonAmoyChainID     = 80002
+	ZkSyncEraChainID       = 324
+	ZkSyncSepoliaChainID   = 300
 )
 
 // Gas 相关常量
@@ -106,6 +112,17 @@ var (
 	WeiPerGWei  = big.NewInt(GWei)
 )
 
+// GasModel 网络的 Gas 计费模型
+// 不同的 L2 网络在 L2 执行费用之外还会收取 L1 数据费用，需要使用不同的估算方式
+type GasModel string
+
+// Gas 计费模型
+const (
+	GasModelDefault  GasModel = ""         // 标准 EVM 网络，直接使用 eth_estimateGas
+	GasModelArbitrum GasModel = "arbitrum" // Arbitrum 网络，通过 NodeInterface 获取 L1 + L2 的 gas 组成
+	GasModelZkSync   GasModel = "zksync"   // zkSync Era 网络，使用 zks_estimateFee 和 EIP-712（0x71）交易
+)
+
 // 网络配置
 type NetworkConfig struct {
 	ChainID       int64
@@ -113,6 +130,12 @@ type NetworkConfig struct {
 	Symbol        string
 	BlockTime     int // 秒
 	Confirmations int
+	GasModel      GasModel // Gas 计费模型（空值表示标准模型）
+	GasStationURL string   // 链专用的 gas 价格预言机地址（空值表示使用节点的 eth_gasPrice）
+	ExplorerURL   string   // 区块浏览器地址（不带结尾的 /）
+
+	ElasticityMultiplier     uint64 // EIP-1559 弹性系数（0 表示使用以太坊主网的 2）
+	BaseFeeChangeDenominator uint64 // EIP-1559 基础费用变化分母（0 表示使用以太坊主网的 8）
 }
 
 // 预定义网络配置
@@ -123,6 +146,7 @@ var NetworkConfigs = map[int64]NetworkConfig{
 		Symbol:        "ETH",
 		BlockTime:     12,
 		Confirmations: 12,
+		ExplorerURL:   "https://etherscan.io",
 	},
 	GoerliChainID: {
 		ChainID:       GoerliChainID,
@@ -130,6 +154,7 @@ var NetworkConfigs = map[int64]NetworkConfig{
 		Symbol:        "ETH",
 		BlockTime:     12,
 		Confirmations: 3,
+		ExplorerURL:   "https://goerli.etherscan.io",
 	},
 	SepoliaChainID: {
 		ChainID:       SepoliaChainID,
@@ -137,13 +162,27 @@ var NetworkConfigs = map[int64]NetworkConfig{
 		Symbol:        "ETH",
 		BlockTime:     12,
 		Confirmations: 3,
+		ExplorerURL:   "https://sepolia.etherscan.io",
 	},
 	PolygonChainID: {
-		ChainID:       PolygonChainID,
-		Name:          "Polygon",
-		Symbol:        "MATIC",
-		BlockTime:     2,
-		Confirmations: 20,
+		ChainID:                  PolygonChainID,
+		Name:                     "Polygon",
+		Symbol:                   "MATIC",
+		BlockTime:                2,
+		Confirmations:            20,
+		GasStationURL:            PolygonGasStationURL,
+		ExplorerURL:              "https://polygonscan.com",
+		BaseFeeChangeDenominator: 16,
+	},
+	PolygonAmoyChainID: {
+		ChainID:                  PolygonAmoyChainID,
+		Name:                     "Polygon Amoy Testnet",
+		Symbol:                   "POL",
+		BlockTime:                2,
+		Confirmations:            5,
+		GasStationURL:            PolygonAmoyGasStationURL,
+		ExplorerURL:              "https://amoy.polygonscan.com",
+		BaseFeeChangeDenominator: 16,
 	},
 	BSCChainID: {
 		ChainID:       BSCChainID,
@@ -151,5 +190,111 @@ var NetworkConfigs = map[int64]NetworkConfig{
 		Symbol:        "BNB",
 		BlockTime:     3,
 		Confirmations: 15,
+		ExplorerURL:   "https://bscscan.com",
+	},
+	ArbitrumChainID: {
+		ChainID:       ArbitrumChainID,
+		Name:          "Arbitrum One",
+		Symbol:        "ETH",
+		BlockTime:     1,
+		Confirmations: 20,
+		GasModel:      GasModelArbitrum,
+		ExplorerURL:   "https://arbiscan.io",
+	},
+	ArbitrumNovaChainID: {
+		ChainID:       ArbitrumNovaChainID,
+		Name:          "Arbitrum Nova",
+		Symbol:        "ETH",
+		BlockTime:     1,
+		Confirmations: 20,
+		GasModel:      GasModelArbitrum,
+		ExplorerURL:   "https://nova.arbiscan.io",
+	},
+	ArbitrumSepoliaChainID: {
+		ChainID:       ArbitrumSepoliaChainID,
+		Name:          "Arbitrum Sepolia",
+		Symbol:        "ETH",
+		BlockTime:     1,
+		Confirmations: 5,
+		GasModel:      GasModelArbitrum,
+		ExplorerURL:   "https://sepolia.arbiscan.io",
 	},
+	OptimismChainID: {
+		ChainID:                  OptimismChainID,
+		Name:                     "Optimism",
+		Symbol:                   "ETH",
+		BlockTime:                2,
+		Confirmations:            20,
+		ExplorerURL:              "https://optimistic.etherscan.io",
+		ElasticityMultiplier:     6,
+		BaseFeeChangeDenominator: 250,
+	},
+	AvalancheChainID: {
+		ChainID:       AvalancheChainID,
+		Name:          "Avalanche C-Chain",
+		Symbol:        "AVAX",
+		BlockTime:     2,
+		Confirmations: 12,
+		ExplorerURL:   "https://snowtrace.io",
+	},
+	FantomChainID: {
+		ChainID:       FantomChainID,
+		Name:          "Fantom Opera",
+		Symbol:        "FTM",
+		BlockTime:     1,
+		Confirmations: 12,
+		ExplorerURL:   "https://ftmscan.com",
+	},
+	ZkSyncEraChainID: {
+		ChainID:       ZkSyncEraChainID,
+		Name:          "zkSync Era",
+		Symbol:        "ETH",
+		BlockTime:     1,
+		Confirmations: 20,
+		GasModel:      GasModelZkSync,
+		ExplorerURL:   "https://explorer.zksync.io",
+	},
+	ZkSyncSepoliaChainID: {
+		ChainID:       ZkSyncSepoliaChainID,
+		Name:          "zkSync Sepolia Testnet",
+		Symbol:        "ETH",
+		BlockTime:     1,
+		Confirmations: 5,
+		GasModel:      GasModelZkSync,
+		ExplorerURL:   "https://sepolia.explorer.zksync.io",
+	},
+}
+
+// GetNetworkConfig 从链注册表中获取网络配置
+// 参数说明：
+//   - chainID: 链 ID
+//
+// 返回：
+//   - NetworkConfig: 网络配置
+//   - bool: 是否找到对应的配置
+func GetNetworkConfig(chainID int64) (NetworkConfig, bool) {
+	cfg, ok := NetworkConfigs[chainID]
+	return cfg, ok
+}
+
+// RegisterNetworkConfig 向链注册表中注册（或覆盖）网络配置
+// 适用于自定义链、私有链或需要调整默认参数的场景
+// 参数说明：
+//   - cfg: 网络配置（以 cfg.ChainID 作为键）
+//
+// 注意：此方法修改全局注册表，不是并发安全的，建议在程序初始化阶段调用
+func RegisterNetworkConfig(cfg NetworkConfig) {
+	NetworkConfigs[cfg.ChainID] = cfg
+}
+
+// getGasModel 获取指定链的 Gas 计费模型
+// 链 ID 为 nil 或未在注册表中找到时返回标准模型
+func getGasModel(chainID *big.Int) GasModel {
+	if chainID == nil || !chainID.IsInt64() {
+		return GasModelDefault
+	}
+	if cfg, ok := NetworkConfigs[chainID.Int64()]; ok {
+		return cfg.GasModel
+	}
+	return GasModelDefault
 }
@@ -0,0 +1,158 @@
+package etherkit
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+//############ Constructor Arguments ############
+
+// DecodeConstructorArgsFromInput 从部署交易的数据中解码构造函数参数
+// 提供创建字节码时直接去掉字节码前缀；不提供时从数据末尾查找能按构造函数定义完整解码并重新编码一致的最短片段
+// 参数说明：
+//   - contractAbi: 合约 ABI（使用其中的构造函数定义）
+//   - input: 部署交易的数据（创建字节码 + ABI 编码的构造函数参数）
+//   - bytecode: 合约创建字节码（nil 表示自动查找参数位置）
+//
+// 返回：
+//   - []interface{}: 构造函数参数（按定义顺序）
+//   - error: 如果数据与字节码或构造函数定义不匹配则返回错误
+func DecodeConstructorArgsFromInput(contractAbi abi.ABI, input, bytecode []byte) ([]interface{}, error) {
+	inputs := contractAbi.Constructor.Inputs
+	if bytecode != nil {
+		if !bytes.HasPrefix(input, bytecode) {
+			return nil, errors.New("deployment input does not start with the given bytecode")
+		}
+		return inputs.Unpack(input[len(bytecode):])
+	}
+	if len(inputs) == 0 {
+		return []interface{}{}, nil
+	}
+	// ABI 编码按 32 字节对齐，从静态部分的最小长度开始向前扩展，直到找到完整编码的参数
+	for size := 32 * len(inputs); size <= len(input); size += 32 {
+		tail := input[len(input)-size:]
+		values, err := inputs.Unpack(tail)
+		if err != nil {
+			continue
+		}
+		if encoded, err := inputs.Pack(values...); err == nil && bytes.Equal(encoded, tail) {
+			return values, nil
+		}
+	}
+	return nil, errors.New("no ABI-encoded constructor arguments found at the end of the deployment input")
+}
+
+// FindCreationTx 查找直接部署合约的交易（在 FindDeploymentBlock 找到的区块中匹配发送者和 nonce 推导出的合约地址）
+// 通过工厂合约（CREATE2 或内部 CREATE）部署的合约没有对应的部署交易，返回错误
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者（需要能查询历史状态）
+//   - contract: 合约地址
+//
+// 返回：
+//   - *types.Transaction: 部署交易
+//   - error: 如果找不到部署交易或查询失败则返回错误
+func FindCreationTx(ctx context.Context, ep EtherProvider, contract common.Address) (*types.Transaction, error) {
+	number, err := FindDeploymentBlock(ctx, ep, contract)
+	if err != nil {
+		return nil, err
+	}
+	block, err := providerExt[BlockRefReader](ep).GetBlockAt(ctx, BlockAtNumber(number))
+	if err != nil {
+		return nil, err
+	}
+	for _, tx := range block.Transactions() {
+		if tx.To() != nil {
+			continue
+		}
+		from, err := txSender(tx)
+		if err != nil {
+			continue
+		}
+		if crypto.CreateAddress(from, tx.Nonce()) == contract {
+			return tx, nil
+		}
+	}
+	return nil, fmt.Errorf("no direct creation transaction for %s in block %d (deployed by a factory contract?)", contract.Hex(), number)
+}
+
+// DecodeConstructorArgs 查找合约的部署交易并解码构造函数参数（用于审计和重新验证合约）
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - contractAbi: 合约 ABI
+//   - contract: 合约地址
+//   - bytecode: 合约创建字节码（nil 表示自动查找参数位置，见 DecodeConstructorArgsFromInput）
+//
+// 返回：
+//   - []interface{}: 构造函数参数
+//   - error: 如果找不到部署交易或解码失败则返回错误
+//
+// 使用示例：
+//
+//	args, err := DecodeConstructorArgs(ctx, provider, tokenAbi, token, nil)
+//	named := NamedOutputs(tokenAbi.Constructor.Inputs, args)
+func DecodeConstructorArgs(ctx context.Context, ep EtherProvider, contractAbi abi.ABI, contract common.Address, bytecode []byte) ([]interface{}, error) {
+	tx, err := FindCreationTx(ctx, ep, contract)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeConstructorArgsFromInput(contractAbi, tx.Data(), bytecode)
+}
+
+// DecodeConstructorArgsFromTx 根据部署交易哈希解码构造函数参数
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - contractAbi: 合约 ABI
+//   - txHash: 部署交易哈希
+//   - bytecode: 合约创建字节码（nil 表示自动查找参数位置）
+//
+// 返回：
+//   - []interface{}: 构造函数参数
+//   - error: 如果交易不是合约部署交易或解码失败则返回错误
+func DecodeConstructorArgsFromTx(ctx context.Context, ep EtherProvider, contractAbi abi.ABI, txHash common.Hash, bytecode []byte) ([]interface{}, error) {
+	tx, _, err := ep.GetTransactionByHash(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+	if tx.To() != nil {
+		return nil, fmt.Errorf("transaction %s is not a contract creation", txHash.Hex())
+	}
+	return DecodeConstructorArgsFromInput(contractAbi, tx.Data(), bytecode)
+}
+
+// DecodeConstructorArgs 查找合约的部署交易并解码构造函数参数
+// 参数说明：
+//   - ctx: 上下文对象
+//   - contractAbi: 合约 ABI
+//   - contract: 合约地址
+//   - bytecode: 合约创建字节码（nil 表示自动查找参数位置）
+//
+// 返回：
+//   - []interface{}: 构造函数参数
+//   - error: 如果找不到部署交易或解码失败则返回错误
+func (k *Kit) DecodeConstructorArgs(ctx context.Context, contractAbi abi.ABI, contract common.Address, bytecode []byte) ([]interface{}, error) {
+	return DecodeConstructorArgs(ctx, k.EtherProvider, contractAbi, contract, bytecode)
+}
+
+// DecodeConstructorArgsFromTx 根据部署交易哈希解码构造函数参数
+// 参数说明：
+//   - ctx: 上下文对象
+//   - contractAbi: 合约 ABI
+//   - txHash: 部署交易哈希
+//   - bytecode: 合约创建字节码（nil 表示自动查找参数位置）
+//
+// 返回：
+//   - []interface{}: 构造函数参数
+//   - error: 如果交易不是合约部署交易或解码失败则返回错误
+func (k *Kit) DecodeConstructorArgsFromTx(ctx context.Context, contractAbi abi.ABI, txHash common.Hash, bytecode []byte) ([]interface{}, error) {
+	return DecodeConstructorArgsFromTx(ctx, k.EtherProvider, contractAbi, txHash, bytecode)
+}
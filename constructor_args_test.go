@@ -0,0 +1,173 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+const tokenConstructorABI = `[{"type":"constructor","inputs":[{"name":"name","type":"string"},{"name":"supply","type":"uint256"},{"name":"owner","type":"address"}]}]`
+
+// mockBlockWithTxs 构造带完整交易列表的区块 JSON（eth_getBlockByNumber 的返回值）
+func mockBlockWithTxs(t *testing.T, number uint64, txs types.Transactions) map[string]interface{} {
+	t.Helper()
+	header := &types.Header{
+		Number:     new(big.Int).SetUint64(number),
+		Difficulty: big.NewInt(0),
+		UncleHash:  types.EmptyUncleHash,
+		TxHash:     types.DeriveSha(txs, trie.NewStackTrie(nil)),
+	}
+	raw, err := json.Marshal(header)
+	if err != nil {
+		t.Fatalf("json.Marshal() failed: %v", err)
+	}
+	var block map[string]interface{}
+	if err := json.Unmarshal(raw, &block); err != nil {
+		t.Fatalf("json.Unmarshal() failed: %v", err)
+	}
+	block["transactions"] = txs
+	block["uncles"] = []interface{}{}
+	return block
+}
+
+func TestDecodeConstructorArgsFromInput(t *testing.T) {
+	tokenAbi, _ := abi.JSON(strings.NewReader(tokenConstructorABI))
+	noArgsAbi, _ := abi.JSON(strings.NewReader(`[{"type":"constructor","inputs":[]}]`))
+	owner := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	supply, _ := new(big.Int).SetString("1000000000000000000000000", 10)
+	bytecode := common.FromHex("0x608060405234801561001057600080fd5b50")
+	args, _ := tokenAbi.Pack("", "Test Token", supply, owner)
+	input := append(common.CopyBytes(bytecode), args...)
+
+	tests := []struct {
+		name     string
+		abi      abi.ABI
+		input    []byte
+		bytecode []byte
+		wantErr  bool
+	}{
+		{name: "strip bytecode", abi: tokenAbi, input: input, bytecode: bytecode},
+		{name: "auto detect", abi: tokenAbi, input: input},
+		{name: "bytecode mismatch", abi: tokenAbi, input: input, bytecode: common.FromHex("0x6080604052600a"), wantErr: true},
+		{name: "truncated args", abi: tokenAbi, input: input[:len(input)-16], wantErr: true},
+		{name: "no constructor args", abi: noArgsAbi, input: bytecode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := DecodeConstructorArgsFromInput(tt.abi, tt.input, tt.bytecode)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("DecodeConstructorArgsFromInput() = %v, expected error", values)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DecodeConstructorArgsFromInput() failed: %v", err)
+			}
+			if len(values) != len(tt.abi.Constructor.Inputs) {
+				t.Fatalf("len(values) = %d, expected %d", len(values), len(tt.abi.Constructor.Inputs))
+			}
+			if len(values) == 0 {
+				return
+			}
+			if values[0] != "Test Token" || values[1].(*big.Int).Cmp(supply) != 0 || values[2] != owner {
+				t.Errorf("DecodeConstructorArgsFromInput() = %v", values)
+			}
+		})
+	}
+}
+
+func TestDecodeConstructorArgs(t *testing.T) {
+	tokenAbi, _ := abi.JSON(strings.NewReader(tokenConstructorABI))
+	deployer, _ := GetTestAccount(1)
+	owner := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	args, _ := tokenAbi.Pack("", "Test Token", big.NewInt(1000), owner)
+	input := append(common.FromHex("0x6080604052"), args...)
+
+	signer := types.LatestSignerForChainID(big.NewInt(1))
+	creation := types.MustSignNewTx(deployer.PrivateKey, signer, &types.LegacyTx{Nonce: 3, Gas: 1000000, GasPrice: big.NewInt(1e9), Data: input})
+	transfer := types.MustSignNewTx(deployer.PrivateKey, signer, &types.LegacyTx{Nonce: 4, Gas: 21000, GasPrice: big.NewInt(1e9), To: &owner, Value: big.NewInt(1)})
+	contract := crypto.CreateAddress(deployer.Address, 3)
+	const deployedAt = 7
+
+	tests := []struct {
+		name    string
+		txs     types.Transactions
+		wantErr bool
+	}{
+		{name: "creation tx in block", txs: types.Transactions{transfer, creation}},
+		// 合约由工厂合约部署，区块中没有直接部署交易
+		{name: "factory deployment", txs: types.Transactions{transfer}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			block := mockBlockWithTxs(t, deployedAt, tt.txs)
+			server := newMockRPCServer(t, map[string]mockRPCHandler{
+				"eth_chainId":          mockResult("0x1"),
+				"eth_blockNumber":      mockResult("0x10"),
+				"eth_getBlockByNumber": mockResult(block),
+				"eth_getCode": func(params []json.RawMessage) (interface{}, error) {
+					var tag string
+					_ = json.Unmarshal(params[1], &tag)
+					number, _ := strconv.ParseUint(tag[2:], 16, 64)
+					if number >= deployedAt {
+						return "0x6080", nil
+					}
+					return "0x", nil
+				},
+			})
+			kit := newMockKit(t, server)
+
+			values, err := kit.DecodeConstructorArgs(context.Background(), tokenAbi, contract, nil)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("DecodeConstructorArgs() = %v, expected error", values)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DecodeConstructorArgs() failed: %v", err)
+			}
+			if values[0] != "Test Token" || values[1].(*big.Int).Int64() != 1000 || values[2] != owner {
+				t.Errorf("DecodeConstructorArgs() = %v", values)
+			}
+		})
+	}
+
+	t.Run("from tx hash", func(t *testing.T) {
+		server := newMockRPCServer(t, map[string]mockRPCHandler{
+			"eth_chainId": mockResult("0x1"),
+			"eth_getTransactionByHash": func(params []json.RawMessage) (interface{}, error) {
+				var hash common.Hash
+				_ = json.Unmarshal(params[0], &hash)
+				if hash == transfer.Hash() {
+					return transfer, nil
+				}
+				return creation, nil
+			},
+		})
+		kit := newMockKit(t, server)
+
+		values, err := kit.DecodeConstructorArgsFromTx(context.Background(), tokenAbi, creation.Hash(), common.FromHex("0x6080604052"))
+		if err != nil {
+			t.Fatalf("DecodeConstructorArgsFromTx() failed: %v", err)
+		}
+		if values[2] != owner {
+			t.Errorf("owner = %v, expected %v", values[2], owner)
+		}
+		if _, err := kit.DecodeConstructorArgsFromTx(context.Background(), tokenAbi, transfer.Hash(), nil); err == nil {
+			t.Error("DecodeConstructorArgsFromTx() on a transfer should fail")
+		}
+	})
+}
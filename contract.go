@@ -1,9 +1,11 @@
 package etherkit
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
 )
@@ -59,12 +61,54 @@ func GetEventTopic(event string) string {
 	return crypto.Keccak256Hash([]byte(event)).String()
 }
 
+// GetEventTopicFromABI 从已解析的 ABI 中获取事件的 topic，避免手写事件签名出错
+// 参数说明：
+//   - contractAbi: 合约 ABI 对象
+//   - name: 事件名（如 "Transfer"；重载事件使用 go-ethereum 生成的名称如 "Transfer0"，或直接传入签名 "Transfer(address,address,uint256)"）
+//
+// 返回：
+//   - common.Hash: 事件 topic
+//   - error: 如果 ABI 中没有该事件则返回错误
+//
+// 示例：
+//   - topic, err := GetEventTopicFromABI(erc20Abi, "Transfer")
+func GetEventTopicFromABI(contractAbi abi.ABI, name string) (common.Hash, error) {
+	if event, ok := contractAbi.Events[name]; ok {
+		return event.ID, nil
+	}
+	if strings.Contains(name, "(") {
+		for _, event := range contractAbi.Events {
+			if event.Sig == strings.ReplaceAll(name, " ", "") {
+				return event.ID, nil
+			}
+		}
+	}
+	return common.Hash{}, fmt.Errorf("event %q not found in ABI", name)
+}
+
+// GetEventByTopic 根据日志的第一个 topic（topic0）在 ABI 中查找对应的事件
+// 参数说明：
+//   - contractAbi: 合约 ABI 对象
+//   - topic: 事件 topic（通常为 log.Topics[0]）
+//
+// 返回：
+//   - *abi.Event: 事件定义（可用于解码日志）
+//   - error: 如果 ABI 中没有该事件则返回错误
+func GetEventByTopic(contractAbi abi.ABI, topic common.Hash) (*abi.Event, error) {
+	event, err := contractAbi.EventByID(topic)
+	if err != nil {
+		return nil, fmt.Errorf("no event with topic %s in ABI", topic.Hex())
+	}
+	return event, nil
+}
+
 // BuildContractInputData 构建合约调用的输入数据
 // 将函数名和参数打包成合约调用所需的字节数据
 // 参数说明：
 //   - contract: 合约 ABI 对象
 //   - name: 函数名（如 "transfer", "balanceOf"）
-//   - args: 函数参数（按函数定义顺序传入）
+//   - args: 函数参数（按函数定义顺序传入；元组参数可传入字段名或 abi 标签与组件名对应的结构体、结构体指针、
+//     以组件名为键的 map[string]interface{} 或按顺序排列的 []interface{}，整数参数可使用任意 Go 整数类型）
 //
 // 返回：
 //   - []byte: 合约调用数据（包含函数选择器和编码后的参数）
@@ -73,6 +117,19 @@ func GetEventTopic(event string) string {
 // 示例：
 //   - data, err := BuildContractInputData(abi, "transfer", toAddress, amount)
 //   - data, err := BuildContractInputData(abi, "balanceOf", userAddress)
+//   - data, err := BuildContractInputData(seaportAbi, "fulfillOrder", &order, fulfillerConduitKey) // order 为带 abi 标签的结构体
 func BuildContractInputData(contract abi.ABI, name string, args ...interface{}) ([]byte, error) {
+	inputs := contract.Constructor.Inputs
+	if name != "" {
+		method, ok := contract.Methods[name]
+		if !ok {
+			return nil, fmt.Errorf("method '%s' not found", name)
+		}
+		inputs = method.Inputs
+	}
+	args, err := normalizeABIArgs(inputs, args)
+	if err != nil {
+		return nil, err
+	}
 	return contract.Pack(name, args...)
 }
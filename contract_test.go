@@ -157,6 +157,41 @@ func TestGetEventTopic(t *testing.T) {
 	}
 }
 
+func TestGetEventTopicFromABI(t *testing.T) {
+	transfer := common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	tests := []struct {
+		name     string
+		event    string
+		expected common.Hash
+		wantErr  bool
+	}{
+		{"by name", "Transfer", transfer, false},
+		{"by signature", "Transfer(address, address, uint256)", transfer, false},
+		{"unknown event", "Mint", common.Hash{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetEventTopicFromABI(erc20ABI, tt.event)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetEventTopicFromABI() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Errorf("GetEventTopicFromABI(%s) = %s, expected %s", tt.event, got.Hex(), tt.expected.Hex())
+			}
+		})
+	}
+
+	// 根据 topic 反查事件
+	event, err := GetEventByTopic(erc20ABI, transfer)
+	if err != nil || event.Name != "Transfer" {
+		t.Errorf("GetEventByTopic() = %v, %v, expected Transfer", event, err)
+	}
+	if _, err := GetEventByTopic(erc20ABI, common.HexToHash("0x01")); err == nil {
+		t.Error("GetEventByTopic() expected error for unknown topic")
+	}
+}
+
 func TestBuildContractInputData(t *testing.T) {
 	// 创建测试ABI
 	abiString := `[
@@ -0,0 +1,70 @@
+package etherkit
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/shopspring/decimal"
+)
+
+//############ Cost Estimation ############
+
+// TxSpec 待估算的交易参数（与 SendTx 的参数含义一致）
+type TxSpec struct {
+	To       common.Address // 接收地址
+	Value    *big.Int       // 转账金额（nil 表示不转账）
+	Data     []byte         // 交易数据
+	GasLimit uint64         // Gas 限制（0 表示自动估算）
+	GasPrice *big.Int       // Gas 价格（nil 表示使用 GasPricer 或节点建议价格）
+}
+
+// TxCostEstimate 交易的总成本估算
+type TxCostEstimate struct {
+	GasLimit   uint64          // Gas 限制
+	GasPrice   *big.Int        // 实际使用的 gas 价格（单位为 Wei）
+	Value      *big.Int        // 转账金额（单位为 Wei）
+	Fee        *big.Int        // 最大手续费（GasLimit × GasPrice，单位为 Wei）
+	Total      *big.Int        // 总成本（Fee + Value，单位为 Wei）
+	FeeEther   decimal.Decimal // 最大手续费（以 Ether 为单位）
+	TotalEther decimal.Decimal // 总成本（以 Ether 为单位）
+}
+
+// EstimateTotalCost 估算交易的 gas、手续费和总成本
+// gas 价格和 gas 估算方式与 SendTx 构建交易时一致，可用于发送前向用户展示费用
+// 参数说明：
+//   - ctx: 上下文对象
+//   - spec: 交易参数
+//
+// 返回：
+//   - *TxCostEstimate: 成本估算结果
+//   - error: 如果获取 gas 价格或估算 gas 失败则返回错误
+func (k *Kit) EstimateTotalCost(ctx context.Context, spec TxSpec) (*TxCostEstimate, error) {
+	gasPrice := spec.GasPrice
+	if gasPrice == nil || gasPrice.Sign() == 0 {
+		var err error
+		if gasPrice, err = k.suggestGasPrice(ctx); err != nil {
+			return nil, err
+		}
+	}
+	gasLimit := spec.GasLimit
+	if gasLimit == 0 {
+		var err error
+		if gasLimit, err = k.EstimateGas(ctx, k.GetAddress(), spec.To, 0, gasPrice, spec.Value, spec.Data); err != nil {
+			return nil, NormalizeError(err)
+		}
+	}
+
+	value := bigOrZero(spec.Value)
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(gasLimit), gasPrice)
+	total := new(big.Int).Add(fee, value)
+	return &TxCostEstimate{
+		GasLimit:   gasLimit,
+		GasPrice:   gasPrice,
+		Value:      value,
+		Fee:        fee,
+		Total:      total,
+		FeeEther:   ToDecimal(fee, EthDecimals),
+		TotalEther: ToDecimal(total, EthDecimals),
+	}, nil
+}
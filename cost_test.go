@@ -0,0 +1,43 @@
+package etherkit
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestEstimateTotalCost(t *testing.T) {
+	recipient := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	kit := newMockKit(t, newMockSendServer(t))
+
+	tests := []struct {
+		name      string
+		spec      TxSpec
+		wantGas   uint64
+		wantFee   int64
+		wantTotal string
+	}{
+		// 模拟节点：gasPrice 1 gwei，estimateGas 65000
+		{"estimated", TxSpec{To: recipient, Value: big.NewInt(1e18)}, 65000, 65000 * 1e9, "1.000065"},
+		{"explicit gas", TxSpec{To: recipient, GasLimit: 21000, GasPrice: big.NewInt(2e9)}, 21000, 42000 * 1e9, "0.000042"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cost, err := kit.EstimateTotalCost(context.Background(), tt.spec)
+			if err != nil {
+				t.Fatalf("EstimateTotalCost() failed: %v", err)
+			}
+			if cost.GasLimit != tt.wantGas || cost.Fee.Int64() != tt.wantFee {
+				t.Errorf("GasLimit = %d, Fee = %s; want %d, %d", cost.GasLimit, cost.Fee, tt.wantGas, tt.wantFee)
+			}
+			if cost.TotalEther.String() != tt.wantTotal {
+				t.Errorf("TotalEther = %s, want %s", cost.TotalEther, tt.wantTotal)
+			}
+			if new(big.Int).Sub(cost.Total, cost.Fee).Cmp(cost.Value) != 0 {
+				t.Errorf("Total %s should equal Fee %s + Value %s", cost.Total, cost.Fee, cost.Value)
+			}
+		})
+	}
+}
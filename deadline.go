@@ -0,0 +1,153 @@
+package etherkit
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+//############ Transaction Deadline ############
+
+// TxDeadline 交易的截止条件（区块号和时间可以同时设置，任一满足即视为过期）
+type TxDeadline struct {
+	Block          uint64    // 交易必须在该区块（含）之前被打包（0 表示不限制）
+	Time           time.Time // 交易必须在该时间之前被打包（零值表示不限制）
+	CancelOnExpiry bool      // 过期后是否发送同 nonce 的空交易替换原交易，防止其延迟上链（按 Kit 的替换策略加价）
+}
+
+// DeadlineAtBlock 创建以区块号为截止条件的 TxDeadline
+func DeadlineAtBlock(block uint64) TxDeadline {
+	return TxDeadline{Block: block}
+}
+
+// DeadlineAfter 创建以相对时间为截止条件的 TxDeadline
+func DeadlineAfter(d time.Duration) TxDeadline {
+	return TxDeadline{Time: time.Now().Add(d)}
+}
+
+// Expired 判断截止条件在给定时间和区块号下是否已过期
+// 参数说明：
+//   - now: 当前时间
+//   - blockNumber: 当前最新区块号
+//
+// 返回：
+//   - bool: true 表示已过期
+func (d TxDeadline) Expired(now time.Time, blockNumber uint64) bool {
+	if !d.Time.IsZero() && !now.Before(d.Time) {
+		return true
+	}
+	return d.Block != 0 && blockNumber >= d.Block
+}
+
+// expired 查询最新区块号后判断是否过期（只设置时间截止时不发起查询）
+func (d TxDeadline) expired(ctx context.Context, ep EtherProvider) (bool, error) {
+	var blockNumber uint64
+	if d.Block != 0 {
+		var err error
+		if blockNumber, err = ep.GetBlockNumber(ctx); err != nil {
+			return false, err
+		}
+	}
+	return d.Expired(time.Now(), blockNumber), nil
+}
+
+// SendTxWithDeadline 发送带截止条件的交易并监控直到被打包或过期
+// 截止条件已满足时不会发送交易；监控期间定期重新广播，过期后停止重播并返回 ErrTxExpired
+// 参数说明：
+//   - ctx: 上下文对象
+//   - to: 接收地址
+//   - nonce: 交易 nonce（0 表示自动计算）
+//   - gasLimit: Gas 限制（0 表示自动估算）
+//   - gasPrice: Gas 价格（nil 表示自动获取）
+//   - value: 转账金额（nil 表示不转账）
+//   - data: 交易数据
+//   - deadline: 截止条件
+//   - interval: 轮询和重播间隔（<= 0 时使用 DefaultWaitInterval）
+//
+// 返回：
+//   - *types.Receipt: 交易收据
+//   - error: 交易过期时返回包装了 ErrTxExpired 的错误（可用 errors.Is 判断）
+func (k *Kit) SendTxWithDeadline(ctx context.Context, to common.Address, nonce, gasLimit uint64, gasPrice, value *big.Int, data []byte, deadline TxDeadline, interval time.Duration) (*types.Receipt, error) {
+	expired, err := deadline.expired(ctx, k.EtherProvider)
+	if err != nil {
+		return nil, err
+	}
+	if expired {
+		return nil, fmt.Errorf("%w: deadline reached before sending", ErrTxExpired)
+	}
+	tx, err := k.buildTx(ctx, to, nonce, gasLimit, gasPrice, value, data)
+	if err != nil {
+		return nil, err
+	}
+	signedTx, err := k.signTx(ctx, tx, nil)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := k.SendSignedTx(ctx, signedTx); err != nil {
+		return nil, err
+	}
+	return k.MonitorTx(ctx, signedTx, deadline, interval)
+}
+
+// MonitorTx 监控已广播的交易：定期查询收据并重新广播，直到交易被打包或截止条件满足
+// 交易在截止区块之后才被打包时，同时返回收据和 ErrTxExpired 错误
+// 参数说明：
+//   - ctx: 上下文对象（取消时停止监控）
+//   - signedTx: 已签名并广播的交易
+//   - deadline: 截止条件（零值表示只受 ctx 控制）
+//   - interval: 轮询和重播间隔（<= 0 时使用 DefaultWaitInterval）
+//
+// 返回：
+//   - *types.Receipt: 交易收据
+//   - error: 交易过期时返回包装了 ErrTxExpired 的错误；开启 CancelOnExpiry 但替换失败时错误中包含替换失败原因
+func (k *Kit) MonitorTx(ctx context.Context, signedTx *types.Transaction, deadline TxDeadline, interval time.Duration) (*types.Receipt, error) {
+	if interval <= 0 {
+		interval = DefaultWaitInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	hash := signedTx.Hash()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+
+		receipt, err := k.GetTransactionReceipt(ctx, hash)
+		if err == nil && receipt != nil {
+			if deadline.Block != 0 && receipt.BlockNumber.Uint64() > deadline.Block {
+				return receipt, fmt.Errorf("%w: %s mined in block %d after deadline block %d",
+					ErrTxExpired, hash.Hex(), receipt.BlockNumber.Uint64(), deadline.Block)
+			}
+			return receipt, nil
+		}
+
+		expired, err := deadline.expired(ctx, k.EtherProvider)
+		if err != nil {
+			continue
+		}
+		if expired {
+			expiredErr := fmt.Errorf("%w: %s", ErrTxExpired, hash.Hex())
+			if deadline.CancelOnExpiry {
+				attempt := k.replacementAttempt(ctx, signedTx.Nonce())
+				gasPrice, err := k.ReplacementPolicy().Bump(signedTx.GasFeeCap(), DefaultGasLimit, attempt)
+				if err == nil {
+					_, err = k.sendNoopTx(ctx, signedTx.Nonce(), gasPrice, attempt)
+				}
+				if err != nil {
+					return nil, fmt.Errorf("%w (failed to cancel: %w)", expiredErr, err)
+				}
+			}
+			return nil, expiredErr
+		}
+
+		// 重新广播（交易已在交易池中时节点会返回 already known，忽略即可）
+		_ = k.SendTransaction(ctx, signedTx)
+	}
+}
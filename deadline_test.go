@@ -0,0 +1,120 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestTxDeadlineExpired(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name     string
+		deadline TxDeadline
+		block    uint64
+		want     bool
+	}{
+		{"no deadline", TxDeadline{}, 100, false},
+		{"before block", DeadlineAtBlock(101), 100, false},
+		{"at block", DeadlineAtBlock(100), 100, true},
+		{"before time", TxDeadline{Time: now.Add(time.Minute)}, 0, false},
+		{"after time", TxDeadline{Time: now.Add(-time.Second)}, 0, true},
+		{"either condition", TxDeadline{Block: 200, Time: now.Add(-time.Second)}, 100, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.deadline.Expired(now, tt.block); got != tt.want {
+				t.Errorf("Expired() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSendTxWithDeadline(t *testing.T) {
+	recipient := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+
+	// newServer 模拟节点：区块号从 100 开始每次查询加 1，mineAt 为交易被打包的区块（0 表示永不打包）
+	newServer := func(t *testing.T, mineAt uint64) (*mockRPCServer, *[]uint64) {
+		server := newMockSendServer(t)
+		var block atomic.Uint64
+		block.Store(99)
+		var sentNonces []uint64
+		server.handlers["eth_blockNumber"] = func(params []json.RawMessage) (interface{}, error) {
+			return fmt.Sprintf("0x%x", block.Add(1)), nil
+		}
+		server.handlers["eth_sendRawTransaction"] = func(params []json.RawMessage) (interface{}, error) {
+			var raw string
+			_ = json.Unmarshal(params[0], &raw)
+			tx, err := DecodeRawTxHex(raw[2:])
+			if err != nil {
+				return nil, err
+			}
+			sentNonces = append(sentNonces, tx.Nonce())
+			return tx.Hash(), nil
+		}
+		server.handlers["eth_getTransactionReceipt"] = func(params []json.RawMessage) (interface{}, error) {
+			if mineAt == 0 || block.Load() < mineAt {
+				return nil, nil
+			}
+			var hash common.Hash
+			_ = json.Unmarshal(params[0], &hash)
+			return &types.Receipt{Status: 1, TxHash: hash, BlockNumber: new(big.Int).SetUint64(mineAt), Logs: []*types.Log{}}, nil
+		}
+		return server, &sentNonces
+	}
+
+	t.Run("already expired", func(t *testing.T) {
+		server, _ := newServer(t, 0)
+		kit := newMockKit(t, server)
+
+		_, err := kit.SendTxWithDeadline(context.Background(), recipient, 0, 21000, nil, big.NewInt(1), nil, DeadlineAtBlock(50), 10*time.Millisecond)
+		if !errors.Is(err, ErrTxExpired) {
+			t.Fatalf("SendTxWithDeadline() error = %v, want ErrTxExpired", err)
+		}
+		if n := server.callCount("eth_sendRawTransaction"); n != 0 {
+			t.Errorf("sent %d transactions, want 0", n)
+		}
+	})
+
+	t.Run("mined before deadline", func(t *testing.T) {
+		server, _ := newServer(t, 102)
+		kit := newMockKit(t, server)
+
+		receipt, err := kit.SendTxWithDeadline(context.Background(), recipient, 0, 21000, nil, big.NewInt(1), nil, DeadlineAtBlock(110), 10*time.Millisecond)
+		if err != nil {
+			t.Fatalf("SendTxWithDeadline() failed: %v", err)
+		}
+		if receipt.BlockNumber.Uint64() != 102 {
+			t.Errorf("receipt block = %d, want 102", receipt.BlockNumber.Uint64())
+		}
+	})
+
+	t.Run("expires and cancels", func(t *testing.T) {
+		server, sentNonces := newServer(t, 0)
+		kit := newMockKit(t, server)
+
+		deadline := TxDeadline{Block: 104, CancelOnExpiry: true}
+		_, err := kit.SendTxWithDeadline(context.Background(), recipient, 0, 21000, nil, big.NewInt(1), nil, deadline, 10*time.Millisecond)
+		if !errors.Is(err, ErrTxExpired) {
+			t.Fatalf("SendTxWithDeadline() error = %v, want ErrTxExpired", err)
+		}
+		// 原交易 + 若干次重播 + 1 笔取消交易，全部使用同一个 nonce
+		sent := *sentNonces
+		if len(sent) < 3 {
+			t.Fatalf("sent %d transactions, want original, rebroadcasts and cancellation", len(sent))
+		}
+		for _, nonce := range sent {
+			if nonce != 5 {
+				t.Errorf("sent nonce %d, want 5", nonce)
+			}
+		}
+	})
+}
@@ -0,0 +1,74 @@
+package etherkit
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+//############ Deployment Estimation ############
+
+// DeploymentEstimate 合约部署的成本估算和预测地址
+type DeploymentEstimate struct {
+	TxCostEstimate
+	Address common.Address // 预测的合约地址（由部署账户地址和 nonce 决定）
+	Nonce   uint64         // 估算时使用的部署交易 nonce
+	Data    []byte         // 部署交易数据（字节码 + ABI 编码的构造函数参数）
+}
+
+// EstimateDeployment 估算部署合约所需的 gas、手续费，并预测合约地址
+// gas 价格的获取方式与 EstimateTotalCost 一致；预测地址假设部署交易使用当前的 pending nonce 发送
+// 参数说明：
+//   - ctx: 上下文对象
+//   - contractAbi: 合约 ABI（用于编码构造函数参数）
+//   - bytecode: 合约创建字节码
+//   - constructorArgs: 构造函数参数（按定义顺序传入，支持与 BuildContractInputData 相同的参数形式）
+//
+// 返回：
+//   - *DeploymentEstimate: 估算结果
+//   - error: 如果参数编码失败、构造函数回滚（*RevertError）或查询失败则返回错误
+//
+// 使用示例：
+//
+//	estimate, err := kit.EstimateDeployment(ctx, tokenAbi, tokenBytecode, "My Token", "MTK", big.NewInt(1e6))
+//	fmt.Printf("deploying to %s costs up to %s ETH\n", estimate.Address.Hex(), estimate.FeeEther)
+func (k *Kit) EstimateDeployment(ctx context.Context, contractAbi abi.ABI, bytecode []byte, constructorArgs ...interface{}) (*DeploymentEstimate, error) {
+	args, err := BuildContractInputData(contractAbi, "", constructorArgs...)
+	if err != nil {
+		return nil, err
+	}
+	data := append(common.CopyBytes(bytecode), args...)
+
+	nonce, err := k.GetNonce(ctx)
+	if err != nil {
+		return nil, err
+	}
+	gasPrice, err := k.suggestGasPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+	gasLimit, err := k.GetEthClient().EstimateGas(ctx, ethereum.CallMsg{From: k.GetAddress(), GasPrice: gasPrice, Data: data})
+	if err != nil {
+		return nil, NormalizeError(err)
+	}
+
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(gasLimit), gasPrice)
+	return &DeploymentEstimate{
+		TxCostEstimate: TxCostEstimate{
+			GasLimit:   gasLimit,
+			GasPrice:   gasPrice,
+			Value:      big.NewInt(0),
+			Fee:        fee,
+			Total:      new(big.Int).Set(fee),
+			FeeEther:   ToDecimal(fee, EthDecimals),
+			TotalEther: ToDecimal(fee, EthDecimals),
+		},
+		Address: crypto.CreateAddress(k.GetAddress(), nonce),
+		Nonce:   nonce,
+		Data:    data,
+	}, nil
+}
@@ -0,0 +1,74 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestEstimateDeployment(t *testing.T) {
+	contractAbi, _ := abi.JSON(strings.NewReader(`[{"type":"constructor","inputs":[{"name":"name","type":"string"},{"name":"supply","type":"uint256"}]}]`))
+	bytecode := common.FromHex("0x6080604052348015600f57600080fd5b50")
+
+	tests := []struct {
+		name    string
+		args    []interface{}
+		wantErr bool
+	}{
+		{"with constructor args", []interface{}{"Token", 1000}, false},
+		{"wrong arg count", []interface{}{"Token"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newMockSendServer(t)
+			var gotArg mockCallArg
+			server.handlers["eth_estimateGas"] = func(params []json.RawMessage) (interface{}, error) {
+				_ = json.Unmarshal(params[0], &gotArg)
+				return "0x30d40", nil
+			}
+			kit := newMockKit(t, server)
+
+			estimate, err := kit.EstimateDeployment(context.Background(), contractAbi, bytecode, tt.args...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("EstimateDeployment() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			// 模拟节点：nonce 5，gasPrice 1 gwei，estimateGas 200000
+			if estimate.GasLimit != 200000 || estimate.Fee.Int64() != 200000*1e9 || estimate.FeeEther.String() != "0.0002" {
+				t.Errorf("GasLimit = %d, Fee = %s (%s ETH)", estimate.GasLimit, estimate.Fee, estimate.FeeEther)
+			}
+			// 0xf39F…2266 以 nonce 5 部署的合约地址
+			if want := common.HexToAddress("0x5FC8d32690cc91D4c39d9d3abcBD16989F875707"); estimate.Address != want || estimate.Nonce != 5 {
+				t.Errorf("Address = %s, Nonce = %d; want %s, 5", estimate.Address.Hex(), estimate.Nonce, want.Hex())
+			}
+			if gotArg.To != "" {
+				t.Errorf("eth_estimateGas to = %s, expected contract creation", gotArg.To)
+			}
+			if !strings.HasPrefix(common.Bytes2Hex(gotArg.calldata()), common.Bytes2Hex(bytecode)) || len(gotArg.calldata()) != len(bytecode)+4*32 {
+				t.Errorf("eth_estimateGas data = %x, expected bytecode followed by constructor args", gotArg.calldata())
+			}
+		})
+	}
+}
+
+func TestEstimateDeploymentRevert(t *testing.T) {
+	server := newMockSendServer(t)
+	server.handlers["eth_estimateGas"] = func(params []json.RawMessage) (interface{}, error) {
+		return nil, &mockRPCError{Code: 3, Message: "execution reverted"}
+	}
+	kit := newMockKit(t, server)
+
+	_, err := kit.EstimateDeployment(context.Background(), abi.ABI{}, common.FromHex("0xfe"))
+	var revert *RevertError
+	if !errors.As(err, &revert) {
+		t.Errorf("EstimateDeployment() error = %v, expected *RevertError", err)
+	}
+}
@@ -0,0 +1,77 @@
+package etherkit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+//############ Deployment Block ############
+
+// FindDeploymentBlock 通过在区块号上二分查找 eth_getCode，找到合约被部署的区块（事件索引器可以从该高度开始扫描）
+// 需要节点能查询历史状态（归档节点）；对自毁后在同一地址重新部署的合约，结果可能是任一次部署所在的区块
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - address: 合约地址
+//
+// 返回：
+//   - uint64: 合约部署所在的区块号（第一个存在代码的区块）
+//   - error: 如果地址在最新区块没有代码（ErrInvalidContractAddress）或查询失败则返回错误
+//
+// 使用示例：
+//
+//	start, err := FindDeploymentBlock(ctx, provider, usdc)
+//	logs, err := NewLogFetcher(provider).FetchLogs(ctx, query, start, head)
+func FindDeploymentBlock(ctx context.Context, ep EtherProvider, address common.Address) (uint64, error) {
+	head, err := ep.GetBlockNumber(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get block number: %w", err)
+	}
+	deployed, err := hasCodeAt(ctx, ep, address, head)
+	if err != nil {
+		return 0, err
+	}
+	if !deployed {
+		return 0, fmt.Errorf("%w: no code at %s", ErrInvalidContractAddress, address.Hex())
+	}
+
+	// 不变式：hi 区块有代码，lo 之前的区块都没有代码
+	lo, hi := uint64(0), head
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		deployed, err := hasCodeAt(ctx, ep, address, mid)
+		if err != nil {
+			return 0, err
+		}
+		if deployed {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return hi, nil
+}
+
+// hasCodeAt 查询地址在指定区块是否存在代码
+func hasCodeAt(ctx context.Context, ep EtherProvider, address common.Address, number uint64) (bool, error) {
+	var code hexutil.Bytes
+	if err := ep.GetRpcClient().CallContext(ctx, &code, "eth_getCode", address, hexutil.Uint64(number)); err != nil {
+		return false, fmt.Errorf("failed to get code at block %d: %w", number, err)
+	}
+	return len(code) > 0, nil
+}
+
+// FindDeploymentBlock 查找合约被部署的区块
+// 参数说明：
+//   - ctx: 上下文对象
+//   - address: 合约地址
+//
+// 返回：
+//   - uint64: 合约部署所在的区块号
+//   - error: 如果地址没有代码或查询失败则返回错误
+func (k *Kit) FindDeploymentBlock(ctx context.Context, address common.Address) (uint64, error) {
+	return FindDeploymentBlock(ctx, k.EtherProvider, address)
+}
@@ -0,0 +1,68 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestFindDeploymentBlock(t *testing.T) {
+	address := common.HexToAddress("0x1c7D4B196Cb0C7B01d743Fbc6116a902379C7238")
+
+	tests := []struct {
+		name       string
+		head       uint64
+		deployedAt int64 // -1 表示从未部署
+		wantErr    error
+	}{
+		{name: "middle", head: 1000, deployedAt: 437},
+		{name: "genesis", head: 1000, deployedAt: 0},
+		{name: "head", head: 1000, deployedAt: 1000},
+		{name: "not deployed", head: 1000, deployedAt: -1, wantErr: ErrInvalidContractAddress},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newMockRPCServer(t, map[string]mockRPCHandler{
+				"eth_chainId":     mockResult("0x1"),
+				"eth_blockNumber": mockResult("0x" + strconv.FormatUint(tt.head, 16)),
+				"eth_getCode": func(params []json.RawMessage) (interface{}, error) {
+					var tag string
+					_ = json.Unmarshal(params[1], &tag)
+					number, _ := strconv.ParseUint(tag[2:], 16, 64)
+					if tt.deployedAt >= 0 && number >= uint64(tt.deployedAt) {
+						return "0x6080", nil
+					}
+					return "0x", nil
+				},
+			})
+			provider, err := NewProvider(server.URL)
+			if err != nil {
+				t.Fatalf("NewProvider() failed: %v", err)
+			}
+			defer provider.Close()
+
+			block, err := FindDeploymentBlock(context.Background(), provider, address)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("FindDeploymentBlock() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FindDeploymentBlock() failed: %v", err)
+			}
+			if block != uint64(tt.deployedAt) {
+				t.Errorf("FindDeploymentBlock() = %d, expected %d", block, tt.deployedAt)
+			}
+			// 二分查找的查询次数为 O(log n)
+			if calls := server.callCount("eth_getCode"); calls > 12 {
+				t.Errorf("eth_getCode called %d times, expected <= 12", calls)
+			}
+		})
+	}
+}
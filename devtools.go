@@ -0,0 +1,228 @@
+package etherkit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+//############ Dev Chain ############
+
+// ErrDevMethodUnsupported 开发节点不支持该状态操作方法
+var ErrDevMethodUnsupported = errors.New("method not supported by dev node")
+
+// DevNodeKind 开发节点类型
+type DevNodeKind string
+
+// 开发节点类型
+const (
+	DevNodeAnvil   DevNodeKind = "anvil"   // Foundry Anvil
+	DevNodeHardhat DevNodeKind = "hardhat" // Hardhat Network
+	DevNodeGeth    DevNodeKind = "geth"    // geth --dev（不支持快照、时间推进和账户状态修改）
+)
+
+// DevChain 开发链状态操作工具（用于测试编排：快照回滚、时间推进、出块、修改账户状态）
+// 仅可用于本地开发节点（anvil、hardhat、geth --dev），不要连接到公共网络
+type DevChain struct {
+	Kind DevNodeKind // 节点类型
+	ep   EtherProvider
+}
+
+// NewDevChain 根据 web3_clientVersion 识别开发节点类型并创建 DevChain
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 连接到开发节点的以太坊提供者
+//
+// 返回：
+//   - *DevChain: 开发链操作工具
+//   - error: 如果查询客户端版本失败或节点类型无法识别则返回错误
+func NewDevChain(ctx context.Context, ep EtherProvider) (*DevChain, error) {
+	var version string
+	if err := ep.GetRpcClient().CallContext(ctx, &version, "web3_clientVersion"); err != nil {
+		return nil, fmt.Errorf("failed to query client version: %w", err)
+	}
+	lower := strings.ToLower(version)
+	switch {
+	case strings.HasPrefix(lower, "anvil"):
+		return NewDevChainWithKind(ep, DevNodeAnvil), nil
+	case strings.HasPrefix(lower, "hardhat"):
+		return NewDevChainWithKind(ep, DevNodeHardhat), nil
+	case strings.HasPrefix(lower, "geth"):
+		return NewDevChainWithKind(ep, DevNodeGeth), nil
+	}
+	return nil, fmt.Errorf("unrecognized dev node %q", version)
+}
+
+// NewDevChainWithKind 使用指定的节点类型创建 DevChain（不查询节点）
+func NewDevChainWithKind(ep EtherProvider, kind DevNodeKind) *DevChain {
+	return &DevChain{Kind: kind, ep: ep}
+}
+
+// call 调用开发节点 RPC 方法
+func (d *DevChain) call(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	if err := d.ep.GetRpcClient().CallContext(ctx, result, method, args...); err != nil {
+		if isMethodNotFound(err) {
+			return fmt.Errorf("%w: %s on %s", ErrDevMethodUnsupported, method, d.Kind)
+		}
+		return fmt.Errorf("%s failed: %w", method, err)
+	}
+	return nil
+}
+
+// evmMethod 返回 evm_* 方法名（geth 不支持）
+func (d *DevChain) evmMethod(name string) (string, error) {
+	if d.Kind == DevNodeGeth {
+		return "", fmt.Errorf("%w: evm_%s on %s", ErrDevMethodUnsupported, name, d.Kind)
+	}
+	return "evm_" + name, nil
+}
+
+// nodeMethod 返回节点专有的方法名（anvil_* / hardhat_*，geth 不支持）
+func (d *DevChain) nodeMethod(name string) (string, error) {
+	switch d.Kind {
+	case DevNodeAnvil, DevNodeHardhat:
+		return string(d.Kind) + "_" + name, nil
+	}
+	return "", fmt.Errorf("%w: %s on %s", ErrDevMethodUnsupported, name, d.Kind)
+}
+
+// Snapshot 保存当前链状态快照
+// 返回：
+//   - string: 快照 ID（用于 Revert，快照回滚后即失效）
+//   - error: 如果节点不支持或调用失败则返回错误
+func (d *DevChain) Snapshot(ctx context.Context) (string, error) {
+	method, err := d.evmMethod("snapshot")
+	if err != nil {
+		return "", err
+	}
+	var id string
+	if err := d.call(ctx, &id, method); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// Revert 回滚到指定快照
+// 参数说明：
+//   - ctx: 上下文对象
+//   - id: Snapshot 返回的快照 ID
+//
+// 返回：
+//   - error: 如果快照不存在（已被使用或从未创建）或调用失败则返回错误
+func (d *DevChain) Revert(ctx context.Context, id string) error {
+	method, err := d.evmMethod("revert")
+	if err != nil {
+		return err
+	}
+	var ok bool
+	if err := d.call(ctx, &ok, method, id); err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("snapshot %s not found", id)
+	}
+	return nil
+}
+
+// IncreaseTime 推进链上时间（在下一个区块生效）
+// 参数说明：
+//   - ctx: 上下文对象
+//   - duration: 推进的时长（按秒取整）
+//
+// 返回：
+//   - error: 如果节点不支持或调用失败则返回错误
+func (d *DevChain) IncreaseTime(ctx context.Context, duration time.Duration) error {
+	method, err := d.evmMethod("increaseTime")
+	if err != nil {
+		return err
+	}
+	return d.call(ctx, nil, method, hexutil.Uint64(duration/time.Second))
+}
+
+// SetNextBlockTimestamp 设置下一个区块的时间戳
+func (d *DevChain) SetNextBlockTimestamp(ctx context.Context, timestamp time.Time) error {
+	method, err := d.evmMethod("setNextBlockTimestamp")
+	if err != nil {
+		return err
+	}
+	return d.call(ctx, nil, method, hexutil.Uint64(timestamp.Unix()))
+}
+
+// Mine 立即出块
+// 参数说明：
+//   - ctx: 上下文对象
+//   - blocks: 出块数量（0 按 1 处理）
+//
+// 返回：
+//   - error: 如果节点不支持或调用失败则返回错误
+func (d *DevChain) Mine(ctx context.Context, blocks uint64) error {
+	if blocks <= 1 {
+		method, err := d.evmMethod("mine")
+		if err != nil {
+			return err
+		}
+		return d.call(ctx, nil, method)
+	}
+	method, err := d.nodeMethod("mine")
+	if err != nil {
+		return err
+	}
+	return d.call(ctx, nil, method, hexutil.Uint64(blocks))
+}
+
+// SetBalance 设置账户本位币余额
+// 参数说明：
+//   - ctx: 上下文对象
+//   - address: 账户地址
+//   - balance: 余额（单位为 Wei）
+//
+// 返回：
+//   - error: 如果节点不支持或调用失败则返回错误
+func (d *DevChain) SetBalance(ctx context.Context, address common.Address, balance *big.Int) error {
+	method, err := d.nodeMethod("setBalance")
+	if err != nil {
+		return err
+	}
+	return d.call(ctx, nil, method, address, (*hexutil.Big)(bigOrZero(balance)))
+}
+
+// SetCode 设置账户的合约代码（可用于替换合约实现或在任意地址部署 mock）
+func (d *DevChain) SetCode(ctx context.Context, address common.Address, code []byte) error {
+	method, err := d.nodeMethod("setCode")
+	if err != nil {
+		return err
+	}
+	return d.call(ctx, nil, method, address, hexutil.Bytes(code))
+}
+
+// SetStorageAt 设置合约存储槽的值
+// 参数说明：
+//   - ctx: 上下文对象
+//   - address: 合约地址
+//   - slot: 存储槽
+//   - value: 存储值（32 字节）
+//
+// 返回：
+//   - error: 如果节点不支持或调用失败则返回错误
+func (d *DevChain) SetStorageAt(ctx context.Context, address common.Address, slot, value common.Hash) error {
+	method, err := d.nodeMethod("setStorageAt")
+	if err != nil {
+		return err
+	}
+	return d.call(ctx, nil, method, address, slot, value)
+}
+
+// SetNonce 设置账户 nonce
+func (d *DevChain) SetNonce(ctx context.Context, address common.Address, nonce uint64) error {
+	method, err := d.nodeMethod("setNonce")
+	if err != nil {
+		return err
+	}
+	return d.call(ctx, nil, method, address, hexutil.Uint64(nonce))
+}
@@ -0,0 +1,82 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestDevChain(t *testing.T) {
+	var params = map[string][]json.RawMessage{}
+	record := func(method string, result interface{}) mockRPCHandler {
+		return func(p []json.RawMessage) (interface{}, error) {
+			params[method] = p
+			return result, nil
+		}
+	}
+	server := newMockRPCServer(t, map[string]mockRPCHandler{
+		"web3_clientVersion": mockResult("anvil/v1.0.0"),
+		"evm_snapshot":       record("evm_snapshot", "0x1"),
+		"evm_revert":         record("evm_revert", true),
+		"evm_increaseTime":   record("evm_increaseTime", "0xe10"),
+		"evm_mine":           record("evm_mine", "0x0"),
+		"anvil_mine":         record("anvil_mine", nil),
+		"anvil_setBalance":   record("anvil_setBalance", nil),
+		"anvil_setStorageAt": record("anvil_setStorageAt", true),
+	})
+	provider, err := NewProvider(server.URL)
+	if err != nil {
+		t.Fatalf("NewProvider() failed: %v", err)
+	}
+	defer provider.Close()
+	ctx := context.Background()
+
+	dev, err := NewDevChain(ctx, provider)
+	if err != nil {
+		t.Fatalf("NewDevChain() failed: %v", err)
+	}
+	if dev.Kind != DevNodeAnvil {
+		t.Fatalf("Kind = %s, expected anvil", dev.Kind)
+	}
+
+	id, err := dev.Snapshot(ctx)
+	if err != nil || id != "0x1" {
+		t.Fatalf("Snapshot() = %s, %v", id, err)
+	}
+	if err := dev.Revert(ctx, id); err != nil {
+		t.Errorf("Revert() failed: %v", err)
+	}
+	if err := dev.IncreaseTime(ctx, time.Hour); err != nil || string(params["evm_increaseTime"][0]) != `"0xe10"` {
+		t.Errorf("IncreaseTime() params = %s, err %v", params["evm_increaseTime"], err)
+	}
+	if err := dev.Mine(ctx, 1); err != nil || server.callCount("evm_mine") != 1 {
+		t.Errorf("Mine(1) err %v, evm_mine calls %d", err, server.callCount("evm_mine"))
+	}
+	if err := dev.Mine(ctx, 10); err != nil || string(params["anvil_mine"][0]) != `"0xa"` {
+		t.Errorf("Mine(10) params = %s, err %v", params["anvil_mine"], err)
+	}
+
+	account := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	if err := dev.SetBalance(ctx, account, big.NewInt(1e18)); err != nil || string(params["anvil_setBalance"][1]) != `"0xde0b6b3a7640000"` {
+		t.Errorf("SetBalance() params = %s, err %v", params["anvil_setBalance"], err)
+	}
+	if err := dev.SetStorageAt(ctx, account, common.Hash{}, common.BigToHash(big.NewInt(1))); err != nil {
+		t.Errorf("SetStorageAt() failed: %v", err)
+	}
+
+	// 节点未实现的方法
+	if err := dev.SetCode(ctx, account, []byte{0x60}); !errors.Is(err, ErrDevMethodUnsupported) {
+		t.Errorf("SetCode() error = %v, expected ErrDevMethodUnsupported", err)
+	}
+
+	// geth --dev 不支持快照
+	geth := NewDevChainWithKind(provider, DevNodeGeth)
+	if _, err := geth.Snapshot(ctx); !errors.Is(err, ErrDevMethodUnsupported) {
+		t.Errorf("geth Snapshot() error = %v, expected ErrDevMethodUnsupported", err)
+	}
+}
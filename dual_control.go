@@ -0,0 +1,163 @@
+package etherkit
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+)
+
+//############ Dual Control ############
+
+// SecondApprover 大额交易的第二审批人
+// token 为调用方通过 ContextWithApprovalToken 附加的审批凭证（未附加时为空字符串），
+// 返回 nil 表示批准，返回错误表示拒绝
+type SecondApprover func(ctx context.Context, review *TxReview, token string) error
+
+// DualControlPolicy 双人审批（dual control）策略
+// 金额超过 Threshold 的交易需要第二审批；低于阈值的交易自动批准，但自动批准的数量和累计金额受时间窗口限制，
+// 超出限制后同样需要第二审批，防止私钥泄露时被拆分成大量小额交易转走资金
+// 策略作用于 Kit 的所有签名入口：消息类签名能识别资金去向时（Safe 交易、EIP-3009 授权、转发请求、用户操作）按对应金额处理，
+// 无法确定金额时（原始消息、哈希、其他 EIP-712 数据）一律需要第二审批；
+// 未设置 Valuer 时，带调用数据的合约调用（代币转账、授权、Safe execTransaction 等）同样无法仅凭 Value 确定金额，一律需要第二审批
+// 自动批准只有在交易或消息实际签名后才计入窗口限制，被后续审核回调拒绝或签名失败时不占用额度
+type DualControlPolicy struct {
+	Threshold        *big.Int                        // 单笔金额超过该值需要第二审批（单位为 Wei，nil 表示不限制单笔金额）
+	Approver         SecondApprover                  // 第二审批人（nil 表示需要审批的交易一律拒绝）
+	Window           time.Duration                   // 自动批准的统计窗口（<= 0 表示不限制自动批准）
+	MaxAutoApproved  *big.Int                        // 窗口内自动批准的累计金额上限（nil 表示不限制）
+	MaxAutoApprovals int                             // 窗口内自动批准的交易数上限（<= 0 表示不限制）
+	Valuer           func(review *TxReview) *big.Int // 交易金额的计算方式（nil 表示使用 review.Value 且合约调用需要第二审批，可自定义以计入代币转账）
+}
+
+// autoApproval 一次自动批准的记录
+type autoApproval struct {
+	at    time.Time
+	value *big.Int
+}
+
+// dualControl 双人审批的状态（自动批准记录）
+type dualControl struct {
+	policy DualControlPolicy
+	mu     sync.Mutex
+	recent []*autoApproval
+}
+
+// review 实现 ConfirmationHook：判断交易是否可以自动批准，否则交给第二审批人
+func (d *dualControl) review(ctx context.Context, review *TxReview) error {
+	value := review.Value
+	if d.policy.Valuer != nil {
+		value = d.policy.Valuer(review)
+	} else if len(review.Data) > 0 {
+		// 合约调用转移的资产（代币、授权额度、多签交易）不体现在 Value 中
+		return d.approve(ctx, review, "value of contract call cannot be determined")
+	}
+	if value == nil && review.Kind != AuditKindTransaction {
+		// 原始消息、哈希和无法识别资金去向的结构化数据（如任意许可签名）无法确定金额，一律需要第二审批
+		return d.approve(ctx, review, fmt.Sprintf("value of %s signature cannot be determined", review.Kind))
+	}
+	value = bigOrZero(value)
+
+	if d.policy.Threshold != nil && value.Cmp(d.policy.Threshold) > 0 {
+		return d.approve(ctx, review, fmt.Sprintf("value %s exceeds threshold %s", value, d.policy.Threshold))
+	}
+	approval, reason := d.reserve(value)
+	if reason != "" {
+		return d.approve(ctx, review, reason)
+	}
+	if approval != nil {
+		// 审核期间先占用额度（避免并发签名同时通过），最终未签名时释放
+		review.onSettled(func(signed bool) {
+			if !signed {
+				d.release(approval)
+			}
+		})
+	}
+	return nil
+}
+
+// reserve 在窗口限制内占用一次自动批准的额度，超出限制时返回原因
+func (d *dualControl) reserve(value *big.Int) (*autoApproval, string) {
+	if d.policy.Window <= 0 {
+		return nil, ""
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := time.Now()
+	kept := d.recent[:0]
+	total := new(big.Int).Set(value)
+	for _, approval := range d.recent {
+		if now.Sub(approval.at) < d.policy.Window {
+			kept = append(kept, approval)
+			total.Add(total, approval.value)
+		}
+	}
+	d.recent = kept
+
+	if d.policy.MaxAutoApprovals > 0 && len(d.recent) >= d.policy.MaxAutoApprovals {
+		return nil, fmt.Sprintf("%d auto-approved transactions within %s", len(d.recent), d.policy.Window)
+	}
+	if d.policy.MaxAutoApproved != nil && total.Cmp(d.policy.MaxAutoApproved) > 0 {
+		return nil, fmt.Sprintf("auto-approved value %s within %s exceeds %s", total, d.policy.Window, d.policy.MaxAutoApproved)
+	}
+	approval := &autoApproval{at: now, value: value}
+	d.recent = append(d.recent, approval)
+	return approval, ""
+}
+
+// release 释放未最终签名的自动批准额度
+func (d *dualControl) release(approval *autoApproval) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for i, recent := range d.recent {
+		if recent == approval {
+			d.recent = append(d.recent[:i], d.recent[i+1:]...)
+			return
+		}
+	}
+}
+
+// approve 请求第二审批
+func (d *dualControl) approve(ctx context.Context, review *TxReview, reason string) error {
+	if d.policy.Approver == nil {
+		return fmt.Errorf("%w: %s", ErrApprovalRequired, reason)
+	}
+	token, _ := ctx.Value(approvalTokenKey{}).(string)
+	if err := d.policy.Approver(ctx, review, token); err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrApprovalRequired, reason, err)
+	}
+	return nil
+}
+
+// approvalTokenKey 上下文中第二审批凭证的键
+type approvalTokenKey struct{}
+
+// ContextWithApprovalToken 返回携带第二审批凭证的上下文（如审批系统签发的一次性令牌），由 SecondApprover 校验
+func ContextWithApprovalToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, approvalTokenKey{}, token)
+}
+
+// WithDualControl 启用双人审批模式（适用于资金库级别的热钱包部署）
+// 以审核回调的形式注册（与 WithConfirmationHook 按注册顺序执行），需要审批但未获批准时
+// 返回的错误同时满足 errors.Is(err, ErrTxRejected) 和 errors.Is(err, ErrApprovalRequired)
+// 参数说明：
+//   - policy: 双人审批策略
+//
+// 使用示例：
+//
+//	kit, err := NewKit(pk, url, WithDualControl(DualControlPolicy{
+//	    Threshold:       ToWei(1, EthDecimals),
+//	    Window:          time.Hour,
+//	    MaxAutoApproved: ToWei(5, EthDecimals),
+//	    Approver: func(ctx context.Context, r *TxReview, token string) error {
+//	        return approvals.Verify(token, r.String()) // 由审批系统校验令牌与交易摘要
+//	    },
+//	}))
+//	ctx = ContextWithApprovalToken(ctx, tokenFromSecondOfficer)
+//	hash, err := kit.SendTx(ctx, to, 0, 0, nil, ToWei(10, EthDecimals), nil)
+func WithDualControl(policy DualControlPolicy) KitOption {
+	guard := &dualControl{policy: policy}
+	return WithConfirmationHook(guard.review)
+}
@@ -0,0 +1,250 @@
+package etherkit
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+func TestDualControl(t *testing.T) {
+	to := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	// 第二审批人只接受令牌 "ok"
+	approver := func(ctx context.Context, review *TxReview, token string) error {
+		if token != "ok" {
+			return errors.New("invalid approval token")
+		}
+		return nil
+	}
+
+	tests := []struct {
+		name     string
+		policy   DualControlPolicy
+		values   []int64 // 依次发送的金额
+		token    string
+		wantSent int // 成功发送的交易数（之后的交易都应被拒绝）
+	}{
+		{name: "below threshold", policy: DualControlPolicy{Threshold: big.NewInt(100), Approver: approver}, values: []int64{100, 50}, wantSent: 2},
+		{name: "above threshold without token", policy: DualControlPolicy{Threshold: big.NewInt(100), Approver: approver}, values: []int64{101}, wantSent: 0},
+		{name: "above threshold with token", policy: DualControlPolicy{Threshold: big.NewInt(100), Approver: approver}, values: []int64{101}, token: "ok", wantSent: 1},
+		{name: "above threshold without approver", policy: DualControlPolicy{Threshold: big.NewInt(100)}, values: []int64{101}, token: "ok", wantSent: 0},
+		{name: "auto-approval value budget", policy: DualControlPolicy{Threshold: big.NewInt(100), Window: time.Hour, MaxAutoApproved: big.NewInt(150)}, values: []int64{80, 70, 1}, wantSent: 2},
+		{name: "auto-approval count limit", policy: DualControlPolicy{Window: time.Hour, MaxAutoApprovals: 2}, values: []int64{1, 1, 1}, wantSent: 2},
+		{name: "budget exceeded with token", policy: DualControlPolicy{Window: time.Hour, MaxAutoApprovals: 1, Approver: approver}, values: []int64{1, 1, 1}, token: "ok", wantSent: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kit := newMockKit(t, newMockSendServer(t), WithDualControl(tt.policy))
+			ctx := context.Background()
+			if tt.token != "" {
+				ctx = ContextWithApprovalToken(ctx, tt.token)
+			}
+			for i, v := range tt.values {
+				_, err := kit.SendTx(ctx, to, 0, 21000, nil, big.NewInt(v), nil)
+				if i < tt.wantSent {
+					if err != nil {
+						t.Fatalf("tx %d: SendTx() failed: %v", i, err)
+					}
+					continue
+				}
+				if !errors.Is(err, ErrApprovalRequired) || !errors.Is(err, ErrTxRejected) {
+					t.Fatalf("tx %d: SendTx() error = %v, want ErrApprovalRequired", i, err)
+				}
+			}
+		})
+	}
+}
+
+func TestDualControlContractCall(t *testing.T) {
+	token := common.HexToAddress("0x1c7D4B196Cb0C7B01d743Fbc6116a902379C7238")
+	recipient := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	kit := newMockKit(t, newMockSendServer(t), WithDualControl(DualControlPolicy{Threshold: big.NewInt(1000)}))
+
+	// 未设置 Valuer 时，合约调用转移的资产无法确定，即使 Value 为 0 也需要第二审批
+	for _, method := range []string{"transfer", "approve"} {
+		data, _ := erc20ABI.Pack(method, recipient, math.MaxBig256)
+		if _, err := kit.SendTx(context.Background(), token, 0, 0, nil, nil, data); !errors.Is(err, ErrApprovalRequired) {
+			t.Fatalf("%s: SendTx() error = %v, want ErrApprovalRequired", method, err)
+		}
+	}
+	if _, err := kit.SendTx(context.Background(), recipient, 0, 21000, nil, big.NewInt(1), nil); err != nil {
+		t.Fatalf("plain transfer: SendTx() failed: %v", err)
+	}
+}
+
+func TestDualControlReleasesUnsigned(t *testing.T) {
+	to := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	// 后注册的回调拒绝金额为 1 的交易
+	rejectOne := func(ctx context.Context, review *TxReview) error {
+		if review.Value.Int64() == 1 {
+			return errors.New("blocked by risk rule")
+		}
+		return nil
+	}
+	kit := newMockKit(t, newMockSendServer(t),
+		WithDualControl(DualControlPolicy{Window: time.Hour, MaxAutoApprovals: 1}),
+		WithConfirmationHook(rejectOne),
+	)
+
+	if _, err := kit.SendTx(context.Background(), to, 0, 21000, nil, big.NewInt(1), nil); !errors.Is(err, ErrTxRejected) || errors.Is(err, ErrApprovalRequired) {
+		t.Fatalf("SendTx() error = %v, want rejection by risk rule", err)
+	}
+	// 被拒绝的交易没有签名，不占用自动批准额度
+	if _, err := kit.SendTx(context.Background(), to, 0, 21000, nil, big.NewInt(2), nil); err != nil {
+		t.Fatalf("SendTx() failed: %v", err)
+	}
+	if _, err := kit.SendTx(context.Background(), to, 0, 21000, nil, big.NewInt(2), nil); !errors.Is(err, ErrApprovalRequired) {
+		t.Fatalf("SendTx() error = %v, want ErrApprovalRequired once the signed transaction used the quota", err)
+	}
+}
+
+func TestDualControlValuer(t *testing.T) {
+	token := common.HexToAddress("0x1c7D4B196Cb0C7B01d743Fbc6116a902379C7238")
+	recipient := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	// 将 ERC20 transfer 的数量计入交易金额
+	valuer := func(review *TxReview) *big.Int {
+		if review.Method != nil && review.Method.Name == "transfer" {
+			return review.Args[1].(*big.Int)
+		}
+		return review.Value
+	}
+	kit := newMockKit(t, newMockSendServer(t), WithDualControl(DualControlPolicy{Threshold: big.NewInt(1000), Valuer: valuer}))
+
+	small, _ := erc20ABI.Pack("transfer", recipient, big.NewInt(1000))
+	if _, err := kit.SendTx(context.Background(), token, 0, 0, nil, nil, small); err != nil {
+		t.Fatalf("SendTx() failed: %v", err)
+	}
+	large, _ := erc20ABI.Pack("transfer", recipient, big.NewInt(1001))
+	if _, err := kit.SendTx(context.Background(), token, 0, 0, nil, nil, large); !errors.Is(err, ErrApprovalRequired) {
+		t.Fatalf("SendTx() error = %v, want ErrApprovalRequired", err)
+	}
+}
+
+func TestDualControlSigningPaths(t *testing.T) {
+	ctx := context.Background()
+	recipient := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	token := common.HexToAddress("0x1c7D4B196Cb0C7B01d743Fbc6116a902379C7238")
+	amount := big.NewInt(1000)
+	// 将 ERC20 transfer 的数量计入金额，使 EIP-3009 授权同样受阈值限制
+	policy := DualControlPolicy{Threshold: big.NewInt(100), Valuer: func(review *TxReview) *big.Int {
+		if review.Method != nil && review.Method.Name == "transfer" {
+			return review.Args[1].(*big.Int)
+		}
+		return review.Value
+	}}
+	safe := &Safe{Address: common.HexToAddress("0x5afe"), ChainID: big.NewInt(1)}
+	safeTx := &SafeTx{To: recipient, Value: amount, Nonce: big.NewInt(0)}
+	executeData, _ := simpleAccountAbi.Pack("execute", recipient, amount, []byte{})
+
+	tests := []struct {
+		name string
+		node bool // 使用节点管理的账户
+		sign func(kit *Kit) error
+	}{
+		{"SignMessage", false, func(kit *Kit) error {
+			_, err := kit.SignMessage(ctx, []byte("hello"))
+			return err
+		}},
+		{"Signature", false, func(kit *Kit) error {
+			_, err := kit.Signature([]byte("hello"))
+			return err
+		}},
+		{"SignHash", false, func(kit *Kit) error {
+			_, err := kit.SignHash(common.HexToHash("0x01"))
+			return err
+		}},
+		{"SignTypedData", false, func(kit *Kit) error {
+			_, err := kit.SignTypedData(mailTypedData("Hello, Bob!"))
+			return err
+		}},
+		{"SignAuthorization", false, func(kit *Kit) error {
+			auth := &TransferAuthorization{From: kit.GetAddress(), To: recipient, Value: amount, ValidAfter: big.NewInt(0), ValidBefore: big.NewInt(1)}
+			_, err := kit.SignAuthorization(TransferWithAuthorization, apitypes.TypedDataDomain{
+				Name: "USD Coin", Version: "2", ChainId: math.NewHexOrDecimal256(1), VerifyingContract: token.Hex(),
+			}, auth)
+			return err
+		}},
+		{"SignSafeTx", false, func(kit *Kit) error {
+			_, err := kit.SignSafeTx(safe, safeTx)
+			return err
+		}},
+		{"SignPendingSafeTx", false, func(kit *Kit) error {
+			pending, _ := NewPendingSafeTx(safe, safeTx)
+			return kit.SignPendingSafeTx(ctx, pending)
+		}},
+		{"SignForwardRequest", false, func(kit *Kit) error {
+			forwarder := &Forwarder{Address: common.HexToAddress("0xf0"), ChainID: big.NewInt(1), Name: DefaultForwarderName, Version: DefaultForwarderVersion}
+			_, err := kit.SignForwardRequest(forwarder, &ForwardRequest{From: kit.GetAddress(), To: recipient, Value: amount, Gas: big.NewInt(0), Nonce: big.NewInt(0)})
+			return err
+		}},
+		{"SignUserOperation", false, func(kit *Kit) error {
+			op := &UserOperation{Sender: common.HexToAddress("0xacc0"), Nonce: big.NewInt(0), CallData: executeData}
+			return kit.SignUserOperation(ctx, op, common.HexToAddress(EntryPointV06Address))
+		}},
+		{"SendSignedZkSyncTx", false, func(kit *Kit) error {
+			_, err := kit.SendSignedZkSyncTx(ctx, &ZkSyncTx{ChainID: big.NewInt(ZkSyncSepoliaChainID), From: kit.GetAddress(), To: recipient, Gas: 21000, GasFeeCap: big.NewInt(1), Value: amount})
+			return err
+		}},
+		{"BuildTxOpts", false, func(kit *Kit) error {
+			opts, err := kit.BuildTxOpts(ctx, amount, nil, nil)
+			if err != nil {
+				return err
+			}
+			_, err = opts.Signer(opts.From, types.NewTx(&types.LegacyTx{To: &recipient, Gas: 21000, GasPrice: big.NewInt(1), Value: amount}))
+			return err
+		}},
+		{"SendTxViaNode", true, func(kit *Kit) error {
+			_, err := kit.SendTxViaNode(ctx, recipient, 0, 0, nil, amount, nil)
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var server *mockRPCServer
+			var kit *Kit
+			if tt.node {
+				pk, _ := BuildPrivateKeyFromHex("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+				server = newMockNodeAccountServer(t, pk)
+				var err error
+				kit, err = New(server.URL, WithNodeAccount(common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")), WithDualControl(policy))
+				if err != nil {
+					t.Fatalf("New() failed: %v", err)
+				}
+				defer kit.Close()
+			} else {
+				server = newMockSendServer(t)
+				kit = newMockKit(t, server, WithDualControl(policy))
+			}
+			if err := tt.sign(kit); !errors.Is(err, ErrApprovalRequired) || !errors.Is(err, ErrTxRejected) {
+				t.Fatalf("error = %v, want ErrApprovalRequired", err)
+			}
+			for _, method := range []string{"eth_sendRawTransaction", "eth_sendTransaction"} {
+				if server.callCount(method) != 0 {
+					t.Errorf("%s called for an unapproved signature", method)
+				}
+			}
+		})
+	}
+}
+
+func TestDualControlApprovesMessage(t *testing.T) {
+	kit := newMockKit(t, newMockSendServer(t), WithDualControl(DualControlPolicy{
+		Approver: func(ctx context.Context, review *TxReview, token string) error {
+			if review.Kind != AuditKindMessage || token != "ok" {
+				return errors.New("invalid approval token")
+			}
+			return nil
+		},
+	}))
+	ctx := ContextWithApprovalToken(context.Background(), "ok")
+	if _, err := kit.SignMessage(ctx, []byte("hello")); err != nil {
+		t.Fatalf("SignMessage() with approval failed: %v", err)
+	}
+}
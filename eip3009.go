@@ -0,0 +1,320 @@
+package etherkit
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+//############ EIP-3009 ############
+
+// AuthorizationKind EIP-3009 授权类型
+type AuthorizationKind string
+
+// EIP-3009 授权类型
+const (
+	// TransferWithAuthorization 任何人都可以提交的转账授权
+	TransferWithAuthorization AuthorizationKind = "TransferWithAuthorization"
+	// ReceiveWithAuthorization 只能由收款人提交的转账授权（防止授权被抢跑）
+	ReceiveWithAuthorization AuthorizationKind = "ReceiveWithAuthorization"
+)
+
+// eip3009ABI EIP-3009 代币中用到的方法
+const eip3009ABI = `[
+{"inputs":[],"name":"name","outputs":[{"internalType":"string","name":"","type":"string"}],"stateMutability":"view","type":"function"},
+{"inputs":[],"name":"version","outputs":[{"internalType":"string","name":"","type":"string"}],"stateMutability":"view","type":"function"},
+{"inputs":[{"internalType":"address","name":"authorizer","type":"address"},{"internalType":"bytes32","name":"nonce","type":"bytes32"}],"name":"authorizationState","outputs":[{"internalType":"bool","name":"","type":"bool"}],"stateMutability":"view","type":"function"},
+{"inputs":[{"internalType":"address","name":"from","type":"address"},{"internalType":"address","name":"to","type":"address"},{"internalType":"uint256","name":"value","type":"uint256"},{"internalType":"uint256","name":"validAfter","type":"uint256"},{"internalType":"uint256","name":"validBefore","type":"uint256"},{"internalType":"bytes32","name":"nonce","type":"bytes32"},{"internalType":"uint8","name":"v","type":"uint8"},{"internalType":"bytes32","name":"r","type":"bytes32"},{"internalType":"bytes32","name":"s","type":"bytes32"}],"name":"transferWithAuthorization","outputs":[],"stateMutability":"nonpayable","type":"function"},
+{"inputs":[{"internalType":"address","name":"from","type":"address"},{"internalType":"address","name":"to","type":"address"},{"internalType":"uint256","name":"value","type":"uint256"},{"internalType":"uint256","name":"validAfter","type":"uint256"},{"internalType":"uint256","name":"validBefore","type":"uint256"},{"internalType":"bytes32","name":"nonce","type":"bytes32"},{"internalType":"uint8","name":"v","type":"uint8"},{"internalType":"bytes32","name":"r","type":"bytes32"},{"internalType":"bytes32","name":"s","type":"bytes32"}],"name":"receiveWithAuthorization","outputs":[],"stateMutability":"nonpayable","type":"function"}
+]`
+
+// authorizationFields TransferWithAuthorization / ReceiveWithAuthorization 共用的字段
+var authorizationFields = []apitypes.Type{
+	{Name: "from", Type: "address"},
+	{Name: "to", Type: "address"},
+	{Name: "value", Type: "uint256"},
+	{Name: "validAfter", Type: "uint256"},
+	{Name: "validBefore", Type: "uint256"},
+	{Name: "nonce", Type: "bytes32"},
+}
+
+// TransferAuthorization EIP-3009 转账授权
+type TransferAuthorization struct {
+	From        common.Address // 付款人（签名者）
+	To          common.Address // 收款人
+	Value       *big.Int       // 转账金额（最小单位）
+	ValidAfter  *big.Int       // 生效时间（Unix 秒，0 表示立即生效）
+	ValidBefore *big.Int       // 失效时间（Unix 秒）
+	Nonce       common.Hash    // 随机 nonce（每个授权唯一）
+}
+
+// NewAuthorizationNonce 生成随机的 32 字节授权 nonce
+func NewAuthorizationNonce() (common.Hash, error) {
+	var nonce common.Hash
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return common.Hash{}, err
+	}
+	return nonce, nil
+}
+
+// GetTokenEIP712Domain 读取代币的 EIP-712 域（name、version、chainId、verifyingContract）
+// 代币没有 version() 方法时使用 "1"
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - token: 代币合约地址
+//
+// 返回：
+//   - apitypes.TypedDataDomain: EIP-712 域
+//   - error: 如果查询失败则返回错误
+func GetTokenEIP712Domain(ctx context.Context, ep EtherProvider, token common.Address) (apitypes.TypedDataDomain, error) {
+	parsed, err := GetABI(eip3009ABI)
+	if err != nil {
+		return apitypes.TypedDataDomain{}, err
+	}
+	callString := func(method string) (string, error) {
+		data, err := parsed.Pack(method)
+		if err != nil {
+			return "", err
+		}
+		res, err := providerExt[BlockRefReader](ep).CallContractAt(ctx, ethereum.CallMsg{To: &token, Data: data}, BlockRef{})
+		if err != nil {
+			return "", err
+		}
+		out, err := parsed.Unpack(method, res)
+		if err != nil {
+			return "", err
+		}
+		return out[0].(string), nil
+	}
+
+	chainId, err := ep.GetChainID(ctx)
+	if err != nil {
+		return apitypes.TypedDataDomain{}, err
+	}
+	name, err := callString("name")
+	if err != nil {
+		return apitypes.TypedDataDomain{}, fmt.Errorf("failed to query token name: %w", err)
+	}
+	version, err := callString("version")
+	if err != nil || version == "" {
+		version = "1"
+	}
+	return apitypes.TypedDataDomain{
+		Name:              name,
+		Version:           version,
+		ChainId:           (*math.HexOrDecimal256)(chainId),
+		VerifyingContract: token.Hex(),
+	}, nil
+}
+
+// TypedData 返回授权的 EIP-712 结构化数据
+// 参数说明：
+//   - kind: 授权类型
+//   - domain: 代币的 EIP-712 域（可通过 GetTokenEIP712Domain 获取）
+func (a *TransferAuthorization) TypedData(kind AuthorizationKind, domain apitypes.TypedDataDomain) apitypes.TypedData {
+	return apitypes.TypedData{
+		Types:       apitypes.Types{string(kind): authorizationFields},
+		PrimaryType: string(kind),
+		Domain:      domain,
+		Message: apitypes.TypedDataMessage{
+			"from":        a.From.Hex(),
+			"to":          a.To.Hex(),
+			"value":       bigOrZero(a.Value),
+			"validAfter":  bigOrZero(a.ValidAfter),
+			"validBefore": bigOrZero(a.ValidBefore),
+			"nonce":       hexutil.Bytes(a.Nonce.Bytes()),
+		},
+	}
+}
+
+// CallData 构建 transferWithAuthorization / receiveWithAuthorization 的调用数据
+// 参数说明：
+//   - kind: 授权类型
+//   - signature: 付款人的签名（65 字节）
+//
+// 返回：
+//   - []byte: 调用数据
+//   - error: 如果签名格式无效或编码失败则返回错误
+func (a *TransferAuthorization) CallData(kind AuthorizationKind, signature []byte) ([]byte, error) {
+	if len(signature) != 65 {
+		return nil, fmt.Errorf("invalid signature length %d, expected 65", len(signature))
+	}
+	parsed, err := GetABI(eip3009ABI)
+	if err != nil {
+		return nil, err
+	}
+
+	var method string
+	switch kind {
+	case TransferWithAuthorization:
+		method = "transferWithAuthorization"
+	case ReceiveWithAuthorization:
+		method = "receiveWithAuthorization"
+	default:
+		return nil, fmt.Errorf("unsupported authorization kind %q", kind)
+	}
+
+	v := signature[64]
+	if v < 27 {
+		v += 27
+	}
+	return parsed.Pack(method,
+		a.From, a.To, bigOrZero(a.Value), bigOrZero(a.ValidAfter), bigOrZero(a.ValidBefore), a.Nonce,
+		v, common.BytesToHash(signature[:32]), common.BytesToHash(signature[32:64]),
+	)
+}
+
+// SignAuthorization 使用钱包私钥对 EIP-3009 授权进行签名
+// 参数说明：
+//   - kind: 授权类型
+//   - domain: 代币的 EIP-712 域
+//   - auth: 转账授权（From 必须是钱包地址）
+//
+// 返回：
+//   - []byte: 签名（65 字节，v 为 27 或 28）
+//   - error: 如果签名失败则返回错误
+func (w *Wallet) SignAuthorization(kind AuthorizationKind, domain apitypes.TypedDataDomain, auth *TransferAuthorization) ([]byte, error) {
+	if auth.From != w.GetAddress() {
+		return nil, fmt.Errorf("authorization from %s does not match wallet address %s", auth.From.Hex(), w.GetAddress().Hex())
+	}
+	return w.SignTypedData(auth.TypedData(kind, domain))
+}
+
+// SignAuthorization 使用 Kit 的私钥对 EIP-3009 授权进行签名（签名前执行审核回调，启用审计时写入审计记录）
+// 覆盖 Wallet.SignAuthorization，审核回调看到的是一笔从 auth.From 到 auth.To 的代币转账
+// 参数说明：
+//   - kind: 授权类型
+//   - domain: 代币的 EIP-712 域
+//   - auth: 转账授权（From 必须是 Kit 地址）
+//
+// 返回：
+//   - []byte: 签名（65 字节，v 为 27 或 28）
+//   - error: 如果审核被拒绝、签名或写入审计记录失败则返回错误
+func (k *Kit) SignAuthorization(kind AuthorizationKind, domain apitypes.TypedDataDomain, auth *TransferAuthorization) ([]byte, error) {
+	return k.signAuthorization(context.Background(), kind, domain, auth)
+}
+
+// signAuthorization 审核并签名 EIP-3009 授权
+func (k *Kit) signAuthorization(ctx context.Context, kind AuthorizationKind, domain apitypes.TypedDataDomain, auth *TransferAuthorization) ([]byte, error) {
+	if auth.From != k.GetAddress() {
+		return nil, fmt.Errorf("authorization from %s does not match wallet address %s", auth.From.Hex(), k.GetAddress().Hex())
+	}
+	token := common.HexToAddress(domain.VerifyingContract)
+	intent := fmt.Sprintf("EIP-3009 %s of %s on token %s to %s", kind, bigOrZero(auth.Value), token.Hex(), auth.To.Hex())
+	return k.signTypedData(ctx, auth.TypedData(kind, domain), intent, func(review *TxReview) {
+		// 授权等价于一笔代币转账，按 transfer(to, value) 交给审核回调
+		data, _ := erc20ABI.Pack("transfer", auth.To, bigOrZero(auth.Value))
+		review.setCall(auth.From, token, big.NewInt(0), data)
+	})
+}
+
+// SignTransferAuthorization 构建并签名 EIP-3009 转账授权（付款人为 Kit 账户）
+// 签名后的授权可以交给收款人或中继者提交，付款人无需支付 gas
+// 参数说明：
+//   - ctx: 上下文对象
+//   - kind: 授权类型（ReceiveWithAuthorization 只能由收款人提交）
+//   - token: 代币合约地址（如 USDC）
+//   - to: 收款人地址
+//   - value: 转账金额（最小单位）
+//   - validFor: 授权有效期（如 time.Hour）
+//
+// 返回：
+//   - *TransferAuthorization: 转账授权
+//   - []byte: 签名
+//   - error: 如果查询域信息、签名或写入审计记录失败则返回错误
+func (k *Kit) SignTransferAuthorization(ctx context.Context, kind AuthorizationKind, token, to common.Address, value *big.Int, validFor time.Duration) (*TransferAuthorization, []byte, error) {
+	domain, err := GetTokenEIP712Domain(ctx, k.EtherProvider, token)
+	if err != nil {
+		return nil, nil, err
+	}
+	nonce, err := NewAuthorizationNonce()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	auth := &TransferAuthorization{
+		From:        k.GetAddress(),
+		To:          to,
+		Value:       value,
+		ValidAfter:  big.NewInt(0),
+		ValidBefore: big.NewInt(time.Now().Add(validFor).Unix()),
+		Nonce:       nonce,
+	}
+	signature, err := k.signAuthorization(ctx, kind, domain, auth)
+	if err != nil {
+		return nil, nil, err
+	}
+	return auth, signature, nil
+}
+
+// SubmitAuthorization 提交 EIP-3009 转账授权（由 Kit 账户支付 gas）
+// 参数说明：
+//   - ctx: 上下文对象
+//   - kind: 授权类型（ReceiveWithAuthorization 要求 Kit 账户是收款人）
+//   - token: 代币合约地址
+//   - auth: 转账授权
+//   - signature: 付款人的签名
+//
+// 返回：
+//   - common.Hash: 交易哈希
+//   - error: 如果授权已被使用、提交者不符合要求或发送失败则返回错误
+func (k *Kit) SubmitAuthorization(ctx context.Context, kind AuthorizationKind, token common.Address, auth *TransferAuthorization, signature []byte) (common.Hash, error) {
+	if kind == ReceiveWithAuthorization && auth.To != k.GetAddress() {
+		return common.Hash{}, errors.New("receiveWithAuthorization must be submitted by the payee")
+	}
+
+	used, err := IsAuthorizationUsed(ctx, k.EtherProvider, token, auth.From, auth.Nonce)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if used {
+		return common.Hash{}, fmt.Errorf("authorization nonce %s has already been used", auth.Nonce.Hex())
+	}
+
+	data, err := auth.CallData(kind, signature)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return k.SendTx(ctx, token, 0, 0, nil, nil, data)
+}
+
+// IsAuthorizationUsed 查询授权 nonce 是否已被使用或取消
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - token: 代币合约地址
+//   - authorizer: 付款人地址
+//   - nonce: 授权 nonce
+//
+// 返回：
+//   - bool: true 表示已被使用或取消
+//   - error: 如果查询失败则返回错误
+func IsAuthorizationUsed(ctx context.Context, ep EtherProvider, token, authorizer common.Address, nonce common.Hash) (bool, error) {
+	parsed, err := GetABI(eip3009ABI)
+	if err != nil {
+		return false, err
+	}
+	data, err := parsed.Pack("authorizationState", authorizer, nonce)
+	if err != nil {
+		return false, err
+	}
+	res, err := providerExt[BlockRefReader](ep).CallContractAt(ctx, ethereum.CallMsg{To: &token, Data: data}, BlockRef{})
+	if err != nil {
+		return false, fmt.Errorf("failed to query authorization state: %w", err)
+	}
+	out, err := parsed.Unpack("authorizationState", res)
+	if err != nil {
+		return false, err
+	}
+	return out[0].(bool), nil
+}
@@ -0,0 +1,83 @@
+package etherkit
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+func TestTransferAuthorizationSigning(t *testing.T) {
+	pk, _ := BuildPrivateKeyFromHex("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	wallet, _ := NewWalletWithComponents(pk, nil)
+
+	token := common.HexToAddress("0x1c7D4B196Cb0C7B01d743Fbc6116a902379C7238")
+	domain := apitypes.TypedDataDomain{
+		Name:              "USDC",
+		Version:           "2",
+		ChainId:           math.NewHexOrDecimal256(SepoliaChainID),
+		VerifyingContract: token.Hex(),
+	}
+	auth := &TransferAuthorization{
+		From:        wallet.GetAddress(),
+		To:          common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8"),
+		Value:       big.NewInt(1000000),
+		ValidAfter:  big.NewInt(0),
+		ValidBefore: big.NewInt(1900000000),
+		Nonce:       common.HexToHash("0x01"),
+	}
+
+	hash, err := HashTypedData(auth.TypedData(TransferWithAuthorization, domain))
+	if err != nil {
+		t.Fatalf("HashTypedData() failed: %v", err)
+	}
+
+	// 按 FiatTokenV2 合约逻辑手动计算
+	bytes32, _ := abi.NewType("bytes32", "", nil)
+	uint256, _ := abi.NewType("uint256", "", nil)
+	address, _ := abi.NewType("address", "", nil)
+	domainSeparator := crypto.Keccak256(mustPack(t, abi.Arguments{{Type: bytes32}, {Type: bytes32}, {Type: bytes32}, {Type: uint256}, {Type: address}},
+		crypto.Keccak256Hash([]byte("EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)")),
+		crypto.Keccak256Hash([]byte("USDC")), crypto.Keccak256Hash([]byte("2")), big.NewInt(SepoliaChainID), token))
+	structHash := crypto.Keccak256(mustPack(t, abi.Arguments{{Type: bytes32}, {Type: address}, {Type: address}, {Type: uint256}, {Type: uint256}, {Type: uint256}, {Type: bytes32}},
+		crypto.Keccak256Hash([]byte("TransferWithAuthorization(address from,address to,uint256 value,uint256 validAfter,uint256 validBefore,bytes32 nonce)")),
+		auth.From, auth.To, auth.Value, auth.ValidAfter, auth.ValidBefore, auth.Nonce))
+	expected := crypto.Keccak256Hash([]byte{0x19, 0x01}, domainSeparator, structHash)
+	if hash != expected {
+		t.Errorf("Authorization hash = %s, expected %s", hash.Hex(), expected.Hex())
+	}
+
+	signature, err := wallet.SignAuthorization(TransferWithAuthorization, domain, auth)
+	if err != nil {
+		t.Fatalf("SignAuthorization() failed: %v", err)
+	}
+
+	// 调用数据中的 v/r/s 应与签名一致
+	data, err := auth.CallData(TransferWithAuthorization, signature)
+	if err != nil {
+		t.Fatalf("CallData() failed: %v", err)
+	}
+	parsed, _ := GetABI(eip3009ABI)
+	method, err := parsed.MethodById(data[:4])
+	if err != nil || method.Name != "transferWithAuthorization" {
+		t.Fatalf("CallData() method = %v, err %v", method, err)
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		t.Fatalf("Unpack() failed: %v", err)
+	}
+	if args[6].(uint8) != signature[64] || common.Hash(args[7].([32]byte)) != common.BytesToHash(signature[:32]) {
+		t.Errorf("CallData() v/r mismatch")
+	}
+
+	// 付款人必须是钱包地址
+	other := *auth
+	other.From = auth.To
+	if _, err := wallet.SignAuthorization(TransferWithAuthorization, domain, &other); err == nil {
+		t.Error("Expected error when authorization from does not match wallet")
+	}
+}
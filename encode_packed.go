@@ -0,0 +1,221 @@
+package etherkit
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+//############ Encode Packed ############
+
+// PackedValue 指定 Solidity 类型的值（用于 EncodePacked 中 Go 类型无法确定 Solidity 类型的情况，如 uint128、bytes4）
+type PackedValue struct {
+	Type  string      // Solidity 类型（如 "uint128"、"bytes4"、"address[]"）
+	Value interface{} // 值（Go 类型需与 Solidity 类型对应，如 uint128 传 *big.Int、bytes4 传 [4]byte）
+}
+
+// Packed 创建指定 Solidity 类型的值
+// 示例：
+//   - Packed("uint128", big.NewInt(1))
+//   - Packed("int16", int16(-1))
+func Packed(typ string, value interface{}) PackedValue {
+	return PackedValue{Type: typ, Value: value}
+}
+
+// EncodePacked 按 Solidity abi.encodePacked 的规则编码，用于复现合约中 keccak256(abi.encodePacked(...)) 计算的哈希和签名
+// 规则：静态类型按实际长度编码（不补齐到 32 字节），string/bytes 不带长度前缀，数组元素按标准 ABI 补齐到 32 字节
+// 未用 Packed 指定类型时按 Go 类型推断：
+//   - common.Address → address，bool → bool，string → string，[]byte → bytes
+//   - common.Hash / [N]byte → bytesN，*big.Int → uint256（负数为 int256）
+//   - uint8~uint64 / int8~int64 → uintN / intN，int / uint → int256 / uint256
+//   - 以上类型的切片 → T[]
+//
+// 参数说明：
+//   - values: 要编码的值
+//
+// 返回：
+//   - []byte: 编码结果
+//   - error: 如果值的类型不受支持或与指定的 Solidity 类型不匹配则返回错误
+//
+// 示例：
+//   - EncodePacked(Packed("int16", int16(-1)), Packed("bytes1", [1]byte{0x42}), uint16(3), "Hello, world!")
+//     // 0xffff42000348656c6c6f2c20776f726c6421
+func EncodePacked(values ...interface{}) ([]byte, error) {
+	var out []byte
+	for i, value := range values {
+		typed, ok := value.(PackedValue)
+		if !ok {
+			var err error
+			if typed, err = inferPackedValue(value); err != nil {
+				return nil, fmt.Errorf("value %d: %w", i, err)
+			}
+		}
+		encoded, err := encodePackedValue(typed)
+		if err != nil {
+			return nil, fmt.Errorf("value %d (%s): %w", i, typed.Type, err)
+		}
+		out = append(out, encoded...)
+	}
+	return out, nil
+}
+
+// Keccak256Packed 计算 keccak256(abi.encodePacked(values...))（即 Solidity 中常见的 solidityKeccak256）
+// 参数说明：
+//   - values: 要编码的值（规则同 EncodePacked）
+//
+// 返回：
+//   - common.Hash: 哈希值
+//   - error: 如果编码失败则返回错误
+func Keccak256Packed(values ...interface{}) (common.Hash, error) {
+	encoded, err := EncodePacked(values...)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash(encoded), nil
+}
+
+// inferPackedValue 根据 Go 类型推断 Solidity 类型
+func inferPackedValue(value interface{}) (PackedValue, error) {
+	switch v := value.(type) {
+	case int:
+		return PackedValue{"int256", big.NewInt(int64(v))}, nil
+	case uint:
+		return PackedValue{"uint256", new(big.Int).SetUint64(uint64(v))}, nil
+	case *big.Int:
+		if v == nil {
+			return PackedValue{}, fmt.Errorf("nil *big.Int")
+		}
+		if v.Sign() < 0 {
+			return PackedValue{"int256", v}, nil
+		}
+		return PackedValue{"uint256", v}, nil
+	}
+	typ, err := packedTypeOf(reflect.TypeOf(value))
+	if err != nil {
+		return PackedValue{}, err
+	}
+	return PackedValue{typ, value}, nil
+}
+
+// packedTypeOf 根据 Go 类型返回对应的 Solidity 类型
+func packedTypeOf(t reflect.Type) (string, error) {
+	if t == nil {
+		return "", fmt.Errorf("nil value")
+	}
+	switch t {
+	case reflect.TypeOf(common.Address{}):
+		return "address", nil
+	case reflect.TypeOf(common.Hash{}):
+		return "bytes32", nil
+	case reflect.TypeOf(&big.Int{}):
+		return "uint256", nil
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return "bool", nil
+	case reflect.String:
+		return "string", nil
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return fmt.Sprintf("uint%d", t.Bits()), nil
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return fmt.Sprintf("int%d", t.Bits()), nil
+	case reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 && t.Len() >= 1 && t.Len() <= 32 {
+			return fmt.Sprintf("bytes%d", t.Len()), nil
+		}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "bytes", nil
+		}
+		elem, err := packedTypeOf(t.Elem())
+		if err != nil {
+			return "", err
+		}
+		return elem + "[]", nil
+	}
+	return "", fmt.Errorf("unsupported type %s for encodePacked, use Packed to specify the solidity type", t)
+}
+
+// encodePackedValue 按指定的 Solidity 类型紧凑编码
+func encodePackedValue(pv PackedValue) ([]byte, error) {
+	typ, err := abi.NewType(pv.Type, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	switch typ.T {
+	case abi.StringTy:
+		s, ok := pv.Value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected string, got %T", pv.Value)
+		}
+		return []byte(s), nil
+	case abi.BytesTy:
+		b, ok := pv.Value.([]byte)
+		if !ok {
+			return nil, fmt.Errorf("expected []byte, got %T", pv.Value)
+		}
+		return b, nil
+	case abi.SliceTy, abi.ArrayTy:
+		if isDynamicABIType(*typ.Elem) {
+			return nil, fmt.Errorf("arrays of dynamic types are not supported by encodePacked")
+		}
+		rv := reflect.ValueOf(pv.Value)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return nil, fmt.Errorf("expected slice or array, got %T", pv.Value)
+		}
+		if typ.T == abi.ArrayTy && rv.Len() != typ.Size {
+			return nil, fmt.Errorf("expected %d elements, got %d", typ.Size, rv.Len())
+		}
+		// 数组元素按标准 ABI 编码（补齐到 32 字节）
+		elem := abi.Arguments{{Type: *typ.Elem}}
+		var out []byte
+		for i := 0; i < rv.Len(); i++ {
+			encoded, err := elem.Pack(rv.Index(i).Interface())
+			if err != nil {
+				return nil, fmt.Errorf("element %d: %w", i, err)
+			}
+			out = append(out, encoded...)
+		}
+		return out, nil
+	case abi.TupleTy:
+		return nil, fmt.Errorf("structs are not supported by encodePacked")
+	}
+
+	// 静态类型：先按标准 ABI 编码（同时校验值与类型是否匹配），再截取实际长度
+	encoded, err := abi.Arguments{{Type: typ}}.Pack(pv.Value)
+	if err != nil {
+		return nil, err
+	}
+	switch typ.T {
+	case abi.AddressTy:
+		return encoded[32-common.AddressLength:], nil
+	case abi.BoolTy:
+		return encoded[31:], nil
+	case abi.IntTy, abi.UintTy:
+		return encoded[32-typ.Size/8:], nil
+	case abi.FixedBytesTy:
+		return encoded[:typ.Size], nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", pv.Type)
+}
+
+// isDynamicABIType 判断类型在标准 ABI 中是否为动态类型
+func isDynamicABIType(t abi.Type) bool {
+	switch t.T {
+	case abi.StringTy, abi.BytesTy, abi.SliceTy:
+		return true
+	case abi.ArrayTy:
+		return isDynamicABIType(*t.Elem)
+	case abi.TupleTy:
+		for _, elem := range t.TupleElems {
+			if isDynamicABIType(*elem) {
+				return true
+			}
+		}
+	}
+	return false
+}
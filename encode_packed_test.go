@@ -0,0 +1,80 @@
+package etherkit
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestEncodePacked(t *testing.T) {
+	addr := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+
+	tests := []struct {
+		name    string
+		values  []interface{}
+		want    string
+		wantErr bool
+	}{
+		{
+			// Solidity 文档中的示例
+			name:   "solidity docs",
+			values: []interface{}{Packed("int16", int16(-1)), Packed("bytes1", [1]byte{0x42}), uint16(3), "Hello, world!"},
+			want:   "0xffff42000348656c6c6f2c20776f726c6421",
+		},
+		{
+			name:   "address and uint256",
+			values: []interface{}{addr, big.NewInt(1)},
+			want:   "0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266" + "0000000000000000000000000000000000000000000000000000000000000001",
+		},
+		{
+			name:   "bool bytes and negative int",
+			values: []interface{}{true, []byte{0xde, 0xad}, big.NewInt(-1)},
+			want:   "0x01dead" + "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		},
+		{
+			name:   "sized uint",
+			values: []interface{}{Packed("uint128", big.NewInt(2)), uint8(7)},
+			want:   "0x0000000000000000000000000000000207",
+		},
+		{
+			// 数组元素补齐到 32 字节
+			name:   "array",
+			values: []interface{}{[]common.Address{addr}, Packed("uint8[]", []uint8{1, 2})},
+			want:   "0x000000000000000000000000f39fd6e51aad88f6f4ce6ab8827279cfffb92266" + "0000000000000000000000000000000000000000000000000000000000000001" + "0000000000000000000000000000000000000000000000000000000000000002",
+		},
+		{
+			name:   "hash",
+			values: []interface{}{common.HexToHash("0x01")},
+			want:   "0x0000000000000000000000000000000000000000000000000000000000000001",
+		},
+		{name: "type mismatch", values: []interface{}{Packed("uint8", big.NewInt(1))}, wantErr: true},
+		{name: "dynamic array", values: []interface{}{[]string{"a"}}, wantErr: true},
+		{name: "unsupported", values: []interface{}{struct{}{}}, wantErr: true},
+		{name: "overflow", values: []interface{}{Packed("uint8", uint16(300))}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EncodePacked(tt.values...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("EncodePacked() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && hexutil.Encode(got) != tt.want {
+				t.Errorf("EncodePacked() = %s, expected %s", hexutil.Encode(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestKeccak256Packed(t *testing.T) {
+	// keccak256(abi.encodePacked("Transfer(address,address,uint256)")) 即事件 topic
+	got, err := Keccak256Packed("Transfer(address,address,uint256)")
+	if err != nil {
+		t.Fatalf("Keccak256Packed() failed: %v", err)
+	}
+	if got != erc20ABI.Events["Transfer"].ID {
+		t.Errorf("Keccak256Packed() = %s", got.Hex())
+	}
+}
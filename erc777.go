@@ -0,0 +1,261 @@
+package etherkit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//############ ERC-777 ############
+
+// ErrInvalidGranularity 数量不是 ERC-777 代币 granularity 的整数倍（代币合约会拒绝此类操作）
+var ErrInvalidGranularity = errors.New("amount is not a multiple of token granularity")
+
+// erc777ABI ERC-777 代币中用到的方法
+const erc777ABI = `[
+{"inputs":[],"name":"granularity","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+{"inputs":[],"name":"defaultOperators","outputs":[{"internalType":"address[]","name":"","type":"address[]"}],"stateMutability":"view","type":"function"},
+{"inputs":[{"internalType":"address","name":"operator","type":"address"},{"internalType":"address","name":"tokenHolder","type":"address"}],"name":"isOperatorFor","outputs":[{"internalType":"bool","name":"","type":"bool"}],"stateMutability":"view","type":"function"},
+{"inputs":[{"internalType":"address","name":"recipient","type":"address"},{"internalType":"uint256","name":"amount","type":"uint256"},{"internalType":"bytes","name":"data","type":"bytes"}],"name":"send","outputs":[],"stateMutability":"nonpayable","type":"function"},
+{"inputs":[{"internalType":"address","name":"sender","type":"address"},{"internalType":"address","name":"recipient","type":"address"},{"internalType":"uint256","name":"amount","type":"uint256"},{"internalType":"bytes","name":"data","type":"bytes"},{"internalType":"bytes","name":"operatorData","type":"bytes"}],"name":"operatorSend","outputs":[],"stateMutability":"nonpayable","type":"function"},
+{"inputs":[{"internalType":"uint256","name":"amount","type":"uint256"},{"internalType":"bytes","name":"data","type":"bytes"}],"name":"burn","outputs":[],"stateMutability":"nonpayable","type":"function"},
+{"inputs":[{"internalType":"address","name":"account","type":"address"},{"internalType":"uint256","name":"amount","type":"uint256"},{"internalType":"bytes","name":"data","type":"bytes"},{"internalType":"bytes","name":"operatorData","type":"bytes"}],"name":"operatorBurn","outputs":[],"stateMutability":"nonpayable","type":"function"},
+{"inputs":[{"internalType":"address","name":"operator","type":"address"}],"name":"authorizeOperator","outputs":[],"stateMutability":"nonpayable","type":"function"},
+{"inputs":[{"internalType":"address","name":"operator","type":"address"}],"name":"revokeOperator","outputs":[],"stateMutability":"nonpayable","type":"function"}
+]`
+
+// erc777Parsed 解析后的 ERC-777 ABI
+var erc777Parsed, _ = GetABI(erc777ABI)
+
+// callERC777 调用 ERC-777 代币的只读方法
+func callERC777(ctx context.Context, ep EtherProvider, token common.Address, method string, args ...interface{}) ([]interface{}, error) {
+	data, err := erc777Parsed.Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+	ret, err := providerExt[BlockRefReader](ep).CallContractAt(ctx, ethereum.CallMsg{To: &token, Data: data}, BlockRef{})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrContractCall, method, err)
+	}
+	return erc777Parsed.Unpack(method, ret)
+}
+
+// GetERC777Granularity 查询 ERC-777 代币的最小操作单位（所有转账、销毁数量都必须是它的整数倍）
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - token: 代币合约地址
+//
+// 返回：
+//   - *big.Int: granularity（绝大多数代币为 1）
+//   - error: 如果查询失败则返回错误
+func GetERC777Granularity(ctx context.Context, ep EtherProvider, token common.Address) (*big.Int, error) {
+	out, err := callERC777(ctx, ep, token, "granularity")
+	if err != nil {
+		return nil, err
+	}
+	return out[0].(*big.Int), nil
+}
+
+// GetERC777DefaultOperators 查询 ERC-777 代币的默认操作员（默认可代所有持有人转账，持有人可单独撤销）
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - token: 代币合约地址
+//
+// 返回：
+//   - []common.Address: 默认操作员列表
+//   - error: 如果查询失败则返回错误
+func GetERC777DefaultOperators(ctx context.Context, ep EtherProvider, token common.Address) ([]common.Address, error) {
+	out, err := callERC777(ctx, ep, token, "defaultOperators")
+	if err != nil {
+		return nil, err
+	}
+	return out[0].([]common.Address), nil
+}
+
+// IsERC777OperatorFor 查询 operator 是否可以代 holder 操作代币
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - token: 代币合约地址
+//   - operator: 操作员地址
+//   - holder: 持有人地址
+//
+// 返回：
+//   - bool: 是否为持有人的操作员（持有人本身总是自己的操作员）
+//   - error: 如果查询失败则返回错误
+func IsERC777OperatorFor(ctx context.Context, ep EtherProvider, token, operator, holder common.Address) (bool, error) {
+	out, err := callERC777(ctx, ep, token, "isOperatorFor", operator, holder)
+	if err != nil {
+		return false, err
+	}
+	return out[0].(bool), nil
+}
+
+// CheckGranularity 检查数量是否为 granularity 的整数倍
+// 参数说明：
+//   - amount: 操作数量（最小单位）
+//   - granularity: 代币的 granularity
+//
+// 返回：
+//   - error: 如果数量不合法则返回包装了 ErrInvalidGranularity 的错误
+func CheckGranularity(amount, granularity *big.Int) error {
+	if amount == nil || amount.Sign() < 0 {
+		return fmt.Errorf("%w: invalid amount %v", ErrInvalidGranularity, amount)
+	}
+	if granularity == nil || granularity.Sign() <= 0 {
+		return fmt.Errorf("%w: invalid granularity %v", ErrInvalidGranularity, granularity)
+	}
+	if new(big.Int).Mod(amount, granularity).Sign() != 0 {
+		return fmt.Errorf("%w: %s is not a multiple of %s", ErrInvalidGranularity, amount, granularity)
+	}
+	return nil
+}
+
+// checkERC777Granularity 查询代币 granularity 并检查数量
+func (k *Kit) checkERC777Granularity(ctx context.Context, token common.Address, amount *big.Int) error {
+	granularity, err := GetERC777Granularity(ctx, k.EtherProvider, token)
+	if err != nil {
+		return err
+	}
+	return CheckGranularity(amount, granularity)
+}
+
+// invokeERC777 发送 ERC-777 代币交易（走 Kit 统一的审核、签名、审计流程）
+func (k *Kit) invokeERC777(ctx context.Context, token common.Address, method string, args ...interface{}) (common.Hash, error) {
+	data, err := erc777Parsed.Pack(method, args...)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return k.sendTx(ctx, token, 0, 0, nil, nil, data, &erc777Parsed)
+}
+
+// ERC777Send 发送 ERC-777 代币（携带 data，会触发收款方的 tokensReceived 钩子）
+// 与 ERC20 transfer 不同，收款方为未注册 ERC777TokensRecipient 的合约时交易会回滚
+// 参数说明：
+//   - ctx: 上下文对象
+//   - token: 代币合约地址
+//   - to: 收款地址
+//   - amount: 数量（最小单位，必须是 granularity 的整数倍）
+//   - data: 附带给收款方的数据（可为 nil）
+//
+// 返回：
+//   - common.Hash: 交易哈希
+//   - error: 如果数量不合法（ErrInvalidGranularity）或发送失败则返回错误
+func (k *Kit) ERC777Send(ctx context.Context, token, to common.Address, amount *big.Int, data []byte) (common.Hash, error) {
+	if err := k.checkERC777Granularity(ctx, token, amount); err != nil {
+		return common.Hash{}, err
+	}
+	return k.invokeERC777(ctx, token, "send", to, amount, data)
+}
+
+// ERC777OperatorSend 以操作员身份代持有人发送 ERC-777 代币
+// 参数说明：
+//   - ctx: 上下文对象
+//   - token: 代币合约地址
+//   - from: 持有人地址（Kit 账户必须是其操作员）
+//   - to: 收款地址
+//   - amount: 数量（最小单位，必须是 granularity 的整数倍）
+//   - data: 附带给收款方的数据（可为 nil）
+//   - operatorData: 操作员附带的数据（可为 nil）
+//
+// 返回：
+//   - common.Hash: 交易哈希
+//   - error: 如果 Kit 账户不是持有人的操作员、数量不合法或发送失败则返回错误
+func (k *Kit) ERC777OperatorSend(ctx context.Context, token, from, to common.Address, amount *big.Int, data, operatorData []byte) (common.Hash, error) {
+	if err := k.checkERC777Operator(ctx, token, from); err != nil {
+		return common.Hash{}, err
+	}
+	if err := k.checkERC777Granularity(ctx, token, amount); err != nil {
+		return common.Hash{}, err
+	}
+	return k.invokeERC777(ctx, token, "operatorSend", from, to, amount, data, operatorData)
+}
+
+// ERC777Burn 销毁 Kit 账户持有的 ERC-777 代币
+// 参数说明：
+//   - ctx: 上下文对象
+//   - token: 代币合约地址
+//   - amount: 数量（最小单位，必须是 granularity 的整数倍）
+//   - data: 附带的数据（可为 nil）
+//
+// 返回：
+//   - common.Hash: 交易哈希
+//   - error: 如果数量不合法或发送失败则返回错误
+func (k *Kit) ERC777Burn(ctx context.Context, token common.Address, amount *big.Int, data []byte) (common.Hash, error) {
+	if err := k.checkERC777Granularity(ctx, token, amount); err != nil {
+		return common.Hash{}, err
+	}
+	return k.invokeERC777(ctx, token, "burn", amount, data)
+}
+
+// ERC777OperatorBurn 以操作员身份销毁持有人的 ERC-777 代币
+// 参数说明：
+//   - ctx: 上下文对象
+//   - token: 代币合约地址
+//   - from: 持有人地址（Kit 账户必须是其操作员）
+//   - amount: 数量（最小单位，必须是 granularity 的整数倍）
+//   - data: 附带的数据（可为 nil）
+//   - operatorData: 操作员附带的数据（可为 nil）
+//
+// 返回：
+//   - common.Hash: 交易哈希
+//   - error: 如果 Kit 账户不是持有人的操作员、数量不合法或发送失败则返回错误
+func (k *Kit) ERC777OperatorBurn(ctx context.Context, token, from common.Address, amount *big.Int, data, operatorData []byte) (common.Hash, error) {
+	if err := k.checkERC777Operator(ctx, token, from); err != nil {
+		return common.Hash{}, err
+	}
+	if err := k.checkERC777Granularity(ctx, token, amount); err != nil {
+		return common.Hash{}, err
+	}
+	return k.invokeERC777(ctx, token, "operatorBurn", from, amount, data, operatorData)
+}
+
+// ERC777AuthorizeOperator 授权 operator 代 Kit 账户操作代币（也可用于恢复被撤销的默认操作员）
+// 参数说明：
+//   - ctx: 上下文对象
+//   - token: 代币合约地址
+//   - operator: 操作员地址
+//
+// 返回：
+//   - common.Hash: 交易哈希
+//   - error: 如果发送失败则返回错误
+func (k *Kit) ERC777AuthorizeOperator(ctx context.Context, token, operator common.Address) (common.Hash, error) {
+	if operator == k.GetAddress() {
+		return common.Hash{}, errors.New("cannot authorize self as operator")
+	}
+	return k.invokeERC777(ctx, token, "authorizeOperator", operator)
+}
+
+// ERC777RevokeOperator 撤销 operator 的操作权限（包括默认操作员）
+// 参数说明：
+//   - ctx: 上下文对象
+//   - token: 代币合约地址
+//   - operator: 操作员地址
+//
+// 返回：
+//   - common.Hash: 交易哈希
+//   - error: 如果发送失败则返回错误
+func (k *Kit) ERC777RevokeOperator(ctx context.Context, token, operator common.Address) (common.Hash, error) {
+	if operator == k.GetAddress() {
+		return common.Hash{}, errors.New("cannot revoke self as operator")
+	}
+	return k.invokeERC777(ctx, token, "revokeOperator", operator)
+}
+
+// checkERC777Operator 检查 Kit 账户是否为持有人的操作员
+func (k *Kit) checkERC777Operator(ctx context.Context, token, holder common.Address) error {
+	ok, err := IsERC777OperatorFor(ctx, k.EtherProvider, token, k.GetAddress(), holder)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%s is not an operator for %s", k.GetAddress().Hex(), holder.Hex())
+	}
+	return nil
+}
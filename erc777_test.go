@@ -0,0 +1,146 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestCheckGranularity(t *testing.T) {
+	tests := []struct {
+		name        string
+		amount      *big.Int
+		granularity *big.Int
+		wantErr     bool
+	}{
+		{"granularity one", big.NewInt(12345), big.NewInt(1), false},
+		{"exact multiple", big.NewInt(3000), big.NewInt(1000), false},
+		{"not a multiple", big.NewInt(1500), big.NewInt(1000), true},
+		{"zero granularity", big.NewInt(1), big.NewInt(0), true},
+		{"nil amount", nil, big.NewInt(1), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckGranularity(tt.amount, tt.granularity)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckGranularity() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidGranularity) {
+				t.Errorf("error %v should wrap ErrInvalidGranularity", err)
+			}
+		})
+	}
+}
+
+// newMockERC777Server 模拟 ERC-777 代币合约，并记录广播的交易
+func newMockERC777Server(t *testing.T, granularity int64, operator bool, sent *[]*types.Transaction) *mockRPCServer {
+	t.Helper()
+	server := newMockSendServer(t)
+	server.handlers["eth_call"] = func(params []json.RawMessage) (interface{}, error) {
+		arg, err := parseMockCallArg(params)
+		if err != nil {
+			return nil, err
+		}
+		method, err := erc777Parsed.MethodById(arg.calldata()[:4])
+		if err != nil {
+			return nil, err
+		}
+		switch method.Name {
+		case "granularity":
+			return packOutputs(method.Outputs, big.NewInt(granularity))
+		case "isOperatorFor":
+			return packOutputs(method.Outputs, operator)
+		case "defaultOperators":
+			return packOutputs(method.Outputs, []common.Address{common.HexToAddress("0x1820a4B7618BdE71Dce8cdc73aAB6C95905faD24")})
+		}
+		return nil, errors.New("unsupported method " + method.Name)
+	}
+	server.handlers["eth_sendRawTransaction"] = func(params []json.RawMessage) (interface{}, error) {
+		var raw hexutil.Bytes
+		if err := json.Unmarshal(params[0], &raw); err != nil {
+			return nil, err
+		}
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(raw); err != nil {
+			return nil, err
+		}
+		*sent = append(*sent, tx)
+		return tx.Hash(), nil
+	}
+	return server
+}
+
+func TestERC777(t *testing.T) {
+	token := common.HexToAddress("0x00000000000000000000000000000000000777")
+	recipient := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	holder := common.HexToAddress("0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC")
+	ctx := context.Background()
+
+	t.Run("send with data", func(t *testing.T) {
+		var sent []*types.Transaction
+		kit := newMockKit(t, newMockERC777Server(t, 1, false, &sent))
+		if _, err := kit.ERC777Send(ctx, token, recipient, big.NewInt(1000), []byte("memo")); err != nil {
+			t.Fatalf("ERC777Send() failed: %v", err)
+		}
+		if len(sent) != 1 || *sent[0].To() != token {
+			t.Fatalf("sent = %v, expected one tx to token", sent)
+		}
+		args, err := erc777Parsed.Methods["send"].Inputs.Unpack(sent[0].Data()[4:])
+		if err != nil {
+			t.Fatalf("Unpack() failed: %v", err)
+		}
+		if args[0].(common.Address) != recipient || args[1].(*big.Int).Int64() != 1000 || string(args[2].([]byte)) != "memo" {
+			t.Errorf("send args = %v", args)
+		}
+	})
+
+	t.Run("granularity violation", func(t *testing.T) {
+		var sent []*types.Transaction
+		kit := newMockKit(t, newMockERC777Server(t, 1000, false, &sent))
+		if _, err := kit.ERC777Burn(ctx, token, big.NewInt(1500), nil); !errors.Is(err, ErrInvalidGranularity) {
+			t.Errorf("ERC777Burn() error = %v, expected ErrInvalidGranularity", err)
+		}
+		if len(sent) != 0 {
+			t.Errorf("sent %d txs, expected none", len(sent))
+		}
+	})
+
+	t.Run("operator send", func(t *testing.T) {
+		var sent []*types.Transaction
+		kit := newMockKit(t, newMockERC777Server(t, 1, false, &sent))
+		if _, err := kit.ERC777OperatorSend(ctx, token, holder, recipient, big.NewInt(1), nil, nil); err == nil {
+			t.Error("Expected error when kit is not an operator")
+		}
+
+		kit = newMockKit(t, newMockERC777Server(t, 1, true, &sent))
+		if _, err := kit.ERC777OperatorSend(ctx, token, holder, recipient, big.NewInt(1), nil, []byte("op")); err != nil {
+			t.Fatalf("ERC777OperatorSend() failed: %v", err)
+		}
+		method, err := erc777Parsed.MethodById(sent[0].Data()[:4])
+		if err != nil || method.Name != "operatorSend" {
+			t.Errorf("method = %v, err %v", method, err)
+		}
+	})
+
+	t.Run("default operators", func(t *testing.T) {
+		var sent []*types.Transaction
+		server := newMockERC777Server(t, 1, false, &sent)
+		kit := newMockKit(t, server)
+		operators, err := GetERC777DefaultOperators(ctx, kit.EtherProvider, token)
+		if err != nil || len(operators) != 1 {
+			t.Errorf("GetERC777DefaultOperators() = %v, %v", operators, err)
+		}
+		if _, err := kit.ERC777RevokeOperator(ctx, token, operators[0]); err != nil {
+			t.Errorf("ERC777RevokeOperator() failed: %v", err)
+		}
+		if _, err := kit.ERC777AuthorizeOperator(ctx, token, kit.GetAddress()); err == nil {
+			t.Error("Expected error when authorizing self")
+		}
+	})
+}
@@ -1,22 +1,29 @@
 package etherkit
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // 标准错误定义
 var (
 	// 网络相关错误
-	ErrNetworkConnection = errors.New("failed to connect to ethereum network")
-	ErrInvalidRPCURL     = errors.New("invalid RPC URL")
-	ErrNetworkTimeout    = errors.New("network request timeout")
+	ErrNetworkConnection     = errors.New("failed to connect to ethereum network")
+	ErrInvalidRPCURL         = errors.New("invalid RPC URL")
+	ErrNetworkTimeout        = errors.New("network request timeout")
+	ErrUnknownChain          = errors.New("chain not found in network registry")
+	ErrUnsupportedByEndpoint = errors.New("unsupported by endpoint")
 
 	// 地址相关错误
-	ErrInvalidAddress = errors.New("invalid ethereum address")
-	ErrZeroAddress    = errors.New("address cannot be zero address")
+	ErrInvalidAddress   = errors.New("invalid ethereum address")
+	ErrZeroAddress      = errors.New("address cannot be zero address")
+	ErrLookalikeAddress = errors.New("address looks like a known counterparty")
 
 	// 私钥相关错误
 	ErrInvalidPrivateKey = errors.New("invalid private key")
 	ErrInvalidMnemonic   = errors.New("invalid mnemonic phrase")
 	ErrInvalidKeyFormat  = errors.New("invalid key format")
+	ErrKeyNotFound       = errors.New("key not found in keystore")
 
 	// 交易相关错误
 	ErrInsufficientFunds = errors.New("insufficient funds for transaction")
@@ -24,11 +31,26 @@ var (
 	ErrInvalidGasLimit   = errors.New("invalid gas limit")
 	ErrInvalidNonce      = errors.New("invalid nonce")
 	ErrTransactionFailed = errors.New("transaction execution failed")
+	ErrTxExpired         = errors.New("transaction expired before being mined")
+	ErrReceiptTimeout    = errors.New("timed out waiting for transaction receipt")
+	ErrAlreadyKnown      = errors.New("transaction already known")
+	ErrReplacementLimit  = errors.New("replacement policy limit reached")
+	ErrApprovalRequired  = errors.New("transaction requires second approval")
+
+	// 以下错误包装了更通用的错误，errors.Is 对两者都成立（如 errors.Is(err, ErrInvalidNonce) 对 ErrNonceTooLow 同样成立）
+	ErrNonceTooLow            = fmt.Errorf("%w: nonce too low", ErrInvalidNonce)
+	ErrReplacementUnderpriced = fmt.Errorf("%w: replacement transaction underpriced", ErrInvalidGasPrice)
+	ErrReverted               = fmt.Errorf("%w: reverted", ErrTransactionFailed)
 
 	// 合约相关错误
 	ErrContractCall           = errors.New("contract call failed")
 	ErrInvalidABI             = errors.New("invalid contract ABI")
 	ErrInvalidContractAddress = errors.New("invalid contract address")
+	ErrTokenCallFailed        = errors.New("token call returned false or malformed data")
+	ErrEventNotFound          = errors.New("event not found in receipt")
+	ErrUnsupportedInterface   = errors.New("contract does not support interface")
+	ErrInvalidSlippage        = errors.New("invalid slippage")
+	ErrDeadlinePassed         = errors.New("swap deadline passed or too close")
 
 	// 签名相关错误
 	ErrSignatureFailed             = errors.New("signature generation failed")
@@ -36,6 +58,8 @@ var (
 	ErrSignatureVerificationFailed = errors.New("signature verification failed")
 
 	// 钱包相关错误
-	ErrWalletClosed        = errors.New("wallet connection is closed")
-	ErrInvalidWalletConfig = errors.New("invalid wallet configuration")
+	ErrWalletClosed           = errors.New("wallet connection is closed")
+	ErrInvalidWalletConfig    = errors.New("invalid wallet configuration")
+	ErrReadOnly               = errors.New("kit is read-only: no private key configured")
+	ErrNodeAccountUnsupported = errors.New("operation not supported for node-managed accounts")
 )
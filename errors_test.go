@@ -0,0 +1,55 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestSendTxNonceTooLow(t *testing.T) {
+	server := newMockSendServer(t)
+	server.handlers["eth_sendRawTransaction"] = func(params []json.RawMessage) (interface{}, error) {
+		return nil, &mockRPCError{Code: -32000, Message: "nonce too low"}
+	}
+	kit := newMockKit(t, server)
+
+	recipient := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	_, err := kit.SendTx(context.Background(), recipient, 0, 21000, nil, big.NewInt(1), nil)
+	if !errors.Is(err, ErrNonceTooLow) {
+		t.Errorf("SendTx() error = %v, want ErrNonceTooLow", err)
+	}
+}
+
+func TestCheckReceiptStatus(t *testing.T) {
+	if err := CheckReceiptStatus(&types.Receipt{Status: types.ReceiptStatusSuccessful}); err != nil {
+		t.Errorf("CheckReceiptStatus(success) = %v", err)
+	}
+	err := CheckReceiptStatus(&types.Receipt{Status: types.ReceiptStatusFailed, BlockNumber: big.NewInt(1)})
+	if !errors.Is(err, ErrReverted) || !errors.Is(err, ErrTransactionFailed) {
+		t.Errorf("CheckReceiptStatus(failed) = %v, want ErrReverted", err)
+	}
+}
+
+func TestWaitForReceiptTimeout(t *testing.T) {
+	server := newMockSendServer(t)
+	server.handlers["eth_getTransactionReceipt"] = mockResult(nil)
+	kit := newMockKit(t, server)
+
+	_, err := kit.WaitForReceipt(context.Background(), common.Hash{1}, 50*time.Millisecond)
+	if !errors.Is(err, ErrReceiptTimeout) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("WaitForReceipt() error = %v, want ErrReceiptTimeout", err)
+	}
+
+	// 调用方取消时返回调用方的错误
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := kit.WaitForReceipt(ctx, common.Hash{1}, time.Second); !errors.Is(err, context.Canceled) || errors.Is(err, ErrReceiptTimeout) {
+		t.Errorf("WaitForReceipt() error = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,80 @@
+package etherkit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//############ Explorer ############
+
+// ExplorerLink 区块浏览器链接生成器
+// 根据链注册表中的 ExplorerURL 生成交易、地址、代币和区块的页面链接，便于在日志、webhook 和报表中嵌入
+type ExplorerLink struct {
+	BaseURL string // 区块浏览器地址（如 "https://etherscan.io"）
+}
+
+// NewExplorerLink 根据链 ID 创建区块浏览器链接生成器
+// 参数说明：
+//   - chainID: 链 ID（如 MainnetChainID）
+//
+// 返回：
+//   - *ExplorerLink: 链接生成器
+//   - error: 如果链未注册或未配置 ExplorerURL 则返回错误
+func NewExplorerLink(chainID int64) (*ExplorerLink, error) {
+	cfg, ok := GetNetworkConfig(chainID)
+	if !ok || cfg.ExplorerURL == "" {
+		return nil, fmt.Errorf("%w: no explorer configured for chain %d", ErrUnknownChain, chainID)
+	}
+	return &ExplorerLink{BaseURL: strings.TrimRight(cfg.ExplorerURL, "/")}, nil
+}
+
+// Tx 生成交易详情页链接
+// 示例：https://etherscan.io/tx/0x...
+func (e *ExplorerLink) Tx(txHash common.Hash) string {
+	return e.BaseURL + "/tx/" + txHash.Hex()
+}
+
+// Address 生成地址详情页链接
+// 示例：https://etherscan.io/address/0x...
+func (e *ExplorerLink) Address(address common.Address) string {
+	return e.BaseURL + "/address/" + address.Hex()
+}
+
+// Token 生成代币详情页链接
+// 示例：https://etherscan.io/token/0x...
+func (e *ExplorerLink) Token(token common.Address) string {
+	return e.BaseURL + "/token/" + token.Hex()
+}
+
+// TokenHolder 生成指定持有人在代币页面中的链接（只显示该地址相关的转账）
+// 示例：https://etherscan.io/token/0x...?a=0x...
+func (e *ExplorerLink) TokenHolder(token, holder common.Address) string {
+	return e.Token(token) + "?a=" + holder.Hex()
+}
+
+// Block 生成区块详情页链接
+// 示例：https://etherscan.io/block/12345678
+func (e *ExplorerLink) Block(number uint64) string {
+	return fmt.Sprintf("%s/block/%d", e.BaseURL, number)
+}
+
+// ExplorerLink 获取当前连接链的区块浏览器链接生成器
+// 参数说明：
+//   - ctx: 上下文对象
+//
+// 返回：
+//   - *ExplorerLink: 链接生成器
+//   - error: 如果查询链 ID 失败或链未配置区块浏览器则返回错误
+func (k *Kit) ExplorerLink(ctx context.Context) (*ExplorerLink, error) {
+	chainId, err := k.GetChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !chainId.IsInt64() {
+		return nil, fmt.Errorf("%w: chain id %s", ErrUnknownChain, chainId)
+	}
+	return NewExplorerLink(chainId.Int64())
+}
@@ -0,0 +1,46 @@
+package etherkit
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestExplorerLink(t *testing.T) {
+	link, err := NewExplorerLink(MainnetChainID)
+	if err != nil {
+		t.Fatalf("NewExplorerLink() failed: %v", err)
+	}
+
+	txHash := common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	address := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	token := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+
+	tests := []struct {
+		name     string
+		result   string
+		expected string
+	}{
+		{"Tx", link.Tx(txHash), "https://etherscan.io/tx/" + txHash.Hex()},
+		{"Address", link.Address(address), "https://etherscan.io/address/" + address.Hex()},
+		{"Token", link.Token(token), "https://etherscan.io/token/" + token.Hex()},
+		{"TokenHolder", link.TokenHolder(token, address), "https://etherscan.io/token/" + token.Hex() + "?a=" + address.Hex()},
+		{"Block", link.Block(12345678), "https://etherscan.io/block/12345678"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.result != tt.expected {
+				t.Errorf("got %s, expected %s", tt.result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestExplorerLinkUnknownChain(t *testing.T) {
+	_, err := NewExplorerLink(999999)
+	if !errors.Is(err, ErrUnknownChain) {
+		t.Errorf("NewExplorerLink() error = %v, expected ErrUnknownChain", err)
+	}
+}
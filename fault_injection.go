@@ -0,0 +1,251 @@
+package etherkit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+//############ Fault Injection ############
+
+// FaultConfig 故障注入配置（概率取值 0~1，0 表示不注入）
+type FaultConfig struct {
+	Latency       time.Duration // 每个请求额外增加的延迟
+	LatencyJitter time.Duration // 在 Latency 基础上随机增加 [0, LatencyJitter) 的延迟
+	TimeoutRate   float64       // 请求挂起直到超时的概率（模拟节点无响应）
+	Timeout       time.Duration // 挂起的请求在多久后返回 ErrNetworkTimeout（0 表示 30 秒；调用方 context 先结束时以其为准）
+	RateLimitRate float64       // 返回 HTTP 429 限流响应的概率
+	DropTxRate    float64       // eth_sendRawTransaction 返回成功但不转发给节点的概率（模拟交易被丢弃）
+	Methods       []string      // 只对这些 RPC 方法注入故障（空表示全部方法；批量请求中任一方法匹配即注入）
+	Seed          uint64        // 随机数种子（0 表示随机，固定种子可复现故障序列）
+}
+
+// FaultStats 已注入的故障次数
+type FaultStats struct {
+	Requests    int // 经过的请求总数
+	Delayed     int // 增加了延迟的请求数
+	TimedOut    int // 挂起直到超时的请求数
+	RateLimited int // 返回限流响应的请求数
+	DroppedTxs  int // 被丢弃的交易数
+}
+
+// FaultInjector 在 HTTP 传输层注入延迟、超时、限流和交易丢弃，用于测试重试和监控逻辑能否应对真实的故障
+// 运行时可通过 SetConfig 调整配置（如在测试中途开启或关闭故障）
+type FaultInjector struct {
+	mu    sync.Mutex
+	cfg   FaultConfig
+	rng   *rand.Rand
+	stats FaultStats
+}
+
+// NewFaultInjector 创建故障注入器
+// 参数说明：
+//   - cfg: 故障注入配置
+//
+// 返回：
+//   - *FaultInjector: 故障注入器（通过 WithFaultInjection 用于 Provider，或通过 Transport 包装任意 HTTP 传输层）
+//
+// 使用示例：
+//
+//	faults := NewFaultInjector(FaultConfig{Latency: 200 * time.Millisecond, RateLimitRate: 0.2, DropTxRate: 0.1, Seed: 1})
+//	provider, err := NewProvider(rpcUrl, WithFaultInjection(faults), WithReadRetry(DefaultReadRetryPolicy))
+//	// ... 运行被测逻辑 ...
+//	fmt.Printf("%+v\n", faults.Stats())
+func NewFaultInjector(cfg FaultConfig) *FaultInjector {
+	f := &FaultInjector{}
+	f.SetConfig(cfg)
+	return f
+}
+
+// SetConfig 替换故障注入配置（统计数据保留）
+func (f *FaultInjector) SetConfig(cfg FaultConfig) {
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = rand.Uint64()
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.cfg = cfg
+	f.rng = rand.New(rand.NewPCG(seed, seed))
+}
+
+// Stats 返回已注入的故障次数
+func (f *FaultInjector) Stats() FaultStats {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.stats
+}
+
+// Transport 返回在 base 之上注入故障的 HTTP 传输层
+// 参数说明：
+//   - base: 实际发送请求的传输层（nil 表示 http.DefaultTransport）
+func (f *FaultInjector) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &faultTransport{base: base, faults: f}
+}
+
+// WithFaultInjection 在 Provider 的 HTTP 传输层注入故障（仅对 HTTP(S) 节点生效）
+// 故障注入位于重试之下，WithReadRetry / WithSendRetry 配置的重试会看到注入的故障
+func WithFaultInjection(f *FaultInjector) ProviderOption {
+	return func(c *providerConfig) {
+		c.faults = f
+	}
+}
+
+// faultDecision 单个请求要注入的故障
+type faultDecision struct {
+	delay     time.Duration
+	timeout   time.Duration
+	rateLimit bool
+	dropTx    bool
+}
+
+// decide 按配置随机决定单个请求要注入的故障
+func (f *FaultInjector) decide(calls []faultCall) faultDecision {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.stats.Requests++
+	cfg := f.cfg
+	if len(cfg.Methods) > 0 && !slices.ContainsFunc(calls, func(c faultCall) bool { return slices.Contains(cfg.Methods, c.Method) }) {
+		return faultDecision{}
+	}
+
+	var d faultDecision
+	d.delay = cfg.Latency
+	if cfg.LatencyJitter > 0 {
+		d.delay += time.Duration(f.rng.Int64N(int64(cfg.LatencyJitter)))
+	}
+	if d.delay > 0 {
+		f.stats.Delayed++
+	}
+	switch {
+	case f.rng.Float64() < cfg.TimeoutRate:
+		d.timeout = cfg.Timeout
+		if d.timeout <= 0 {
+			d.timeout = 30 * time.Second
+		}
+		f.stats.TimedOut++
+	case f.rng.Float64() < cfg.RateLimitRate:
+		d.rateLimit = true
+		f.stats.RateLimited++
+	case len(calls) == 1 && calls[0].Method == "eth_sendRawTransaction" && f.rng.Float64() < cfg.DropTxRate:
+		d.dropTx = true
+		f.stats.DroppedTxs++
+	}
+	return d
+}
+
+// faultCall 请求中的单个 JSON-RPC 调用
+type faultCall struct {
+	ID     json.RawMessage   `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+// faultTransport 注入故障的 HTTP 传输层
+type faultTransport struct {
+	base   http.RoundTripper
+	faults *FaultInjector
+}
+
+// RoundTrip 实现 http.RoundTripper 接口
+func (t *faultTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		_ = req.Body.Close()
+	}
+	calls := parseFaultCalls(body)
+	d := t.faults.decide(calls)
+
+	ctx := req.Context()
+	if d.delay > 0 {
+		if err := sleepContext(ctx, d.delay); err != nil {
+			return nil, err
+		}
+	}
+	if d.timeout > 0 {
+		if err := sleepContext(ctx, d.timeout); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: injected fault", ErrNetworkTimeout)
+	}
+	if d.rateLimit {
+		return faultResponse(req, http.StatusTooManyRequests, []byte(`{"jsonrpc":"2.0","id":null,"error":{"code":-32005,"message":"rate limit exceeded (injected fault)"}}`)), nil
+	}
+	if d.dropTx {
+		if resp := droppedTxResponse(req, calls[0]); resp != nil {
+			return resp, nil
+		}
+	}
+
+	req = req.Clone(ctx)
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return t.base.RoundTrip(req)
+}
+
+// parseFaultCalls 解析 JSON-RPC 请求体（单条或批量）
+func parseFaultCalls(body []byte) []faultCall {
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] != '[' {
+		body = append(append([]byte{'['}, trimmed...), ']')
+	}
+	var calls []faultCall
+	_ = json.Unmarshal(body, &calls)
+	return calls
+}
+
+// droppedTxResponse 构造广播成功的响应（返回交易哈希，但交易不会到达节点）
+func droppedTxResponse(req *http.Request, call faultCall) *http.Response {
+	var raw hexutil.Bytes
+	if len(call.Params) == 0 || json.Unmarshal(call.Params[0], &raw) != nil {
+		return nil
+	}
+	tx := new(types.Transaction)
+	if tx.UnmarshalBinary(raw) != nil {
+		return nil
+	}
+	body, _ := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": call.ID, "result": tx.Hash()})
+	return faultResponse(req, http.StatusOK, body)
+}
+
+// faultResponse 构造 JSON 响应
+func faultResponse(req *http.Request, status int, body []byte) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// sleepContext 等待 d 或直到 ctx 结束
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,123 @@
+package etherkit
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestFaultInjection(t *testing.T) {
+	tests := []struct {
+		name       string
+		cfg        FaultConfig
+		retry      RetryPolicy
+		wantErr    bool
+		wantCalls  int
+		wantStats  FaultStats
+		minLatency time.Duration
+	}{
+		{
+			name:      "no faults",
+			cfg:       FaultConfig{},
+			wantCalls: 1,
+			wantStats: FaultStats{Requests: 1},
+		},
+		{
+			name:       "latency",
+			cfg:        FaultConfig{Latency: 50 * time.Millisecond},
+			wantCalls:  1,
+			wantStats:  FaultStats{Requests: 1, Delayed: 1},
+			minLatency: 50 * time.Millisecond,
+		},
+		{
+			name:      "rate limited with retries",
+			cfg:       FaultConfig{RateLimitRate: 1},
+			retry:     RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond},
+			wantErr:   true,
+			wantStats: FaultStats{Requests: 3, RateLimited: 3},
+		},
+		{
+			name:      "timeout",
+			cfg:       FaultConfig{TimeoutRate: 1, Timeout: 20 * time.Millisecond},
+			wantErr:   true,
+			wantStats: FaultStats{Requests: 1, TimedOut: 1},
+		},
+		{
+			name:      "other methods only",
+			cfg:       FaultConfig{RateLimitRate: 1, Methods: []string{"eth_sendRawTransaction"}},
+			wantCalls: 1,
+			wantStats: FaultStats{Requests: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newMockRPCServer(t, map[string]mockRPCHandler{"eth_blockNumber": mockResult("0x10")})
+			faults := NewFaultInjector(tt.cfg)
+			provider, err := NewProvider(server.URL, WithFaultInjection(faults), WithReadRetry(tt.retry))
+			if err != nil {
+				t.Fatalf("NewProvider() failed: %v", err)
+			}
+			defer provider.Close()
+
+			start := time.Now()
+			var number hexutil.Uint64
+			err = provider.GetRpcClient().CallContext(context.Background(), &number, "eth_blockNumber")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CallContext() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.cfg.TimeoutRate > 0 && !errors.Is(err, ErrNetworkTimeout) {
+				t.Errorf("CallContext() error = %v, expected ErrNetworkTimeout", err)
+			}
+			if elapsed := time.Since(start); elapsed < tt.minLatency {
+				t.Errorf("CallContext() took %v, expected at least %v", elapsed, tt.minLatency)
+			}
+			if got := server.callCount("eth_blockNumber"); got != tt.wantCalls {
+				t.Errorf("node received %d calls, expected %d", got, tt.wantCalls)
+			}
+			if got := faults.Stats(); got != tt.wantStats {
+				t.Errorf("Stats() = %+v, expected %+v", got, tt.wantStats)
+			}
+		})
+	}
+}
+
+func TestFaultInjectionDropTx(t *testing.T) {
+	server := newMockSendServer(t)
+	faults := NewFaultInjector(FaultConfig{DropTxRate: 1})
+	provider, err := NewProvider(server.URL, WithFaultInjection(faults))
+	if err != nil {
+		t.Fatalf("NewProvider() failed: %v", err)
+	}
+	defer provider.Close()
+
+	pk, _ := BuildPrivateKeyFromHex("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	to := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	tx, _ := types.SignTx(types.NewTx(&types.LegacyTx{Nonce: 5, To: &to, Gas: 21000, GasPrice: big.NewInt(1e9), Value: big.NewInt(1)}), types.NewLondonSigner(big.NewInt(1)), pk)
+
+	// 广播看起来成功，但交易没有到达节点
+	if err := provider.SendTransaction(context.Background(), tx); err != nil {
+		t.Fatalf("SendTransaction() failed: %v", err)
+	}
+	if server.callCount("eth_sendRawTransaction") != 0 {
+		t.Error("dropped transaction reached the node")
+	}
+	if faults.Stats().DroppedTxs != 1 {
+		t.Errorf("Stats().DroppedTxs = %d, expected 1", faults.Stats().DroppedTxs)
+	}
+
+	// 关闭故障后正常广播
+	faults.SetConfig(FaultConfig{})
+	if err := provider.SendTransaction(context.Background(), tx); err != nil {
+		t.Fatalf("SendTransaction() failed: %v", err)
+	}
+	if server.callCount("eth_sendRawTransaction") != 1 {
+		t.Errorf("node received %d transactions, expected 1", server.callCount("eth_sendRawTransaction"))
+	}
+}
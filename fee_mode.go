@@ -0,0 +1,151 @@
+package etherkit
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+//############ Fee Mode ############
+
+// FeeMode 构建交易时使用的费用类型
+type FeeMode int
+
+const (
+	FeeModeAuto    FeeMode = iota // 自动（默认）：链支持 EIP-1559 且未指定 gas 价格和 GasPricer 时使用动态费用交易，否则使用 legacy 交易
+	FeeModeLegacy                 // 始终使用 legacy 交易（gasPrice）
+	FeeModeDynamic                // 始终使用 EIP-1559 动态费用交易（maxFeePerGas / maxPriorityFeePerGas）
+)
+
+// String 返回费用类型名称
+func (m FeeMode) String() string {
+	switch m {
+	case FeeModeAuto:
+		return "auto"
+	case FeeModeLegacy:
+		return "legacy"
+	case FeeModeDynamic:
+		return "dynamic"
+	default:
+		return fmt.Sprintf("FeeMode(%d)", int(m))
+	}
+}
+
+// eip1559 检测结果的缓存值
+const (
+	eip1559Unknown int32 = iota
+	eip1559Supported
+	eip1559Unsupported
+)
+
+// SupportsEIP1559 根据最新区块头是否包含 baseFee 判断链是否支持 EIP-1559
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//
+// 返回：
+//   - bool: true 表示支持动态费用交易
+//   - error: 如果查询区块头失败则返回错误
+func SupportsEIP1559(ctx context.Context, ep EtherProvider) (bool, error) {
+	header, err := providerExt[BlockRefReader](ep).GetHeaderAt(ctx, BlockAtTag(BlockTagLatest))
+	if err != nil {
+		return false, fmt.Errorf("failed to query latest header: %w", err)
+	}
+	return header.BaseFee != nil, nil
+}
+
+// SetFeeMode 设置构建交易时使用的费用类型（默认 FeeModeAuto）
+// 参数说明：
+//   - mode: 费用类型（如 FeeModeLegacy 用于 baseFee 存在但节点不接受类型化交易的链）
+func (w *Wallet) SetFeeMode(mode FeeMode) {
+	w.feeMode = mode
+}
+
+// GetFeeMode 返回构建交易时使用的费用类型
+func (w *Wallet) GetFeeMode() FeeMode {
+	return w.feeMode
+}
+
+// supportsEIP1559 检测链是否支持 EIP-1559（结果缓存在钱包上；检测失败时不缓存，本次按不支持处理）
+func (w *Wallet) supportsEIP1559(ctx context.Context) bool {
+	switch w.eip1559.Load() {
+	case eip1559Supported:
+		return true
+	case eip1559Unsupported:
+		return false
+	}
+	supported, err := SupportsEIP1559(ctx, w.ep)
+	if err != nil {
+		return false
+	}
+	if supported {
+		w.eip1559.Store(eip1559Supported)
+	} else {
+		w.eip1559.Store(eip1559Unsupported)
+	}
+	return supported
+}
+
+// useDynamicFees 判断本次交易是否使用动态费用
+// 调用方指定了 gas 价格或配置了 GasPricer 时，自动模式沿用 legacy 交易，保持价格语义不变
+func (w *Wallet) useDynamicFees(ctx context.Context, gasPrice *big.Int) bool {
+	switch w.feeMode {
+	case FeeModeLegacy:
+		return false
+	case FeeModeDynamic:
+		return true
+	}
+	if (gasPrice != nil && gasPrice.Sign() > 0) || w.gasPricer != nil {
+		return false
+	}
+	return w.supportsEIP1559(ctx)
+}
+
+// suggestFees 获取动态费用交易的费用
+// 调用方指定了 gas 价格时，将其同时作为 maxFeePerGas 和 maxPriorityFeePerGas（实际价格不超过该值，与 legacy 语义一致）
+func (w *Wallet) suggestFees(ctx context.Context, gasPrice *big.Int) (*GasStationFees, error) {
+	if gasPrice != nil && gasPrice.Sign() > 0 {
+		return &GasStationFees{MaxFeePerGas: gasPrice, MaxPriorityFeePerGas: new(big.Int).Set(gasPrice)}, nil
+	}
+	return SuggestDynamicFees(ctx, w.ep)
+}
+
+// NewDynamicFeeTx 创建 EIP-1559 动态费用交易对象
+// 参数说明：
+//   - chainID: 链 ID
+//   - to: 接收地址
+//   - nonce: 交易 nonce
+//   - gasLimit: Gas 限制
+//   - maxFeePerGas: 每单位 gas 愿意支付的最高费用（baseFee + 小费的上限，单位为 Wei）
+//   - maxPriorityFeePerGas: 每单位 gas 的小费上限（单位为 Wei）
+//   - value: 转账金额（单位为 Wei，nil 表示不转账）
+//   - data: 交易数据（合约调用数据或 nil）
+//
+// 返回：
+//   - *types.Transaction: 交易对象（未签名）
+func NewDynamicFeeTx(chainID *big.Int, to common.Address, nonce, gasLimit uint64, maxFeePerGas, maxPriorityFeePerGas, value *big.Int, data []byte) *types.Transaction {
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     nonce,
+		To:        &to,
+		Value:     value,
+		Gas:       gasLimit,
+		GasFeeCap: maxFeePerGas,
+		GasTipCap: maxPriorityFeePerGas,
+		Data:      data,
+	})
+}
+
+// WithFeeMode 设置构建交易时使用的费用类型（等同于创建后调用 SetFeeMode）
+func WithFeeMode(mode FeeMode) KitOption {
+	return func(k *Kit) {
+		if k.setup == nil {
+			k.Wallet.SetFeeMode(mode)
+			return
+		}
+		k.setup.feeMode = mode
+	}
+}
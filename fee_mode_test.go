@@ -0,0 +1,80 @@
+package etherkit
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestFeeMode(t *testing.T) {
+	recipient := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	londonHeader := &types.Header{Number: big.NewInt(100), Difficulty: big.NewInt(0), BaseFee: big.NewInt(10e9)}
+	legacyHeader := &types.Header{Number: big.NewInt(100), Difficulty: big.NewInt(0)}
+
+	tests := []struct {
+		name       string
+		header     *types.Header
+		mode       FeeMode
+		gasPrice   *big.Int
+		wantType   uint8
+		wantFeeCap *big.Int
+		wantTipCap *big.Int
+	}{
+		// maxFeePerGas = 2 × baseFee + 小费
+		{"auto on london chain", londonHeader, FeeModeAuto, nil, types.DynamicFeeTxType, big.NewInt(22e9), big.NewInt(2e9)},
+		{"auto on legacy chain", legacyHeader, FeeModeAuto, nil, types.LegacyTxType, big.NewInt(1e9), big.NewInt(1e9)},
+		{"auto with explicit gas price", londonHeader, FeeModeAuto, big.NewInt(30e9), types.LegacyTxType, big.NewInt(30e9), big.NewInt(30e9)},
+		{"auto when detection fails", nil, FeeModeAuto, nil, types.LegacyTxType, big.NewInt(1e9), big.NewInt(1e9)},
+		{"forced legacy", londonHeader, FeeModeLegacy, nil, types.LegacyTxType, big.NewInt(1e9), big.NewInt(1e9)},
+		{"forced dynamic with explicit gas price", legacyHeader, FeeModeDynamic, big.NewInt(30e9), types.DynamicFeeTxType, big.NewInt(30e9), big.NewInt(30e9)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newMockSendServer(t)
+			if tt.header != nil {
+				server.handlers["eth_getBlockByNumber"] = mockResult(tt.header)
+			}
+			server.handlers["eth_maxPriorityFeePerGas"] = mockResult("0x77359400")
+			kit := newMockKit(t, server, WithFeeMode(tt.mode))
+
+			tx, err := kit.NewTx(context.Background(), recipient, 0, 0, tt.gasPrice, big.NewInt(1), nil)
+			if err != nil {
+				t.Fatalf("NewTx() failed: %v", err)
+			}
+			if tx.Type() != tt.wantType {
+				t.Errorf("Type() = %d, expected %d", tx.Type(), tt.wantType)
+			}
+			if tx.GasFeeCap().Cmp(tt.wantFeeCap) != 0 || tx.GasTipCap().Cmp(tt.wantTipCap) != 0 {
+				t.Errorf("fees = (%v, %v), expected (%v, %v)", tx.GasFeeCap(), tx.GasTipCap(), tt.wantFeeCap, tt.wantTipCap)
+			}
+			if tx.Type() == types.DynamicFeeTxType && tx.ChainId().Int64() != 1 {
+				t.Errorf("ChainId() = %v, expected 1", tx.ChainId())
+			}
+			if _, err := kit.SignTx(context.Background(), tx); err != nil {
+				t.Errorf("SignTx() failed: %v", err)
+			}
+		})
+	}
+}
+
+func TestFeeModeDetectionCached(t *testing.T) {
+	server := newMockSendServer(t)
+	server.handlers["eth_getBlockByNumber"] = mockResult(&types.Header{Number: big.NewInt(100), Difficulty: big.NewInt(0), BaseFee: big.NewInt(10e9)})
+	server.handlers["eth_maxPriorityFeePerGas"] = mockResult("0x77359400")
+	kit := newMockKit(t, server)
+	recipient := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+
+	for i := 0; i < 3; i++ {
+		if _, err := kit.SendTx(context.Background(), recipient, 0, 21000, nil, big.NewInt(1), nil); err != nil {
+			t.Fatalf("SendTx() failed: %v", err)
+		}
+	}
+	// 第一次检测后缓存结果，后续只有计算费用时查询区块头
+	if got := server.callCount("eth_getBlockByNumber"); got != 4 {
+		t.Errorf("eth_getBlockByNumber count = %d, expected 4", got)
+	}
+}
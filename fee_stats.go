@@ -0,0 +1,159 @@
+package etherkit
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+//############ Fee Statistics ############
+
+// MaxFeeStatsBlocks FeeStats 单次最多统计的区块数（大多数节点的 eth_feeHistory 上限）
+const MaxFeeStatsBlocks = 1024
+
+// DefaultTipPercentiles FeeStats 统计的优先费百分位（按区块内 gas 使用量加权）
+var DefaultTipPercentiles = []float64{10, 25, 50, 75, 90}
+
+// BlockFeeStats 单个区块的费用统计
+type BlockFeeStats struct {
+	Number       uint64     // 区块号
+	BaseFee      *big.Int   // 基础费用（单位为 Wei）
+	GasUsedRatio float64    // gas 使用率（gasUsed / gasLimit）
+	Tips         []*big.Int // 各百分位的优先费（与 FeeStatistics.Percentiles 一一对应）
+}
+
+// FeeStatistics 最近若干区块的费用统计
+type FeeStatistics struct {
+	OldestBlock    uint64          // 统计的第一个区块
+	NewestBlock    uint64          // 统计的最后一个区块
+	Percentiles    []float64       // 优先费百分位
+	Blocks         []BlockFeeStats // 各区块的统计（按区块号升序）
+	NextBaseFee    *big.Int        // 下一个区块的基础费用（单位为 Wei）
+	MinBaseFee     *big.Int        // 统计区间内的最低基础费用
+	MaxBaseFee     *big.Int        // 统计区间内的最高基础费用
+	BaseFeeTrend   float64         // 基础费用的相对变化（从第一个区块到下一个区块，如 0.25 表示上涨 25%）
+	TipPercentiles []*big.Int      // 各百分位优先费在统计区间内的中位数
+	AvgFillRate    float64         // 平均 gas 使用率
+	FullBlocks     int             // gas 使用率超过 90% 的区块数
+}
+
+// Tip 返回指定百分位优先费的中位数（百分位不在统计中时返回 nil）
+func (s *FeeStatistics) Tip(percentile float64) *big.Int {
+	for i, p := range s.Percentiles {
+		if p == percentile {
+			return s.TipPercentiles[i]
+		}
+	}
+	return nil
+}
+
+// FeeStats 统计最近若干区块的基础费用趋势、优先费百分位和区块填充率（eth_feeHistory）
+// 用于更智能的重试/加价策略和监控面板
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - lastNBlocks: 统计的区块数（1 ~ MaxFeeStatsBlocks）
+//
+// 返回：
+//   - *FeeStatistics: 费用统计（优先费百分位为 DefaultTipPercentiles）
+//   - error: 如果参数无效或查询失败则返回错误
+//
+// 使用示例：
+//
+//	stats, err := FeeStats(ctx, provider, 20)
+//	if stats.BaseFeeTrend > 0.2 || stats.FullBlocks > 10 {
+//	    tip = stats.Tip(90) // 网络拥堵，使用较高的优先费
+//	}
+func FeeStats(ctx context.Context, ep EtherProvider, lastNBlocks int) (*FeeStatistics, error) {
+	if lastNBlocks <= 0 || lastNBlocks > MaxFeeStatsBlocks {
+		return nil, fmt.Errorf("lastNBlocks must be between 1 and %d, got %d", MaxFeeStatsBlocks, lastNBlocks)
+	}
+	history, err := ep.GetEthClient().FeeHistory(ctx, uint64(lastNBlocks), nil, DefaultTipPercentiles)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query fee history: %w", err)
+	}
+	n := len(history.GasUsedRatio)
+	if n == 0 || len(history.BaseFee) < n {
+		return nil, fmt.Errorf("fee history returned %d blocks with %d base fees", n, len(history.BaseFee))
+	}
+
+	oldest := history.OldestBlock.Uint64()
+	stats := &FeeStatistics{
+		OldestBlock: oldest,
+		NewestBlock: oldest + uint64(n) - 1,
+		Percentiles: DefaultTipPercentiles,
+		Blocks:      make([]BlockFeeStats, n),
+		MinBaseFee:  new(big.Int).Set(history.BaseFee[0]),
+		MaxBaseFee:  new(big.Int).Set(history.BaseFee[0]),
+	}
+	var fillTotal float64
+	for i := 0; i < n; i++ {
+		block := BlockFeeStats{
+			Number:       oldest + uint64(i),
+			BaseFee:      history.BaseFee[i],
+			GasUsedRatio: history.GasUsedRatio[i],
+		}
+		if i < len(history.Reward) {
+			block.Tips = history.Reward[i]
+		}
+		stats.Blocks[i] = block
+
+		if block.BaseFee.Cmp(stats.MinBaseFee) < 0 {
+			stats.MinBaseFee.Set(block.BaseFee)
+		}
+		if block.BaseFee.Cmp(stats.MaxBaseFee) > 0 {
+			stats.MaxBaseFee.Set(block.BaseFee)
+		}
+		fillTotal += block.GasUsedRatio
+		if block.GasUsedRatio > 0.9 {
+			stats.FullBlocks++
+		}
+	}
+	stats.AvgFillRate = fillTotal / float64(n)
+
+	// baseFeePerGas 比区块数多一项，最后一项为下一个区块的基础费用
+	stats.NextBaseFee = stats.Blocks[n-1].BaseFee
+	if len(history.BaseFee) > n {
+		stats.NextBaseFee = history.BaseFee[n]
+	}
+	if first := stats.Blocks[0].BaseFee; first.Sign() > 0 {
+		change, _ := new(big.Float).Quo(new(big.Float).SetInt(new(big.Int).Sub(stats.NextBaseFee, first)), new(big.Float).SetInt(first)).Float64()
+		stats.BaseFeeTrend = change
+	}
+
+	stats.TipPercentiles = make([]*big.Int, len(stats.Percentiles))
+	for p := range stats.Percentiles {
+		var tips []*big.Int
+		for _, block := range stats.Blocks {
+			if p < len(block.Tips) && block.GasUsedRatio > 0 {
+				tips = append(tips, block.Tips[p])
+			}
+		}
+		stats.TipPercentiles[p] = medianBigInt(tips)
+	}
+	return stats, nil
+}
+
+// medianBigInt 返回中位数（偶数个时取较低的一个，空切片返回 0）
+func medianBigInt(values []*big.Int) *big.Int {
+	if len(values) == 0 {
+		return new(big.Int)
+	}
+	sorted := make([]*big.Int, len(values))
+	copy(sorted, values)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Cmp(sorted[j]) < 0 })
+	return new(big.Int).Set(sorted[(len(sorted)-1)/2])
+}
+
+// FeeStats 统计最近若干区块的费用
+// 参数说明：
+//   - ctx: 上下文对象
+//   - lastNBlocks: 统计的区块数（1 ~ MaxFeeStatsBlocks）
+//
+// 返回：
+//   - *FeeStatistics: 费用统计
+//   - error: 如果参数无效或查询失败则返回错误
+func (k *Kit) FeeStats(ctx context.Context, lastNBlocks int) (*FeeStatistics, error) {
+	return FeeStats(ctx, k.EtherProvider, lastNBlocks)
+}
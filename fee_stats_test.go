@@ -0,0 +1,76 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestFeeStats(t *testing.T) {
+	var gotParams []json.RawMessage
+	server := newMockRPCServer(t, map[string]mockRPCHandler{
+		"eth_chainId": mockResult("0x1"),
+		"eth_feeHistory": func(params []json.RawMessage) (interface{}, error) {
+			gotParams = params
+			// 区块 100 ~ 103，第 102 块为空块（优先费为 0，不参与中位数）
+			return map[string]interface{}{
+				"oldestBlock":   "0x64",
+				"baseFeePerGas": []string{"0x3b9aca00", "0x4190ab00", "0x3e95ba80", "0x3b9aca00", "0x4190ab00"},
+				"gasUsedRatio":  []float64{1, 0.3, 0, 0.95},
+				"reward": [][]string{
+					{"0x1", "0x2", "0x3", "0x4", "0x5"},
+					{"0x2", "0x4", "0x6", "0x8", "0xa"},
+					{"0x0", "0x0", "0x0", "0x0", "0x0"},
+					{"0x3", "0x6", "0x9", "0xc", "0xf"},
+				},
+			}, nil
+		},
+	})
+	kit := newMockKit(t, server)
+
+	stats, err := kit.FeeStats(context.Background(), 4)
+	if err != nil {
+		t.Fatalf("FeeStats() failed: %v", err)
+	}
+	if string(gotParams[0]) != `"0x4"` || string(gotParams[1]) != `"latest"` {
+		t.Errorf("eth_feeHistory params = %s, %s", gotParams[0], gotParams[1])
+	}
+
+	tests := []struct {
+		name string
+		got  interface{}
+		want interface{}
+	}{
+		{name: "oldest block", got: stats.OldestBlock, want: uint64(100)},
+		{name: "newest block", got: stats.NewestBlock, want: uint64(103)},
+		{name: "blocks", got: len(stats.Blocks), want: 4},
+		{name: "next base fee", got: stats.NextBaseFee.Int64(), want: int64(1.1e9)},
+		{name: "min base fee", got: stats.MinBaseFee.Int64(), want: int64(1e9)},
+		{name: "max base fee", got: stats.MaxBaseFee.Int64(), want: int64(1.1e9)},
+		{name: "full blocks", got: stats.FullBlocks, want: 2},
+		{name: "median p50 tip", got: stats.Tip(50).Int64(), want: int64(6)},
+		{name: "median p90 tip", got: stats.Tip(90).Int64(), want: int64(10)},
+		{name: "unknown percentile", got: stats.Tip(99) == nil, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %v, expected %v", tt.got, tt.want)
+			}
+		})
+	}
+
+	if math.Abs(stats.BaseFeeTrend-0.1) > 1e-9 {
+		t.Errorf("BaseFeeTrend = %v, expected 0.1", stats.BaseFeeTrend)
+	}
+	if math.Abs(stats.AvgFillRate-0.5625) > 1e-9 {
+		t.Errorf("AvgFillRate = %v, expected 0.5625", stats.AvgFillRate)
+	}
+
+	for _, n := range []int{0, MaxFeeStatsBlocks + 1} {
+		if _, err := kit.FeeStats(context.Background(), n); err == nil {
+			t.Errorf("FeeStats(%d) expected error", n)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package etherkit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//############ Finality ############
+
+// DefaultFinalityConfirmations 未在链注册表中配置确认数时使用的默认确认深度
+const DefaultFinalityConfirmations = 12
+
+// SetFinalityConfirmations 设置指定链在不支持 finalized 标签时使用的确认深度
+// 如果链未在注册表中，会以该链 ID 注册一个新的配置
+// 参数说明：
+//   - chainID: 链 ID
+//   - confirmations: 确认深度（区块数）
+//
+// 注意：此方法修改全局注册表，不是并发安全的，建议在程序初始化阶段调用
+func SetFinalityConfirmations(chainID int64, confirmations int) {
+	cfg, ok := NetworkConfigs[chainID]
+	if !ok {
+		cfg = NetworkConfig{ChainID: chainID}
+	}
+	cfg.Confirmations = confirmations
+	RegisterNetworkConfig(cfg)
+}
+
+// getFinalityConfirmations 获取指定链的确认深度（未配置时返回默认值）
+func getFinalityConfirmations(chainID *big.Int) uint64 {
+	if chainID != nil && chainID.IsInt64() {
+		if cfg, ok := NetworkConfigs[chainID.Int64()]; ok && cfg.Confirmations > 0 {
+			return uint64(cfg.Confirmations)
+		}
+	}
+	return DefaultFinalityConfirmations
+}
+
+// GetFinalizedBlockNumber 获取已最终确定的区块号
+// 优先使用节点的 finalized 区块标签；节点不支持该标签时（如部分 L2 或旧版本节点），
+// 回退为 最新区块号 - 确认深度（确认深度来自链注册表的 Confirmations，可通过 SetFinalityConfirmations 调整）
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//
+// 返回：
+//   - uint64: 已最终确定的区块号
+//   - error: 如果查询失败则返回错误
+func GetFinalizedBlockNumber(ctx context.Context, ep EtherProvider) (uint64, error) {
+	header, err := providerExt[BlockRefReader](ep).GetHeaderAt(ctx, BlockAtTag(BlockTagFinalized))
+	if err == nil && header != nil && header.Number != nil {
+		return header.Number.Uint64(), nil
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return 0, ctxErr
+	}
+
+	// 回退：按确认深度计算
+	chainId, err := ep.GetChainID(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query chain id: %w", err)
+	}
+	latest, err := ep.GetBlockNumber(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query latest block number: %w", err)
+	}
+	confirmations := getFinalityConfirmations(chainId)
+	if latest < confirmations {
+		return 0, nil
+	}
+	return latest - confirmations, nil
+}
+
+// IsFinalized 检查交易是否已最终确定（不会再被重组）
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - txHash: 交易哈希
+//
+// 返回：
+//   - bool: true 表示交易所在区块已最终确定；交易未打包或尚未最终确定时返回 false
+//   - error: 如果查询失败则返回错误
+func IsFinalized(ctx context.Context, ep EtherProvider, txHash common.Hash) (bool, error) {
+	receipt, err := ep.GetTransactionReceipt(ctx, txHash)
+	if err != nil {
+		if errors.Is(err, ethereum.NotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	if receipt == nil || receipt.BlockNumber == nil {
+		return false, nil
+	}
+
+	finalized, err := GetFinalizedBlockNumber(ctx, ep)
+	if err != nil {
+		return false, err
+	}
+	return receipt.BlockNumber.Uint64() <= finalized, nil
+}
+
+// GetFinalizedBlockNumber 获取当前链已最终确定的区块号
+// 参数说明：
+//   - ctx: 上下文对象
+//
+// 返回：
+//   - uint64: 已最终确定的区块号
+//   - error: 如果查询失败则返回错误
+func (k *Kit) GetFinalizedBlockNumber(ctx context.Context) (uint64, error) {
+	return GetFinalizedBlockNumber(ctx, k.EtherProvider)
+}
+
+// IsFinalized 检查交易是否已最终确定（不会再被重组）
+// 参数说明：
+//   - ctx: 上下文对象
+//   - txHash: 交易哈希
+//
+// 返回：
+//   - bool: true 表示交易所在区块已最终确定
+//   - error: 如果查询失败则返回错误
+func (k *Kit) IsFinalized(ctx context.Context, txHash common.Hash) (bool, error) {
+	return IsFinalized(ctx, k.EtherProvider, txHash)
+}
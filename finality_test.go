@@ -0,0 +1,74 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestGetFinalizedBlockNumber(t *testing.T) {
+	tests := []struct {
+		name      string
+		finalized mockRPCHandler
+		expected  uint64
+	}{
+		{
+			name: "finalized tag supported",
+			finalized: func(params []json.RawMessage) (interface{}, error) {
+				if string(params[0]) != `"finalized"` {
+					return nil, errors.New("unexpected block param " + string(params[0]))
+				}
+				return &types.Header{Number: big.NewInt(90), Difficulty: big.NewInt(0)}, nil
+			},
+			expected: 90,
+		},
+		{
+			name: "fallback to confirmation depth",
+			finalized: func(params []json.RawMessage) (interface{}, error) {
+				return nil, &mockRPCError{Code: -32602, Message: "invalid block tag"}
+			},
+			expected: 100 - 12, // 主网确认深度为 12
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newMockRPCServer(t, map[string]mockRPCHandler{
+				"eth_getBlockByNumber": tt.finalized,
+				"eth_blockNumber":      mockResult("0x64"),
+			})
+			provider, err := NewProviderWithChainId(server.URL, MainnetChainID)
+			if err != nil {
+				t.Fatalf("NewProviderWithChainId() failed: %v", err)
+			}
+			defer provider.Close()
+
+			got, err := GetFinalizedBlockNumber(context.Background(), provider)
+			if err != nil {
+				t.Fatalf("GetFinalizedBlockNumber() failed: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("GetFinalizedBlockNumber() = %d, expected %d", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetFinalityConfirmations(t *testing.T) {
+	const customChainID = 987654321
+	defer delete(NetworkConfigs, customChainID)
+
+	// 未注册的链使用默认确认深度
+	if got := getFinalityConfirmations(big.NewInt(customChainID)); got != DefaultFinalityConfirmations {
+		t.Errorf("getFinalityConfirmations() = %d, expected %d", got, DefaultFinalityConfirmations)
+	}
+
+	SetFinalityConfirmations(customChainID, 64)
+	if got := getFinalityConfirmations(big.NewInt(customChainID)); got != 64 {
+		t.Errorf("getFinalityConfirmations() = %d, expected 64", got)
+	}
+}
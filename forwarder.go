@@ -0,0 +1,277 @@
+package etherkit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+//############ ERC-2771 Meta Transaction ############
+
+// MinimalForwarder 默认的 EIP-712 域参数（OpenZeppelin MinimalForwarder）
+const (
+	DefaultForwarderName    = "MinimalForwarder"
+	DefaultForwarderVersion = "0.0.1"
+)
+
+// forwarderABI MinimalForwarder 合约中用到的方法
+const forwarderABI = `[
+{"inputs":[{"internalType":"address","name":"from","type":"address"}],"name":"getNonce","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+{"inputs":[{"components":[{"internalType":"address","name":"from","type":"address"},{"internalType":"address","name":"to","type":"address"},{"internalType":"uint256","name":"value","type":"uint256"},{"internalType":"uint256","name":"gas","type":"uint256"},{"internalType":"uint256","name":"nonce","type":"uint256"},{"internalType":"bytes","name":"data","type":"bytes"}],"internalType":"struct MinimalForwarder.ForwardRequest","name":"req","type":"tuple"},{"internalType":"bytes","name":"signature","type":"bytes"}],"name":"verify","outputs":[{"internalType":"bool","name":"","type":"bool"}],"stateMutability":"view","type":"function"},
+{"inputs":[{"components":[{"internalType":"address","name":"from","type":"address"},{"internalType":"address","name":"to","type":"address"},{"internalType":"uint256","name":"value","type":"uint256"},{"internalType":"uint256","name":"gas","type":"uint256"},{"internalType":"uint256","name":"nonce","type":"uint256"},{"internalType":"bytes","name":"data","type":"bytes"}],"internalType":"struct MinimalForwarder.ForwardRequest","name":"req","type":"tuple"},{"internalType":"bytes","name":"signature","type":"bytes"}],"name":"execute","outputs":[{"internalType":"bool","name":"","type":"bool"},{"internalType":"bytes","name":"","type":"bytes"}],"stateMutability":"payable","type":"function"}
+]`
+
+// forwardRequestTypes ForwardRequest 的 EIP-712 类型定义
+var forwardRequestTypes = apitypes.Types{
+	"ForwardRequest": {
+		{Name: "from", Type: "address"},
+		{Name: "to", Type: "address"},
+		{Name: "value", Type: "uint256"},
+		{Name: "gas", Type: "uint256"},
+		{Name: "nonce", Type: "uint256"},
+		{Name: "data", Type: "bytes"},
+	},
+}
+
+// ForwardRequest ERC-2771 转发请求
+type ForwardRequest struct {
+	From  common.Address `abi:"from"`  // 实际发起人（签名者）
+	To    common.Address `abi:"to"`    // 目标合约（需要信任该转发合约）
+	Value *big.Int       `abi:"value"` // 转账金额（由中继者支付）
+	Gas   *big.Int       `abi:"gas"`   // 目标调用的 gas 限制
+	Nonce *big.Int       `abi:"nonce"` // 发起人在转发合约中的 nonce
+	Data  []byte         `abi:"data"`  // 目标合约调用数据
+}
+
+// Forwarder ERC-2771 可信转发合约（兼容 OpenZeppelin MinimalForwarder）
+type Forwarder struct {
+	Address common.Address // 转发合约地址
+	ChainID *big.Int       // 链 ID
+	Name    string         // EIP-712 域名称（默认 DefaultForwarderName）
+	Version string         // EIP-712 域版本（默认 DefaultForwarderVersion）
+	ep      EtherProvider
+	abi     abi.ABI
+}
+
+// NewForwarder 创建转发合约实例
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - address: 转发合约地址
+//
+// 返回：
+//   - *Forwarder: 转发合约实例（域名称和版本可以在创建后修改）
+//   - error: 如果查询链 ID 失败则返回错误
+func NewForwarder(ctx context.Context, ep EtherProvider, address common.Address) (*Forwarder, error) {
+	parsed, err := GetABI(forwarderABI)
+	if err != nil {
+		return nil, err
+	}
+	chainId, err := ep.GetChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Forwarder{
+		Address: address,
+		ChainID: chainId,
+		Name:    DefaultForwarderName,
+		Version: DefaultForwarderVersion,
+		ep:      ep,
+		abi:     parsed,
+	}, nil
+}
+
+// GetNonce 获取发起人在转发合约中的 nonce
+func (f *Forwarder) GetNonce(ctx context.Context, from common.Address) (*big.Int, error) {
+	data, err := f.abi.Pack("getNonce", from)
+	if err != nil {
+		return nil, err
+	}
+	res, err := providerExt[BlockRefReader](f.ep).CallContractAt(ctx, ethereum.CallMsg{To: &f.Address, Data: data}, BlockRef{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query forwarder nonce: %w", err)
+	}
+	out, err := f.abi.Unpack("getNonce", res)
+	if err != nil {
+		return nil, err
+	}
+	return abi.ConvertType(out[0], new(big.Int)).(*big.Int), nil
+}
+
+// BuildRequest 构建转发请求（nonce 从转发合约获取）
+// 参数说明：
+//   - ctx: 上下文对象
+//   - from: 实际发起人地址
+//   - to: 目标合约地址
+//   - value: 转账金额（nil 表示不转账）
+//   - data: 目标合约调用数据
+//   - gas: 目标调用的 gas 限制（0 表示以发起人身份估算）
+//
+// 返回：
+//   - *ForwardRequest: 转发请求
+//   - error: 如果查询 nonce 或估算 gas 失败则返回错误
+func (f *Forwarder) BuildRequest(ctx context.Context, from, to common.Address, value *big.Int, data []byte, gas uint64) (*ForwardRequest, error) {
+	nonce, err := f.GetNonce(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	if gas == 0 {
+		gas, err = f.ep.EstimateGas(ctx, from, to, 0, nil, value, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to estimate forwarded call gas: %w", err)
+		}
+	}
+	return &ForwardRequest{
+		From:  from,
+		To:    to,
+		Value: bigOrZero(value),
+		Gas:   new(big.Int).SetUint64(gas),
+		Nonce: nonce,
+		Data:  data,
+	}, nil
+}
+
+// TypedData 返回转发请求的 EIP-712 结构化数据
+func (f *Forwarder) TypedData(req *ForwardRequest) apitypes.TypedData {
+	return apitypes.TypedData{
+		Types:       forwardRequestTypes,
+		PrimaryType: "ForwardRequest",
+		Domain: apitypes.TypedDataDomain{
+			Name:              f.Name,
+			Version:           f.Version,
+			ChainId:           (*math.HexOrDecimal256)(f.ChainID),
+			VerifyingContract: f.Address.Hex(),
+		},
+		Message: apitypes.TypedDataMessage{
+			"from":  req.From.Hex(),
+			"to":    req.To.Hex(),
+			"value": bigOrZero(req.Value),
+			"gas":   bigOrZero(req.Gas),
+			"nonce": bigOrZero(req.Nonce),
+			"data":  hexutil.Bytes(req.Data),
+		},
+	}
+}
+
+// Hash 计算转发请求的 EIP-712 签名哈希
+func (f *Forwarder) Hash(req *ForwardRequest) (common.Hash, error) {
+	return HashTypedData(f.TypedData(req))
+}
+
+// Verify 在中继前校验转发请求：签名者必须是 req.From、nonce 必须是当前 nonce，并通过转发合约的 verify 复核
+// 参数说明：
+//   - ctx: 上下文对象
+//   - req: 转发请求
+//   - signature: 发起人的签名
+//
+// 返回：
+//   - error: 如果校验不通过则返回错误（nil 表示可以中继）
+func (f *Forwarder) Verify(ctx context.Context, req *ForwardRequest, signature []byte) error {
+	hash, err := f.Hash(req)
+	if err != nil {
+		return err
+	}
+	if len(signature) != 65 {
+		return fmt.Errorf("invalid signature length %d, expected 65", len(signature))
+	}
+	sig := common.CopyBytes(signature)
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+	pub, err := crypto.SigToPub(hash.Bytes(), sig)
+	if err != nil {
+		return err
+	}
+	if signer := crypto.PubkeyToAddress(*pub); signer != req.From {
+		return fmt.Errorf("forward request signed by %s, expected %s", signer.Hex(), req.From.Hex())
+	}
+
+	nonce, err := f.GetNonce(ctx, req.From)
+	if err != nil {
+		return err
+	}
+	if nonce.Cmp(bigOrZero(req.Nonce)) != 0 {
+		return fmt.Errorf("forward request nonce %s does not match current nonce %s", req.Nonce, nonce)
+	}
+
+	data, err := f.abi.Pack("verify", *req, signature)
+	if err != nil {
+		return err
+	}
+	res, err := providerExt[BlockRefReader](f.ep).CallContractAt(ctx, ethereum.CallMsg{To: &f.Address, Data: data}, BlockRef{})
+	if err != nil {
+		return fmt.Errorf("failed to call forwarder verify: %w", err)
+	}
+	out, err := f.abi.Unpack("verify", res)
+	if err != nil {
+		return err
+	}
+	if ok, _ := out[0].(bool); !ok {
+		return errors.New("forwarder rejected the forward request")
+	}
+	return nil
+}
+
+// ExecuteData 构建转发合约 execute 的调用数据
+func (f *Forwarder) ExecuteData(req *ForwardRequest, signature []byte) ([]byte, error) {
+	return f.abi.Pack("execute", *req, signature)
+}
+
+// SignForwardRequest 使用钱包私钥对转发请求进行 EIP-712 签名
+// 参数说明：
+//   - forwarder: 转发合约实例
+//   - req: 转发请求
+//
+// 返回：
+//   - []byte: 签名（65 字节，v 为 27 或 28）
+//   - error: 如果签名失败则返回错误
+func (w *Wallet) SignForwardRequest(forwarder *Forwarder, req *ForwardRequest) ([]byte, error) {
+	return w.SignTypedData(forwarder.TypedData(req))
+}
+
+// SignForwardRequest 使用 Kit 的私钥对转发请求进行 EIP-712 签名（签名前执行审核回调，启用审计时写入审计记录）
+// 覆盖 Wallet.SignForwardRequest，审核回调看到的是转发合约最终执行的调用
+// 参数说明：
+//   - forwarder: 转发合约实例
+//   - req: 转发请求
+//
+// 返回：
+//   - []byte: 签名（65 字节，v 为 27 或 28）
+//   - error: 如果审核被拒绝、签名或写入审计记录失败则返回错误
+func (k *Kit) SignForwardRequest(forwarder *Forwarder, req *ForwardRequest) ([]byte, error) {
+	intent := fmt.Sprintf("ERC-2771 request to %s value %s via forwarder %s, nonce %s",
+		req.To.Hex(), bigOrZero(req.Value), forwarder.Address.Hex(), bigOrZero(req.Nonce))
+	return k.signTypedData(context.Background(), forwarder.TypedData(req), intent, func(review *TxReview) {
+		review.setCall(req.From, req.To, bigOrZero(req.Value), req.Data)
+	})
+}
+
+// RelayForwardRequest 作为中继者校验并提交转发请求（由 Kit 账户支付 gas）
+// 参数说明：
+//   - ctx: 上下文对象
+//   - forwarder: 转发合约实例
+//   - req: 转发请求
+//   - signature: 发起人的签名
+//
+// 返回：
+//   - common.Hash: 交易哈希
+//   - error: 如果校验或发送失败则返回错误
+func (k *Kit) RelayForwardRequest(ctx context.Context, forwarder *Forwarder, req *ForwardRequest, signature []byte) (common.Hash, error) {
+	if err := forwarder.Verify(ctx, req, signature); err != nil {
+		return common.Hash{}, err
+	}
+	data, err := forwarder.ExecuteData(req, signature)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return k.SendTx(ctx, forwarder.Address, 0, 0, nil, req.Value, data)
+}
@@ -0,0 +1,75 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestForwarderSignAndVerify(t *testing.T) {
+	parsed, _ := GetABI(forwarderABI)
+	server := newMockRPCServer(t, map[string]mockRPCHandler{
+		"eth_call": func(params []json.RawMessage) (interface{}, error) {
+			arg, err := parseMockCallArg(params)
+			if err != nil {
+				return nil, err
+			}
+			method, err := parsed.MethodById(arg.calldata())
+			if err != nil {
+				return nil, err
+			}
+			if method.Name == "getNonce" {
+				return packOutputs(method.Outputs, big.NewInt(3))
+			}
+			// verify：确认请求能被正确编码为 tuple
+			if _, err := method.Inputs.Unpack(arg.calldata()[4:]); err != nil {
+				return nil, err
+			}
+			return packOutputs(method.Outputs, true)
+		},
+	})
+	provider, _ := NewProviderWithChainId(server.URL, SepoliaChainID)
+	defer provider.Close()
+
+	ctx := context.Background()
+	forwarder, err := NewForwarder(ctx, provider, common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3"))
+	if err != nil {
+		t.Fatalf("NewForwarder() failed: %v", err)
+	}
+
+	pk, _ := BuildPrivateKeyFromHex("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	user, _ := NewWalletWithComponents(pk, nil)
+	req, err := forwarder.BuildRequest(ctx, user.GetAddress(), common.HexToAddress("0x02"), nil, []byte{0x01, 0x02}, 100000)
+	if err != nil {
+		t.Fatalf("BuildRequest() failed: %v", err)
+	}
+	if req.Nonce.Int64() != 3 {
+		t.Errorf("BuildRequest() nonce = %s, expected 3", req.Nonce)
+	}
+
+	signature, err := user.SignForwardRequest(forwarder, req)
+	if err != nil {
+		t.Fatalf("SignForwardRequest() failed: %v", err)
+	}
+	if err := forwarder.Verify(ctx, req, signature); err != nil {
+		t.Errorf("Verify() failed: %v", err)
+	}
+
+	// 篡改请求后签名失效
+	tampered := *req
+	tampered.To = common.HexToAddress("0x03")
+	if err := forwarder.Verify(ctx, &tampered, signature); err == nil {
+		t.Error("Expected error for tampered forward request")
+	}
+
+	// 过期的 nonce 会被拒绝
+	stale := *req
+	stale.Nonce = big.NewInt(2)
+	staleSig, _ := user.SignForwardRequest(forwarder, &stale)
+	if err := forwarder.Verify(ctx, &stale, staleSig); err == nil {
+		t.Error("Expected error for stale nonce")
+	}
+}
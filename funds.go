@@ -0,0 +1,81 @@
+package etherkit
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+//############ Funds Preflight ############
+
+// InsufficientFundsError 账户余额不足以支付交易的转账金额和最大手续费
+type InsufficientFundsError struct {
+	Address   common.Address // 发送地址
+	Balance   *big.Int       // 当前余额（单位为 Wei）
+	Value     *big.Int       // 转账金额（单位为 Wei）
+	MaxFee    *big.Int       // 最大手续费（gasLimit × gasFeeCap，单位为 Wei）
+	Shortfall *big.Int       // 缺口（Value + MaxFee - Balance，单位为 Wei）
+}
+
+// Required 返回交易需要的总金额（Value + MaxFee）
+func (e *InsufficientFundsError) Required() *big.Int {
+	return new(big.Int).Add(e.Value, e.MaxFee)
+}
+
+// Error 实现 error 接口
+func (e *InsufficientFundsError) Error() string {
+	return fmt.Sprintf("%s: %s has %s wei, needs %s wei (value %s + max fee %s), short by %s wei",
+		ErrInsufficientFunds, e.Address.Hex(), e.Balance, e.Required(), e.Value, e.MaxFee, e.Shortfall)
+}
+
+// Unwrap 支持 errors.Is(err, ErrInsufficientFunds)
+func (e *InsufficientFundsError) Unwrap() error {
+	return ErrInsufficientFunds
+}
+
+// CheckFunds 检查地址余额是否足以支付交易（balance >= value + gasLimit × gasFeeCap）
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - from: 发送地址
+//   - tx: 交易（签名与否均可）
+//
+// 返回：
+//   - error: 余额不足时返回 *InsufficientFundsError（可用 errors.Is(err, ErrInsufficientFunds) 判断），查询失败时返回查询错误
+func CheckFunds(ctx context.Context, ep EtherProvider, from common.Address, tx *types.Transaction) error {
+	value := bigOrZero(tx.Value())
+	return checkFunds(ctx, ep, from, value, new(big.Int).Sub(tx.Cost(), value))
+}
+
+// checkFunds 检查地址余额是否足以支付 value + maxFee（用于无法表示为 types.Transaction 的交易，如 zkSync 0x71 交易）
+func checkFunds(ctx context.Context, ep EtherProvider, from common.Address, value, maxFee *big.Int) error {
+	balance, err := providerExt[BlockRefReader](ep).GetBalanceOf(ctx, from)
+	if err != nil {
+		return err
+	}
+	required := new(big.Int).Add(value, maxFee)
+	if balance.Cmp(required) >= 0 {
+		return nil
+	}
+	return &InsufficientFundsError{
+		Address:   from,
+		Balance:   balance,
+		Value:     value,
+		MaxFee:    maxFee,
+		Shortfall: new(big.Int).Sub(required, balance),
+	}
+}
+
+// CheckFunds 检查钱包余额是否足以支付交易
+// 参数说明：
+//   - ctx: 上下文对象
+//   - tx: 交易
+//
+// 返回：
+//   - error: 余额不足时返回 *InsufficientFundsError
+func (w *Wallet) CheckFunds(ctx context.Context, tx *types.Transaction) error {
+	return CheckFunds(ctx, w.ep, w.GetAddress(), tx)
+}
@@ -0,0 +1,65 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestSendTxInsufficientFunds(t *testing.T) {
+	recipient := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+
+	t.Run("preflight shortfall", func(t *testing.T) {
+		server := newMockSendServer(t)
+		sent := false
+		server.handlers["eth_sendRawTransaction"] = func(params []json.RawMessage) (interface{}, error) {
+			sent = true
+			return common.Hash{}, nil
+		}
+		kit := newMockKit(t, server)
+
+		// 余额 1 ETH，转账 1 ETH，手续费 21000 × 1 gwei
+		_, err := kit.SendTx(context.Background(), recipient, 0, 21000, nil, big.NewInt(1e18), nil)
+		if !errors.Is(err, ErrInsufficientFunds) {
+			t.Fatalf("SendTx() error = %v, want ErrInsufficientFunds", err)
+		}
+		var fundsErr *InsufficientFundsError
+		if !errors.As(err, &fundsErr) {
+			t.Fatalf("error %v is not *InsufficientFundsError", err)
+		}
+		wantFee := big.NewInt(21000 * 1e9)
+		if fundsErr.MaxFee.Cmp(wantFee) != 0 || fundsErr.Shortfall.Cmp(wantFee) != 0 {
+			t.Errorf("MaxFee = %s, Shortfall = %s, want both %s", fundsErr.MaxFee, fundsErr.Shortfall, wantFee)
+		}
+		if fundsErr.Address != kit.GetAddress() {
+			t.Errorf("Address = %s, want %s", fundsErr.Address.Hex(), kit.GetAddress().Hex())
+		}
+		if sent {
+			t.Error("transaction should not be broadcast")
+		}
+	})
+
+	t.Run("node error is wrapped", func(t *testing.T) {
+		server := newMockSendServer(t)
+		server.handlers["eth_sendRawTransaction"] = func(params []json.RawMessage) (interface{}, error) {
+			return nil, errors.New("insufficient funds for gas * price + value")
+		}
+		kit := newMockKit(t, server)
+
+		_, err := kit.SendTx(context.Background(), recipient, 0, 21000, nil, big.NewInt(1), nil)
+		if !errors.Is(err, ErrInsufficientFunds) {
+			t.Fatalf("SendTx() error = %v, want ErrInsufficientFunds", err)
+		}
+	})
+
+	t.Run("sufficient balance", func(t *testing.T) {
+		kit := newMockKit(t, newMockSendServer(t))
+		if _, err := kit.SendTx(context.Background(), recipient, 0, 21000, nil, big.NewInt(1), nil); err != nil {
+			t.Fatalf("SendTx() failed: %v", err)
+		}
+	})
+}
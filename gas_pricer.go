@@ -0,0 +1,275 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+//############ Gas Pricer ############
+
+// Gas 价格预言机地址
+const (
+	PolygonGasStationURL     = "https://gasstation.polygon.technology/v2"
+	PolygonAmoyGasStationURL = "https://gasstation.polygon.technology/amoy"
+)
+
+// DefaultGasStationTimeout gas 价格预言机 HTTP 请求的默认超时时间
+const DefaultGasStationTimeout = 5 * time.Second
+
+// GasPricer gas 价格来源接口
+// Wallet 在自动获取 gas 价格时会使用此接口，可以替换为链专用的预言机实现
+type GasPricer interface {
+	// SuggestGasPrice 获取建议的 gas 价格
+	// 参数说明：
+	//   - ctx: 上下文对象
+	// 返回：
+	//   - *big.Int: 建议的 gas 价格（单位为 Wei）
+	//   - error: 如果获取失败则返回错误
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+}
+
+// ProviderGasPricer 使用节点 eth_gasPrice 的 gas 价格来源（默认实现）
+type ProviderGasPricer struct {
+	ep EtherProvider
+}
+
+// NewProviderGasPricer 创建使用节点 eth_gasPrice 的 gas 价格来源
+// 参数说明：
+//   - ep: 以太坊提供者
+//
+// 返回：
+//   - *ProviderGasPricer: gas 价格来源实例
+func NewProviderGasPricer(ep EtherProvider) *ProviderGasPricer {
+	return &ProviderGasPricer{ep: ep}
+}
+
+// SuggestGasPrice 获取节点建议的 gas 价格
+func (g *ProviderGasPricer) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return g.ep.GetSuggestGasPrice(ctx)
+}
+
+// GasStationSpeed gas 价格预言机的速度档位
+type GasStationSpeed string
+
+// Gas 价格预言机速度档位
+const (
+	GasStationSafeLow  GasStationSpeed = "safeLow"
+	GasStationStandard GasStationSpeed = "standard"
+	GasStationFast     GasStationSpeed = "fast"
+)
+
+// GasStationFees gas 价格预言机返回的单个档位的费用（单位为 Wei）
+type GasStationFees struct {
+	MaxFeePerGas         *big.Int // 最大 gas 费用
+	MaxPriorityFeePerGas *big.Int // 最大优先费（小费）
+}
+
+// polygonGasStationTier Polygon gas station 返回的单个档位（单位为 Gwei）
+type polygonGasStationTier struct {
+	MaxPriorityFee json.Number `json:"maxPriorityFee"`
+	MaxFee         json.Number `json:"maxFee"`
+}
+
+// polygonGasStationResponse Polygon gas station v2 接口的返回结构
+type polygonGasStationResponse struct {
+	SafeLow          polygonGasStationTier `json:"safeLow"`
+	Standard         polygonGasStationTier `json:"standard"`
+	Fast             polygonGasStationTier `json:"fast"`
+	EstimatedBaseFee json.Number           `json:"estimatedBaseFee"`
+	BlockTime        json.Number           `json:"blockTime"`
+	BlockNumber      json.Number           `json:"blockNumber"`
+}
+
+// PolygonGasStation Polygon gas station 价格来源
+// 节点的 eth_gasPrice 在 Polygon 上经常偏低导致交易卡住，gas station 基于最近区块给出更可靠的价格
+// 也兼容其他返回相同 JSON 格式的链专用预言机
+type PolygonGasStation struct {
+	URL        string          // 预言机接口地址
+	Speed      GasStationSpeed // 速度档位（默认 standard）
+	HTTPClient *http.Client    // HTTP 客户端（nil 表示使用默认超时的客户端）
+}
+
+// NewPolygonGasStation 创建 Polygon gas station 价格来源
+// 参数说明：
+//   - url: 预言机接口地址（如 PolygonGasStationURL）
+//   - speed: 速度档位（如 GasStationFast）
+//
+// 返回：
+//   - *PolygonGasStation: gas 价格来源实例
+func NewPolygonGasStation(url string, speed GasStationSpeed) *PolygonGasStation {
+	return &PolygonGasStation{
+		URL:        url,
+		Speed:      speed,
+		HTTPClient: &http.Client{Timeout: DefaultGasStationTimeout},
+	}
+}
+
+// SuggestGasPrice 获取 gas station 建议的 gas 价格
+// 返回所选档位的 maxFee，对于传统交易可以直接作为 gasPrice 使用
+func (g *PolygonGasStation) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	fees, err := g.SuggestGasFees(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return fees.MaxFeePerGas, nil
+}
+
+// SuggestGasFees 获取 gas station 建议的 EIP-1559 费用
+// 参数说明：
+//   - ctx: 上下文对象
+//
+// 返回：
+//   - *GasStationFees: 所选档位的 maxFee 和 maxPriorityFee（单位为 Wei）
+//   - error: 如果请求或解析失败则返回错误
+func (g *PolygonGasStation) SuggestGasFees(ctx context.Context) (*GasStationFees, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	client := g.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: DefaultGasStationTimeout}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request gas station: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("gas station returned status %d", resp.StatusCode)
+	}
+
+	var res polygonGasStationResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, fmt.Errorf("failed to decode gas station response: %w", err)
+	}
+
+	var tier polygonGasStationTier
+	switch g.Speed {
+	case GasStationSafeLow:
+		tier = res.SafeLow
+	case GasStationFast:
+		tier = res.Fast
+	default:
+		tier = res.Standard
+	}
+
+	maxFee, err := gweiToWei(tier.MaxFee)
+	if err != nil {
+		return nil, err
+	}
+	maxPriorityFee, err := gweiToWei(tier.MaxPriorityFee)
+	if err != nil {
+		return nil, err
+	}
+	if maxFee.Sign() <= 0 {
+		return nil, errors.New("gas station returned empty max fee")
+	}
+
+	return &GasStationFees{
+		MaxFeePerGas:         maxFee,
+		MaxPriorityFeePerGas: maxPriorityFee,
+	}, nil
+}
+
+// gweiToWei 将 Gwei 为单位的数值（可能带小数）转换为 Wei
+func gweiToWei(n json.Number) (*big.Int, error) {
+	if n == "" {
+		return big.NewInt(0), nil
+	}
+	d, err := decimal.NewFromString(n.String())
+	if err != nil {
+		return nil, fmt.Errorf("invalid gwei value %q: %w", n, err)
+	}
+	return ToWei(d.Truncate(9), 9), nil
+}
+
+// FallbackGasPricer 依次尝试多个 gas 价格来源，返回第一个成功的结果
+// 适用于外部预言机不可用时回退到节点价格的场景
+type FallbackGasPricer struct {
+	pricers []GasPricer
+}
+
+// NewFallbackGasPricer 创建带回退的 gas 价格来源
+// 参数说明：
+//   - pricers: gas 价格来源列表（按优先级排列）
+//
+// 返回：
+//   - *FallbackGasPricer: gas 价格来源实例
+func NewFallbackGasPricer(pricers ...GasPricer) *FallbackGasPricer {
+	return &FallbackGasPricer{pricers: pricers}
+}
+
+// SuggestGasPrice 依次尝试每个 gas 价格来源（返回 nil 价格视为失败）
+// 如果全部失败，返回最后一个错误
+func (g *FallbackGasPricer) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	lastErr := errors.New("no gas pricer configured")
+	for _, pricer := range g.pricers {
+		price, err := pricer.SuggestGasPrice(ctx)
+		if err == nil && price != nil {
+			return price, nil
+		}
+		if err == nil {
+			err = errors.New("gas pricer returned nil price")
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+// NewGasPricerForChain 根据链注册表为指定链创建合适的 gas 价格来源
+// 如果注册表中配置了 GasStationURL，则优先使用链专用预言机并回退到节点价格；否则直接使用节点价格
+// 参数说明：
+//   - chainID: 链 ID
+//   - ep: 以太坊提供者（用于回退）
+//
+// 返回：
+//   - GasPricer: gas 价格来源
+func NewGasPricerForChain(chainID int64, ep EtherProvider) GasPricer {
+	providerPricer := NewProviderGasPricer(ep)
+	if cfg, ok := GetNetworkConfig(chainID); ok && cfg.GasStationURL != "" {
+		return NewFallbackGasPricer(NewPolygonGasStation(cfg.GasStationURL, GasStationStandard), providerPricer)
+	}
+	return providerPricer
+}
+
+// SuggestDynamicFees 根据节点数据计算 EIP-1559 动态费用
+// maxFeePerGas = 2 × 最新区块 baseFee + 小费，可以容忍连续多个区块的 baseFee 上涨
+// 不支持 EIP-1559 的网络（区块没有 baseFee）会使用 eth_gasPrice 作为两个字段的值
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//
+// 返回：
+//   - *GasStationFees: 建议的最大费用和优先费（单位为 Wei）
+//   - error: 如果查询失败则返回错误
+func SuggestDynamicFees(ctx context.Context, ep EtherProvider) (*GasStationFees, error) {
+	header, err := providerExt[BlockRefReader](ep).GetHeaderAt(ctx, BlockAtTag(BlockTagLatest))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query latest header: %w", err)
+	}
+	if header.BaseFee == nil {
+		gasPrice, err := ep.GetSuggestGasPrice(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &GasStationFees{MaxFeePerGas: gasPrice, MaxPriorityFeePerGas: new(big.Int).Set(gasPrice)}, nil
+	}
+
+	tip, err := ep.GetEthClient().SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query max priority fee: %w", err)
+	}
+	maxFee := new(big.Int).Mul(header.BaseFee, big.NewInt(2))
+	maxFee.Add(maxFee, tip)
+	return &GasStationFees{MaxFeePerGas: maxFee, MaxPriorityFeePerGas: tip}, nil
+}
@@ -0,0 +1,112 @@
+package etherkit
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// staticGasPricer 测试用的固定 gas 价格来源
+type staticGasPricer struct {
+	price *big.Int
+	err   error
+}
+
+func (g *staticGasPricer) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return g.price, g.err
+}
+
+func TestPolygonGasStation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"safeLow": {"maxPriorityFee": 30.5, "maxFee": 31.25},
+			"standard": {"maxPriorityFee": 32.123456789, "maxFee": 40},
+			"fast": {"maxPriorityFee": 50, "maxFee": 60.000000001},
+			"estimatedBaseFee": 1.5,
+			"blockTime": 2,
+			"blockNumber": 50000000
+		}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name           string
+		speed          GasStationSpeed
+		maxFee         string
+		maxPriorityFee string
+	}{
+		{"Safe low", GasStationSafeLow, "31250000000", "30500000000"},
+		{"Standard", GasStationStandard, "40000000000", "32123456789"},
+		{"Fast", GasStationFast, "60000000001", "50000000000"},
+		{"Default speed", "", "40000000000", "32123456789"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			station := NewPolygonGasStation(server.URL, tt.speed)
+			fees, err := station.SuggestGasFees(context.Background())
+			if err != nil {
+				t.Fatalf("SuggestGasFees() failed: %v", err)
+			}
+			if fees.MaxFeePerGas.String() != tt.maxFee {
+				t.Errorf("MaxFeePerGas = %s, expected %s", fees.MaxFeePerGas, tt.maxFee)
+			}
+			if fees.MaxPriorityFeePerGas.String() != tt.maxPriorityFee {
+				t.Errorf("MaxPriorityFeePerGas = %s, expected %s", fees.MaxPriorityFeePerGas, tt.maxPriorityFee)
+			}
+
+			price, err := station.SuggestGasPrice(context.Background())
+			if err != nil {
+				t.Fatalf("SuggestGasPrice() failed: %v", err)
+			}
+			if price.String() != tt.maxFee {
+				t.Errorf("SuggestGasPrice() = %s, expected %s", price, tt.maxFee)
+			}
+		})
+	}
+}
+
+func TestPolygonGasStationError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	station := NewPolygonGasStation(server.URL, GasStationStandard)
+	if _, err := station.SuggestGasPrice(context.Background()); err == nil {
+		t.Error("Expected error for non-200 response")
+	}
+}
+
+func TestFallbackGasPricer(t *testing.T) {
+	failing := &staticGasPricer{err: errors.New("oracle down")}
+	working := &staticGasPricer{price: big.NewInt(42)}
+
+	price, err := NewFallbackGasPricer(failing, working).SuggestGasPrice(context.Background())
+	if err != nil {
+		t.Fatalf("SuggestGasPrice() failed: %v", err)
+	}
+	if price.Int64() != 42 {
+		t.Errorf("SuggestGasPrice() = %s, expected 42", price)
+	}
+
+	if _, err := NewFallbackGasPricer(failing).SuggestGasPrice(context.Background()); err == nil {
+		t.Error("Expected error when all pricers fail")
+	}
+
+	// 返回 (nil, nil) 的来源视为失败，不能让整体返回 nil 价格和 nil 错误
+	empty := &staticGasPricer{}
+	for _, pricers := range [][]GasPricer{{empty}, {failing, empty}} {
+		price, err := NewFallbackGasPricer(pricers...).SuggestGasPrice(context.Background())
+		if err == nil || price != nil {
+			t.Errorf("SuggestGasPrice() = %v, %v, expected error for nil price", price, err)
+		}
+	}
+	if price, err := NewFallbackGasPricer(empty, working).SuggestGasPrice(context.Background()); err != nil || price.Int64() != 42 {
+		t.Errorf("SuggestGasPrice() = %v, %v, expected fallback to 42", price, err)
+	}
+}
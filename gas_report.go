@@ -0,0 +1,191 @@
+package etherkit
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+//############ Gas Report ############
+
+// GasReport 的内置分类标签
+const (
+	GasLabelNativeTransfer = "(transfer)" // 不带调用数据的本位币转账
+	GasLabelDeploy         = "(deploy)"   // 合约创建
+)
+
+// GasReportRow 单个方法（或标签）的 gas 统计
+type GasReportRow struct {
+	Label    string   // 方法名、方法选择器或自定义标签
+	Count    int      // 交易数量
+	Reverted int      // 执行失败的交易数量
+	MinGas   uint64   // 最小 gas 消耗
+	MaxGas   uint64   // 最大 gas 消耗
+	AvgGas   uint64   // 平均 gas 消耗
+	TotalGas uint64   // gas 消耗合计
+	TotalFee *big.Int // 实际支付的手续费合计（Wei）
+}
+
+// GasReport 按方法汇总交易收据的 gas 消耗和手续费（并发安全）
+// 用于分析批量发送交易的服务中各方法的成本，找出值得优化的调用
+//
+// 使用示例：
+//
+//	report := etherkit.NewGasReport(tokenAbi)
+//	report.AddTx(tx, receipt)              // 按方法选择器归类（已知 ABI 时显示方法名）
+//	report.Add("payout-batch", receipt)    // 按自定义标签归类
+//	fmt.Print(report)
+type GasReport struct {
+	mu   sync.Mutex
+	abis []*abi.ABI
+	rows map[string]*GasReportRow
+}
+
+// NewGasReport 创建 gas 统计报告
+// 参数说明：
+//   - abis: 用于将方法选择器解析为方法名的合约 ABI（可选）
+func NewGasReport(abis ...*abi.ABI) *GasReport {
+	return &GasReport{abis: abis, rows: make(map[string]*GasReportRow)}
+}
+
+// Add 按自定义标签记录交易收据
+// 参数说明：
+//   - label: 统计标签
+//   - receipt: 交易收据（EffectiveGasPrice 为空时手续费按 0 计算）
+func (r *GasReport) Add(label string, receipt *types.Receipt) {
+	r.add(label, receipt, receipt.EffectiveGasPrice)
+}
+
+// AddTx 按交易调用的方法记录交易收据
+// 方法选择器能在 ABI 中找到时使用方法名，否则使用十六进制选择器；本位币转账和合约创建使用内置标签
+// 参数说明：
+//   - tx: 交易
+//   - receipt: 交易收据（EffectiveGasPrice 为空时使用交易的 gasPrice）
+func (r *GasReport) AddTx(tx *types.Transaction, receipt *types.Receipt) {
+	gasPrice := receipt.EffectiveGasPrice
+	if gasPrice == nil {
+		gasPrice = tx.GasPrice()
+	}
+	r.add(r.labelFor(tx), receipt, gasPrice)
+}
+
+// labelFor 返回交易的统计标签
+func (r *GasReport) labelFor(tx *types.Transaction) string {
+	if tx.To() == nil {
+		return GasLabelDeploy
+	}
+	data := tx.Data()
+	if len(data) < 4 {
+		return GasLabelNativeTransfer
+	}
+	for _, contractAbi := range r.abis {
+		if method, err := contractAbi.MethodById(data[:4]); err == nil {
+			return method.Name
+		}
+	}
+	return hexutil.Encode(data[:4])
+}
+
+// add 累加一条收据
+func (r *GasReport) add(label string, receipt *types.Receipt, gasPrice *big.Int) {
+	fee := new(big.Int)
+	if gasPrice != nil {
+		fee.Mul(new(big.Int).SetUint64(receipt.GasUsed), gasPrice)
+	}
+	if receipt.BlobGasUsed > 0 && receipt.BlobGasPrice != nil {
+		fee.Add(fee, new(big.Int).Mul(new(big.Int).SetUint64(receipt.BlobGasUsed), receipt.BlobGasPrice))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	row, ok := r.rows[label]
+	if !ok {
+		row = &GasReportRow{Label: label, MinGas: receipt.GasUsed, TotalFee: new(big.Int)}
+		r.rows[label] = row
+	}
+	row.Count++
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		row.Reverted++
+	}
+	row.MinGas = min(row.MinGas, receipt.GasUsed)
+	row.MaxGas = max(row.MaxGas, receipt.GasUsed)
+	row.TotalGas += receipt.GasUsed
+	row.AvgGas = row.TotalGas / uint64(row.Count)
+	row.TotalFee.Add(row.TotalFee, fee)
+}
+
+// Rows 返回各标签的统计（按手续费合计降序，相同时按标签排序）
+func (r *GasReport) Rows() []GasReportRow {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rows := make([]GasReportRow, 0, len(r.rows))
+	for _, row := range r.rows {
+		copied := *row
+		copied.TotalFee = new(big.Int).Set(row.TotalFee)
+		rows = append(rows, copied)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if c := rows[i].TotalFee.Cmp(rows[j].TotalFee); c != 0 {
+			return c > 0
+		}
+		return rows[i].Label < rows[j].Label
+	})
+	return rows
+}
+
+// TotalFee 返回所有交易的手续费合计（Wei）
+func (r *GasReport) TotalFee() *big.Int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	total := new(big.Int)
+	for _, row := range r.rows {
+		total.Add(total, row.TotalFee)
+	}
+	return total
+}
+
+// String 以表格形式输出报告（手续费以 ETH 为单位）
+func (r *GasReport) String() string {
+	var sb strings.Builder
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "method\tcount\treverted\tmin\tavg\tmax\tfee (ETH)\t")
+	for _, row := range r.Rows() {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%d\t%s\t\n", row.Label, row.Count, row.Reverted, row.MinGas, row.AvgGas, row.MaxGas, ToDecimal(row.TotalFee, EthDecimals).String())
+	}
+	_ = w.Flush()
+	return sb.String()
+}
+
+// BuildGasReport 查询交易及其收据并生成 gas 统计报告
+// 参数说明：
+//   - ctx: 上下文对象
+//   - txHashes: 已打包的交易哈希
+//   - abis: 用于解析方法名的合约 ABI（可选）
+//
+// 返回：
+//   - *GasReport: gas 统计报告
+//   - error: 如果任一交易或收据查询失败则返回错误
+func (k *Kit) BuildGasReport(ctx context.Context, txHashes []common.Hash, abis ...*abi.ABI) (*GasReport, error) {
+	report := NewGasReport(abis...)
+	for _, hash := range txHashes {
+		tx, _, err := k.GetTransactionByHash(ctx, hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get transaction %s: %w", hash.Hex(), err)
+		}
+		receipt, err := k.GetTransactionReceipt(ctx, hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get receipt %s: %w", hash.Hex(), err)
+		}
+		report.AddTx(tx, receipt)
+	}
+	return report, nil
+}
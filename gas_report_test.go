@@ -0,0 +1,64 @@
+package etherkit
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestGasReport(t *testing.T) {
+	token := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	recipient := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	transferData, _ := erc20ABI.Pack("transfer", recipient, big.NewInt(1))
+	newTx := func(to *common.Address, data []byte) *types.Transaction {
+		return types.NewTx(&types.LegacyTx{To: to, Gas: 100000, GasPrice: big.NewInt(2), Data: data})
+	}
+	receipt := func(gasUsed uint64, status uint64, price *big.Int) *types.Receipt {
+		return &types.Receipt{GasUsed: gasUsed, Status: status, EffectiveGasPrice: price}
+	}
+
+	report := NewGasReport(&erc20ABI)
+	report.AddTx(newTx(&token, transferData), receipt(50000, 1, big.NewInt(10)))
+	report.AddTx(newTx(&token, transferData), receipt(30000, 0, big.NewInt(10)))
+	report.AddTx(newTx(&token, transferData), receipt(40000, 1, big.NewInt(10)))
+	report.AddTx(newTx(&recipient, nil), receipt(21000, 1, nil)) // 使用交易的 gasPrice
+	report.AddTx(newTx(nil, []byte{0x60, 0x80}), receipt(500000, 1, big.NewInt(1)))
+	report.AddTx(newTx(&token, []byte{0xde, 0xad, 0xbe, 0xef}), receipt(25000, 1, big.NewInt(1)))
+	report.Add("payout", receipt(60000, 1, big.NewInt(1)))
+
+	tests := []struct {
+		label    string
+		count    int
+		reverted int
+		min, max uint64
+		avg      uint64
+		fee      int64
+	}{
+		{"transfer", 3, 1, 30000, 50000, 40000, 1200000},
+		{GasLabelDeploy, 1, 0, 500000, 500000, 500000, 500000},
+		{"payout", 1, 0, 60000, 60000, 60000, 60000},
+		{GasLabelNativeTransfer, 1, 0, 21000, 21000, 21000, 42000},
+		{"0xdeadbeef", 1, 0, 25000, 25000, 25000, 25000},
+	}
+
+	rows := report.Rows()
+	if len(rows) != len(tests) {
+		t.Fatalf("len(rows) = %d, expected %d", len(rows), len(tests))
+	}
+	// 按手续费合计降序
+	for i, tt := range tests {
+		row := rows[i]
+		if row.Label != tt.label || row.Count != tt.count || row.Reverted != tt.reverted || row.MinGas != tt.min || row.MaxGas != tt.max || row.AvgGas != tt.avg || row.TotalFee.Int64() != tt.fee {
+			t.Errorf("rows[%d] = %+v, expected %+v", i, row, tt)
+		}
+	}
+	if total := report.TotalFee().Int64(); total != 1827000 {
+		t.Errorf("TotalFee() = %d, expected 1827000", total)
+	}
+	if out := report.String(); !strings.Contains(out, "transfer") || !strings.Contains(out, "0xdeadbeef") {
+		t.Errorf("String() = %q", out)
+	}
+}
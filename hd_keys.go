@@ -0,0 +1,141 @@
+package etherkit
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	hdwallet "github.com/miguelmota/go-ethereum-hdwallet"
+)
+
+//############ HD Extended Keys ############
+
+// DefaultHDBasePath 以太坊账户的 BIP-44 父路径（其子节点 0, 1, 2... 即 BuildPrivateKeyFromMnemonicAndAccountId 派生的账户）
+const DefaultHDBasePath = "m/44'/60'/0'/0"
+
+// hdwalletFixIssue172Env go-ethereum-hdwallet 切换为标准 BIP-32 派生的环境变量
+const hdwalletFixIssue172Env = "GO_ETHEREUM_HDWALLET_FIX_ISSUE_179"
+
+// ExportExtendedKeys 从助记词派生指定路径的 BIP-32 扩展密钥
+// 将 xpub 交给只读（watch-only）系统后，对方可以自行派生该路径下的所有地址而无需接触私钥
+// 派生方式与 BuildPrivateKeyFromMnemonicAndAccountId 一致，xpub 派生出的地址即钱包实际使用的账户
+// 参数说明：
+//   - mnemonic: BIP-39 助记词
+//   - path: 派生路径（必须以 "m/" 开头，如 DefaultHDBasePath）
+//
+// 返回：
+//   - xprv: 扩展私钥（可派生子私钥，务必妥善保管）
+//   - xpub: 扩展公钥（只能派生非强化子节点的公钥和地址）
+//   - error: 如果助记词或路径无效则返回错误
+//
+// 使用示例：
+//
+//	_, xpub, err := ExportExtendedKeys(mnemonic, DefaultHDBasePath)
+//	// 只读系统：
+//	addresses, err := DeriveAddressesFromExtendedKey(xpub, 0, 100)
+func ExportExtendedKeys(mnemonic string, path string) (xprv, xpub string, err error) {
+	if !strings.HasPrefix(strings.TrimSpace(path), "m/") {
+		return "", "", fmt.Errorf("derivation path %q must be absolute (start with m/)", path)
+	}
+	derivationPath, err := accounts.ParseDerivationPath(strings.TrimSpace(path))
+	if err != nil {
+		return "", "", err
+	}
+	seed, err := hdwallet.NewSeedFromMnemonic(mnemonic)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: %w", ErrInvalidMnemonic, err)
+	}
+	key, err := hdkeychain.NewMaster(seed, &chaincfg.MainNetParams)
+	if err != nil {
+		return "", "", err
+	}
+	for _, index := range derivationPath {
+		if key, err = deriveHDChild(key, index); err != nil {
+			return "", "", err
+		}
+	}
+	public, err := key.Neuter()
+	if err != nil {
+		return "", "", err
+	}
+	return key.String(), public.String(), nil
+}
+
+// DeriveAddressesFromExtendedKey 从扩展密钥（xpub 或 xprv）派生连续的子地址
+// 参数说明：
+//   - extendedKey: 扩展密钥字符串
+//   - start: 起始子节点索引（非强化）
+//   - count: 派生数量
+//
+// 返回：
+//   - []common.Address: 子节点 start ~ start+count-1 的地址
+//   - error: 如果扩展密钥无效则返回错误
+func DeriveAddressesFromExtendedKey(extendedKey string, start, count uint32) ([]common.Address, error) {
+	key, err := hdkeychain.NewKeyFromString(extendedKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKeyFormat, err)
+	}
+	if uint64(start)+uint64(count) > hdkeychain.HardenedKeyStart {
+		return nil, fmt.Errorf("child index range [%d, %d) exceeds non-hardened range", start, uint64(start)+uint64(count))
+	}
+	addresses := make([]common.Address, count)
+	for i := range addresses {
+		child, err := key.Derive(start + uint32(i))
+		if err != nil {
+			return nil, err
+		}
+		pub, err := child.ECPubKey()
+		if err != nil {
+			return nil, err
+		}
+		ecdsaPub, err := crypto.UnmarshalPubkey(pub.SerializeUncompressed())
+		if err != nil {
+			return nil, err
+		}
+		addresses[i] = crypto.PubkeyToAddress(*ecdsaPub)
+	}
+	return addresses, nil
+}
+
+// DerivePrivateKeyFromExtendedKey 从扩展私钥派生子节点的私钥
+// 参数说明：
+//   - xprv: 扩展私钥
+//   - index: 子节点索引（>= hdkeychain.HardenedKeyStart 表示强化派生）
+//
+// 返回：
+//   - *ecdsa.PrivateKey: 子节点私钥
+//   - error: 如果扩展密钥无效或为扩展公钥则返回错误
+func DerivePrivateKeyFromExtendedKey(xprv string, index uint32) (*ecdsa.PrivateKey, error) {
+	key, err := hdkeychain.NewKeyFromString(xprv)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKeyFormat, err)
+	}
+	if !key.IsPrivate() {
+		return nil, fmt.Errorf("%w: extended public key cannot derive private keys", ErrInvalidKeyFormat)
+	}
+	child, err := deriveHDChild(key, index)
+	if err != nil {
+		return nil, err
+	}
+	priv, err := child.ECPrivKey()
+	if err != nil {
+		return nil, err
+	}
+	return crypto.ToECDSA(priv.Serialize())
+}
+
+// deriveHDChild 按与 go-ethereum-hdwallet（BuildPrivateKeyFromMnemonicAndAccountId）相同的方式派生子节点
+// hdwallet 默认使用 DeriveNonStandard（btcutil issue 172：私钥不足 32 字节时强化派生结果与标准 BIP-32 不同），
+// 只有设置了 hdwalletFixIssue172Env 且节点受影响时才使用标准派生；非强化派生两者结果相同
+func deriveHDChild(key *hdkeychain.ExtendedKey, index uint32) (*hdkeychain.ExtendedKey, error) {
+	if os.Getenv(hdwalletFixIssue172Env) != "" && key.IsAffectedByIssue172() {
+		return key.Derive(index)
+	}
+	return key.DeriveNonStandard(index)
+}
@@ -0,0 +1,106 @@
+package etherkit
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	hdwallet "github.com/miguelmota/go-ethereum-hdwallet"
+)
+
+func TestExportExtendedKeys(t *testing.T) {
+	tests := []struct {
+		name     string
+		mnemonic string
+		path     string
+		wantErr  bool
+	}{
+		{"default base path", TestMnemonic, DefaultHDBasePath, false},
+		{"relative path", TestMnemonic, "0/1", true},
+		{"malformed path", TestMnemonic, "m/44'/x", true},
+		{"invalid mnemonic", "not a valid mnemonic", DefaultHDBasePath, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			xprv, xpub, err := ExportExtendedKeys(tt.mnemonic, tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExportExtendedKeys() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (!strings.HasPrefix(xprv, "xprv") || !strings.HasPrefix(xpub, "xpub")) {
+				t.Errorf("ExportExtendedKeys() = %s, %s, expected xprv/xpub", xprv, xpub)
+			}
+		})
+	}
+}
+
+func TestDeriveFromExtendedKey(t *testing.T) {
+	xprv, xpub, err := ExportExtendedKeys(TestMnemonic, DefaultHDBasePath)
+	if err != nil {
+		t.Fatalf("ExportExtendedKeys() failed: %v", err)
+	}
+	accounts := WellKnownTestAccounts()
+
+	// xpub 和 xprv 派生的地址与助记词直接派生的账户一致
+	for _, key := range []string{xpub, xprv} {
+		addresses, err := DeriveAddressesFromExtendedKey(key, 1, 3)
+		if err != nil {
+			t.Fatalf("DeriveAddressesFromExtendedKey() failed: %v", err)
+		}
+		for i, address := range addresses {
+			if address != accounts[i+1].Address {
+				t.Errorf("address %d = %s, expected %s", i+1, address.Hex(), accounts[i+1].Address.Hex())
+			}
+		}
+	}
+
+	pk, err := DerivePrivateKeyFromExtendedKey(xprv, 0)
+	if err != nil {
+		t.Fatalf("DerivePrivateKeyFromExtendedKey() failed: %v", err)
+	}
+	if !pk.Equal(accounts[0].PrivateKey) {
+		t.Errorf("DerivePrivateKeyFromExtendedKey() = %s, expected %s", GetHexPrivateKey(pk), accounts[0].HexPrivateKey())
+	}
+
+	if _, err := DerivePrivateKeyFromExtendedKey(xpub, 0); !errors.Is(err, ErrInvalidKeyFormat) {
+		t.Errorf("DerivePrivateKeyFromExtendedKey(xpub) error = %v, expected ErrInvalidKeyFormat", err)
+	}
+	if _, err := DeriveAddressesFromExtendedKey("xpub-invalid", 0, 1); !errors.Is(err, ErrInvalidKeyFormat) {
+		t.Errorf("DeriveAddressesFromExtendedKey() error = %v, expected ErrInvalidKeyFormat", err)
+	}
+	if _, err := DeriveAddressesFromExtendedKey(xpub, 1<<31-1, 2); err == nil {
+		t.Error("DeriveAddressesFromExtendedKey() expected error for hardened range")
+	}
+}
+
+func TestExtendedKeysMatchWallet(t *testing.T) {
+	// 第一个助记词的派生路径受 btcutil issue 172 影响（父私钥不足 32 字节）
+	mnemonics := []string{"way business dry reveal popular again tomato step stumble hollow trim estate"}
+	for i := 0; i < 300; i++ {
+		mnemonic, err := hdwallet.NewMnemonic(128)
+		if err != nil {
+			t.Fatalf("NewMnemonic() failed: %v", err)
+		}
+		mnemonics = append(mnemonics, mnemonic)
+	}
+
+	for _, mnemonic := range mnemonics {
+		_, xpub, err := ExportExtendedKeys(mnemonic, DefaultHDBasePath)
+		if err != nil {
+			t.Fatalf("ExportExtendedKeys(%q) failed: %v", mnemonic, err)
+		}
+		addresses, err := DeriveAddressesFromExtendedKey(xpub, 0, 2)
+		if err != nil {
+			t.Fatalf("DeriveAddressesFromExtendedKey() failed: %v", err)
+		}
+		for i, address := range addresses {
+			pk, err := BuildPrivateKeyFromMnemonicAndAccountId(mnemonic, uint32(i))
+			if err != nil {
+				t.Fatalf("BuildPrivateKeyFromMnemonicAndAccountId() failed: %v", err)
+			}
+			if want := PrivateKeyToAddress(pk); address != want {
+				t.Errorf("%q account %d: xpub address = %s, wallet address = %s", mnemonic, i, address.Hex(), want.Hex())
+			}
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package etherkit
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+//############ Impersonation ############
+
+// ImpersonateAccount 允许不持有私钥直接以 address 身份发送交易（节点端 eth_sendTransaction）
+// 仅在 anvil / hardhat 节点（通常是主网分叉）上可用
+func (d *DevChain) ImpersonateAccount(ctx context.Context, address common.Address) error {
+	method, err := d.nodeMethod("impersonateAccount")
+	if err != nil {
+		return err
+	}
+	return d.call(ctx, nil, method, address)
+}
+
+// StopImpersonating 停止以 address 身份发送交易
+func (d *DevChain) StopImpersonating(ctx context.Context, address common.Address) error {
+	method, err := d.nodeMethod("stopImpersonatingAccount")
+	if err != nil {
+		return err
+	}
+	return d.call(ctx, nil, method, address)
+}
+
+// ImpersonatedKit 以被模拟地址身份发送交易的 Kit 变体（用于主网分叉测试，如扮演巨鲸或协议管理员）
+// 交易由节点代为签名（eth_sendTransaction），因此不需要私钥；只读方法直接使用内嵌的 EtherProvider
+type ImpersonatedKit struct {
+	EtherProvider
+	dev     *DevChain
+	address common.Address
+}
+
+// Impersonate 开始模拟 address 并返回以其身份发送交易的 ImpersonatedKit
+// 参数说明：
+//   - ctx: 上下文对象
+//   - address: 要模拟的地址（如持有大量代币的地址或合约管理员）
+//
+// 返回：
+//   - *ImpersonatedKit: 模拟账户的 Kit（使用完毕后调用 Stop）
+//   - error: 如果节点不支持账户模拟则返回错误
+//
+// 使用示例：
+//
+//	dev, _ := NewDevChain(ctx, provider)
+//	whale, err := dev.Impersonate(ctx, whaleAddress)
+//	defer whale.Stop(ctx)
+//	hash, err := whale.InvokeContract(ctx, usdc, erc20Abi, "transfer", 0, 0, nil, nil, me, amount)
+func (d *DevChain) Impersonate(ctx context.Context, address common.Address) (*ImpersonatedKit, error) {
+	if err := d.ImpersonateAccount(ctx, address); err != nil {
+		return nil, err
+	}
+	return &ImpersonatedKit{EtherProvider: d.ep, dev: d, address: address}, nil
+}
+
+// GetAddress 获取被模拟的地址
+func (k *ImpersonatedKit) GetAddress() common.Address {
+	return k.address
+}
+
+// Stop 停止模拟账户（之后该 Kit 不能再发送交易）
+func (k *ImpersonatedKit) Stop(ctx context.Context) error {
+	return k.dev.StopImpersonating(ctx, k.address)
+}
+
+// SendTx 以被模拟地址的身份发送交易（参数含义与 Kit.SendTx 相同，零值由节点自动填充）
+// 参数说明：
+//   - ctx: 上下文对象
+//   - to: 接收地址
+//   - nonce: 交易 nonce（0 表示由节点计算）
+//   - gasLimit: Gas 限制（0 表示由节点估算）
+//   - gasPrice: Gas 价格（nil 表示由节点决定）
+//   - value: 转账金额（nil 表示不转账）
+//   - data: 交易数据
+//
+// 返回：
+//   - common.Hash: 交易哈希
+//   - error: 如果发送失败则返回错误
+func (k *ImpersonatedKit) SendTx(ctx context.Context, to common.Address, nonce, gasLimit uint64, gasPrice, value *big.Int, data []byte) (common.Hash, error) {
+	return sendNodeTransaction(ctx, k.GetRpcClient(), k.address, to, nonce, gasLimit, gasPrice, value, data)
+}
+
+// SendTxAndWait 以被模拟地址的身份发送交易并等待确认
+func (k *ImpersonatedKit) SendTxAndWait(ctx context.Context, to common.Address, nonce, gasLimit uint64, gasPrice, value *big.Int, data []byte, timeout time.Duration) (*types.Receipt, error) {
+	hash, err := k.SendTx(ctx, to, nonce, gasLimit, gasPrice, value, data)
+	if err != nil {
+		return nil, err
+	}
+	return waitForReceipt(ctx, k.EtherProvider, hash, timeout, DefaultWaitInterval)
+}
+
+// InvokeContract 以被模拟地址的身份调用合约方法（参数含义与 Kit.InvokeContract 相同）
+func (k *ImpersonatedKit) InvokeContract(ctx context.Context, contractAddress common.Address, contractAbi abi.ABI, functionName string, nonce, gasLimit uint64, gasPrice, value *big.Int, params ...interface{}) (common.Hash, error) {
+	if functionName == "" {
+		return common.Hash{}, errors.New("function name cannot be empty")
+	}
+	inputData, err := BuildContractInputData(contractAbi, functionName, params...)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return k.SendTx(ctx, contractAddress, nonce, gasLimit, gasPrice, value, inputData)
+}
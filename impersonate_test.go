@@ -0,0 +1,68 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestImpersonatedKit(t *testing.T) {
+	whale := common.HexToAddress("0x47ac0Fb4F2D84898e4D9E7b4DaB3C24507a6D503")
+	token := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	recipient := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+
+	var impersonated, stopped common.Address
+	var sent map[string]interface{}
+	server := newMockRPCServer(t, map[string]mockRPCHandler{
+		"anvil_impersonateAccount": func(params []json.RawMessage) (interface{}, error) {
+			return nil, json.Unmarshal(params[0], &impersonated)
+		},
+		"anvil_stopImpersonatingAccount": func(params []json.RawMessage) (interface{}, error) {
+			return nil, json.Unmarshal(params[0], &stopped)
+		},
+		"eth_sendTransaction": func(params []json.RawMessage) (interface{}, error) {
+			if err := json.Unmarshal(params[0], &sent); err != nil {
+				return nil, err
+			}
+			return common.HexToHash("0xabc"), nil
+		},
+	})
+	provider, err := NewProvider(server.URL)
+	if err != nil {
+		t.Fatalf("NewProvider() failed: %v", err)
+	}
+	defer provider.Close()
+	ctx := context.Background()
+
+	dev := NewDevChainWithKind(provider, DevNodeAnvil)
+	kit, err := dev.Impersonate(ctx, whale)
+	if err != nil {
+		t.Fatalf("Impersonate() failed: %v", err)
+	}
+	if impersonated != whale || kit.GetAddress() != whale {
+		t.Errorf("impersonated = %s, expected %s", impersonated.Hex(), whale.Hex())
+	}
+
+	hash, err := kit.InvokeContract(ctx, token, erc20ABI, "transfer", 0, 0, nil, nil, recipient, big.NewInt(1000))
+	if err != nil || hash != common.HexToHash("0xabc") {
+		t.Fatalf("InvokeContract() = %s, %v", hash.Hex(), err)
+	}
+	if common.HexToAddress(sent["from"].(string)) != whale || common.HexToAddress(sent["to"].(string)) != token {
+		t.Errorf("eth_sendTransaction from/to = %v/%v", sent["from"], sent["to"])
+	}
+	if _, ok := sent["nonce"]; ok {
+		t.Error("nonce should be left to the node when zero")
+	}
+	want, _ := erc20ABI.Pack("transfer", recipient, big.NewInt(1000))
+	if sent["input"] != hexutil.Encode(want) {
+		t.Errorf("input = %v, expected %s", sent["input"], hexutil.Encode(want))
+	}
+
+	if err := kit.Stop(ctx); err != nil || stopped != whale {
+		t.Errorf("Stop() err %v, stopped %s", err, stopped.Hex())
+	}
+}
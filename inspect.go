@@ -0,0 +1,239 @@
+package etherkit
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/shopspring/decimal"
+)
+
+//############ Transaction Inspector ############
+
+// SelectorRegistry 函数选择器数据库（并发安全），用于在没有合约 ABI 时解码调用数据
+// 同一选择器对应多个签名时保留先登记的签名
+type SelectorRegistry struct {
+	mu      sync.RWMutex
+	methods map[[4]byte]abi.Method
+}
+
+// NewSelectorRegistry 创建空的函数选择器数据库
+func NewSelectorRegistry() *SelectorRegistry {
+	return &SelectorRegistry{methods: make(map[[4]byte]abi.Method)}
+}
+
+// Register 按函数签名登记（支持 EncodeFunctionCall 接受的签名格式，建议带参数名以便输出更易读）
+// 参数说明：
+//   - signatures: 函数签名（如 "function transfer(address to, uint256 amount)"）
+//
+// 返回：
+//   - error: 如果任一签名无效则返回错误（之前的签名已登记）
+func (r *SelectorRegistry) Register(signatures ...string) error {
+	for _, signature := range signatures {
+		method, err := parseSignatureMethod(signature)
+		if err != nil {
+			return err
+		}
+		r.add(method)
+	}
+	return nil
+}
+
+// RegisterABI 登记合约 ABI 中的全部方法
+func (r *SelectorRegistry) RegisterABI(contractAbi *abi.ABI) {
+	for _, method := range contractAbi.Methods {
+		r.add(method)
+	}
+}
+
+// add 登记方法（已存在的选择器不覆盖）
+func (r *SelectorRegistry) add(method abi.Method) {
+	var selector [4]byte
+	copy(selector[:], method.ID)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.methods[selector]; !ok {
+		r.methods[selector] = method
+	}
+}
+
+// Lookup 根据调用数据的前 4 字节查找方法
+func (r *SelectorRegistry) Lookup(selector []byte) (abi.Method, bool) {
+	if len(selector) < 4 {
+		return abi.Method{}, false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	method, ok := r.methods[[4]byte(selector[:4])]
+	return method, ok
+}
+
+// DefaultSelectorRegistry 内置常见标准（ERC20、ERC721、ERC1155、WETH、Multicall 等）方法的选择器数据库
+var DefaultSelectorRegistry = newDefaultSelectorRegistry()
+
+// newDefaultSelectorRegistry 创建内置常见方法的选择器数据库
+func newDefaultSelectorRegistry() *SelectorRegistry {
+	r := NewSelectorRegistry()
+	r.RegisterABI(&erc20ABI)
+	_ = r.Register(
+		"function increaseAllowance(address spender, uint256 addedValue) returns (bool)",
+		"function decreaseAllowance(address spender, uint256 subtractedValue) returns (bool)",
+		"function permit(address owner, address spender, uint256 value, uint256 deadline, uint8 v, bytes32 r, bytes32 s)",
+		"function safeTransferFrom(address from, address to, uint256 tokenId)",
+		"function safeTransferFrom(address from, address to, uint256 tokenId, bytes data)",
+		"function safeTransferFrom(address from, address to, uint256 id, uint256 amount, bytes data)",
+		"function safeBatchTransferFrom(address from, address to, uint256[] ids, uint256[] amounts, bytes data)",
+		"function setApprovalForAll(address operator, bool approved)",
+		"function deposit() payable",
+		"function withdraw(uint256 amount)",
+		"function multicall(bytes[] data) returns (bytes[] results)",
+		"function multicall(uint256 deadline, bytes[] data) returns (bytes[] results)",
+		"function aggregate3((address target, bool allowFailure, bytes callData)[] calls) payable returns ((bool success, bytes returnData)[] returnData)",
+	)
+	return r
+}
+
+// TxExplanation 交易的可读解析结果
+type TxExplanation struct {
+	ChainID   *big.Int        // 链 ID
+	From      *common.Address // 发送地址（交易未签名时为 nil）
+	To        *common.Address // 接收地址（合约创建时为 nil）
+	Nonce     uint64          // 交易 nonce
+	Value     *big.Int        // 转账金额（Wei）
+	GasLimit  uint64          // Gas 限制
+	GasFeeCap *big.Int        // 最高 gas 价格（legacy 交易为 gasPrice）
+	GasTipCap *big.Int        // 优先费（legacy 交易为 gasPrice）
+	MaxFee    *big.Int        // 最大手续费（GasLimit × GasFeeCap）
+	Data      []byte          // 原始调用数据
+	Method    *abi.Method     // 解码后的方法（无法解码时为 nil）
+	Args      []interface{}   // 解码后的参数
+}
+
+// ExplainTx 将交易解析为可读的摘要信息，用于日志和签名前的确认提示
+// 调用数据依次使用传入的 ABI 和 DefaultSelectorRegistry 解码
+// 参数说明：
+//   - tx: 交易（已签名时会恢复发送地址）
+//   - abis: 用于解码调用数据的合约 ABI（可选）
+//
+// 返回：
+//   - *TxExplanation: 解析结果，String() 输出多行文本
+func ExplainTx(tx *types.Transaction, abis ...*abi.ABI) *TxExplanation {
+	e := &TxExplanation{
+		ChainID:   tx.ChainId(),
+		To:        tx.To(),
+		Nonce:     tx.Nonce(),
+		Value:     tx.Value(),
+		GasLimit:  tx.Gas(),
+		GasFeeCap: tx.GasFeeCap(),
+		GasTipCap: tx.GasTipCap(),
+		MaxFee:    new(big.Int).Mul(tx.GasFeeCap(), new(big.Int).SetUint64(tx.Gas())),
+		Data:      tx.Data(),
+	}
+	if v, _, _ := tx.RawSignatureValues(); v != nil && v.Sign() != 0 {
+		if from, err := txSender(tx); err == nil {
+			e.From = &from
+		}
+	}
+	e.Method, e.Args = decodeCallDataWith(tx.Data(), abis)
+	return e
+}
+
+// String 返回交易的多行可读摘要
+func (e *TxExplanation) String() string {
+	var sb strings.Builder
+	if e.From != nil {
+		fmt.Fprintf(&sb, "from:    %s\n", e.From.Hex())
+	}
+	if e.To != nil {
+		fmt.Fprintf(&sb, "to:      %s\n", e.To.Hex())
+	} else {
+		sb.WriteString("to:      <contract creation>\n")
+	}
+	if e.ChainID != nil && e.ChainID.Sign() > 0 {
+		fmt.Fprintf(&sb, "chain:   %s\n", e.ChainID)
+	}
+	fmt.Fprintf(&sb, "value:   %s ETH\n", formatWei(e.Value, EthDecimals))
+	fmt.Fprintf(&sb, "nonce:   %d\n", e.Nonce)
+	fmt.Fprintf(&sb, "gas:     limit %d, max %s gwei, tip %s gwei (max fee %s ETH)\n",
+		e.GasLimit, formatWei(e.GasFeeCap, 9), formatWei(e.GasTipCap, 9), formatWei(e.MaxFee, EthDecimals))
+	if len(e.Data) > 0 {
+		fmt.Fprintf(&sb, "call:    %s\n", explainCall(e.Data, e.Method, e.Args))
+	}
+	return sb.String()
+}
+
+// ExplainCalldata 将调用数据解析为可读文本，如 "transfer(address to=0x..., uint256 amount=1000000)"
+// 参数说明：
+//   - data: 调用数据
+//   - abis: 用于解码的合约 ABI（可选，未命中时使用 DefaultSelectorRegistry）
+//
+// 返回：
+//   - string: 可读文本（无法解码时输出选择器和数据长度，不足 4 字节时输出原始数据）
+func ExplainCalldata(data []byte, abis ...*abi.ABI) string {
+	method, args := decodeCallDataWith(data, abis)
+	return explainCall(data, method, args)
+}
+
+// explainCall 格式化方法调用
+func explainCall(data []byte, method *abi.Method, args []interface{}) string {
+	if method == nil {
+		if len(data) < 4 {
+			return hexutil.Encode(data)
+		}
+		return fmt.Sprintf("unknown %s (%d bytes)", hexutil.Encode(data[:4]), len(data))
+	}
+	parts := make([]string, len(args))
+	for i, arg := range args {
+		input := method.Inputs[i]
+		if input.Name != "" {
+			parts[i] = fmt.Sprintf("%s %s=%s", input.Type, input.Name, formatABIValue(arg))
+		} else {
+			parts[i] = fmt.Sprintf("%s %s", input.Type, formatABIValue(arg))
+		}
+	}
+	return fmt.Sprintf("%s(%s)", method.RawName, strings.Join(parts, ", "))
+}
+
+// decodeCallDataWith 依次使用给定 ABI 和 DefaultSelectorRegistry 解码调用数据
+func decodeCallDataWith(data []byte, abis []*abi.ABI) (*abi.Method, []interface{}) {
+	for _, contractAbi := range abis {
+		if method, args := decodeCallData(data, contractAbi); method != nil {
+			return method, args
+		}
+	}
+	return decodeCallData(data, nil)
+}
+
+// formatABIValue 格式化解码后的参数值
+func formatABIValue(v interface{}) string {
+	switch x := v.(type) {
+	case common.Address:
+		return x.Hex()
+	case *big.Int:
+		return x.String()
+	case []byte:
+		return hexutil.Encode(x)
+	case [32]byte:
+		return hexutil.Encode(x[:])
+	case []common.Address:
+		parts := make([]string, len(x))
+		for i, a := range x {
+			parts[i] = a.Hex()
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// formatWei 按精度格式化最小单位数值（nil 表示 0）
+func formatWei(v *big.Int, decimals int32) string {
+	if v == nil {
+		return "0"
+	}
+	return decimal.NewFromBigInt(v, -decimals).String()
+}
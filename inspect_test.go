@@ -0,0 +1,106 @@
+package etherkit
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestExplainCalldata(t *testing.T) {
+	to := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	transfer, _ := erc20ABI.Pack("transfer", to, big.NewInt(1000000))
+	approveAll, _ := EncodeFunctionCall("setApprovalForAll(address,bool)", to, true)
+	custom, _ := ParseABIFragments("function stake(uint256 amount, bytes32 ref)")
+	stake, _ := custom.Pack("stake", big.NewInt(5), [32]byte{0xab})
+
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"erc20 transfer", transfer, "transfer(address to=" + to.Hex() + ", uint256 amount=1000000)"},
+		{"selector registry", approveAll, "setApprovalForAll(address operator=" + to.Hex() + ", bool approved=true)"},
+		{"custom abi", stake, "stake(uint256 amount=5, bytes32 ref=0xab00000000000000000000000000000000000000000000000000000000000000)"},
+		{"unknown selector", []byte{0xde, 0xad, 0xbe, 0xef, 0x01}, "unknown 0xdeadbeef (5 bytes)"},
+		{"short data", []byte{0x01}, "0x01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExplainCalldata(tt.data, &custom); got != tt.want {
+				t.Errorf("ExplainCalldata() = %q, expected %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectorRegistry(t *testing.T) {
+	r := NewSelectorRegistry()
+	if err := r.Register("function mint(address to, uint256 amount)", "not a signature"); err == nil {
+		t.Error("Register() expected error for invalid signature")
+	}
+	data, _ := EncodeFunctionCall("mint(address,uint256)", common.Address{}, big.NewInt(1))
+	method, ok := r.Lookup(data)
+	if !ok || method.Sig != "mint(address,uint256)" {
+		t.Errorf("Lookup() = %v, %v, expected mint(address,uint256)", method.Sig, ok)
+	}
+	// 已登记的选择器不被覆盖
+	_ = r.Register("function mint(address recipient, uint256 value)")
+	if method, _ := r.Lookup(data); method.Inputs[0].Name != "to" {
+		t.Errorf("Lookup() input name = %q, expected to", method.Inputs[0].Name)
+	}
+	if _, ok := r.Lookup([]byte{0x01}); ok {
+		t.Error("Lookup() expected no match for short selector")
+	}
+}
+
+func TestExplainTx(t *testing.T) {
+	pk, _ := BuildPrivateKeyFromHex("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	token := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	to := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	data, _ := erc20ABI.Pack("approve", to, big.NewInt(42))
+	unsigned := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(1),
+		Nonce:     7,
+		To:        &token,
+		Gas:       50000,
+		GasFeeCap: big.NewInt(30e9),
+		GasTipCap: big.NewInt(2e9),
+		Data:      data,
+	})
+	signed, _ := types.SignTx(unsigned, types.LatestSignerForChainID(big.NewInt(1)), pk)
+
+	// 未签名交易不包含发送地址
+	if e := ExplainTx(unsigned); e.From != nil {
+		t.Errorf("ExplainTx() From = %v, expected nil for unsigned tx", e.From)
+	}
+
+	e := ExplainTx(signed)
+	if e.From == nil || *e.From != common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266") {
+		t.Errorf("ExplainTx() From = %v, expected signer address", e.From)
+	}
+	if e.Method == nil || e.Method.RawName != "approve" {
+		t.Fatalf("ExplainTx() Method = %v, expected approve", e.Method)
+	}
+	for _, want := range []string{
+		"to:      " + token.Hex(),
+		"value:   0 ETH",
+		"nonce:   7",
+		"gas:     limit 50000, max 30 gwei, tip 2 gwei (max fee 0.0015 ETH)",
+		"call:    approve(address spender=" + to.Hex() + ", uint256 amount=42)",
+	} {
+		if !strings.Contains(e.String(), want) {
+			t.Errorf("String() missing %q in:\n%s", want, e)
+		}
+	}
+
+	// 合约创建
+	deploy := types.NewTx(&types.LegacyTx{Nonce: 1, Gas: 100000, GasPrice: big.NewInt(1e9), Value: big.NewInt(1e18), Data: []byte{0x60, 0x80, 0x60, 0x40, 0x52}})
+	out := ExplainTx(deploy).String()
+	if !strings.Contains(out, "<contract creation>") || !strings.Contains(out, "value:   1 ETH") {
+		t.Errorf("String() = %q, expected contract creation with 1 ETH", out)
+	}
+}
@@ -0,0 +1,118 @@
+package etherkit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+//############ Decoded Events ############
+
+// DecodedEvent 按合约 ABI 解码后的事件日志
+type DecodedEvent struct {
+	Name    string                 // 事件名称（如 "Transfer"）
+	Address common.Address         // 触发事件的合约地址
+	Args    map[string]interface{} // 事件参数（包括 indexed 参数，按参数名索引）
+	Log     *types.Log             // 原始日志
+}
+
+// DecodeEvent 按合约 ABI 解码单条事件日志
+// 参数说明：
+//   - contractAbi: 包含事件定义的合约 ABI
+//   - log: 事件日志
+//
+// 返回：
+//   - *DecodedEvent: 解码后的事件
+//   - error: 如果 ABI 中没有匹配的事件或数据与定义不符则返回错误
+func DecodeEvent(contractAbi abi.ABI, log *types.Log) (*DecodedEvent, error) {
+	if len(log.Topics) == 0 {
+		return nil, errors.New("anonymous event log has no topics")
+	}
+	event, err := contractAbi.EventByID(log.Topics[0])
+	if err != nil {
+		return nil, err
+	}
+	args := make(map[string]interface{})
+	if len(log.Data) > 0 {
+		if err := event.Inputs.UnpackIntoMap(args, log.Data); err != nil {
+			return nil, fmt.Errorf("failed to decode event %s data: %w", event.Name, err)
+		}
+	}
+	var indexed abi.Arguments
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+	if len(indexed) != len(log.Topics)-1 {
+		return nil, fmt.Errorf("event %s expects %d indexed topics, log has %d", event.Name, len(indexed), len(log.Topics)-1)
+	}
+	if err := abi.ParseTopicsIntoMap(args, indexed, log.Topics[1:]); err != nil {
+		return nil, fmt.Errorf("failed to decode event %s topics: %w", event.Name, err)
+	}
+	return &DecodedEvent{Name: event.Name, Address: log.Address, Args: args, Log: log}, nil
+}
+
+// DecodeReceiptEvents 按合约 ABI 解码收据中的所有事件（跳过 ABI 中没有定义或无法解码的日志）
+// 事件可能来自被调用合约内部调用的其他合约，需要区分时检查 DecodedEvent.Address
+// 参数说明：
+//   - contractAbi: 包含事件定义的合约 ABI
+//   - receipt: 交易收据
+//
+// 返回：
+//   - []DecodedEvent: 解码后的事件（按日志顺序）
+func DecodeReceiptEvents(contractAbi abi.ABI, receipt *types.Receipt) []DecodedEvent {
+	var events []DecodedEvent
+	for _, log := range receipt.Logs {
+		if event, err := DecodeEvent(contractAbi, log); err == nil {
+			events = append(events, *event)
+		}
+	}
+	return events
+}
+
+// InvokeContractAndWait 调用合约方法、等待交易确认并解码交易产生的事件
+// 这是 InvokeContract、WaitForReceipt 和 DecodeReceiptEvents 的组合方法
+// 参数说明：
+//   - ctx: 上下文对象
+//   - contractAddress: 合约地址
+//   - contractAbi: 合约 ABI 对象（同时用于解码事件）
+//   - functionName: 函数名
+//   - nonce: 交易 nonce（0 表示自动计算）
+//   - gasLimit: Gas 限制（0 表示自动估算）
+//   - gasPrice: Gas 价格（nil 表示自动获取）
+//   - value: 转账金额（nil 表示不转账）
+//   - timeout: 等待超时时间（<= 0 时使用 TimeoutPolicy.Wait）
+//   - params: 函数参数（按函数定义顺序传入）
+//
+// 返回：
+//   - *types.Receipt: 交易收据
+//   - []DecodedEvent: 解码后的事件
+//   - error: 如果发送失败、等待超时或交易执行失败（ErrReverted，此时仍返回收据）则返回错误
+//
+// 使用示例：
+//
+//	receipt, events, err := kit.InvokeContractAndWait(ctx, token, erc20Abi, "transfer", 0, 0, nil, nil, time.Minute, to, amount)
+//	for _, event := range events {
+//	    fmt.Println(event.Name, event.Args["from"], event.Args["to"], event.Args["value"])
+//	}
+func (k *Kit) InvokeContractAndWait(ctx context.Context, contractAddress common.Address, contractAbi abi.ABI, functionName string, nonce, gasLimit uint64, gasPrice, value *big.Int, timeout time.Duration, params ...interface{}) (*types.Receipt, []DecodedEvent, error) {
+	txHash, err := k.InvokeContract(ctx, contractAddress, contractAbi, functionName, nonce, gasLimit, gasPrice, value, params...)
+	if err != nil {
+		return nil, nil, err
+	}
+	receipt, err := k.WaitForReceipt(ctx, txHash, timeout)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := CheckReceiptStatus(receipt); err != nil {
+		return receipt, nil, err
+	}
+	return receipt, DecodeReceiptEvents(contractAbi, receipt), nil
+}
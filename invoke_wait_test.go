@@ -0,0 +1,108 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// transferLog 构造 ERC20 Transfer 事件日志
+func transferLog(token, from, to common.Address, amount int64) *types.Log {
+	return &types.Log{
+		Address: token,
+		Topics:  []common.Hash{crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)")), common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())},
+		Data:    common.BigToHash(big.NewInt(amount)).Bytes(),
+	}
+}
+
+func TestDecodeEvent(t *testing.T) {
+	token := common.HexToAddress("0x1c7D4B196Cb0C7B01d743Fbc6116a902379C7238")
+	from := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	to := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	// ERC721 Transfer 与 ERC20 Transfer 的 topic0 相同，但 tokenId 也是 indexed
+	nftLog := transferLog(token, from, to, 0)
+	nftLog.Topics = append(nftLog.Topics, common.BigToHash(big.NewInt(7)))
+	nftLog.Data = nil
+
+	tests := []struct {
+		name     string
+		log      *types.Log
+		wantName string
+		wantErr  bool
+	}{
+		{"erc20 transfer", transferLog(token, from, to, 1000), "Transfer", false},
+		{"unknown event", &types.Log{Address: token, Topics: []common.Hash{crypto.Keccak256Hash([]byte("Unknown()"))}}, "", true},
+		{"indexed mismatch", nftLog, "", true},
+		{"no topics", &types.Log{Address: token}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := DecodeEvent(erc20ABI, tt.log)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DecodeEvent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if event.Name != tt.wantName || event.Address != token {
+				t.Errorf("DecodeEvent() = %s at %s", event.Name, event.Address.Hex())
+			}
+			if event.Args["from"] != from || event.Args["to"] != to || event.Args["value"].(*big.Int).Int64() != 1000 {
+				t.Errorf("DecodeEvent() args = %v", event.Args)
+			}
+		})
+	}
+}
+
+func TestInvokeContractAndWait(t *testing.T) {
+	token := common.HexToAddress("0x1c7D4B196Cb0C7B01d743Fbc6116a902379C7238")
+	recipient := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+
+	tests := []struct {
+		name       string
+		status     uint64
+		wantErr    error
+		wantEvents int
+	}{
+		{"success", types.ReceiptStatusSuccessful, nil, 1},
+		{"reverted", types.ReceiptStatusFailed, ErrReverted, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newMockSendServer(t)
+			kit := newMockKit(t, server)
+			server.handlers["eth_getTransactionReceipt"] = func(params []json.RawMessage) (interface{}, error) {
+				var hash common.Hash
+				_ = json.Unmarshal(params[0], &hash)
+				receipt := &types.Receipt{Status: tt.status, TxHash: hash, BlockNumber: big.NewInt(1), Logs: []*types.Log{}}
+				if tt.status == types.ReceiptStatusSuccessful {
+					receipt.Logs = append(receipt.Logs, transferLog(token, kit.GetAddress(), recipient, 1000))
+				}
+				return receipt, nil
+			}
+
+			receipt, events, err := kit.InvokeContractAndWait(context.Background(), token, erc20ABI, "transfer", 0, 0, nil, nil, 5*time.Second, recipient, big.NewInt(1000))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("InvokeContractAndWait() error = %v, expected %v", err, tt.wantErr)
+			}
+			if receipt == nil || receipt.Status != tt.status {
+				t.Fatalf("InvokeContractAndWait() receipt = %+v", receipt)
+			}
+			if len(events) != tt.wantEvents {
+				t.Fatalf("InvokeContractAndWait() returned %d events, expected %d", len(events), tt.wantEvents)
+			}
+			if tt.wantEvents > 0 && (events[0].Name != "Transfer" || events[0].Args["to"] != recipient) {
+				t.Errorf("events[0] = %s %v", events[0].Name, events[0].Args)
+			}
+		})
+	}
+}
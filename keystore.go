@@ -0,0 +1,214 @@
+package etherkit
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+)
+
+//############ Keystore ############
+
+// KeyPair 私钥及其地址
+type KeyPair struct {
+	Address    common.Address    // 地址
+	PrivateKey *ecdsa.PrivateKey // 私钥
+}
+
+// NewKeyPair 根据私钥创建 KeyPair
+func NewKeyPair(privateKey *ecdsa.PrivateKey) KeyPair {
+	return KeyPair{Address: PrivateKeyToAddress(privateKey), PrivateKey: privateKey}
+}
+
+// GenerateAccounts 批量生成随机账户（用于一次性开通大量充值地址或工作账户）
+// 参数说明：
+//   - n: 账户数量
+//
+// 返回：
+//   - []KeyPair: 生成的账户
+//   - error: 如果生成私钥失败则返回错误
+//
+// 使用示例：
+//
+//	accounts, _ := GenerateAccounts(100)
+//	bundle, _ := ExportKeystoreBundle(accounts, passphrase, true)
+//	os.WriteFile("deposit-keys.json", bundle, 0600)
+func GenerateAccounts(n int) ([]KeyPair, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("invalid account count %d", n)
+	}
+	result := make([]KeyPair, n)
+	for i := range result {
+		pk, err := GeneratePrivateKey()
+		if err != nil {
+			return nil, err
+		}
+		result[i] = NewKeyPair(pk)
+	}
+	return result, nil
+}
+
+// keystoreParams 返回 scrypt 参数（light 为 true 时使用轻量参数）
+func keystoreParams(light bool) (scryptN, scryptP int) {
+	if light {
+		return keystore.LightScryptN, keystore.LightScryptP
+	}
+	return keystore.StandardScryptN, keystore.StandardScryptP
+}
+
+// ExportKeystore 将私钥导出为加密的 keystore JSON（Web3 Secret Storage v3，与 geth、MetaMask 等兼容）
+// 参数说明：
+//   - privateKey: 私钥
+//   - passphrase: 加密密码
+//   - light: 是否使用轻量 scrypt 参数（加解密更快但更容易被暴力破解，适合大量账户；false 使用 geth 的标准参数，每个账户约需 1 秒）
+//
+// 返回：
+//   - []byte: keystore JSON
+//   - error: 如果加密失败则返回错误
+func ExportKeystore(privateKey *ecdsa.PrivateKey, passphrase string, light bool) ([]byte, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+	key := &keystore.Key{Id: id, Address: PrivateKeyToAddress(privateKey), PrivateKey: privateKey}
+	scryptN, scryptP := keystoreParams(light)
+	return keystore.EncryptKey(key, passphrase, scryptN, scryptP)
+}
+
+// ImportKeystore 解密 keystore JSON 得到私钥
+// 参数说明：
+//   - keyJSON: keystore JSON
+//   - passphrase: 加密密码
+//
+// 返回：
+//   - *ecdsa.PrivateKey: 私钥
+//   - error: 如果密码错误或格式无效则返回错误
+func ImportKeystore(keyJSON []byte, passphrase string) (*ecdsa.PrivateKey, error) {
+	key, err := keystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKeyFormat, err)
+	}
+	return key.PrivateKey, nil
+}
+
+// ExportKeystoreBundle 将多个账户导出为 keystore JSON 数组（所有账户使用同一密码）
+// 参数说明：
+//   - accounts: 账户
+//   - passphrase: 加密密码
+//   - light: 是否使用轻量 scrypt 参数（批量导出时建议为 true）
+//
+// 返回：
+//   - []byte: JSON 数组，每个元素为一个 keystore
+//   - error: 如果加密失败则返回错误
+func ExportKeystoreBundle(accounts []KeyPair, passphrase string, light bool) ([]byte, error) {
+	bundle := make([]json.RawMessage, len(accounts))
+	for i, account := range accounts {
+		keyJSON, err := ExportKeystore(account.PrivateKey, passphrase, light)
+		if err != nil {
+			return nil, fmt.Errorf("account %s: %w", account.Address.Hex(), err)
+		}
+		bundle[i] = keyJSON
+	}
+	return json.Marshal(bundle)
+}
+
+// ImportKeystoreBundle 解密 ExportKeystoreBundle 导出的 keystore JSON 数组
+// 参数说明：
+//   - bundle: keystore JSON 数组
+//   - passphrase: 加密密码
+//
+// 返回：
+//   - []KeyPair: 账户（顺序与导出时一致）
+//   - error: 如果格式无效或任一账户解密失败则返回错误
+func ImportKeystoreBundle(bundle []byte, passphrase string) ([]KeyPair, error) {
+	var entries []json.RawMessage
+	if err := json.Unmarshal(bundle, &entries); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKeyFormat, err)
+	}
+	result := make([]KeyPair, len(entries))
+	for i, entry := range entries {
+		pk, err := ImportKeystore(entry, passphrase)
+		if err != nil {
+			return nil, fmt.Errorf("keystore %d: %w", i, err)
+		}
+		result[i] = NewKeyPair(pk)
+	}
+	return result, nil
+}
+
+// WriteKeystoreDir 将账户按 geth 的文件命名方式（UTC--<时间>--<地址>）逐个写入目录，可直接作为 geth --keystore 目录使用
+// 参数说明：
+//   - dir: 目标目录（不存在时创建）
+//   - accounts: 账户
+//   - passphrase: 加密密码
+//   - light: 是否使用轻量 scrypt 参数
+//
+// 返回：
+//   - []string: 写入的文件路径
+//   - error: 如果加密或写入失败则返回错误
+func WriteKeystoreDir(dir string, accounts []KeyPair, passphrase string, light bool) ([]string, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	paths := make([]string, 0, len(accounts))
+	for _, account := range accounts {
+		path, err := writeKeystoreFile(dir, account, passphrase, light)
+		if err != nil {
+			return paths, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// writeKeystoreFile 加密账户并按 geth 的命名方式写入目录
+func writeKeystoreFile(dir string, account KeyPair, passphrase string, light bool) (string, error) {
+	keyJSON, err := ExportKeystore(account.PrivateKey, passphrase, light)
+	if err != nil {
+		return "", fmt.Errorf("account %s: %w", account.Address.Hex(), err)
+	}
+	name := fmt.Sprintf("UTC--%s--%s", time.Now().UTC().Format("2006-01-02T15-04-05.000000000Z"), strings.ToLower(account.Address.Hex()[2:]))
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, keyJSON, 0600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// ReadKeystoreDir 解密目录中的所有 keystore 文件（跳过子目录和隐藏文件）
+// 参数说明：
+//   - dir: keystore 目录
+//   - passphrase: 加密密码
+//
+// 返回：
+//   - []KeyPair: 账户（按文件名排序）
+//   - error: 如果读取失败或任一文件解密失败则返回错误
+func ReadKeystoreDir(dir string, passphrase string) ([]KeyPair, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var result []KeyPair
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		keyJSON, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		pk, err := ImportKeystore(keyJSON, passphrase)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+		result = append(result, NewKeyPair(pk))
+	}
+	return result, nil
+}
@@ -0,0 +1,203 @@
+package etherkit
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//############ Keystore Manager ############
+
+// KeystoreAccount keystore 目录中的账户
+type KeystoreAccount struct {
+	Address common.Address // 账户地址（读取自密钥文件，无需解密）
+	Path    string         // 密钥文件路径
+}
+
+// KeystoreManager 管理 geth 风格的 keystore 目录（与 geth account new / list / import 使用相同的文件格式）
+type KeystoreManager struct {
+	dir   string
+	light bool
+}
+
+// NewKeystoreManager 创建 keystore 目录管理器
+// 参数说明：
+//   - dir: keystore 目录（不存在时创建，如 geth 数据目录下的 keystore）
+//   - light: 新建密钥文件时是否使用轻量 scrypt 参数（见 ExportKeystore）
+//
+// 返回：
+//   - *KeystoreManager: 管理器
+//   - error: 如果创建目录失败则返回错误
+//
+// 使用示例：
+//
+//	ks, _ := NewKeystoreManager("/data/geth/keystore", false)
+//	account, _ := ks.NewAccount(passphrase)
+//	kit, err := New(rpcUrl, WithKeystoreAccount(ks, account.Address, passphrase))
+func NewKeystoreManager(dir string, light bool) (*KeystoreManager, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &KeystoreManager{dir: dir, light: light}, nil
+}
+
+// Dir 返回 keystore 目录
+func (m *KeystoreManager) Dir() string {
+	return m.dir
+}
+
+// Accounts 扫描目录并列出所有账户（按文件名排序，即按创建时间排序；无法识别的文件被跳过）
+func (m *KeystoreManager) Accounts() ([]KeystoreAccount, error) {
+	entries, err := os.ReadDir(m.dir)
+	if err != nil {
+		return nil, err
+	}
+	var result []KeystoreAccount
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		path := filepath.Join(m.dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var header struct {
+			Address string `json:"address"`
+		}
+		if json.Unmarshal(data, &header) != nil || !common.IsHexAddress(header.Address) {
+			continue
+		}
+		result = append(result, KeystoreAccount{Address: common.HexToAddress(header.Address), Path: path})
+	}
+	return result, nil
+}
+
+// Find 查找指定地址的密钥文件
+// 返回：
+//   - KeystoreAccount: 账户
+//   - error: 如果目录中没有该地址则返回 ErrKeyNotFound
+func (m *KeystoreManager) Find(address common.Address) (KeystoreAccount, error) {
+	accounts, err := m.Accounts()
+	if err != nil {
+		return KeystoreAccount{}, err
+	}
+	for _, account := range accounts {
+		if account.Address == address {
+			return account, nil
+		}
+	}
+	return KeystoreAccount{}, fmt.Errorf("%w: %s", ErrKeyNotFound, address.Hex())
+}
+
+// Unlock 使用密码解密指定地址的私钥
+// 参数说明：
+//   - address: 账户地址
+//   - passphrase: 密码
+//
+// 返回：
+//   - *ecdsa.PrivateKey: 私钥
+//   - error: 如果目录中没有该地址（ErrKeyNotFound）或密码错误（ErrInvalidKeyFormat）则返回错误
+func (m *KeystoreManager) Unlock(address common.Address, passphrase string) (*ecdsa.PrivateKey, error) {
+	account, err := m.Find(address)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(account.Path)
+	if err != nil {
+		return nil, err
+	}
+	return ImportKeystore(data, passphrase)
+}
+
+// UnlockWallet 解密指定地址的私钥并创建钱包
+// 参数说明：
+//   - ep: 以太坊提供者
+//   - address: 账户地址
+//   - passphrase: 密码
+//
+// 返回：
+//   - *Wallet: 钱包实例
+//   - error: 如果解密失败则返回错误
+func (m *KeystoreManager) UnlockWallet(ep EtherProvider, address common.Address, passphrase string) (*Wallet, error) {
+	pk, err := m.Unlock(address, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return NewWalletWithComponents(pk, ep)
+}
+
+// NewAccount 生成新账户并写入加密的密钥文件
+// 参数说明：
+//   - passphrase: 密码
+//
+// 返回：
+//   - KeystoreAccount: 新账户
+//   - error: 如果生成或写入失败则返回错误
+func (m *KeystoreManager) NewAccount(passphrase string) (KeystoreAccount, error) {
+	pk, err := GeneratePrivateKey()
+	if err != nil {
+		return KeystoreAccount{}, err
+	}
+	return m.Import(pk, passphrase)
+}
+
+// Import 将已有私钥加密写入目录
+// 参数说明：
+//   - privateKey: 私钥
+//   - passphrase: 密码
+//
+// 返回：
+//   - KeystoreAccount: 导入的账户
+//   - error: 如果目录中已存在该地址或写入失败则返回错误
+func (m *KeystoreManager) Import(privateKey *ecdsa.PrivateKey, passphrase string) (KeystoreAccount, error) {
+	account := NewKeyPair(privateKey)
+	if existing, err := m.Find(account.Address); err == nil {
+		return existing, fmt.Errorf("account %s already exists in %s", account.Address.Hex(), existing.Path)
+	} else if !errors.Is(err, ErrKeyNotFound) {
+		return KeystoreAccount{}, err
+	}
+	path, err := writeKeystoreFile(m.dir, account, passphrase, m.light)
+	if err != nil {
+		return KeystoreAccount{}, err
+	}
+	return KeystoreAccount{Address: account.Address, Path: path}, nil
+}
+
+// Delete 删除指定地址的密钥文件（需要正确的密码，避免误删）
+// 参数说明：
+//   - address: 账户地址
+//   - passphrase: 密码
+//
+// 返回：
+//   - error: 如果目录中没有该地址、密码错误或删除失败则返回错误
+func (m *KeystoreManager) Delete(address common.Address, passphrase string) error {
+	if _, err := m.Unlock(address, passphrase); err != nil {
+		return err
+	}
+	account, err := m.Find(address)
+	if err != nil {
+		return err
+	}
+	return os.Remove(account.Path)
+}
+
+// WithKeystoreAccount 使用 keystore 目录中的账户创建 Kit（仅在创建 Kit 时生效）
+// 参数说明：
+//   - m: keystore 目录管理器
+//   - address: 账户地址
+//   - passphrase: 密码
+func WithKeystoreAccount(m *KeystoreManager, address common.Address, passphrase string) KitOption {
+	return func(k *Kit) {
+		if k.setup == nil {
+			return
+		}
+		k.setup.privateKey, k.setup.keyErr = m.Unlock(address, passphrase)
+	}
+}
@@ -0,0 +1,88 @@
+package etherkit
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestKeystoreManager(t *testing.T) {
+	ks, err := NewKeystoreManager(filepath.Join(t.TempDir(), "keystore"), true)
+	if err != nil {
+		t.Fatalf("NewKeystoreManager() failed: %v", err)
+	}
+
+	created, err := ks.NewAccount("secret")
+	if err != nil {
+		t.Fatalf("NewAccount() failed: %v", err)
+	}
+	imported, err := ks.Import(WellKnownTestAccounts()[0].PrivateKey, "other")
+	if err != nil {
+		t.Fatalf("Import() failed: %v", err)
+	}
+	if _, err := ks.Import(WellKnownTestAccounts()[0].PrivateKey, "other"); err == nil {
+		t.Error("Import() expected error for duplicate account")
+	}
+	// 无法识别的文件被跳过
+	_ = os.WriteFile(filepath.Join(ks.Dir(), "notes.txt"), []byte("not a key"), 0600)
+
+	accounts, err := ks.Accounts()
+	if err != nil {
+		t.Fatalf("Accounts() failed: %v", err)
+	}
+	if len(accounts) != 2 {
+		t.Fatalf("Accounts() returned %d accounts, expected 2", len(accounts))
+	}
+
+	tests := []struct {
+		name       string
+		address    common.Address
+		passphrase string
+		wantErr    error
+	}{
+		{"created account", created.Address, "secret", nil},
+		{"imported account", imported.Address, "other", nil},
+		{"wrong passphrase", created.Address, "other", ErrInvalidKeyFormat},
+		{"unknown address", common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8"), "secret", ErrKeyNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pk, err := ks.Unlock(tt.address, tt.passphrase)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Unlock() error = %v, expected %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && PrivateKeyToAddress(pk) != tt.address {
+				t.Errorf("Unlock() key address = %s, expected %s", PrivateKeyToAddress(pk).Hex(), tt.address.Hex())
+			}
+		})
+	}
+
+	// 使用 keystore 账户创建 Kit
+	server := newMockSendServer(t)
+	kit, err := New(server.URL, WithKeystoreAccount(ks, imported.Address, "other"))
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	kit.Close()
+	if kit.GetAddress() != imported.Address {
+		t.Errorf("GetAddress() = %s, expected %s", kit.GetAddress().Hex(), imported.Address.Hex())
+	}
+	if _, err := New(server.URL, WithKeystoreAccount(ks, imported.Address, "wrong")); err == nil {
+		t.Error("New() expected error for wrong passphrase")
+	}
+
+	// 删除需要正确的密码
+	if err := ks.Delete(created.Address, "wrong"); err == nil {
+		t.Error("Delete() expected error for wrong passphrase")
+	}
+	if err := ks.Delete(created.Address, "secret"); err != nil {
+		t.Fatalf("Delete() failed: %v", err)
+	}
+	if _, err := ks.Find(created.Address); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Find() error = %v, expected ErrKeyNotFound after delete", err)
+	}
+}
@@ -0,0 +1,104 @@
+package etherkit
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateAccounts(t *testing.T) {
+	accounts, err := GenerateAccounts(5)
+	if err != nil {
+		t.Fatalf("GenerateAccounts() failed: %v", err)
+	}
+	seen := map[string]bool{}
+	for _, account := range accounts {
+		if PrivateKeyToAddress(account.PrivateKey) != account.Address {
+			t.Errorf("GenerateAccounts() address %s does not match its key", account.Address.Hex())
+		}
+		seen[account.Address.Hex()] = true
+	}
+	if len(seen) != 5 {
+		t.Errorf("GenerateAccounts() returned %d unique accounts, expected 5", len(seen))
+	}
+	if _, err := GenerateAccounts(-1); err == nil {
+		t.Error("GenerateAccounts() expected error for negative count")
+	}
+}
+
+func TestKeystoreBundle(t *testing.T) {
+	accounts, _ := GenerateAccounts(3)
+	bundle, err := ExportKeystoreBundle(accounts, "secret", true)
+	if err != nil {
+		t.Fatalf("ExportKeystoreBundle() failed: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		bundle     []byte
+		passphrase string
+		wantErr    bool
+	}{
+		{"correct passphrase", bundle, "secret", false},
+		{"wrong passphrase", bundle, "wrong", true},
+		{"malformed bundle", []byte("{}"), "secret", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			imported, err := ImportKeystoreBundle(tt.bundle, tt.passphrase)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ImportKeystoreBundle() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidKeyFormat) {
+					t.Errorf("ImportKeystoreBundle() error = %v, expected ErrInvalidKeyFormat", err)
+				}
+				return
+			}
+			for i := range accounts {
+				if imported[i].Address != accounts[i].Address || !imported[i].PrivateKey.Equal(accounts[i].PrivateKey) {
+					t.Errorf("account %d = %s, expected %s", i, imported[i].Address.Hex(), accounts[i].Address.Hex())
+				}
+			}
+		})
+	}
+}
+
+func TestKeystoreDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "keystore")
+	accounts, _ := GenerateAccounts(2)
+	paths, err := WriteKeystoreDir(dir, accounts, "secret", true)
+	if err != nil {
+		t.Fatalf("WriteKeystoreDir() failed: %v", err)
+	}
+	for i, path := range paths {
+		name := filepath.Base(path)
+		if !strings.HasPrefix(name, "UTC--") || !strings.HasSuffix(name, strings.ToLower(accounts[i].Address.Hex()[2:])) {
+			t.Errorf("keystore file name = %s, expected geth naming", name)
+		}
+		if info, _ := os.Stat(path); info.Mode().Perm() != 0600 {
+			t.Errorf("keystore file mode = %v, expected 0600", info.Mode().Perm())
+		}
+	}
+	_ = os.WriteFile(filepath.Join(dir, ".DS_Store"), []byte("ignored"), 0600)
+
+	imported, err := ReadKeystoreDir(dir, "secret")
+	if err != nil {
+		t.Fatalf("ReadKeystoreDir() failed: %v", err)
+	}
+	if len(imported) != len(accounts) {
+		t.Fatalf("ReadKeystoreDir() returned %d accounts, expected %d", len(imported), len(accounts))
+	}
+	found := map[string]bool{}
+	for _, account := range imported {
+		found[account.Address.Hex()] = true
+	}
+	for _, account := range accounts {
+		if !found[account.Address.Hex()] {
+			t.Errorf("ReadKeystoreDir() missing %s", account.Address.Hex())
+		}
+	}
+}
@@ -4,13 +4,17 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"errors"
+	"fmt"
+	"log/slog"
 	"math/big"
-	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
 // Kit 相关常量
@@ -24,25 +28,51 @@ const (
 type Kit struct {
 	*Wallet       // 嵌入 Wallet，获得所有钱包方法（包括 GetAddress、GetPrivateKey）
 	EtherProvider // 嵌入 Provider 接口，直接调用所有 Provider 方法！
+
+	confirmationHooks []ConfirmationHook                // 签名前的交易审核回调（按注册顺序执行）
+	counterparties    *CounterpartyBook                 // 最近交易对手（启用 WithLookalikeGuard 时记录）
+	auditSinks        []AuditSink                       // 签名审计记录接收器
+	priceSource       PriceSource                       // 法币价格来源（用于 CostInUSD、BalanceInUSD）
+	explorerAPI       *ExplorerAPI                      // 区块浏览器 API（用于 GetTransactionHistory）
+	trackedTokens     []common.Address                  // 关注的代币（用于 GetTokenTransfers）
+	logger            *slog.Logger                      // 日志（nil 表示不输出日志）
+	timeouts          TimeoutPolicy                     // 默认超时策略
+	simulateFirst     bool                              // InvokeContract 发送前先模拟执行（见 WithSimulateFirst）
+	replacement       *ReplacementPolicy                // 替换交易的加价策略（nil 使用 DefaultReplacementPolicy）
+	txStore           TxStore                           // 已广播交易的持久化存储（nil 表示不持久化，见 WithTxStore）
+	accessListOpt     bool                              // 构建合约调用交易时附加能节省 gas 的访问列表（见 WithAccessListOptimization）
+	capabilities      atomic.Pointer[Capabilities]      // 节点功能探测结果（见 DetectCapabilities，nil 表示尚未探测）
+	signed            *lruCache[uint64, signedTxRecord] // 最近签名的交易（按 nonce 索引，用于 GetPendingTransactions）
+
+	setup *kitSetup // 创建过程中的配置（仅在 New 执行期间不为 nil）
+}
+
+// KitOption Kit 的可选配置
+type KitOption func(*Kit)
+
+// applyOptions 依次应用可选配置
+func (k *Kit) applyOptions(opts []KitOption) *Kit {
+	for _, opt := range opts {
+		if opt != nil {
+			opt(k)
+		}
+	}
+	return k
 }
 
 // NewKit 创建以太坊开发工具包
 // 参数说明：
 //   - hexPk: 十六进制私钥字符串（带或不带 0x 前缀）
 //   - rawUrl: 以太坊节点 RPC URL（如 "https://eth-mainnet.g.alchemy.com/v2/your-api-key"）
+//   - opts: 可选配置（如 WithConfirmationHook）
 //
 // 返回：
 //   - *Kit: 创建的 Kit 实例
 //   - error: 如果创建失败则返回错误
-func NewKit(hexPk string, rawUrl string) (*Kit, error) {
-	wallet, err := NewWallet(hexPk, rawUrl)
-	if err != nil {
-		return nil, err
-	}
-	return &Kit{
-		Wallet:        wallet,
-		EtherProvider: wallet.GetEthProvider(),
-	}, nil
+//
+// 等同于 New(rawUrl, WithPrivateKeyHex(hexPk), opts...)
+func NewKit(hexPk string, rawUrl string, opts ...KitOption) (*Kit, error) {
+	return New(rawUrl, append([]KitOption{WithPrivateKeyHex(hexPk)}, opts...)...)
 }
 
 // NewKitWithGeneratedKey 创建以太坊开发工具包（自动生成随机私钥）
@@ -50,6 +80,7 @@ func NewKit(hexPk string, rawUrl string) (*Kit, error) {
 // 会自动生成一个随机私钥并创建对应的 Kit 实例
 // 参数说明：
 //   - rawUrl: 以太坊节点 RPC URL（如 "https://eth-mainnet.g.alchemy.com/v2/your-api-key"）
+//   - opts: 可选配置
 //
 // 返回：
 //   - *Kit: 创建的 Kit 实例（包含新生成的私钥和地址）
@@ -59,16 +90,12 @@ func NewKit(hexPk string, rawUrl string) (*Kit, error) {
 //   - 生成的私钥是随机的，每次调用都会创建新的钱包
 //   - 请妥善保存生成的私钥，可通过 kit.GetPrivateKey() 获取私钥对象，或使用 GetHexPrivateKey(kit.GetPrivateKey()) 获取十六进制字符串
 //   - 适用于临时场景，生产环境建议使用 NewKit 导入已有私钥
-func NewKitWithGeneratedKey(rawUrl string) (*Kit, error) {
+func NewKitWithGeneratedKey(rawUrl string, opts ...KitOption) (*Kit, error) {
 	pk, err := GeneratePrivateKey()
 	if err != nil {
 		return nil, err
 	}
-	ep, err := NewProvider(rawUrl)
-	if err != nil {
-		return nil, err
-	}
-	return NewKitWithComponents(pk, ep)
+	return New(rawUrl, append([]KitOption{WithPrivateKey(pk)}, opts...)...)
 }
 
 // NewKitWithComponents 使用已有组件创建 Kit
@@ -76,19 +103,36 @@ func NewKitWithGeneratedKey(rawUrl string) (*Kit, error) {
 // 参数说明：
 //   - privateKey: 已存在的 ECDSA 私钥
 //   - ep: 已存在的 EtherProvider 实例
+//   - opts: 可选配置
 //
 // 返回：
 //   - *Kit: 创建的 Kit 实例
 //   - error: 如果创建失败则返回错误
-func NewKitWithComponents(privateKey *ecdsa.PrivateKey, ep EtherProvider) (*Kit, error) {
-	wallet, err := NewWalletWithComponents(privateKey, ep)
-	if err != nil {
-		return nil, err
+func NewKitWithComponents(privateKey *ecdsa.PrivateKey, ep EtherProvider, opts ...KitOption) (*Kit, error) {
+	return New("", append([]KitOption{WithPrivateKey(privateKey), WithProvider(ep)}, opts...)...)
+}
+
+// NewReadOnlyKit 创建不需要私钥的只读 Kit（适用于数据分析、监控面板等只查询链上数据的服务）
+// 所有 Provider 方法和查询类增强方法（如 GetBalanceOf、StaticCall、GetNetworkStatus）均可正常使用；
+// 签名、发送交易以及查询自身账户（GetNonce、GetBalance）的方法返回 ErrReadOnly
+// 参数说明：
+//   - rawUrl: 以太坊节点 RPC URL
+//   - opts: 可选配置（如 WithChainID、WithTimeout、WithProviderOptions）
+//
+// 返回：
+//   - *Kit: 只读 Kit 实例
+//   - error: 如果连接失败则返回错误
+func NewReadOnlyKit(rawUrl string, opts ...KitOption) (*Kit, error) {
+	return New(rawUrl, append([]KitOption{withReadOnly()}, opts...)...)
+}
+
+// withReadOnly 允许 New 在没有私钥时创建只读 Kit
+func withReadOnly() KitOption {
+	return func(k *Kit) {
+		if k.setup != nil {
+			k.setup.readOnly = true
+		}
 	}
-	return &Kit{
-		Wallet:        wallet,
-		EtherProvider: ep,
-	}, nil
 }
 
 // ============ 以下是增强功能 ============
@@ -98,11 +142,11 @@ func NewKitWithComponents(privateKey *ecdsa.PrivateKey, ep EtherProvider) (*Kit,
 // 参数说明：
 //   - ctx: 上下文对象
 //   - txHash: 交易哈希
-//   - timeout: 超时时间（如 30*time.Second）
+//   - timeout: 超时时间（如 30*time.Second，<= 0 时使用 TimeoutPolicy.Wait，均未设置时一直等待到 ctx 取消）
 //
 // 返回：
 //   - *types.Receipt: 交易收据，包含交易状态、gas 使用等信息
-//   - error: 如果超时或查询失败则返回错误
+//   - error: 如果超时（ErrReceiptTimeout）或查询失败则返回错误
 func (k *Kit) WaitForReceipt(ctx context.Context, txHash common.Hash, timeout time.Duration) (*types.Receipt, error) {
 	return k.WaitForReceiptWithInterval(ctx, txHash, timeout, DefaultWaitInterval)
 }
@@ -112,18 +156,32 @@ func (k *Kit) WaitForReceipt(ctx context.Context, txHash common.Hash, timeout ti
 // 参数说明：
 //   - ctx: 上下文对象
 //   - txHash: 交易哈希
-//   - timeout: 超时时间（如 30*time.Second）
+//   - timeout: 超时时间（如 30*time.Second，<= 0 时使用 TimeoutPolicy.Wait）
 //   - interval: 轮询间隔（如 2*time.Second，建议不小于 1 秒以避免频繁请求）
 //
 // 返回：
 //   - *types.Receipt: 交易收据，包含交易状态、gas 使用等信息
-//   - error: 如果超时或查询失败则返回错误
+//   - error: 如果超时（ErrReceiptTimeout）或查询失败则返回错误
 func (k *Kit) WaitForReceiptWithInterval(ctx context.Context, txHash common.Hash, timeout time.Duration, interval time.Duration) (*types.Receipt, error) {
+	if timeout <= 0 {
+		timeout = k.timeouts.Wait
+	}
+	return waitForReceipt(ctx, k.EtherProvider, txHash, timeout, interval)
+}
+
+// waitForReceipt 按间隔轮询交易收据，直到交易被打包或超时
+func waitForReceipt(ctx context.Context, ep EtherProvider, txHash common.Hash, timeout time.Duration, interval time.Duration) (*types.Receipt, error) {
 	if interval < time.Second {
 		interval = DefaultWaitInterval // 最小间隔为 1 秒
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, timeout)
+	parent := ctx
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx) // 不限制等待时间，直到调用方取消
+	}
 	defer cancel()
 
 	ticker := time.NewTicker(interval)
@@ -132,9 +190,12 @@ func (k *Kit) WaitForReceiptWithInterval(ctx context.Context, txHash common.Hash
 	for {
 		select {
 		case <-ctx.Done():
-			return nil, ctx.Err()
+			if parent.Err() != nil {
+				return nil, parent.Err()
+			}
+			return nil, fmt.Errorf("%w: %s after %s: %w", ErrReceiptTimeout, txHash.Hex(), timeout, ctx.Err())
 		case <-ticker.C:
-			receipt, err := k.GetTransactionReceipt(ctx, txHash)
+			receipt, err := ep.GetTransactionReceipt(ctx, txHash)
 			if err == nil && receipt != nil {
 				return receipt, nil
 			}
@@ -142,6 +203,19 @@ func (k *Kit) WaitForReceiptWithInterval(ctx context.Context, txHash common.Hash
 	}
 }
 
+// CheckReceiptStatus 检查交易收据的执行状态
+// 参数说明：
+//   - receipt: 交易收据
+//
+// 返回：
+//   - error: 交易执行失败时返回 ErrReverted（同时满足 errors.Is(err, ErrTransactionFailed)），成功时返回 nil
+func CheckReceiptStatus(receipt *types.Receipt) error {
+	if receipt.Status == types.ReceiptStatusSuccessful {
+		return nil
+	}
+	return fmt.Errorf("%w: tx %s in block %s", ErrReverted, receipt.TxHash.Hex(), receipt.BlockNumber)
+}
+
 // SendTxAndWait 发送交易并等待确认
 // 这是 SendTx 和 WaitForReceipt 的组合方法，发送交易后自动等待打包
 // 参数说明：
@@ -167,6 +241,7 @@ func (k *Kit) SendTxAndWait(ctx context.Context, to common.Address, nonce, gasLi
 
 // TransferEther 转账以太币（便捷方法）
 // 将 ETH 金额转换为 Wei 并发送交易，自动计算 nonce、gasLimit 和 gasPrice
+// 金额经过 float64 表示可能存在精度误差，需要精确金额时使用 TransferEtherExact 或 TransferEtherDecimal
 // 参数说明：
 //   - ctx: 上下文对象
 //   - to: 接收地址
@@ -260,7 +335,7 @@ func (k *Kit) StaticCallWithABIString(ctx context.Context, contractAddress commo
 		return nil, errors.New("function name cannot be empty")
 	}
 
-	contractAbi, err := abi.JSON(strings.NewReader(abiJSON))
+	contractAbi, err := getABICached(abiJSON)
 	if err != nil {
 		return nil, err
 	}
@@ -283,7 +358,7 @@ func (k *Kit) StaticCallWithABIString(ctx context.Context, contractAddress commo
 //
 // 返回：
 //   - common.Hash: 交易哈希，可用于查询交易状态
-//   - error: 如果发送失败则返回错误
+//   - error: 如果发送失败则返回错误（启用 WithSimulateFirst 时，模拟回滚返回 *RevertError）
 func (k *Kit) InvokeContract(ctx context.Context, contractAddress common.Address, contractAbi abi.ABI, functionName string, nonce, gasLimit uint64, gasPrice, value *big.Int, params ...interface{}) (common.Hash, error) {
 	// 输入验证
 	if !IsValidAddress(contractAddress) {
@@ -299,8 +374,14 @@ func (k *Kit) InvokeContract(ctx context.Context, contractAddress common.Address
 		return common.Hash{}, err
 	}
 
-	// 发送交易
-	return k.SendTx(ctx, contractAddress, nonce, gasLimit, gasPrice, value, inputData)
+	if k.simulateFirst {
+		if err := k.simulateInvoke(ctx, contractAddress, contractAbi, functionName, gasLimit, value, inputData); err != nil {
+			return common.Hash{}, err
+		}
+	}
+
+	// 发送交易（审核回调可以看到解码后的方法和参数）
+	return k.sendTx(ctx, contractAddress, nonce, gasLimit, gasPrice, value, inputData, &contractAbi)
 }
 
 // InvokeContractWithABIString 使用 ABI JSON 字符串调用合约方法并发送交易（花费 gas）
@@ -332,7 +413,7 @@ func (k *Kit) InvokeContractWithABIString(ctx context.Context, contractAddress c
 		return common.Hash{}, errors.New("function name cannot be empty")
 	}
 
-	contractAbi, err := abi.JSON(strings.NewReader(abiJSON))
+	contractAbi, err := getABICached(abiJSON)
 	if err != nil {
 		return common.Hash{}, err
 	}
@@ -382,9 +463,9 @@ func (k *Kit) GetContractBytecode(ctx context.Context, address common.Address) (
 //   - common.Hash: 交易哈希，可用于后续查询交易状态
 //   - error: 如果发送失败则返回错误
 //
-// 注意：此方法通过嵌入的 Wallet 提供，如需等待交易确认，请使用 SendTxAndWait
+// 注意：签名前会依次执行通过 WithConfirmationHook 注册的审核回调，如需等待交易确认，请使用 SendTxAndWait
 func (k *Kit) SendTx(ctx context.Context, to common.Address, nonce, gasLimit uint64, gasPrice, value *big.Int, data []byte) (common.Hash, error) {
-	return k.Wallet.SendTx(ctx, to, nonce, gasLimit, gasPrice, value, data)
+	return k.sendTx(ctx, to, nonce, gasLimit, gasPrice, value, data, nil)
 }
 
 // SendTxWithHexInput 发送十六进制输入的交易（不等待确认）
@@ -402,9 +483,13 @@ func (k *Kit) SendTx(ctx context.Context, to common.Address, nonce, gasLimit uin
 //   - common.Hash: 交易哈希，可用于后续查询交易状态
 //   - error: 如果发送失败则返回错误
 //
-// 注意：此方法通过嵌入的 Wallet 提供，如需等待交易确认，请使用 SendTxWithHexInputAndWait
+// 注意：与 SendTx 相同会执行审核回调，如需等待交易确认，请使用 SendTxWithHexInputAndWait
 func (k *Kit) SendTxWithHexInput(ctx context.Context, to common.Address, nonce, gasLimit uint64, gasPrice, value *big.Int, input string) (common.Hash, error) {
-	return k.Wallet.SendTxWithHexInput(ctx, to, nonce, gasLimit, gasPrice, value, input)
+	data, err := hexutil.Decode(input)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return k.SendTx(ctx, to, nonce, gasLimit, gasPrice, value, data)
 }
 
 // SendTxWithHexInputAndWait 发送十六进制输入的交易并等待确认
@@ -467,19 +552,209 @@ func (k *Kit) GetLatestBlock(ctx context.Context) (*types.Block, error) {
 	return k.GetBlockByNumber(ctx, big.NewInt(int64(blockNumber)))
 }
 
+// GetChainInfo 一次性获取链的基本信息
+// 依次查询链 ID、网络 ID 和最新区块号
+// 参数说明：
+//   - ctx: 上下文对象
+//
+// 返回：
+//   - chainID: 链 ID
+//   - networkID: 网络 ID
+//   - blockNumber: 最新区块号
+//   - err: 如果任一查询失败则返回错误
+func (k *Kit) GetChainInfo(ctx context.Context) (chainID, networkID, blockNumber *big.Int, err error) {
+	chainID, err = k.GetChainID(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	networkID, err = k.GetNetworkID(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	number, err := k.GetBlockNumber(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return chainID, networkID, new(big.Int).SetUint64(number), nil
+}
+
+// NetworkStatus 节点和网络的当前状态
+type NetworkStatus struct {
+	ChainID     *big.Int      // 链 ID
+	BlockNumber uint64        // 最新区块号
+	BlockTime   time.Time     // 最新区块时间
+	GasPrice    *big.Int      // 节点建议的 gas 价格（单位为 Wei）
+	Syncing     bool          // 节点是否正在同步
+	Latency     time.Duration // 查询最新区块头的往返耗时
+}
+
+// Lag 返回最新区块距今的时间（节点落后或出块停滞时会明显变大）
+func (s *NetworkStatus) Lag() time.Duration {
+	return time.Since(s.BlockTime)
+}
+
+// GetNetworkStatus 获取节点和网络的当前状态（链 ID、最新区块、gas 价格、同步状态和延迟）
+// 参数说明：
+//   - ctx: 上下文对象
+//
+// 返回：
+//   - *NetworkStatus: 网络状态
+//   - error: 如果任一查询失败则返回错误
+func (k *Kit) GetNetworkStatus(ctx context.Context) (*NetworkStatus, error) {
+	chainID, err := k.GetChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	header, err := k.GetHeaderAt(ctx, BlockRef{})
+	if err != nil {
+		return nil, err
+	}
+	latency := time.Since(start)
+	gasPrice, err := k.GetSuggestGasPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+	progress, err := k.GetEthClient().SyncProgress(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &NetworkStatus{
+		ChainID:     chainID,
+		BlockNumber: header.Number.Uint64(),
+		BlockTime:   time.Unix(int64(header.Time), 0),
+		GasPrice:    gasPrice,
+		Syncing:     progress != nil && !progress.Done(),
+		Latency:     latency,
+	}, nil
+}
+
+// GetBalanceInEther 获取 Kit 账户的余额（以 ETH 为单位）
+// 参数说明：
+//   - ctx: 上下文对象
+//
+// 返回：
+//   - float64: 余额（以 ETH 为单位，如 0.5 表示 0.5 ETH）
+//   - error: 如果查询失败则返回错误
+//
+// 注意：float64 只适合展示，需要精确计算时请使用 GetBalance 返回的 Wei 值
+func (k *Kit) GetBalanceInEther(ctx context.Context) (float64, error) {
+	balance, err := k.GetBalance(ctx)
+	if err != nil {
+		return 0, err
+	}
+	value, _ := ToDecimal(balance, EthDecimals).Float64()
+	return value, nil
+}
+
+// ============ Gas 估算增强方法 ============
+
+// EstimateGasBreakdown 估算交易的 gas 及费用组成（L1 + L2）
+// 根据链注册表自动选择估算方式：
+//   - Arbitrum 网络：通过 NodeInterface.gasEstimateComponents 获取 L1 数据费用和 L2 执行费用
+//   - 其他网络：使用 eth_estimateGas，费用全部计入 L2 部分
+//
+// 其他网络的费用按最新区块 baseFee 加 eth_maxPriorityFeePerGas 建议小费计算，即预计实际支付的费用，
+// 而不是动态费用交易的 maxFeePerGas 上限（2 × baseFee + 小费）；不支持 EIP-1559 的网络使用 eth_gasPrice
+//
+// 参数说明：
+//   - ctx: 上下文对象
+//   - to: 接收地址（合约地址或普通地址）
+//   - value: 转账金额（nil 表示不转账）
+//   - data: 交易数据（合约调用数据或 nil）
+//
+// 返回：
+//   - *GasEstimate: gas 估算结果
+//   - error: 如果估算失败则返回错误
+func (k *Kit) EstimateGasBreakdown(ctx context.Context, to common.Address, value *big.Int, data []byte) (*GasEstimate, error) {
+	chainId, err := k.GetChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if IsArbitrumChain(chainId) {
+		return EstimateArbitrumGasComponents(ctx, k.EtherProvider, k.GetAddress(), to, value, data)
+	}
+
+	gasLimit, err := k.EstimateGas(ctx, k.GetAddress(), to, 0, nil, value, data)
+	if err != nil {
+		return nil, err
+	}
+
+	// 支持 EIP-1559 的网络按 baseFee + 建议小费计算，不支持的网络使用建议 gas 价格（已包含小费）
+	header, err := k.GetEthClient().HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	baseFee, tip := header.BaseFee, big.NewInt(0)
+	if baseFee == nil {
+		baseFee, err = k.GetSuggestGasPrice(ctx)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		tip, err = k.GetEthClient().SuggestGasTipCap(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query max priority fee: %w", err)
+		}
+	}
+
+	price := new(big.Int).Add(baseFee, tip)
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(gasLimit), price)
+	return &GasEstimate{
+		GasLimit:    gasLimit,
+		L2Gas:       gasLimit,
+		BaseFee:     baseFee,
+		PriorityFee: tip,
+		L1Fee:       big.NewInt(0),
+		L2Fee:       fee,
+		TotalFee:    new(big.Int).Set(fee),
+	}, nil
+}
+
 // ============ 签名和验证增强方法 ============
 
 // SignMessage 对消息进行签名
-// 使用 Kit 的私钥对消息进行 ECDSA 签名
+// 使用 Kit 的私钥对消息进行 ECDSA 签名（签名前执行审核回调，启用审计时在返回签名前写入审计记录）
 // 参数说明：
-//   - ctx: 上下文对象（当前未使用，保留用于未来扩展）
+//   - ctx: 上下文对象（传递给审核回调和审计接收器）
 //   - message: 要签名的消息（原始字节）
 //
 // 返回：
 //   - []byte: 签名结果（65 字节，包含 r、s、v）
-//   - error: 如果签名失败则返回错误
+//   - error: 如果审核被拒绝、签名或写入审计记录失败则返回错误
 func (k *Kit) SignMessage(ctx context.Context, message []byte) ([]byte, error) {
-	return k.Signature(message)
+	review := k.newSignatureReview(AuditKindMessage, nil, crypto.Keccak256Hash(message), fmt.Sprintf("sign %d bytes message", len(message)))
+	review.Message = message
+	return k.signReviewed(ctx, review, func() ([]byte, error) {
+		return k.Wallet.Signature(message)
+	})
+}
+
+// Signature 对数据进行签名（先做 Keccak256 哈希，签名前执行审核回调，启用审计时在返回签名前写入审计记录）
+// 覆盖 Wallet.Signature，保证通过 Kit 产生的签名都经过审核和审计
+// 参数说明：
+//   - data: 要签名的原始数据（字节）
+//
+// 返回：
+//   - []byte: 签名结果（65 字节，包含 r、s、v）
+//   - error: 如果审核被拒绝、签名或写入审计记录失败则返回错误
+func (k *Kit) Signature(data []byte) ([]byte, error) {
+	return k.SignMessage(context.Background(), data)
+}
+
+// SignHash 直接对 32 字节哈希进行签名（签名前执行审核回调，启用审计时在返回签名前写入审计记录）
+// 覆盖 Wallet.SignHash；审核回调无法得知哈希对应的内容，优先使用 SignMessage、SignTypedData 等带原始内容的方法
+// 参数说明：
+//   - hash: 待签名的哈希
+//
+// 返回：
+//   - []byte: 签名结果（65 字节，r ‖ s ‖ v，v 为 27 或 28）
+//   - error: 如果审核被拒绝、签名或写入审计记录失败则返回错误
+func (k *Kit) SignHash(hash common.Hash) ([]byte, error) {
+	review := k.newSignatureReview(AuditKindHash, nil, hash, fmt.Sprintf("sign raw hash %s", hash.Hex()))
+	return k.signReviewed(context.Background(), review, func() ([]byte, error) {
+		return k.Wallet.SignHash(hash)
+	})
 }
 
 // VerifyMessage 验证消息签名
@@ -538,3 +813,27 @@ func (k *Kit) FilterEventLogs(ctx context.Context, contractAddress *common.Addre
 	// 调用 Provider 的 FilterLogs 方法
 	return k.EtherProvider.FilterLogs(ctx, contractAddress, eventTopic, fromBlock, toBlock, indexedParams)
 }
+
+// FilterEventLogsMulti 按多个合约、多个事件签名过滤事件日志
+// 参数说明：
+//   - ctx: 上下文对象
+//   - contractAddresses: 合约地址列表（空表示查询所有合约）
+//   - eventSignatures: 事件签名列表（如 "Transfer(address,address,uint256)"，空表示不限制事件）
+//   - fromBlock: 起始区块号
+//   - toBlock: 结束区块号
+//   - indexedParams: 每个 indexed 参数位置的候选值（nil 或空元素表示该位置不过滤）
+//
+// 返回：
+//   - []types.Log: 事件日志列表
+//   - error: 如果参数无效或查询失败则返回错误
+//
+// 使用示例：
+//   - 查询 from 为 alice 或 bob 的 Transfer 和 Approval：
+//     logs, err := kit.FilterEventLogsMulti(ctx, []common.Address{token}, []string{"Transfer(address,address,uint256)", "Approval(address,address,uint256)"}, fromBlock, toBlock, [][]common.Hash{{aliceTopic, bobTopic}})
+func (k *Kit) FilterEventLogsMulti(ctx context.Context, contractAddresses []common.Address, eventSignatures []string, fromBlock, toBlock *big.Int, indexedParams [][]common.Hash) ([]types.Log, error) {
+	eventTopics := make([]common.Hash, len(eventSignatures))
+	for i, signature := range eventSignatures {
+		eventTopics[i] = common.HexToHash(GetEventTopic(signature))
+	}
+	return k.FilterLogsMulti(ctx, contractAddresses, eventTopics, fromBlock, toBlock, indexedParams)
+}
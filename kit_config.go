@@ -0,0 +1,189 @@
+package etherkit
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//############ Kit Config ############
+
+// gas 价格策略
+const (
+	GasPolicyNode  = "node"  // 使用节点的 eth_gasPrice（默认）
+	GasPolicyChain = "chain" // 按链注册表选择（配置了 gas 预言机的链优先使用预言机，需要 ChainID）
+)
+
+// 环境变量名
+const (
+	EnvRPCURL         = "EVMKIT_RPC_URL"          // 节点 RPC URL（必填）
+	EnvSendRPCURL     = "EVMKIT_SEND_RPC_URL"     // 广播交易使用的节点
+	EnvChainID        = "EVMKIT_CHAIN_ID"         // 链 ID
+	EnvPrivateKey     = "EVMKIT_PRIVATE_KEY"      // 十六进制私钥
+	EnvPrivateKeyFile = "EVMKIT_PRIVATE_KEY_FILE" // 保存十六进制私钥的文件路径
+	EnvMnemonic       = "EVMKIT_MNEMONIC"         // 助记词
+	EnvAccountIndex   = "EVMKIT_ACCOUNT_INDEX"    // 助记词派生的账户索引
+	EnvGasPolicy      = "EVMKIT_GAS_POLICY"       // gas 价格策略（node 或 chain）
+	EnvTimeout        = "EVMKIT_TIMEOUT"          // 单次请求超时（如 10s）
+	EnvRetryAttempts  = "EVMKIT_RETRY_ATTEMPTS"   // 读请求最大尝试次数
+)
+
+// KitConfig 创建 Kit 所需的配置（私钥来源 PrivateKey、PrivateKeyFile、Mnemonic 必须且只能设置一个）
+type KitConfig struct {
+	RPCURL         string        // 节点 RPC URL
+	SendRPCURL     string        // 广播交易使用的节点（空表示与 RPCURL 相同）
+	ChainID        int64         // 链 ID（0 表示首次使用时查询）
+	PrivateKey     string        // 十六进制私钥
+	PrivateKeyFile string        // 保存十六进制私钥的文件路径（适用于 Docker/Kubernetes secret 挂载）
+	Mnemonic       string        // 助记词
+	AccountIndex   uint32        // 助记词派生的账户索引
+	GasPolicy      string        // gas 价格策略（GasPolicyNode 或 GasPolicyChain，空表示 GasPolicyNode）
+	Timeout        time.Duration // 单次请求超时（0 表示不限制）
+	RetryAttempts  int           // 读请求最大尝试次数（<= 1 表示不重试，重试间隔使用 DefaultReadRetryPolicy）
+}
+
+// privateKey 根据配置的私钥来源加载私钥
+func (c KitConfig) privateKey() (*ecdsa.PrivateKey, error) {
+	sources := 0
+	for _, s := range []string{c.PrivateKey, c.PrivateKeyFile, c.Mnemonic} {
+		if s != "" {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return nil, fmt.Errorf("%w: exactly one of private key, private key file or mnemonic must be set", ErrInvalidWalletConfig)
+	}
+
+	switch {
+	case c.PrivateKeyFile != "":
+		content, err := os.ReadFile(c.PrivateKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read private key file: %w", err)
+		}
+		return BuildPrivateKeyFromHex(strings.TrimSpace(string(content)))
+	case c.Mnemonic != "":
+		return BuildPrivateKeyFromMnemonicAndAccountId(c.Mnemonic, c.AccountIndex)
+	default:
+		return BuildPrivateKeyFromHex(c.PrivateKey)
+	}
+}
+
+// options 将配置转换为 New 的可选配置
+func (c KitConfig) options() ([]KitOption, error) {
+	if c.RPCURL == "" {
+		return nil, fmt.Errorf("%w: RPC URL is required", ErrInvalidWalletConfig)
+	}
+	pk, err := c.privateKey()
+	if err != nil {
+		return nil, err
+	}
+	opts := []KitOption{WithPrivateKey(pk)}
+	if c.ChainID != 0 {
+		opts = append(opts, WithChainID(c.ChainID))
+	}
+	if c.SendRPCURL != "" {
+		opts = append(opts, WithProviderOptions(WithSendEndpoint(c.SendRPCURL)))
+	}
+	if c.Timeout > 0 {
+		opts = append(opts, WithTimeout(c.Timeout))
+	}
+	if c.RetryAttempts > 1 {
+		policy := DefaultReadRetryPolicy
+		policy.MaxAttempts = c.RetryAttempts
+		opts = append(opts, WithProviderOptions(WithReadRetry(policy)))
+	}
+
+	switch c.GasPolicy {
+	case "", GasPolicyNode:
+	case GasPolicyChain:
+		if c.ChainID == 0 {
+			return nil, fmt.Errorf("%w: gas policy %q requires chain ID", ErrInvalidWalletConfig, c.GasPolicy)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unknown gas policy %q", ErrInvalidWalletConfig, c.GasPolicy)
+	}
+	return opts, nil
+}
+
+// NewKitFromConfig 根据配置创建 Kit
+// 参数说明：
+//   - cfg: Kit 配置
+//   - opts: 额外的可选配置（如 WithLogger、WithConfirmationHook，在配置项之后应用）
+//
+// 返回：
+//   - *Kit: 创建的 Kit 实例
+//   - error: 如果配置无效（ErrInvalidWalletConfig）、私钥加载失败或连接节点失败则返回错误
+func NewKitFromConfig(cfg KitConfig, opts ...KitOption) (*Kit, error) {
+	cfgOpts, err := cfg.options()
+	if err != nil {
+		return nil, err
+	}
+	kit, err := New(cfg.RPCURL, append(cfgOpts, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.GasPolicy == GasPolicyChain {
+		kit.SetGasPricer(NewGasPricerForChain(cfg.ChainID, kit.EtherProvider))
+	}
+	return kit, nil
+}
+
+// KitConfigFromEnv 从 EVMKIT_* 环境变量读取 Kit 配置
+// 返回：
+//   - KitConfig: 读取到的配置（未设置的变量保持零值）
+//   - error: 如果数值类型的变量格式无效则返回错误
+func KitConfigFromEnv() (KitConfig, error) {
+	cfg := KitConfig{
+		RPCURL:         os.Getenv(EnvRPCURL),
+		SendRPCURL:     os.Getenv(EnvSendRPCURL),
+		PrivateKey:     os.Getenv(EnvPrivateKey),
+		PrivateKeyFile: os.Getenv(EnvPrivateKeyFile),
+		Mnemonic:       os.Getenv(EnvMnemonic),
+		GasPolicy:      os.Getenv(EnvGasPolicy),
+	}
+	if v := os.Getenv(EnvChainID); v != "" {
+		chainID, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return cfg, fmt.Errorf("%w: invalid %s: %v", ErrInvalidWalletConfig, EnvChainID, err)
+		}
+		cfg.ChainID = chainID
+	}
+	if v := os.Getenv(EnvAccountIndex); v != "" {
+		index, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return cfg, fmt.Errorf("%w: invalid %s: %v", ErrInvalidWalletConfig, EnvAccountIndex, err)
+		}
+		cfg.AccountIndex = uint32(index)
+	}
+	if v := os.Getenv(EnvTimeout); v != "" {
+		timeout, err := time.ParseDuration(v)
+		if err != nil {
+			return cfg, fmt.Errorf("%w: invalid %s: %v", ErrInvalidWalletConfig, EnvTimeout, err)
+		}
+		cfg.Timeout = timeout
+	}
+	if v := os.Getenv(EnvRetryAttempts); v != "" {
+		attempts, err := strconv.Atoi(v)
+		if err != nil {
+			return cfg, fmt.Errorf("%w: invalid %s: %v", ErrInvalidWalletConfig, EnvRetryAttempts, err)
+		}
+		cfg.RetryAttempts = attempts
+	}
+	return cfg, nil
+}
+
+// NewKitFromEnv 根据 EVMKIT_* 环境变量创建 Kit
+// 使用示例：
+//
+//	// EVMKIT_RPC_URL=https://polygon-rpc.com EVMKIT_CHAIN_ID=137 EVMKIT_PRIVATE_KEY_FILE=/run/secrets/key
+//	kit, err := NewKitFromEnv(WithLogger(slog.Default()))
+func NewKitFromEnv(opts ...KitOption) (*Kit, error) {
+	cfg, err := KitConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return NewKitFromConfig(cfg, opts...)
+}
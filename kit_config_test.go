@@ -0,0 +1,85 @@
+package etherkit
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestNewKitFromConfig(t *testing.T) {
+	const hexPk = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+	wantAddress := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	keyFile := filepath.Join(t.TempDir(), "key")
+	if err := os.WriteFile(keyFile, []byte(hexPk+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	server := newMockSendServer(t)
+
+	tests := []struct {
+		name    string
+		cfg     KitConfig
+		wantErr bool
+	}{
+		{name: "hex key", cfg: KitConfig{RPCURL: server.URL, PrivateKey: hexPk}},
+		{name: "key file", cfg: KitConfig{RPCURL: server.URL, PrivateKeyFile: keyFile, Timeout: time.Second, RetryAttempts: 3}},
+		{name: "chain gas policy", cfg: KitConfig{RPCURL: server.URL, PrivateKey: hexPk, ChainID: 1, GasPolicy: GasPolicyChain}},
+		{name: "missing url", cfg: KitConfig{PrivateKey: hexPk}, wantErr: true},
+		{name: "missing key", cfg: KitConfig{RPCURL: server.URL}, wantErr: true},
+		{name: "multiple keys", cfg: KitConfig{RPCURL: server.URL, PrivateKey: hexPk, PrivateKeyFile: keyFile}, wantErr: true},
+		{name: "chain policy without chain id", cfg: KitConfig{RPCURL: server.URL, PrivateKey: hexPk, GasPolicy: GasPolicyChain}, wantErr: true},
+		{name: "unknown gas policy", cfg: KitConfig{RPCURL: server.URL, PrivateKey: hexPk, GasPolicy: "fastest"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kit, err := NewKitFromConfig(tt.cfg)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidWalletConfig) {
+					t.Fatalf("NewKitFromConfig() error = %v, want ErrInvalidWalletConfig", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewKitFromConfig() failed: %v", err)
+			}
+			defer kit.CloseWallet()
+			if kit.GetAddress() != wantAddress {
+				t.Errorf("GetAddress() = %s, expected %s", kit.GetAddress().Hex(), wantAddress.Hex())
+			}
+		})
+	}
+}
+
+func TestKitConfigFromEnv(t *testing.T) {
+	t.Setenv(EnvRPCURL, "http://localhost:8545")
+	t.Setenv(EnvChainID, "137")
+	t.Setenv(EnvPrivateKey, "0x01")
+	t.Setenv(EnvTimeout, "15s")
+	t.Setenv(EnvRetryAttempts, "4")
+	t.Setenv(EnvGasPolicy, GasPolicyChain)
+
+	cfg, err := KitConfigFromEnv()
+	if err != nil {
+		t.Fatalf("KitConfigFromEnv() failed: %v", err)
+	}
+	want := KitConfig{
+		RPCURL:        "http://localhost:8545",
+		ChainID:       137,
+		PrivateKey:    "0x01",
+		GasPolicy:     GasPolicyChain,
+		Timeout:       15 * time.Second,
+		RetryAttempts: 4,
+	}
+	if cfg != want {
+		t.Errorf("KitConfigFromEnv() = %+v, expected %+v", cfg, want)
+	}
+
+	t.Setenv(EnvChainID, "polygon")
+	if _, err := KitConfigFromEnv(); !errors.Is(err, ErrInvalidWalletConfig) {
+		t.Errorf("KitConfigFromEnv() error = %v, want ErrInvalidWalletConfig", err)
+	}
+}
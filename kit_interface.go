@@ -0,0 +1,100 @@
+package etherkit
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/shopspring/decimal"
+)
+
+//############ Kit Interface ############
+
+// EtherKit 以太坊开发工具包接口
+// 包含 Provider、Wallet 的全部方法以及 Kit 的增强方法，*Kit 实现了该接口
+// 下游代码可以依赖此接口，便于在测试中替换为 mock 或在外层包装（如加入指标、限流）
+//
+// 使用示例：
+//
+//	type PayoutService struct {
+//	    kit etherkit.EtherKit // 生产环境传入 *etherkit.Kit，测试中传入 mock
+//	}
+type EtherKit interface {
+	EtherProvider
+	EtherWallet
+	TxBroadcaster
+	BlockRefReader
+	BlockIndexReader
+	StateOverrideCaller
+	LogQuerier
+
+	// WaitForReceipt 等待交易被打包，超时返回 ErrReceiptTimeout
+	WaitForReceipt(ctx context.Context, txHash common.Hash, timeout time.Duration) (*types.Receipt, error)
+	// WaitForReceiptWithInterval 按自定义轮询间隔等待交易被打包
+	WaitForReceiptWithInterval(ctx context.Context, txHash common.Hash, timeout time.Duration, interval time.Duration) (*types.Receipt, error)
+	// WaitForReceiptWithBackoff 按指数退避策略等待交易被打包
+	WaitForReceiptWithBackoff(ctx context.Context, txHash common.Hash, timeout time.Duration, backoff ReceiptBackoff) (*types.Receipt, error)
+	// SendTxAndWait 发送交易并等待确认
+	SendTxAndWait(ctx context.Context, to common.Address, nonce, gasLimit uint64, gasPrice, value *big.Int, data []byte, timeout time.Duration) (*types.Receipt, error)
+	// SendTxWithHexInputAndWait 发送十六进制输入的交易并等待确认
+	SendTxWithHexInputAndWait(ctx context.Context, to common.Address, nonce, gasLimit uint64, gasPrice, value *big.Int, input string, timeout time.Duration) (*types.Receipt, error)
+	// TransferEther 转账以太币（以 ETH 为单位）
+	TransferEther(ctx context.Context, to common.Address, valueInEther float64) (common.Hash, error)
+	// TransferEtherExact 按十进制字符串转账以太币，金额不会被舍入
+	TransferEtherExact(ctx context.Context, to common.Address, amount string) (common.Hash, error)
+	// TransferEtherDecimal 按 decimal.Decimal 转账以太币，金额不会被舍入
+	TransferEtherDecimal(ctx context.Context, to common.Address, amount decimal.Decimal) (common.Hash, error)
+	// TransferEtherAndWait 转账以太币并等待确认
+	TransferEtherAndWait(ctx context.Context, to common.Address, valueInEther float64, timeout time.Duration) (*types.Receipt, error)
+
+	// StaticCall 静态调用合约方法（不发送交易）
+	StaticCall(ctx context.Context, contractAddress common.Address, contractAbi abi.ABI, functionName string, blockNumber *big.Int, from *common.Address, value *big.Int, params ...interface{}) ([]interface{}, error)
+	// StaticCallWithABIString 使用 ABI JSON 字符串进行静态调用
+	StaticCallWithABIString(ctx context.Context, contractAddress common.Address, abiJSON string, functionName string, blockNumber *big.Int, from *common.Address, value *big.Int, params ...interface{}) ([]interface{}, error)
+	// InvokeContract 调用合约方法并发送交易
+	InvokeContract(ctx context.Context, contractAddress common.Address, contractAbi abi.ABI, functionName string, nonce, gasLimit uint64, gasPrice, value *big.Int, params ...interface{}) (common.Hash, error)
+	// InvokeContractWithABIString 使用 ABI JSON 字符串调用合约方法并发送交易
+	InvokeContractWithABIString(ctx context.Context, contractAddress common.Address, abiJSON string, functionName string, nonce, gasLimit uint64, gasPrice, value *big.Int, params ...interface{}) (common.Hash, error)
+	// IsContract 检查地址是否为合约地址
+	IsContract(ctx context.Context, address common.Address) (bool, error)
+
+	// GetLatestBlock 获取最新区块
+	GetLatestBlock(ctx context.Context) (*types.Block, error)
+	// GetChainInfo 获取链 ID、网络 ID 和最新区块号
+	GetChainInfo(ctx context.Context) (chainID, networkID, blockNumber *big.Int, err error)
+	// BuildGasReport 查询交易及其收据并生成 gas 统计报告
+	BuildGasReport(ctx context.Context, txHashes []common.Hash, abis ...*abi.ABI) (*GasReport, error)
+	// GetTokenTransfers 扫描地址在 Kit 关注的代币中的转账记录
+	GetTokenTransfers(ctx context.Context, address common.Address, from, to uint64) ([]TokenTransferEntry, error)
+	// GetTransactionHistory 通过区块浏览器 API 查询地址的交易历史
+	GetTransactionHistory(ctx context.Context, address common.Address, opts HistoryOptions) ([]HistoryTx, error)
+	// EstimateBlockAtTimestamp 估算指定时间对应的区块号
+	EstimateBlockAtTimestamp(ctx context.Context, ts time.Time) (uint64, error)
+	// EstimateTimestampOfBlock 估算指定区块的出块时间
+	EstimateTimestampOfBlock(ctx context.Context, number uint64) (time.Time, error)
+	// GetPendingTransactions 列出账户尚未打包的交易
+	GetPendingTransactions(ctx context.Context) ([]PendingTx, error)
+	// GetNetworkStatus 获取节点和网络的当前状态
+	GetNetworkStatus(ctx context.Context) (*NetworkStatus, error)
+	// GetBalanceInEther 获取账户余额（以 ETH 为单位）
+	GetBalanceInEther(ctx context.Context) (float64, error)
+	// EstimateGasBreakdown 估算交易的 gas 及费用组成（L1 + L2）
+	EstimateGasBreakdown(ctx context.Context, to common.Address, value *big.Int, data []byte) (*GasEstimate, error)
+	// FilterEventLogs 按事件签名过滤事件日志
+	FilterEventLogs(ctx context.Context, contractAddress *common.Address, eventSignature string, fromBlock, toBlock *big.Int, indexedParams []common.Hash) ([]types.Log, error)
+	// FilterEventLogsMulti 按多个合约、多个事件签名过滤事件日志
+	FilterEventLogsMulti(ctx context.Context, contractAddresses []common.Address, eventSignatures []string, fromBlock, toBlock *big.Int, indexedParams [][]common.Hash) ([]types.Log, error)
+	// QueryLogs 按 LogQuery 构建的条件查询事件日志
+	QueryLogs(ctx context.Context, q *LogQuery) ([]types.Log, error)
+
+	// SignMessage 对消息进行签名
+	SignMessage(ctx context.Context, message []byte) ([]byte, error)
+	// VerifyMessage 验证消息签名是否由账户签名
+	VerifyMessage(ctx context.Context, message, signature []byte) bool
+}
+
+// 编译期检查 *Kit 实现了 EtherKit 接口
+var _ EtherKit = (*Kit)(nil)
@@ -0,0 +1,46 @@
+package etherkit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// recordingKit 包装 EtherKit，记录 TransferEther 调用而不发送交易
+type recordingKit struct {
+	EtherKit
+	transfers []float64
+}
+
+func (r *recordingKit) TransferEther(ctx context.Context, to common.Address, valueInEther float64) (common.Hash, error) {
+	r.transfers = append(r.transfers, valueInEther)
+	return common.Hash{byte(len(r.transfers))}, nil
+}
+
+func TestEtherKitInterface(t *testing.T) {
+	kit := newMockKit(t, newMockSendServer(t))
+
+	// 依赖接口的下游代码
+	payout := func(ctx context.Context, k EtherKit, to common.Address, amounts ...float64) error {
+		for _, amount := range amounts {
+			if _, err := k.TransferEther(ctx, to, amount); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	mock := &recordingKit{EtherKit: kit}
+	recipient := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	if err := payout(context.Background(), mock, recipient, 0.1, 0.2); err != nil {
+		t.Fatalf("payout() failed: %v", err)
+	}
+	if len(mock.transfers) != 2 {
+		t.Errorf("recorded %d transfers, expected 2", len(mock.transfers))
+	}
+	// 未覆盖的方法委托给真实的 Kit
+	if mock.GetAddress() != kit.GetAddress() {
+		t.Errorf("GetAddress() = %s, expected %s", mock.GetAddress().Hex(), kit.GetAddress().Hex())
+	}
+}
@@ -0,0 +1,178 @@
+package etherkit
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//############ Kit Options ############
+
+// kitSetup 只在创建 Kit 时使用的配置（私钥、Provider 等），创建完成后丢弃
+type kitSetup struct {
+	privateKey   *ecdsa.PrivateKey
+	keyErr       error
+	provider     EtherProvider
+	providerOpts []ProviderOption
+	chainID      int64
+	gasPricer    GasPricer
+	readOnly     bool            // 允许不配置私钥（只读 Kit）
+	nodeAccount  *common.Address // 使用节点管理的账户（见 WithNodeAccount）
+	signerKind   SignerKind
+	feeMode      FeeMode
+}
+
+// discardLogger 未配置日志时使用的空日志
+var discardLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+// New 使用可选配置创建以太坊开发工具包
+// 参数说明：
+//   - rawUrl: 以太坊节点 RPC URL（使用 WithProvider 时忽略）
+//   - opts: 可选配置（必须包含 WithPrivateKeyHex、WithPrivateKey 或 WithNodeAccount）
+//
+// 返回：
+//   - *Kit: 创建的 Kit 实例
+//   - error: 如果未配置私钥、私钥无效或连接节点失败则返回错误
+//
+// 使用示例：
+//
+//	kit, err := New("https://polygon-rpc.com",
+//	    WithPrivateKeyHex(hexPk),
+//	    WithChainID(137),
+//	    WithTimeout(10*time.Second),
+//	    WithGasPricer(NewPolygonGasStation(PolygonGasStationURL, GasStationFast)),
+//	    WithLogger(slog.Default()))
+func New(rawUrl string, opts ...KitOption) (*Kit, error) {
+	kit := &Kit{setup: &kitSetup{}, signed: newLRUCache[uint64, signedTxRecord](DefaultSignedTxHistory)}
+	kit.applyOptions(opts)
+	setup := kit.setup
+	kit.setup = nil
+
+	if setup.keyErr != nil {
+		return nil, setup.keyErr
+	}
+	if setup.privateKey != nil && setup.nodeAccount != nil {
+		return nil, fmt.Errorf("%w: both private key and node account configured", ErrInvalidWalletConfig)
+	}
+	if setup.privateKey == nil && setup.nodeAccount == nil && !setup.readOnly {
+		return nil, fmt.Errorf("%w: no private key configured", ErrInvalidPrivateKey)
+	}
+
+	ep := setup.provider
+	if ep == nil {
+		var err error
+		if setup.chainID != 0 {
+			ep, err = NewProviderWithChainId(rawUrl, setup.chainID, setup.providerOpts...)
+		} else {
+			ep, err = NewProvider(rawUrl, setup.providerOpts...)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	wallet := &Wallet{ep: ep}
+	if setup.nodeAccount != nil {
+		wallet = &Wallet{address: *setup.nodeAccount, ep: ep, nodeAccount: true}
+	} else if setup.privateKey != nil {
+		var err error
+		if wallet, err = NewWalletWithComponents(setup.privateKey, ep); err != nil {
+			// 由 New 创建的提供者需要关闭，通过 WithProvider 传入的由调用方负责
+			if setup.provider == nil {
+				ep.Close()
+			}
+			return nil, err
+		}
+	}
+	wallet.SetGasPricer(setup.gasPricer)
+	wallet.SetSignerKind(setup.signerKind)
+	wallet.SetFeeMode(setup.feeMode)
+	kit.Wallet = wallet
+	kit.EtherProvider = ep
+	return kit, nil
+}
+
+// WithPrivateKeyHex 使用十六进制私钥（带或不带 0x 前缀）创建 Kit（仅在创建 Kit 时生效）
+func WithPrivateKeyHex(hexPk string) KitOption {
+	return func(k *Kit) {
+		if k.setup == nil {
+			return
+		}
+		k.setup.privateKey, k.setup.keyErr = BuildPrivateKeyFromHex(hexPk)
+	}
+}
+
+// WithPrivateKey 使用已有私钥创建 Kit（仅在创建 Kit 时生效）
+func WithPrivateKey(privateKey *ecdsa.PrivateKey) KitOption {
+	return func(k *Kit) {
+		if k.setup == nil {
+			return
+		}
+		k.setup.privateKey, k.setup.keyErr = privateKey, nil
+	}
+}
+
+// WithProvider 使用已有的 Provider，不再根据 URL 连接节点（仅在创建 Kit 时生效，WithChainID、WithTimeout 和 WithProviderOptions 将被忽略）
+func WithProvider(ep EtherProvider) KitOption {
+	return func(k *Kit) {
+		if k.setup == nil {
+			return
+		}
+		k.setup.provider = ep
+	}
+}
+
+// WithProviderOptions 创建 Provider 时使用的可选配置（如 WithSendEndpoint、WithCache，仅在创建 Kit 时生效）
+func WithProviderOptions(opts ...ProviderOption) KitOption {
+	return func(k *Kit) {
+		if k.setup == nil {
+			return
+		}
+		k.setup.providerOpts = append(k.setup.providerOpts, opts...)
+	}
+}
+
+// WithChainID 预先设置链 ID，避免首次调用时查询（仅在创建 Kit 时生效）
+func WithChainID(chainID int64) KitOption {
+	return func(k *Kit) {
+		if k.setup == nil {
+			return
+		}
+		k.setup.chainID = chainID
+	}
+}
+
+// WithTimeout 设置对节点的单次请求超时时间（仅对 HTTP(S) 节点生效，等同于 WithProviderOptions(WithCallTimeout(timeout))）
+func WithTimeout(timeout time.Duration) KitOption {
+	return WithProviderOptions(WithCallTimeout(timeout))
+}
+
+// WithGasPricer 设置自动获取 gas 价格时使用的价格来源（等同于创建后调用 SetGasPricer）
+func WithGasPricer(gasPricer GasPricer) KitOption {
+	return func(k *Kit) {
+		if k.setup == nil {
+			k.Wallet.SetGasPricer(gasPricer)
+			return
+		}
+		k.setup.gasPricer = gasPricer
+	}
+}
+
+// WithLogger 设置 Kit 记录交易广播等事件使用的日志（默认不输出日志）
+func WithLogger(logger *slog.Logger) KitOption {
+	return func(k *Kit) {
+		k.logger = logger
+	}
+}
+
+// Logger 返回 Kit 使用的日志（未配置时返回不输出任何内容的日志）
+func (k *Kit) Logger() *slog.Logger {
+	if k.logger == nil {
+		return discardLogger
+	}
+	return k.logger
+}
@@ -0,0 +1,105 @@
+package etherkit
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// fixedGasPricer 返回固定价格的 gas 价格来源
+type fixedGasPricer struct{ price *big.Int }
+
+func (g fixedGasPricer) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return g.price, nil
+}
+
+func TestNewWithOptions(t *testing.T) {
+	const hexPk = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+	wantAddress := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+
+	t.Run("options", func(t *testing.T) {
+		server := newMockSendServer(t)
+		var logs bytes.Buffer
+		kit, err := New(server.URL,
+			WithPrivateKeyHex(hexPk),
+			WithChainID(1),
+			WithTimeout(5*time.Second),
+			WithGasPricer(fixedGasPricer{price: big.NewInt(7)}),
+			WithLogger(slog.New(slog.NewTextHandler(&logs, nil))),
+		)
+		if err != nil {
+			t.Fatalf("New() failed: %v", err)
+		}
+		defer kit.CloseWallet()
+		if kit.GetAddress() != wantAddress {
+			t.Errorf("GetAddress() = %s, expected %s", kit.GetAddress().Hex(), wantAddress.Hex())
+		}
+
+		recipient := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+		tx, err := kit.NewTx(context.Background(), recipient, 0, 21000, nil, big.NewInt(1), nil)
+		if err != nil {
+			t.Fatalf("NewTx() failed: %v", err)
+		}
+		if tx.GasPrice().Int64() != 7 {
+			t.Errorf("gas price = %s, expected 7 from WithGasPricer", tx.GasPrice())
+		}
+		if _, err := kit.SendTx(context.Background(), recipient, 0, 21000, nil, big.NewInt(1), nil); err != nil {
+			t.Fatalf("SendTx() failed: %v", err)
+		}
+		// 预设链 ID 后不再查询 eth_chainId
+		if n := server.callCount("eth_chainId"); n != 0 {
+			t.Errorf("eth_chainId called %d times, expected 0", n)
+		}
+		if !strings.Contains(logs.String(), "transaction sent") {
+			t.Errorf("log output = %q, expected transaction sent entry", logs.String())
+		}
+	})
+
+	t.Run("with provider", func(t *testing.T) {
+		server := newMockSendServer(t)
+		provider, err := NewProvider(server.URL)
+		if err != nil {
+			t.Fatalf("NewProvider() failed: %v", err)
+		}
+		defer provider.Close()
+		kit, err := New("", WithPrivateKeyHex(hexPk), WithProvider(provider))
+		if err != nil {
+			t.Fatalf("New() failed: %v", err)
+		}
+		if kit.EtherProvider != provider {
+			t.Error("EtherProvider should be the provided instance")
+		}
+	})
+
+	tests := []struct {
+		name    string
+		opts    []KitOption
+		wantErr error // nil 表示只要求返回错误
+	}{
+		{name: "missing key", opts: nil, wantErr: ErrInvalidPrivateKey},
+		{name: "invalid key", opts: []KitOption{WithPrivateKeyHex("0xzz")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newMockSendServer(t)
+			_, err := New(server.URL, tt.opts...)
+			if err == nil || (tt.wantErr != nil && !errors.Is(err, tt.wantErr)) {
+				t.Errorf("New() error = %v, expected %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestKitLoggerDefault(t *testing.T) {
+	kit := newMockKit(t, newMockSendServer(t))
+	if kit.Logger() == nil {
+		t.Fatal("Logger() should never be nil")
+	}
+}
@@ -2,10 +2,14 @@ package etherkit
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
 // TestKitCreation 测试 Kit 的创建
@@ -168,3 +172,55 @@ func BenchmarkKitCreation(b *testing.B) {
 		kit.CloseWallet()
 	}
 }
+
+// TestReadOnlyKit 测试只读 Kit：查询正常，签名和发送返回 ErrReadOnly
+func TestReadOnlyKit(t *testing.T) {
+	server := newMockSendServer(t)
+	blockTime := time.Now().Add(-3 * time.Second).Truncate(time.Second)
+	server.handlers["eth_getBlockByNumber"] = func(params []json.RawMessage) (interface{}, error) {
+		header := &types.Header{
+			Number:     big.NewInt(100),
+			Time:       uint64(blockTime.Unix()),
+			Difficulty: big.NewInt(0),
+			UncleHash:  types.EmptyUncleHash,
+			TxHash:     types.EmptyTxsHash,
+		}
+		return header, nil
+	}
+	server.handlers["eth_syncing"] = mockResult(false)
+
+	kit, err := NewReadOnlyKit(server.URL)
+	if err != nil {
+		t.Fatalf("NewReadOnlyKit() failed: %v", err)
+	}
+	defer kit.CloseWallet()
+	ctx := context.Background()
+
+	if !kit.IsReadOnly() {
+		t.Error("IsReadOnly() = false, expected true")
+	}
+	holder := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	if balance, err := kit.GetBalanceOf(ctx, holder); err != nil || balance.Sign() <= 0 {
+		t.Errorf("GetBalanceOf() = %v, %v", balance, err)
+	}
+	status, err := kit.GetNetworkStatus(ctx)
+	if err != nil {
+		t.Fatalf("GetNetworkStatus() failed: %v", err)
+	}
+	if status.ChainID.Int64() != 1 || status.BlockNumber != 100 || !status.BlockTime.Equal(blockTime) || status.Syncing || status.GasPrice.Int64() != 1e9 {
+		t.Errorf("GetNetworkStatus() = %+v", status)
+	}
+
+	if _, err := kit.SendTx(ctx, holder, 0, 21000, nil, big.NewInt(1), nil); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("SendTx() error = %v, want ErrReadOnly", err)
+	}
+	if _, err := kit.SignMessage(ctx, []byte("hello")); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("SignMessage() error = %v, want ErrReadOnly", err)
+	}
+	if _, err := kit.GetBalance(ctx); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("GetBalance() error = %v, want ErrReadOnly", err)
+	}
+	if n := server.callCount("eth_sendRawTransaction"); n != 0 {
+		t.Errorf("eth_sendRawTransaction called %d times, expected 0", n)
+	}
+}
@@ -0,0 +1,210 @@
+package etherkit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+//############ Log Fetcher ############
+
+// LogFetcher 默认参数
+const (
+	DefaultLogFetchConcurrency = 4    // 默认并发数
+	DefaultLogChunkSize        = 2000 // 默认每次查询的区块数
+)
+
+// LogFetcher 并发查询大区块范围内的事件日志
+// 将区块范围切分为多个分段并发查询；节点返回结果过多、范围过大或超时时自动将分段对半拆分，
+// 并缩小后续分段的大小，最终按区块顺序合并结果
+type LogFetcher struct {
+	Concurrency  int    // 并发数（<= 0 使用 DefaultLogFetchConcurrency）
+	ChunkSize    uint64 // 初始分段大小（0 使用 DefaultLogChunkSize）
+	MinChunkSize uint64 // 分段最小值，分段已不能再拆分时返回错误（0 表示 1）
+	ep           EtherProvider
+}
+
+// NewLogFetcher 使用默认参数创建日志查询器
+func NewLogFetcher(ep EtherProvider) *LogFetcher {
+	return &LogFetcher{Concurrency: DefaultLogFetchConcurrency, ChunkSize: DefaultLogChunkSize, MinChunkSize: 1, ep: ep}
+}
+
+// logChunk 一个分段的查询结果
+type logChunk struct {
+	from uint64
+	logs []types.Log
+}
+
+// logRangeState 并发查询时共享的分段分配状态
+type logRangeState struct {
+	mu      sync.Mutex
+	next    uint64 // 下一个待分配的区块号
+	to      uint64 // 结束区块号（包含）
+	done    bool   // 是否已分配完毕
+	size    uint64 // 当前分段大小（遇到范围错误时缩小）
+	minSize uint64
+}
+
+// take 分配下一个分段
+func (s *logRangeState) take() (from, to uint64, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.done {
+		return 0, 0, false
+	}
+	from = s.next
+	to = from + s.size - 1
+	if to >= s.to || to < from {
+		to = s.to
+		s.done = true
+	} else {
+		s.next = to + 1
+	}
+	return from, to, true
+}
+
+// shrink 将后续分段缩小到不超过 size
+func (s *logRangeState) shrink(size uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if size < s.minSize {
+		size = s.minSize
+	}
+	if size < s.size {
+		s.size = size
+	}
+}
+
+// FetchLogs 并发查询 [from, to] 区块范围内的事件日志
+// 参数说明：
+//   - ctx: 上下文对象
+//   - query: 查询条件（地址和 topics，FromBlock/ToBlock/BlockHash 会被忽略）
+//   - from: 起始区块号（包含）
+//   - to: 结束区块号（包含）
+//
+// 返回：
+//   - []types.Log: 按区块顺序排列的事件日志
+//   - error: 如果任一分段查询失败（且无法再拆分）则返回错误
+//
+// 使用示例：
+//
+//	fetcher := NewLogFetcher(provider)
+//	fetcher.Concurrency = 8
+//	logs, err := fetcher.FetchLogs(ctx, ethereum.FilterQuery{
+//	    Addresses: []common.Address{usdc},
+//	    Topics:    [][]common.Hash{{transferTopic}},
+//	}, 17000000, 18000000)
+func (f *LogFetcher) FetchLogs(ctx context.Context, query ethereum.FilterQuery, from, to uint64) ([]types.Log, error) {
+	if from > to {
+		return nil, fmt.Errorf("invalid block range %d-%d", from, to)
+	}
+	concurrency := f.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultLogFetchConcurrency
+	}
+	state := &logRangeState{next: from, to: to, size: f.ChunkSize, minSize: f.MinChunkSize}
+	if state.size == 0 {
+		state.size = DefaultLogChunkSize
+	}
+	if state.minSize == 0 {
+		state.minSize = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		chunks   []logChunk
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				lo, hi, ok := state.take()
+				if !ok || ctx.Err() != nil {
+					return
+				}
+				result, err := f.fetchRange(ctx, query, lo, hi, state)
+				mu.Lock()
+				if err != nil {
+					if firstErr == nil {
+						firstErr = err
+						cancel()
+					}
+					mu.Unlock()
+					return
+				}
+				chunks = append(chunks, result...)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	sort.Slice(chunks, func(i, j int) bool { return chunks[i].from < chunks[j].from })
+	var logs []types.Log
+	for _, chunk := range chunks {
+		logs = append(logs, chunk.logs...)
+	}
+	return logs, nil
+}
+
+// fetchRange 查询一个分段，遇到范围错误时对半拆分后分别查询
+func (f *LogFetcher) fetchRange(ctx context.Context, query ethereum.FilterQuery, from, to uint64, state *logRangeState) ([]logChunk, error) {
+	q := query
+	q.BlockHash = nil
+	q.FromBlock = new(big.Int).SetUint64(from)
+	q.ToBlock = new(big.Int).SetUint64(to)
+	logs, err := f.ep.GetEthClient().FilterLogs(ctx, q)
+	if err == nil {
+		return []logChunk{{from: from, logs: logs}}, nil
+	}
+	size := to - from + 1
+	if ctx.Err() != nil || !isLogRangeError(err) || size <= state.minSize {
+		return nil, fmt.Errorf("failed to fetch logs for blocks %d-%d: %w", from, to, err)
+	}
+
+	half := size / 2
+	state.shrink(half)
+	left, err := f.fetchRange(ctx, query, from, from+half-1, state)
+	if err != nil {
+		return nil, err
+	}
+	right, err := f.fetchRange(ctx, query, from+half, to, state)
+	if err != nil {
+		return nil, err
+	}
+	return append(left, right...), nil
+}
+
+// isLogRangeError 判断 eth_getLogs 错误是否可以通过缩小区块范围解决
+func isLogRangeError(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var rpcErr interface{ ErrorCode() int }
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == -32005 {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	for _, s := range []string{"too many", "more than", "range", "limit exceeded", "response size", "timeout", "timed out"} {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// newMockLogServer 每 10 个区块返回一条日志，查询范围超过 maxRange 时返回结果过多的错误
+func newMockLogServer(t *testing.T, maxRange uint64) *mockRPCServer {
+	t.Helper()
+	return newMockRPCServer(t, map[string]mockRPCHandler{
+		"eth_getLogs": func(params []json.RawMessage) (interface{}, error) {
+			var filter struct {
+				FromBlock hexutil.Uint64 `json:"fromBlock"`
+				ToBlock   hexutil.Uint64 `json:"toBlock"`
+			}
+			if err := json.Unmarshal(params[0], &filter); err != nil {
+				return nil, err
+			}
+			if uint64(filter.ToBlock-filter.FromBlock)+1 > maxRange {
+				return nil, &mockRPCError{Code: -32005, Message: "query returned more than 10000 results"}
+			}
+			logs := []types.Log{}
+			for n := uint64(filter.FromBlock); n <= uint64(filter.ToBlock); n++ {
+				if n%10 == 0 {
+					logs = append(logs, types.Log{Address: common.HexToAddress("0x01"), Topics: []common.Hash{}, BlockNumber: n, Data: []byte{}})
+				}
+			}
+			return logs, nil
+		},
+	})
+}
+
+func TestLogFetcher(t *testing.T) {
+	server := newMockLogServer(t, 150)
+	provider, err := NewProvider(server.URL)
+	if err != nil {
+		t.Fatalf("NewProvider() failed: %v", err)
+	}
+	defer provider.Close()
+
+	fetcher := NewLogFetcher(provider)
+	fetcher.ChunkSize = 1000
+	logs, err := fetcher.FetchLogs(context.Background(), ethereum.FilterQuery{}, 1, 2000)
+	if err != nil {
+		t.Fatalf("FetchLogs() failed: %v", err)
+	}
+	if len(logs) != 200 {
+		t.Fatalf("len(logs) = %d, expected 200", len(logs))
+	}
+	for i, log := range logs {
+		if log.BlockNumber != uint64(i+1)*10 {
+			t.Fatalf("logs[%d].BlockNumber = %d, expected %d (results out of order)", i, log.BlockNumber, (i+1)*10)
+		}
+	}
+
+	// 缩小后的分段大小应用到后续分段，请求数远少于逐块查询
+	if calls := server.callCount("eth_getLogs"); calls > 60 {
+		t.Errorf("eth_getLogs count = %d, expected adaptive chunking to stay small", calls)
+	}
+}
+
+func TestLogFetcherErrors(t *testing.T) {
+	server := newMockLogServer(t, 0) // 任何范围都返回结果过多
+	provider, err := NewProvider(server.URL)
+	if err != nil {
+		t.Fatalf("NewProvider() failed: %v", err)
+	}
+	defer provider.Close()
+
+	fetcher := NewLogFetcher(provider)
+	fetcher.MinChunkSize = 8
+	if _, err := fetcher.FetchLogs(context.Background(), ethereum.FilterQuery{}, 1, 100); err == nil {
+		t.Error("Expected error when chunk cannot shrink further")
+	}
+	if _, err := fetcher.FetchLogs(context.Background(), ethereum.FilterQuery{}, 10, 1); err == nil {
+		t.Error("Expected error for inverted range")
+	}
+
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{errors.New("query returned more than 10000 results"), true},
+		{errors.New("block range too large"), true},
+		{context.DeadlineExceeded, true},
+		{errors.New("invalid params"), false},
+	}
+	for _, tt := range tests {
+		if got := isLogRangeError(tt.err); got != tt.want {
+			t.Errorf("isLogRangeError(%v) = %v, expected %v", tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package etherkit
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+//############ Log Query ############
+
+// maxLogTopics 日志最多包含的 topic 数量（事件签名 + 3 个 indexed 参数）
+const maxLogTopics = 4
+
+// LogQuery 事件日志查询条件构建器
+// 以链式调用的方式组合合约地址、事件和 indexed 参数过滤，最终通过 Build 生成 ethereum.FilterQuery
+// 构建过程中的错误会被记录下来，在 Build 时统一返回
+//
+// 使用示例：
+//
+//	query, err := etherkit.NewLogQuery().
+//	    Addresses(usdc, usdt).
+//	    Event("Transfer(address,address,uint256)").
+//	    AddressAt(2, alice, bob). // to 为 alice 或 bob
+//	    FromBlock(big.NewInt(19000000)).
+//	    Build()
+type LogQuery struct {
+	addresses []common.Address
+	topics    [][]common.Hash
+	fromBlock *big.Int
+	toBlock   *big.Int
+	blockHash *common.Hash
+	err       error
+}
+
+// NewLogQuery 创建空的事件日志查询条件（不限制合约、事件和区块范围）
+func NewLogQuery() *LogQuery {
+	return &LogQuery{}
+}
+
+// Addresses 追加要查询的合约地址（多个地址之间为"或"关系）
+func (q *LogQuery) Addresses(addresses ...common.Address) *LogQuery {
+	q.addresses = append(q.addresses, addresses...)
+	return q
+}
+
+// Event 追加要查询的事件（写入 topic 0，多次调用之间为"或"关系）
+// 参数说明：
+//   - event: 事件签名字符串（如 "Transfer(address,address,uint256)"）、abi.Event、*abi.Event 或事件 topic（common.Hash）
+func (q *LogQuery) Event(event interface{}) *LogQuery {
+	var topic common.Hash
+	switch e := event.(type) {
+	case string:
+		topic = common.HexToHash(GetEventTopic(e))
+	case abi.Event:
+		topic = e.ID
+	case *abi.Event:
+		if e == nil {
+			return q.fail(fmt.Errorf("nil event"))
+		}
+		topic = e.ID
+	case common.Hash:
+		topic = e
+	default:
+		return q.fail(fmt.Errorf("unsupported event type %T", event))
+	}
+	return q.TopicAt(0, topic)
+}
+
+// TopicAt 追加第 i 个 topic 的候选值（同一位置的多个值之间为"或"关系）
+// 参数说明：
+//   - i: topic 位置（0 为事件签名，1~3 依次对应事件的 indexed 参数）
+//   - values: 候选值（地址参数可使用 AddressAt）
+func (q *LogQuery) TopicAt(i int, values ...common.Hash) *LogQuery {
+	if i < 0 || i >= maxLogTopics {
+		return q.fail(fmt.Errorf("topic index %d out of range [0, %d)", i, maxLogTopics))
+	}
+	for len(q.topics) <= i {
+		q.topics = append(q.topics, nil)
+	}
+	q.topics[i] = append(q.topics[i], values...)
+	return q
+}
+
+// AddressAt 追加第 i 个 topic 的地址候选值（地址左侧补零为 32 字节）
+func (q *LogQuery) AddressAt(i int, addresses ...common.Address) *LogQuery {
+	values := make([]common.Hash, len(addresses))
+	for j, address := range addresses {
+		values[j] = common.BytesToHash(address.Bytes())
+	}
+	return q.TopicAt(i, values...)
+}
+
+// FromBlock 设置起始区块（nil 表示最新区块，可使用 BlockTag.BlockNumber() 传入区块标签）
+func (q *LogQuery) FromBlock(number *big.Int) *LogQuery {
+	q.fromBlock = number
+	return q
+}
+
+// ToBlock 设置结束区块（nil 表示最新区块，可使用 BlockTag.BlockNumber() 传入区块标签）
+func (q *LogQuery) ToBlock(number *big.Int) *LogQuery {
+	q.toBlock = number
+	return q
+}
+
+// BlockHash 只查询指定区块内的日志（不能与 FromBlock/ToBlock 同时使用）
+func (q *LogQuery) BlockHash(hash common.Hash) *LogQuery {
+	q.blockHash = &hash
+	return q
+}
+
+// Build 生成 ethereum.FilterQuery
+// 返回：
+//   - ethereum.FilterQuery: 查询条件，可直接用于 FilterLogs、LogFetcher 等
+//   - error: 如果构建过程中有无效参数，或同时指定了区块哈希和区块范围则返回错误
+func (q *LogQuery) Build() (ethereum.FilterQuery, error) {
+	if q.err != nil {
+		return ethereum.FilterQuery{}, q.err
+	}
+	if q.blockHash != nil && (q.fromBlock != nil || q.toBlock != nil) {
+		return ethereum.FilterQuery{}, fmt.Errorf("block hash cannot be combined with a block range")
+	}
+	query := ethereum.FilterQuery{
+		BlockHash: q.blockHash,
+		FromBlock: q.fromBlock,
+		ToBlock:   q.toBlock,
+		Addresses: slices.Clone(q.addresses),
+	}
+	for _, values := range q.topics {
+		query.Topics = append(query.Topics, slices.Clone(values))
+	}
+	return query, nil
+}
+
+// fail 记录第一个构建错误
+func (q *LogQuery) fail(err error) *LogQuery {
+	if q.err == nil {
+		q.err = err
+	}
+	return q
+}
+
+// QueryLogs 按 LogQuery 构建的条件查询事件日志
+// 参数说明：
+//   - ctx: 上下文对象
+//   - q: 查询条件
+//
+// 返回：
+//   - []types.Log: 事件日志列表
+//   - error: 如果查询条件无效或查询失败则返回错误
+func (k *Kit) QueryLogs(ctx context.Context, q *LogQuery) ([]types.Log, error) {
+	query, err := q.Build()
+	if err != nil {
+		return nil, err
+	}
+	return k.FilterLogsByQuery(ctx, query)
+}
@@ -0,0 +1,215 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestLogQueryBuild(t *testing.T) {
+	usdc := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	usdt := common.HexToAddress("0xdAC17F958D2ee523a2206206994597C13D831ec7")
+	alice := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	bob := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	transferTopic := erc20ABI.Events["Transfer"].ID
+	approvalTopic := erc20ABI.Events["Approval"].ID
+	blockHash := common.HexToHash("0x01")
+
+	tests := []struct {
+		name    string
+		query   *LogQuery
+		want    ethereum.FilterQuery
+		wantErr bool
+	}{
+		{
+			name:  "empty",
+			query: NewLogQuery(),
+			want:  ethereum.FilterQuery{},
+		},
+		{
+			name: "signature and abi event",
+			query: NewLogQuery().
+				Addresses(usdc, usdt).
+				Event("Transfer(address,address,uint256)").
+				Event(erc20ABI.Events["Approval"]).
+				FromBlock(big.NewInt(100)).
+				ToBlock(big.NewInt(200)),
+			want: ethereum.FilterQuery{
+				Addresses: []common.Address{usdc, usdt},
+				Topics:    [][]common.Hash{{transferTopic, approvalTopic}},
+				FromBlock: big.NewInt(100),
+				ToBlock:   big.NewInt(200),
+			},
+		},
+		{
+			// 跳过的 topic 位置为通配
+			name:  "wildcard gap",
+			query: NewLogQuery().Event(transferTopic).AddressAt(2, alice, bob),
+			want: ethereum.FilterQuery{
+				Topics: [][]common.Hash{{transferTopic}, nil, {common.BytesToHash(alice.Bytes()), common.BytesToHash(bob.Bytes())}},
+			},
+		},
+		{
+			name:  "block hash",
+			query: NewLogQuery().BlockHash(blockHash),
+			want:  ethereum.FilterQuery{BlockHash: &blockHash},
+		},
+		{
+			name:    "block hash with range",
+			query:   NewLogQuery().BlockHash(blockHash).FromBlock(big.NewInt(1)),
+			wantErr: true,
+		},
+		{
+			name:    "topic out of range",
+			query:   NewLogQuery().TopicAt(4, transferTopic),
+			wantErr: true,
+		},
+		{
+			name:    "unsupported event",
+			query:   NewLogQuery().Event(42),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.query.Build()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Build() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Build() = %+v, expected %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryLogs(t *testing.T) {
+	usdc := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	usdt := common.HexToAddress("0xdAC17F958D2ee523a2206206994597C13D831ec7")
+	transferTopic := erc20ABI.Events["Transfer"].ID
+
+	var filter struct {
+		Address []common.Address `json:"address"`
+		Topics  [][]common.Hash  `json:"topics"`
+	}
+	server := newMockSendServer(t)
+	server.handlers["eth_getLogs"] = func(params []json.RawMessage) (interface{}, error) {
+		if err := json.Unmarshal(params[0], &filter); err != nil {
+			return nil, err
+		}
+		return []types.Log{{Address: usdt, Topics: []common.Hash{transferTopic}, Data: []byte{}}}, nil
+	}
+	kit := newMockKit(t, server)
+
+	logs, err := kit.QueryLogs(context.Background(), NewLogQuery().Addresses(usdc, usdt).Event(transferTopic))
+	if err != nil {
+		t.Fatalf("QueryLogs() failed: %v", err)
+	}
+	if len(logs) != 1 || logs[0].Address != usdt {
+		t.Errorf("QueryLogs() = %v", logs)
+	}
+	// 多个地址原样发送给节点
+	if len(filter.Address) != 2 || len(filter.Topics) != 1 || filter.Topics[0][0] != transferTopic {
+		t.Errorf("eth_getLogs filter = %+v", filter)
+	}
+
+	if _, err := kit.QueryLogs(context.Background(), NewLogQuery().TopicAt(-1)); err == nil {
+		t.Error("Expected error for invalid query")
+	}
+}
+
+func TestFilterLogsMulti(t *testing.T) {
+	usdc := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	usdt := common.HexToAddress("0xdAC17F958D2ee523a2206206994597C13D831ec7")
+	alice := common.BytesToHash(common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266").Bytes())
+	bob := common.BytesToHash(common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8").Bytes())
+	transferTopic := erc20ABI.Events["Transfer"].ID
+	approvalTopic := erc20ABI.Events["Approval"].ID
+
+	var filter struct {
+		Address []common.Address `json:"address"`
+		Topics  [][]common.Hash  `json:"topics"`
+	}
+	server := newMockSendServer(t)
+	server.handlers["eth_getLogs"] = func(params []json.RawMessage) (interface{}, error) {
+		filter.Address, filter.Topics = nil, nil
+		if err := json.Unmarshal(params[0], &filter); err != nil {
+			return nil, err
+		}
+		return []types.Log{}, nil
+	}
+	kit := newMockKit(t, server)
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		call       func() error
+		wantAddrs  int
+		wantTopics [][]common.Hash
+		wantErr    bool
+	}{
+		{
+			name: "or topics",
+			call: func() error {
+				_, err := kit.FilterLogsMulti(ctx, []common.Address{usdc, usdt}, []common.Hash{transferTopic}, nil, nil, [][]common.Hash{nil, {alice, bob}})
+				return err
+			},
+			wantAddrs:  2,
+			wantTopics: [][]common.Hash{{transferTopic}, nil, {alice, bob}},
+		},
+		{
+			name: "event signatures",
+			call: func() error {
+				_, err := kit.FilterEventLogsMulti(ctx, []common.Address{usdc}, []string{"Transfer(address,address,uint256)", "Approval(address,address,uint256)"}, nil, nil, [][]common.Hash{{alice}})
+				return err
+			},
+			wantAddrs:  1,
+			wantTopics: [][]common.Hash{{transferTopic, approvalTopic}, {alice}},
+		},
+		{
+			// 不限制事件和 indexed 参数时不发送 topics
+			name: "addresses only",
+			call: func() error {
+				_, err := kit.FilterLogsMulti(ctx, []common.Address{usdc, usdt}, nil, nil, nil, nil)
+				return err
+			},
+			wantAddrs: 2,
+		},
+		{
+			name: "too many indexed topics",
+			call: func() error {
+				_, err := kit.FilterLogsMulti(ctx, nil, []common.Hash{transferTopic}, nil, nil, [][]common.Hash{{alice}, {bob}, {alice}, {bob}})
+				return err
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(filter.Address) != tt.wantAddrs {
+				t.Errorf("len(address) = %d, expected %d", len(filter.Address), tt.wantAddrs)
+			}
+			if !reflect.DeepEqual(filter.Topics, tt.wantTopics) {
+				t.Errorf("topics = %v, expected %v", filter.Topics, tt.wantTopics)
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package etherkit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//############ Lookalike Address Guard ############
+
+// 相似地址检测的默认参数
+const (
+	DefaultLookalikePrefixLen   = 4   // 比较的前缀长度（十六进制字符，不含 0x）
+	DefaultLookalikeSuffixLen   = 4   // 比较的后缀长度（十六进制字符）
+	DefaultCounterpartyBookSize = 256 // 记录的最近交易对手数量
+)
+
+// LookalikeAddressError 目标地址与已知交易对手地址相似（疑似地址投毒）
+type LookalikeAddressError struct {
+	Destination  common.Address // 本次交易的目标地址
+	Counterparty common.Address // 与之相似的已知交易对手地址
+}
+
+// Error 实现 error 接口
+func (e *LookalikeAddressError) Error() string {
+	return fmt.Sprintf("%s: %s resembles %s", ErrLookalikeAddress, e.Destination.Hex(), e.Counterparty.Hex())
+}
+
+// Unwrap 支持 errors.Is(err, ErrLookalikeAddress)
+func (e *LookalikeAddressError) Unwrap() error {
+	return ErrLookalikeAddress
+}
+
+// IsLookalikeAddress 判断两个不同地址是否前缀和后缀都相同（地址投毒常见手法）
+// 参数说明：
+//   - a, b: 要比较的地址
+//   - prefixLen: 比较的前缀长度（十六进制字符，不含 0x，必须大于 0）
+//   - suffixLen: 比较的后缀长度（十六进制字符，必须大于 0，且 prefixLen+suffixLen 小于 40）
+//
+// 返回：
+//   - bool: true 表示两个地址不同但首尾相同（长度参数无效时返回 false）
+func IsLookalikeAddress(a, b common.Address, prefixLen, suffixLen int) bool {
+	if a == b || !validLookalikeLens(prefixLen, suffixLen) {
+		return false
+	}
+	ha := strings.ToLower(a.Hex()[2:])
+	hb := strings.ToLower(b.Hex()[2:])
+	return ha[:prefixLen] == hb[:prefixLen] && ha[len(ha)-suffixLen:] == hb[len(hb)-suffixLen:]
+}
+
+// validLookalikeLens 判断前缀、后缀长度是否有效（都大于 0 且合计小于地址的 40 个十六进制字符）
+func validLookalikeLens(prefixLen, suffixLen int) bool {
+	return prefixLen > 0 && suffixLen > 0 && prefixLen+suffixLen < 2*common.AddressLength
+}
+
+// CounterpartyBook 最近交易对手地址簿（并发安全，超出容量时淘汰最早的地址）
+type CounterpartyBook struct {
+	mu    sync.RWMutex
+	size  int
+	order []common.Address
+	known map[common.Address]struct{}
+}
+
+// NewCounterpartyBook 创建交易对手地址簿
+// 参数说明：
+//   - size: 最多记录的地址数量（<= 0 时使用 DefaultCounterpartyBookSize）
+func NewCounterpartyBook(size int) *CounterpartyBook {
+	if size <= 0 {
+		size = DefaultCounterpartyBookSize
+	}
+	return &CounterpartyBook{size: size, known: make(map[common.Address]struct{})}
+}
+
+// Add 记录交易对手地址（已存在的地址会移动到最新位置）
+func (b *CounterpartyBook) Add(addresses ...common.Address) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, address := range addresses {
+		if _, ok := b.known[address]; ok {
+			b.remove(address)
+		}
+		b.order = append(b.order, address)
+		b.known[address] = struct{}{}
+		if len(b.order) > b.size {
+			delete(b.known, b.order[0])
+			b.order = b.order[1:]
+		}
+	}
+}
+
+// remove 从顺序列表中移除地址（调用方持有锁）
+func (b *CounterpartyBook) remove(address common.Address) {
+	for i, a := range b.order {
+		if a == address {
+			b.order = append(b.order[:i], b.order[i+1:]...)
+			break
+		}
+	}
+	delete(b.known, address)
+}
+
+// Contains 判断地址是否为已知交易对手
+func (b *CounterpartyBook) Contains(address common.Address) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	_, ok := b.known[address]
+	return ok
+}
+
+// Addresses 返回已记录的交易对手（从旧到新）
+func (b *CounterpartyBook) Addresses() []common.Address {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return append([]common.Address(nil), b.order...)
+}
+
+// FindLookalike 查找与目标地址相似的已知交易对手
+// 参数说明：
+//   - destination: 目标地址
+//   - prefixLen: 比较的前缀长度（要求同 IsLookalikeAddress）
+//   - suffixLen: 比较的后缀长度
+//
+// 返回：
+//   - common.Address: 相似的交易对手地址
+//   - bool: 是否找到（目标地址本身是已知交易对手或长度参数无效时返回 false）
+func (b *CounterpartyBook) FindLookalike(destination common.Address, prefixLen, suffixLen int) (common.Address, bool) {
+	if !validLookalikeLens(prefixLen, suffixLen) {
+		return common.Address{}, false
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if _, ok := b.known[destination]; ok {
+		return common.Address{}, false
+	}
+	for i := len(b.order) - 1; i >= 0; i-- {
+		if IsLookalikeAddress(destination, b.order[i], prefixLen, suffixLen) {
+			return b.order[i], true
+		}
+	}
+	return common.Address{}, false
+}
+
+// lookalikeOverrideKey 上下文中跳过相似地址检测的标记
+type lookalikeOverrideKey struct{}
+
+// AllowLookalikeAddress 返回跳过相似地址检测的上下文
+// 用于用户已确认目标地址无误、需要显式放行的场景
+func AllowLookalikeAddress(ctx context.Context) context.Context {
+	return context.WithValue(ctx, lookalikeOverrideKey{}, true)
+}
+
+// WithLookalikeGuard 启用地址投毒检测
+// 每次签名前将目标地址（以及 ERC20 transfer/approve 的接收地址）与最近的交易对手比较，
+// 发现首尾相同但中间不同的地址时返回 *LookalikeAddressError（可用 errors.Is(err, ErrLookalikeAddress) 判断），
+// 使用 AllowLookalikeAddress(ctx) 可以显式放行。交易成功广播后目标地址会被记录为交易对手
+// 参数说明：
+//   - book: 交易对手地址簿（nil 表示新建一个默认容量的地址簿，可用于预先导入常用地址）
+func WithLookalikeGuard(book *CounterpartyBook) KitOption {
+	return func(k *Kit) {
+		if book == nil {
+			book = NewCounterpartyBook(DefaultCounterpartyBookSize)
+		}
+		k.counterparties = book
+		k.confirmationHooks = append(k.confirmationHooks, func(ctx context.Context, review *TxReview) error {
+			if allowed, _ := ctx.Value(lookalikeOverrideKey{}).(bool); allowed {
+				return nil
+			}
+			for _, destination := range reviewDestinations(review) {
+				if similar, ok := book.FindLookalike(destination, DefaultLookalikePrefixLen, DefaultLookalikeSuffixLen); ok {
+					return &LookalikeAddressError{Destination: destination, Counterparty: similar}
+				}
+			}
+			return nil
+		})
+	}
+}
+
+// GetCounterparties 获取 Kit 记录的最近交易对手地址簿（未启用 WithLookalikeGuard 时为 nil）
+func (k *Kit) GetCounterparties() *CounterpartyBook {
+	return k.counterparties
+}
+
+// reviewDestinations 返回交易实际的资金去向（目标地址以及 ERC20 / ERC-777 调用中的接收地址）
+func reviewDestinations(review *TxReview) []common.Address {
+	var destinations []common.Address
+	if review.To != nil {
+		destinations = append(destinations, *review.To)
+	}
+	if review.Method != nil {
+		switch review.Method.Name {
+		case "transfer", "approve", "send":
+			if len(review.Args) > 0 {
+				if address, ok := review.Args[0].(common.Address); ok {
+					destinations = append(destinations, address)
+				}
+			}
+		case "transferFrom", "operatorSend":
+			if len(review.Args) > 1 {
+				if address, ok := review.Args[1].(common.Address); ok {
+					destinations = append(destinations, address)
+				}
+			}
+		}
+	}
+	return destinations
+}
@@ -0,0 +1,141 @@
+package etherkit
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestIsLookalikeAddress(t *testing.T) {
+	known := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	tests := []struct {
+		name string
+		addr common.Address
+		want bool
+	}{
+		{"same address", known, false},
+		{"same prefix and suffix", common.HexToAddress("0x70990000000000000000000000000000000079C8"), true},
+		{"same prefix only", common.HexToAddress("0x7099000000000000000000000000000000001234"), false},
+		{"same suffix only", common.HexToAddress("0x12340000000000000000000000000000000079C8"), false},
+		{"unrelated", common.HexToAddress("0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsLookalikeAddress(tt.addr, known, DefaultLookalikePrefixLen, DefaultLookalikeSuffixLen); got != tt.want {
+				t.Errorf("IsLookalikeAddress() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// 无效的长度参数不会越界，也不会把任意地址判为相似
+	similar := common.HexToAddress("0x70990000000000000000000000000000000079C8")
+	for _, lens := range [][2]int{{0, 0}, {0, 4}, {4, 0}, {-1, 4}, {41, 4}, {20, 20}} {
+		if IsLookalikeAddress(similar, known, lens[0], lens[1]) {
+			t.Errorf("IsLookalikeAddress(prefix %d, suffix %d) = true, want false", lens[0], lens[1])
+		}
+	}
+	book := NewCounterpartyBook(0)
+	book.Add(known)
+	if _, ok := book.FindLookalike(similar, 41, 4); ok {
+		t.Error("FindLookalike() with invalid lengths should not find a match")
+	}
+	if _, ok := book.FindLookalike(similar, 4, 4); !ok {
+		t.Error("FindLookalike() should find the similar counterparty")
+	}
+}
+
+func TestCounterpartyBook(t *testing.T) {
+	a := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	b := common.HexToAddress("0x0000000000000000000000000000000000000002")
+	c := common.HexToAddress("0x0000000000000000000000000000000000000003")
+
+	book := NewCounterpartyBook(2)
+	book.Add(a, b)
+	book.Add(a) // 重复添加会移动到最新位置
+	book.Add(c) // 超出容量淘汰最早的 b
+
+	if book.Contains(b) {
+		t.Error("oldest counterparty should be evicted")
+	}
+	got := book.Addresses()
+	if len(got) != 2 || got[0] != a || got[1] != c {
+		t.Errorf("Addresses() = %v, want [%s %s]", got, a.Hex(), c.Hex())
+	}
+}
+
+func TestLookalikeGuard(t *testing.T) {
+	known := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	poisoned := common.HexToAddress("0x70990000000000000000000000000000000079C8")
+	token := common.HexToAddress("0x1c7D4B196Cb0C7B01d743Fbc6116a902379C7238")
+
+	newGuardedKit := func(t *testing.T) *Kit {
+		book := NewCounterpartyBook(0)
+		book.Add(known)
+		return newMockKit(t, newMockSendServer(t), WithLookalikeGuard(book))
+	}
+
+	t.Run("blocks lookalike destination", func(t *testing.T) {
+		kit := newGuardedKit(t)
+		_, err := kit.SendTx(context.Background(), poisoned, 0, 21000, nil, big.NewInt(1), nil)
+		if !errors.Is(err, ErrLookalikeAddress) || !errors.Is(err, ErrTxRejected) {
+			t.Fatalf("SendTx() error = %v, want ErrLookalikeAddress", err)
+		}
+		var lookalikeErr *LookalikeAddressError
+		if !errors.As(err, &lookalikeErr) || lookalikeErr.Counterparty != known || lookalikeErr.Destination != poisoned {
+			t.Errorf("unexpected lookalike error: %v", err)
+		}
+	})
+
+	t.Run("blocks lookalike token recipient", func(t *testing.T) {
+		kit := newGuardedKit(t)
+		data, _ := erc20ABI.Pack("transfer", poisoned, big.NewInt(1000))
+		if _, err := kit.SendTx(context.Background(), token, 0, 0, nil, nil, data); !errors.Is(err, ErrLookalikeAddress) {
+			t.Fatalf("SendTx() error = %v, want ErrLookalikeAddress", err)
+		}
+	})
+
+	t.Run("blocks lookalike safe transaction", func(t *testing.T) {
+		kit := newGuardedKit(t)
+		safe := &Safe{Address: common.HexToAddress("0x5afe"), ChainID: big.NewInt(1)}
+		pending, _ := NewPendingSafeTx(safe, &SafeTx{To: poisoned, Value: big.NewInt(1), Nonce: big.NewInt(0)})
+		if err := kit.SignPendingSafeTx(context.Background(), pending); !errors.Is(err, ErrLookalikeAddress) {
+			t.Fatalf("SignPendingSafeTx() error = %v, want ErrLookalikeAddress", err)
+		}
+		if len(pending.Signatures) != 0 {
+			t.Error("rejected safe transaction must not be signed")
+		}
+	})
+
+	t.Run("blocks lookalike user operation", func(t *testing.T) {
+		kit := newGuardedKit(t)
+		callData, _ := simpleAccountAbi.Pack("execute", poisoned, big.NewInt(1), []byte{})
+		op := &UserOperation{Sender: common.HexToAddress("0xacc0"), Nonce: big.NewInt(0), CallData: callData}
+		if err := kit.SignUserOperation(context.Background(), op, common.HexToAddress(EntryPointV06Address)); !errors.Is(err, ErrLookalikeAddress) {
+			t.Fatalf("SignUserOperation() error = %v, want ErrLookalikeAddress", err)
+		}
+		if len(op.Signature) != 0 {
+			t.Error("rejected user operation must not be signed")
+		}
+	})
+
+	t.Run("allows known counterparty", func(t *testing.T) {
+		kit := newGuardedKit(t)
+		if _, err := kit.SendTx(context.Background(), known, 0, 21000, nil, big.NewInt(1), nil); err != nil {
+			t.Fatalf("SendTx() failed: %v", err)
+		}
+	})
+
+	t.Run("explicit override records counterparty", func(t *testing.T) {
+		kit := newGuardedKit(t)
+		ctx := AllowLookalikeAddress(context.Background())
+		if _, err := kit.SendTx(ctx, poisoned, 0, 21000, nil, big.NewInt(1), nil); err != nil {
+			t.Fatalf("SendTx() with override failed: %v", err)
+		}
+		if !kit.GetCounterparties().Contains(poisoned) {
+			t.Error("destination should be recorded after a successful send")
+		}
+	})
+}
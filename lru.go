@@ -0,0 +1,77 @@
+package etherkit
+
+import (
+	"container/list"
+	"sync"
+)
+
+//############ LRU Cache ############
+
+// lruCache 并发安全的定长 LRU 缓存
+type lruCache[K comparable, V any] struct {
+	mu     sync.Mutex
+	size   int
+	ll     *list.List
+	items  map[K]*list.Element
+	hits   uint64
+	misses uint64
+}
+
+// lruEntry LRU 缓存中的条目
+type lruEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// newLRUCache 创建最多保存 size 个条目的 LRU 缓存（size <= 0 时不缓存任何条目）
+func newLRUCache[K comparable, V any](size int) *lruCache[K, V] {
+	return &lruCache[K, V]{size: size, ll: list.New(), items: make(map[K]*list.Element)}
+}
+
+// Get 获取缓存的值，并将其标记为最近使用
+func (c *lruCache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		c.ll.MoveToFront(el)
+		c.hits++
+		return el.Value.(*lruEntry[K, V]).value, true
+	}
+	c.misses++
+	var zero V
+	return zero, false
+}
+
+// Add 添加（或更新）缓存条目，超出容量时淘汰最久未使用的条目
+func (c *lruCache[K, V]) Add(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.size <= 0 {
+		return
+	}
+	if el, ok := c.items[key]; ok {
+		c.ll.MoveToFront(el)
+		el.Value.(*lruEntry[K, V]).value = value
+		return
+	}
+	c.items[key] = c.ll.PushFront(&lruEntry[K, V]{key: key, value: value})
+	for c.ll.Len() > c.size {
+		oldest := c.ll.Back()
+		c.ll.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruEntry[K, V]).key)
+	}
+}
+
+// Len 返回缓存的条目数量
+func (c *lruCache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ll.Len()
+}
+
+// stats 返回命中和未命中次数
+func (c *lruCache[K, V]) stats() (hits, misses uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits, c.misses
+}
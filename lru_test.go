@@ -0,0 +1,34 @@
+package etherkit
+
+import "testing"
+
+func TestLRUCache(t *testing.T) {
+	cache := newLRUCache[string, int](2)
+	cache.Add("a", 1)
+	cache.Add("b", 2)
+
+	// 访问 a 后，b 成为最久未使用的条目
+	if v, ok := cache.Get("a"); !ok || v != 1 {
+		t.Errorf("Get(a) = %d, %v", v, ok)
+	}
+	cache.Add("c", 3)
+	if _, ok := cache.Get("b"); ok {
+		t.Error("b should have been evicted")
+	}
+	if v, ok := cache.Get("c"); !ok || v != 3 {
+		t.Errorf("Get(c) = %d, %v", v, ok)
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Len() = %d, expected 2", cache.Len())
+	}
+	if hits, misses := cache.stats(); hits != 2 || misses != 1 {
+		t.Errorf("stats() = %d/%d, expected 2/1", hits, misses)
+	}
+
+	// 容量为 0 时不缓存
+	disabled := newLRUCache[string, int](0)
+	disabled.Add("a", 1)
+	if _, ok := disabled.Get("a"); ok {
+		t.Error("disabled cache should not store entries")
+	}
+}
@@ -0,0 +1,225 @@
+package etherkit
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	hdwallet "github.com/miguelmota/go-ethereum-hdwallet"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/scrypt"
+)
+
+//############ Encrypted Mnemonic ############
+
+// MnemonicKDF 加密助记词时使用的密钥派生算法
+type MnemonicKDF string
+
+const (
+	KDFScrypt   MnemonicKDF = "scrypt"   // scrypt（与 keystore 文件相同）
+	KDFArgon2id MnemonicKDF = "argon2id" // Argon2id（RFC 9106 推荐）
+)
+
+const (
+	encryptedMnemonicVersion = 1
+	encryptedMnemonicCipher  = "aes-256-gcm"
+	mnemonicKeyLen           = 32
+	mnemonicSaltLen          = 32
+)
+
+// encryptedMnemonicFile 加密助记词文件格式（JSON）
+type encryptedMnemonicFile struct {
+	Version    int               `json:"version"`
+	KDF        MnemonicKDF       `json:"kdf"`
+	KDFParams  mnemonicKDFParams `json:"kdfparams"`
+	Cipher     string            `json:"cipher"`
+	Nonce      hexutil.Bytes     `json:"nonce"`
+	Ciphertext hexutil.Bytes     `json:"ciphertext"`
+}
+
+// mnemonicKDFParams 密钥派生参数（scrypt 使用 N/R/P，argon2id 使用 Time/Memory/Threads）
+type mnemonicKDFParams struct {
+	Salt    hexutil.Bytes `json:"salt"`
+	N       int           `json:"n,omitempty"`
+	R       int           `json:"r,omitempty"`
+	P       int           `json:"p,omitempty"`
+	Time    uint32        `json:"time,omitempty"`
+	Memory  uint32        `json:"memory,omitempty"` // KiB
+	Threads uint8         `json:"threads,omitempty"`
+}
+
+// defaultMnemonicKDFParams 返回默认派生参数（light 为 true 时使用轻量参数）
+func defaultMnemonicKDFParams(kdf MnemonicKDF, light bool) (mnemonicKDFParams, error) {
+	switch kdf {
+	case KDFScrypt:
+		n, p := keystoreParams(light)
+		return mnemonicKDFParams{N: n, R: 8, P: p}, nil
+	case KDFArgon2id:
+		if light {
+			return mnemonicKDFParams{Time: 1, Memory: 16 * 1024, Threads: 4}, nil
+		}
+		return mnemonicKDFParams{Time: 3, Memory: 64 * 1024, Threads: 4}, nil
+	default:
+		return mnemonicKDFParams{}, fmt.Errorf("unsupported mnemonic KDF %q", kdf)
+	}
+}
+
+// deriveMnemonicKey 根据派生参数从密码派生 AES 密钥
+func deriveMnemonicKey(kdf MnemonicKDF, params mnemonicKDFParams, passphrase string) ([]byte, error) {
+	switch kdf {
+	case KDFScrypt:
+		// 限制 N 和 r，避免被篡改的文件耗尽内存（128*N*r 字节，上限 4 GiB）
+		if params.N <= 0 || params.R <= 0 || params.N > 1<<22 || params.R > 8 {
+			return nil, fmt.Errorf("invalid scrypt parameters")
+		}
+		return scrypt.Key([]byte(passphrase), params.Salt, params.N, params.R, params.P, mnemonicKeyLen)
+	case KDFArgon2id:
+		// 同样限制内存参数（上限 4 GiB）
+		if params.Time == 0 || params.Threads == 0 || params.Memory == 0 || params.Memory > 4*1024*1024 {
+			return nil, fmt.Errorf("invalid argon2id parameters")
+		}
+		return argon2.IDKey([]byte(passphrase), params.Salt, params.Time, params.Memory, params.Threads, mnemonicKeyLen), nil
+	default:
+		return nil, fmt.Errorf("unsupported mnemonic KDF %q", kdf)
+	}
+}
+
+// newMnemonicAEAD 创建 AES-256-GCM 实例
+func newMnemonicAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// EncryptMnemonic 使用密码加密助记词（scrypt 或 argon2id 派生密钥 + AES-256-GCM），得到可移植的 JSON 格式
+// 参数说明：
+//   - mnemonic: BIP-39 助记词（加密前校验有效性）
+//   - passphrase: 加密密码
+//   - kdf: 密钥派生算法（KDFScrypt 或 KDFArgon2id）
+//   - light: 是否使用轻量派生参数（更快但更容易被暴力破解，仅建议用于测试）
+//
+// 返回：
+//   - []byte: 加密后的 JSON
+//   - error: 如果助记词无效或加密失败则返回错误
+//
+// 使用示例：
+//
+//	data, _ := EncryptMnemonic(mnemonic, passphrase, KDFArgon2id, false)
+//	mnemonic, err := DecryptMnemonic(data, passphrase)
+func EncryptMnemonic(mnemonic, passphrase string, kdf MnemonicKDF, light bool) ([]byte, error) {
+	mnemonic = strings.Join(strings.Fields(mnemonic), " ")
+	if _, err := hdwallet.NewSeedFromMnemonic(mnemonic); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidMnemonic, err)
+	}
+	params, err := defaultMnemonicKDFParams(kdf, light)
+	if err != nil {
+		return nil, err
+	}
+	params.Salt = make([]byte, mnemonicSaltLen)
+	if _, err := rand.Read(params.Salt); err != nil {
+		return nil, err
+	}
+	key, err := deriveMnemonicKey(kdf, params, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newMnemonicAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	file := encryptedMnemonicFile{
+		Version:   encryptedMnemonicVersion,
+		KDF:       kdf,
+		KDFParams: params,
+		Cipher:    encryptedMnemonicCipher,
+		Nonce:     nonce,
+	}
+	file.Ciphertext = aead.Seal(nil, nonce, []byte(mnemonic), file.additionalData())
+	return json.MarshalIndent(file, "", "  ")
+}
+
+// additionalData 返回 GCM 的附加认证数据（将算法和参数与密文绑定，防止被篡改）
+func (f *encryptedMnemonicFile) additionalData() []byte {
+	header := *f
+	header.Nonce, header.Ciphertext = nil, nil
+	data, _ := json.Marshal(header)
+	return data
+}
+
+// DecryptMnemonic 解密 EncryptMnemonic 生成的 JSON 得到助记词
+// 参数说明：
+//   - data: 加密后的 JSON
+//   - passphrase: 加密密码
+//
+// 返回：
+//   - string: 助记词
+//   - error: 如果格式无效或密码错误则返回 ErrInvalidKeyFormat
+func DecryptMnemonic(data []byte, passphrase string) (string, error) {
+	var file encryptedMnemonicFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidKeyFormat, err)
+	}
+	if file.Version != encryptedMnemonicVersion || file.Cipher != encryptedMnemonicCipher {
+		return "", fmt.Errorf("%w: unsupported encrypted mnemonic version %d cipher %q", ErrInvalidKeyFormat, file.Version, file.Cipher)
+	}
+	key, err := deriveMnemonicKey(file.KDF, file.KDFParams, passphrase)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidKeyFormat, err)
+	}
+	aead, err := newMnemonicAEAD(key)
+	if err != nil {
+		return "", err
+	}
+	if len(file.Nonce) != aead.NonceSize() {
+		return "", fmt.Errorf("%w: invalid nonce length %d", ErrInvalidKeyFormat, len(file.Nonce))
+	}
+	plaintext, err := aead.Open(nil, file.Nonce, file.Ciphertext, file.additionalData())
+	if err != nil {
+		return "", fmt.Errorf("%w: could not decrypt mnemonic with given passphrase", ErrInvalidKeyFormat)
+	}
+	return string(plaintext), nil
+}
+
+// SaveEncryptedMnemonic 加密助记词并写入文件（权限 0600）
+// 参数说明：
+//   - path: 文件路径
+//   - mnemonic: BIP-39 助记词
+//   - passphrase: 加密密码
+//   - kdf: 密钥派生算法
+//
+// 返回：
+//   - error: 如果助记词无效、加密或写入失败则返回错误
+func SaveEncryptedMnemonic(path, mnemonic, passphrase string, kdf MnemonicKDF) error {
+	data, err := EncryptMnemonic(mnemonic, passphrase, kdf, false)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// LoadEncryptedMnemonic 读取并解密 SaveEncryptedMnemonic 写入的文件
+// 参数说明：
+//   - path: 文件路径
+//   - passphrase: 加密密码
+//
+// 返回：
+//   - string: 助记词（可用于 BuildPrivateKeyFromMnemonicAndAccountId、ExportExtendedKeys 等）
+//   - error: 如果读取失败、格式无效或密码错误则返回错误
+func LoadEncryptedMnemonic(path, passphrase string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return DecryptMnemonic(data, passphrase)
+}
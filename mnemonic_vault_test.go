@@ -0,0 +1,82 @@
+package etherkit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestEncryptMnemonic(t *testing.T) {
+	tests := []struct {
+		name     string
+		mnemonic string
+		kdf      MnemonicKDF
+		wantErr  error
+	}{
+		{"scrypt", TestMnemonic, KDFScrypt, nil},
+		{"argon2id", TestMnemonic, KDFArgon2id, nil},
+		{"extra whitespace", "  test test test test test test test test test test test   junk ", KDFScrypt, nil},
+		{"invalid mnemonic", "test test test", KDFScrypt, ErrInvalidMnemonic},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := EncryptMnemonic(tt.mnemonic, "secret", tt.kdf, true)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("EncryptMnemonic() error = %v, expected %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if bytes.Contains(data, []byte("junk")) {
+				t.Error("EncryptMnemonic() output contains plaintext")
+			}
+			got, err := DecryptMnemonic(data, "secret")
+			if err != nil {
+				t.Fatalf("DecryptMnemonic() failed: %v", err)
+			}
+			if got != TestMnemonic {
+				t.Errorf("DecryptMnemonic() = %q, expected %q", got, TestMnemonic)
+			}
+			if _, err := DecryptMnemonic(data, "wrong"); !errors.Is(err, ErrInvalidKeyFormat) {
+				t.Errorf("DecryptMnemonic() with wrong passphrase error = %v, expected ErrInvalidKeyFormat", err)
+			}
+		})
+	}
+}
+
+func TestDecryptMnemonicTampered(t *testing.T) {
+	data, err := EncryptMnemonic(TestMnemonic, "secret", KDFScrypt, true)
+	if err != nil {
+		t.Fatalf("EncryptMnemonic() failed: %v", err)
+	}
+
+	// 篡改派生参数（降低强度）会导致认证失败
+	var file map[string]any
+	_ = json.Unmarshal(data, &file)
+	file["kdfparams"].(map[string]any)["p"] = 1
+	tampered, _ := json.Marshal(file)
+	if _, err := DecryptMnemonic(tampered, "secret"); !errors.Is(err, ErrInvalidKeyFormat) {
+		t.Errorf("DecryptMnemonic() error = %v, expected ErrInvalidKeyFormat", err)
+	}
+
+	if _, err := DecryptMnemonic([]byte("not json"), "secret"); !errors.Is(err, ErrInvalidKeyFormat) {
+		t.Errorf("DecryptMnemonic() error = %v, expected ErrInvalidKeyFormat", err)
+	}
+}
+
+func TestSaveEncryptedMnemonic(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mnemonic.json")
+	if err := SaveEncryptedMnemonic(path, TestMnemonic, "secret", KDFArgon2id); err != nil {
+		t.Fatalf("SaveEncryptedMnemonic() failed: %v", err)
+	}
+	got, err := LoadEncryptedMnemonic(path, "secret")
+	if err != nil {
+		t.Fatalf("LoadEncryptedMnemonic() failed: %v", err)
+	}
+	if got != TestMnemonic {
+		t.Errorf("LoadEncryptedMnemonic() = %q, expected %q", got, TestMnemonic)
+	}
+}
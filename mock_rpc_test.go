@@ -0,0 +1,199 @@
+package etherkit
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/guanzhenxing/go-evm-kit/contracts/erc20"
+)
+
+// mockRPCHandler 模拟单个 RPC 方法的处理函数
+type mockRPCHandler func(params []json.RawMessage) (interface{}, error)
+
+// mockRPCError 模拟节点返回的 JSON-RPC 错误
+type mockRPCError struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+func (e *mockRPCError) Error() string { return e.Message }
+
+// mockRPCServer 测试用的 JSON-RPC 服务（支持批量请求）
+type mockRPCServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	handlers map[string]mockRPCHandler
+	calls    map[string]int
+}
+
+type mockRPCRequest struct {
+	ID     json.RawMessage   `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+type mockRPCResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *mockRPCError   `json:"error,omitempty"`
+}
+
+// newMockRPCServer 创建测试用的 JSON-RPC 服务，未注册的方法返回 method not found
+func newMockRPCServer(t *testing.T, handlers map[string]mockRPCHandler) *mockRPCServer {
+	t.Helper()
+	m := &mockRPCServer{handlers: handlers, calls: map[string]int{}}
+	m.Server = httptest.NewServer(http.HandlerFunc(m.serveHTTP))
+	t.Cleanup(m.Close)
+	return m
+}
+
+// callCount 返回指定方法被调用的次数
+func (m *mockRPCServer) callCount(method string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.calls[method]
+}
+
+func (m *mockRPCServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if len(body) > 0 && body[0] == '[' {
+		var reqs []mockRPCRequest
+		if err := json.Unmarshal(body, &reqs); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		resps := make([]mockRPCResponse, len(reqs))
+		for i, req := range reqs {
+			resps[i] = m.handle(req)
+		}
+		_ = json.NewEncoder(w).Encode(resps)
+		return
+	}
+
+	var req mockRPCRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(m.handle(req))
+}
+
+func (m *mockRPCServer) handle(req mockRPCRequest) mockRPCResponse {
+	m.mu.Lock()
+	m.calls[req.Method]++
+	handler, ok := m.handlers[req.Method]
+	m.mu.Unlock()
+
+	resp := mockRPCResponse{JSONRPC: "2.0", ID: req.ID}
+	if !ok {
+		resp.Error = &mockRPCError{Code: -32601, Message: "the method " + req.Method + " does not exist/is not available"}
+		return resp
+	}
+	result, err := handler(req.Params)
+	if err != nil {
+		if rpcErr, ok := err.(*mockRPCError); ok {
+			resp.Error = rpcErr
+		} else {
+			resp.Error = &mockRPCError{Code: -32000, Message: err.Error()}
+		}
+		return resp
+	}
+	if result == nil {
+		result = json.RawMessage("null")
+	}
+	resp.Result = result
+	return resp
+}
+
+// mockResult 返回固定结果的处理函数
+func mockResult(result interface{}) mockRPCHandler {
+	return func(params []json.RawMessage) (interface{}, error) {
+		return result, nil
+	}
+}
+
+// mockCallArg eth_call / eth_estimateGas 的调用参数
+type mockCallArg struct {
+	From  string        `json:"from"`
+	To    string        `json:"to"`
+	Input hexutil.Bytes `json:"input"`
+	Data  hexutil.Bytes `json:"data"`
+	Value *hexutil.Big  `json:"value"`
+}
+
+// calldata 返回调用数据（兼容 input 和 data 两种字段）
+func (a mockCallArg) calldata() []byte {
+	if len(a.Input) > 0 {
+		return a.Input
+	}
+	return a.Data
+}
+
+// parseMockCallArg 解析第一个参数为调用对象
+func parseMockCallArg(params []json.RawMessage) (mockCallArg, error) {
+	var arg mockCallArg
+	if len(params) == 0 {
+		return arg, errors.New("missing call argument")
+	}
+	err := json.Unmarshal(params[0], &arg)
+	return arg, err
+}
+
+// packOutputs 将返回值编码为 eth_call 的结果
+func packOutputs(outputs abi.Arguments, values ...interface{}) (interface{}, error) {
+	out, err := outputs.Pack(values...)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(out), nil
+}
+
+// mockERC20Call 模拟 ERC20 合约的 eth_call（根据方法选择器返回 balanceOf / decimals / symbol）
+func mockERC20Call(balance *big.Int, decimals uint8, symbol string) mockRPCHandler {
+	return func(params []json.RawMessage) (interface{}, error) {
+		arg, err := parseMockCallArg(params)
+		if err != nil {
+			return nil, err
+		}
+		parsed, _ := GetABI(erc20.IERC20MetaData.ABI)
+		data := arg.calldata()
+		if len(data) < 4 {
+			return nil, errors.New("empty calldata")
+		}
+		method, err := parsed.MethodById(data[:4])
+		if err != nil {
+			return nil, err
+		}
+		var out []byte
+		switch method.Name {
+		case "balanceOf":
+			out, err = method.Outputs.Pack(balance)
+		case "decimals":
+			out, err = method.Outputs.Pack(decimals)
+		case "symbol", "name":
+			out, err = method.Outputs.Pack(symbol)
+		default:
+			return nil, errors.New("unsupported method " + method.Name)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return hexutil.Bytes(out), nil
+	}
+}
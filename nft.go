@@ -0,0 +1,337 @@
+package etherkit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//############ NFT Extensions ############
+
+// ERC-165 接口 ID
+var (
+	InterfaceIDERC165             = [4]byte{0x01, 0xff, 0xc9, 0xa7}
+	InterfaceIDERC721             = [4]byte{0x80, 0xac, 0x58, 0xcd}
+	InterfaceIDERC721Metadata     = [4]byte{0x5b, 0x5e, 0x13, 0x9f}
+	InterfaceIDERC721Enumerable   = [4]byte{0x78, 0x0e, 0x9d, 0x63}
+	InterfaceIDERC1155            = [4]byte{0xd9, 0xb6, 0x7a, 0x26}
+	InterfaceIDERC1155MetadataURI = [4]byte{0x0e, 0x89, 0x34, 0x1c}
+	InterfaceIDERC2981            = [4]byte{0x2a, 0x55, 0x20, 0x5a}
+)
+
+// nftABI NFT 标准及常见扩展中用到的只读方法
+const nftABI = `[
+{"inputs":[{"internalType":"bytes4","name":"interfaceId","type":"bytes4"}],"name":"supportsInterface","outputs":[{"internalType":"bool","name":"","type":"bool"}],"stateMutability":"view","type":"function"},
+{"inputs":[{"internalType":"uint256","name":"tokenId","type":"uint256"},{"internalType":"uint256","name":"salePrice","type":"uint256"}],"name":"royaltyInfo","outputs":[{"internalType":"address","name":"receiver","type":"address"},{"internalType":"uint256","name":"royaltyAmount","type":"uint256"}],"stateMutability":"view","type":"function"},
+{"inputs":[],"name":"contractURI","outputs":[{"internalType":"string","name":"","type":"string"}],"stateMutability":"view","type":"function"},
+{"inputs":[],"name":"name","outputs":[{"internalType":"string","name":"","type":"string"}],"stateMutability":"view","type":"function"},
+{"inputs":[],"name":"symbol","outputs":[{"internalType":"string","name":"","type":"string"}],"stateMutability":"view","type":"function"},
+{"inputs":[{"internalType":"uint256","name":"tokenId","type":"uint256"}],"name":"tokenURI","outputs":[{"internalType":"string","name":"","type":"string"}],"stateMutability":"view","type":"function"},
+{"inputs":[{"internalType":"uint256","name":"id","type":"uint256"}],"name":"uri","outputs":[{"internalType":"string","name":"","type":"string"}],"stateMutability":"view","type":"function"},
+{"inputs":[],"name":"totalSupply","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+{"inputs":[{"internalType":"uint256","name":"index","type":"uint256"}],"name":"tokenByIndex","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+{"inputs":[{"internalType":"address","name":"owner","type":"address"},{"internalType":"uint256","name":"index","type":"uint256"}],"name":"tokenOfOwnerByIndex","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+{"inputs":[{"internalType":"address","name":"owner","type":"address"}],"name":"balanceOf","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"}
+]`
+
+// nftParsed 解析后的 NFT ABI
+var nftParsed, _ = GetABI(nftABI)
+
+// callNFT 调用 NFT 合约的只读方法
+func callNFT(ctx context.Context, ep EtherProvider, nft common.Address, method string, args ...interface{}) ([]interface{}, error) {
+	data, err := nftParsed.Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+	ret, err := providerExt[BlockRefReader](ep).CallContractAt(ctx, ethereum.CallMsg{To: &nft, Data: data}, BlockRef{})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrContractCall, method, err)
+	}
+	return nftParsed.Unpack(method, ret)
+}
+
+// callSupportsInterface 调用 supportsInterface，回滚或返回数据无效时视为不支持
+func callSupportsInterface(ctx context.Context, ep EtherProvider, contract common.Address, interfaceID [4]byte) (bool, error) {
+	out, err := callNFT(ctx, ep, contract, "supportsInterface", interfaceID)
+	if err != nil {
+		var revert *RevertError
+		if errors.As(err, &revert) || !errors.Is(err, ErrContractCall) {
+			// 合约回滚、没有代码（返回空数据无法解码）时视为不支持
+			return false, nil
+		}
+		return false, err
+	}
+	return out[0].(bool), nil
+}
+
+// SupportsInterface 按 ERC-165 规定的检测流程判断合约是否支持指定接口
+// 先确认合约实现了 ERC-165（supportsInterface(0x01ffc9a7) 为 true 且 supportsInterface(0xffffffff) 为 false），再查询目标接口
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - contract: 合约地址
+//   - interfaceID: 接口 ID（如 InterfaceIDERC2981）
+//
+// 返回：
+//   - bool: 是否支持（没有实现 ERC-165 的合约和普通地址返回 false）
+//   - error: 如果查询失败（如网络错误）则返回错误
+func SupportsInterface(ctx context.Context, ep EtherProvider, contract common.Address, interfaceID [4]byte) (bool, error) {
+	ok, err := callSupportsInterface(ctx, ep, contract, InterfaceIDERC165)
+	if err != nil || !ok {
+		return false, err
+	}
+	invalid, err := callSupportsInterface(ctx, ep, contract, [4]byte{0xff, 0xff, 0xff, 0xff})
+	if err != nil || invalid {
+		return false, err
+	}
+	if interfaceID == InterfaceIDERC165 {
+		return true, nil
+	}
+	return callSupportsInterface(ctx, ep, contract, interfaceID)
+}
+
+// requireInterface 合约不支持接口时返回包装了 ErrUnsupportedInterface 的错误
+func requireInterface(ctx context.Context, ep EtherProvider, contract common.Address, interfaceID [4]byte, name string) error {
+	ok, err := SupportsInterface(ctx, ep, contract, interfaceID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%w: %s does not support %s", ErrUnsupportedInterface, contract.Hex(), name)
+	}
+	return nil
+}
+
+// RoyaltyInfo 查询 ERC-2981 版税信息（先通过 ERC-165 确认合约支持 ERC-2981）
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - nft: NFT 合约地址
+//   - tokenId: 代币 ID
+//   - salePrice: 成交价格（任意计价单位，版税按同一单位返回）
+//
+// 返回：
+//   - common.Address: 版税接收地址
+//   - *big.Int: 版税金额
+//   - error: 如果合约不支持 ERC-2981（ErrUnsupportedInterface）或查询失败则返回错误
+func RoyaltyInfo(ctx context.Context, ep EtherProvider, nft common.Address, tokenId, salePrice *big.Int) (common.Address, *big.Int, error) {
+	if err := requireInterface(ctx, ep, nft, InterfaceIDERC2981, "ERC-2981"); err != nil {
+		return common.Address{}, nil, err
+	}
+	out, err := callNFT(ctx, ep, nft, "royaltyInfo", tokenId, salePrice)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	return out[0].(common.Address), out[1].(*big.Int), nil
+}
+
+// GetContractURI 查询合约级元数据 URI（contractURI，OpenSea 等市场用于展示集合信息）
+// contractURI 没有 ERC-165 接口 ID，合约没有实现时返回 ErrContractCall
+func GetContractURI(ctx context.Context, ep EtherProvider, nft common.Address) (string, error) {
+	out, err := callNFT(ctx, ep, nft, "contractURI")
+	if err != nil {
+		return "", err
+	}
+	return out[0].(string), nil
+}
+
+// GetTokenURI 查询单个 NFT 的元数据 URI
+// ERC-721 使用 tokenURI（需要支持 ERC721Metadata）；ERC-1155 使用 uri（需要支持 ERC1155MetadataURI），
+// 并按标准将 URI 中的 {id} 替换为 64 位小写十六进制的代币 ID
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - nft: NFT 合约地址
+//   - tokenId: 代币 ID
+//
+// 返回：
+//   - string: 元数据 URI
+//   - error: 如果合约两种元数据扩展都不支持（ErrUnsupportedInterface）或查询失败则返回错误
+func GetTokenURI(ctx context.Context, ep EtherProvider, nft common.Address, tokenId *big.Int) (string, error) {
+	if ok, err := SupportsInterface(ctx, ep, nft, InterfaceIDERC721Metadata); err != nil {
+		return "", err
+	} else if ok {
+		out, err := callNFT(ctx, ep, nft, "tokenURI", tokenId)
+		if err != nil {
+			return "", err
+		}
+		return out[0].(string), nil
+	}
+	if err := requireInterface(ctx, ep, nft, InterfaceIDERC1155MetadataURI, "ERC721Metadata or ERC1155MetadataURI"); err != nil {
+		return "", err
+	}
+	out, err := callNFT(ctx, ep, nft, "uri", tokenId)
+	if err != nil {
+		return "", err
+	}
+	return strings.ReplaceAll(out[0].(string), "{id}", fmt.Sprintf("%064x", tokenId)), nil
+}
+
+// TokensOfOwner 通过 ERC721Enumerable 列出地址持有的全部代币 ID
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - nft: NFT 合约地址
+//   - owner: 持有人地址
+//
+// 返回：
+//   - []*big.Int: 代币 ID（按合约中的索引顺序）
+//   - error: 如果合约不支持 ERC721Enumerable（ErrUnsupportedInterface）或查询失败则返回错误
+func TokensOfOwner(ctx context.Context, ep EtherProvider, nft, owner common.Address) ([]*big.Int, error) {
+	if err := requireInterface(ctx, ep, nft, InterfaceIDERC721Enumerable, "ERC721Enumerable"); err != nil {
+		return nil, err
+	}
+	out, err := callNFT(ctx, ep, nft, "balanceOf", owner)
+	if err != nil {
+		return nil, err
+	}
+	balance := out[0].(*big.Int)
+	if !balance.IsUint64() {
+		return nil, fmt.Errorf("balance %s of %s is too large to enumerate", balance, owner.Hex())
+	}
+	tokens := make([]*big.Int, 0, balance.Uint64())
+	for i := uint64(0); i < balance.Uint64(); i++ {
+		out, err := callNFT(ctx, ep, nft, "tokenOfOwnerByIndex", owner, new(big.Int).SetUint64(i))
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, out[0].(*big.Int))
+	}
+	return tokens, nil
+}
+
+// TokenByIndex 通过 ERC721Enumerable 查询第 index 个代币的 ID
+func TokenByIndex(ctx context.Context, ep EtherProvider, nft common.Address, index *big.Int) (*big.Int, error) {
+	if err := requireInterface(ctx, ep, nft, InterfaceIDERC721Enumerable, "ERC721Enumerable"); err != nil {
+		return nil, err
+	}
+	out, err := callNFT(ctx, ep, nft, "tokenByIndex", index)
+	if err != nil {
+		return nil, err
+	}
+	return out[0].(*big.Int), nil
+}
+
+// NFTInfo NFT 合约的标准、元数据和扩展支持情况
+type NFTInfo struct {
+	Address            common.Address // 合约地址
+	IsERC721           bool           // 是否支持 ERC-721
+	IsERC1155          bool           // 是否支持 ERC-1155
+	Metadata           bool           // 是否支持元数据扩展（ERC721Metadata 或 ERC1155MetadataURI）
+	Enumerable         bool           // 是否支持 ERC721Enumerable
+	Royalties          bool           // 是否支持 ERC-2981 版税
+	Name               string         // 集合名称（仅 ERC721Metadata）
+	Symbol             string         // 集合符号（仅 ERC721Metadata）
+	TotalSupply        *big.Int       // 总量（仅 ERC721Enumerable，否则为 nil）
+	ContractURI        string         // 合约级元数据 URI（没有实现 contractURI 时为空）
+	RoyaltyReceiver    common.Address // 代币 0 的版税接收地址（仅 ERC-2981）
+	RoyaltyBasisPoints *big.Int       // 代币 0 的版税比例（基点，10000 = 100%，仅 ERC-2981）
+}
+
+// royaltyQuotePrice 计算版税比例时使用的参考价格（10000 基点）
+var royaltyQuotePrice = big.NewInt(10000)
+
+// GetNFTInfo 一次查询 NFT 合约的标准、元数据、可枚举性和版税信息，供市场类应用使用
+// 接口支持情况通过 ERC-165 检测，各扩展只在支持时查询；名称、符号、contractURI 查询失败时对应字段为空
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - nft: NFT 合约地址
+//
+// 返回：
+//   - *NFTInfo: 合约信息
+//   - error: 如果合约不支持 ERC-165（ErrUnsupportedInterface）或查询失败则返回错误
+//
+// 使用示例：
+//
+//	info, err := GetNFTInfo(ctx, provider, collection)
+//	if info.Royalties {
+//	    receiver, amount, err := RoyaltyInfo(ctx, provider, collection, tokenId, salePrice)
+//	}
+func GetNFTInfo(ctx context.Context, ep EtherProvider, nft common.Address) (*NFTInfo, error) {
+	if err := requireInterface(ctx, ep, nft, InterfaceIDERC165, "ERC-165"); err != nil {
+		return nil, err
+	}
+	info := &NFTInfo{Address: nft}
+	checks := []struct {
+		id  [4]byte
+		out *bool
+	}{
+		{InterfaceIDERC721, &info.IsERC721},
+		{InterfaceIDERC1155, &info.IsERC1155},
+		{InterfaceIDERC721Metadata, &info.Metadata},
+		{InterfaceIDERC721Enumerable, &info.Enumerable},
+		{InterfaceIDERC2981, &info.Royalties},
+	}
+	for _, c := range checks {
+		ok, err := callSupportsInterface(ctx, ep, nft, c.id)
+		if err != nil {
+			return nil, err
+		}
+		*c.out = ok
+	}
+
+	if info.Metadata {
+		if out, err := callNFT(ctx, ep, nft, "name"); err == nil {
+			info.Name = out[0].(string)
+		}
+		if out, err := callNFT(ctx, ep, nft, "symbol"); err == nil {
+			info.Symbol = out[0].(string)
+		}
+	} else if info.IsERC1155 {
+		ok, err := callSupportsInterface(ctx, ep, nft, InterfaceIDERC1155MetadataURI)
+		if err != nil {
+			return nil, err
+		}
+		info.Metadata = ok
+	}
+	if info.Enumerable {
+		out, err := callNFT(ctx, ep, nft, "totalSupply")
+		if err != nil {
+			return nil, err
+		}
+		info.TotalSupply = out[0].(*big.Int)
+	}
+	if info.Royalties {
+		out, err := callNFT(ctx, ep, nft, "royaltyInfo", big.NewInt(0), royaltyQuotePrice)
+		if err != nil {
+			return nil, err
+		}
+		info.RoyaltyReceiver = out[0].(common.Address)
+		info.RoyaltyBasisPoints = out[1].(*big.Int)
+	}
+	if out, err := callNFT(ctx, ep, nft, "contractURI"); err == nil {
+		info.ContractURI = out[0].(string)
+	}
+	return info, nil
+}
+
+// SupportsInterface 按 ERC-165 判断合约是否支持指定接口
+func (k *Kit) SupportsInterface(ctx context.Context, contract common.Address, interfaceID [4]byte) (bool, error) {
+	return SupportsInterface(ctx, k.EtherProvider, contract, interfaceID)
+}
+
+// RoyaltyInfo 查询 ERC-2981 版税信息
+func (k *Kit) RoyaltyInfo(ctx context.Context, nft common.Address, tokenId, salePrice *big.Int) (common.Address, *big.Int, error) {
+	return RoyaltyInfo(ctx, k.EtherProvider, nft, tokenId, salePrice)
+}
+
+// GetTokenURI 查询单个 NFT 的元数据 URI（ERC-721 tokenURI 或 ERC-1155 uri）
+func (k *Kit) GetTokenURI(ctx context.Context, nft common.Address, tokenId *big.Int) (string, error) {
+	return GetTokenURI(ctx, k.EtherProvider, nft, tokenId)
+}
+
+// TokensOfOwner 通过 ERC721Enumerable 列出地址持有的全部代币 ID
+func (k *Kit) TokensOfOwner(ctx context.Context, nft, owner common.Address) ([]*big.Int, error) {
+	return TokensOfOwner(ctx, k.EtherProvider, nft, owner)
+}
+
+// GetNFTInfo 一次查询 NFT 合约的标准、元数据、可枚举性和版税信息
+func (k *Kit) GetNFTInfo(ctx context.Context, nft common.Address) (*NFTInfo, error) {
+	return GetNFTInfo(ctx, k.EtherProvider, nft)
+}
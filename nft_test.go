@@ -0,0 +1,256 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// mockNFT 模拟的 NFT 合约
+type mockNFT struct {
+	erc165     bool           // 是否实现 supportsInterface
+	interfaces [][4]byte      // 声明支持的接口
+	owned      []*big.Int     // owner 持有的代币
+	uri        string         // tokenURI / uri 的返回值
+	contract   string         // contractURI 的返回值（为空表示没有实现）
+	receiver   common.Address // 版税接收地址
+}
+
+// newMockNFTServer 按合约地址分派 eth_call 的模拟节点
+func newMockNFTServer(t *testing.T, contracts map[common.Address]*mockNFT) *mockRPCServer {
+	t.Helper()
+	server := newMockSendServer(t)
+	server.handlers["eth_call"] = func(params []json.RawMessage) (interface{}, error) {
+		arg, err := parseMockCallArg(params)
+		if err != nil {
+			return nil, err
+		}
+		nft, ok := contracts[common.HexToAddress(arg.To)]
+		if !ok {
+			// 普通地址没有代码，返回空数据
+			return "0x", nil
+		}
+		method, err := nftParsed.MethodById(arg.calldata()[:4])
+		if err != nil {
+			return nil, err
+		}
+		args, err := method.Inputs.Unpack(arg.calldata()[4:])
+		if err != nil {
+			return nil, err
+		}
+		revert := &mockRPCError{Code: 3, Message: "execution reverted"}
+		switch method.Name {
+		case "supportsInterface":
+			if !nft.erc165 {
+				return nil, revert
+			}
+			id := args[0].([4]byte)
+			if id == InterfaceIDERC165 {
+				return packOutputs(method.Outputs, true)
+			}
+			for _, supported := range nft.interfaces {
+				if supported == id {
+					return packOutputs(method.Outputs, true)
+				}
+			}
+			return packOutputs(method.Outputs, false)
+		case "royaltyInfo":
+			// 5% 版税
+			price := args[1].(*big.Int)
+			return packOutputs(method.Outputs, nft.receiver, new(big.Int).Div(new(big.Int).Mul(price, big.NewInt(500)), big.NewInt(10000)))
+		case "contractURI":
+			if nft.contract == "" {
+				return nil, revert
+			}
+			return packOutputs(method.Outputs, nft.contract)
+		case "name":
+			return packOutputs(method.Outputs, "Mock Collection")
+		case "symbol":
+			return packOutputs(method.Outputs, "MOCK")
+		case "tokenURI", "uri":
+			return packOutputs(method.Outputs, nft.uri)
+		case "totalSupply":
+			return packOutputs(method.Outputs, big.NewInt(100))
+		case "balanceOf":
+			return packOutputs(method.Outputs, big.NewInt(int64(len(nft.owned))))
+		case "tokenOfOwnerByIndex":
+			return packOutputs(method.Outputs, nft.owned[args[1].(*big.Int).Int64()])
+		case "tokenByIndex":
+			return packOutputs(method.Outputs, new(big.Int).Add(args[0].(*big.Int), big.NewInt(1)))
+		}
+		return nil, errors.New("unsupported method " + method.Name)
+	}
+	return server
+}
+
+func TestNFTExtensions(t *testing.T) {
+	receiver := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	owner := common.HexToAddress("0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC")
+	erc721 := common.HexToAddress("0x0000000000000000000000000000000000000721")
+	erc1155 := common.HexToAddress("0x0000000000000000000000000000000000001155")
+	legacy := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	eoa := common.HexToAddress("0x00000000000000000000000000000000000000bb")
+	server := newMockNFTServer(t, map[common.Address]*mockNFT{
+		erc721: {
+			erc165:     true,
+			interfaces: [][4]byte{InterfaceIDERC721, InterfaceIDERC721Metadata, InterfaceIDERC721Enumerable, InterfaceIDERC2981},
+			owned:      []*big.Int{big.NewInt(7), big.NewInt(42)},
+			uri:        "ipfs://token/7",
+			contract:   "ipfs://collection",
+			receiver:   receiver,
+		},
+		erc1155: {
+			erc165:     true,
+			interfaces: [][4]byte{InterfaceIDERC1155, InterfaceIDERC1155MetadataURI},
+			uri:        "https://example.com/{id}.json",
+		},
+		// 没有实现 ERC-165 的老合约
+		legacy: {uri: "ipfs://legacy"},
+	})
+	kit := newMockKit(t, server)
+	ctx := context.Background()
+
+	t.Run("supports interface", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			contract common.Address
+			id       [4]byte
+			want     bool
+		}{
+			{"erc721 royalties", erc721, InterfaceIDERC2981, true},
+			{"erc721 not erc1155", erc721, InterfaceIDERC1155, false},
+			{"erc1155", erc1155, InterfaceIDERC1155, true},
+			{"erc165 itself", erc1155, InterfaceIDERC165, true},
+			{"without erc165", legacy, InterfaceIDERC721, false},
+			{"externally owned account", eoa, InterfaceIDERC721, false},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				got, err := kit.SupportsInterface(ctx, tt.contract, tt.id)
+				if err != nil {
+					t.Fatalf("SupportsInterface() failed: %v", err)
+				}
+				if got != tt.want {
+					t.Errorf("SupportsInterface() = %v, expected %v", got, tt.want)
+				}
+			})
+		}
+	})
+
+	t.Run("royalty info", func(t *testing.T) {
+		tests := []struct {
+			name       string
+			contract   common.Address
+			wantAmount *big.Int
+			wantErr    error
+		}{
+			{"erc2981", erc721, big.NewInt(5e16), nil},
+			{"no royalties", erc1155, nil, ErrUnsupportedInterface},
+			{"without erc165", legacy, nil, ErrUnsupportedInterface},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				got, amount, err := kit.RoyaltyInfo(ctx, tt.contract, big.NewInt(7), big.NewInt(1e18))
+				if tt.wantErr != nil {
+					if !errors.Is(err, tt.wantErr) {
+						t.Fatalf("RoyaltyInfo() error = %v, want %v", err, tt.wantErr)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("RoyaltyInfo() failed: %v", err)
+				}
+				if got != receiver || amount.Cmp(tt.wantAmount) != 0 {
+					t.Errorf("RoyaltyInfo() = %s, %s, expected %s, %s", got.Hex(), amount, receiver.Hex(), tt.wantAmount)
+				}
+			})
+		}
+	})
+
+	t.Run("token uri", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			contract common.Address
+			want     string
+			wantErr  error
+		}{
+			{"erc721 metadata", erc721, "ipfs://token/7", nil},
+			{"erc1155 id substitution", erc1155, "https://example.com/0000000000000000000000000000000000000000000000000000000000000007.json", nil},
+			{"without erc165", legacy, "", ErrUnsupportedInterface},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				got, err := kit.GetTokenURI(ctx, tt.contract, big.NewInt(7))
+				if tt.wantErr != nil {
+					if !errors.Is(err, tt.wantErr) {
+						t.Fatalf("GetTokenURI() error = %v, want %v", err, tt.wantErr)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("GetTokenURI() failed: %v", err)
+				}
+				if got != tt.want {
+					t.Errorf("GetTokenURI() = %q, expected %q", got, tt.want)
+				}
+			})
+		}
+	})
+
+	t.Run("enumerable", func(t *testing.T) {
+		tokens, err := kit.TokensOfOwner(ctx, erc721, owner)
+		if err != nil {
+			t.Fatalf("TokensOfOwner() failed: %v", err)
+		}
+		if len(tokens) != 2 || tokens[0].Int64() != 7 || tokens[1].Int64() != 42 {
+			t.Errorf("TokensOfOwner() = %v", tokens)
+		}
+		if id, err := TokenByIndex(ctx, kit.EtherProvider, erc721, big.NewInt(0)); err != nil || id.Int64() != 1 {
+			t.Errorf("TokenByIndex() = %v, %v", id, err)
+		}
+		if _, err := kit.TokensOfOwner(ctx, erc1155, owner); !errors.Is(err, ErrUnsupportedInterface) {
+			t.Errorf("TokensOfOwner() on erc1155 error = %v, want ErrUnsupportedInterface", err)
+		}
+	})
+
+	t.Run("contract uri", func(t *testing.T) {
+		if uri, err := GetContractURI(ctx, kit.EtherProvider, erc721); err != nil || uri != "ipfs://collection" {
+			t.Errorf("GetContractURI() = %q, %v", uri, err)
+		}
+		if _, err := GetContractURI(ctx, kit.EtherProvider, erc1155); !errors.Is(err, ErrContractCall) {
+			t.Errorf("GetContractURI() without contractURI error = %v, want ErrContractCall", err)
+		}
+	})
+
+	t.Run("nft info", func(t *testing.T) {
+		info, err := kit.GetNFTInfo(ctx, erc721)
+		if err != nil {
+			t.Fatalf("GetNFTInfo() failed: %v", err)
+		}
+		if !info.IsERC721 || info.IsERC1155 || !info.Metadata || !info.Enumerable || !info.Royalties {
+			t.Errorf("GetNFTInfo() interfaces = %+v", info)
+		}
+		if info.Name != "Mock Collection" || info.Symbol != "MOCK" || info.ContractURI != "ipfs://collection" || info.TotalSupply.Int64() != 100 {
+			t.Errorf("GetNFTInfo() metadata = %+v", info)
+		}
+		if info.RoyaltyReceiver != receiver || info.RoyaltyBasisPoints.Int64() != 500 {
+			t.Errorf("GetNFTInfo() royalty = %s, %s", info.RoyaltyReceiver.Hex(), info.RoyaltyBasisPoints)
+		}
+
+		info, err = kit.GetNFTInfo(ctx, erc1155)
+		if err != nil {
+			t.Fatalf("GetNFTInfo() failed: %v", err)
+		}
+		if info.IsERC721 || !info.IsERC1155 || !info.Metadata || info.Enumerable || info.Royalties || info.TotalSupply != nil || info.ContractURI != "" {
+			t.Errorf("GetNFTInfo() erc1155 = %+v", info)
+		}
+
+		if _, err := kit.GetNFTInfo(ctx, legacy); !errors.Is(err, ErrUnsupportedInterface) {
+			t.Errorf("GetNFTInfo() without erc165 error = %v, want ErrUnsupportedInterface", err)
+		}
+	})
+}
@@ -0,0 +1,228 @@
+package etherkit
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+//############ Node-Managed Accounts ############
+
+// NodeAccounts 获取节点管理的账户列表（eth_accounts）
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//
+// 返回：
+//   - []common.Address: 节点上的账户地址（公共 RPC 节点通常返回空列表）
+//   - error: 如果查询失败则返回错误
+func NodeAccounts(ctx context.Context, ep EtherProvider) ([]common.Address, error) {
+	var accounts []common.Address
+	if err := ep.GetRpcClient().CallContext(ctx, &accounts, "eth_accounts"); err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+// UnlockNodeAccount 使用密码解锁节点上的账户（personal_unlockAccount，需要节点开启 personal 命名空间）
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - address: 账户地址
+//   - passphrase: 账户密码
+//   - duration: 解锁时长（0 表示使用节点默认值）
+//
+// 返回：
+//   - error: 如果节点未开启 personal 命名空间或密码错误则返回错误
+func UnlockNodeAccount(ctx context.Context, ep EtherProvider, address common.Address, passphrase string, duration time.Duration) error {
+	var seconds interface{}
+	if duration > 0 {
+		seconds = uint64(duration / time.Second)
+	}
+	var unlocked bool
+	if err := ep.GetRpcClient().CallContext(ctx, &unlocked, "personal_unlockAccount", address, passphrase, seconds); err != nil {
+		return fmt.Errorf("failed to unlock %s: %w", address.Hex(), err)
+	}
+	if !unlocked {
+		return fmt.Errorf("failed to unlock %s", address.Hex())
+	}
+	return nil
+}
+
+// NewNodeWallet 创建使用节点管理账户的钱包（适用于私钥保存在节点上的私有链）
+// 交易通过 eth_signTransaction 由节点签名后再广播，因此审核回调、审计记录等 Kit 功能照常生效；
+// 节点无法对任意数据签名，Signature、SignHash 和 SignTypedData 返回 ErrNodeAccountUnsupported
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - address: 节点上已解锁的账户地址（锁定的账户可先调用 UnlockNodeAccount）
+//
+// 返回：
+//   - *Wallet: 创建的钱包实例
+//   - error: 如果节点上没有该账户则返回错误
+func NewNodeWallet(ctx context.Context, ep EtherProvider, address common.Address) (*Wallet, error) {
+	accounts, err := NodeAccounts(ctx, ep)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list node accounts: %w", err)
+	}
+	if !slices.Contains(accounts, address) {
+		return nil, fmt.Errorf("%w: account %s is not managed by the node", ErrInvalidWalletConfig, address.Hex())
+	}
+	return &Wallet{address: address, ep: ep, nodeAccount: true}, nil
+}
+
+// WithNodeAccount 使用节点管理的账户创建 Kit，代替 WithPrivateKeyHex / WithPrivateKey（仅在创建 Kit 时生效）
+// 创建时不检查节点上是否存在该账户，需要检查时使用 NodeAccounts
+//
+// 使用示例：
+//
+//	kit, err := New("http://127.0.0.1:8545", WithNodeAccount(common.HexToAddress("0x...")))
+func WithNodeAccount(address common.Address) KitOption {
+	return func(k *Kit) {
+		if k.setup == nil {
+			return
+		}
+		k.setup.nodeAccount = &address
+	}
+}
+
+// IsNodeAccount 判断钱包是否使用节点管理的账户
+func (w *Wallet) IsNodeAccount() bool {
+	return w.nodeAccount
+}
+
+// signTxOnNode 通过 eth_signTransaction 由节点签名交易，并检查签名地址
+func (w *Wallet) signTxOnNode(ctx context.Context, chainId *big.Int, tx *types.Transaction) (*types.Transaction, error) {
+	msg := ethereum.CallMsg{From: w.address, To: tx.To(), Gas: tx.Gas(), Value: tx.Value(), Data: tx.Data(), AccessList: tx.AccessList()}
+	if tx.Type() == types.LegacyTxType {
+		msg.GasPrice = tx.GasPrice()
+	} else {
+		msg.GasFeeCap, msg.GasTipCap = tx.GasFeeCap(), tx.GasTipCap()
+	}
+	arg := toCallArg(msg).(map[string]interface{})
+	arg["nonce"] = hexutil.Uint64(tx.Nonce())
+	arg["chainId"] = (*hexutil.Big)(chainId)
+
+	var result struct {
+		Raw hexutil.Bytes `json:"raw"`
+	}
+	if err := w.ep.GetRpcClient().CallContext(ctx, &result, "eth_signTransaction", arg); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSignatureFailed, err)
+	}
+	signedTx := new(types.Transaction)
+	if err := signedTx.UnmarshalBinary(result.Raw); err != nil {
+		return nil, fmt.Errorf("%w: invalid signed transaction from node: %w", ErrSignatureFailed, err)
+	}
+	from, err := types.Sender(types.LatestSignerForChainID(chainId), signedTx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSignatureFailed, err)
+	}
+	if from != w.address {
+		return nil, fmt.Errorf("%w: node signed as %s, expected %s", ErrSignatureFailed, from.Hex(), w.address.Hex())
+	}
+	return signedTx, nil
+}
+
+// nodeSigner 返回通过节点签名的 bind.SignerFn（用于 BuildTxOpts）
+func (w *Wallet) nodeSigner(ctx context.Context, chainId *big.Int) bind.SignerFn {
+	return func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		if address != w.address {
+			return nil, bind.ErrNotAuthorized
+		}
+		return w.signTxOnNode(ctx, chainId, tx)
+	}
+}
+
+// SendTxViaNode 由节点构建、签名并广播交易（eth_sendTransaction），用于不支持 eth_signTransaction 的节点
+// 钱包层面的发送不经过审核回调和审计记录（Kit.SendTxViaNode 会执行），零值参数由节点自动填充
+// 参数说明：
+//   - ctx: 上下文对象
+//   - to: 接收地址
+//   - nonce: 交易 nonce（0 表示由节点计算）
+//   - gasLimit: Gas 限制（0 表示由节点估算）
+//   - gasPrice: Gas 价格（nil 表示由节点决定）
+//   - value: 转账金额（nil 表示不转账）
+//   - data: 交易数据
+//
+// 返回：
+//   - common.Hash: 交易哈希
+//   - error: 如果钱包不是节点管理的账户或发送失败则返回错误
+func (w *Wallet) SendTxViaNode(ctx context.Context, to common.Address, nonce, gasLimit uint64, gasPrice, value *big.Int, data []byte) (common.Hash, error) {
+	if !w.nodeAccount {
+		return common.Hash{}, fmt.Errorf("%w: wallet is not a node-managed account", ErrInvalidWalletConfig)
+	}
+	return sendNodeTransaction(ctx, w.ep.GetRpcClient(), w.address, to, nonce, gasLimit, gasPrice, value, data)
+}
+
+// SendTxViaNode 由节点构建、签名并广播交易（eth_sendTransaction）
+// 覆盖 Wallet.SendTxViaNode：发送前执行审核回调（节点自动填充的字段在审核时为零值）；
+// 签名和广播由节点一步完成，审计记录只能在广播后写入，写入失败时同时返回交易哈希和错误
+// 参数说明：
+//   - ctx: 上下文对象
+//   - to: 接收地址
+//   - nonce: 交易 nonce（0 表示由节点计算）
+//   - gasLimit: Gas 限制（0 表示由节点估算）
+//   - gasPrice: Gas 价格（nil 表示由节点决定）
+//   - value: 转账金额（nil 表示不转账）
+//   - data: 交易数据
+//
+// 返回：
+//   - common.Hash: 交易哈希
+//   - error: 如果钱包不是节点管理的账户、审核被拒绝、发送或写入审计记录失败则返回错误
+func (k *Kit) SendTxViaNode(ctx context.Context, to common.Address, nonce, gasLimit uint64, gasPrice, value *big.Int, data []byte) (common.Hash, error) {
+	if !k.IsNodeAccount() || (len(k.confirmationHooks) == 0 && len(k.auditSinks) == 0) {
+		return k.Wallet.SendTxViaNode(ctx, to, nonce, gasLimit, gasPrice, value, data)
+	}
+	chainId, err := k.GetChainID(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	review := &TxReview{
+		Kind:     AuditKindTransaction,
+		ChainID:  chainId,
+		From:     k.GetAddress(),
+		To:       &to,
+		Value:    bigOrZero(value),
+		GasLimit: gasLimit,
+		GasPrice: gasPrice,
+		MaxFee:   new(big.Int).Mul(bigOrZero(gasPrice), new(big.Int).SetUint64(gasLimit)),
+		Data:     data,
+	}
+	review.Method, review.Args = decodeCallData(data, nil)
+	if err := k.reviewTx(ctx, review); err != nil {
+		return common.Hash{}, err
+	}
+	hash, err := k.Wallet.SendTxViaNode(ctx, to, nonce, gasLimit, gasPrice, value, data)
+	review.settle(err == nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return hash, k.audit(ctx, &AuditRecord{
+		Kind:    AuditKindTransaction,
+		ChainID: chainId,
+		Hash:    hash,
+		Intent:  review.String(),
+	})
+}
+
+// sendNodeTransaction 通过 eth_sendTransaction 以 from 身份发送交易（由节点签名）
+func sendNodeTransaction(ctx context.Context, rc *rpc.Client, from, to common.Address, nonce, gasLimit uint64, gasPrice, value *big.Int, data []byte) (common.Hash, error) {
+	arg := toCallArg(ethereum.CallMsg{From: from, To: &to, Gas: gasLimit, GasPrice: gasPrice, Value: value, Data: data}).(map[string]interface{})
+	if nonce != 0 {
+		arg["nonce"] = hexutil.Uint64(nonce)
+	}
+	var hash common.Hash
+	if err := rc.CallContext(ctx, &hash, "eth_sendTransaction", arg); err != nil {
+		return common.Hash{}, NormalizeError(err)
+	}
+	return hash, nil
+}
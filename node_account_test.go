@@ -0,0 +1,194 @@
+package etherkit
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// newMockNodeAccountServer 创建模拟节点，节点使用 signer 私钥管理 0xf39F…2266 账户
+func newMockNodeAccountServer(t *testing.T, signer *ecdsa.PrivateKey) *mockRPCServer {
+	t.Helper()
+	server := newMockSendServer(t)
+	server.handlers["eth_accounts"] = mockResult([]string{"0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"})
+	server.handlers["eth_signTransaction"] = func(params []json.RawMessage) (interface{}, error) {
+		var arg struct {
+			To       *common.Address `json:"to"`
+			Nonce    hexutil.Uint64  `json:"nonce"`
+			Gas      hexutil.Uint64  `json:"gas"`
+			GasPrice *hexutil.Big    `json:"gasPrice"`
+			Value    *hexutil.Big    `json:"value"`
+			Input    hexutil.Bytes   `json:"input"`
+			ChainID  *hexutil.Big    `json:"chainId"`
+		}
+		if err := json.Unmarshal(params[0], &arg); err != nil {
+			return nil, err
+		}
+		tx := types.NewTx(&types.LegacyTx{Nonce: uint64(arg.Nonce), To: arg.To, Gas: uint64(arg.Gas), GasPrice: arg.GasPrice.ToInt(), Value: arg.Value.ToInt(), Data: arg.Input})
+		signed, err := types.SignTx(tx, types.NewLondonSigner(arg.ChainID.ToInt()), signer)
+		if err != nil {
+			return nil, err
+		}
+		raw, _ := signed.MarshalBinary()
+		return map[string]interface{}{"raw": hexutil.Bytes(raw), "tx": signed}, nil
+	}
+	server.handlers["eth_sendTransaction"] = mockResult(common.HexToHash("0x1234"))
+	server.handlers["personal_unlockAccount"] = func(params []json.RawMessage) (interface{}, error) {
+		var passphrase string
+		_ = json.Unmarshal(params[1], &passphrase)
+		return passphrase == "secret", nil
+	}
+	return server
+}
+
+func TestNewNodeWallet(t *testing.T) {
+	pk, _ := BuildPrivateKeyFromHex("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	server := newMockNodeAccountServer(t, pk)
+	provider, err := NewProvider(server.URL)
+	if err != nil {
+		t.Fatalf("NewProvider() failed: %v", err)
+	}
+	defer provider.Close()
+
+	tests := []struct {
+		name    string
+		address common.Address
+		wantErr bool
+	}{
+		{"managed account", common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"), false},
+		{"unknown account", common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wallet, err := NewNodeWallet(context.Background(), provider, tt.address)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewNodeWallet() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidWalletConfig) {
+					t.Errorf("NewNodeWallet() error = %v, expected ErrInvalidWalletConfig", err)
+				}
+				return
+			}
+			if !wallet.IsNodeAccount() || wallet.IsReadOnly() || wallet.GetAddress() != tt.address {
+				t.Errorf("NewNodeWallet() = {node: %v, readOnly: %v, address: %s}", wallet.IsNodeAccount(), wallet.IsReadOnly(), wallet.GetAddress().Hex())
+			}
+		})
+	}
+}
+
+func TestNodeAccountKit(t *testing.T) {
+	ctx := context.Background()
+	account := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	recipient := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	pk, _ := BuildPrivateKeyFromHex("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+
+	t.Run("signs on node", func(t *testing.T) {
+		server := newMockNodeAccountServer(t, pk)
+		var reviewed *TxReview
+		kit, err := New(server.URL, WithNodeAccount(account), WithConfirmationHook(func(ctx context.Context, review *TxReview) error {
+			reviewed = review
+			return nil
+		}))
+		if err != nil {
+			t.Fatalf("New() failed: %v", err)
+		}
+		defer kit.Close()
+
+		if _, err := kit.SendTx(ctx, recipient, 0, 0, nil, big.NewInt(1), nil); err != nil {
+			t.Fatalf("SendTx() failed: %v", err)
+		}
+		if server.callCount("eth_signTransaction") != 1 || server.callCount("eth_sendRawTransaction") != 1 {
+			t.Errorf("eth_signTransaction calls = %d, eth_sendRawTransaction calls = %d, expected 1 each",
+				server.callCount("eth_signTransaction"), server.callCount("eth_sendRawTransaction"))
+		}
+		if reviewed == nil || reviewed.From != account {
+			t.Errorf("confirmation hook review = %v, expected from %s", reviewed, account.Hex())
+		}
+
+		opts, err := kit.BuildTxOpts(ctx, nil, nil, nil)
+		if err != nil {
+			t.Fatalf("BuildTxOpts() failed: %v", err)
+		}
+		tx := types.NewTx(&types.LegacyTx{Nonce: 1, To: &recipient, Gas: 21000, GasPrice: big.NewInt(1e9)})
+		if _, err := opts.Signer(account, tx); err != nil {
+			t.Errorf("TransactOpts.Signer() failed: %v", err)
+		}
+
+		if _, err := kit.Signature([]byte("hello")); !errors.Is(err, ErrNodeAccountUnsupported) {
+			t.Errorf("Signature() error = %v, expected ErrNodeAccountUnsupported", err)
+		}
+	})
+
+	t.Run("node signs with another key", func(t *testing.T) {
+		other, _ := GeneratePrivateKey()
+		server := newMockNodeAccountServer(t, other)
+		kit, err := New(server.URL, WithNodeAccount(account))
+		if err != nil {
+			t.Fatalf("New() failed: %v", err)
+		}
+		defer kit.Close()
+
+		if _, err := kit.SendTx(ctx, recipient, 0, 0, nil, big.NewInt(1), nil); !errors.Is(err, ErrSignatureFailed) {
+			t.Errorf("SendTx() error = %v, expected ErrSignatureFailed", err)
+		}
+		if server.callCount("eth_sendRawTransaction") != 0 {
+			t.Error("SendTx() broadcast a transaction signed by the wrong account")
+		}
+	})
+
+	t.Run("conflicting options", func(t *testing.T) {
+		_, err := New("http://127.0.0.1:8545", WithNodeAccount(account), WithPrivateKey(pk))
+		if !errors.Is(err, ErrInvalidWalletConfig) {
+			t.Errorf("New() error = %v, expected ErrInvalidWalletConfig", err)
+		}
+	})
+}
+
+func TestUnlockNodeAccount(t *testing.T) {
+	pk, _ := BuildPrivateKeyFromHex("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	server := newMockNodeAccountServer(t, pk)
+	provider, err := NewProvider(server.URL)
+	if err != nil {
+		t.Fatalf("NewProvider() failed: %v", err)
+	}
+	defer provider.Close()
+	account := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+
+	tests := []struct {
+		name       string
+		passphrase string
+		wantErr    bool
+	}{
+		{"correct passphrase", "secret", false},
+		{"wrong passphrase", "wrong", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := UnlockNodeAccount(context.Background(), provider, account, tt.passphrase, time.Minute)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UnlockNodeAccount() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// 由节点构建并签名交易
+	wallet, err := NewNodeWallet(context.Background(), provider, account)
+	if err != nil {
+		t.Fatalf("NewNodeWallet() failed: %v", err)
+	}
+	hash, err := wallet.SendTxViaNode(context.Background(), common.Address{}, 0, 0, nil, big.NewInt(1), nil)
+	if err != nil || hash != common.HexToHash("0x1234") {
+		t.Errorf("SendTxViaNode() = %s, %v, expected 0x…1234", hash.Hex(), err)
+	}
+}
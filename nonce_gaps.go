@@ -0,0 +1,114 @@
+package etherkit
+
+import (
+	"context"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//############ Nonce Diagnosis ############
+
+// NonceDiagnosis 账户 nonce 状态诊断结果
+type NonceDiagnosis struct {
+	Address       common.Address // 账户地址
+	Latest        uint64         // 已确认的 nonce（下一笔待打包交易的 nonce）
+	Pending       uint64         // 包含交易池的 nonce（下一笔新交易的 nonce）
+	PoolAvailable bool           // 节点是否支持 txpool 查询（不支持时无法检测间隙）
+	InFlight      []uint64       // 已在交易池中等待打包的 nonce（[Latest, Pending)）
+	Stuck         []uint64       // 等待打包但 gas 价格低于当前建议价格的 nonce
+	Queued        []uint64       // 交易池中因间隙无法执行的 nonce
+	Gaps          []uint64       // 缺失的 nonce（填补后 Queued 中的交易才能执行）
+}
+
+// HasGaps 判断是否存在 nonce 间隙
+func (d *NonceDiagnosis) HasGaps() bool {
+	return len(d.Gaps) > 0
+}
+
+// DiagnoseNonces 诊断账户的 nonce 状态：比较已确认 nonce、待处理 nonce 和交易池内容，找出卡住的交易和 nonce 间隙
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - address: 账户地址
+//
+// 返回：
+//   - *NonceDiagnosis: 诊断结果
+//   - error: 如果查询 nonce 失败则返回错误（txpool 不可用不视为错误）
+func DiagnoseNonces(ctx context.Context, ep EtherProvider, address common.Address) (*NonceDiagnosis, error) {
+	latest, pending, err := GetNonceRange(ctx, ep, address)
+	if err != nil {
+		return nil, err
+	}
+	diag := &NonceDiagnosis{Address: address, Latest: latest, Pending: pending}
+	for nonce := latest; nonce < pending; nonce++ {
+		diag.InFlight = append(diag.InFlight, nonce)
+	}
+
+	pool, err := GetTxPoolContentFrom(ctx, ep, address)
+	if err != nil {
+		return diag, nil
+	}
+	diag.PoolAvailable = true
+
+	if len(pool.Pending) > 0 {
+		gasPrice, err := ep.GetSuggestGasPrice(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, nonce := range diag.InFlight {
+			if tx, ok := pool.Pending[nonce]; ok && tx.GasFeeCap().Cmp(gasPrice) < 0 {
+				diag.Stuck = append(diag.Stuck, nonce)
+			}
+		}
+	}
+
+	var maxQueued uint64
+	for nonce := range pool.Queued {
+		diag.Queued = append(diag.Queued, nonce)
+		if nonce > maxQueued {
+			maxQueued = nonce
+		}
+	}
+	sort.Slice(diag.Queued, func(i, j int) bool { return diag.Queued[i] < diag.Queued[j] })
+	for nonce := pending; len(diag.Queued) > 0 && nonce < maxQueued; nonce++ {
+		if _, ok := pool.Queued[nonce]; !ok {
+			diag.Gaps = append(diag.Gaps, nonce)
+		}
+	}
+	return diag, nil
+}
+
+// DiagnoseNonces 诊断 Kit 账户的 nonce 状态
+func (k *Kit) DiagnoseNonces(ctx context.Context) (*NonceDiagnosis, error) {
+	return DiagnoseNonces(ctx, k.EtherProvider, k.GetAddress())
+}
+
+// FillNonceGaps 使用向自己转账 0 的交易填补诊断出的 nonce 间隙，使 queued 中的交易可以执行
+// 参数说明：
+//   - ctx: 上下文对象
+//   - diag: DiagnoseNonces 的诊断结果
+//   - gasPrice: 填补交易的 gas 价格（nil 表示自动获取）
+//
+// 返回：
+//   - []CancelResult: 每个间隙 nonce 的发送结果（Replaced 为空，单个失败不会中止其余 nonce）
+//   - error: 如果获取 gas 价格失败则返回错误
+func (k *Kit) FillNonceGaps(ctx context.Context, diag *NonceDiagnosis, gasPrice *big.Int) ([]CancelResult, error) {
+	if !diag.HasGaps() {
+		return nil, nil
+	}
+	if gasPrice == nil || gasPrice.Sign() == 0 {
+		var err error
+		if gasPrice, err = k.suggestGasPrice(ctx); err != nil {
+			return nil, err
+		}
+	}
+	results := make([]CancelResult, 0, len(diag.Gaps))
+	for _, nonce := range diag.Gaps {
+		result := CancelResult{Nonce: nonce, GasPrice: gasPrice}
+		result.TxHash, result.Err = k.sendNoopTx(ctx, nonce, gasPrice, 0)
+		results = append(results, result)
+	}
+	return results, nil
+}
@@ -0,0 +1,98 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// newMockNonceServer 模拟 nonce 查询：latest 为 3，pending 为 5，txpool 为 pool（nil 表示节点不支持 txpool）
+func newMockNonceServer(t *testing.T, pool map[string]map[string]*types.Transaction) *mockRPCServer {
+	t.Helper()
+	server := newMockSendServer(t)
+	server.handlers["eth_getTransactionCount"] = func(params []json.RawMessage) (interface{}, error) {
+		var tag string
+		_ = json.Unmarshal(params[1], &tag)
+		if tag == "pending" {
+			return "0x5", nil
+		}
+		return "0x3", nil
+	}
+	if pool != nil {
+		server.handlers["txpool_contentFrom"] = mockResult(pool)
+	}
+	return server
+}
+
+func TestDiagnoseNonces(t *testing.T) {
+	pk, _ := BuildPrivateKeyFromHex("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	signer := types.NewLondonSigner(big.NewInt(1))
+	sign := func(nonce uint64, gasPrice int64) *types.Transaction {
+		tx, _ := types.SignTx(types.NewTx(&types.LegacyTx{Nonce: nonce, Gas: 21000, GasPrice: big.NewInt(gasPrice)}), signer, pk)
+		return tx
+	}
+
+	t.Run("gaps and stuck transactions", func(t *testing.T) {
+		// nonce 3 价格低于建议价格 1 gwei；queued 中有 7 和 9，间隙为 5、6、8
+		server := newMockNonceServer(t, map[string]map[string]*types.Transaction{
+			"pending": {"3": sign(3, 5e8), "4": sign(4, 2e9)},
+			"queued":  {"7": sign(7, 1e9), "9": sign(9, 1e9)},
+		})
+		kit := newMockKit(t, server)
+
+		diag, err := kit.DiagnoseNonces(context.Background())
+		if err != nil {
+			t.Fatalf("DiagnoseNonces() failed: %v", err)
+		}
+		if !diag.PoolAvailable || diag.Latest != 3 || diag.Pending != 5 {
+			t.Errorf("unexpected diagnosis header: %+v", diag)
+		}
+		checks := []struct {
+			name string
+			got  []uint64
+			want []uint64
+		}{
+			{"InFlight", diag.InFlight, []uint64{3, 4}},
+			{"Stuck", diag.Stuck, []uint64{3}},
+			{"Queued", diag.Queued, []uint64{7, 9}},
+			{"Gaps", diag.Gaps, []uint64{5, 6, 8}},
+		}
+		for _, c := range checks {
+			if !reflect.DeepEqual(c.got, c.want) {
+				t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+			}
+		}
+
+		results, err := kit.FillNonceGaps(context.Background(), diag, nil)
+		if err != nil {
+			t.Fatalf("FillNonceGaps() failed: %v", err)
+		}
+		if len(results) != 3 || server.callCount("eth_sendRawTransaction") != 3 {
+			t.Fatalf("expected 3 gap filling transactions, got %d", len(results))
+		}
+		for i, r := range results {
+			if r.Err != nil || r.Nonce != diag.Gaps[i] {
+				t.Errorf("results[%d] = {nonce %d, err %v}", i, r.Nonce, r.Err)
+			}
+		}
+	})
+
+	t.Run("txpool unavailable", func(t *testing.T) {
+		kit := newMockKit(t, newMockNonceServer(t, nil))
+
+		diag, err := kit.DiagnoseNonces(context.Background())
+		if err != nil {
+			t.Fatalf("DiagnoseNonces() failed: %v", err)
+		}
+		if diag.PoolAvailable || diag.HasGaps() || !reflect.DeepEqual(diag.InFlight, []uint64{3, 4}) {
+			t.Errorf("unexpected diagnosis: %+v", diag)
+		}
+		if results, err := kit.FillNonceGaps(context.Background(), diag, nil); err != nil || results != nil {
+			t.Errorf("FillNonceGaps() = %v, %v; want nothing to fill", results, err)
+		}
+	})
+}
@@ -0,0 +1,294 @@
+package etherkit
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+//############ ERC-4337 Paymaster ############
+
+// Paymaster ERC-4337 paymaster 客户端接口
+// 用于为用户操作申请 gas 赞助，使用户无需持有本位币即可发送交易
+type Paymaster interface {
+	// StubPaymasterAndData 返回 gas 估算时使用的占位 paymasterAndData
+	// 占位数据的长度和格式应与最终数据一致，使 bundler 能估算出包含 paymaster 校验开销的 gas
+	// 参数说明：
+	//   - ctx: 上下文对象
+	//   - op: 尚未估算 gas 的用户操作
+	//   - entryPoint: EntryPoint 合约地址
+	// 返回：
+	//   - []byte: 占位 paymasterAndData（nil 表示不需要占位数据）
+	//   - error: 如果构建失败则返回错误
+	StubPaymasterAndData(ctx context.Context, op *UserOperation, entryPoint common.Address) ([]byte, error)
+	// SponsorUserOperation 为已估算 gas 的用户操作申请赞助
+	// 参数说明：
+	//   - ctx: 上下文对象
+	//   - op: 已填充 gas 字段的用户操作
+	//   - entryPoint: EntryPoint 合约地址
+	// 返回：
+	//   - *PaymasterSponsorship: 赞助结果（paymasterAndData 以及可选的 gas 字段覆盖）
+	//   - error: 如果赞助被拒绝或请求失败则返回错误
+	SponsorUserOperation(ctx context.Context, op *UserOperation, entryPoint common.Address) (*PaymasterSponsorship, error)
+}
+
+// PaymasterSponsorship paymaster 的赞助结果
+type PaymasterSponsorship struct {
+	PaymasterAndData     []byte   // 写入用户操作的 paymasterAndData
+	PreVerificationGas   *big.Int // 覆盖的 preVerificationGas（nil 表示保持不变）
+	VerificationGasLimit *big.Int // 覆盖的 verificationGasLimit（nil 表示保持不变）
+	CallGasLimit         *big.Int // 覆盖的 callGasLimit（nil 表示保持不变）
+}
+
+// apply 将赞助结果写入用户操作
+func (s *PaymasterSponsorship) apply(op *UserOperation) {
+	op.PaymasterAndData = s.PaymasterAndData
+	if s.PreVerificationGas != nil {
+		op.PreVerificationGas = s.PreVerificationGas
+	}
+	if s.VerificationGasLimit != nil {
+		op.VerificationGasLimit = s.VerificationGasLimit
+	}
+	if s.CallGasLimit != nil {
+		op.CallGasLimit = s.CallGasLimit
+	}
+}
+
+// RPCPaymaster 通过 pm_sponsorUserOperation 接口申请赞助的 paymaster 客户端
+// 兼容 Stackup、Pimlico、Alchemy 等服务商的 v0.6 赞助接口
+type RPCPaymaster struct {
+	rc      *rpc.Client
+	context interface{}
+}
+
+// sponsorUserOperationResult pm_sponsorUserOperation 的返回结构
+type sponsorUserOperationResult struct {
+	PaymasterAndData     string       `json:"paymasterAndData"`
+	PreVerificationGas   *rpcQuantity `json:"preVerificationGas"`
+	VerificationGasLimit *rpcQuantity `json:"verificationGasLimit"`
+	CallGasLimit         *rpcQuantity `json:"callGasLimit"`
+}
+
+// NewRPCPaymaster 创建基于 pm_sponsorUserOperation 的 paymaster 客户端
+// 参数说明：
+//   - rawUrl: paymaster 服务 RPC 地址
+//   - sponsorContext: 服务商要求的上下文参数（如 {"type": "payg"} 或赞助策略 ID，nil 表示不传）
+//
+// 返回：
+//   - *RPCPaymaster: paymaster 客户端实例
+//   - error: 如果连接失败则返回错误
+func NewRPCPaymaster(rawUrl string, sponsorContext interface{}) (*RPCPaymaster, error) {
+	rc, err := rpc.Dial(rawUrl)
+	if err != nil {
+		return nil, err
+	}
+	return &RPCPaymaster{rc: rc, context: sponsorContext}, nil
+}
+
+// Close 关闭 paymaster 服务连接
+func (p *RPCPaymaster) Close() {
+	p.rc.Close()
+}
+
+// StubPaymasterAndData RPC paymaster 会在赞助时返回新的 gas 字段，不需要占位数据
+func (p *RPCPaymaster) StubPaymasterAndData(ctx context.Context, op *UserOperation, entryPoint common.Address) ([]byte, error) {
+	return nil, nil
+}
+
+// SponsorUserOperation 调用 pm_sponsorUserOperation 申请赞助
+func (p *RPCPaymaster) SponsorUserOperation(ctx context.Context, op *UserOperation, entryPoint common.Address) (*PaymasterSponsorship, error) {
+	args := []interface{}{op, entryPoint}
+	if p.context != nil {
+		args = append(args, p.context)
+	}
+
+	var result sponsorUserOperationResult
+	if err := p.rc.CallContext(ctx, &result, "pm_sponsorUserOperation", args...); err != nil {
+		return nil, err
+	}
+	if result.PaymasterAndData == "" || result.PaymasterAndData == "0x" {
+		return nil, errors.New("paymaster returned empty paymasterAndData")
+	}
+
+	sponsorship := &PaymasterSponsorship{PaymasterAndData: common.FromHex(result.PaymasterAndData)}
+	if result.PreVerificationGas != nil {
+		sponsorship.PreVerificationGas = result.PreVerificationGas.toBig()
+	}
+	if result.VerificationGasLimit != nil {
+		sponsorship.VerificationGasLimit = result.VerificationGasLimit.toBig()
+	}
+	if result.CallGasLimit != nil {
+		sponsorship.CallGasLimit = result.CallGasLimit.toBig()
+	}
+	return sponsorship, nil
+}
+
+// VerifyingPaymaster 本地签名的 VerifyingPaymaster 客户端
+// 适用于自行部署 eth-infinitism VerifyingPaymaster（v0.6）合约的应用：
+// 由应用持有 verifyingSigner 私钥，在服务端为用户操作签发赞助
+type VerifyingPaymaster struct {
+	Address  common.Address // VerifyingPaymaster 合约地址
+	ChainID  *big.Int       // 链 ID
+	Signer   *Wallet        // 合约中配置的 verifyingSigner
+	Validity time.Duration  // 赞助的有效期（0 表示永不过期）
+}
+
+// NewVerifyingPaymaster 创建 VerifyingPaymaster 客户端
+// 参数说明：
+//   - address: VerifyingPaymaster 合约地址
+//   - chainID: 链 ID
+//   - signer: 合约中配置的 verifyingSigner 钱包
+//   - validity: 赞助的有效期（0 表示永不过期）
+//
+// 返回：
+//   - *VerifyingPaymaster: paymaster 客户端实例
+func NewVerifyingPaymaster(address common.Address, chainID *big.Int, signer *Wallet, validity time.Duration) *VerifyingPaymaster {
+	return &VerifyingPaymaster{Address: address, ChainID: chainID, Signer: signer, Validity: validity}
+}
+
+// abiBytesType / abiUint48Type VerifyingPaymaster 哈希编码使用的 ABI 类型
+var (
+	abiBytesType, _  = abi.NewType("bytes", "", nil)
+	abiUint48Type, _ = abi.NewType("uint48", "", nil)
+)
+
+// verifyingPaymasterPackArgs VerifyingPaymaster.pack(userOp)：不包含 paymasterAndData 和签名
+var verifyingPaymasterPackArgs = userOpPackArgs[:9]
+
+// verifyingPaymasterHashArgs abi.encode(pack(userOp), chainid, paymaster, validUntil, validAfter)
+var verifyingPaymasterHashArgs = abi.Arguments{
+	{Type: abiBytesType}, {Type: abiUint256Type}, {Type: abiAddressType}, {Type: abiUint48Type}, {Type: abiUint48Type},
+}
+
+// validityArgs paymasterAndData 中的 abi.encode(validUntil, validAfter)
+var validityArgs = abi.Arguments{{Type: abiUint48Type}, {Type: abiUint48Type}}
+
+// Hash 计算 VerifyingPaymaster 需要签名的哈希（与合约的 getHash 一致）
+// 参数说明：
+//   - op: 用户操作
+//   - validUntil: 赞助失效时间（Unix 秒，0 表示永不过期）
+//   - validAfter: 赞助生效时间（Unix 秒）
+//
+// 返回：
+//   - common.Hash: 待签名的哈希
+//   - error: 如果编码失败则返回错误
+func (p *VerifyingPaymaster) Hash(op *UserOperation, validUntil, validAfter uint64) (common.Hash, error) {
+	packed, err := verifyingPaymasterPackArgs.Pack(
+		op.Sender,
+		bigOrZero(op.Nonce),
+		crypto.Keccak256Hash(op.InitCode),
+		crypto.Keccak256Hash(op.CallData),
+		bigOrZero(op.CallGasLimit),
+		bigOrZero(op.VerificationGasLimit),
+		bigOrZero(op.PreVerificationGas),
+		bigOrZero(op.MaxFeePerGas),
+		bigOrZero(op.MaxPriorityFeePerGas),
+	)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	encoded, err := verifyingPaymasterHashArgs.Pack(
+		packed,
+		bigOrZero(p.ChainID),
+		p.Address,
+		new(big.Int).SetUint64(validUntil),
+		new(big.Int).SetUint64(validAfter),
+	)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash(encoded), nil
+}
+
+// buildPaymasterAndData 拼接 paymaster 地址 ‖ abi.encode(validUntil, validAfter) ‖ 签名
+func (p *VerifyingPaymaster) buildPaymasterAndData(validUntil, validAfter uint64, signature []byte) ([]byte, error) {
+	validity, err := validityArgs.Pack(new(big.Int).SetUint64(validUntil), new(big.Int).SetUint64(validAfter))
+	if err != nil {
+		return nil, err
+	}
+	data := make([]byte, 0, common.AddressLength+len(validity)+len(signature))
+	data = append(data, p.Address.Bytes()...)
+	data = append(data, validity...)
+	return append(data, signature...), nil
+}
+
+// StubPaymasterAndData 返回带占位签名的 paymasterAndData（长度与最终数据相同）
+func (p *VerifyingPaymaster) StubPaymasterAndData(ctx context.Context, op *UserOperation, entryPoint common.Address) ([]byte, error) {
+	return p.buildPaymasterAndData(0, 0, common.FromHex(DummyUserOperationSignature))
+}
+
+// SponsorUserOperation 使用 verifyingSigner 私钥为用户操作签发赞助
+func (p *VerifyingPaymaster) SponsorUserOperation(ctx context.Context, op *UserOperation, entryPoint common.Address) (*PaymasterSponsorship, error) {
+	if p.Signer == nil {
+		return nil, errors.New("verifying paymaster has no signer")
+	}
+
+	var validUntil uint64
+	validAfter := uint64(time.Now().Unix())
+	if p.Validity > 0 {
+		validUntil = validAfter + uint64(p.Validity/time.Second)
+	}
+
+	hash, err := p.Hash(op, validUntil, validAfter)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := p.Signer.SignHash(common.BytesToHash(accounts.TextHash(hash.Bytes())))
+	if err != nil {
+		return nil, err
+	}
+	paymasterAndData, err := p.buildPaymasterAndData(validUntil, validAfter, signature)
+	if err != nil {
+		return nil, err
+	}
+	return &PaymasterSponsorship{PaymasterAndData: paymasterAndData}, nil
+}
+
+// BuildSponsoredUserOperation 构建由 paymaster 赞助 gas 的用户操作（尚未签名）
+// 参数说明：
+//   - ctx: 上下文对象
+//   - bundler: bundler 客户端
+//   - paymaster: paymaster 客户端（如 RPCPaymaster 或 VerifyingPaymaster）
+//   - sender: 智能合约账户地址
+//   - callData: 账户执行的调用数据
+//   - initCode: 账户部署代码（账户已部署时传 nil）
+//
+// 返回：
+//   - *UserOperation: 已填充 gas 字段和 paymasterAndData 的用户操作
+//   - error: 如果查询、估算或赞助失败则返回错误
+func (k *Kit) BuildSponsoredUserOperation(ctx context.Context, bundler *BundlerClient, paymaster Paymaster, sender common.Address, callData, initCode []byte) (*UserOperation, error) {
+	if paymaster == nil {
+		return nil, errors.New("paymaster cannot be nil")
+	}
+	return k.buildUserOperation(ctx, bundler, paymaster, sender, callData, initCode)
+}
+
+// SendSponsoredUserOperation 构建、申请赞助、签名并提交用户操作（用户无需持有本位币）
+// 参数说明：
+//   - ctx: 上下文对象
+//   - bundler: bundler 客户端
+//   - paymaster: paymaster 客户端
+//   - sender: 智能合约账户地址（Kit 的私钥需要是该账户的 owner）
+//   - callData: 账户执行的调用数据
+//   - initCode: 账户部署代码（账户已部署时传 nil）
+//
+// 返回：
+//   - common.Hash: 用户操作哈希
+//   - error: 如果任一步骤失败则返回错误
+func (k *Kit) SendSponsoredUserOperation(ctx context.Context, bundler *BundlerClient, paymaster Paymaster, sender common.Address, callData, initCode []byte) (common.Hash, error) {
+	op, err := k.BuildSponsoredUserOperation(ctx, bundler, paymaster, sender, callData, initCode)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if err := k.SignUserOperation(ctx, op, bundler.EntryPoint()); err != nil {
+		return common.Hash{}, err
+	}
+	return bundler.SendUserOperation(ctx, op)
+}
@@ -0,0 +1,103 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestVerifyingPaymasterSponsor(t *testing.T) {
+	pk, _ := BuildPrivateKeyFromHex("59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d")
+	signer, err := NewWalletWithComponents(pk, nil)
+	if err != nil {
+		t.Fatalf("NewWalletWithComponents() failed: %v", err)
+	}
+
+	paymaster := NewVerifyingPaymaster(common.HexToAddress("0x3cB2b87D10Ac01736A65688F3e0Fb1b070B4eea3"), big.NewInt(SepoliaChainID), signer, time.Hour)
+	op := &UserOperation{Sender: common.HexToAddress("0x1234"), Nonce: big.NewInt(3), CallGasLimit: big.NewInt(50000)}
+	ctx := context.Background()
+	entryPoint := common.HexToAddress(EntryPointV06Address)
+
+	stub, err := paymaster.StubPaymasterAndData(ctx, op, entryPoint)
+	if err != nil {
+		t.Fatalf("StubPaymasterAndData() failed: %v", err)
+	}
+	sponsorship, err := paymaster.SponsorUserOperation(ctx, op, entryPoint)
+	if err != nil {
+		t.Fatalf("SponsorUserOperation() failed: %v", err)
+	}
+
+	data := sponsorship.PaymasterAndData
+	// paymaster(20) ‖ validUntil/validAfter(64) ‖ signature(65)
+	if len(data) != 20+64+65 || len(stub) != len(data) {
+		t.Fatalf("paymasterAndData length = %d (stub %d), expected %d", len(data), len(stub), 20+64+65)
+	}
+	if common.BytesToAddress(data[:20]) != paymaster.Address {
+		t.Errorf("paymasterAndData prefix = %x, expected paymaster address", data[:20])
+	}
+
+	validUntil := new(big.Int).SetBytes(data[20:52]).Uint64()
+	validAfter := new(big.Int).SetBytes(data[52:84]).Uint64()
+	if validUntil != validAfter+3600 {
+		t.Errorf("validUntil = %d, expected validAfter + 3600 (%d)", validUntil, validAfter+3600)
+	}
+
+	// 签名应能恢复出 verifyingSigner
+	hash, _ := paymaster.Hash(op, validUntil, validAfter)
+	sig := common.CopyBytes(data[84:])
+	sig[64] -= 27
+	pub, err := crypto.SigToPub(accounts.TextHash(hash.Bytes()), sig)
+	if err != nil {
+		t.Fatalf("SigToPub() failed: %v", err)
+	}
+	if crypto.PubkeyToAddress(*pub) != signer.GetAddress() {
+		t.Error("Recovered signer does not match verifying signer")
+	}
+}
+
+func TestRPCPaymasterSponsor(t *testing.T) {
+	var received []json.RawMessage
+	server := newMockRPCServer(t, map[string]mockRPCHandler{
+		"pm_sponsorUserOperation": func(params []json.RawMessage) (interface{}, error) {
+			received = params
+			return map[string]string{
+				"paymasterAndData":     "0xe93eca6595fe94091dc1af46aac2a8b5d7990770",
+				"preVerificationGas":   "0xc350",
+				"verificationGasLimit": "0x30d40",
+			}, nil
+		},
+	})
+
+	paymaster, err := NewRPCPaymaster(server.URL, map[string]string{"type": "payg"})
+	if err != nil {
+		t.Fatalf("NewRPCPaymaster() failed: %v", err)
+	}
+	defer paymaster.Close()
+
+	op := &UserOperation{Sender: common.HexToAddress("0x1234"), CallGasLimit: big.NewInt(10000)}
+	sponsorship, err := paymaster.SponsorUserOperation(context.Background(), op, common.HexToAddress(EntryPointV06Address))
+	if err != nil {
+		t.Fatalf("SponsorUserOperation() failed: %v", err)
+	}
+	if len(received) != 3 {
+		t.Errorf("pm_sponsorUserOperation params = %d, expected 3 (op, entryPoint, context)", len(received))
+	}
+
+	sponsorship.apply(op)
+	if len(op.PaymasterAndData) != 20 {
+		t.Errorf("PaymasterAndData length = %d, expected 20", len(op.PaymasterAndData))
+	}
+	if op.PreVerificationGas.Int64() != 50000 || op.VerificationGasLimit.Int64() != 200000 {
+		t.Errorf("Unexpected gas fields: pvg=%s vgl=%s", op.PreVerificationGas, op.VerificationGasLimit)
+	}
+	// 未返回的字段保持不变
+	if op.CallGasLimit.Int64() != 10000 {
+		t.Errorf("CallGasLimit = %s, expected 10000", op.CallGasLimit)
+	}
+}
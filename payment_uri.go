@@ -0,0 +1,304 @@
+package etherkit
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/shopspring/decimal"
+)
+
+//############ EIP-681 Payment URI ############
+
+// ErrInvalidPaymentURI EIP-681 支付链接格式无效
+var ErrInvalidPaymentURI = errors.New("invalid EIP-681 payment URI")
+
+// PaymentURIScheme EIP-681 支付链接的 scheme
+const PaymentURIScheme = "ethereum"
+
+// PaymentParam 支付链接中的合约方法参数（按出现顺序排列）
+type PaymentParam struct {
+	Type  string // ABI 类型（如 address、uint256）
+	Value string // 参数值（原始字符串）
+}
+
+// PaymentRequest EIP-681 支付请求
+// 本位币转账形如 ethereum:0xabc...@1?value=1e18；
+// 代币转账形如 ethereum:0xToken@1/transfer?address=0xRecipient&uint256=1000000
+type PaymentRequest struct {
+	Target   common.Address // 收款地址（本位币转账）或合约地址（合约调用）
+	ChainID  *big.Int       // 链 ID（nil 表示未指定）
+	Function string         // 合约方法名（空表示本位币转账）
+	Value    *big.Int       // 附带的本位币金额（单位为 Wei，nil 表示未指定）
+	GasLimit uint64         // Gas 限制（0 表示未指定）
+	GasPrice *big.Int       // Gas 价格（nil 表示未指定）
+	Params   []PaymentParam // 合约方法参数
+}
+
+// NewTokenPaymentRequest 创建 ERC20 代币转账的支付请求
+// 参数说明：
+//   - chainID: 链 ID
+//   - token: 代币合约地址
+//   - to: 收款地址
+//   - amount: 转账数量（最小单位）
+func NewTokenPaymentRequest(chainID int64, token, to common.Address, amount *big.Int) *PaymentRequest {
+	return &PaymentRequest{
+		Target:   token,
+		ChainID:  big.NewInt(chainID),
+		Function: "transfer",
+		Params: []PaymentParam{
+			{Type: "address", Value: to.Hex()},
+			{Type: "uint256", Value: bigOrZero(amount).String()},
+		},
+	}
+}
+
+// IsTokenTransfer 是否为 ERC20 transfer 支付请求
+func (r *PaymentRequest) IsTokenTransfer() bool {
+	return r.Function == "transfer" && len(r.Params) == 2 &&
+		r.Params[0].Type == "address" && r.Params[1].Type == "uint256"
+}
+
+// Recipient 返回实际收款地址（代币转账为 transfer 的接收地址，否则为 Target）
+func (r *PaymentRequest) Recipient() common.Address {
+	if r.IsTokenTransfer() && common.IsHexAddress(r.Params[0].Value) {
+		return common.HexToAddress(r.Params[0].Value)
+	}
+	return r.Target
+}
+
+// CallData 按参数类型构建合约调用数据（本位币转账返回 nil）
+// 返回：
+//   - []byte: 调用数据
+//   - error: 如果参数类型或参数值无效则返回错误
+func (r *PaymentRequest) CallData() ([]byte, error) {
+	if r.Function == "" {
+		return nil, nil
+	}
+	args := make(abi.Arguments, len(r.Params))
+	values := make([]interface{}, len(r.Params))
+	for i, param := range r.Params {
+		typ, err := abi.NewType(param.Type, "", nil)
+		if err != nil {
+			return nil, fmt.Errorf("%w: parameter %d: %w", ErrInvalidPaymentURI, i, err)
+		}
+		value, err := parsePaymentParamValue(typ, param.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: parameter %d (%s): %w", ErrInvalidPaymentURI, i, param.Type, err)
+		}
+		args[i] = abi.Argument{Type: typ}
+		values[i] = value
+	}
+	packed, err := args.Pack(values...)
+	if err != nil {
+		return nil, err
+	}
+	selector := abi.NewMethod(r.Function, r.Function, abi.Function, "", false, false, args, nil).ID
+	return append(selector, packed...), nil
+}
+
+// parsePaymentParamValue 将参数字符串转换为 ABI 编码所需的 Go 值
+func parsePaymentParamValue(typ abi.Type, value string) (interface{}, error) {
+	switch typ.T {
+	case abi.AddressTy:
+		if !common.IsHexAddress(value) {
+			return nil, fmt.Errorf("invalid address %q", value)
+		}
+		return common.HexToAddress(value), nil
+	case abi.UintTy, abi.IntTy:
+		n, err := parsePaymentNumber(value)
+		if err != nil {
+			return nil, err
+		}
+		if typ.T == abi.UintTy && n.Sign() < 0 {
+			return nil, fmt.Errorf("negative value %s for %s", n, typ)
+		}
+		if !integerFits(typ, n) {
+			return nil, fmt.Errorf("value %s out of range for %s", n, typ)
+		}
+		return abi.ReadInteger(typ, math.U256Bytes(n))
+	case abi.BoolTy:
+		return strconv.ParseBool(value)
+	case abi.StringTy:
+		return value, nil
+	case abi.BytesTy:
+		return hexutil.Decode(value)
+	case abi.FixedBytesTy:
+		b, err := hexutil.Decode(value)
+		if err != nil {
+			return nil, err
+		}
+		if len(b) != typ.Size {
+			return nil, fmt.Errorf("expected %d bytes, got %d", typ.Size, len(b))
+		}
+		arr := reflect.New(typ.GetType()).Elem()
+		reflect.Copy(arr, reflect.ValueOf(b))
+		return arr.Interface(), nil
+	}
+	return nil, fmt.Errorf("unsupported parameter type %s", typ)
+}
+
+// integerFits 判断整数是否在 ABI 整数类型（uintN / intN）的取值范围内
+func integerFits(typ abi.Type, n *big.Int) bool {
+	if typ.T == abi.UintTy {
+		return n.Sign() >= 0 && n.BitLen() <= typ.Size
+	}
+	if n.Sign() >= 0 {
+		return n.BitLen() < typ.Size
+	}
+	// intN 的最小值为 -2^(N-1)，即 |n|-1 不超过 N-1 位
+	return new(big.Int).Sub(new(big.Int).Neg(n), big.NewInt(1)).BitLen() < typ.Size
+}
+
+// parsePaymentAmount 解析金额类保留参数（value、gasPrice），结果必须是非负的 uint256
+func parsePaymentAmount(value string) (*big.Int, error) {
+	n, err := parsePaymentNumber(value)
+	if err != nil {
+		return nil, err
+	}
+	if n.Sign() < 0 || n.BitLen() > 256 {
+		return nil, fmt.Errorf("number %q out of uint256 range", value)
+	}
+	return n, nil
+}
+
+// parsePaymentNumber 解析 EIP-681 数字（支持科学计数法，如 2.014e18），结果必须是整数
+func parsePaymentNumber(value string) (*big.Int, error) {
+	d, err := decimal.NewFromString(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid number %q", value)
+	}
+	if !d.IsInteger() {
+		return nil, fmt.Errorf("number %q is not an integer", value)
+	}
+	return d.BigInt(), nil
+}
+
+// ParsePaymentURI 解析 EIP-681 支付链接
+// 支持 ethereum:[pay-]<地址>[@链ID][/方法名][?参数] 形式；value、gas/gasLimit、gasPrice 为保留参数，
+// 其余参数按 ABI 类型作为方法参数（按出现顺序）。不支持 ENS 名称作为目标地址
+// 参数说明：
+//   - uri: 支付链接（如扫描二维码得到的字符串）
+//
+// 返回：
+//   - *PaymentRequest: 支付请求
+//   - error: 如果链接格式无效则返回包装了 ErrInvalidPaymentURI 的错误
+//
+// 使用示例：
+//
+//	req, err := ParsePaymentURI("ethereum:0xA0b8...eB48@1/transfer?address=0x7099...79C8&uint256=1e6")
+//	data, err := req.CallData()
+//	hash, err := kit.SendTx(ctx, req.Target, 0, req.GasLimit, req.GasPrice, req.Value, data)
+func ParsePaymentURI(uri string) (*PaymentRequest, error) {
+	rest, ok := strings.CutPrefix(uri, PaymentURIScheme+":")
+	if !ok {
+		return nil, fmt.Errorf("%w: missing %q scheme", ErrInvalidPaymentURI, PaymentURIScheme)
+	}
+	rest = strings.TrimPrefix(rest, "pay-")
+
+	rest, query, _ := strings.Cut(rest, "?")
+	rest, function, _ := strings.Cut(rest, "/")
+	target, chain, hasChain := strings.Cut(rest, "@")
+
+	if !common.IsHexAddress(target) || !strings.HasPrefix(target, "0x") {
+		return nil, fmt.Errorf("%w: invalid target address %q", ErrInvalidPaymentURI, target)
+	}
+	req := &PaymentRequest{Target: common.HexToAddress(target), Function: function}
+	if hasChain {
+		chainID, ok := new(big.Int).SetString(chain, 10)
+		if !ok || chainID.Sign() <= 0 {
+			return nil, fmt.Errorf("%w: invalid chain id %q", ErrInvalidPaymentURI, chain)
+		}
+		req.ChainID = chainID
+	}
+
+	if query == "" {
+		return req, nil
+	}
+	for _, pair := range strings.Split(query, "&") {
+		key, value, _ := strings.Cut(pair, "=")
+		key, err := url.QueryUnescape(key)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidPaymentURI, err)
+		}
+		value, err = url.QueryUnescape(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidPaymentURI, err)
+		}
+		switch key {
+		case "value":
+			if req.Value, err = parsePaymentAmount(value); err != nil {
+				return nil, fmt.Errorf("%w: value: %w", ErrInvalidPaymentURI, err)
+			}
+		case "gas", "gasLimit":
+			gas, err := parsePaymentNumber(value)
+			if err != nil || !gas.IsUint64() {
+				return nil, fmt.Errorf("%w: invalid gas limit %q", ErrInvalidPaymentURI, value)
+			}
+			req.GasLimit = gas.Uint64()
+		case "gasPrice":
+			if req.GasPrice, err = parsePaymentAmount(value); err != nil {
+				return nil, fmt.Errorf("%w: gasPrice: %w", ErrInvalidPaymentURI, err)
+			}
+		default:
+			if req.Function == "" {
+				return nil, fmt.Errorf("%w: parameter %q without function name", ErrInvalidPaymentURI, key)
+			}
+			req.Params = append(req.Params, PaymentParam{Type: key, Value: value})
+		}
+	}
+	return req, nil
+}
+
+// BuildPaymentURI 生成 EIP-681 支付链接（数值均以十进制整数输出）
+// 参数说明：
+//   - req: 支付请求
+//
+// 返回：
+//   - string: 支付链接（可用于生成二维码）
+//   - error: 如果参数类型无效则返回错误
+func BuildPaymentURI(req *PaymentRequest) (string, error) {
+	if req == nil {
+		return "", fmt.Errorf("%w: nil request", ErrInvalidPaymentURI)
+	}
+	var b strings.Builder
+	b.WriteString(PaymentURIScheme + ":" + req.Target.Hex())
+	if req.ChainID != nil {
+		b.WriteString("@" + req.ChainID.String())
+	}
+	if req.Function != "" {
+		b.WriteString("/" + req.Function)
+	}
+
+	var query []string
+	for _, param := range req.Params {
+		if req.Function == "" {
+			return "", fmt.Errorf("%w: parameters require a function name", ErrInvalidPaymentURI)
+		}
+		if _, err := abi.NewType(param.Type, "", nil); err != nil {
+			return "", fmt.Errorf("%w: invalid parameter type %q", ErrInvalidPaymentURI, param.Type)
+		}
+		query = append(query, param.Type+"="+url.QueryEscape(param.Value))
+	}
+	if req.Value != nil {
+		query = append(query, "value="+req.Value.String())
+	}
+	if req.GasLimit > 0 {
+		query = append(query, "gasLimit="+strconv.FormatUint(req.GasLimit, 10))
+	}
+	if req.GasPrice != nil {
+		query = append(query, "gasPrice="+req.GasPrice.String())
+	}
+	if len(query) > 0 {
+		b.WriteString("?" + strings.Join(query, "&"))
+	}
+	return b.String(), nil
+}
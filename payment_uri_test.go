@@ -0,0 +1,138 @@
+package etherkit
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestParsePaymentURI(t *testing.T) {
+	recipient := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	token := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+
+	tests := []struct {
+		name      string
+		uri       string
+		target    common.Address
+		chainID   int64
+		value     string
+		recipient common.Address
+		wantErr   bool
+	}{
+		{"ether transfer", "ethereum:0x70997970C51812dc3A010C7d01b50e0d17dc79C8?value=2.014e18", recipient, 0, "2014000000000000000", recipient, false},
+		{"pay prefix with chain", "ethereum:pay-0x70997970C51812dc3A010C7d01b50e0d17dc79C8@137?value=1", recipient, 137, "1", recipient, false},
+		{"token transfer", "ethereum:0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48@1/transfer?address=0x70997970C51812dc3A010C7d01b50e0d17dc79C8&uint256=1e6", token, 1, "", recipient, false},
+		{"missing scheme", "bitcoin:0x70997970C51812dc3A010C7d01b50e0d17dc79C8", common.Address{}, 0, "", common.Address{}, true},
+		{"ens name", "ethereum:alice.eth?value=1", common.Address{}, 0, "", common.Address{}, true},
+		{"fractional value", "ethereum:0x70997970C51812dc3A010C7d01b50e0d17dc79C8?value=0.5", common.Address{}, 0, "", common.Address{}, true},
+		{"bad chain id", "ethereum:0x70997970C51812dc3A010C7d01b50e0d17dc79C8@main", common.Address{}, 0, "", common.Address{}, true},
+		{"negative value", "ethereum:0x70997970C51812dc3A010C7d01b50e0d17dc79C8?value=-1", common.Address{}, 0, "", common.Address{}, true},
+		{"negative gas price", "ethereum:0x70997970C51812dc3A010C7d01b50e0d17dc79C8?value=1&gasPrice=-1e9", common.Address{}, 0, "", common.Address{}, true},
+		{"value above uint256", "ethereum:0x70997970C51812dc3A010C7d01b50e0d17dc79C8?value=1e80", common.Address{}, 0, "", common.Address{}, true},
+		{"param without function", "ethereum:0x70997970C51812dc3A010C7d01b50e0d17dc79C8?uint256=1", common.Address{}, 0, "", common.Address{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := ParsePaymentURI(tt.uri)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePaymentURI() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !errors.Is(err, ErrInvalidPaymentURI) {
+					t.Errorf("error %v should wrap ErrInvalidPaymentURI", err)
+				}
+				return
+			}
+			if req.Target != tt.target || req.Recipient() != tt.recipient {
+				t.Errorf("Target = %s, Recipient = %s", req.Target.Hex(), req.Recipient().Hex())
+			}
+			if tt.chainID != 0 && (req.ChainID == nil || req.ChainID.Int64() != tt.chainID) {
+				t.Errorf("ChainID = %v, expected %d", req.ChainID, tt.chainID)
+			}
+			if tt.value != "" && (req.Value == nil || req.Value.String() != tt.value) {
+				t.Errorf("Value = %v, expected %s", req.Value, tt.value)
+			}
+		})
+	}
+}
+
+func TestPaymentURIRoundTrip(t *testing.T) {
+	token := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	recipient := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	req := NewTokenPaymentRequest(MainnetChainID, token, recipient, big.NewInt(1500000))
+	req.GasLimit = 65000
+
+	uri, err := BuildPaymentURI(req)
+	if err != nil {
+		t.Fatalf("BuildPaymentURI() failed: %v", err)
+	}
+	expected := "ethereum:" + token.Hex() + "@1/transfer?address=" + recipient.Hex() + "&uint256=1500000&gasLimit=65000"
+	if uri != expected {
+		t.Errorf("BuildPaymentURI() = %s, expected %s", uri, expected)
+	}
+
+	parsed, err := ParsePaymentURI(uri)
+	if err != nil {
+		t.Fatalf("ParsePaymentURI() failed: %v", err)
+	}
+	if !parsed.IsTokenTransfer() || parsed.GasLimit != 65000 {
+		t.Errorf("parsed = %+v", parsed)
+	}
+
+	// 调用数据应与 ERC20 transfer 编码一致
+	data, err := parsed.CallData()
+	if err != nil {
+		t.Fatalf("CallData() failed: %v", err)
+	}
+	want, _ := erc20ABI.Pack("transfer", recipient, big.NewInt(1500000))
+	if !bytes.Equal(data, want) {
+		t.Errorf("CallData() = %x, expected %x", data, want)
+	}
+
+	// 小位宽整数和定长字节参数
+	custom := &PaymentRequest{Target: token, Function: "set", Params: []PaymentParam{
+		{Type: "uint8", Value: "7"},
+		{Type: "bytes4", Value: "0xdeadbeef"},
+	}}
+	if _, err := custom.CallData(); err != nil {
+		t.Errorf("CallData() with uint8/bytes4 failed: %v", err)
+	}
+	custom.Params[0].Value = "300"
+	if _, err := custom.CallData(); err == nil {
+		t.Error("Expected overflow error for uint8 = 300")
+	}
+}
+
+func TestPaymentCallDataRange(t *testing.T) {
+	token := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	tests := []struct {
+		typ     string
+		value   string
+		wantErr bool
+	}{
+		{"uint256", "115792089237316195423570985008687907853269984665640564039457584007913129639935", false},
+		{"uint256", "1e80", true},
+		{"uint8", "255", false},
+		{"uint8", "256", true},
+		{"int8", "127", false},
+		{"int8", "128", true},
+		{"int8", "-128", false},
+		{"int8", "-129", true},
+		{"int256", "-1e80", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.typ+"="+tt.value, func(t *testing.T) {
+			req := &PaymentRequest{Target: token, Function: "set", Params: []PaymentParam{{Type: tt.typ, Value: tt.value}}}
+			_, err := req.CallData()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CallData() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidPaymentURI) {
+				t.Errorf("error %v should wrap ErrInvalidPaymentURI", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package etherkit
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+//############ Own Pending Transactions ############
+
+// DefaultSignedTxHistory Kit 记录的最近签名交易数量（用于计算待处理交易的等待时长）
+const DefaultSignedTxHistory = 1024
+
+// PendingTx 账户尚未打包的交易
+type PendingTx struct {
+	Nonce     uint64             // 交易 nonce
+	Hash      common.Hash        // 交易哈希（交易内容未知时为空）
+	To        *common.Address    // 接收地址（合约创建或交易内容未知时为 nil）
+	Value     *big.Int           // 转账金额（交易内容未知时为 nil）
+	GasFeeCap *big.Int           // 最高 gas 价格（legacy 交易为 gasPrice，交易内容未知时为 nil）
+	GasTipCap *big.Int           // 优先费（legacy 交易为 gasPrice，交易内容未知时为 nil）
+	Queued    bool               // 是否在 queued 队列中（因 nonce 间隙暂不可执行）
+	Age       time.Duration      // 自本 Kit 首次签名该 nonce 以来的时长（0 表示未知，如由其他程序发送）
+	Tx        *types.Transaction // 交易内容（交易池不可查询且不是本 Kit 签名时为 nil）
+}
+
+// signedTxRecord 本 Kit 签名的交易记录
+type signedTxRecord struct {
+	tx           *types.Transaction // 该 nonce 最近一次签名的交易（替换交易会覆盖原交易）
+	firstSigned  time.Time          // 该 nonce 首次签名的时间
+	replacements int                // 该 nonce 已签名的替换交易数量
+}
+
+// recordSigned 记录签名的交易，同一 nonce 的替换交易保留首次签名时间和替换次数
+func (k *Kit) recordSigned(tx *types.Transaction) {
+	if k.signed == nil {
+		return
+	}
+	record := signedTxRecord{tx: tx, firstSigned: time.Now()}
+	if prev, ok := k.signed.Get(tx.Nonce()); ok {
+		record.firstSigned = prev.firstSigned
+		record.replacements = prev.replacements
+	}
+	k.signed.Add(tx.Nonce(), record)
+}
+
+// GetPendingTransactions 列出账户尚未打包的交易（按 nonce 升序）
+// 节点支持 txpool_contentFrom 时返回交易池中的 pending 和 queued 交易；
+// 否则按 nonce 区间 [latest, pending) 列出，交易内容来自本 Kit 的签名记录（没有记录的 nonce 只有 Nonce 字段）
+// 参数说明：
+//   - ctx: 上下文对象
+//
+// 返回：
+//   - []PendingTx: 待处理交易列表
+//   - error: 如果 Kit 为只读（ErrReadOnly）或查询 nonce 失败则返回错误
+//
+// 使用示例：
+//
+//	txs, err := kit.GetPendingTransactions(ctx)
+//	for _, tx := range txs {
+//	    if tx.Age > 10*time.Minute {
+//	        // 交易可能卡住，考虑加价替换或 CancelAllPending
+//	    }
+//	}
+func (k *Kit) GetPendingTransactions(ctx context.Context) ([]PendingTx, error) {
+	if k.IsReadOnly() {
+		return nil, ErrReadOnly
+	}
+	self := k.GetAddress()
+	var txs []PendingTx
+	if pool, err := GetTxPoolContentFrom(ctx, k.EtherProvider, self); err == nil {
+		for _, tx := range pool.Pending {
+			txs = append(txs, k.newPendingTx(tx.Nonce(), tx, false))
+		}
+		for _, tx := range pool.Queued {
+			txs = append(txs, k.newPendingTx(tx.Nonce(), tx, true))
+		}
+	} else {
+		latest, pending, err := GetNonceRange(ctx, k.EtherProvider, self)
+		if err != nil {
+			return nil, err
+		}
+		for nonce := latest; nonce < pending; nonce++ {
+			txs = append(txs, k.newPendingTx(nonce, nil, false))
+		}
+	}
+	sort.Slice(txs, func(i, j int) bool { return txs[i].Nonce < txs[j].Nonce })
+	return txs, nil
+}
+
+// newPendingTx 构建待处理交易信息，tx 为 nil 时使用本 Kit 的签名记录补全
+func (k *Kit) newPendingTx(nonce uint64, tx *types.Transaction, queued bool) PendingTx {
+	pending := PendingTx{Nonce: nonce, Queued: queued}
+	if k.signed != nil {
+		// 交易池中的交易不是本 Kit 签名的（如由其他程序发送）时等待时长未知
+		if record, ok := k.signed.Get(nonce); ok && (tx == nil || tx.Hash() == record.tx.Hash()) {
+			tx = record.tx
+			pending.Age = time.Since(record.firstSigned)
+		}
+	}
+	if tx != nil {
+		pending.Hash = tx.Hash()
+		pending.To = tx.To()
+		pending.Value = tx.Value()
+		pending.GasFeeCap = tx.GasFeeCap()
+		pending.GasTipCap = tx.GasTipCap()
+		pending.Tx = tx
+	}
+	return pending
+}
@@ -0,0 +1,79 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestGetPendingTransactions(t *testing.T) {
+	recipient := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	pk, _ := BuildPrivateKeyFromHex("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	// 由其他程序发送的交易
+	foreign, _ := types.SignTx(types.NewTx(&types.LegacyTx{Nonce: 9, Gas: 21000, GasPrice: big.NewInt(2e9), Value: big.NewInt(1)}), types.NewLondonSigner(big.NewInt(1)), pk)
+
+	server := newMockSendServer(t)
+	server.handlers["eth_getTransactionCount"] = func(params []json.RawMessage) (interface{}, error) {
+		var tag string
+		_ = json.Unmarshal(params[1], &tag)
+		if tag == "pending" {
+			return "0x7", nil
+		}
+		return "0x5", nil
+	}
+	kit := newMockKit(t, server)
+	ctx := context.Background()
+
+	if _, err := kit.SendTx(ctx, recipient, 5, 21000, big.NewInt(3e9), big.NewInt(1), nil); err != nil {
+		t.Fatalf("SendTx() failed: %v", err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	// 替换交易保留首次签名时间
+	if _, err := kit.SendTx(ctx, recipient, 5, 21000, big.NewInt(4e9), big.NewInt(1), nil); err != nil {
+		t.Fatalf("SendTx() failed: %v", err)
+	}
+	record, _ := kit.signed.Get(5)
+	own := record.tx
+
+	t.Run("nonce range fallback", func(t *testing.T) {
+		txs, err := kit.GetPendingTransactions(ctx)
+		if err != nil {
+			t.Fatalf("GetPendingTransactions() failed: %v", err)
+		}
+		if len(txs) != 2 {
+			t.Fatalf("len(txs) = %d, expected 2", len(txs))
+		}
+		if txs[0].Nonce != 5 || txs[0].Hash != own.Hash() || txs[0].GasFeeCap.Int64() != 4e9 || txs[0].Age < 5*time.Millisecond {
+			t.Errorf("txs[0] = %+v", txs[0])
+		}
+		// 没有签名记录的 nonce 只有 Nonce 字段
+		if txs[1].Nonce != 6 || txs[1].Tx != nil || txs[1].Age != 0 {
+			t.Errorf("txs[1] = %+v", txs[1])
+		}
+	})
+
+	t.Run("txpool", func(t *testing.T) {
+		server.handlers["txpool_contentFrom"] = mockResult(map[string]map[string]*types.Transaction{
+			"pending": {"5": own},
+			"queued":  {"9": foreign},
+		})
+		txs, err := kit.GetPendingTransactions(ctx)
+		if err != nil {
+			t.Fatalf("GetPendingTransactions() failed: %v", err)
+		}
+		if len(txs) != 2 {
+			t.Fatalf("len(txs) = %d, expected 2", len(txs))
+		}
+		if txs[0].Nonce != 5 || txs[0].Queued || txs[0].Age == 0 {
+			t.Errorf("txs[0] = %+v", txs[0])
+		}
+		if txs[1].Nonce != 9 || !txs[1].Queued || txs[1].Hash != foreign.Hash() || txs[1].Age != 0 {
+			t.Errorf("txs[1] = %+v", txs[1])
+		}
+	})
+}
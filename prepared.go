@@ -0,0 +1,134 @@
+package etherkit
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+//############ Prepared Transaction ############
+
+// PreparedTx 已签名、待广播的交易（可序列化为 JSON 持久化，由其他进程稍后提交）
+type PreparedTx struct {
+	ChainID *big.Int       `json:"chainId"` // 链 ID
+	From    common.Address `json:"from"`    // 发送地址
+	Nonce   uint64         `json:"nonce"`   // 交易 nonce
+	Hash    common.Hash    `json:"hash"`    // 交易哈希
+	Raw     hexutil.Bytes  `json:"raw"`     // 已签名的原始交易（RLP / EIP-2718 编码）
+}
+
+// Transaction 解码并校验原始交易（哈希、链 ID 和签名者必须与记录一致）
+// 返回：
+//   - *types.Transaction: 已签名的交易
+//   - error: 如果原始交易无法解码或与记录不一致则返回错误
+func (p *PreparedTx) Transaction() (*types.Transaction, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(p.Raw); err != nil {
+		return nil, fmt.Errorf("invalid prepared transaction: %w", err)
+	}
+	if tx.Hash() != p.Hash {
+		return nil, fmt.Errorf("prepared transaction hash %s does not match raw transaction %s", p.Hash.Hex(), tx.Hash().Hex())
+	}
+	if p.ChainID != nil && tx.ChainId().Cmp(p.ChainID) != 0 {
+		return nil, fmt.Errorf("prepared transaction chain id %s does not match raw transaction %s", p.ChainID, tx.ChainId())
+	}
+	from, err := txSender(tx)
+	if err != nil {
+		return nil, err
+	}
+	if from != p.From {
+		return nil, fmt.Errorf("prepared transaction signed by %s, expected %s", from.Hex(), p.From.Hex())
+	}
+	return tx, nil
+}
+
+// PrepareTx 构建并签名交易但不广播，返回可持久化的交易句柄
+// 签名流程与 SendTx 相同（执行审核回调并写入审计记录），签名后可通过 SubmitPrepared 在任意时间、任意进程提交
+// 参数说明：
+//   - ctx: 上下文对象
+//   - to: 接收地址
+//   - nonce: 交易 nonce（0 表示自动计算；同时准备多笔交易时应显式指定）
+//   - gasLimit: Gas 限制（0 表示自动估算）
+//   - gasPrice: Gas 价格（nil 表示自动获取）
+//   - value: 转账金额（nil 表示不转账）
+//   - data: 交易数据
+//
+// 返回：
+//   - *PreparedTx: 已签名的交易句柄
+//   - error: 如果构建、审核或签名失败则返回错误
+func (k *Kit) PrepareTx(ctx context.Context, to common.Address, nonce, gasLimit uint64, gasPrice, value *big.Int, data []byte) (*PreparedTx, error) {
+	tx, err := k.buildTx(ctx, to, nonce, gasLimit, gasPrice, value, data)
+	if err != nil {
+		return nil, err
+	}
+	signedTx, err := k.signTx(ctx, tx, nil)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := signedTx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return &PreparedTx{
+		ChainID: signedTx.ChainId(),
+		From:    k.GetAddress(),
+		Nonce:   signedTx.Nonce(),
+		Hash:    signedTx.Hash(),
+		Raw:     raw,
+	}, nil
+}
+
+// SubmitPrepared 广播 PrepareTx 生成的交易（不需要私钥）
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者（链 ID 必须与交易一致）
+//   - prepared: 交易句柄
+//
+// 返回：
+//   - common.Hash: 交易哈希
+//   - error: 如果交易校验失败、链 ID 不匹配、余额不足（*InsufficientFundsError）或广播失败则返回错误
+func SubmitPrepared(ctx context.Context, ep EtherProvider, prepared *PreparedTx) (common.Hash, error) {
+	tx, err := checkPrepared(ctx, ep, prepared)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if err := providerExt[TxBroadcaster](ep).SendTransaction(ctx, tx); err != nil {
+		return common.Hash{}, NormalizeError(err)
+	}
+	return tx.Hash(), nil
+}
+
+// checkPrepared 解码交易句柄并检查链 ID 和余额
+func checkPrepared(ctx context.Context, ep EtherProvider, prepared *PreparedTx) (*types.Transaction, error) {
+	tx, err := prepared.Transaction()
+	if err != nil {
+		return nil, err
+	}
+	chainId, err := ep.GetChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if chainId.Cmp(tx.ChainId()) != 0 {
+		return nil, fmt.Errorf("prepared transaction is for chain %s, provider is connected to chain %s", tx.ChainId(), chainId)
+	}
+	if err := CheckFunds(ctx, ep, prepared.From, tx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// SubmitPrepared 使用 Kit 的 Provider 广播 PrepareTx 生成的交易（配置了 WithTxStore 时在广播前持久化）
+func (k *Kit) SubmitPrepared(ctx context.Context, prepared *PreparedTx) (common.Hash, error) {
+	tx, err := checkPrepared(ctx, k.EtherProvider, prepared)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if err := k.broadcastTx(ctx, tx); err != nil {
+		return common.Hash{}, err
+	}
+	return tx.Hash(), nil
+}
@@ -0,0 +1,65 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestPrepareAndSubmit(t *testing.T) {
+	recipient := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	server := newMockSendServer(t)
+	kit := newMockKit(t, server)
+
+	prepared, err := kit.PrepareTx(context.Background(), recipient, 0, 21000, nil, big.NewInt(1), nil)
+	if err != nil {
+		t.Fatalf("PrepareTx() failed: %v", err)
+	}
+	if server.callCount("eth_sendRawTransaction") != 0 {
+		t.Fatal("PrepareTx() should not broadcast")
+	}
+
+	// 模拟持久化后由另一个进程（只有 Provider）提交
+	data, err := json.Marshal(prepared)
+	if err != nil {
+		t.Fatalf("json.Marshal() failed: %v", err)
+	}
+	var restored PreparedTx
+	if err := json.Unmarshal(data, &restored); err != nil {
+		t.Fatalf("json.Unmarshal() failed: %v", err)
+	}
+	provider, _ := NewProvider(server.URL)
+	defer provider.Close()
+
+	hash, err := SubmitPrepared(context.Background(), provider, &restored)
+	if err != nil {
+		t.Fatalf("SubmitPrepared() failed: %v", err)
+	}
+	if hash != prepared.Hash || server.callCount("eth_sendRawTransaction") != 1 {
+		t.Errorf("SubmitPrepared() = %s, want %s", hash.Hex(), prepared.Hash.Hex())
+	}
+
+	t.Run("rejects tampered handle", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			tamper func(p *PreparedTx)
+		}{
+			{"hash", func(p *PreparedTx) { p.Hash = common.Hash{1} }},
+			{"from", func(p *PreparedTx) { p.From = recipient }},
+			{"chain id", func(p *PreparedTx) { p.ChainID = big.NewInt(5) }},
+			{"raw", func(p *PreparedTx) { p.Raw = p.Raw[:10] }},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				p := restored
+				tt.tamper(&p)
+				if _, err := SubmitPrepared(context.Background(), provider, &p); err == nil {
+					t.Error("SubmitPrepared() should reject tampered handle")
+				}
+			})
+		}
+	})
+}
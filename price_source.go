@@ -0,0 +1,278 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/shopspring/decimal"
+)
+
+//############ Fiat Price ############
+
+// 价格来源相关错误
+var (
+	ErrPriceUnavailable = errors.New("price unavailable")
+	ErrNoPriceSource    = errors.New("no price source configured")
+)
+
+// 常用的 Chainlink 价格源（以太坊主网）
+const (
+	ChainlinkETHUSDFeed  = "0x5f4eC3Df9cbd43714FE2740f5E3616155c5b8419"
+	ChainlinkBTCUSDFeed  = "0xF4030086522a5bEEa4988F8cA5B36dbC97BeE88c"
+	ChainlinkUSDCUSDFeed = "0x8fFfFfd4AfB6115b954Bd326cbe7B4BA576818f6"
+)
+
+// DefaultCoingeckoURL Coingecko 公共 API 地址
+const DefaultCoingeckoURL = "https://api.coingecko.com/api/v3"
+
+// PriceSource 资产的法币价格来源
+type PriceSource interface {
+	// USDPrice 获取资产的美元价格
+	// 参数说明：
+	//   - ctx: 上下文对象
+	//   - symbol: 资产符号（如 "ETH"、"USDC"，不区分大小写）
+	// 返回：
+	//   - decimal.Decimal: 1 个资产对应的美元价格
+	//   - error: 不支持该资产时返回包装了 ErrPriceUnavailable 的错误
+	USDPrice(ctx context.Context, symbol string) (decimal.Decimal, error)
+}
+
+// FixedPriceSource 固定汇率的价格来源（用于测试、离线报表或稳定币）
+// 键为资产符号（大写）
+type FixedPriceSource map[string]decimal.Decimal
+
+// USDPrice 实现 PriceSource 接口
+func (f FixedPriceSource) USDPrice(ctx context.Context, symbol string) (decimal.Decimal, error) {
+	price, ok := f[strings.ToUpper(symbol)]
+	if !ok {
+		return decimal.Zero, fmt.Errorf("%w: no fixed rate for %s", ErrPriceUnavailable, symbol)
+	}
+	return price, nil
+}
+
+// chainlinkAggregatorABI Chainlink AggregatorV3Interface 中用到的方法
+const chainlinkAggregatorABI = `[
+{"inputs":[],"name":"decimals","outputs":[{"internalType":"uint8","name":"","type":"uint8"}],"stateMutability":"view","type":"function"},
+{"inputs":[],"name":"latestRoundData","outputs":[{"internalType":"uint80","name":"roundId","type":"uint80"},{"internalType":"int256","name":"answer","type":"int256"},{"internalType":"uint256","name":"startedAt","type":"uint256"},{"internalType":"uint256","name":"updatedAt","type":"uint256"},{"internalType":"uint80","name":"answeredInRound","type":"uint80"}],"stateMutability":"view","type":"function"}
+]`
+
+// ChainlinkPriceSource 基于 Chainlink 价格源合约的价格来源
+type ChainlinkPriceSource struct {
+	Feeds  map[string]common.Address // 资产符号（大写）→ USD 价格源合约地址
+	MaxAge time.Duration             // 价格最长有效期（0 表示不检查，超过时返回错误）
+	ep     EtherProvider
+}
+
+// NewChainlinkPriceSource 创建 Chainlink 价格来源
+// 参数说明：
+//   - ep: 价格源所在链的以太坊提供者
+//   - feeds: 资产符号 → USD 价格源合约地址（如 {"ETH": common.HexToAddress(ChainlinkETHUSDFeed)}）
+//
+// 返回：
+//   - *ChainlinkPriceSource: 价格来源实例
+func NewChainlinkPriceSource(ep EtherProvider, feeds map[string]common.Address) *ChainlinkPriceSource {
+	normalized := make(map[string]common.Address, len(feeds))
+	for symbol, feed := range feeds {
+		normalized[strings.ToUpper(symbol)] = feed
+	}
+	return &ChainlinkPriceSource{Feeds: normalized, ep: ep}
+}
+
+// USDPrice 实现 PriceSource 接口（读取 latestRoundData 并按 decimals 换算）
+func (c *ChainlinkPriceSource) USDPrice(ctx context.Context, symbol string) (decimal.Decimal, error) {
+	feed, ok := c.Feeds[strings.ToUpper(symbol)]
+	if !ok {
+		return decimal.Zero, fmt.Errorf("%w: no chainlink feed for %s", ErrPriceUnavailable, symbol)
+	}
+	parsed, err := GetABI(chainlinkAggregatorABI)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	call := func(method string) ([]interface{}, error) {
+		data, err := parsed.Pack(method)
+		if err != nil {
+			return nil, err
+		}
+		res, err := providerExt[BlockRefReader](c.ep).CallContractAt(ctx, ethereum.CallMsg{To: &feed, Data: data}, BlockRef{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to call chainlink feed %s: %w", feed.Hex(), err)
+		}
+		return parsed.Unpack(method, res)
+	}
+
+	round, err := call("latestRoundData")
+	if err != nil {
+		return decimal.Zero, err
+	}
+	decimals, err := call("decimals")
+	if err != nil {
+		return decimal.Zero, err
+	}
+	answer := round[1].(*big.Int)
+	if answer.Sign() <= 0 {
+		return decimal.Zero, fmt.Errorf("%w: chainlink feed %s returned non-positive answer", ErrPriceUnavailable, feed.Hex())
+	}
+	if c.MaxAge > 0 {
+		updatedAt := time.Unix(round[3].(*big.Int).Int64(), 0)
+		if age := time.Since(updatedAt); age > c.MaxAge {
+			return decimal.Zero, fmt.Errorf("%w: chainlink feed %s is stale (updated %s ago)", ErrPriceUnavailable, feed.Hex(), age.Round(time.Second))
+		}
+	}
+	return ToDecimal(answer, int(decimals[0].(uint8))), nil
+}
+
+// CoingeckoPriceSource 基于 Coingecko simple/price 接口的价格来源
+type CoingeckoPriceSource struct {
+	BaseURL    string            // API 地址（默认 DefaultCoingeckoURL，Pro 用户可改为 pro-api 地址）
+	APIKey     string            // API Key（为空表示匿名访问）
+	IDs        map[string]string // 资产符号（大写）→ Coingecko 币种 ID
+	HTTPClient *http.Client      // HTTP 客户端（nil 表示使用 http.DefaultClient）
+}
+
+// NewCoingeckoPriceSource 创建 Coingecko 价格来源（内置常见资产的币种 ID，可通过 IDs 扩展）
+// 参数说明：
+//   - apiKey: Coingecko demo API Key（为空表示匿名访问，容易被限流）
+func NewCoingeckoPriceSource(apiKey string) *CoingeckoPriceSource {
+	return &CoingeckoPriceSource{
+		BaseURL: DefaultCoingeckoURL,
+		APIKey:  apiKey,
+		IDs: map[string]string{
+			"ETH":   "ethereum",
+			"WETH":  "weth",
+			"BTC":   "bitcoin",
+			"MATIC": "matic-network",
+			"POL":   "polygon-ecosystem-token",
+			"BNB":   "binancecoin",
+			"AVAX":  "avalanche-2",
+			"FTM":   "fantom",
+			"USDC":  "usd-coin",
+			"USDT":  "tether",
+			"DAI":   "dai",
+		},
+		HTTPClient: http.DefaultClient,
+	}
+}
+
+// USDPrice 实现 PriceSource 接口
+func (c *CoingeckoPriceSource) USDPrice(ctx context.Context, symbol string) (decimal.Decimal, error) {
+	id, ok := c.IDs[strings.ToUpper(symbol)]
+	if !ok {
+		return decimal.Zero, fmt.Errorf("%w: no coingecko id for %s", ErrPriceUnavailable, symbol)
+	}
+	endpoint := fmt.Sprintf("%s/simple/price?ids=%s&vs_currencies=usd", strings.TrimRight(c.BaseURL, "/"), url.QueryEscape(id))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if c.APIKey != "" {
+		req.Header.Set("x-cg-demo-api-key", c.APIKey)
+	}
+
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("failed to request coingecko: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return decimal.Zero, fmt.Errorf("coingecko returned status %d", resp.StatusCode)
+	}
+
+	var res map[string]map[string]decimal.Decimal
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return decimal.Zero, fmt.Errorf("failed to decode coingecko response: %w", err)
+	}
+	price, ok := res[id]["usd"]
+	if !ok {
+		return decimal.Zero, fmt.Errorf("%w: coingecko has no usd price for %s", ErrPriceUnavailable, id)
+	}
+	return price, nil
+}
+
+// ToUSD 将资产数量（最小单位）换算为美元金额
+// 参数说明：
+//   - ctx: 上下文对象
+//   - source: 价格来源
+//   - symbol: 资产符号
+//   - amount: 数量（最小单位，如 Wei）
+//   - decimals: 资产精度
+//
+// 返回：
+//   - decimal.Decimal: 美元金额
+//   - error: 如果获取价格失败则返回错误
+func ToUSD(ctx context.Context, source PriceSource, symbol string, amount *big.Int, decimals int) (decimal.Decimal, error) {
+	price, err := source.USDPrice(ctx, symbol)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return ToDecimal(amount, decimals).Mul(price), nil
+}
+
+// WithPriceSource 设置 Kit 使用的法币价格来源（用于 CostInUSD、BalanceInUSD）
+func WithPriceSource(source PriceSource) KitOption {
+	return func(k *Kit) {
+		k.priceSource = source
+	}
+}
+
+// nativeToUSD 将本位币数量换算为美元（本位币符号来自链注册表，未注册的链按 ETH 处理）
+func (k *Kit) nativeToUSD(ctx context.Context, amount *big.Int) (decimal.Decimal, error) {
+	if k.priceSource == nil {
+		return decimal.Zero, ErrNoPriceSource
+	}
+	chainId, err := k.GetChainID(ctx)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	symbol := "ETH"
+	if cfg, ok := GetNetworkConfig(chainId.Int64()); ok && cfg.Symbol != "" {
+		symbol = cfg.Symbol
+	}
+	return ToUSD(ctx, k.priceSource, symbol, amount, EthDecimals)
+}
+
+// CostInUSD 计算已打包交易实际支付手续费的美元金额（GasUsed × EffectiveGasPrice）
+// 参数说明：
+//   - ctx: 上下文对象
+//   - receipt: 交易收据
+//
+// 返回：
+//   - decimal.Decimal: 手续费的美元金额
+//   - error: 未设置价格来源时返回 ErrNoPriceSource，获取价格失败时返回对应错误
+func (k *Kit) CostInUSD(ctx context.Context, receipt *types.Receipt) (decimal.Decimal, error) {
+	gasPrice := receipt.EffectiveGasPrice
+	if gasPrice == nil {
+		tx, _, err := k.GetTransactionByHash(ctx, receipt.TxHash)
+		if err != nil {
+			return decimal.Zero, err
+		}
+		gasPrice = tx.GasPrice()
+	}
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), gasPrice)
+	return k.nativeToUSD(ctx, fee)
+}
+
+// BalanceInUSD 获取 Kit 账户本位币余额的美元金额
+// 返回：
+//   - decimal.Decimal: 余额的美元金额
+//   - error: 未设置价格来源时返回 ErrNoPriceSource，查询余额或价格失败时返回对应错误
+func (k *Kit) BalanceInUSD(ctx context.Context) (decimal.Decimal, error) {
+	balance, err := k.GetBalance(ctx)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return k.nativeToUSD(ctx, balance)
+}
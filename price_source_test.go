@@ -0,0 +1,115 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/shopspring/decimal"
+)
+
+func TestKitFiatConversion(t *testing.T) {
+	source := FixedPriceSource{"ETH": decimal.NewFromInt(2000)}
+
+	t.Run("cost in usd", func(t *testing.T) {
+		kit := newMockKit(t, newMockSendServer(t), WithPriceSource(source))
+		// 21000 gas × 10 gwei = 0.00021 ETH = 0.42 USD
+		receipt := &types.Receipt{GasUsed: 21000, EffectiveGasPrice: big.NewInt(10e9)}
+		cost, err := kit.CostInUSD(context.Background(), receipt)
+		if err != nil {
+			t.Fatalf("CostInUSD() failed: %v", err)
+		}
+		if !cost.Equal(decimal.RequireFromString("0.42")) {
+			t.Errorf("CostInUSD() = %s, want 0.42", cost)
+		}
+	})
+
+	t.Run("balance in usd", func(t *testing.T) {
+		kit := newMockKit(t, newMockSendServer(t), WithPriceSource(source))
+		balance, err := kit.BalanceInUSD(context.Background())
+		if err != nil {
+			t.Fatalf("BalanceInUSD() failed: %v", err)
+		}
+		if !balance.Equal(decimal.NewFromInt(2000)) {
+			t.Errorf("BalanceInUSD() = %s, want 2000", balance)
+		}
+	})
+
+	t.Run("no price source", func(t *testing.T) {
+		kit := newMockKit(t, newMockSendServer(t))
+		if _, err := kit.BalanceInUSD(context.Background()); !errors.Is(err, ErrNoPriceSource) {
+			t.Errorf("BalanceInUSD() error = %v, want ErrNoPriceSource", err)
+		}
+	})
+}
+
+func TestChainlinkPriceSource(t *testing.T) {
+	parsed, _ := GetABI(chainlinkAggregatorABI)
+	newSource := func(t *testing.T, updatedAt time.Time) *ChainlinkPriceSource {
+		server := newMockRPCServer(t, map[string]mockRPCHandler{
+			"eth_call": func(params []json.RawMessage) (interface{}, error) {
+				arg, err := parseMockCallArg(params)
+				if err != nil {
+					return nil, err
+				}
+				method, err := parsed.MethodById(arg.calldata()[:4])
+				if err != nil {
+					return nil, err
+				}
+				if method.Name == "decimals" {
+					return packOutputs(method.Outputs, uint8(8))
+				}
+				// 价格 2345.67891234 USD（8 位小数）
+				return packOutputs(method.Outputs, big.NewInt(1), big.NewInt(234567891234), big.NewInt(0), big.NewInt(updatedAt.Unix()), big.NewInt(1))
+			},
+		})
+		provider, _ := NewProvider(server.URL)
+		t.Cleanup(provider.Close)
+		source := NewChainlinkPriceSource(provider, map[string]common.Address{"eth": common.HexToAddress(ChainlinkETHUSDFeed)})
+		source.MaxAge = time.Hour
+		return source
+	}
+
+	price, err := newSource(t, time.Now()).USDPrice(context.Background(), "ETH")
+	if err != nil {
+		t.Fatalf("USDPrice() failed: %v", err)
+	}
+	if !price.Equal(decimal.RequireFromString("2345.67891234")) {
+		t.Errorf("USDPrice() = %s, want 2345.67891234", price)
+	}
+
+	if _, err := newSource(t, time.Now().Add(-2*time.Hour)).USDPrice(context.Background(), "ETH"); !errors.Is(err, ErrPriceUnavailable) {
+		t.Errorf("stale feed error = %v, want ErrPriceUnavailable", err)
+	}
+	if _, err := newSource(t, time.Now()).USDPrice(context.Background(), "DOGE"); !errors.Is(err, ErrPriceUnavailable) {
+		t.Errorf("unknown asset error = %v, want ErrPriceUnavailable", err)
+	}
+}
+
+func TestCoingeckoPriceSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/simple/price" || r.URL.Query().Get("ids") != "ethereum" || r.Header.Get("x-cg-demo-api-key") != "demo" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"ethereum":{"usd":3012.55}}`))
+	}))
+	defer server.Close()
+
+	source := NewCoingeckoPriceSource("demo")
+	source.BaseURL = server.URL
+	price, err := source.USDPrice(context.Background(), "eth")
+	if err != nil {
+		t.Fatalf("USDPrice() failed: %v", err)
+	}
+	if !price.Equal(decimal.RequireFromString("3012.55")) {
+		t.Errorf("USDPrice() = %s, want 3012.55", price)
+	}
+}
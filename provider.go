@@ -3,11 +3,14 @@ package etherkit
 import (
 	"context"
 	"encoding/hex"
-	"fmt"
+	"encoding/json"
+	"errors"
 	"math/big"
+	"sync/atomic"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -135,8 +138,8 @@ type EtherProvider interface {
 	//   - ctx: 上下文对象
 	//   - contractAddress: 合约地址（nil 表示查询所有合约）
 	//   - eventTopic: 事件签名 topic（如 GetEventTopic("Transfer(address,address,uint256)")）
-	//   - fromBlock: 起始区块号（nil 表示从最新区块开始）
-	//   - toBlock: 结束区块号（nil 表示到最新区块）
+	//   - fromBlock: 起始区块号（nil 表示从最新区块开始，可使用 BlockTag.BlockNumber() 传入区块标签）
+	//   - toBlock: 结束区块号（nil 表示到最新区块，可使用 BlockTag.BlockNumber() 传入区块标签）
 	//   - indexedTopics: 可选的 indexed 参数过滤（nil 表示不过滤，每个元素对应一个 indexed 参数）
 	// 返回：
 	//   - []types.Log: 事件日志列表，用户需要自行解析 Data 和 Topics
@@ -147,30 +150,49 @@ type EtherProvider interface {
 // Provider 以太坊提供者实现
 // 封装了与以太坊节点通信的底层客户端
 type Provider struct {
-	rc      *rpc.Client       // RPC 客户端
-	ec      *ethclient.Client // 以太坊客户端
-	chainId *big.Int          // 链 ID（缓存，避免重复查询）
+	rc      *rpc.Client             // RPC 客户端
+	ec      *ethclient.Client       // 以太坊客户端
+	sendRc  *rpc.Client             // 广播交易使用的 RPC 客户端（nil 表示使用 rc）
+	chainId atomic.Pointer[big.Int] // 链 ID（缓存，避免重复查询）
+	cache   *providerCache          // 不可变数据缓存（nil 表示未启用）
 }
 
 // NewProvider 创建新的以太坊提供者实例
 // 连接到指定的以太坊节点 RPC URL
 // 参数说明：
 //   - rawUrl: 以太坊节点 RPC URL（如 "https://eth-mainnet.g.alchemy.com/v2/your-api-key" 或 "http://localhost:8545"）
+//   - opts: 可选配置（如 WithSendEndpoint、WithReadRetry、WithSendRetry、WithCache、WithTransport）
 //
 // 返回：
 //   - *Provider: 创建的 Provider 实例
 //   - error: 如果连接失败则返回错误
-func NewProvider(rawUrl string) (*Provider, error) {
+//
+// 使用示例：
+//
+//	// 读请求走归档节点并重试，交易通过私有中继广播
+//	p, err := NewProvider(archiveUrl,
+//	    WithReadRetry(DefaultReadRetryPolicy),
+//	    WithSendEndpoint(relayUrl))
+func NewProvider(rawUrl string, opts ...ProviderOption) (*Provider, error) {
+	cfg := &providerConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
 
-	rpcClient, err := rpc.Dial(rawUrl)
+	rpcClient, sendClient, err := newProviderClients(rawUrl, cfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to rpc.Dial(): %w", err)
+		return nil, err
 	}
 
-	return &Provider{
-		rc: rpcClient,
-		ec: ethclient.NewClient(rpcClient),
-	}, nil
+	p := &Provider{
+		rc:     rpcClient,
+		ec:     ethclient.NewClient(rpcClient),
+		sendRc: sendClient,
+	}
+	if cfg.cache != nil {
+		p.cache = newProviderCache(*cfg.cache)
+	}
+	return p, nil
 }
 
 // NewProviderWithChainId 创建新的以太坊提供者实例（指定链 ID）
@@ -179,17 +201,18 @@ func NewProvider(rawUrl string) (*Provider, error) {
 // 参数说明：
 //   - rawUrl: 以太坊节点 RPC URL
 //   - chainId: 链 ID（如主网为 1，Goerli 为 5）
+//   - opts: 可选配置（同 NewProvider）
 //
 // 返回：
 //   - *Provider: 创建的 Provider 实例
 //   - error: 如果连接失败则返回错误
-func NewProviderWithChainId(rawUrl string, chainId int64) (*Provider, error) {
+func NewProviderWithChainId(rawUrl string, chainId int64, opts ...ProviderOption) (*Provider, error) {
 
-	p, err := NewProvider(rawUrl)
+	p, err := NewProvider(rawUrl, opts...)
 	if err != nil {
 		return nil, err
 	}
-	p.chainId = big.NewInt(chainId)
+	p.chainId.Store(big.NewInt(chainId))
 
 	return p, nil
 }
@@ -216,6 +239,32 @@ func (p *Provider) GetRpcClient() *rpc.Client {
 func (p *Provider) Close() {
 	p.ec.Close()
 	p.rc.Close()
+	if p.sendRc != nil {
+		p.sendRc.Close()
+	}
+}
+
+// SendTransaction 广播已签名的交易
+// 配置了 WithSendEndpoint 时发送到广播节点，节点返回 already known（交易已在交易池中）视为成功
+// 参数说明：
+//   - ctx: 上下文对象
+//   - tx: 已签名的交易
+//
+// 返回：
+//   - error: 如果广播失败则返回错误
+func (p *Provider) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	rc := p.rc
+	if p.sendRc != nil {
+		rc = p.sendRc
+	}
+	if err := rc.CallContext(ctx, nil, "eth_sendRawTransaction", hexutil.Bytes(raw)); err != nil && !isAlreadyKnown(err) {
+		return NormalizeError(err)
+	}
+	return nil
 }
 
 // GetNetworkID 获取网络 ID
@@ -241,15 +290,16 @@ func (p *Provider) GetNetworkID(ctx context.Context) (*big.Int, error) {
 //   - error: 如果查询失败则返回错误
 func (p *Provider) GetChainID(ctx context.Context) (*big.Int, error) {
 
-	if p.chainId == nil {
-		chainId, err := p.ec.ChainID(ctx)
-		if err != nil {
-			return nil, err
-		}
-		p.chainId = chainId
+	if chainId := p.chainId.Load(); chainId != nil {
+		return chainId, nil
+	}
+	chainId, err := p.ec.ChainID(ctx)
+	if err != nil {
+		return nil, err
 	}
+	p.chainId.CompareAndSwap(nil, chainId)
 
-	return p.chainId, nil
+	return p.chainId.Load(), nil
 }
 
 // GetBlockByHash 根据区块哈希获取区块信息
@@ -262,7 +312,7 @@ func (p *Provider) GetChainID(ctx context.Context) (*big.Int, error) {
 //   - *types.Block: 区块对象，包含区块头、交易列表等信息
 //   - error: 如果查询失败则返回错误
 func (p *Provider) GetBlockByHash(ctx context.Context, blkHash common.Hash) (*types.Block, error) {
-	return p.ec.BlockByHash(ctx, blkHash)
+	return p.cachedBlockByHash(ctx, blkHash)
 }
 
 // GetBlockByNumber 根据区块号获取区块信息
@@ -290,6 +340,203 @@ func (p *Provider) GetBlockNumber(ctx context.Context) (uint64, error) {
 	return p.ec.BlockNumber(ctx)
 }
 
+// GetBalanceOf 获取任意地址的本位币余额
+// 与 Wallet.GetBalance 不同，此方法不需要私钥，可以查询任意地址
+// 参数说明：
+//   - ctx: 上下文对象
+//   - address: 要查询的地址
+//
+// 返回：
+//   - *big.Int: 最新区块的余额（单位为 Wei）
+//   - error: 如果查询失败则返回错误
+func (p *Provider) GetBalanceOf(ctx context.Context, address common.Address) (*big.Int, error) {
+	return p.ec.BalanceAt(ctx, address, nil)
+}
+
+// GetBalanceAt 获取任意地址在指定区块的本位币余额
+// 参数说明：
+//   - ctx: 上下文对象
+//   - address: 要查询的地址
+//   - block: 区块引用（区块号、latest/safe/finalized/pending 标签或 EIP-1898 区块哈希）
+//
+// 返回：
+//   - *big.Int: 指定区块的余额（单位为 Wei）
+//   - error: 如果查询失败则返回错误
+//
+// 使用示例：
+//   - 已最终确定的余额：GetBalanceAt(ctx, addr, BlockAtTag(BlockTagFinalized))
+//   - 指定区块哈希的余额：GetBalanceAt(ctx, addr, BlockAtHash(blockHash, true))
+func (p *Provider) GetBalanceAt(ctx context.Context, address common.Address, block BlockRef) (*big.Int, error) {
+	var result hexutil.Big
+	if err := p.rc.CallContext(ctx, &result, "eth_getBalance", address, block.rpcArg()); err != nil {
+		return nil, err
+	}
+	return (*big.Int)(&result), nil
+}
+
+// GetBlockAt 根据区块引用获取区块信息
+// 参数说明：
+//   - ctx: 上下文对象
+//   - block: 区块引用（区块号、区块标签或区块哈希）
+//
+// 返回：
+//   - *types.Block: 区块对象
+//   - error: 如果查询失败则返回错误
+func (p *Provider) GetBlockAt(ctx context.Context, block BlockRef) (*types.Block, error) {
+	if block.Hash != nil {
+		return p.ec.BlockByHash(ctx, *block.Hash)
+	}
+	return p.ec.BlockByNumber(ctx, block.Number)
+}
+
+// GetHeaderAt 根据区块引用获取区块头
+// 参数说明：
+//   - ctx: 上下文对象
+//   - block: 区块引用（区块号、区块标签或区块哈希）
+//
+// 返回：
+//   - *types.Header: 区块头
+//   - error: 如果查询失败则返回错误
+func (p *Provider) GetHeaderAt(ctx context.Context, block BlockRef) (*types.Header, error) {
+	if block.Hash != nil {
+		return p.ec.HeaderByHash(ctx, *block.Hash)
+	}
+	return p.ec.HeaderByNumber(ctx, block.Number)
+}
+
+// GetTransactionInBlock 根据区块引用和交易在区块中的位置获取交易
+// 用于按位置遍历区块的浏览器和对账任务
+// 参数说明：
+//   - ctx: 上下文对象
+//   - block: 区块引用（区块号、区块标签或区块哈希）
+//   - index: 交易在区块中的索引（从 0 开始）
+//
+// 返回：
+//   - *types.Transaction: 交易对象
+//   - error: 如果区块或索引不存在（ethereum.NotFound）或查询失败则返回错误
+//
+// 使用示例：
+//
+//	count, err := provider.GetTransactionCountInBlock(ctx, BlockAtNumber(n))
+//	for i := uint(0); i < count; i++ {
+//	    tx, err := provider.GetTransactionInBlock(ctx, BlockAtNumber(n), i)
+//	}
+func (p *Provider) GetTransactionInBlock(ctx context.Context, block BlockRef, index uint) (*types.Transaction, error) {
+	var raw json.RawMessage
+	var err error
+	if block.Hash != nil {
+		err = p.rc.CallContext(ctx, &raw, "eth_getTransactionByBlockHashAndIndex", *block.Hash, hexutil.Uint64(index))
+	} else {
+		err = p.rc.CallContext(ctx, &raw, "eth_getTransactionByBlockNumberAndIndex", toBlockNumArg(block.Number), hexutil.Uint64(index))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, ethereum.NotFound
+	}
+	tx := new(types.Transaction)
+	if err := json.Unmarshal(raw, tx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// GetTransactionCountInBlock 获取区块中的交易数量
+// 参数说明：
+//   - ctx: 上下文对象
+//   - block: 区块引用（区块号、区块标签或区块哈希）
+//
+// 返回：
+//   - uint: 交易数量
+//   - error: 如果区块不存在（ethereum.NotFound）或查询失败则返回错误
+func (p *Provider) GetTransactionCountInBlock(ctx context.Context, block BlockRef) (uint, error) {
+	var count *hexutil.Uint
+	var err error
+	if block.Hash != nil {
+		err = p.rc.CallContext(ctx, &count, "eth_getBlockTransactionCountByHash", *block.Hash)
+	} else {
+		err = p.rc.CallContext(ctx, &count, "eth_getBlockTransactionCountByNumber", toBlockNumArg(block.Number))
+	}
+	if err != nil {
+		return 0, err
+	}
+	if count == nil {
+		return 0, ethereum.NotFound
+	}
+	return uint(*count), nil
+}
+
+// GetUncleInBlock 根据区块引用和叔块在区块中的位置获取叔块（ommer）区块头
+// 叔块只存在于合并（The Merge）之前的工作量证明区块中
+// 参数说明：
+//   - ctx: 上下文对象
+//   - block: 区块引用（区块号、区块标签或区块哈希）
+//   - index: 叔块在区块中的索引（从 0 开始）
+//
+// 返回：
+//   - *types.Header: 叔块区块头
+//   - error: 如果区块或索引不存在（ethereum.NotFound）或查询失败则返回错误
+func (p *Provider) GetUncleInBlock(ctx context.Context, block BlockRef, index uint) (*types.Header, error) {
+	var header *types.Header
+	var err error
+	if block.Hash != nil {
+		err = p.rc.CallContext(ctx, &header, "eth_getUncleByBlockHashAndIndex", *block.Hash, hexutil.Uint64(index))
+	} else {
+		err = p.rc.CallContext(ctx, &header, "eth_getUncleByBlockNumberAndIndex", toBlockNumArg(block.Number), hexutil.Uint64(index))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return nil, ethereum.NotFound
+	}
+	return header, nil
+}
+
+// GetUncleCountInBlock 获取区块中的叔块数量
+// 参数说明：
+//   - ctx: 上下文对象
+//   - block: 区块引用（区块号、区块标签或区块哈希）
+//
+// 返回：
+//   - uint: 叔块数量（合并后的区块总是 0）
+//   - error: 如果区块不存在（ethereum.NotFound）或查询失败则返回错误
+func (p *Provider) GetUncleCountInBlock(ctx context.Context, block BlockRef) (uint, error) {
+	var count *hexutil.Uint
+	var err error
+	if block.Hash != nil {
+		err = p.rc.CallContext(ctx, &count, "eth_getUncleCountByBlockHash", *block.Hash)
+	} else {
+		err = p.rc.CallContext(ctx, &count, "eth_getUncleCountByBlockNumber", toBlockNumArg(block.Number))
+	}
+	if err != nil {
+		return 0, err
+	}
+	if count == nil {
+		return 0, ethereum.NotFound
+	}
+	return uint(*count), nil
+}
+
+// CallContractAt 在指定区块上执行静态调用（eth_call）
+// 与 ethclient 不同，使用区块哈希时会保留 RequireCanonical 设置
+// 参数说明：
+//   - ctx: 上下文对象
+//   - msg: 调用消息
+//   - block: 区块引用（区块号、区块标签或区块哈希）
+//
+// 返回：
+//   - []byte: 调用返回的原始数据
+//   - error: 如果调用失败则返回错误
+func (p *Provider) CallContractAt(ctx context.Context, msg ethereum.CallMsg, block BlockRef) ([]byte, error) {
+	var result hexutil.Bytes
+	if err := p.rc.CallContext(ctx, &result, "eth_call", toCallArg(msg), block.rpcArg()); err != nil {
+		return nil, NormalizeError(err)
+	}
+	return result, nil
+}
+
 // GetSuggestGasPrice 获取建议的 Gas 价格
 // 返回网络建议的 Gas 价格（单位为 Wei）
 // 参数说明：
@@ -327,7 +574,7 @@ func (p *Provider) GetTransactionByHash(ctx context.Context, txHash common.Hash)
 //   - *types.Receipt: 交易收据，包含交易状态、gas 使用等信息
 //   - error: 如果查询失败则返回错误（交易未打包时会返回错误）
 func (p *Provider) GetTransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
-	return p.ec.TransactionReceipt(ctx, txHash)
+	return p.cachedReceipt(ctx, txHash)
 }
 
 // GetContractBytecode 根据合约地址获取字节码
@@ -342,7 +589,7 @@ func (p *Provider) GetTransactionReceipt(ctx context.Context, txHash common.Hash
 //
 // 注意：如果地址不是合约（普通地址），返回的字节码为空字符串
 func (p *Provider) GetContractBytecode(ctx context.Context, address common.Address) (string, error) {
-	bytecode, err := p.ec.CodeAt(ctx, address, nil) // nil is the latest block
+	bytecode, err := p.cachedCode(ctx, address)
 	if err != nil {
 		return "", err
 	}
@@ -380,9 +627,27 @@ func (p *Provider) IsContractAddress(ctx context.Context, address common.Address
 //
 // 返回：
 //   - uint64: 估算的 Gas 数量
-//   - error: 如果估算失败则返回错误（如合约执行失败、余额不足等）
+//   - error: 如果估算失败则返回标准化后的错误（如合约执行失败返回 *RevertError、余额不足返回 ErrInsufficientFunds）
+//
+// 注意：如果链注册表中标记为 Arbitrum 网络，会优先使用 NodeInterface.gasEstimateComponents 进行估算（包含 L1 数据费用部分），
+// 只有节点上不存在 NodeInterface 时才回退到 eth_estimateGas，其他错误（如上下文取消、交易回滚）直接返回
 func (p *Provider) EstimateGas(ctx context.Context, from, to common.Address, nonce uint64, gasPrice, value *big.Int, data []byte) (uint64, error) {
-	return p.ec.EstimateGas(ctx, ethereum.CallMsg{
+	chainId, err := p.GetChainID(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if IsArbitrumChain(chainId) {
+		estimate, err := EstimateArbitrumGasComponents(ctx, p, from, to, value, data)
+		if err == nil {
+			return estimate.GasLimit, nil
+		}
+		if ctx.Err() != nil || !errors.Is(err, ErrNodeInterfaceUnavailable) {
+			return 0, NormalizeError(err)
+		}
+		// NodeInterface 不可用时回退到 eth_estimateGas
+	}
+
+	gas, err := p.ec.EstimateGas(ctx, ethereum.CallMsg{
 		From:       from,
 		To:         &to,
 		GasPrice:   gasPrice,
@@ -393,6 +658,7 @@ func (p *Provider) EstimateGas(ctx context.Context, from, to common.Address, non
 		GasTipCap:  nil,
 		AccessList: nil,
 	})
+	return gas, NormalizeError(err)
 }
 
 // GetFromAddress 从交易中提取发送地址
@@ -404,7 +670,7 @@ func (p *Provider) EstimateGas(ctx context.Context, from, to common.Address, non
 //   - common.Address: 发送地址
 //   - error: 如果提取失败则返回错误（如签名无效）
 func (p *Provider) GetFromAddress(tx *types.Transaction) (common.Address, error) {
-	return types.Sender(types.NewLondonSigner(tx.ChainId()), tx)
+	return txSender(tx)
 }
 
 // FilterLogs 查询事件日志
@@ -413,8 +679,8 @@ func (p *Provider) GetFromAddress(tx *types.Transaction) (common.Address, error)
 //   - ctx: 上下文对象
 //   - contractAddress: 合约地址（nil 表示查询所有合约）
 //   - eventTopic: 事件签名 topic（如 GetEventTopic("Transfer(address,address,uint256)")）
-//   - fromBlock: 起始区块号（nil 表示从最新区块开始）
-//   - toBlock: 结束区块号（nil 表示到最新区块）
+//   - fromBlock: 起始区块号（nil 表示从最新区块开始，可使用 BlockTag.BlockNumber() 传入区块标签）
+//   - toBlock: 结束区块号（nil 表示到最新区块，可使用 BlockTag.BlockNumber() 传入区块标签）
 //   - indexedTopics: 可选的 indexed 参数过滤（nil 表示不过滤，每个元素对应一个 indexed 参数）
 //
 // 返回：
@@ -426,9 +692,91 @@ func (p *Provider) GetFromAddress(tx *types.Transaction) (common.Address, error)
 //   - 查询所有合约的事件：FilterLogs(ctx, nil, topicHash, fromBlock, toBlock, nil)
 //   - 带 indexed 参数过滤：FilterLogs(ctx, &contractAddr, topicHash, fromBlock, toBlock, []common.Hash{fromAddr.Hash(), toAddr.Hash()})
 func (p *Provider) FilterLogs(ctx context.Context, contractAddress *common.Address, eventTopic common.Hash, fromBlock, toBlock *big.Int, indexedTopics []common.Hash) ([]types.Log, error) {
+	query := buildFilterQuery(contractAddress, eventTopic, indexedTopics)
+	query.FromBlock = fromBlock
+	query.ToBlock = toBlock
+	return p.ec.FilterLogs(ctx, query)
+}
+
+// FilterLogsAt 查询单个区块内的事件日志
+// 参数说明：
+//   - ctx: 上下文对象
+//   - contractAddress: 合约地址（nil 表示查询所有合约）
+//   - eventTopic: 事件签名 topic
+//   - block: 区块引用（区块号、区块标签或区块哈希，使用区块哈希时可以避免重组导致的日志不一致）
+//   - indexedTopics: 可选的 indexed 参数过滤
+//
+// 返回：
+//   - []types.Log: 事件日志列表
+//   - error: 如果查询失败则返回错误
+func (p *Provider) FilterLogsAt(ctx context.Context, contractAddress *common.Address, eventTopic common.Hash, block BlockRef, indexedTopics []common.Hash) ([]types.Log, error) {
+	query := buildFilterQuery(contractAddress, eventTopic, indexedTopics)
+	if block.Hash != nil {
+		query.BlockHash = block.Hash
+	} else {
+		number := block.Number
+		if number == nil {
+			number = BlockTagLatest.BlockNumber()
+		}
+		query.FromBlock = number
+		query.ToBlock = number
+	}
+	return p.ec.FilterLogs(ctx, query)
+}
+
+// FilterLogsMulti 查询多个合约、多个事件的事件日志
+// 与 FilterLogs 不同，每个位置都可以指定多个候选值（同一位置的候选值之间为"或"关系，不同位置之间为"且"关系）
+// 参数说明：
+//   - ctx: 上下文对象
+//   - contractAddresses: 合约地址列表（空表示查询所有合约）
+//   - eventTopics: 事件签名 topic 列表（空表示不限制事件）
+//   - fromBlock: 起始区块号（nil 表示从最新区块开始）
+//   - toBlock: 结束区块号（nil 表示到最新区块）
+//   - indexedTopics: 每个 indexed 参数位置的候选值（indexedTopics[0] 对应第一个 indexed 参数，nil 或空元素表示该位置不过滤）
+//
+// 返回：
+//   - []types.Log: 事件日志列表
+//   - error: 如果参数无效（如超过 3 个 indexed 参数位置）或查询失败则返回错误
+//
+// 使用示例：
+//   - 查询 USDC、USDT 中转入 alice 或 bob 的 Transfer：
+//     FilterLogsMulti(ctx, []common.Address{usdc, usdt}, []common.Hash{transferTopic}, fromBlock, toBlock, [][]common.Hash{nil, {aliceTopic, bobTopic}})
+func (p *Provider) FilterLogsMulti(ctx context.Context, contractAddresses []common.Address, eventTopics []common.Hash, fromBlock, toBlock *big.Int, indexedTopics [][]common.Hash) ([]types.Log, error) {
+	q := NewLogQuery().Addresses(contractAddresses...).FromBlock(fromBlock).ToBlock(toBlock)
+	if len(eventTopics) > 0 || len(indexedTopics) > 0 {
+		q.TopicAt(0, eventTopics...)
+	}
+	for i, values := range indexedTopics {
+		q.TopicAt(i+1, values...)
+	}
+	query, err := q.Build()
+	if err != nil {
+		return nil, err
+	}
+	return p.ec.FilterLogs(ctx, query)
+}
+
+// FilterLogsByQuery 按完整的查询条件查询事件日志
+// 适用于 FilterLogs 的参数列表无法表达的查询（如多个合约、同一位置的多个候选 topic）
+// 参数说明：
+//   - ctx: 上下文对象
+//   - query: 查询条件（可使用 NewLogQuery 构建）
+//
+// 返回：
+//   - []types.Log: 事件日志列表
+//   - error: 如果查询失败则返回错误
+//
+// 使用示例：
+//
+//	query, err := etherkit.NewLogQuery().Addresses(usdc, usdt).Event("Transfer(address,address,uint256)").Build()
+//	logs, err := provider.FilterLogsByQuery(ctx, query)
+func (p *Provider) FilterLogsByQuery(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	return p.ec.FilterLogs(ctx, query)
+}
+
+// buildFilterQuery 构建事件日志查询条件（不包含区块范围）
+func buildFilterQuery(contractAddress *common.Address, eventTopic common.Hash, indexedTopics []common.Hash) ethereum.FilterQuery {
 	query := ethereum.FilterQuery{
-		FromBlock: fromBlock,
-		ToBlock:   toBlock,
 		Topics: [][]common.Hash{
 			{eventTopic}, // 第一个 topic 是事件签名
 		},
@@ -446,6 +794,35 @@ func (p *Provider) FilterLogs(ctx context.Context, contractAddress *common.Addre
 			query.Topics = append(query.Topics, []common.Hash{topic})
 		}
 	}
+	return query
+}
 
-	return p.ec.FilterLogs(ctx, query)
+// toCallArg 将 CallMsg 转换为 eth_call 的参数对象
+func toCallArg(msg ethereum.CallMsg) interface{} {
+	arg := map[string]interface{}{
+		"from": msg.From,
+		"to":   msg.To,
+	}
+	if len(msg.Data) > 0 {
+		arg["input"] = hexutil.Bytes(msg.Data)
+	}
+	if msg.Value != nil {
+		arg["value"] = (*hexutil.Big)(msg.Value)
+	}
+	if msg.Gas != 0 {
+		arg["gas"] = hexutil.Uint64(msg.Gas)
+	}
+	if msg.GasPrice != nil {
+		arg["gasPrice"] = (*hexutil.Big)(msg.GasPrice)
+	}
+	if msg.GasFeeCap != nil {
+		arg["maxFeePerGas"] = (*hexutil.Big)(msg.GasFeeCap)
+	}
+	if msg.GasTipCap != nil {
+		arg["maxPriorityFeePerGas"] = (*hexutil.Big)(msg.GasTipCap)
+	}
+	if msg.AccessList != nil {
+		arg["accessList"] = msg.AccessList
+	}
+	return arg
 }
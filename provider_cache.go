@@ -0,0 +1,217 @@
+package etherkit
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+//############ Provider Cache ############
+
+// CacheConfig Provider 不可变数据缓存的配置
+// 只缓存不会再变化的数据：已最终确定（finalized）区块中的收据、按哈希查询的区块、非空合约代码
+type CacheConfig struct {
+	MaxEntries int  // 所有缓存共享的条目上限（按 LRU 淘汰）
+	Receipts   bool // 缓存已最终确定区块中的交易收据
+	Blocks     bool // 缓存按哈希查询的区块
+	Code       bool // 缓存非空的合约代码（EIP-7702 委托代码除外）
+}
+
+// DefaultCacheConfig 推荐的缓存配置（适用于索引器、监控程序）
+var DefaultCacheConfig = CacheConfig{MaxEntries: 4096, Receipts: true, Blocks: true, Code: true}
+
+// finalizedRefreshInterval 刷新最终确定区块号的最小间隔
+const finalizedRefreshInterval = 12 * time.Second
+
+// WithCache 为 Provider 启用不可变数据缓存
+// 参数说明：
+//   - cfg: 缓存配置（如 DefaultCacheConfig）
+//
+// 使用示例：
+//
+//	p, err := NewProvider(url, WithCache(DefaultCacheConfig))
+//	...
+//	stats := p.CacheStats()
+//	fmt.Printf("receipt hit rate %.2f\n", stats.Receipts.HitRate())
+func WithCache(cfg CacheConfig) ProviderOption {
+	return func(c *providerConfig) {
+		c.cache = &cfg
+	}
+}
+
+// CacheCounter 单类缓存的命中统计
+type CacheCounter struct {
+	Hits   uint64 // 命中次数
+	Misses uint64 // 未命中次数
+}
+
+// HitRate 返回命中率（0 ~ 1，无查询时为 0）
+func (c CacheCounter) HitRate() float64 {
+	total := c.Hits + c.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(c.Hits) / float64(total)
+}
+
+// CacheStats Provider 缓存的统计信息
+type CacheStats struct {
+	Receipts CacheCounter // 收据缓存
+	Blocks   CacheCounter // 区块缓存
+	Code     CacheCounter // 合约代码缓存
+	Entries  int          // 当前缓存的条目总数
+}
+
+// cacheKind 缓存的数据类型
+type cacheKind uint8
+
+const (
+	cacheReceipt cacheKind = iota
+	cacheBlock
+	cacheCode
+)
+
+// cacheKey 缓存键（收据和区块使用哈希，合约代码使用地址）
+type cacheKey struct {
+	kind cacheKind
+	key  common.Hash
+}
+
+// providerCache Provider 的不可变数据缓存
+type providerCache struct {
+	cfg     CacheConfig
+	entries *lruCache[cacheKey, interface{}]
+	hits    [3]atomic.Uint64
+	misses  [3]atomic.Uint64
+
+	mu          sync.Mutex
+	finalized   uint64    // 最近一次查询到的最终确定区块号
+	finalizedAt time.Time // 最近一次查询最终确定区块的时间
+}
+
+// newProviderCache 根据配置创建缓存
+func newProviderCache(cfg CacheConfig) *providerCache {
+	return &providerCache{cfg: cfg, entries: newLRUCache[cacheKey, interface{}](cfg.MaxEntries)}
+}
+
+// get 查询缓存并记录命中统计
+func (c *providerCache) get(kind cacheKind, key common.Hash) (interface{}, bool) {
+	v, ok := c.entries.Get(cacheKey{kind, key})
+	if ok {
+		c.hits[kind].Add(1)
+	} else {
+		c.misses[kind].Add(1)
+	}
+	return v, ok
+}
+
+// add 写入缓存
+func (c *providerCache) add(kind cacheKind, key common.Hash, value interface{}) {
+	c.entries.Add(cacheKey{kind, key}, value)
+}
+
+// stats 返回统计信息
+func (c *providerCache) stats() CacheStats {
+	counter := func(kind cacheKind) CacheCounter {
+		return CacheCounter{Hits: c.hits[kind].Load(), Misses: c.misses[kind].Load()}
+	}
+	return CacheStats{
+		Receipts: counter(cacheReceipt),
+		Blocks:   counter(cacheBlock),
+		Code:     counter(cacheCode),
+		Entries:  c.entries.Len(),
+	}
+}
+
+// isFinalized 判断区块是否已最终确定（必要时刷新最终确定区块号，刷新失败视为未确定）
+func (c *providerCache) isFinalized(ctx context.Context, p *Provider, number *big.Int) bool {
+	if number == nil || !number.IsUint64() {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if number.Uint64() <= c.finalized {
+		return true
+	}
+	if time.Since(c.finalizedAt) < finalizedRefreshInterval {
+		return false
+	}
+	c.finalizedAt = time.Now()
+	header, err := p.ec.HeaderByNumber(ctx, big.NewInt(int64(rpc.FinalizedBlockNumber)))
+	if err != nil || header == nil {
+		return false
+	}
+	c.finalized = header.Number.Uint64()
+	return number.Uint64() <= c.finalized
+}
+
+// CacheStats 获取缓存统计信息（未启用 WithCache 时返回零值）
+func (p *Provider) CacheStats() CacheStats {
+	if p.cache == nil {
+		return CacheStats{}
+	}
+	return p.cache.stats()
+}
+
+// cachedBlockByHash 按哈希查询区块（启用缓存时优先使用缓存）
+func (p *Provider) cachedBlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
+	if p.cache == nil || !p.cache.cfg.Blocks {
+		return p.ec.BlockByHash(ctx, hash)
+	}
+	if v, ok := p.cache.get(cacheBlock, hash); ok {
+		return v.(*types.Block), nil
+	}
+	block, err := p.ec.BlockByHash(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	p.cache.add(cacheBlock, hash, block)
+	return block, nil
+}
+
+// cachedReceipt 查询交易收据（启用缓存时只缓存已最终确定区块中的收据）
+func (p *Provider) cachedReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	if p.cache == nil || !p.cache.cfg.Receipts {
+		return p.ec.TransactionReceipt(ctx, txHash)
+	}
+	if v, ok := p.cache.get(cacheReceipt, txHash); ok {
+		return v.(*types.Receipt), nil
+	}
+	receipt, err := p.ec.TransactionReceipt(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+	if p.cache.isFinalized(ctx, p, receipt.BlockNumber) {
+		p.cache.add(cacheReceipt, txHash, receipt)
+	}
+	return receipt, nil
+}
+
+// delegationPrefix EIP-7702 委托代码的前缀（委托可以随时更改，不能缓存）
+var delegationPrefix = []byte{0xef, 0x01, 0x00}
+
+// cachedCode 查询最新区块的合约代码（启用缓存时只缓存非空且非委托的代码）
+func (p *Provider) cachedCode(ctx context.Context, address common.Address) ([]byte, error) {
+	if p.cache == nil || !p.cache.cfg.Code {
+		return p.ec.CodeAt(ctx, address, nil)
+	}
+	key := common.BytesToHash(address.Bytes())
+	if v, ok := p.cache.get(cacheCode, key); ok {
+		return v.([]byte), nil
+	}
+	code, err := p.ec.CodeAt(ctx, address, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(code) > 0 && !bytes.HasPrefix(code, delegationPrefix) {
+		p.cache.add(cacheCode, key, code)
+	}
+	return code, nil
+}
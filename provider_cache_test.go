@@ -0,0 +1,104 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestProviderCache(t *testing.T) {
+	finalTx := common.HexToHash("0x01")  // 打包在已最终确定的区块 50
+	recentTx := common.HexToHash("0x02") // 打包在未最终确定的区块 150
+	contract := common.HexToAddress("0x00000000000000000000000000000000000000c0")
+	delegated := common.HexToAddress("0x00000000000000000000000000000000000000d0")
+
+	server := newMockRPCServer(t, map[string]mockRPCHandler{
+		"eth_getTransactionReceipt": func(params []json.RawMessage) (interface{}, error) {
+			var hash common.Hash
+			if err := json.Unmarshal(params[0], &hash); err != nil {
+				return nil, err
+			}
+			number := big.NewInt(50)
+			if hash == recentTx {
+				number = big.NewInt(150)
+			}
+			return &types.Receipt{Status: types.ReceiptStatusSuccessful, TxHash: hash, BlockNumber: number, Logs: []*types.Log{}}, nil
+		},
+		"eth_getBlockByNumber": mockResult(&types.Header{Number: big.NewInt(100), Difficulty: big.NewInt(0)}),
+		"eth_getCode": func(params []json.RawMessage) (interface{}, error) {
+			var address common.Address
+			if err := json.Unmarshal(params[0], &address); err != nil {
+				return nil, err
+			}
+			if address == delegated {
+				return "0xef0100" + common.Bytes2Hex(contract.Bytes()), nil
+			}
+			return "0x6080", nil
+		},
+	})
+	provider, err := NewProvider(server.URL, WithCache(DefaultCacheConfig))
+	if err != nil {
+		t.Fatalf("NewProvider() failed: %v", err)
+	}
+	defer provider.Close()
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if _, err := provider.GetTransactionReceipt(ctx, finalTx); err != nil {
+			t.Fatalf("GetTransactionReceipt() failed: %v", err)
+		}
+		if _, err := provider.GetTransactionReceipt(ctx, recentTx); err != nil {
+			t.Fatalf("GetTransactionReceipt() failed: %v", err)
+		}
+		if _, err := provider.IsContractAddress(ctx, contract); err != nil {
+			t.Fatalf("IsContractAddress() failed: %v", err)
+		}
+		if _, err := provider.IsContractAddress(ctx, delegated); err != nil {
+			t.Fatalf("IsContractAddress() failed: %v", err)
+		}
+	}
+
+	// 已最终确定的收据只查询一次，未确定的收据每次都查询
+	if calls := server.callCount("eth_getTransactionReceipt"); calls != 4 {
+		t.Errorf("eth_getTransactionReceipt count = %d, expected 4", calls)
+	}
+	// 普通合约代码只查询一次，EIP-7702 委托代码每次都查询
+	if calls := server.callCount("eth_getCode"); calls != 4 {
+		t.Errorf("eth_getCode count = %d, expected 4", calls)
+	}
+
+	stats := provider.CacheStats()
+	if stats.Receipts.Hits != 2 || stats.Receipts.Misses != 4 {
+		t.Errorf("Receipts stats = %+v, expected 2 hits / 4 misses", stats.Receipts)
+	}
+	if stats.Code.HitRate() != 2.0/6.0 {
+		t.Errorf("Code hit rate = %f", stats.Code.HitRate())
+	}
+	if stats.Entries != 2 {
+		t.Errorf("Entries = %d, expected 2", stats.Entries)
+	}
+}
+
+func TestProviderCacheSizeCap(t *testing.T) {
+	server := newMockRPCServer(t, map[string]mockRPCHandler{
+		"eth_getCode": mockResult("0x6080"),
+	})
+	provider, err := NewProvider(server.URL, WithCache(CacheConfig{MaxEntries: 2, Code: true}))
+	if err != nil {
+		t.Fatalf("NewProvider() failed: %v", err)
+	}
+	defer provider.Close()
+
+	for i := 1; i <= 5; i++ {
+		if _, err := provider.GetContractBytecode(context.Background(), common.BigToAddress(big.NewInt(int64(i)))); err != nil {
+			t.Fatalf("GetContractBytecode() failed: %v", err)
+		}
+	}
+	if entries := provider.CacheStats().Entries; entries != 2 {
+		t.Errorf("Entries = %d, expected cap of 2", entries)
+	}
+}
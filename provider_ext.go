@@ -0,0 +1,267 @@
+package etherkit
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+//############ Provider Extensions ############
+
+// 以下接口是 EtherProvider 之外的可选能力，*Provider 实现了全部接口
+// 自定义的 EtherProvider 实现（如 mock 或包装器）无需实现它们：
+// 未实现时，包内会基于其 GetRpcClient/GetEthClient 返回的底层客户端完成对应查询
+
+// TxBroadcaster 广播已签名交易的能力
+type TxBroadcaster interface {
+	// SendTransaction 广播已签名的交易（配置了广播节点时路由到广播节点）
+	// 参数说明：
+	//   - ctx: 上下文对象
+	//   - tx: 已签名的交易
+	// 返回：
+	//   - error: 如果广播失败则返回错误
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+}
+
+// BlockRefReader 按区块引用（区块号、latest/safe/finalized/pending 标签或 EIP-1898 区块哈希）查询的能力
+type BlockRefReader interface {
+	// GetBalanceOf 获取任意地址的本位币余额
+	// 参数说明：
+	//   - ctx: 上下文对象
+	//   - address: 要查询的地址
+	// 返回：
+	//   - *big.Int: 最新区块的余额（单位为 Wei）
+	//   - error: 如果查询失败则返回错误
+	GetBalanceOf(ctx context.Context, address common.Address) (*big.Int, error)
+	// GetBalanceAt 获取任意地址在指定区块的本位币余额
+	// 参数说明：
+	//   - ctx: 上下文对象
+	//   - address: 要查询的地址
+	//   - block: 区块引用（区块号、latest/safe/finalized/pending 标签或 EIP-1898 区块哈希）
+	// 返回：
+	//   - *big.Int: 指定区块的余额（单位为 Wei）
+	//   - error: 如果查询失败则返回错误
+	GetBalanceAt(ctx context.Context, address common.Address, block BlockRef) (*big.Int, error)
+	// GetBlockAt 根据区块引用获取区块信息
+	// 参数说明：
+	//   - ctx: 上下文对象
+	//   - block: 区块引用（区块号、区块标签或区块哈希）
+	// 返回：
+	//   - *types.Block: 区块对象
+	//   - error: 如果查询失败则返回错误
+	GetBlockAt(ctx context.Context, block BlockRef) (*types.Block, error)
+	// GetHeaderAt 根据区块引用获取区块头
+	// 参数说明：
+	//   - ctx: 上下文对象
+	//   - block: 区块引用（区块号、区块标签或区块哈希）
+	// 返回：
+	//   - *types.Header: 区块头
+	//   - error: 如果查询失败则返回错误
+	GetHeaderAt(ctx context.Context, block BlockRef) (*types.Header, error)
+	// CallContractAt 在指定区块上执行静态调用（eth_call）
+	// 参数说明：
+	//   - ctx: 上下文对象
+	//   - msg: 调用消息
+	//   - block: 区块引用（区块号、区块标签或区块哈希）
+	// 返回：
+	//   - []byte: 调用返回的原始数据
+	//   - error: 如果调用失败则返回错误
+	CallContractAt(ctx context.Context, msg ethereum.CallMsg, block BlockRef) ([]byte, error)
+}
+
+// BlockIndexReader 按区块内位置查询交易和叔块的能力
+type BlockIndexReader interface {
+	// GetTransactionInBlock 根据区块引用和交易在区块中的位置获取交易
+	// 参数说明：
+	//   - ctx: 上下文对象
+	//   - block: 区块引用（区块号、区块标签或区块哈希）
+	//   - index: 交易在区块中的索引（从 0 开始）
+	// 返回：
+	//   - *types.Transaction: 交易对象
+	//   - error: 如果区块或索引不存在（ethereum.NotFound）或查询失败则返回错误
+	GetTransactionInBlock(ctx context.Context, block BlockRef, index uint) (*types.Transaction, error)
+	// GetTransactionCountInBlock 获取区块中的交易数量
+	// 参数说明：
+	//   - ctx: 上下文对象
+	//   - block: 区块引用（区块号、区块标签或区块哈希）
+	// 返回：
+	//   - uint: 交易数量
+	//   - error: 如果区块不存在（ethereum.NotFound）或查询失败则返回错误
+	GetTransactionCountInBlock(ctx context.Context, block BlockRef) (uint, error)
+	// GetUncleInBlock 根据区块引用和叔块在区块中的位置获取叔块（ommer）区块头
+	// 参数说明：
+	//   - ctx: 上下文对象
+	//   - block: 区块引用（区块号、区块标签或区块哈希）
+	//   - index: 叔块在区块中的索引（从 0 开始）
+	// 返回：
+	//   - *types.Header: 叔块区块头
+	//   - error: 如果区块或索引不存在（ethereum.NotFound）或查询失败则返回错误
+	GetUncleInBlock(ctx context.Context, block BlockRef, index uint) (*types.Header, error)
+	// GetUncleCountInBlock 获取区块中的叔块数量
+	// 参数说明：
+	//   - ctx: 上下文对象
+	//   - block: 区块引用（区块号、区块标签或区块哈希）
+	// 返回：
+	//   - uint: 叔块数量（合并后的区块总是 0）
+	//   - error: 如果区块不存在（ethereum.NotFound）或查询失败则返回错误
+	GetUncleCountInBlock(ctx context.Context, block BlockRef) (uint, error)
+}
+
+// StateOverrideCaller 带临时状态覆盖执行 eth_call / eth_estimateGas 的能力
+type StateOverrideCaller interface {
+	// CallContractWithOverrides 在覆盖状态后执行静态调用（eth_call）
+	// 参数说明：
+	//   - ctx: 上下文对象
+	//   - msg: 调用消息
+	//   - block: 区块引用（区块号、区块标签或区块哈希）
+	//   - overrides: 临时状态覆盖（余额、代码、存储）
+	// 返回：
+	//   - []byte: 调用返回的原始数据
+	//   - error: 如果调用失败则返回错误
+	CallContractWithOverrides(ctx context.Context, msg ethereum.CallMsg, block BlockRef, overrides StateOverride) ([]byte, error)
+	// EstimateGasWithOverrides 在覆盖状态后估算交易所需的 gas（eth_estimateGas）
+	// 参数说明：
+	//   - ctx: 上下文对象
+	//   - msg: 调用消息
+	//   - block: 区块引用（区块号、区块标签或区块哈希）
+	//   - overrides: 临时状态覆盖（余额、代码、存储）
+	// 返回：
+	//   - uint64: 估算的 Gas 数量
+	//   - error: 如果估算失败则返回错误
+	EstimateGasWithOverrides(ctx context.Context, msg ethereum.CallMsg, block BlockRef, overrides StateOverride) (uint64, error)
+}
+
+// LogQuerier 按区块引用或完整查询条件查询事件日志的能力
+type LogQuerier interface {
+	// FilterLogsAt 查询单个区块内的事件日志
+	// 参数说明：
+	//   - ctx: 上下文对象
+	//   - contractAddress: 合约地址（nil 表示查询所有合约）
+	//   - eventTopic: 事件签名 topic
+	//   - block: 区块引用（区块号、区块标签或区块哈希，使用区块哈希时可以避免重组导致的日志不一致）
+	//   - indexedTopics: 可选的 indexed 参数过滤
+	// 返回：
+	//   - []types.Log: 事件日志列表
+	//   - error: 如果查询失败则返回错误
+	FilterLogsAt(ctx context.Context, contractAddress *common.Address, eventTopic common.Hash, block BlockRef, indexedTopics []common.Hash) ([]types.Log, error)
+	// FilterLogsMulti 查询多个合约、多个事件的事件日志，每个 indexed 参数位置可以指定多个候选值
+	// 参数说明：
+	//   - ctx: 上下文对象
+	//   - contractAddresses: 合约地址列表（空表示查询所有合约）
+	//   - eventTopics: 事件签名 topic 列表（空表示不限制事件）
+	//   - fromBlock: 起始区块号
+	//   - toBlock: 结束区块号
+	//   - indexedTopics: 每个 indexed 参数位置的候选值（nil 或空元素表示该位置不过滤）
+	// 返回：
+	//   - []types.Log: 事件日志列表
+	//   - error: 如果查询失败则返回错误
+	FilterLogsMulti(ctx context.Context, contractAddresses []common.Address, eventTopics []common.Hash, fromBlock, toBlock *big.Int, indexedTopics [][]common.Hash) ([]types.Log, error)
+	// FilterLogsByQuery 按完整的查询条件查询事件日志（可使用 NewLogQuery 构建）
+	// 参数说明：
+	//   - ctx: 上下文对象
+	//   - query: 查询条件
+	// 返回：
+	//   - []types.Log: 事件日志列表
+	//   - error: 如果查询失败则返回错误
+	FilterLogsByQuery(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error)
+}
+
+var (
+	_ TxBroadcaster       = (*Provider)(nil)
+	_ BlockRefReader      = (*Provider)(nil)
+	_ BlockIndexReader    = (*Provider)(nil)
+	_ StateOverrideCaller = (*Provider)(nil)
+	_ LogQuerier          = (*Provider)(nil)
+)
+
+// providerExt 返回 ep 的可选能力 T
+// ep 实现了 T 时直接使用；否则基于 ep 的底层客户端构造 *Provider 作为回退（不含缓存和广播节点配置）
+func providerExt[T any](ep EtherProvider) T {
+	if ext, ok := ep.(T); ok {
+		return ext
+	}
+	var fallback interface{} = &Provider{rc: ep.GetRpcClient(), ec: ep.GetEthClient()}
+	return fallback.(T)
+}
+
+//############ Kit Provider Extensions ############
+
+// Kit 通过嵌入的 EtherProvider 提供以下方法，EtherProvider 未实现对应接口时使用底层客户端回退
+
+// SendTransaction 广播已签名的交易，见 TxBroadcaster
+func (k *Kit) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	return providerExt[TxBroadcaster](k.EtherProvider).SendTransaction(ctx, tx)
+}
+
+// GetBalanceOf 获取任意地址的本位币余额，见 BlockRefReader
+func (k *Kit) GetBalanceOf(ctx context.Context, address common.Address) (*big.Int, error) {
+	return providerExt[BlockRefReader](k.EtherProvider).GetBalanceOf(ctx, address)
+}
+
+// GetBalanceAt 获取任意地址在指定区块的本位币余额，见 BlockRefReader
+func (k *Kit) GetBalanceAt(ctx context.Context, address common.Address, block BlockRef) (*big.Int, error) {
+	return providerExt[BlockRefReader](k.EtherProvider).GetBalanceAt(ctx, address, block)
+}
+
+// GetBlockAt 根据区块引用获取区块信息，见 BlockRefReader
+func (k *Kit) GetBlockAt(ctx context.Context, block BlockRef) (*types.Block, error) {
+	return providerExt[BlockRefReader](k.EtherProvider).GetBlockAt(ctx, block)
+}
+
+// GetHeaderAt 根据区块引用获取区块头，见 BlockRefReader
+func (k *Kit) GetHeaderAt(ctx context.Context, block BlockRef) (*types.Header, error) {
+	return providerExt[BlockRefReader](k.EtherProvider).GetHeaderAt(ctx, block)
+}
+
+// CallContractAt 在指定区块上执行静态调用，见 BlockRefReader
+func (k *Kit) CallContractAt(ctx context.Context, msg ethereum.CallMsg, block BlockRef) ([]byte, error) {
+	return providerExt[BlockRefReader](k.EtherProvider).CallContractAt(ctx, msg, block)
+}
+
+// GetTransactionInBlock 根据区块引用和交易在区块中的位置获取交易，见 BlockIndexReader
+func (k *Kit) GetTransactionInBlock(ctx context.Context, block BlockRef, index uint) (*types.Transaction, error) {
+	return providerExt[BlockIndexReader](k.EtherProvider).GetTransactionInBlock(ctx, block, index)
+}
+
+// GetTransactionCountInBlock 获取区块中的交易数量，见 BlockIndexReader
+func (k *Kit) GetTransactionCountInBlock(ctx context.Context, block BlockRef) (uint, error) {
+	return providerExt[BlockIndexReader](k.EtherProvider).GetTransactionCountInBlock(ctx, block)
+}
+
+// GetUncleInBlock 根据区块引用和叔块在区块中的位置获取叔块区块头，见 BlockIndexReader
+func (k *Kit) GetUncleInBlock(ctx context.Context, block BlockRef, index uint) (*types.Header, error) {
+	return providerExt[BlockIndexReader](k.EtherProvider).GetUncleInBlock(ctx, block, index)
+}
+
+// GetUncleCountInBlock 获取区块中的叔块数量，见 BlockIndexReader
+func (k *Kit) GetUncleCountInBlock(ctx context.Context, block BlockRef) (uint, error) {
+	return providerExt[BlockIndexReader](k.EtherProvider).GetUncleCountInBlock(ctx, block)
+}
+
+// CallContractWithOverrides 在覆盖状态后执行静态调用，见 StateOverrideCaller
+func (k *Kit) CallContractWithOverrides(ctx context.Context, msg ethereum.CallMsg, block BlockRef, overrides StateOverride) ([]byte, error) {
+	return providerExt[StateOverrideCaller](k.EtherProvider).CallContractWithOverrides(ctx, msg, block, overrides)
+}
+
+// EstimateGasWithOverrides 在覆盖状态后估算交易所需的 gas，见 StateOverrideCaller
+func (k *Kit) EstimateGasWithOverrides(ctx context.Context, msg ethereum.CallMsg, block BlockRef, overrides StateOverride) (uint64, error) {
+	return providerExt[StateOverrideCaller](k.EtherProvider).EstimateGasWithOverrides(ctx, msg, block, overrides)
+}
+
+// FilterLogsAt 查询单个区块内的事件日志，见 LogQuerier
+func (k *Kit) FilterLogsAt(ctx context.Context, contractAddress *common.Address, eventTopic common.Hash, block BlockRef, indexedTopics []common.Hash) ([]types.Log, error) {
+	return providerExt[LogQuerier](k.EtherProvider).FilterLogsAt(ctx, contractAddress, eventTopic, block, indexedTopics)
+}
+
+// FilterLogsMulti 查询多个合约、多个事件的事件日志，见 LogQuerier
+func (k *Kit) FilterLogsMulti(ctx context.Context, contractAddresses []common.Address, eventTopics []common.Hash, fromBlock, toBlock *big.Int, indexedTopics [][]common.Hash) ([]types.Log, error) {
+	return providerExt[LogQuerier](k.EtherProvider).FilterLogsMulti(ctx, contractAddresses, eventTopics, fromBlock, toBlock, indexedTopics)
+}
+
+// FilterLogsByQuery 按完整的查询条件查询事件日志，见 LogQuerier
+func (k *Kit) FilterLogsByQuery(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	return providerExt[LogQuerier](k.EtherProvider).FilterLogsByQuery(ctx, query)
+}
@@ -0,0 +1,44 @@
+package etherkit
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// minimalProvider 只实现 EtherProvider 的外部实现（如下游的 mock 或包装器）
+type minimalProvider struct {
+	EtherProvider
+}
+
+// TestProviderExtFallback 测试未实现可选接口的 EtherProvider 回退到底层客户端
+func TestProviderExtFallback(t *testing.T) {
+	server := newMockRPCServer(t, map[string]mockRPCHandler{
+		"eth_getBlockByNumber": mockResult(&types.Header{Number: big.NewInt(100), Difficulty: big.NewInt(0), BaseFee: big.NewInt(7)}),
+	})
+	provider, err := NewProvider(server.URL)
+	if err != nil {
+		t.Fatalf("NewProvider() failed: %v", err)
+	}
+	defer provider.Close()
+
+	ep := minimalProvider{provider}
+	if _, ok := interface{}(ep).(BlockRefReader); ok {
+		t.Fatal("minimalProvider should not implement BlockRefReader")
+	}
+	baseFee, err := GetBaseFee(context.Background(), ep)
+	if err != nil || baseFee.Int64() != 7 {
+		t.Fatalf("GetBaseFee() = %v, %v, want 7", baseFee, err)
+	}
+
+	kit, err := NewReadOnlyKit("", WithProvider(ep))
+	if err != nil {
+		t.Fatalf("NewReadOnlyKit() failed: %v", err)
+	}
+	header, err := kit.GetHeaderAt(context.Background(), BlockAtTag(BlockTagLatest))
+	if err != nil || header.Number.Int64() != 100 {
+		t.Fatalf("GetHeaderAt() = %v, %v, want block 100", header, err)
+	}
+}
@@ -0,0 +1,176 @@
+package etherkit
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+//############ Provider Options ############
+
+// RetryPolicy HTTP 请求的重试策略（只对网络错误和 429/502/503/504 响应重试）
+type RetryPolicy struct {
+	MaxAttempts int           // 最大尝试次数（含首次，<= 1 表示不重试）
+	Backoff     time.Duration // 首次重试前的等待时间（之后每次翻倍）
+	MaxBackoff  time.Duration // 单次等待时间上限（0 表示不限制）
+}
+
+// DefaultReadRetryPolicy 读请求的推荐重试策略
+var DefaultReadRetryPolicy = RetryPolicy{MaxAttempts: 3, Backoff: 200 * time.Millisecond, MaxBackoff: 2 * time.Second}
+
+// backoff 返回第 attempt 次重试前的等待时间（attempt 从 1 开始）
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.Backoff << (attempt - 1)
+	if p.MaxBackoff > 0 && (d > p.MaxBackoff || d <= 0) {
+		d = p.MaxBackoff
+	}
+	return d
+}
+
+// providerConfig Provider 的可选配置
+type providerConfig struct {
+	sendURL   string       // 广播交易使用的节点（空表示与读请求相同）
+	readRetry RetryPolicy  // 读请求重试策略
+	sendRetry RetryPolicy  // 广播交易重试策略
+	cache     *CacheConfig // 不可变数据缓存（nil 表示不缓存）
+
+	transport   http.RoundTripper // HTTP 传输层（nil 表示使用 http.DefaultTransport）
+	callTimeout time.Duration     // 单次 HTTP 请求超时（0 表示不限制）
+	readTimeout time.Duration     // 没有截止时间的查询请求的默认超时（0 表示不限制）
+	sendTimeout time.Duration     // 没有截止时间的广播请求的默认超时（0 表示不限制）
+	faults      *FaultInjector    // 故障注入（nil 表示不注入，见 WithFaultInjection）
+}
+
+// ProviderOption Provider 的可选配置项
+type ProviderOption func(*providerConfig)
+
+// WithSendEndpoint 将 eth_sendRawTransaction 路由到单独的节点（如私有交易中继或专用节点），其余请求仍使用主节点
+// 参数说明：
+//   - rawUrl: 广播交易使用的 RPC URL
+func WithSendEndpoint(rawUrl string) ProviderOption {
+	return func(c *providerConfig) {
+		c.sendURL = rawUrl
+	}
+}
+
+// WithReadRetry 设置读请求（主节点）的重试策略（仅对 HTTP(S) 节点生效）
+func WithReadRetry(policy RetryPolicy) ProviderOption {
+	return func(c *providerConfig) {
+		c.readRetry = policy
+	}
+}
+
+// WithSendRetry 设置广播交易的重试策略（仅对 HTTP(S) 节点生效）
+// 重试时节点可能已收到交易，此时返回的 already known 会被视为成功
+func WithSendRetry(policy RetryPolicy) ProviderOption {
+	return func(c *providerConfig) {
+		c.sendRetry = policy
+	}
+}
+
+// dialRPC 连接 RPC 节点，HTTP(S) 节点使用配置的传输层和超时，并按重试策略包装传输层
+func dialRPC(rawUrl string, policy RetryPolicy, cfg *providerConfig) (*rpc.Client, error) {
+	if !strings.HasPrefix(rawUrl, "http") || (policy.MaxAttempts <= 1 && cfg.transport == nil && cfg.callTimeout == 0 && cfg.readTimeout == 0 && cfg.sendTimeout == 0 && cfg.faults == nil) {
+		return rpc.Dial(rawUrl)
+	}
+	var transport http.RoundTripper = http.DefaultTransport
+	if cfg.transport != nil {
+		transport = cfg.transport
+	}
+	if cfg.faults != nil {
+		transport = cfg.faults.Transport(transport)
+	}
+	if policy.MaxAttempts > 1 {
+		transport = &retryTransport{base: transport, policy: policy}
+	}
+	if cfg.readTimeout > 0 || cfg.sendTimeout > 0 {
+		// 默认超时包含所有重试
+		transport = &timeoutTransport{base: transport, read: cfg.readTimeout, send: cfg.sendTimeout}
+	}
+	httpClient := &http.Client{Transport: transport, Timeout: cfg.callTimeout}
+	return rpc.DialOptions(context.Background(), rawUrl, rpc.WithHTTPClient(httpClient))
+}
+
+// retryTransport 按重试策略重发 HTTP 请求的传输层
+type retryTransport struct {
+	base   http.RoundTripper
+	policy RetryPolicy
+}
+
+// RoundTrip 实现 http.RoundTripper 接口
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		_ = req.Body.Close()
+	}
+
+	for attempt := 1; ; attempt++ {
+		attemptReq := req.Clone(req.Context())
+		attemptReq.Body = io.NopCloser(bytes.NewReader(body))
+		resp, err := t.base.RoundTrip(attemptReq)
+		if attempt >= t.policy.MaxAttempts || !retryable(resp, err) || req.Context().Err() != nil {
+			return resp, err
+		}
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(t.policy.backoff(attempt)):
+		}
+	}
+}
+
+// retryable 判断请求结果是否值得重试
+func retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// isAlreadyKnown 判断节点是否因交易已在交易池中而拒绝（重复广播）
+func isAlreadyKnown(err error) bool {
+	if err == nil {
+		return false
+	}
+	return errors.Is(NormalizeError(err), ErrAlreadyKnown)
+}
+
+// newProviderClients 根据配置连接读节点和广播节点
+func newProviderClients(rawUrl string, cfg *providerConfig) (rc, sendRc *rpc.Client, err error) {
+	rc, err = dialRPC(rawUrl, cfg.readRetry, cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to rpc.Dial(): %w", err)
+	}
+	if cfg.sendURL == "" && cfg.sendRetry.MaxAttempts <= 1 {
+		return rc, nil, nil
+	}
+	sendURL := cfg.sendURL
+	if sendURL == "" {
+		sendURL = rawUrl
+	}
+	sendRc, err = dialRPC(sendURL, cfg.sendRetry, cfg)
+	if err != nil {
+		rc.Close()
+		return nil, nil, fmt.Errorf("failed to dial send endpoint: %w", err)
+	}
+	return rc, sendRc, nil
+}
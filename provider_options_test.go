@@ -0,0 +1,104 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// newFlakyServer 在前 failures 次请求返回 503，之后转发给模拟节点
+func newFlakyServer(t *testing.T, m *mockRPCServer, failures int32) *httptest.Server {
+	t.Helper()
+	var remaining atomic.Int32
+	remaining.Store(failures)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if remaining.Add(-1) >= 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		m.serveHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestProviderSendEndpoint(t *testing.T) {
+	read := newMockSendServer(t)
+	send := newMockRPCServer(t, map[string]mockRPCHandler{
+		"eth_sendRawTransaction": mockResult(common.Hash{}),
+	})
+	provider, err := NewProvider(read.URL, WithSendEndpoint(send.URL))
+	if err != nil {
+		t.Fatalf("NewProvider() failed: %v", err)
+	}
+	defer provider.Close()
+	pk, _ := BuildPrivateKeyFromHex("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	kit, _ := NewKitWithComponents(pk, provider)
+
+	recipient := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	if _, err := kit.SendTx(context.Background(), recipient, 0, 21000, nil, big.NewInt(1), nil); err != nil {
+		t.Fatalf("SendTx() failed: %v", err)
+	}
+	if read.callCount("eth_sendRawTransaction") != 0 || send.callCount("eth_sendRawTransaction") != 1 {
+		t.Errorf("eth_sendRawTransaction routed to read endpoint %d times, send endpoint %d times; want 0 and 1",
+			read.callCount("eth_sendRawTransaction"), send.callCount("eth_sendRawTransaction"))
+	}
+	if read.callCount("eth_getTransactionCount") != 1 {
+		t.Error("reads should go to the read endpoint")
+	}
+}
+
+func TestProviderRetry(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond}
+
+	tests := []struct {
+		name     string
+		failures int32
+		opts     []ProviderOption
+		wantErr  bool
+	}{
+		{"no retry by default", 1, nil, true},
+		{"recovers within attempts", 2, []ProviderOption{WithReadRetry(policy)}, false},
+		{"gives up after max attempts", 3, []ProviderOption{WithReadRetry(policy)}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newMockRPCServer(t, map[string]mockRPCHandler{"eth_blockNumber": mockResult("0x64")})
+			server := newFlakyServer(t, m, tt.failures)
+			provider, err := NewProvider(server.URL, tt.opts...)
+			if err != nil {
+				t.Fatalf("NewProvider() failed: %v", err)
+			}
+			defer provider.Close()
+
+			number, err := provider.GetBlockNumber(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetBlockNumber() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && number != 100 {
+				t.Errorf("GetBlockNumber() = %d, want 100", number)
+			}
+		})
+	}
+}
+
+func TestSendTransactionAlreadyKnown(t *testing.T) {
+	server := newMockSendServer(t)
+	server.handlers["eth_sendRawTransaction"] = func(params []json.RawMessage) (interface{}, error) {
+		return nil, errors.New("already known")
+	}
+	kit := newMockKit(t, server)
+
+	recipient := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	if _, err := kit.SendTx(context.Background(), recipient, 0, 21000, nil, big.NewInt(1), nil); err != nil {
+		t.Fatalf("SendTx() should treat already known as success: %v", err)
+	}
+}
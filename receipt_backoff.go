@@ -0,0 +1,114 @@
+package etherkit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+//############ Receipt Backoff ############
+
+// ReceiptBackoff 等待交易收据时的指数退避轮询策略
+// 首次查询立即进行（快速链上可以尽快返回），之后每次等待时间按倍数增长直到上限（慢速链上减少 RPC 请求）
+type ReceiptBackoff struct {
+	Initial    time.Duration // 首次重试前的等待时间（<= 0 表示 1 秒）
+	Max        time.Duration // 单次等待时间上限（<= 0 表示 16 秒）
+	Multiplier float64       // 每次等待时间的增长倍数（<= 1 表示 2）
+	OnNewBlock bool          // 只在区块高度变化时查询收据（每次轮询改为先查询 eth_blockNumber）
+}
+
+// DefaultReceiptBackoff 默认的收据轮询退避策略（1s → 2s → 4s → 8s → 16s）
+var DefaultReceiptBackoff = ReceiptBackoff{Initial: time.Second, Max: 16 * time.Second, Multiplier: 2}
+
+// withDefaults 返回补全默认值后的策略
+func (b ReceiptBackoff) withDefaults() ReceiptBackoff {
+	if b.Initial <= 0 {
+		b.Initial = DefaultReceiptBackoff.Initial
+	}
+	if b.Max <= 0 {
+		b.Max = DefaultReceiptBackoff.Max
+	}
+	if b.Multiplier <= 1 {
+		b.Multiplier = DefaultReceiptBackoff.Multiplier
+	}
+	return b
+}
+
+// next 返回当前等待时间之后的下一次等待时间
+func (b ReceiptBackoff) next(delay time.Duration) time.Duration {
+	return min(time.Duration(float64(delay)*b.Multiplier), b.Max)
+}
+
+// WaitForReceiptWithBackoff 按指数退避策略等待交易被打包
+// 参数说明：
+//   - ctx: 上下文对象
+//   - txHash: 交易哈希
+//   - timeout: 超时时间（<= 0 时使用 TimeoutPolicy.Wait）
+//   - backoff: 退避策略（零值等同于 DefaultReceiptBackoff）
+//
+// 返回：
+//   - *types.Receipt: 交易收据
+//   - error: 如果超时（ErrReceiptTimeout）或上下文被取消则返回错误
+//
+// 使用示例：
+//
+//	receipt, err := kit.WaitForReceiptWithBackoff(ctx, txHash, 10*time.Minute, etherkit.ReceiptBackoff{OnNewBlock: true})
+func (k *Kit) WaitForReceiptWithBackoff(ctx context.Context, txHash common.Hash, timeout time.Duration, backoff ReceiptBackoff) (*types.Receipt, error) {
+	if timeout <= 0 {
+		timeout = k.timeouts.Wait
+	}
+	return waitForReceiptBackoff(ctx, k.EtherProvider, txHash, timeout, backoff)
+}
+
+// waitForReceiptBackoff 按退避策略轮询交易收据，直到交易被打包或超时
+func waitForReceiptBackoff(ctx context.Context, ep EtherProvider, txHash common.Hash, timeout time.Duration, backoff ReceiptBackoff) (*types.Receipt, error) {
+	backoff = backoff.withDefaults()
+
+	parent := ctx
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	var lastBlock uint64
+	checked := false
+	delay := backoff.Initial
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if parent.Err() != nil {
+				return nil, parent.Err()
+			}
+			return nil, fmt.Errorf("%w: %s after %s: %w", ErrReceiptTimeout, txHash.Hex(), timeout, ctx.Err())
+		case <-timer.C:
+		}
+
+		poll := true
+		if backoff.OnNewBlock {
+			// 查询区块高度失败时仍然查询收据
+			if number, err := ep.GetBlockNumber(ctx); err == nil {
+				poll = !checked || number != lastBlock
+				lastBlock = number
+			}
+		}
+		if poll {
+			checked = true
+			receipt, err := ep.GetTransactionReceipt(ctx, txHash)
+			if err == nil && receipt != nil {
+				return receipt, nil
+			}
+		}
+
+		timer.Reset(delay)
+		delay = backoff.next(delay)
+	}
+}
@@ -0,0 +1,95 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestReceiptBackoffNext(t *testing.T) {
+	b := ReceiptBackoff{}.withDefaults()
+	want := []time.Duration{2 * time.Second, 4 * time.Second, 8 * time.Second, 16 * time.Second, 16 * time.Second}
+	delay := b.Initial
+	for i, w := range want {
+		delay = b.next(delay)
+		if delay != w {
+			t.Errorf("step %d: delay = %s, expected %s", i, delay, w)
+		}
+	}
+}
+
+func TestWaitForReceiptWithBackoff(t *testing.T) {
+	hash := common.HexToHash("0x1234")
+	backoff := ReceiptBackoff{Initial: 10 * time.Millisecond, Max: 20 * time.Millisecond}
+
+	t.Run("backoff", func(t *testing.T) {
+		server := newMockSendServer(t)
+		server.handlers["eth_getTransactionReceipt"] = func(params []json.RawMessage) (interface{}, error) {
+			// 第 4 次查询时已打包
+			if server.callCount("eth_getTransactionReceipt") < 4 {
+				return nil, nil
+			}
+			return &types.Receipt{Status: 1, TxHash: hash, BlockNumber: big.NewInt(1), Logs: []*types.Log{}}, nil
+		}
+		kit := newMockKit(t, server)
+
+		start := time.Now()
+		receipt, err := kit.WaitForReceiptWithBackoff(context.Background(), hash, time.Second, backoff)
+		if err != nil {
+			t.Fatalf("WaitForReceiptWithBackoff() failed: %v", err)
+		}
+		if receipt.TxHash != hash {
+			t.Errorf("receipt.TxHash = %s, expected %s", receipt.TxHash, hash)
+		}
+		// 等待时间：10ms + 20ms + 20ms（上限）
+		if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+			t.Errorf("elapsed = %s, expected backoff of at least 50ms", elapsed)
+		}
+	})
+
+	t.Run("on new block", func(t *testing.T) {
+		server := newMockSendServer(t)
+		server.handlers["eth_blockNumber"] = func(params []json.RawMessage) (interface{}, error) {
+			// 前 3 次查询区块高度不变
+			if server.callCount("eth_blockNumber") <= 3 {
+				return hexutil.Uint64(5), nil
+			}
+			return hexutil.Uint64(6), nil
+		}
+		server.handlers["eth_getTransactionReceipt"] = func(params []json.RawMessage) (interface{}, error) {
+			if server.callCount("eth_blockNumber") <= 3 {
+				return nil, nil
+			}
+			return &types.Receipt{Status: 1, TxHash: hash, BlockNumber: big.NewInt(6), Logs: []*types.Log{}}, nil
+		}
+		kit := newMockKit(t, server)
+
+		b := backoff
+		b.OnNewBlock = true
+		if _, err := kit.WaitForReceiptWithBackoff(context.Background(), hash, time.Second, b); err != nil {
+			t.Fatalf("WaitForReceiptWithBackoff() failed: %v", err)
+		}
+		// 区块高度不变时不查询收据
+		if calls := server.callCount("eth_getTransactionReceipt"); calls != 2 {
+			t.Errorf("eth_getTransactionReceipt count = %d, expected 2", calls)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		server := newMockSendServer(t)
+		server.handlers["eth_getTransactionReceipt"] = mockResult(nil)
+		kit := newMockKit(t, server)
+
+		_, err := kit.WaitForReceiptWithBackoff(context.Background(), hash, 50*time.Millisecond, backoff)
+		if !errors.Is(err, ErrReceiptTimeout) {
+			t.Errorf("WaitForReceiptWithBackoff() error = %v, want ErrReceiptTimeout", err)
+		}
+	})
+}
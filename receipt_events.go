@@ -0,0 +1,100 @@
+package etherkit
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+//############ Receipt Events ############
+
+// FindEventsInReceipt 查找并解码收据中所有指定名称的事件
+// 事件签名相同但 indexed 参数数量不同的日志（如 ERC20 与 ERC721 的 Transfer）会因无法按 ABI 解码而被跳过
+// 参数说明：
+//   - receipt: 交易收据
+//   - contractAbi: 包含事件定义的合约 ABI
+//   - eventName: 事件名称（如 "Transfer"）
+//
+// 返回：
+//   - []DecodedEvent: 解码后的事件（按日志顺序，可能来自多个合约，需要时检查 DecodedEvent.Address）
+//   - error: 如果 ABI 中没有该事件（ErrInvalidABI）或收据中没有匹配的事件（ErrEventNotFound）则返回错误
+func FindEventsInReceipt(receipt *types.Receipt, contractAbi abi.ABI, eventName string) ([]DecodedEvent, error) {
+	event, ok := contractAbi.Events[eventName]
+	if !ok {
+		return nil, fmt.Errorf("%w: event %s not defined", ErrInvalidABI, eventName)
+	}
+	var events []DecodedEvent
+	for _, log := range receipt.Logs {
+		if len(log.Topics) == 0 || log.Topics[0] != event.ID {
+			continue
+		}
+		if decoded, err := DecodeEvent(contractAbi, log); err == nil {
+			events = append(events, *decoded)
+		}
+	}
+	if len(events) == 0 {
+		return nil, fmt.Errorf("%w: %s in tx %s", ErrEventNotFound, eventName, receipt.TxHash.Hex())
+	}
+	return events, nil
+}
+
+// FindEventInReceipt 查找并解码收据中第一个指定名称的事件
+// 参数说明：
+//   - receipt: 交易收据
+//   - contractAbi: 包含事件定义的合约 ABI
+//   - eventName: 事件名称（如 "Transfer"）
+//
+// 返回：
+//   - *DecodedEvent: 解码后的事件
+//   - error: 如果 ABI 中没有该事件（ErrInvalidABI）或收据中没有匹配的事件（ErrEventNotFound）则返回错误
+//
+// 使用示例：
+//
+//	txHash, err := kit.InvokeContract(ctx, token, erc20Abi, "transfer", 0, 0, nil, nil, to, amount)
+//	receipt, err := kit.WaitForReceipt(ctx, txHash, time.Minute)
+//	event, err := FindEventInReceipt(receipt, erc20Abi, "Transfer")
+//	fmt.Println(event.Args["value"])
+func FindEventInReceipt(receipt *types.Receipt, contractAbi abi.ABI, eventName string) (*DecodedEvent, error) {
+	events, err := FindEventsInReceipt(receipt, contractAbi, eventName)
+	if err != nil {
+		return nil, err
+	}
+	return &events[0], nil
+}
+
+// GetEventArg 查找收据中第一个指定名称的事件并返回其参数值
+// 参数说明：
+//   - receipt: 交易收据
+//   - contractAbi: 包含事件定义的合约 ABI
+//   - eventName: 事件名称
+//   - argName: 参数名称（包括 indexed 参数）
+//
+// 返回：
+//   - interface{}: 参数值（类型与 abi.Unpack 一致，如 uint256 为 *big.Int）
+//   - error: 如果找不到事件或事件没有该参数则返回错误
+func GetEventArg(receipt *types.Receipt, contractAbi abi.ABI, eventName, argName string) (interface{}, error) {
+	event, err := FindEventInReceipt(receipt, contractAbi, eventName)
+	if err != nil {
+		return nil, err
+	}
+	value, ok := event.Args[argName]
+	if !ok {
+		return nil, fmt.Errorf("event %s has no argument %q", eventName, argName)
+	}
+	return value, nil
+}
+
+// MustGetEventArg 与 GetEventArg 相同，但找不到事件或参数时 panic
+// 适用于测试和脚本中确定交易一定会产生该事件的场景
+//
+// 使用示例：
+//
+//	tokenID := MustGetEventArg(receipt, nftAbi, "Transfer", "tokenId").(*big.Int)
+func MustGetEventArg(receipt *types.Receipt, contractAbi abi.ABI, eventName, argName string) interface{} {
+	value, err := GetEventArg(receipt, contractAbi, eventName, argName)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
@@ -0,0 +1,79 @@
+package etherkit
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestFindEventInReceipt(t *testing.T) {
+	token := common.HexToAddress("0x1c7D4B196Cb0C7B01d743Fbc6116a902379C7238")
+	from := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	to := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	// ERC721 Transfer 的 topic0 相同但无法按 ERC20 ABI 解码，应被跳过
+	nftLog := transferLog(token, from, to, 0)
+	nftLog.Topics = append(nftLog.Topics, common.BigToHash(big.NewInt(7)))
+	nftLog.Data = nil
+	approval := &types.Log{Address: token, Topics: []common.Hash{erc20ABI.Events["Approval"].ID, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())}, Data: common.BigToHash(big.NewInt(5)).Bytes()}
+
+	tests := []struct {
+		name      string
+		logs      []*types.Log
+		event     string
+		wantCount int
+		wantValue int64
+		wantErr   error
+	}{
+		{name: "first of many", logs: []*types.Log{approval, transferLog(token, from, to, 100), transferLog(token, to, from, 40)}, event: "Transfer", wantCount: 2, wantValue: 100},
+		{name: "skips undecodable", logs: []*types.Log{nftLog, transferLog(token, from, to, 100)}, event: "Transfer", wantCount: 1, wantValue: 100},
+		{name: "not emitted", logs: []*types.Log{approval}, event: "Transfer", wantErr: ErrEventNotFound},
+		{name: "unknown event", logs: []*types.Log{approval}, event: "Deposit", wantErr: ErrInvalidABI},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receipt := &types.Receipt{Status: types.ReceiptStatusSuccessful, Logs: tt.logs}
+			event, err := FindEventInReceipt(receipt, erc20ABI, tt.event)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("FindEventInReceipt() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FindEventInReceipt() failed: %v", err)
+			}
+			if value := event.Args["value"].(*big.Int); value.Int64() != tt.wantValue {
+				t.Errorf("value = %s, expected %d", value, tt.wantValue)
+			}
+			events, _ := FindEventsInReceipt(receipt, erc20ABI, tt.event)
+			if len(events) != tt.wantCount {
+				t.Errorf("len(FindEventsInReceipt()) = %d, expected %d", len(events), tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestMustGetEventArg(t *testing.T) {
+	token := common.HexToAddress("0x1c7D4B196Cb0C7B01d743Fbc6116a902379C7238")
+	from := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	to := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	receipt := &types.Receipt{Logs: []*types.Log{transferLog(token, from, to, 100)}}
+
+	if got := MustGetEventArg(receipt, erc20ABI, "Transfer", "to"); got != to {
+		t.Errorf("MustGetEventArg(to) = %v, expected %s", got, to)
+	}
+	if _, err := GetEventArg(receipt, erc20ABI, "Transfer", "amount"); err == nil {
+		t.Error("GetEventArg() with unknown argument should fail")
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("MustGetEventArg() should panic when the event is missing")
+		}
+	}()
+	MustGetEventArg(receipt, erc20ABI, "Approval", "value")
+}
@@ -0,0 +1,181 @@
+package etherkit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+//############ Bulk Receipts ############
+
+// 批量收据查询参数
+const (
+	// DefaultBlockReceiptsThreshold 同一区块中请求的交易数达到该值时改用 eth_getBlockReceipts 查询整个区块
+	DefaultBlockReceiptsThreshold = 4
+	// receiptBatchSize 单次 JSON-RPC 批量请求的最大条目数
+	receiptBatchSize = 100
+)
+
+// ReceiptRef 待查询收据的交易（已知所在区块时可以合并为区块收据查询）
+type ReceiptRef struct {
+	TxHash      common.Hash // 交易哈希
+	BlockNumber uint64      // 交易所在区块号（0 表示未知）
+}
+
+// ReceiptRefsFromLogs 从事件日志构建收据查询列表（按交易去重，保留首次出现的顺序）
+func ReceiptRefsFromLogs(logs []types.Log) []ReceiptRef {
+	seen := make(map[common.Hash]bool, len(logs))
+	var refs []ReceiptRef
+	for _, log := range logs {
+		if seen[log.TxHash] {
+			continue
+		}
+		seen[log.TxHash] = true
+		refs = append(refs, ReceiptRef{TxHash: log.TxHash, BlockNumber: log.BlockNumber})
+	}
+	return refs
+}
+
+// GetReceipts 批量查询交易收据（每 100 条合并为一次 JSON-RPC 批量请求）
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - hashes: 交易哈希列表
+//
+// 返回：
+//   - []*types.Receipt: 与 hashes 顺序一致的收据（交易未打包或不存在时为 nil）
+//   - error: 如果请求失败则返回错误
+func GetReceipts(ctx context.Context, ep EtherProvider, hashes []common.Hash) ([]*types.Receipt, error) {
+	refs := make([]ReceiptRef, len(hashes))
+	for i, hash := range hashes {
+		refs[i] = ReceiptRef{TxHash: hash}
+	}
+	return GetReceiptsByRef(ctx, ep, refs)
+}
+
+// GetReceiptsByRef 批量查询交易收据，尽量减少请求量
+// 已知区块号且同一区块中请求的交易数不少于 DefaultBlockReceiptsThreshold 时使用 eth_getBlockReceipts 查询整个区块，
+// 其余交易使用批量 eth_getTransactionReceipt；节点不支持 eth_getBlockReceipts 时自动回退
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - refs: 待查询的交易（可通过 ReceiptRefsFromLogs 从事件日志构建）
+//
+// 返回：
+//   - []*types.Receipt: 与 refs 顺序一致的收据（交易未打包或不存在时为 nil）
+//   - error: 如果请求失败则返回错误
+func GetReceiptsByRef(ctx context.Context, ep EtherProvider, refs []ReceiptRef) ([]*types.Receipt, error) {
+	byBlock := make(map[uint64]int)
+	for _, ref := range refs {
+		if ref.BlockNumber != 0 {
+			byBlock[ref.BlockNumber]++
+		}
+	}
+	var blocks []uint64
+	var singles []common.Hash
+	registered := make(map[uint64]bool)
+	for _, ref := range refs {
+		if ref.BlockNumber == 0 || byBlock[ref.BlockNumber] < DefaultBlockReceiptsThreshold {
+			singles = append(singles, ref.TxHash)
+			continue
+		}
+		if !registered[ref.BlockNumber] {
+			registered[ref.BlockNumber] = true
+			blocks = append(blocks, ref.BlockNumber)
+		}
+	}
+
+	found := make(map[common.Hash]*types.Receipt, len(refs))
+	if len(blocks) > 0 {
+		if err := fetchBlockReceipts(ctx, ep, blocks, found); err != nil {
+			if !isMethodNotFound(err) {
+				return nil, err
+			}
+			// 节点不支持 eth_getBlockReceipts，改为逐笔查询
+			singles = singles[:0]
+			for _, ref := range refs {
+				singles = append(singles, ref.TxHash)
+			}
+		}
+	}
+	if err := fetchTxReceipts(ctx, ep, singles, found); err != nil {
+		return nil, err
+	}
+
+	receipts := make([]*types.Receipt, len(refs))
+	for i, ref := range refs {
+		receipts[i] = found[ref.TxHash]
+	}
+	return receipts, nil
+}
+
+// fetchBlockReceipts 批量查询整个区块的收据
+func fetchBlockReceipts(ctx context.Context, ep EtherProvider, blocks []uint64, found map[common.Hash]*types.Receipt) error {
+	elems := make([]rpc.BatchElem, len(blocks))
+	results := make([][]*types.Receipt, len(blocks))
+	for i, number := range blocks {
+		elems[i] = rpc.BatchElem{Method: "eth_getBlockReceipts", Args: []interface{}{hexutil.Uint64(number)}, Result: &results[i]}
+	}
+	if err := batchCall(ctx, ep, elems); err != nil {
+		return err
+	}
+	for i := range results {
+		for _, receipt := range results[i] {
+			found[receipt.TxHash] = receipt
+		}
+	}
+	return nil
+}
+
+// fetchTxReceipts 批量查询单笔交易的收据
+func fetchTxReceipts(ctx context.Context, ep EtherProvider, hashes []common.Hash, found map[common.Hash]*types.Receipt) error {
+	var elems []rpc.BatchElem
+	for _, hash := range hashes {
+		if _, ok := found[hash]; !ok {
+			elems = append(elems, rpc.BatchElem{Method: "eth_getTransactionReceipt", Args: []interface{}{hash}})
+		}
+	}
+	results := make([]*types.Receipt, len(elems))
+	for i := range elems {
+		elems[i].Result = &results[i]
+	}
+	if err := batchCall(ctx, ep, elems); err != nil {
+		return err
+	}
+	for i, elem := range elems {
+		if results[i] != nil {
+			found[elem.Args[0].(common.Hash)] = results[i]
+		}
+	}
+	return nil
+}
+
+// batchCall 按 receiptBatchSize 分批发送批量请求，返回请求错误或所有条目错误的合并
+func batchCall(ctx context.Context, ep EtherProvider, elems []rpc.BatchElem) error {
+	for start := 0; start < len(elems); start += receiptBatchSize {
+		batch := elems[start:min(start+receiptBatchSize, len(elems))]
+		if err := ep.GetRpcClient().BatchCallContext(ctx, batch); err != nil {
+			return err
+		}
+		var errs []error
+		for _, elem := range batch {
+			if elem.Error != nil {
+				errs = append(errs, fmt.Errorf("%s %v: %w", elem.Method, elem.Args[0], elem.Error))
+			}
+		}
+		if err := errors.Join(errs...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetReceipts 批量查询交易收据（与 hashes 顺序一致，未打包的交易为 nil）
+func (k *Kit) GetReceipts(ctx context.Context, hashes []common.Hash) ([]*types.Receipt, error) {
+	return GetReceipts(ctx, k.EtherProvider, hashes)
+}
@@ -0,0 +1,109 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// newMockReceiptServer 区块 n 中包含交易 n*100+0 ... n*100+9，supportBlockReceipts 控制是否支持 eth_getBlockReceipts
+func newMockReceiptServer(t *testing.T, supportBlockReceipts bool) *mockRPCServer {
+	t.Helper()
+	receipt := func(hash common.Hash) *types.Receipt {
+		id := hash.Big().Uint64()
+		return &types.Receipt{Status: types.ReceiptStatusSuccessful, TxHash: hash, BlockNumber: new(big.Int).SetUint64(id / 100), Logs: []*types.Log{}}
+	}
+	handlers := map[string]mockRPCHandler{
+		"eth_getTransactionReceipt": func(params []json.RawMessage) (interface{}, error) {
+			var hash common.Hash
+			if err := json.Unmarshal(params[0], &hash); err != nil {
+				return nil, err
+			}
+			if hash.Big().Uint64() == 0 {
+				return nil, nil // 未打包
+			}
+			return receipt(hash), nil
+		},
+	}
+	if supportBlockReceipts {
+		handlers["eth_getBlockReceipts"] = func(params []json.RawMessage) (interface{}, error) {
+			var number hexutil.Uint64
+			if err := json.Unmarshal(params[0], &number); err != nil {
+				return nil, err
+			}
+			var receipts []*types.Receipt
+			for i := uint64(0); i < 10; i++ {
+				receipts = append(receipts, receipt(common.BigToHash(new(big.Int).SetUint64(uint64(number)*100+i))))
+			}
+			return receipts, nil
+		}
+	}
+	return newMockRPCServer(t, handlers)
+}
+
+func TestGetReceiptsByRef(t *testing.T) {
+	// 区块 5 中 5 笔交易（合并为区块查询），区块 7 中 1 笔交易，以及一笔未打包的交易
+	var refs []ReceiptRef
+	for i := int64(0); i < 5; i++ {
+		refs = append(refs, ReceiptRef{TxHash: common.BigToHash(big.NewInt(500 + i)), BlockNumber: 5})
+	}
+	refs = append(refs, ReceiptRef{TxHash: common.BigToHash(big.NewInt(703)), BlockNumber: 7}, ReceiptRef{TxHash: common.Hash{}})
+
+	for _, supported := range []bool{true, false} {
+		server := newMockReceiptServer(t, supported)
+		kit := newMockKit(t, server)
+
+		receipts, err := GetReceiptsByRef(context.Background(), kit.EtherProvider, refs)
+		if err != nil {
+			t.Fatalf("GetReceiptsByRef(supported=%v) failed: %v", supported, err)
+		}
+		for i, ref := range refs[:6] {
+			if receipts[i] == nil || receipts[i].TxHash != ref.TxHash {
+				t.Errorf("receipts[%d] = %v, expected %s", i, receipts[i], ref.TxHash.Hex())
+			}
+		}
+		if receipts[6] != nil {
+			t.Errorf("receipts[6] = %v, expected nil for pending tx", receipts[6])
+		}
+
+		blockCalls, txCalls := server.callCount("eth_getBlockReceipts"), server.callCount("eth_getTransactionReceipt")
+		if supported && (blockCalls != 1 || txCalls != 2) {
+			t.Errorf("supported: block calls %d, tx calls %d, expected 1 and 2", blockCalls, txCalls)
+		}
+		if !supported && txCalls != 7 {
+			t.Errorf("fallback: tx calls %d, expected 7", txCalls)
+		}
+	}
+}
+
+func TestGetReceipts(t *testing.T) {
+	server := newMockReceiptServer(t, true)
+	kit := newMockKit(t, server)
+
+	hashes := make([]common.Hash, 250)
+	for i := range hashes {
+		hashes[i] = common.BigToHash(big.NewInt(int64(1000 + i)))
+	}
+	receipts, err := kit.GetReceipts(context.Background(), hashes)
+	if err != nil {
+		t.Fatalf("GetReceipts() failed: %v", err)
+	}
+	for i, receipt := range receipts {
+		if receipt == nil || receipt.TxHash != hashes[i] {
+			t.Fatalf("receipts[%d] mismatch", i)
+		}
+	}
+	if calls := server.callCount("eth_getBlockReceipts"); calls != 0 {
+		t.Errorf("eth_getBlockReceipts count = %d, expected 0 without block numbers", calls)
+	}
+
+	refs := ReceiptRefsFromLogs([]types.Log{{TxHash: hashes[0], BlockNumber: 10}, {TxHash: hashes[0], BlockNumber: 10}, {TxHash: hashes[1], BlockNumber: 10}})
+	if len(refs) != 2 || refs[1].TxHash != hashes[1] {
+		t.Errorf("ReceiptRefsFromLogs() = %v", refs)
+	}
+}
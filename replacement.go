@@ -0,0 +1,225 @@
+package etherkit
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+//############ Replacement Policy ############
+
+// ReplacementPolicy 同 nonce 替换交易（加速、取消、批量发送的加价重发）的加价策略
+type ReplacementPolicy struct {
+	BumpPercent int      // 每次替换的加价比例（低于 MinReplacementBumpPercent 时按其处理）
+	MaxAttempts int      // 同一笔交易最多替换的次数（<= 0 表示不限制）
+	MaxTotalFee *big.Int // 替换交易的最大手续费上限 gasLimit * maxFeePerGas（单位为 Wei，nil 表示不限制）
+}
+
+// DefaultReplacementPolicy 默认的替换策略（每次加价 MinReplacementBumpPercent，最多替换 5 次，不限制手续费）
+var DefaultReplacementPolicy = ReplacementPolicy{BumpPercent: MinReplacementBumpPercent, MaxAttempts: 5}
+
+// Bump 计算第 attempt 次替换的 gas 价格
+// 参数说明：
+//   - price: 当前 gas 价格（动态费用交易为 maxFeePerGas 或 maxPriorityFeePerGas）
+//   - gasLimit: 交易的 gas 限制（用于检查 MaxTotalFee）
+//   - attempt: 第几次替换（从 1 开始）
+//
+// 返回：
+//   - *big.Int: 加价后的 gas 价格
+//   - error: 如果超过 MaxAttempts 或 MaxTotalFee 则返回包装了 ErrReplacementLimit 的错误
+func (p ReplacementPolicy) Bump(price *big.Int, gasLimit uint64, attempt int) (*big.Int, error) {
+	if p.MaxAttempts > 0 && attempt > p.MaxAttempts {
+		return nil, fmt.Errorf("%w: %d replacements allowed", ErrReplacementLimit, p.MaxAttempts)
+	}
+	bumped := BumpGasPrice(price, max(p.BumpPercent, MinReplacementBumpPercent))
+	if p.MaxTotalFee != nil {
+		total := new(big.Int).Mul(bumped, new(big.Int).SetUint64(gasLimit))
+		if total.Cmp(p.MaxTotalFee) > 0 {
+			return nil, fmt.Errorf("%w: fee %s exceeds max total fee %s", ErrReplacementLimit, total, p.MaxTotalFee)
+		}
+	}
+	return bumped, nil
+}
+
+// WithReplacementPolicy 设置 Kit 默认的替换策略（SpeedUpTx、CancelTx、CancelAllPending、
+// MonitorTx 过期取消和 NewBulkSender 共用，未设置时使用 DefaultReplacementPolicy）
+func WithReplacementPolicy(policy ReplacementPolicy) KitOption {
+	return func(k *Kit) {
+		k.replacement = &policy
+	}
+}
+
+// ReplacementPolicy 返回 Kit 默认的替换策略
+func (k *Kit) ReplacementPolicy() ReplacementPolicy {
+	if k.replacement == nil {
+		return DefaultReplacementPolicy
+	}
+	return *k.replacement
+}
+
+// replacementPolicy 返回单次调用使用的替换策略（policy 为 nil 时使用 Kit 默认策略）
+func (k *Kit) replacementPolicy(policy *ReplacementPolicy) ReplacementPolicy {
+	if policy == nil {
+		return k.ReplacementPolicy()
+	}
+	return *policy
+}
+
+// pendingOwnTx 查询交易并确认是本账户尚未打包的交易
+func (k *Kit) pendingOwnTx(ctx context.Context, txHash common.Hash) (*types.Transaction, error) {
+	tx, isPending, err := k.GetTransactionByHash(ctx, txHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction %s: %w", txHash.Hex(), err)
+	}
+	if !isPending {
+		return nil, fmt.Errorf("transaction %s is already mined", txHash.Hex())
+	}
+	from, err := txSender(tx)
+	if err != nil {
+		return nil, err
+	}
+	if from != k.GetAddress() {
+		return nil, fmt.Errorf("transaction %s is sent by %s, not by this wallet", txHash.Hex(), from.Hex())
+	}
+	return tx, nil
+}
+
+// SpeedUpTx 以相同的 nonce、接收地址、金额和数据重新发送待处理交易，并按替换策略加价
+// 动态费用交易同时提高 maxFeePerGas 和 maxPriorityFeePerGas
+// 参数说明：
+//   - ctx: 上下文对象
+//   - txHash: 待加速的交易哈希（必须是本账户尚未打包的交易）
+//   - policy: 替换策略（nil 使用 Kit 默认策略，见 WithReplacementPolicy）
+//
+// 返回：
+//   - common.Hash: 替换交易的哈希
+//   - error: 如果原交易已打包、不属于本账户、超过 MaxTotalFee 或发送失败则返回错误
+//
+// 使用示例：
+//
+//	newHash, err := kit.SpeedUpTx(ctx, stuckHash, &ReplacementPolicy{BumpPercent: 25, MaxTotalFee: ToWei(0.01, EthDecimals)})
+func (k *Kit) SpeedUpTx(ctx context.Context, txHash common.Hash, policy *ReplacementPolicy) (common.Hash, error) {
+	original, err := k.pendingOwnTx(ctx, txHash)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	tx, attempt, err := k.bumpTx(ctx, original, k.replacementPolicy(policy))
+	if err != nil {
+		return common.Hash{}, err
+	}
+	signedTx, err := k.signReplacement(ctx, tx, attempt)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return k.SendSignedTx(ctx, signedTx)
+}
+
+// replacementAttempt 返回 nonce 下一次替换是第几次替换（从 1 开始）
+// 替换次数来自本 Kit 的签名记录，配置了交易存储时同时参考存储中的记录（重启后仍能累计）
+func (k *Kit) replacementAttempt(ctx context.Context, nonce uint64) int {
+	replacements := 0
+	if k.signed != nil {
+		if record, ok := k.signed.Get(nonce); ok {
+			replacements = record.replacements
+		}
+	}
+	if k.txStore != nil {
+		if stored, err := k.txStore.List(ctx); err == nil {
+			self := k.GetAddress()
+			for _, tx := range stored {
+				if tx.From == self && tx.Nonce == nonce {
+					replacements = max(replacements, tx.Replacements)
+				}
+			}
+		}
+	}
+	return replacements + 1
+}
+
+// signReplacement 签名第 attempt 次替换交易并记录替换次数（attempt <= 0 表示不是替换交易）
+func (k *Kit) signReplacement(ctx context.Context, tx *types.Transaction, attempt int) (*types.Transaction, error) {
+	signedTx, err := k.signTx(ctx, tx, nil)
+	if err != nil {
+		return nil, err
+	}
+	if attempt > 0 && k.signed != nil {
+		if record, ok := k.signed.Get(tx.Nonce()); ok && record.tx.Hash() == signedTx.Hash() {
+			record.replacements = max(record.replacements, attempt)
+			k.signed.Add(tx.Nonce(), record)
+		}
+	}
+	return signedTx, nil
+}
+
+// bumpTx 按替换策略构建同一 nonce 的加价交易（接收地址、金额和数据不变），同时返回这是第几次替换
+func (k *Kit) bumpTx(ctx context.Context, original *types.Transaction, p ReplacementPolicy) (*types.Transaction, int, error) {
+	attempt := k.replacementAttempt(ctx, original.Nonce())
+	feeCap, err := p.Bump(original.GasFeeCap(), original.Gas(), attempt)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var tx *types.Transaction
+	switch original.Type() {
+	case types.LegacyTxType:
+		tx = types.NewTx(&types.LegacyTx{
+			Nonce:    original.Nonce(),
+			GasPrice: feeCap,
+			Gas:      original.Gas(),
+			To:       original.To(),
+			Value:    original.Value(),
+			Data:     original.Data(),
+		})
+	case types.AccessListTxType:
+		tx = types.NewTx(&types.AccessListTx{
+			ChainID:    original.ChainId(),
+			Nonce:      original.Nonce(),
+			GasPrice:   feeCap,
+			Gas:        original.Gas(),
+			To:         original.To(),
+			Value:      original.Value(),
+			Data:       original.Data(),
+			AccessList: original.AccessList(),
+		})
+	case types.DynamicFeeTxType:
+		tx = types.NewTx(&types.DynamicFeeTx{
+			ChainID:    original.ChainId(),
+			Nonce:      original.Nonce(),
+			GasTipCap:  BumpGasPrice(original.GasTipCap(), max(p.BumpPercent, MinReplacementBumpPercent)),
+			GasFeeCap:  feeCap,
+			Gas:        original.Gas(),
+			To:         original.To(),
+			Value:      original.Value(),
+			Data:       original.Data(),
+			AccessList: original.AccessList(),
+		})
+	default:
+		return nil, 0, fmt.Errorf("speeding up transaction type %d is not supported", original.Type())
+	}
+	return tx, attempt, nil
+}
+
+// CancelTx 使用相同 nonce 发送向自己转账 0 的交易替换待处理交易，并按替换策略加价
+// 参数说明：
+//   - ctx: 上下文对象
+//   - txHash: 待取消的交易哈希（必须是本账户尚未打包的交易）
+//   - policy: 替换策略（nil 使用 Kit 默认策略，见 WithReplacementPolicy）
+//
+// 返回：
+//   - common.Hash: 取消交易的哈希
+//   - error: 如果原交易已打包、不属于本账户、超过 MaxTotalFee 或发送失败则返回错误
+func (k *Kit) CancelTx(ctx context.Context, txHash common.Hash, policy *ReplacementPolicy) (common.Hash, error) {
+	original, err := k.pendingOwnTx(ctx, txHash)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	attempt := k.replacementAttempt(ctx, original.Nonce())
+	gasPrice, err := k.replacementPolicy(policy).Bump(original.GasFeeCap(), DefaultGasLimit, attempt)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return k.sendNoopTx(ctx, original.Nonce(), gasPrice, attempt)
+}
@@ -0,0 +1,188 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestReplacementPolicyBump(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   ReplacementPolicy
+		price    int64
+		gasLimit uint64
+		attempt  int
+		want     int64
+		wantErr  error
+	}{
+		{name: "default", policy: DefaultReplacementPolicy, price: 10e9, gasLimit: 21000, attempt: 1, want: 11e9},
+		{name: "below minimum bump", policy: ReplacementPolicy{BumpPercent: 5}, price: 10e9, gasLimit: 21000, attempt: 1, want: 11e9},
+		{name: "custom bump", policy: ReplacementPolicy{BumpPercent: 50}, price: 10e9, gasLimit: 21000, attempt: 9, want: 15e9},
+		{name: "too many attempts", policy: ReplacementPolicy{MaxAttempts: 2}, price: 10e9, gasLimit: 21000, attempt: 3, wantErr: ErrReplacementLimit},
+		{name: "within max total fee", policy: ReplacementPolicy{MaxTotalFee: big.NewInt(231000e9)}, price: 10e9, gasLimit: 21000, attempt: 1, want: 11e9},
+		{name: "exceeds max total fee", policy: ReplacementPolicy{MaxTotalFee: big.NewInt(230999e9)}, price: 10e9, gasLimit: 21000, attempt: 1, wantErr: ErrReplacementLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.policy.Bump(big.NewInt(tt.price), tt.gasLimit, tt.attempt)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Bump() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Bump() failed: %v", err)
+			}
+			if got.Int64() != tt.want {
+				t.Errorf("Bump() = %s, expected %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// newMockReplaceServer 模拟节点：eth_getTransactionByHash 返回待处理的 pending 交易，记录广播的替换交易
+func newMockReplaceServer(t *testing.T, pending *types.Transaction, sent **types.Transaction) *mockRPCServer {
+	t.Helper()
+	server := newMockSendServer(t)
+	server.handlers["eth_getTransactionByHash"] = mockResult(pending)
+	server.handlers["eth_sendRawTransaction"] = func(params []json.RawMessage) (interface{}, error) {
+		var raw hexutil.Bytes
+		if err := json.Unmarshal(params[0], &raw); err != nil {
+			return nil, err
+		}
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(raw); err != nil {
+			return nil, err
+		}
+		*sent = tx
+		return tx.Hash(), nil
+	}
+	return server
+}
+
+func TestSpeedUpTx(t *testing.T) {
+	pk, _ := BuildPrivateKeyFromHex("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	other, _ := GetTestAccount(1)
+	signer := types.NewLondonSigner(big.NewInt(1))
+	to := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	legacy := types.MustSignNewTx(pk, signer, &types.LegacyTx{Nonce: 3, Gas: 50000, GasPrice: big.NewInt(5e9), To: &to, Value: big.NewInt(1), Data: []byte{0x01}})
+	dynamic := types.MustSignNewTx(pk, signer, &types.DynamicFeeTx{ChainID: big.NewInt(1), Nonce: 4, Gas: 50000, GasFeeCap: big.NewInt(10e9), GasTipCap: big.NewInt(1e9), To: &to, Value: big.NewInt(2)})
+	foreign := types.MustSignNewTx(other.PrivateKey, signer, &types.LegacyTx{Nonce: 0, Gas: 21000, GasPrice: big.NewInt(5e9), To: &to})
+
+	tests := []struct {
+		name    string
+		pending *types.Transaction
+		opts    []KitOption
+		policy  *ReplacementPolicy
+		wantFee int64
+		wantTip int64
+		wantErr error
+	}{
+		{name: "legacy with per-call policy", pending: legacy, policy: &ReplacementPolicy{BumpPercent: 25}, wantFee: 6.25e9, wantTip: 6.25e9},
+		{name: "dynamic with kit policy", pending: dynamic, opts: []KitOption{WithReplacementPolicy(ReplacementPolicy{BumpPercent: 20})}, wantFee: 12e9, wantTip: 1.2e9},
+		{name: "dynamic with default policy", pending: dynamic, wantFee: 11e9, wantTip: 1.1e9},
+		{name: "exceeds max total fee", pending: legacy, policy: &ReplacementPolicy{MaxTotalFee: big.NewInt(250000e9)}, wantErr: ErrReplacementLimit},
+		{name: "not own transaction", pending: foreign},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent *types.Transaction
+			kit := newMockKit(t, newMockReplaceServer(t, tt.pending, &sent), tt.opts...)
+
+			_, err := kit.SpeedUpTx(context.Background(), tt.pending.Hash(), tt.policy)
+			if tt.wantErr != nil || tt.wantFee == 0 {
+				if err == nil || (tt.wantErr != nil && !errors.Is(err, tt.wantErr)) {
+					t.Fatalf("SpeedUpTx() error = %v, want %v", err, tt.wantErr)
+				}
+				if sent != nil {
+					t.Error("no replacement should be broadcast")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SpeedUpTx() failed: %v", err)
+			}
+			if sent.Nonce() != tt.pending.Nonce() || sent.Type() != tt.pending.Type() || *sent.To() != to || sent.Value().Cmp(tt.pending.Value()) != 0 || string(sent.Data()) != string(tt.pending.Data()) {
+				t.Errorf("replacement does not match original: %+v", sent)
+			}
+			if sent.GasFeeCap().Int64() != tt.wantFee || sent.GasTipCap().Int64() != tt.wantTip {
+				t.Errorf("fee cap = %s, tip = %s, expected %d, %d", sent.GasFeeCap(), sent.GasTipCap(), tt.wantFee, tt.wantTip)
+			}
+		})
+	}
+}
+
+func TestCancelTx(t *testing.T) {
+	pk, _ := BuildPrivateKeyFromHex("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	to := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	pending := types.MustSignNewTx(pk, types.NewLondonSigner(big.NewInt(1)), &types.DynamicFeeTx{ChainID: big.NewInt(1), Nonce: 9, Gas: 50000, GasFeeCap: big.NewInt(10e9), GasTipCap: big.NewInt(1e9), To: &to, Value: big.NewInt(2)})
+
+	var sent *types.Transaction
+	kit := newMockKit(t, newMockReplaceServer(t, pending, &sent), WithReplacementPolicy(ReplacementPolicy{BumpPercent: 30}))
+	if _, err := kit.CancelTx(context.Background(), pending.Hash(), nil); err != nil {
+		t.Fatalf("CancelTx() failed: %v", err)
+	}
+	if sent.Nonce() != 9 || *sent.To() != kit.GetAddress() || sent.Value().Sign() != 0 || sent.GasPrice().Int64() != 13e9 {
+		t.Errorf("cancel tx = {nonce %d, to %s, value %s, gasPrice %s}", sent.Nonce(), sent.To(), sent.Value(), sent.GasPrice())
+	}
+}
+
+func TestReplacementMaxAttempts(t *testing.T) {
+	pk, _ := BuildPrivateKeyFromHex("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	to := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	pending := types.MustSignNewTx(pk, types.NewLondonSigner(big.NewInt(1)), &types.LegacyTx{Nonce: 3, Gas: 21000, GasPrice: big.NewInt(5e9), To: &to})
+	policy := ReplacementPolicy{MaxAttempts: 2}
+
+	t.Run("counts replacements per nonce", func(t *testing.T) {
+		var sent *types.Transaction
+		kit := newMockKit(t, newMockReplaceServer(t, pending, &sent), WithReplacementPolicy(policy))
+		if _, err := kit.SpeedUpTx(context.Background(), pending.Hash(), nil); err != nil {
+			t.Fatalf("SpeedUpTx() failed: %v", err)
+		}
+		if _, err := kit.CancelTx(context.Background(), pending.Hash(), nil); err != nil {
+			t.Fatalf("CancelTx() failed: %v", err)
+		}
+		sent = nil
+		if _, err := kit.SpeedUpTx(context.Background(), pending.Hash(), nil); !errors.Is(err, ErrReplacementLimit) {
+			t.Fatalf("third replacement error = %v, want ErrReplacementLimit", err)
+		}
+		if sent != nil {
+			t.Error("no replacement should be broadcast after MaxAttempts")
+		}
+	})
+
+	t.Run("restores count from tx store", func(t *testing.T) {
+		var sent *types.Transaction
+		store := NewMemoryTxStore()
+		kit := newMockKit(t, newMockReplaceServer(t, pending, &sent), WithReplacementPolicy(policy), WithTxStore(store))
+		raw, _ := pending.MarshalBinary()
+		_ = store.Save(context.Background(), &StoredTx{Hash: pending.Hash(), From: kit.GetAddress(), Nonce: 3, Raw: raw, Status: StoredTxPending, Replacements: 2})
+		if _, err := kit.SpeedUpTx(context.Background(), pending.Hash(), nil); !errors.Is(err, ErrReplacementLimit) {
+			t.Fatalf("SpeedUpTx() error = %v, want ErrReplacementLimit", err)
+		}
+	})
+
+	t.Run("stores replacement count", func(t *testing.T) {
+		var sent *types.Transaction
+		store := NewMemoryTxStore()
+		kit := newMockKit(t, newMockReplaceServer(t, pending, &sent), WithTxStore(store))
+		hash, err := kit.SpeedUpTx(context.Background(), pending.Hash(), nil)
+		if err != nil {
+			t.Fatalf("SpeedUpTx() failed: %v", err)
+		}
+		stored, _ := store.List(context.Background())
+		if len(stored) != 1 || stored[0].Hash != hash || stored[0].Replacements != 1 {
+			t.Errorf("stored = %+v, expected replacement %s with Replacements 1", stored, hash.Hex())
+		}
+	})
+}
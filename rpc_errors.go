@@ -0,0 +1,213 @@
+package etherkit
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+//############ RPC Error Normalization ############
+
+// RevertError 合约执行回滚（eth_call、eth_estimateGas 或交易执行失败），包含节点返回的回滚数据
+type RevertError struct {
+	Data   []byte // 回滚数据（ABI 编码的 Error(string)、Panic(uint256) 或自定义错误，节点未返回时为空）
+	Reason string // 解码后的回滚原因（自定义错误或无数据时为空）
+	err    error  // 节点返回的原始错误
+}
+
+// Error 实现 error 接口
+func (e *RevertError) Error() string {
+	switch {
+	case e.Reason != "":
+		return fmt.Sprintf("%s: %s", ErrReverted, e.Reason)
+	case len(e.Data) > 0:
+		return fmt.Sprintf("%s: data 0x%x", ErrReverted, e.Data)
+	case e.err != nil:
+		return fmt.Sprintf("%s: %s", ErrReverted, e.err)
+	}
+	return ErrReverted.Error()
+}
+
+// Unwrap 支持 errors.Is(err, ErrReverted) 以及对原始错误的判断
+func (e *RevertError) Unwrap() []error {
+	if e.err == nil {
+		return []error{ErrReverted}
+	}
+	return []error{ErrReverted, e.err}
+}
+
+// Selector 返回回滚数据的 4 字节错误选择器（数据不足 4 字节时返回 false）
+func (e *RevertError) Selector() ([4]byte, bool) {
+	var selector [4]byte
+	if len(e.Data) < 4 {
+		return selector, false
+	}
+	copy(selector[:], e.Data[:4])
+	return selector, true
+}
+
+// DecodeCustomError 使用合约 ABI 解码自定义错误（Solidity 0.8.4+ 的 error 定义）
+// 参数说明：
+//   - contractAbi: 包含错误定义的合约 ABI
+//
+// 返回：
+//   - string: 错误名称
+//   - interface{}: 解码后的错误参数
+//   - error: 如果回滚数据不匹配 ABI 中的任何错误则返回错误
+func (e *RevertError) DecodeCustomError(contractAbi abi.ABI) (string, interface{}, error) {
+	selector, ok := e.Selector()
+	if !ok {
+		return "", nil, errors.New("revert data too short for an error selector")
+	}
+	for name, abiErr := range contractAbi.Errors {
+		if bytes.Equal(abiErr.ID[:4], selector[:]) {
+			args, err := abiErr.Unpack(e.Data)
+			if err != nil {
+				return "", nil, fmt.Errorf("failed to decode error %s: %w", name, err)
+			}
+			return name, args, nil
+		}
+	}
+	return "", nil, fmt.Errorf("no error in ABI matches selector 0x%x", selector)
+}
+
+// rpcErrorPattern 节点错误信息中的关键字与对应的标准错误
+type rpcErrorPattern struct {
+	substr string
+	target error
+}
+
+// rpcErrorPatterns 各客户端（geth、erigon、nethermind、besu）和服务商（Alchemy、Infura）的错误信息关键字
+// 按顺序匹配，更具体的关键字必须排在前面（如 replacement transaction underpriced 在 transaction underpriced 之前）
+var rpcErrorPatterns = []rpcErrorPattern{
+	// 余额不足
+	{"insufficient funds", ErrInsufficientFunds},                   // geth、erigon、Alchemy、Infura
+	{"insufficientfunds", ErrInsufficientFunds},                    // nethermind
+	{"upfront cost exceeds account balance", ErrInsufficientFunds}, // besu
+	{"sender doesn't have enough funds", ErrInsufficientFunds},     // openethereum
+	// 已在交易池中
+	{"already known", ErrAlreadyKnown},     // geth、erigon
+	{"alreadyknown", ErrAlreadyKnown},      // nethermind
+	{"known transaction", ErrAlreadyKnown}, // besu、旧版 geth
+	{"already imported", ErrAlreadyKnown},  // openethereum
+	{"transaction already exists", ErrAlreadyKnown},
+	// nonce
+	{"nonce too low", ErrNonceTooLow},               // geth、erigon、besu
+	{"oldnonce", ErrNonceTooLow},                    // nethermind
+	{"nonce has already been used", ErrNonceTooLow}, // Infura
+	{"nonce too high", ErrInvalidNonce},
+	{"noncegap", ErrInvalidNonce}, // nethermind
+	// 替换交易费用不足
+	{"replacement transaction underpriced", ErrReplacementUnderpriced}, // geth、erigon、besu
+	{"replacement fee too low", ErrReplacementUnderpriced},
+	{"feetoolowtocompete", ErrReplacementUnderpriced}, // nethermind
+	// gas 价格过低
+	{"transaction underpriced", ErrInvalidGasPrice},
+	{"max fee per gas less than block base fee", ErrInvalidGasPrice},
+	{"gas price too low", ErrInvalidGasPrice},
+	{"feetoolow", ErrInvalidGasPrice}, // nethermind
+	// gas 限制
+	{"intrinsic gas too low", ErrInvalidGasLimit},
+	{"exceeds block gas limit", ErrInvalidGasLimit},
+	{"gas limit reached", ErrInvalidGasLimit},
+	{"gaslimitexceeded", ErrInvalidGasLimit}, // nethermind
+}
+
+// kitErrors 已经是标准错误时 NormalizeError 不再重复包装
+var kitErrors = []error{
+	ErrInsufficientFunds, ErrAlreadyKnown, ErrInvalidNonce, ErrInvalidGasPrice, ErrInvalidGasLimit, ErrReverted,
+}
+
+// NormalizeError 将各节点客户端和服务商返回的错误转换为统一的标准错误
+// 转换后的错误同时保留原始错误（errors.Is/errors.As 对两者都成立），错误信息以标准错误开头
+//   - 合约回滚：返回 *RevertError（包含回滚数据和解码后的原因）
+//   - 余额不足：ErrInsufficientFunds
+//   - nonce：ErrNonceTooLow、ErrInvalidNonce
+//   - 替换交易费用不足：ErrReplacementUnderpriced；gas 价格过低：ErrInvalidGasPrice
+//   - gas 限制：ErrInvalidGasLimit；交易已在交易池中：ErrAlreadyKnown
+//
+// 参数说明：
+//   - err: 节点返回的错误
+//
+// 返回：
+//   - error: 标准化后的错误（无法识别时原样返回，nil 返回 nil）
+//
+// 使用示例：
+//
+//	_, err := kit.SendTx(ctx, to, 0, 0, nil, value, data)
+//	var revert *RevertError
+//	switch {
+//	case errors.As(err, &revert):
+//	    log.Printf("reverted: %s", revert.Reason)
+//	case errors.Is(err, ErrNonceTooLow):
+//	    // 重新获取 nonce
+//	}
+func NormalizeError(err error) error {
+	if err == nil {
+		return nil
+	}
+	for _, target := range kitErrors {
+		if errors.Is(err, target) {
+			return err
+		}
+	}
+	if revert := asRevertError(err); revert != nil {
+		return revert
+	}
+	msg := strings.ToLower(err.Error())
+	for _, p := range rpcErrorPatterns {
+		if strings.Contains(msg, p.substr) {
+			return fmt.Errorf("%w: %w", p.target, err)
+		}
+	}
+	return err
+}
+
+// asRevertError 识别合约回滚错误并提取回滚数据
+// geth/erigon/Alchemy/Infura 返回错误码 3 且 data 为十六进制回滚数据；nethermind 的 data 为 "Reverted 0x..." 形式
+func asRevertError(err error) *RevertError {
+	var rpcErr interface{ ErrorCode() int }
+	isCode3 := errors.As(err, &rpcErr) && rpcErr.ErrorCode() == 3
+
+	var data []byte
+	var dataErr interface{ ErrorData() interface{} }
+	if errors.As(err, &dataErr) {
+		if s, ok := dataErr.ErrorData().(string); ok {
+			data = parseRevertData(s)
+		}
+	}
+
+	msg := strings.ToLower(err.Error())
+	if !isCode3 && !strings.Contains(msg, "reverted") && !strings.Contains(msg, "vm execution error") {
+		return nil
+	}
+	revert := &RevertError{Data: data, err: err}
+	if reason, unpackErr := abi.UnpackRevert(data); unpackErr == nil {
+		revert.Reason = reason
+	} else if i := strings.Index(msg, "execution reverted: "); i >= 0 && len(data) == 0 {
+		// 部分节点只在错误信息中返回原因
+		revert.Reason = err.Error()[i+len("execution reverted: "):]
+	}
+	return revert
+}
+
+// parseRevertData 从节点返回的 data 字段中提取十六进制回滚数据
+func parseRevertData(s string) []byte {
+	i := strings.Index(s, "0x")
+	if i < 0 {
+		return nil
+	}
+	hexData := s[i+2:]
+	if j := strings.IndexFunc(hexData, func(r rune) bool { return !strings.ContainsRune("0123456789abcdefABCDEF", r) }); j >= 0 {
+		hexData = hexData[:j]
+	}
+	data, err := hex.DecodeString(hexData)
+	if err != nil {
+		return nil
+	}
+	return data
+}
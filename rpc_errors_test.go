@@ -0,0 +1,140 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestNormalizeError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want []error // errors.Is 应成立的错误
+	}{
+		{"geth insufficient funds", errors.New("insufficient funds for gas * price + value"), []error{ErrInsufficientFunds}},
+		{"nethermind insufficient funds", errors.New("InsufficientFunds, Account balance: 0, cumulative cost: 21000"), []error{ErrInsufficientFunds}},
+		{"besu insufficient funds", errors.New("Upfront cost exceeds account balance"), []error{ErrInsufficientFunds}},
+		{"geth nonce too low", errors.New("nonce too low: next nonce 6, tx nonce 5"), []error{ErrNonceTooLow, ErrInvalidNonce}},
+		{"nethermind nonce too low", errors.New("OldNonce, Current nonce: 6, nonce of rejected tx: 5"), []error{ErrNonceTooLow}},
+		{"nonce too high", errors.New("nonce too high"), []error{ErrInvalidNonce}},
+		{"geth replacement underpriced", errors.New("replacement transaction underpriced"), []error{ErrReplacementUnderpriced, ErrInvalidGasPrice}},
+		{"nethermind replacement underpriced", errors.New("FeeTooLowToCompete"), []error{ErrReplacementUnderpriced}},
+		{"underpriced", errors.New("transaction underpriced"), []error{ErrInvalidGasPrice}},
+		{"base fee", errors.New("max fee per gas less than block base fee: address 0x..., maxFeePerGas: 1, baseFee: 2"), []error{ErrInvalidGasPrice}},
+		{"intrinsic gas", errors.New("intrinsic gas too low"), []error{ErrInvalidGasLimit}},
+		{"already known", errors.New("already known"), []error{ErrAlreadyKnown}},
+		{"nethermind already known", errors.New("AlreadyKnown"), []error{ErrAlreadyKnown}},
+		{"unknown", errors.New("header not found"), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			normalized := NormalizeError(tt.err)
+			if !errors.Is(normalized, tt.err) {
+				t.Errorf("normalized error should keep the original error")
+			}
+			for _, want := range tt.want {
+				if !errors.Is(normalized, want) {
+					t.Errorf("errors.Is(%v, %v) = false", normalized, want)
+				}
+			}
+			if tt.want == nil && normalized != tt.err {
+				t.Errorf("NormalizeError() = %v, expected unchanged", normalized)
+			}
+			// 重复标准化不会再次包装
+			if again := NormalizeError(normalized); again != normalized {
+				t.Errorf("NormalizeError() is not idempotent: %v", again)
+			}
+		})
+	}
+	if NormalizeError(nil) != nil {
+		t.Error("NormalizeError(nil) should be nil")
+	}
+}
+
+// mockDataError 模拟带 data 字段的 JSON-RPC 错误
+type mockDataError struct {
+	code int
+	msg  string
+	data interface{}
+}
+
+func (e *mockDataError) Error() string          { return e.msg }
+func (e *mockDataError) ErrorCode() int         { return e.code }
+func (e *mockDataError) ErrorData() interface{} { return e.data }
+
+func TestNormalizeRevertError(t *testing.T) {
+	reasonData := append(common.FromHex("0x08c379a0"), mustPackString(t, "ERC20: transfer amount exceeds balance")...)
+	customABI, _ := abi.JSON(strings.NewReader(`[{"type":"error","name":"InsufficientBalance","inputs":[{"name":"available","type":"uint256"},{"name":"required","type":"uint256"}]}]`))
+	customErr := customABI.Errors["InsufficientBalance"]
+	customArgs, _ := customErr.Inputs.Pack(big.NewInt(1), big.NewInt(2))
+	customData := append(customErr.ID[:4:4], customArgs...)
+
+	tests := []struct {
+		name       string
+		err        error
+		wantReason string
+		wantData   []byte
+	}{
+		{"geth reason", &mockDataError{code: 3, msg: "execution reverted: ERC20: transfer amount exceeds balance", data: hexutil.Encode(reasonData)}, "ERC20: transfer amount exceeds balance", reasonData},
+		{"nethermind data", &mockDataError{code: -32015, msg: "VM execution error.", data: "Reverted " + hexutil.Encode(customData)}, "", customData},
+		{"message only", errors.New("execution reverted: Ownable: caller is not the owner"), "Ownable: caller is not the owner", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var revert *RevertError
+			if !errors.As(NormalizeError(tt.err), &revert) {
+				t.Fatalf("NormalizeError() = %v, expected *RevertError", NormalizeError(tt.err))
+			}
+			if !errors.Is(revert, ErrReverted) || !errors.Is(revert, tt.err) {
+				t.Errorf("RevertError should match ErrReverted and the original error")
+			}
+			if revert.Reason != tt.wantReason {
+				t.Errorf("Reason = %q, expected %q", revert.Reason, tt.wantReason)
+			}
+			if string(revert.Data) != string(tt.wantData) {
+				t.Errorf("Data = %x, expected %x", revert.Data, tt.wantData)
+			}
+		})
+	}
+
+	revert := &RevertError{Data: customData}
+	name, args, err := revert.DecodeCustomError(customABI)
+	if err != nil || name != "InsufficientBalance" {
+		t.Fatalf("DecodeCustomError() = %s, %v, %v", name, args, err)
+	}
+}
+
+func mustPackString(t *testing.T, s string) []byte {
+	t.Helper()
+	stringType, _ := abi.NewType("string", "", nil)
+	data, err := abi.Arguments{{Type: stringType}}.Pack(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestEstimateGasRevert(t *testing.T) {
+	server := newMockSendServer(t)
+	server.handlers["eth_estimateGas"] = func(params []json.RawMessage) (interface{}, error) {
+		return nil, &mockRPCError{Code: 3, Message: "execution reverted: paused"}
+	}
+	kit := newMockKit(t, server)
+
+	recipient := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	_, err := kit.SendTx(context.Background(), recipient, 0, 0, nil, big.NewInt(1), []byte{0x01})
+	var revert *RevertError
+	if !errors.As(err, &revert) || revert.Reason != "paused" {
+		t.Errorf("SendTx() error = %v, expected *RevertError with reason paused", err)
+	}
+}
@@ -0,0 +1,507 @@
+package etherkit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+//############ Safe ############
+
+// SafeOperation Safe 交易的调用类型
+type SafeOperation uint8
+
+// Safe 交易调用类型
+const (
+	SafeCall         SafeOperation = 0 // 普通调用
+	SafeDelegateCall SafeOperation = 1 // delegatecall（在 Safe 上下文中执行目标合约代码）
+)
+
+// Safe Transaction Service 地址
+const (
+	SafeTxServiceMainnetURL  = "https://safe-transaction-mainnet.safe.global"
+	SafeTxServiceSepoliaURL  = "https://safe-transaction-sepolia.safe.global"
+	SafeTxServicePolygonURL  = "https://safe-transaction-polygon.safe.global"
+	SafeTxServiceArbitrumURL = "https://safe-transaction-arbitrum.safe.global"
+	SafeTxServiceOptimismURL = "https://safe-transaction-optimism.safe.global"
+	SafeTxServiceBSCURL      = "https://safe-transaction-bsc.safe.global"
+)
+
+// ErrUnsupportedSafeVersion Safe 合约版本不受支持（v1.0.0 之前的 SafeTx 使用 dataGas 字段，交易哈希格式不同）
+var ErrUnsupportedSafeVersion = errors.New("unsupported safe version")
+
+// safeTxServiceURLs 各链的 Safe Transaction Service 地址
+var safeTxServiceURLs = map[int64]string{
+	MainnetChainID:  SafeTxServiceMainnetURL,
+	SepoliaChainID:  SafeTxServiceSepoliaURL,
+	PolygonChainID:  SafeTxServicePolygonURL,
+	ArbitrumChainID: SafeTxServiceArbitrumURL,
+	OptimismChainID: SafeTxServiceOptimismURL,
+	BSCChainID:      SafeTxServiceBSCURL,
+}
+
+// safeABI Safe 合约中用到的方法（兼容 v1.0.0 及以上版本）
+const safeABI = `[
+{"inputs":[],"name":"getOwners","outputs":[{"internalType":"address[]","name":"","type":"address[]"}],"stateMutability":"view","type":"function"},
+{"inputs":[],"name":"getThreshold","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+{"inputs":[],"name":"nonce","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+{"inputs":[],"name":"VERSION","outputs":[{"internalType":"string","name":"","type":"string"}],"stateMutability":"view","type":"function"},
+{"inputs":[{"internalType":"address","name":"to","type":"address"},{"internalType":"uint256","name":"value","type":"uint256"},{"internalType":"bytes","name":"data","type":"bytes"},{"internalType":"uint8","name":"operation","type":"uint8"},{"internalType":"uint256","name":"safeTxGas","type":"uint256"},{"internalType":"uint256","name":"baseGas","type":"uint256"},{"internalType":"uint256","name":"gasPrice","type":"uint256"},{"internalType":"address","name":"gasToken","type":"address"},{"internalType":"address payable","name":"refundReceiver","type":"address"},{"internalType":"bytes","name":"signatures","type":"bytes"}],"name":"execTransaction","outputs":[{"internalType":"bool","name":"success","type":"bool"}],"stateMutability":"payable","type":"function"}
+]`
+
+// safeTxTypes SafeTx 的 EIP-712 类型定义
+var safeTxTypes = apitypes.Types{
+	"SafeTx": {
+		{Name: "to", Type: "address"},
+		{Name: "value", Type: "uint256"},
+		{Name: "data", Type: "bytes"},
+		{Name: "operation", Type: "uint8"},
+		{Name: "safeTxGas", Type: "uint256"},
+		{Name: "baseGas", Type: "uint256"},
+		{Name: "gasPrice", Type: "uint256"},
+		{Name: "gasToken", Type: "address"},
+		{Name: "refundReceiver", Type: "address"},
+		{Name: "nonce", Type: "uint256"},
+	},
+}
+
+// SafeTx Safe 多签交易
+type SafeTx struct {
+	To             common.Address // 目标地址
+	Value          *big.Int       // 转账金额（单位为 Wei）
+	Data           []byte         // 调用数据
+	Operation      SafeOperation  // 调用类型
+	SafeTxGas      *big.Int       // Safe 内部执行的 gas 限制（0 表示使用全部可用 gas）
+	BaseGas        *big.Int       // 与执行无关的 gas 开销（用于退款计算）
+	GasPrice       *big.Int       // 退款使用的 gas 价格（0 表示不退款）
+	GasToken       common.Address // 退款代币（零地址表示本位币）
+	RefundReceiver common.Address // 退款接收地址（零地址表示 tx.origin）
+	Nonce          *big.Int       // Safe nonce
+}
+
+// Safe Safe（原 Gnosis Safe）多签钱包
+type Safe struct {
+	Address common.Address // Safe 合约地址
+	ChainID *big.Int       // 链 ID
+	Version string         // 合约版本（NewSafe 从链上读取；为空表示 v1.3.0 及以上）
+	ep      EtherProvider
+	abi     abi.ABI
+}
+
+// NewSafe 创建 Safe 实例
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - address: Safe 合约地址
+//
+// 返回：
+//   - *Safe: Safe 实例（Version 为链上读取的合约版本，决定交易哈希的 EIP-712 域格式）
+//   - error: 如果查询链 ID 或合约版本失败，或版本早于 v1.0.0（ErrUnsupportedSafeVersion）则返回错误
+func NewSafe(ctx context.Context, ep EtherProvider, address common.Address) (*Safe, error) {
+	parsed, err := GetABI(safeABI)
+	if err != nil {
+		return nil, err
+	}
+	chainId, err := ep.GetChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	safe := &Safe{Address: address, ChainID: chainId, ep: ep, abi: parsed}
+	if safe.Version, err = safe.GetVersion(ctx); err != nil {
+		return nil, err
+	}
+	version, err := parseSafeVersion(safe.Version)
+	if err != nil {
+		return nil, err
+	}
+	if version[0] < 1 {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedSafeVersion, safe.Version)
+	}
+	return safe, nil
+}
+
+// parseSafeVersion 解析 Safe 合约版本号（如 "1.3.0"、"1.4.1+L2"）
+func parseSafeVersion(version string) ([3]int, error) {
+	var parsed [3]int
+	core, _, _ := strings.Cut(version, "+")
+	parts := strings.Split(core, ".")
+	if len(parts) != len(parsed) {
+		return parsed, fmt.Errorf("%w: %q", ErrUnsupportedSafeVersion, version)
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return parsed, fmt.Errorf("%w: %q", ErrUnsupportedSafeVersion, version)
+		}
+		parsed[i] = n
+	}
+	return parsed, nil
+}
+
+// isLegacySafeDomain 判断 Safe 是否使用 v1.3.0 之前的 EIP-712 域（只有 verifyingContract，不含 chainId）
+// 版本为空或无法解析时按 v1.3.0 及以上处理
+func isLegacySafeDomain(version string) bool {
+	parsed, err := parseSafeVersion(version)
+	if err != nil {
+		return false
+	}
+	return parsed[0] < 1 || (parsed[0] == 1 && parsed[1] < 3)
+}
+
+// call 调用 Safe 合约的只读方法
+func (s *Safe) call(ctx context.Context, method string, args ...interface{}) ([]interface{}, error) {
+	data, err := s.abi.Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+	res, err := providerExt[BlockRefReader](s.ep).CallContractAt(ctx, ethereum.CallMsg{To: &s.Address, Data: data}, BlockRef{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call safe %s: %w", method, err)
+	}
+	return s.abi.Unpack(method, res)
+}
+
+// GetOwners 获取 Safe 的所有者列表
+func (s *Safe) GetOwners(ctx context.Context) ([]common.Address, error) {
+	out, err := s.call(ctx, "getOwners")
+	if err != nil {
+		return nil, err
+	}
+	return *abi.ConvertType(out[0], new([]common.Address)).(*[]common.Address), nil
+}
+
+// GetThreshold 获取执行交易所需的签名数量
+func (s *Safe) GetThreshold(ctx context.Context) (uint64, error) {
+	out, err := s.call(ctx, "getThreshold")
+	if err != nil {
+		return 0, err
+	}
+	return abi.ConvertType(out[0], new(big.Int)).(*big.Int).Uint64(), nil
+}
+
+// GetNonce 获取 Safe 当前的交易 nonce
+func (s *Safe) GetNonce(ctx context.Context) (*big.Int, error) {
+	out, err := s.call(ctx, "nonce")
+	if err != nil {
+		return nil, err
+	}
+	return abi.ConvertType(out[0], new(big.Int)).(*big.Int), nil
+}
+
+// GetVersion 获取 Safe 合约版本（如 "1.3.0"）
+func (s *Safe) GetVersion(ctx context.Context) (string, error) {
+	out, err := s.call(ctx, "VERSION")
+	if err != nil {
+		return "", err
+	}
+	return out[0].(string), nil
+}
+
+// IsOwner 检查地址是否为 Safe 的所有者
+func (s *Safe) IsOwner(ctx context.Context, address common.Address) (bool, error) {
+	owners, err := s.GetOwners(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, owner := range owners {
+		if owner == address {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// BuildTx 构建 Safe 交易（nonce 使用 Safe 当前的 nonce，不使用 gas 退款）
+// 参数说明：
+//   - ctx: 上下文对象
+//   - to: 目标地址
+//   - value: 转账金额（nil 表示不转账）
+//   - data: 调用数据
+//   - operation: 调用类型（SafeCall 或 SafeDelegateCall）
+//
+// 返回：
+//   - *SafeTx: Safe 交易
+//   - error: 如果查询 nonce 失败则返回错误
+func (s *Safe) BuildTx(ctx context.Context, to common.Address, value *big.Int, data []byte, operation SafeOperation) (*SafeTx, error) {
+	nonce, err := s.GetNonce(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &SafeTx{
+		To:        to,
+		Value:     bigOrZero(value),
+		Data:      data,
+		Operation: operation,
+		Nonce:     nonce,
+	}, nil
+}
+
+// TypedData 返回 Safe 交易的 EIP-712 结构化数据
+// v1.3.0 及以上版本的域为 {chainId, verifyingContract}，更早的版本（按 Version 判断）只有 {verifyingContract}
+func (s *Safe) TypedData(tx *SafeTx) apitypes.TypedData {
+	domain := apitypes.TypedDataDomain{VerifyingContract: s.Address.Hex()}
+	if !isLegacySafeDomain(s.Version) {
+		domain.ChainId = (*math.HexOrDecimal256)(s.ChainID)
+	}
+	return apitypes.TypedData{
+		Types:       safeTxTypes,
+		PrimaryType: "SafeTx",
+		Domain:      domain,
+		Message: apitypes.TypedDataMessage{
+			"to":             tx.To.Hex(),
+			"value":          bigOrZero(tx.Value),
+			"data":           hexutil.Bytes(tx.Data),
+			"operation":      big.NewInt(int64(tx.Operation)),
+			"safeTxGas":      bigOrZero(tx.SafeTxGas),
+			"baseGas":        bigOrZero(tx.BaseGas),
+			"gasPrice":       bigOrZero(tx.GasPrice),
+			"gasToken":       tx.GasToken.Hex(),
+			"refundReceiver": tx.RefundReceiver.Hex(),
+			"nonce":          bigOrZero(tx.Nonce),
+		},
+	}
+}
+
+// TransactionHash 计算 Safe 交易哈希（safeTxHash，与合约的 getTransactionHash 一致）
+func (s *Safe) TransactionHash(tx *SafeTx) (common.Hash, error) {
+	return HashTypedData(s.TypedData(tx))
+}
+
+// ExecTransactionData 构建 execTransaction 的调用数据
+// 参数说明：
+//   - tx: Safe 交易
+//   - signatures: 按所有者地址升序拼接的签名
+//
+// 返回：
+//   - []byte: execTransaction 调用数据
+//   - error: 如果编码失败则返回错误
+func (s *Safe) ExecTransactionData(tx *SafeTx, signatures []byte) ([]byte, error) {
+	return s.abi.Pack("execTransaction",
+		tx.To,
+		bigOrZero(tx.Value),
+		nonNilBytes(tx.Data),
+		uint8(tx.Operation),
+		bigOrZero(tx.SafeTxGas),
+		bigOrZero(tx.BaseGas),
+		bigOrZero(tx.GasPrice),
+		tx.GasToken,
+		tx.RefundReceiver,
+		signatures,
+	)
+}
+
+// SignSafeTx 使用钱包私钥对 Safe 交易进行 EIP-712 签名
+// 参数说明：
+//   - safe: Safe 实例
+//   - tx: Safe 交易
+//
+// 返回：
+//   - []byte: 签名（65 字节，v 为 27 或 28，可直接用于 execTransaction）
+//   - error: 如果签名失败则返回错误
+func (w *Wallet) SignSafeTx(safe *Safe, tx *SafeTx) ([]byte, error) {
+	return w.SignTypedData(safe.TypedData(tx))
+}
+
+// SignSafeTx 使用 Kit 的私钥对 Safe 交易进行 EIP-712 签名（签名前执行审核回调，启用审计时写入审计记录）
+// 覆盖 Wallet.SignSafeTx，审核回调看到的是 Safe 最终执行的调用
+// 参数说明：
+//   - safe: Safe 实例
+//   - tx: Safe 交易
+//
+// 返回：
+//   - []byte: 签名（65 字节，v 为 27 或 28，可直接用于 execTransaction）
+//   - error: 如果审核被拒绝、签名或写入审计记录失败则返回错误
+func (k *Kit) SignSafeTx(safe *Safe, tx *SafeTx) ([]byte, error) {
+	return k.signSafeTx(context.Background(), safe, tx)
+}
+
+// signSafeTx 审核并签名 Safe 交易（审核信息中的资金去向为 Safe 最终执行的调用）
+func (k *Kit) signSafeTx(ctx context.Context, safe *Safe, tx *SafeTx) ([]byte, error) {
+	intent := fmt.Sprintf("Safe %s transaction to %s value %s, nonce %s",
+		safe.Address.Hex(), tx.To.Hex(), bigOrZero(tx.Value), bigOrZero(tx.Nonce))
+	return k.signTypedData(ctx, safe.TypedData(tx), intent, func(review *TxReview) {
+		review.setCall(safe.Address, tx.To, bigOrZero(tx.Value), tx.Data)
+	})
+}
+
+// ExecSafeTransaction 调用 Safe 的 execTransaction 执行已收集足够签名的交易
+// 由 Kit 账户发送交易并支付 gas（Kit 账户不需要是 Safe 的所有者）
+// 参数说明：
+//   - ctx: 上下文对象
+//   - safe: Safe 实例
+//   - tx: Safe 交易
+//   - signatures: 按所有者地址升序拼接的签名
+//
+// 返回：
+//   - common.Hash: 交易哈希
+//   - error: 如果发送失败则返回错误
+func (k *Kit) ExecSafeTransaction(ctx context.Context, safe *Safe, tx *SafeTx, signatures []byte) (common.Hash, error) {
+	data, err := safe.ExecTransactionData(tx, signatures)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return k.SendTx(ctx, safe.Address, 0, 0, nil, nil, data)
+}
+
+// SafeTxServiceClient Safe Transaction Service 客户端
+// 用于把交易提议和签名提交到 Safe 官方服务，由其他所有者在 Safe{Wallet} 中确认
+type SafeTxServiceClient struct {
+	BaseURL    string       // 服务地址（不带结尾的 /）
+	HTTPClient *http.Client // HTTP 客户端（nil 表示使用 http.DefaultClient）
+}
+
+// NewSafeTxServiceClient 创建 Safe Transaction Service 客户端
+// 参数说明：
+//   - baseURL: 服务地址（如 SafeTxServiceMainnetURL）
+//
+// 返回：
+//   - *SafeTxServiceClient: 客户端实例
+func NewSafeTxServiceClient(baseURL string) *SafeTxServiceClient {
+	return &SafeTxServiceClient{BaseURL: strings.TrimRight(baseURL, "/"), HTTPClient: http.DefaultClient}
+}
+
+// NewSafeTxServiceClientForChain 根据链 ID 创建 Safe Transaction Service 客户端
+// 参数说明：
+//   - chainID: 链 ID
+//
+// 返回：
+//   - *SafeTxServiceClient: 客户端实例
+//   - error: 如果该链没有已知的服务地址则返回 ErrUnknownChain
+func NewSafeTxServiceClientForChain(chainID int64) (*SafeTxServiceClient, error) {
+	url, ok := safeTxServiceURLs[chainID]
+	if !ok {
+		return nil, fmt.Errorf("%w: no safe transaction service for chain %d", ErrUnknownChain, chainID)
+	}
+	return NewSafeTxServiceClient(url), nil
+}
+
+// safeTxProposal Safe Transaction Service 的交易提议请求体
+type safeTxProposal struct {
+	To                      common.Address `json:"to"`
+	Value                   string         `json:"value"`
+	Data                    *string        `json:"data"`
+	Operation               SafeOperation  `json:"operation"`
+	SafeTxGas               string         `json:"safeTxGas"`
+	BaseGas                 string         `json:"baseGas"`
+	GasPrice                string         `json:"gasPrice"`
+	GasToken                common.Address `json:"gasToken"`
+	RefundReceiver          common.Address `json:"refundReceiver"`
+	Nonce                   string         `json:"nonce"`
+	ContractTransactionHash common.Hash    `json:"contractTransactionHash"`
+	Sender                  common.Address `json:"sender"`
+	Signature               string         `json:"signature"`
+	Origin                  string         `json:"origin,omitempty"`
+}
+
+// ProposeTransaction 向 Safe Transaction Service 提交交易提议及提议者的签名
+// 参数说明：
+//   - ctx: 上下文对象
+//   - safe: Safe 实例
+//   - tx: Safe 交易
+//   - sender: 提议者地址（必须是 Safe 的所有者）
+//   - signature: 提议者对该交易的签名
+//
+// 返回：
+//   - error: 如果请求失败或服务返回错误则返回错误
+func (c *SafeTxServiceClient) ProposeTransaction(ctx context.Context, safe *Safe, tx *SafeTx, sender common.Address, signature []byte) error {
+	safeTxHash, err := safe.TransactionHash(tx)
+	if err != nil {
+		return err
+	}
+
+	var data *string
+	if len(tx.Data) > 0 {
+		encoded := hexutil.Encode(tx.Data)
+		data = &encoded
+	}
+	proposal := safeTxProposal{
+		To:                      tx.To,
+		Value:                   bigOrZero(tx.Value).String(),
+		Data:                    data,
+		Operation:               tx.Operation,
+		SafeTxGas:               bigOrZero(tx.SafeTxGas).String(),
+		BaseGas:                 bigOrZero(tx.BaseGas).String(),
+		GasPrice:                bigOrZero(tx.GasPrice).String(),
+		GasToken:                tx.GasToken,
+		RefundReceiver:          tx.RefundReceiver,
+		Nonce:                   bigOrZero(tx.Nonce).String(),
+		ContractTransactionHash: safeTxHash,
+		Sender:                  sender,
+		Signature:               hexutil.Encode(signature),
+		Origin:                  "go-evm-kit",
+	}
+
+	body, err := json.Marshal(proposal)
+	if err != nil {
+		return err
+	}
+	url := fmt.Sprintf("%s/api/v1/safes/%s/multisig-transactions/", c.BaseURL, safe.Address.Hex())
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to request safe transaction service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var detail json.RawMessage
+		_ = json.NewDecoder(resp.Body).Decode(&detail)
+		return fmt.Errorf("safe transaction service returned status %d: %s", resp.StatusCode, detail)
+	}
+	return nil
+}
+
+// ProposeSafeTransaction 使用 Kit 的私钥签名 Safe 交易，并提交到 Safe Transaction Service
+// Kit 账户必须是 Safe 的所有者
+// 参数说明：
+//   - ctx: 上下文对象
+//   - service: Safe Transaction Service 客户端
+//   - safe: Safe 实例
+//   - tx: Safe 交易
+//
+// 返回：
+//   - common.Hash: safeTxHash（可在 Safe{Wallet} 中查看和确认）
+//   - error: 如果签名或提交失败则返回错误
+func (k *Kit) ProposeSafeTransaction(ctx context.Context, service *SafeTxServiceClient, safe *Safe, tx *SafeTx) (common.Hash, error) {
+	if service == nil {
+		return common.Hash{}, errors.New("safe transaction service client cannot be nil")
+	}
+	isOwner, err := safe.IsOwner(ctx, k.GetAddress())
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if !isOwner {
+		return common.Hash{}, fmt.Errorf("address %s is not an owner of safe %s", k.GetAddress().Hex(), safe.Address.Hex())
+	}
+
+	signature, err := k.signSafeTx(ctx, safe, tx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if err := service.ProposeTransaction(ctx, safe, tx, k.GetAddress(), signature); err != nil {
+		return common.Hash{}, err
+	}
+	return safe.TransactionHash(tx)
+}
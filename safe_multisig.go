@@ -0,0 +1,352 @@
+package etherkit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+//############ Safe Multisig ############
+
+// ErrSafeTxHashMismatch 待签名交易声明的 safeTxHash 与按交易内容重新计算的哈希不一致
+var ErrSafeTxHashMismatch = errors.New("safe tx hash mismatch")
+
+// PendingSafeTx 正在收集签名的 Safe 交易
+// 可以序列化为 JSON 在多个签名者之间传递，每个所有者签名后合并，签名数达到阈值后即可执行
+type PendingSafeTx struct {
+	Safe       common.Address            // Safe 合约地址
+	ChainID    *big.Int                  // 链 ID
+	Version    string                    // Safe 合约版本（决定 safeTxHash 的域格式，为空表示 v1.3.0 及以上）
+	Tx         SafeTx                    // Safe 交易
+	SafeTxHash common.Hash               // 交易哈希（所有签名都针对此哈希）
+	Signatures map[common.Address][]byte // 签名者地址 → 签名
+}
+
+// pendingSafeTxJSON PendingSafeTx 的 JSON 表示
+type pendingSafeTxJSON struct {
+	Safe       common.Address                   `json:"safe"`
+	ChainID    *hexutil.Big                     `json:"chainId"`
+	Version    string                           `json:"version,omitempty"`
+	SafeTxHash common.Hash                      `json:"safeTxHash"`
+	Tx         safeTxJSON                       `json:"tx"`
+	Signatures map[common.Address]hexutil.Bytes `json:"signatures"`
+}
+
+// safeTxJSON SafeTx 的 JSON 表示
+type safeTxJSON struct {
+	To             common.Address `json:"to"`
+	Value          *hexutil.Big   `json:"value"`
+	Data           hexutil.Bytes  `json:"data"`
+	Operation      SafeOperation  `json:"operation"`
+	SafeTxGas      *hexutil.Big   `json:"safeTxGas"`
+	BaseGas        *hexutil.Big   `json:"baseGas"`
+	GasPrice       *hexutil.Big   `json:"gasPrice"`
+	GasToken       common.Address `json:"gasToken"`
+	RefundReceiver common.Address `json:"refundReceiver"`
+	Nonce          *hexutil.Big   `json:"nonce"`
+}
+
+// NewPendingSafeTx 创建待签名的 Safe 交易
+// 参数说明：
+//   - safe: Safe 实例
+//   - tx: Safe 交易
+//
+// 返回：
+//   - *PendingSafeTx: 待签名的交易
+//   - error: 如果计算交易哈希失败则返回错误
+func NewPendingSafeTx(safe *Safe, tx *SafeTx) (*PendingSafeTx, error) {
+	hash, err := safe.TransactionHash(tx)
+	if err != nil {
+		return nil, err
+	}
+	return &PendingSafeTx{
+		Safe:       safe.Address,
+		ChainID:    new(big.Int).Set(safe.ChainID),
+		Version:    safe.Version,
+		Tx:         *tx,
+		SafeTxHash: hash,
+		Signatures: make(map[common.Address][]byte),
+	}, nil
+}
+
+// RecoverSafeSigner 从 Safe 签名中恢复签名者地址
+// 支持 EIP-712 签名（v 为 27/28）和 eth_sign 签名（v 为 31/32，对 safeTxHash 做 personal_sign）
+// 参数说明：
+//   - safeTxHash: Safe 交易哈希
+//   - signature: 65 字节签名
+//
+// 返回：
+//   - common.Address: 签名者地址
+//   - error: 如果签名格式无效或恢复失败则返回错误
+func RecoverSafeSigner(safeTxHash common.Hash, signature []byte) (common.Address, error) {
+	if len(signature) != 65 {
+		return common.Address{}, fmt.Errorf("invalid signature length %d, expected 65", len(signature))
+	}
+
+	sig := common.CopyBytes(signature)
+	digest := safeTxHash.Bytes()
+	switch v := sig[64]; {
+	case v == 27 || v == 28:
+		sig[64] -= 27
+	case v == 31 || v == 32:
+		// eth_sign 签名：v 加了 4 作为标记
+		sig[64] -= 31
+		digest = accounts.TextHash(digest)
+	default:
+		return common.Address{}, fmt.Errorf("unsupported signature v value %d", v)
+	}
+
+	pub, err := crypto.SigToPub(digest, sig)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
+
+// AddSignature 添加一个签名（签名者从签名中恢复）
+// 参数说明：
+//   - signature: 65 字节签名
+//
+// 返回：
+//   - common.Address: 签名者地址
+//   - error: 如果签名无效则返回错误
+func (p *PendingSafeTx) AddSignature(signature []byte) (common.Address, error) {
+	signer, err := RecoverSafeSigner(p.SafeTxHash, signature)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if p.Signatures == nil {
+		p.Signatures = make(map[common.Address][]byte)
+	}
+	p.Signatures[signer] = common.CopyBytes(signature)
+	return signer, nil
+}
+
+// Merge 合并另一份相同交易的签名（用于汇总各签名者分别返回的结果）
+// 参数说明：
+//   - other: 另一份待签名交易
+//
+// 返回：
+//   - error: 如果两份交易的 safeTxHash 不一致或签名无效则返回错误
+func (p *PendingSafeTx) Merge(other *PendingSafeTx) error {
+	if other.SafeTxHash != p.SafeTxHash {
+		return fmt.Errorf("cannot merge different safe transactions: %s != %s", other.SafeTxHash.Hex(), p.SafeTxHash.Hex())
+	}
+	for _, signature := range other.Signatures {
+		if _, err := p.AddSignature(signature); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Signers 返回已签名的地址（按地址升序排列）
+func (p *PendingSafeTx) Signers() []common.Address {
+	signers := make([]common.Address, 0, len(p.Signatures))
+	for signer := range p.Signatures {
+		signers = append(signers, signer)
+	}
+	sort.Slice(signers, func(i, j int) bool {
+		return bytes.Compare(signers[i].Bytes(), signers[j].Bytes()) < 0
+	})
+	return signers
+}
+
+// Validate 校验交易哈希和所有签名是否有效，且签名者都是 Safe 的所有者
+// 参数说明：
+//   - ctx: 上下文对象
+//   - safe: Safe 实例
+//
+// 返回：
+//   - error: 如果交易与 Safe 不匹配、签名无效或签名者不是所有者则返回错误
+func (p *PendingSafeTx) Validate(ctx context.Context, safe *Safe) error {
+	if p.Safe != safe.Address || p.ChainID.Cmp(safe.ChainID) != 0 {
+		return fmt.Errorf("pending transaction belongs to safe %s on chain %s", p.Safe.Hex(), p.ChainID)
+	}
+	if isLegacySafeDomain(p.Version) != isLegacySafeDomain(safe.Version) {
+		return fmt.Errorf("pending transaction was hashed for safe version %q, safe is %q", p.Version, safe.Version)
+	}
+	if err := p.checkHash(); err != nil {
+		return err
+	}
+
+	owners, err := safe.GetOwners(ctx)
+	if err != nil {
+		return err
+	}
+	isOwner := make(map[common.Address]bool, len(owners))
+	for _, owner := range owners {
+		isOwner[owner] = true
+	}
+
+	for signer, signature := range p.Signatures {
+		recovered, err := RecoverSafeSigner(p.SafeTxHash, signature)
+		if err != nil {
+			return fmt.Errorf("invalid signature from %s: %w", signer.Hex(), err)
+		}
+		if recovered != signer {
+			return fmt.Errorf("signature for %s was signed by %s", signer.Hex(), recovered.Hex())
+		}
+		if !isOwner[signer] {
+			return fmt.Errorf("signer %s is not an owner of safe %s", signer.Hex(), safe.Address.Hex())
+		}
+	}
+	return nil
+}
+
+// checkHash 按 Tx、Safe、ChainID 和 Version 重新计算 safeTxHash，与声明的 SafeTxHash 不一致时返回 ErrSafeTxHashMismatch
+// 签名只针对 SafeTxHash，不校验会让签名者看到的交易内容与实际授权的交易不一致
+func (p *PendingSafeTx) checkHash() error {
+	if p.ChainID == nil {
+		return errors.New("pending safe transaction is missing chainId")
+	}
+	hash, err := p.safe().TransactionHash(&p.Tx)
+	if err != nil {
+		return err
+	}
+	if hash != p.SafeTxHash {
+		return fmt.Errorf("%w: computed %s, got %s", ErrSafeTxHashMismatch, hash.Hex(), p.SafeTxHash.Hex())
+	}
+	return nil
+}
+
+// safe 返回计算 safeTxHash 所需的 Safe（不连接节点）
+func (p *PendingSafeTx) safe() *Safe {
+	return &Safe{Address: p.Safe, ChainID: p.ChainID, Version: p.Version}
+}
+
+// EncodeSignatures 按签名者地址升序拼接签名（Safe 合约 checkSignatures 要求的格式）
+// 返回：
+//   - []byte: 拼接后的签名，可直接作为 execTransaction 的 signatures 参数
+func (p *PendingSafeTx) EncodeSignatures() []byte {
+	signers := p.Signers()
+	encoded := make([]byte, 0, len(signers)*65)
+	for _, signer := range signers {
+		encoded = append(encoded, p.Signatures[signer]...)
+	}
+	return encoded
+}
+
+// MarshalJSON 将待签名交易编码为 JSON（用于在签名者之间传递）
+func (p *PendingSafeTx) MarshalJSON() ([]byte, error) {
+	signatures := make(map[common.Address]hexutil.Bytes, len(p.Signatures))
+	for signer, signature := range p.Signatures {
+		signatures[signer] = signature
+	}
+	return json.Marshal(pendingSafeTxJSON{
+		Safe:       p.Safe,
+		ChainID:    (*hexutil.Big)(bigOrZero(p.ChainID)),
+		Version:    p.Version,
+		SafeTxHash: p.SafeTxHash,
+		Tx: safeTxJSON{
+			To:             p.Tx.To,
+			Value:          (*hexutil.Big)(bigOrZero(p.Tx.Value)),
+			Data:           nonNilBytes(p.Tx.Data),
+			Operation:      p.Tx.Operation,
+			SafeTxGas:      (*hexutil.Big)(bigOrZero(p.Tx.SafeTxGas)),
+			BaseGas:        (*hexutil.Big)(bigOrZero(p.Tx.BaseGas)),
+			GasPrice:       (*hexutil.Big)(bigOrZero(p.Tx.GasPrice)),
+			GasToken:       p.Tx.GasToken,
+			RefundReceiver: p.Tx.RefundReceiver,
+			Nonce:          (*hexutil.Big)(bigOrZero(p.Tx.Nonce)),
+		},
+		Signatures: signatures,
+	})
+}
+
+// UnmarshalJSON 从 JSON 解析待签名交易
+// 解析时会按交易内容重新计算 safeTxHash（不一致时返回 ErrSafeTxHashMismatch），
+// 并重新从签名中恢复签名者，签名与声明的签名者不一致时返回错误
+func (p *PendingSafeTx) UnmarshalJSON(input []byte) error {
+	var dec pendingSafeTxJSON
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	if dec.ChainID == nil || dec.Tx.Nonce == nil {
+		return errors.New("pending safe transaction is missing chainId or nonce")
+	}
+
+	*p = PendingSafeTx{
+		Safe:       dec.Safe,
+		ChainID:    (*big.Int)(dec.ChainID),
+		Version:    dec.Version,
+		SafeTxHash: dec.SafeTxHash,
+		Tx: SafeTx{
+			To:             dec.Tx.To,
+			Value:          (*big.Int)(dec.Tx.Value),
+			Data:           dec.Tx.Data,
+			Operation:      dec.Tx.Operation,
+			SafeTxGas:      (*big.Int)(dec.Tx.SafeTxGas),
+			BaseGas:        (*big.Int)(dec.Tx.BaseGas),
+			GasPrice:       (*big.Int)(dec.Tx.GasPrice),
+			GasToken:       dec.Tx.GasToken,
+			RefundReceiver: dec.Tx.RefundReceiver,
+			Nonce:          (*big.Int)(dec.Tx.Nonce),
+		},
+		Signatures: make(map[common.Address][]byte, len(dec.Signatures)),
+	}
+	if err := p.checkHash(); err != nil {
+		return err
+	}
+	for signer, signature := range dec.Signatures {
+		recovered, err := p.AddSignature(signature)
+		if err != nil {
+			return fmt.Errorf("invalid signature from %s: %w", signer.Hex(), err)
+		}
+		if recovered != signer {
+			return fmt.Errorf("signature for %s was signed by %s", signer.Hex(), recovered.Hex())
+		}
+	}
+	return nil
+}
+
+// SignPendingSafeTx 使用 Kit 的私钥为待签名交易添加签名（签名前执行审核回调，启用审计时写入审计记录）
+// 参数说明：
+//   - ctx: 上下文对象（传递给审核回调和审计接收器）
+//   - pending: 待签名交易
+//
+// 返回：
+//   - error: 如果 safeTxHash 与交易内容不一致（ErrSafeTxHashMismatch）、审核被拒绝、签名或写入审计记录失败则返回错误
+func (k *Kit) SignPendingSafeTx(ctx context.Context, pending *PendingSafeTx) error {
+	if err := pending.checkHash(); err != nil {
+		return err
+	}
+	signature, err := k.signSafeTx(ctx, pending.safe(), &pending.Tx)
+	if err != nil {
+		return err
+	}
+	_, err = pending.AddSignature(signature)
+	return err
+}
+
+// ExecPendingSafeTx 校验签名并执行已收集足够签名的 Safe 交易
+// 参数说明：
+//   - ctx: 上下文对象
+//   - safe: Safe 实例
+//   - pending: 待执行的交易
+//
+// 返回：
+//   - common.Hash: 交易哈希
+//   - error: 如果签名无效、签名数不足阈值或发送失败则返回错误
+func (k *Kit) ExecPendingSafeTx(ctx context.Context, safe *Safe, pending *PendingSafeTx) (common.Hash, error) {
+	if err := pending.Validate(ctx, safe); err != nil {
+		return common.Hash{}, err
+	}
+	threshold, err := safe.GetThreshold(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if uint64(len(pending.Signatures)) < threshold {
+		return common.Hash{}, fmt.Errorf("not enough signatures: have %d, threshold %d", len(pending.Signatures), threshold)
+	}
+	return k.ExecSafeTransaction(ctx, safe, &pending.Tx, pending.EncodeSignatures())
+}
@@ -0,0 +1,158 @@
+package etherkit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestPendingSafeTxAggregation(t *testing.T) {
+	safe := testSafe(t)
+	tx := &SafeTx{To: common.HexToAddress("0x01"), Value: big.NewInt(1), Nonce: big.NewInt(0)}
+
+	keys := []string{
+		"ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80",
+		"59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d",
+		"5de4111afa1a4b94908f83103eb1f1706367c2e68ca870fc3fb9a804cdab365a",
+	}
+
+	pending, err := NewPendingSafeTx(safe, tx)
+	if err != nil {
+		t.Fatalf("NewPendingSafeTx() failed: %v", err)
+	}
+
+	// 每个签名者从 JSON 反序列化、签名、再序列化返回
+	payload, err := json.Marshal(pending)
+	if err != nil {
+		t.Fatalf("json.Marshal() failed: %v", err)
+	}
+	for _, key := range keys {
+		pk, _ := BuildPrivateKeyFromHex(key)
+		wallet, _ := NewWalletWithComponents(pk, nil)
+
+		var partial PendingSafeTx
+		if err := json.Unmarshal(payload, &partial); err != nil {
+			t.Fatalf("json.Unmarshal() failed: %v", err)
+		}
+		signature, err := wallet.SignSafeTx(safe, &partial.Tx)
+		if err != nil {
+			t.Fatalf("SignSafeTx() failed: %v", err)
+		}
+		signer, err := partial.AddSignature(signature)
+		if err != nil {
+			t.Fatalf("AddSignature() failed: %v", err)
+		}
+		if signer != wallet.GetAddress() {
+			t.Errorf("AddSignature() signer = %s, expected %s", signer.Hex(), wallet.GetAddress().Hex())
+		}
+
+		if err := pending.Merge(&partial); err != nil {
+			t.Fatalf("Merge() failed: %v", err)
+		}
+	}
+
+	signers := pending.Signers()
+	if len(signers) != 3 {
+		t.Fatalf("Signers() = %d, expected 3", len(signers))
+	}
+	for i := 1; i < len(signers); i++ {
+		if bytes.Compare(signers[i-1].Bytes(), signers[i].Bytes()) >= 0 {
+			t.Errorf("Signers() not sorted: %v", signers)
+		}
+	}
+
+	// 拼接后的签名顺序与签名者地址顺序一致
+	encoded := pending.EncodeSignatures()
+	if len(encoded) != 3*65 {
+		t.Fatalf("EncodeSignatures() length = %d, expected %d", len(encoded), 3*65)
+	}
+	for i, signer := range signers {
+		recovered, err := RecoverSafeSigner(pending.SafeTxHash, encoded[i*65:(i+1)*65])
+		if err != nil || recovered != signer {
+			t.Errorf("Signature %d recovered %s, expected %s (err %v)", i, recovered.Hex(), signer.Hex(), err)
+		}
+	}
+}
+
+func TestPendingSafeTxRejectsMismatchedSigner(t *testing.T) {
+	safe := testSafe(t)
+	pending, _ := NewPendingSafeTx(safe, &SafeTx{To: common.HexToAddress("0x01"), Nonce: big.NewInt(1)})
+
+	pk, _ := BuildPrivateKeyFromHex("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	wallet, _ := NewWalletWithComponents(pk, nil)
+	signature, _ := wallet.SignHash(pending.SafeTxHash)
+	if _, err := pending.AddSignature(signature); err != nil {
+		t.Fatalf("AddSignature() failed: %v", err)
+	}
+
+	payload, _ := json.Marshal(pending)
+	var roundTrip PendingSafeTx
+	if err := json.Unmarshal(payload, &roundTrip); err != nil || len(roundTrip.Signatures) != 1 {
+		t.Fatalf("json.Unmarshal() = %d signatures, err %v", len(roundTrip.Signatures), err)
+	}
+
+	// 把签名归属篡改为其他地址
+	tampered := strings.Replace(string(payload), strings.ToLower(wallet.GetAddress().Hex()), "0x000000000000000000000000000000000000dead", 1)
+	var decoded PendingSafeTx
+	if err := json.Unmarshal([]byte(tampered), &decoded); err == nil {
+		t.Error("Expected error for signature attributed to a different signer")
+	}
+
+	// 不同交易不能合并
+	other, _ := NewPendingSafeTx(safe, &SafeTx{To: common.HexToAddress("0x01"), Nonce: big.NewInt(2)})
+	if err := pending.Merge(other); err == nil {
+		t.Error("Expected error when merging different safe transactions")
+	}
+}
+
+func TestPendingSafeTxRejectsMismatchedHash(t *testing.T) {
+	safe := testSafe(t)
+	pending, _ := NewPendingSafeTx(safe, &SafeTx{To: common.HexToAddress("0x01"), Value: big.NewInt(1), Nonce: big.NewInt(1)})
+	// 交易内容被替换，但保留原交易的 safeTxHash（签名者会对原交易签名，却看到另一笔交易）
+	pending.Tx.Value = big.NewInt(1000)
+
+	payload, _ := json.Marshal(pending)
+	var decoded PendingSafeTx
+	if err := json.Unmarshal(payload, &decoded); !errors.Is(err, ErrSafeTxHashMismatch) {
+		t.Errorf("json.Unmarshal() error = %v, want ErrSafeTxHashMismatch", err)
+	}
+
+	kit := newMockKit(t, newMockSendServer(t))
+	if err := kit.SignPendingSafeTx(context.Background(), pending); !errors.Is(err, ErrSafeTxHashMismatch) {
+		t.Errorf("SignPendingSafeTx() error = %v, want ErrSafeTxHashMismatch", err)
+	}
+	if len(pending.Signatures) != 0 {
+		t.Error("mismatched transaction must not be signed")
+	}
+}
+
+func TestPendingSafeTxLegacyVersion(t *testing.T) {
+	safe := testSafe(t)
+	safe.Version = "1.2.0"
+	pending, _ := NewPendingSafeTx(safe, &SafeTx{To: common.HexToAddress("0x01"), Value: big.NewInt(1), Nonce: big.NewInt(1)})
+	kit := newMockKit(t, newMockSendServer(t))
+	if err := kit.SignPendingSafeTx(context.Background(), pending); err != nil {
+		t.Fatalf("SignPendingSafeTx() failed: %v", err)
+	}
+
+	// 版本随 JSON 传递，接收方按同样的域格式校验哈希和签名
+	payload, _ := json.Marshal(pending)
+	var decoded PendingSafeTx
+	if err := json.Unmarshal(payload, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() failed: %v", err)
+	}
+	if decoded.Version != "1.2.0" || decoded.SafeTxHash != pending.SafeTxHash || len(decoded.Signers()) != 1 || decoded.Signers()[0] != kit.GetAddress() {
+		t.Errorf("decoded = %+v", decoded)
+	}
+
+	modern := testSafe(t)
+	if err := decoded.Validate(context.Background(), modern); err == nil {
+		t.Error("Validate() should reject a pending transaction hashed for another safe version")
+	}
+}
@@ -0,0 +1,223 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// testSafe 创建不依赖节点的 Safe 实例
+func testSafe(t *testing.T) *Safe {
+	t.Helper()
+	parsed, err := GetABI(safeABI)
+	if err != nil {
+		t.Fatalf("GetABI() failed: %v", err)
+	}
+	return &Safe{
+		Address: common.HexToAddress("0x9B5A6B4cdCBB4B5A5c1b5c9a9E7e8cB3b3bF0001"),
+		ChainID: big.NewInt(SepoliaChainID),
+		abi:     parsed,
+	}
+}
+
+func TestSafeTransactionHash(t *testing.T) {
+	safe := testSafe(t)
+	tx := &SafeTx{
+		To:    common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8"),
+		Value: big.NewInt(1e15),
+		Data:  []byte{0xde, 0xad, 0xbe, 0xef},
+		Nonce: big.NewInt(4),
+	}
+
+	hash, err := safe.TransactionHash(tx)
+	if err != nil {
+		t.Fatalf("TransactionHash() failed: %v", err)
+	}
+
+	// 按 Safe 合约的 getTransactionHash 逻辑手动计算
+	bytes32, _ := abi.NewType("bytes32", "", nil)
+	uint256, _ := abi.NewType("uint256", "", nil)
+	address, _ := abi.NewType("address", "", nil)
+	uint8Type, _ := abi.NewType("uint8", "", nil)
+
+	domainSeparator := crypto.Keccak256(mustPack(t, abi.Arguments{{Type: bytes32}, {Type: uint256}, {Type: address}},
+		common.HexToHash("0x47e79534a245952e8b16893a336b85a3d9ea9fa8c573f3d803afb92a79469218"), safe.ChainID, safe.Address))
+	structHash := crypto.Keccak256(mustPack(t, abi.Arguments{
+		{Type: bytes32}, {Type: address}, {Type: uint256}, {Type: bytes32}, {Type: uint8Type},
+		{Type: uint256}, {Type: uint256}, {Type: uint256}, {Type: address}, {Type: address}, {Type: uint256},
+	},
+		common.HexToHash("0xbb8310d486368db6bd6f849402fdd73ad53d316b5a4b2644ad6efe0f941286d8"),
+		tx.To, tx.Value, crypto.Keccak256Hash(tx.Data), uint8(0),
+		big.NewInt(0), big.NewInt(0), big.NewInt(0), common.Address{}, common.Address{}, tx.Nonce,
+	))
+	expected := crypto.Keccak256Hash([]byte{0x19, 0x01}, domainSeparator, structHash)
+
+	if hash != expected {
+		t.Errorf("TransactionHash() = %s, expected %s", hash.Hex(), expected.Hex())
+	}
+}
+
+func TestSafeLegacyTransactionHash(t *testing.T) {
+	safe := testSafe(t)
+	safe.Version = "1.1.1"
+	tx := &SafeTx{To: common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8"), Value: big.NewInt(1e15), Nonce: big.NewInt(4)}
+
+	hash, err := safe.TransactionHash(tx)
+	if err != nil {
+		t.Fatalf("TransactionHash() failed: %v", err)
+	}
+
+	// v1.3.0 之前的域只有 verifyingContract：keccak256("EIP712Domain(address verifyingContract)")
+	bytes32, _ := abi.NewType("bytes32", "", nil)
+	address, _ := abi.NewType("address", "", nil)
+	domainSeparator := crypto.Keccak256(mustPack(t, abi.Arguments{{Type: bytes32}, {Type: address}},
+		common.HexToHash("0x035aff83d86937d35b32e04f0ddc6ff469290eef2f1b692d8a815c89404d4749"), safe.Address))
+	typed := safe.TypedData(tx)
+	structHash, err := typed.HashStruct("SafeTx", typed.Message)
+	if err != nil {
+		t.Fatalf("HashStruct() failed: %v", err)
+	}
+	expected := crypto.Keccak256Hash([]byte{0x19, 0x01}, domainSeparator, structHash)
+	if hash != expected {
+		t.Errorf("TransactionHash() = %s, expected %s", hash.Hex(), expected.Hex())
+	}
+
+	safe.Version = "1.4.1+L2"
+	if modern, _ := safe.TransactionHash(tx); modern == hash {
+		t.Error("v1.4.1 transaction hash should include chainId in the domain")
+	}
+}
+
+func TestNewSafeVersion(t *testing.T) {
+	parsed, _ := GetABI(safeABI)
+	tests := []struct {
+		version string
+		wantErr bool
+	}{
+		{"1.4.1", false},
+		{"1.1.1", false},
+		{"0.1.0", true},
+		{"unknown", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			server := newMockRPCServer(t, map[string]mockRPCHandler{
+				"eth_call": func(params []json.RawMessage) (interface{}, error) {
+					return packOutputs(parsed.Methods["VERSION"].Outputs, tt.version)
+				},
+			})
+			provider, _ := NewProviderWithChainId(server.URL, SepoliaChainID)
+			defer provider.Close()
+
+			safe, err := NewSafe(context.Background(), provider, common.HexToAddress("0x9B5A6B4cdCBB4B5A5c1b5c9a9E7e8cB3b3bF0001"))
+			if tt.wantErr {
+				if !errors.Is(err, ErrUnsupportedSafeVersion) {
+					t.Fatalf("NewSafe() error = %v, expected ErrUnsupportedSafeVersion", err)
+				}
+				return
+			}
+			if err != nil || safe.Version != tt.version {
+				t.Fatalf("NewSafe() = %+v, %v", safe, err)
+			}
+		})
+	}
+}
+
+func mustPack(t *testing.T, args abi.Arguments, values ...interface{}) []byte {
+	t.Helper()
+	packed, err := args.Pack(values...)
+	if err != nil {
+		t.Fatalf("Pack() failed: %v", err)
+	}
+	return packed
+}
+
+func TestSafeGetOwners(t *testing.T) {
+	owners := []common.Address{common.HexToAddress("0x01"), common.HexToAddress("0x02")}
+	parsed, _ := GetABI(safeABI)
+
+	server := newMockRPCServer(t, map[string]mockRPCHandler{
+		"eth_call": func(params []json.RawMessage) (interface{}, error) {
+			arg, err := parseMockCallArg(params)
+			if err != nil {
+				return nil, err
+			}
+			method, err := parsed.MethodById(arg.calldata())
+			if err != nil {
+				return nil, err
+			}
+			switch method.Name {
+			case "getOwners":
+				return packOutputs(method.Outputs, owners)
+			case "VERSION":
+				return packOutputs(method.Outputs, "1.3.0")
+			default:
+				return packOutputs(method.Outputs, big.NewInt(2))
+			}
+		},
+	})
+	provider, _ := NewProviderWithChainId(server.URL, SepoliaChainID)
+	defer provider.Close()
+
+	ctx := context.Background()
+	safe, err := NewSafe(ctx, provider, common.HexToAddress("0x9B5A6B4cdCBB4B5A5c1b5c9a9E7e8cB3b3bF0001"))
+	if err != nil {
+		t.Fatalf("NewSafe() failed: %v", err)
+	}
+	if safe.Version != "1.3.0" {
+		t.Errorf("Version = %q, expected 1.3.0", safe.Version)
+	}
+
+	got, err := safe.GetOwners(ctx)
+	if err != nil {
+		t.Fatalf("GetOwners() failed: %v", err)
+	}
+	if len(got) != 2 || got[1] != owners[1] {
+		t.Errorf("GetOwners() = %v, expected %v", got, owners)
+	}
+	threshold, err := safe.GetThreshold(ctx)
+	if err != nil || threshold != 2 {
+		t.Errorf("GetThreshold() = %d, %v, expected 2", threshold, err)
+	}
+	isOwner, _ := safe.IsOwner(ctx, common.HexToAddress("0x03"))
+	if isOwner {
+		t.Error("IsOwner() should be false for non-owner")
+	}
+}
+
+func TestSafeTxServiceProposeTransaction(t *testing.T) {
+	safe := testSafe(t)
+	var path string
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	tx := &SafeTx{To: common.HexToAddress("0x01"), Value: big.NewInt(10), Nonce: big.NewInt(7)}
+	client := NewSafeTxServiceClient(server.URL + "/")
+	if err := client.ProposeTransaction(context.Background(), safe, tx, common.HexToAddress("0x02"), make([]byte, 65)); err != nil {
+		t.Fatalf("ProposeTransaction() failed: %v", err)
+	}
+
+	if path != "/api/v1/safes/"+safe.Address.Hex()+"/multisig-transactions/" {
+		t.Errorf("Request path = %s", path)
+	}
+	hash, _ := safe.TransactionHash(tx)
+	if body["contractTransactionHash"] != hash.Hex() {
+		t.Errorf("contractTransactionHash = %v, expected %s", body["contractTransactionHash"], hash.Hex())
+	}
+	if body["nonce"] != "7" || body["value"] != "10" || body["data"] != nil {
+		t.Errorf("Unexpected proposal body: %v", body)
+	}
+}
@@ -0,0 +1,104 @@
+package etherkit
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+//############ Signature Recovery ############
+
+// normalizeSignature 校验 65 字节签名并将 v 统一为 0 或 1（钱包返回的签名 v 通常为 27 或 28）
+func normalizeSignature(signature []byte) ([]byte, error) {
+	if len(signature) != crypto.SignatureLength {
+		return nil, fmt.Errorf("%w: length %d, expected %d", ErrInvalidSignature, len(signature), crypto.SignatureLength)
+	}
+	sig := common.CopyBytes(signature)
+	switch v := sig[crypto.RecoveryIDOffset]; v {
+	case 0, 1:
+	case 27, 28:
+		sig[crypto.RecoveryIDOffset] -= 27
+	default:
+		return nil, fmt.Errorf("%w: unsupported v value %d", ErrInvalidSignature, v)
+	}
+	return sig, nil
+}
+
+// RecoverPublicKey 从哈希和签名中恢复签名者公钥
+// 参数说明：
+//   - hash: 被签名的 32 字节哈希
+//   - signature: 65 字节签名（r ‖ s ‖ v，v 可以是 0/1 或 27/28）
+//
+// 返回：
+//   - *ecdsa.PublicKey: 签名者公钥
+//   - error: 如果签名格式无效或恢复失败则返回 ErrInvalidSignature
+func RecoverPublicKey(hash common.Hash, signature []byte) (*ecdsa.PublicKey, error) {
+	sig, err := normalizeSignature(signature)
+	if err != nil {
+		return nil, err
+	}
+	pub, err := crypto.SigToPub(hash.Bytes(), sig)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+	return pub, nil
+}
+
+// RecoverAddress 从哈希和签名中恢复签名者地址
+// 参数说明：
+//   - hash: 被签名的 32 字节哈希
+//   - signature: 65 字节签名（v 可以是 0/1 或 27/28）
+//
+// 返回：
+//   - common.Address: 签名者地址
+//   - error: 如果签名格式无效或恢复失败则返回 ErrInvalidSignature
+func RecoverAddress(hash common.Hash, signature []byte) (common.Address, error) {
+	pub, err := RecoverPublicKey(hash, signature)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
+
+// RecoverAddressFromPersonalSign 从 personal_sign（EIP-191）签名中恢复签名者地址
+// 会按钱包的做法为消息加上 "\x19Ethereum Signed Message:\n" + 长度 前缀后再哈希
+// 参数说明：
+//   - message: 原始消息（即传给钱包 personal_sign 的内容，而不是其哈希）
+//   - signature: 65 字节签名（v 可以是 0/1 或 27/28）
+//
+// 返回：
+//   - common.Address: 签名者地址
+//   - error: 如果签名格式无效或恢复失败则返回 ErrInvalidSignature
+//
+// 使用示例：
+//
+//	// 服务端校验"使用钱包登录"的签名
+//	signer, err := RecoverAddressFromPersonalSign([]byte(challenge), signature)
+//	if err != nil || signer != claimedAddress {
+//		return ErrSignatureVerificationFailed
+//	}
+func RecoverAddressFromPersonalSign(message []byte, signature []byte) (common.Address, error) {
+	return RecoverAddress(common.BytesToHash(accounts.TextHash(message)), signature)
+}
+
+// VerifyPersonalSign 校验 personal_sign 签名是否由指定地址创建
+// 参数说明：
+//   - message: 原始消息
+//   - signature: 65 字节签名
+//   - expected: 期望的签名者地址
+//
+// 返回：
+//   - error: 如果签名无效返回 ErrInvalidSignature，签名者不匹配返回 ErrSignatureVerificationFailed
+func VerifyPersonalSign(message []byte, signature []byte, expected common.Address) error {
+	signer, err := RecoverAddressFromPersonalSign(message, signature)
+	if err != nil {
+		return err
+	}
+	if signer != expected {
+		return fmt.Errorf("%w: signed by %s, expected %s", ErrSignatureVerificationFailed, signer.Hex(), expected.Hex())
+	}
+	return nil
+}
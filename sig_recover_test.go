@@ -0,0 +1,73 @@
+package etherkit
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestRecoverAddressFromPersonalSign(t *testing.T) {
+	account, _ := GetTestAccount(0)
+	message := []byte("Sign in to example.com\nNonce: 42")
+	signature, err := crypto.Sign(accounts.TextHash(message), account.PrivateKey)
+	if err != nil {
+		t.Fatalf("Sign() failed: %v", err)
+	}
+	// 钱包返回的签名 v 为 27 或 28
+	walletSig := common.CopyBytes(signature)
+	walletSig[64] += 27
+
+	tests := []struct {
+		name      string
+		message   []byte
+		signature []byte
+		wantMatch bool
+		wantErr   error
+	}{
+		{"v 27/28", message, walletSig, true, nil},
+		{"v 0/1", message, signature, true, nil},
+		{"different message", []byte("other"), walletSig, false, nil},
+		{"short signature", message, walletSig[:64], false, ErrInvalidSignature},
+		{"invalid v", message, append(common.CopyBytes(walletSig[:64]), 5), false, ErrInvalidSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RecoverAddressFromPersonalSign(tt.message, tt.signature)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RecoverAddressFromPersonalSign() error = %v, expected %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if (got == account.Address) != tt.wantMatch {
+				t.Errorf("RecoverAddressFromPersonalSign() = %s, expected signer match %v", got.Hex(), tt.wantMatch)
+			}
+		})
+	}
+
+	if err := VerifyPersonalSign(message, walletSig, account.Address); err != nil {
+		t.Errorf("VerifyPersonalSign() failed: %v", err)
+	}
+	other, _ := GetTestAccount(1)
+	if err := VerifyPersonalSign(message, walletSig, other.Address); !errors.Is(err, ErrSignatureVerificationFailed) {
+		t.Errorf("VerifyPersonalSign() error = %v, expected ErrSignatureVerificationFailed", err)
+	}
+}
+
+func TestRecoverPublicKey(t *testing.T) {
+	account, _ := GetTestAccount(0)
+	hash := crypto.Keccak256Hash([]byte("hello"))
+	signature, _ := crypto.Sign(hash.Bytes(), account.PrivateKey)
+
+	pub, err := RecoverPublicKey(hash, signature)
+	if err != nil {
+		t.Fatalf("RecoverPublicKey() failed: %v", err)
+	}
+	if !pub.Equal(&account.PrivateKey.PublicKey) {
+		t.Error("RecoverPublicKey() returned a different public key")
+	}
+}
@@ -0,0 +1,40 @@
+package etherkit
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//############ Simulate First ############
+
+// WithSimulateFirst 启用发送前模拟
+// InvokeContract 在构建交易前先以相同的发送地址、金额、调用数据和 gas 限制在 pending 状态上执行 eth_call，
+// 模拟回滚时直接返回 *RevertError（自定义错误会按合约 ABI 解码到 Reason 中），不会广播交易和消耗 gas
+func WithSimulateFirst() KitOption {
+	return func(k *Kit) {
+		k.simulateFirst = true
+	}
+}
+
+// simulateInvoke 以与 InvokeContract 相同的参数模拟执行合约调用
+func (k *Kit) simulateInvoke(ctx context.Context, contractAddress common.Address, contractAbi abi.ABI, functionName string, gasLimit uint64, value *big.Int, data []byte) error {
+	msg := ethereum.CallMsg{From: k.GetAddress(), To: &contractAddress, Gas: gasLimit, Value: value, Data: data}
+	_, err := k.CallContractAt(ctx, msg, BlockRef{Number: BlockTagPending.BlockNumber()})
+	if err == nil {
+		return nil
+	}
+	if revert := asRevertError(err); revert != nil {
+		if revert.Reason == "" {
+			if name, args, decodeErr := revert.DecodeCustomError(contractAbi); decodeErr == nil {
+				revert.Reason = fmt.Sprintf("%s%v", name, args)
+			}
+		}
+		err = revert
+	}
+	return fmt.Errorf("simulation of %s failed: %w", functionName, err)
+}
@@ -0,0 +1,73 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestWithSimulateFirst(t *testing.T) {
+	tokenAbi, _ := abi.JSON(strings.NewReader(`[
+		{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"type":"bool"}]},
+		{"type":"error","name":"InsufficientBalance","inputs":[{"name":"available","type":"uint256"},{"name":"required","type":"uint256"}]}
+	]`))
+	token := common.HexToAddress("0x1c7D4B196Cb0C7B01d743Fbc6116a902379C7238")
+	recipient := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	insufficient := tokenAbi.Errors["InsufficientBalance"]
+	args, _ := insufficient.Inputs.Pack(big.NewInt(5), big.NewInt(1000))
+	customErr := append(common.CopyBytes(insufficient.ID[:4]), args...)
+
+	tests := []struct {
+		name       string
+		simulate   bool
+		callErr    *mockRPCError
+		wantReason string
+		wantSent   int
+	}{
+		{"simulation passes", true, nil, "", 1},
+		{"custom error decoded", true, &mockRPCError{Code: 3, Message: "execution reverted", Data: hexutil.Encode(customErr)}, "InsufficientBalance[5 1000]", 0},
+		{"disabled", false, &mockRPCError{Code: 3, Message: "execution reverted", Data: hexutil.Encode(customErr)}, "", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newMockSendServer(t)
+			var gotBlock string
+			server.handlers["eth_call"] = func(params []json.RawMessage) (interface{}, error) {
+				_ = json.Unmarshal(params[1], &gotBlock)
+				if tt.callErr != nil {
+					return nil, tt.callErr
+				}
+				return hexutil.Encode(common.LeftPadBytes([]byte{1}, 32)), nil
+			}
+			var opts []KitOption
+			if tt.simulate {
+				opts = append(opts, WithSimulateFirst())
+			}
+			kit := newMockKit(t, server, opts...)
+
+			_, err := kit.InvokeContract(context.Background(), token, tokenAbi, "transfer", 0, 0, nil, nil, recipient, big.NewInt(1000))
+			var revert *RevertError
+			if tt.wantReason != "" {
+				if !errors.As(err, &revert) || revert.Reason != tt.wantReason {
+					t.Fatalf("InvokeContract() error = %v, expected revert %q", err, tt.wantReason)
+				}
+			} else if err != nil {
+				t.Fatalf("InvokeContract() failed: %v", err)
+			}
+			if got := server.callCount("eth_sendRawTransaction"); got != tt.wantSent {
+				t.Errorf("eth_sendRawTransaction count = %d, expected %d", got, tt.wantSent)
+			}
+			if tt.simulate && gotBlock != "pending" {
+				t.Errorf("simulation block = %q, expected pending", gotBlock)
+			}
+		})
+	}
+}
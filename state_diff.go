@@ -0,0 +1,340 @@
+package etherkit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+//############ State Diff ############
+
+// ErrStateDiffUnsupported 节点既不支持 debug_traceTransaction（prestateTracer）也不支持 trace_replayTransaction
+var ErrStateDiffUnsupported = fmt.Errorf("%w: neither prestateTracer nor trace_replayTransaction", ErrUnsupportedByEndpoint)
+
+// StorageChange 存储槽位的变化
+type StorageChange struct {
+	Slot common.Hash // 槽位
+	From common.Hash // 交易执行前的值
+	To   common.Hash // 交易执行后的值
+}
+
+// AccountDiff 交易对单个账户造成的状态变化
+// 字段没有变化时 Before 与 After 相同；通过 trace_replayTransaction 获取时，没有变化的字段为零值（节点不返回其当前值）
+type AccountDiff struct {
+	Address       common.Address  // 账户地址
+	Created       bool            // 交易执行前账户不存在（新部署的合约或第一次收到 ETH 的地址）
+	Destroyed     bool            // 账户在交易中被销毁（SELFDESTRUCT）
+	BalanceBefore *big.Int        // 执行前余额
+	BalanceAfter  *big.Int        // 执行后余额
+	NonceBefore   uint64          // 执行前 nonce
+	NonceAfter    uint64          // 执行后 nonce
+	CodeBefore    []byte          // 执行前代码
+	CodeAfter     []byte          // 执行后代码
+	Storage       []StorageChange // 变化的存储槽位（按槽位排序）
+}
+
+// BalanceChanged 判断余额是否变化
+func (d *AccountDiff) BalanceChanged() bool {
+	return d.BalanceBefore.Cmp(d.BalanceAfter) != 0
+}
+
+// BalanceDelta 返回余额变化量（After - Before，可能为负数）
+func (d *AccountDiff) BalanceDelta() *big.Int {
+	return new(big.Int).Sub(d.BalanceAfter, d.BalanceBefore)
+}
+
+// NonceChanged 判断 nonce 是否变化
+func (d *AccountDiff) NonceChanged() bool {
+	return d.NonceBefore != d.NonceAfter
+}
+
+// CodeChanged 判断代码是否变化
+func (d *AccountDiff) CodeChanged() bool {
+	return !bytes.Equal(d.CodeBefore, d.CodeAfter)
+}
+
+// StateDiff 交易造成的全部状态变化
+type StateDiff struct {
+	TxHash   common.Hash   // 交易哈希
+	Accounts []AccountDiff // 状态发生变化的账户（按地址排序）
+}
+
+// Account 返回指定地址的状态变化（该地址没有变化时返回 nil）
+func (s *StateDiff) Account(address common.Address) *AccountDiff {
+	for i := range s.Accounts {
+		if s.Accounts[i].Address == address {
+			return &s.Accounts[i]
+		}
+	}
+	return nil
+}
+
+// GetStateDiff 重放交易，返回其造成的余额、nonce、代码和存储变化，用于审计交易实际做了什么
+// 优先使用 debug_traceTransaction 的 prestateTracer（diffMode），节点不支持时使用 trace_replayTransaction 的 stateDiff（Erigon、Nethermind 等）
+// 注意：矿工/验证者收取的手续费同样体现为余额变化
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - txHash: 已上链的交易哈希
+//
+// 返回：
+//   - *StateDiff: 状态变化
+//   - error: 节点都不支持时返回 ErrStateDiffUnsupported，请求失败时返回对应错误
+//
+// 使用示例：
+//
+//	diff, err := GetStateDiff(ctx, provider, txHash)
+//	for _, acc := range diff.Accounts {
+//	    fmt.Println(acc.Address.Hex(), acc.BalanceDelta(), len(acc.Storage))
+//	}
+func GetStateDiff(ctx context.Context, ep EtherProvider, txHash common.Hash) (*StateDiff, error) {
+	return traceStateDiff(ctx, ep, txHash, prestateDiff, replayStateDiff)
+}
+
+// GetStateDiff 重放交易，返回其造成的余额、nonce、代码和存储变化
+// 已通过 DetectCapabilities 探测节点时直接选择节点支持的 tracer，不支持任何一种时返回 ErrStateDiffUnsupported
+func (k *Kit) GetStateDiff(ctx context.Context, txHash common.Hash) (*StateDiff, error) {
+	caps := k.Capabilities()
+	switch {
+	case caps == nil:
+		return GetStateDiff(ctx, k.EtherProvider, txHash)
+	case caps.Has(CapabilityDebug):
+		return traceStateDiff(ctx, k.EtherProvider, txHash, prestateDiff)
+	case caps.Has(CapabilityTrace):
+		return traceStateDiff(ctx, k.EtherProvider, txHash, replayStateDiff)
+	default:
+		return nil, ErrStateDiffUnsupported
+	}
+}
+
+// stateDiffTracer 获取交易状态变化的一种实现
+type stateDiffTracer func(ctx context.Context, ep EtherProvider, txHash common.Hash) ([]AccountDiff, error)
+
+// traceStateDiff 依次尝试各实现，节点不支持时尝试下一种
+func traceStateDiff(ctx context.Context, ep EtherProvider, txHash common.Hash, tracers ...stateDiffTracer) (*StateDiff, error) {
+	for _, tracer := range tracers {
+		accounts, err := tracer(ctx, ep, txHash)
+		if err != nil && isMethodNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to trace state diff of %s: %w", txHash.Hex(), err)
+		}
+		sort.Slice(accounts, func(i, j int) bool {
+			return bytes.Compare(accounts[i].Address[:], accounts[j].Address[:]) < 0
+		})
+		return &StateDiff{TxHash: txHash, Accounts: accounts}, nil
+	}
+	return nil, ErrStateDiffUnsupported
+}
+
+// prestateAccount prestateTracer 返回的账户状态（diffMode 的 post 中只包含变化的字段）
+type prestateAccount struct {
+	Balance *hexutil.Big                `json:"balance"`
+	Code    hexutil.Bytes               `json:"code"`
+	Nonce   *uint64                     `json:"nonce"`
+	Storage map[common.Hash]common.Hash `json:"storage"`
+}
+
+// prestateDiff 通过 prestateTracer 的 diffMode 获取状态变化
+// pre 中只有发生变化的账户和槽位；post 中省略没有变化的字段和被清零的槽位；被销毁的账户只出现在 pre 中，新账户只出现在 post 中
+func prestateDiff(ctx context.Context, ep EtherProvider, txHash common.Hash) ([]AccountDiff, error) {
+	var raw struct {
+		Pre  map[common.Address]*prestateAccount `json:"pre"`
+		Post map[common.Address]*prestateAccount `json:"post"`
+	}
+	config := map[string]interface{}{"tracer": "prestateTracer", "tracerConfig": map[string]interface{}{"diffMode": true}}
+	if err := ep.GetRpcClient().CallContext(ctx, &raw, "debug_traceTransaction", txHash, config); err != nil {
+		return nil, err
+	}
+
+	empty := &prestateAccount{}
+	addresses := make(map[common.Address]bool, len(raw.Pre)+len(raw.Post))
+	for addr := range raw.Pre {
+		addresses[addr] = true
+	}
+	for addr := range raw.Post {
+		addresses[addr] = true
+	}
+	accounts := make([]AccountDiff, 0, len(addresses))
+	for addr := range addresses {
+		pre, inPre := raw.Pre[addr]
+		post, inPost := raw.Post[addr]
+		if !inPre {
+			pre = empty
+		}
+		diff := AccountDiff{
+			Address:       addr,
+			Created:       !inPre,
+			Destroyed:     !inPost,
+			BalanceBefore: (*big.Int)(pre.Balance),
+			CodeBefore:    pre.Code,
+		}
+		if diff.BalanceBefore == nil {
+			diff.BalanceBefore = new(big.Int)
+		}
+		if pre.Nonce != nil {
+			diff.NonceBefore = *pre.Nonce
+		}
+		if diff.Destroyed {
+			diff.BalanceAfter = new(big.Int)
+			for slot, from := range pre.Storage {
+				diff.Storage = append(diff.Storage, StorageChange{Slot: slot, From: from})
+			}
+			accounts = append(accounts, sortStorage(diff))
+			continue
+		}
+
+		diff.BalanceAfter, diff.NonceAfter, diff.CodeAfter = diff.BalanceBefore, diff.NonceBefore, diff.CodeBefore
+		if post.Balance != nil {
+			diff.BalanceAfter = (*big.Int)(post.Balance)
+		}
+		if post.Nonce != nil {
+			diff.NonceAfter = *post.Nonce
+		}
+		if post.Code != nil {
+			diff.CodeAfter = post.Code
+		}
+		// pre 中的槽位都发生了变化，post 中没有的表示被清零；post 中独有的槽位原值为 0
+		for slot, from := range pre.Storage {
+			diff.Storage = append(diff.Storage, StorageChange{Slot: slot, From: from, To: post.Storage[slot]})
+		}
+		for slot, to := range post.Storage {
+			if _, ok := pre.Storage[slot]; !ok {
+				diff.Storage = append(diff.Storage, StorageChange{Slot: slot, To: to})
+			}
+		}
+		accounts = append(accounts, sortStorage(diff))
+	}
+	return accounts, nil
+}
+
+// sortStorage 按槽位排序存储变化
+func sortStorage(diff AccountDiff) AccountDiff {
+	sort.Slice(diff.Storage, func(i, j int) bool {
+		return bytes.Compare(diff.Storage[i].Slot[:], diff.Storage[j].Slot[:]) < 0
+	})
+	return diff
+}
+
+// parityChange trace_replayTransaction stateDiff 中单个字段的变化
+// 取值为 "="（不变）、{"+": 新值}（新建）、{"-": 原值}（删除）或 {"*": {"from": 原值, "to": 新值}}（修改）
+type parityChange struct {
+	unchanged bool
+	from, to  string
+}
+
+// UnmarshalJSON 解析字段变化
+func (c *parityChange) UnmarshalJSON(data []byte) error {
+	var marker string
+	if err := json.Unmarshal(data, &marker); err == nil {
+		if marker != "=" {
+			return fmt.Errorf("unexpected state diff marker %q", marker)
+		}
+		c.unchanged = true
+		return nil
+	}
+	var change struct {
+		Added   *string `json:"+"`
+		Removed *string `json:"-"`
+		Changed *struct {
+			From string `json:"from"`
+			To   string `json:"to"`
+		} `json:"*"`
+	}
+	if err := json.Unmarshal(data, &change); err != nil {
+		return err
+	}
+	switch {
+	case change.Added != nil:
+		c.to = *change.Added
+	case change.Removed != nil:
+		c.from = *change.Removed
+	case change.Changed != nil:
+		c.from, c.to = change.Changed.From, change.Changed.To
+	default:
+		return fmt.Errorf("unexpected state diff value %s", data)
+	}
+	return nil
+}
+
+// hexBig 解析十六进制整数，空字符串视为 0
+func hexBig(s string) (*big.Int, error) {
+	if s == "" {
+		return new(big.Int), nil
+	}
+	return hexutil.DecodeBig(s)
+}
+
+// replayStateDiff 通过 trace_replayTransaction 的 stateDiff 获取状态变化
+func replayStateDiff(ctx context.Context, ep EtherProvider, txHash common.Hash) ([]AccountDiff, error) {
+	var raw struct {
+		StateDiff map[common.Address]struct {
+			Balance parityChange                 `json:"balance"`
+			Nonce   parityChange                 `json:"nonce"`
+			Code    parityChange                 `json:"code"`
+			Storage map[common.Hash]parityChange `json:"storage"`
+		} `json:"stateDiff"`
+	}
+	if err := ep.GetRpcClient().CallContext(ctx, &raw, "trace_replayTransaction", txHash, []string{"stateDiff"}); err != nil {
+		return nil, err
+	}
+
+	accounts := make([]AccountDiff, 0, len(raw.StateDiff))
+	for addr, acc := range raw.StateDiff {
+		diff := AccountDiff{
+			Address:   addr,
+			Created:   !acc.Balance.unchanged && acc.Balance.from == "",
+			Destroyed: !acc.Balance.unchanged && acc.Balance.to == "",
+		}
+		// stateDiff 不返回没有变化的字段的值，这些字段保持为零值
+		diff.BalanceBefore, diff.BalanceAfter = new(big.Int), new(big.Int)
+		var err error
+		balance, nonce, code := acc.Balance, acc.Nonce, acc.Code
+		if !balance.unchanged {
+			if diff.BalanceBefore, err = hexBig(balance.from); err != nil {
+				return nil, fmt.Errorf("invalid balance of %s: %w", addr.Hex(), err)
+			}
+			if diff.BalanceAfter, err = hexBig(balance.to); err != nil {
+				return nil, fmt.Errorf("invalid balance of %s: %w", addr.Hex(), err)
+			}
+		}
+		if !nonce.unchanged {
+			from, err := hexBig(nonce.from)
+			if err != nil {
+				return nil, fmt.Errorf("invalid nonce of %s: %w", addr.Hex(), err)
+			}
+			to, err := hexBig(nonce.to)
+			if err != nil {
+				return nil, fmt.Errorf("invalid nonce of %s: %w", addr.Hex(), err)
+			}
+			diff.NonceBefore, diff.NonceAfter = from.Uint64(), to.Uint64()
+		}
+		if !code.unchanged {
+			if code.from != "" {
+				if diff.CodeBefore, err = hexutil.Decode(code.from); err != nil {
+					return nil, fmt.Errorf("invalid code of %s: %w", addr.Hex(), err)
+				}
+			}
+			if code.to != "" {
+				if diff.CodeAfter, err = hexutil.Decode(code.to); err != nil {
+					return nil, fmt.Errorf("invalid code of %s: %w", addr.Hex(), err)
+				}
+			}
+		}
+		for slot, change := range acc.Storage {
+			if change.unchanged {
+				continue
+			}
+			diff.Storage = append(diff.Storage, StorageChange{Slot: slot, From: common.HexToHash(change.from), To: common.HexToHash(change.to)})
+		}
+		accounts = append(accounts, sortStorage(diff))
+	}
+	return accounts, nil
+}
@@ -0,0 +1,115 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestGetStateDiff(t *testing.T) {
+	txHash := common.HexToHash("0xabc")
+	sender := common.HexToAddress("0x1000000000000000000000000000000000000001")
+	token := common.HexToAddress("0x2000000000000000000000000000000000000002")
+	created := common.HexToAddress("0x3000000000000000000000000000000000000003")
+	slot1 := common.HexToHash("0x01")
+	slot2 := common.HexToHash("0x02")
+
+	// geth prestateTracer（diffMode）：sender 支付 1 ETH 并增加 nonce，token 的槽位 1 被清零、槽位 2 被写入，created 为新部署的合约
+	prestate := json.RawMessage(`{
+		"pre": {
+			"0x1000000000000000000000000000000000000001": {"balance": "0xde0b6b3a7640000", "nonce": 5},
+			"0x2000000000000000000000000000000000000002": {"balance": "0x0", "code": "0x6001", "nonce": 1, "storage": {
+				"0x0000000000000000000000000000000000000000000000000000000000000001": "0x00000000000000000000000000000000000000000000000000000000000000ff"
+			}}
+		},
+		"post": {
+			"0x1000000000000000000000000000000000000001": {"balance": "0x0", "nonce": 6},
+			"0x2000000000000000000000000000000000000002": {"storage": {
+				"0x0000000000000000000000000000000000000000000000000000000000000002": "0x0000000000000000000000000000000000000000000000000000000000000007"
+			}},
+			"0x3000000000000000000000000000000000000003": {"balance": "0xde0b6b3a7640000", "code": "0x6002", "nonce": 1}
+		}
+	}`)
+	// 相同变化的 trace_replayTransaction 格式
+	replay := json.RawMessage(`{
+		"output": "0x",
+		"stateDiff": {
+			"0x1000000000000000000000000000000000000001": {
+				"balance": {"*": {"from": "0xde0b6b3a7640000", "to": "0x0"}},
+				"nonce": {"*": {"from": "0x5", "to": "0x6"}},
+				"code": "=", "storage": {}
+			},
+			"0x2000000000000000000000000000000000000002": {
+				"balance": "=", "nonce": "=", "code": "=",
+				"storage": {
+					"0x0000000000000000000000000000000000000000000000000000000000000001": {"*": {"from": "0x00000000000000000000000000000000000000000000000000000000000000ff", "to": "0x0000000000000000000000000000000000000000000000000000000000000000"}},
+					"0x0000000000000000000000000000000000000000000000000000000000000002": {"*": {"from": "0x0000000000000000000000000000000000000000000000000000000000000000", "to": "0x0000000000000000000000000000000000000000000000000000000000000007"}}
+				}
+			},
+			"0x3000000000000000000000000000000000000003": {
+				"balance": {"+": "0xde0b6b3a7640000"}, "nonce": {"+": "0x1"}, "code": {"+": "0x6002"}, "storage": {}
+			}
+		}
+	}`)
+
+	tests := []struct {
+		name     string
+		handlers map[string]mockRPCHandler
+		wantErr  error
+	}{
+		{name: "prestate tracer", handlers: map[string]mockRPCHandler{"debug_traceTransaction": mockResult(prestate)}},
+		{name: "trace replay fallback", handlers: map[string]mockRPCHandler{"trace_replayTransaction": mockResult(replay)}},
+		{name: "unsupported", handlers: map[string]mockRPCHandler{}, wantErr: ErrStateDiffUnsupported},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.handlers["eth_chainId"] = mockResult("0x1")
+			kit := newMockKit(t, newMockRPCServer(t, tt.handlers))
+			diff, err := kit.GetStateDiff(context.Background(), txHash)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("GetStateDiff() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetStateDiff() failed: %v", err)
+			}
+			if diff.TxHash != txHash || len(diff.Accounts) != 3 || diff.Accounts[0].Address != sender || diff.Accounts[2].Address != created {
+				t.Fatalf("GetStateDiff() accounts = %+v", diff.Accounts)
+			}
+
+			s := diff.Account(sender)
+			if !s.BalanceChanged() || s.BalanceDelta().Cmp(big.NewInt(-1e18)) != 0 || s.NonceBefore != 5 || s.NonceAfter != 6 || s.CodeChanged() || s.Created {
+				t.Errorf("sender diff = %+v", s)
+			}
+
+			tk := diff.Account(token)
+			if tk.BalanceChanged() || tk.NonceChanged() || tk.CodeChanged() || len(tk.Storage) != 2 {
+				t.Fatalf("token diff = %+v", tk)
+			}
+			wantStorage := []StorageChange{
+				{Slot: slot1, From: common.HexToHash("0xff"), To: common.Hash{}},
+				{Slot: slot2, From: common.Hash{}, To: common.HexToHash("0x07")},
+			}
+			for i, want := range wantStorage {
+				if tk.Storage[i] != want {
+					t.Errorf("storage change %d = %+v, expected %+v", i, tk.Storage[i], want)
+				}
+			}
+
+			c := diff.Account(created)
+			if !c.Created || c.Destroyed || c.BalanceAfter.Cmp(big.NewInt(1e18)) != 0 || c.NonceAfter != 1 || string(c.CodeAfter) != "\x60\x02" || len(c.CodeBefore) != 0 {
+				t.Errorf("created diff = %+v", c)
+			}
+
+			if diff.Account(common.HexToAddress("0x04")) != nil {
+				t.Error("Account() of unchanged address expected nil")
+			}
+		})
+	}
+}
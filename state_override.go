@@ -0,0 +1,93 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+//############ State Override ############
+
+// AccountOverride 单个账户的临时状态覆盖（仅在本次 eth_call / eth_estimateGas 中生效，不会上链）
+type AccountOverride struct {
+	Balance   *big.Int                    // 覆盖余额（nil 表示不覆盖）
+	Nonce     *uint64                     // 覆盖 nonce（nil 表示不覆盖）
+	Code      []byte                      // 覆盖合约代码（nil 表示不覆盖）
+	State     map[common.Hash]common.Hash // 替换整个存储（未列出的槽位视为 0，与 StateDiff 互斥）
+	StateDiff map[common.Hash]common.Hash // 只覆盖列出的存储槽位
+}
+
+// MarshalJSON 按节点要求的格式编码（数值使用十六进制）
+func (o AccountOverride) MarshalJSON() ([]byte, error) {
+	type override struct {
+		Balance   *hexutil.Big                `json:"balance,omitempty"`
+		Nonce     *hexutil.Uint64             `json:"nonce,omitempty"`
+		Code      hexutil.Bytes               `json:"code,omitempty"`
+		State     map[common.Hash]common.Hash `json:"state,omitempty"`
+		StateDiff map[common.Hash]common.Hash `json:"stateDiff,omitempty"`
+	}
+	return json.Marshal(override{
+		Balance:   (*hexutil.Big)(o.Balance),
+		Nonce:     (*hexutil.Uint64)(o.Nonce),
+		Code:      o.Code,
+		State:     o.State,
+		StateDiff: o.StateDiff,
+	})
+}
+
+// StateOverride 按地址覆盖的临时状态（eth_call 和 eth_estimateGas 的第三个参数）
+// 用于估算依赖前置步骤的交易，如尚未执行的 approve 或尚未到账的余额
+type StateOverride map[common.Address]AccountOverride
+
+// CallContractWithOverrides 在覆盖状态后执行静态调用（eth_call）
+// 参数说明：
+//   - ctx: 上下文对象
+//   - msg: 调用消息
+//   - block: 区块引用（区块号、区块标签或区块哈希）
+//   - overrides: 临时状态覆盖（nil 等同于 CallContractAt）
+//
+// 返回：
+//   - []byte: 调用返回的原始数据
+//   - error: 如果调用失败则返回错误
+func (p *Provider) CallContractWithOverrides(ctx context.Context, msg ethereum.CallMsg, block BlockRef, overrides StateOverride) ([]byte, error) {
+	if len(overrides) == 0 {
+		return p.CallContractAt(ctx, msg, block)
+	}
+	var result hexutil.Bytes
+	if err := p.rc.CallContext(ctx, &result, "eth_call", toCallArg(msg), block.rpcArg(), overrides); err != nil {
+		return nil, NormalizeError(err)
+	}
+	return result, nil
+}
+
+// EstimateGasWithOverrides 在覆盖状态后估算交易所需的 gas（eth_estimateGas）
+// 参数说明：
+//   - ctx: 上下文对象
+//   - msg: 调用消息（To 为 nil 表示合约部署）
+//   - block: 区块引用（区块号、区块标签或区块哈希）
+//   - overrides: 临时状态覆盖（节点需要支持 eth_estimateGas 的第三个参数，如 geth 1.13+、reth、erigon）
+//
+// 返回：
+//   - uint64: 估算的 Gas 数量
+//   - error: 如果估算失败则返回标准化后的错误（如合约执行失败返回 *RevertError）
+//
+// 使用示例：
+//
+//	// 在 approve 上链前估算 transferFrom：临时写入 allowance 存储槽
+//	gas, err := kit.EstimateGasWithOverrides(ctx, ethereum.CallMsg{From: spender, To: &token, Data: data}, BlockRef{},
+//	    StateOverride{token: {StateDiff: map[common.Hash]common.Hash{allowanceSlot: common.BigToHash(amount)}}})
+func (p *Provider) EstimateGasWithOverrides(ctx context.Context, msg ethereum.CallMsg, block BlockRef, overrides StateOverride) (uint64, error) {
+	params := []interface{}{toCallArg(msg), block.rpcArg()}
+	if len(overrides) > 0 {
+		params = append(params, overrides)
+	}
+	var gas hexutil.Uint64
+	if err := p.rc.CallContext(ctx, &gas, "eth_estimateGas", params...); err != nil {
+		return 0, NormalizeError(err)
+	}
+	return uint64(gas), nil
+}
@@ -0,0 +1,91 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestEstimateGasWithOverrides(t *testing.T) {
+	token := common.HexToAddress("0x1c7D4B196Cb0C7B01d743Fbc6116a902379C7238")
+	spender := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	nonce := uint64(7)
+	slot := common.HexToHash("0x01")
+
+	tests := []struct {
+		name       string
+		overrides  StateOverride
+		wantParams int
+		wantJSON   string
+	}{
+		{"no overrides", nil, 2, ""},
+		{
+			name:       "balance and storage",
+			overrides:  StateOverride{token: {StateDiff: map[common.Hash]common.Hash{slot: common.BigToHash(big.NewInt(1000))}}, spender: {Balance: big.NewInt(1e18), Nonce: &nonce}},
+			wantParams: 3,
+			wantJSON:   `{"0x1c7d4b196cb0c7b01d743fbc6116a902379c7238":{"stateDiff":{"0x0000000000000000000000000000000000000000000000000000000000000001":"0x00000000000000000000000000000000000000000000000000000000000003e8"}},"0x70997970c51812dc3a010c7d01b50e0d17dc79c8":{"balance":"0xde0b6b3a7640000","nonce":"0x7"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotParams []json.RawMessage
+			server := newMockRPCServer(t, map[string]mockRPCHandler{
+				"eth_estimateGas": func(params []json.RawMessage) (interface{}, error) {
+					gotParams = params
+					return "0xfde8", nil
+				},
+			})
+			provider, err := NewProvider(server.URL)
+			if err != nil {
+				t.Fatalf("NewProvider() failed: %v", err)
+			}
+			defer provider.Close()
+
+			gas, err := provider.EstimateGasWithOverrides(context.Background(), ethereum.CallMsg{From: spender, To: &token}, BlockRef{}, tt.overrides)
+			if err != nil {
+				t.Fatalf("EstimateGasWithOverrides() failed: %v", err)
+			}
+			if gas != 65000 {
+				t.Errorf("EstimateGasWithOverrides() = %d, expected 65000", gas)
+			}
+			if len(gotParams) != tt.wantParams {
+				t.Fatalf("eth_estimateGas received %d params, expected %d", len(gotParams), tt.wantParams)
+			}
+			if tt.wantJSON != "" && string(gotParams[2]) != tt.wantJSON {
+				t.Errorf("overrides = %s, expected %s", gotParams[2], tt.wantJSON)
+			}
+		})
+	}
+}
+
+func TestCallContractWithOverrides(t *testing.T) {
+	contract := common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+	var gotParams []json.RawMessage
+	server := newMockRPCServer(t, map[string]mockRPCHandler{
+		"eth_call": func(params []json.RawMessage) (interface{}, error) {
+			gotParams = params
+			return "0x2a", nil
+		},
+	})
+	provider, err := NewProvider(server.URL)
+	if err != nil {
+		t.Fatalf("NewProvider() failed: %v", err)
+	}
+	defer provider.Close()
+
+	res, err := provider.CallContractWithOverrides(context.Background(), ethereum.CallMsg{To: &contract}, BlockRef{}, StateOverride{contract: {Code: []byte{0x60, 0x2a}}})
+	if err != nil {
+		t.Fatalf("CallContractWithOverrides() failed: %v", err)
+	}
+	if len(res) != 1 || res[0] != 0x2a {
+		t.Errorf("CallContractWithOverrides() = %x, expected 2a", res)
+	}
+	if len(gotParams) != 3 || string(gotParams[2]) != `{"0x5fbdb2315678afecb367f032d93f642f64180aa3":{"code":"0x602a"}}` {
+		t.Errorf("eth_call params = %s", gotParams)
+	}
+}
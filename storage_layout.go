@@ -0,0 +1,501 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+//############ Storage Layout ############
+
+// Solidity 存储布局中的编码方式
+const (
+	StorageEncodingInplace      = "inplace"       // 值类型、结构体、定长数组
+	StorageEncodingMapping      = "mapping"       // mapping
+	StorageEncodingDynamicArray = "dynamic_array" // 动态数组
+	StorageEncodingBytes        = "bytes"         // string / bytes
+)
+
+// StorageVariable 存储布局中的状态变量（或结构体成员）
+type StorageVariable struct {
+	Label  string `json:"label"`  // 变量名
+	Offset int    `json:"offset"` // 在存储槽中的字节偏移（从低位开始）
+	Slot   string `json:"slot"`   // 存储槽（十进制字符串，结构体成员为相对槽位）
+	Type   string `json:"type"`   // 类型 ID（对应 StorageLayout.Types 的键）
+}
+
+// StorageType 存储布局中的类型定义
+type StorageType struct {
+	Encoding      string            `json:"encoding"`          // 编码方式
+	Label         string            `json:"label"`             // 类型名（如 uint256、mapping(address => uint256)）
+	NumberOfBytes string            `json:"numberOfBytes"`     // 占用字节数（十进制字符串）
+	Key           string            `json:"key,omitempty"`     // mapping 的键类型 ID
+	Value         string            `json:"value,omitempty"`   // mapping 的值类型 ID
+	Base          string            `json:"base,omitempty"`    // 数组的元素类型 ID
+	Members       []StorageVariable `json:"members,omitempty"` // 结构体成员
+}
+
+// size 返回类型占用的字节数
+func (t *StorageType) size() int {
+	n, _ := strconv.Atoi(t.NumberOfBytes)
+	return n
+}
+
+// StorageLayout solc 输出的合约存储布局（solc --storage-layout 或 outputSelection 中的 storageLayout）
+type StorageLayout struct {
+	Storage []StorageVariable       `json:"storage"`
+	Types   map[string]*StorageType `json:"types"`
+}
+
+// ParseStorageLayout 解析 solc 存储布局 JSON
+// 既可以直接传入 storageLayout 对象，也可以传入包含 storageLayout 字段的合约编译输出（如 Foundry / Hardhat artifact）
+// 参数说明：
+//   - data: 存储布局 JSON
+//
+// 返回：
+//   - *StorageLayout: 存储布局
+//   - error: 如果 JSON 无效或缺少存储布局则返回错误
+func ParseStorageLayout(data []byte) (*StorageLayout, error) {
+	var wrapper struct {
+		Nested  *StorageLayout          `json:"storageLayout"`
+		Storage []StorageVariable       `json:"storage"`
+		Types   map[string]*StorageType `json:"types"`
+	}
+	if err := json.Unmarshal(data, &wrapper); err != nil {
+		return nil, fmt.Errorf("invalid storage layout: %w", err)
+	}
+	layout := &StorageLayout{Storage: wrapper.Storage, Types: wrapper.Types}
+	if wrapper.Nested != nil {
+		layout = wrapper.Nested
+	}
+	if len(layout.Storage) == 0 || layout.Types == nil {
+		return nil, fmt.Errorf("invalid storage layout: no storage variables")
+	}
+	return layout, nil
+}
+
+// Variable 按名称查找状态变量
+func (l *StorageLayout) Variable(label string) (StorageVariable, bool) {
+	for _, v := range l.Storage {
+		if v.Label == label {
+			return v, true
+		}
+	}
+	return StorageVariable{}, false
+}
+
+// StorageLocation 变量在合约存储中的位置
+type StorageLocation struct {
+	Slot   common.Hash  // 存储槽
+	Offset int          // 槽内字节偏移（从低位开始）
+	Type   *StorageType // 变量类型
+	typeID string
+}
+
+// Locate 计算变量（或其 mapping 值、数组元素、结构体成员）所在的存储槽
+// path 依次对应每一层访问：mapping 的键、数组下标或结构体成员名
+// 参数说明：
+//   - variable: 状态变量名
+//   - path: 访问路径（如 balances 的 owner 地址；users 的地址和成员名 "balance"）
+//
+// 返回：
+//   - StorageLocation: 存储位置（可配合 DevChain.SetStorageAt 修改状态）
+//   - error: 如果变量不存在或访问路径与类型不匹配则返回错误
+func (l *StorageLayout) Locate(variable string, path ...interface{}) (StorageLocation, error) {
+	v, ok := l.Variable(variable)
+	if !ok {
+		return StorageLocation{}, fmt.Errorf("storage variable %q not found", variable)
+	}
+	slot, ok := new(big.Int).SetString(v.Slot, 10)
+	if !ok {
+		return StorageLocation{}, fmt.Errorf("invalid slot %q for %s", v.Slot, variable)
+	}
+	loc, err := l.location(slot, v.Offset, v.Type)
+	if err != nil {
+		return StorageLocation{}, err
+	}
+	for i, step := range path {
+		if loc, err = l.step(loc, step); err != nil {
+			return StorageLocation{}, fmt.Errorf("%s path[%d]: %w", variable, i, err)
+		}
+	}
+	return loc, nil
+}
+
+// location 构建存储位置
+func (l *StorageLayout) location(slot *big.Int, offset int, typeID string) (StorageLocation, error) {
+	typ, ok := l.Types[typeID]
+	if !ok {
+		return StorageLocation{}, fmt.Errorf("unknown storage type %q", typeID)
+	}
+	return StorageLocation{Slot: common.BigToHash(slot), Offset: offset, Type: typ, typeID: typeID}, nil
+}
+
+// step 沿访问路径前进一层
+func (l *StorageLayout) step(loc StorageLocation, step interface{}) (StorageLocation, error) {
+	t := loc.Type
+	switch {
+	case t.Encoding == StorageEncodingMapping:
+		keyType, ok := l.Types[t.Key]
+		if !ok {
+			return StorageLocation{}, fmt.Errorf("unknown storage type %q", t.Key)
+		}
+		key, err := encodeStorageKey(keyType, step)
+		if err != nil {
+			return StorageLocation{}, err
+		}
+		slot := crypto.Keccak256Hash(key, loc.Slot.Bytes()).Big()
+		return l.location(slot, 0, t.Value)
+
+	case t.Encoding == StorageEncodingDynamicArray:
+		index, err := storageIndex(step)
+		if err != nil {
+			return StorageLocation{}, err
+		}
+		return l.element(crypto.Keccak256Hash(loc.Slot.Bytes()).Big(), index, t.Base)
+
+	case t.Encoding == StorageEncodingInplace && len(t.Members) > 0:
+		name, ok := step.(string)
+		if !ok {
+			return StorageLocation{}, fmt.Errorf("struct %s requires a member name, got %T", t.Label, step)
+		}
+		for _, m := range t.Members {
+			if m.Label == name {
+				rel, ok := new(big.Int).SetString(m.Slot, 10)
+				if !ok {
+					return StorageLocation{}, fmt.Errorf("invalid slot %q for member %s", m.Slot, name)
+				}
+				return l.location(rel.Add(rel, loc.Slot.Big()), m.Offset, m.Type)
+			}
+		}
+		return StorageLocation{}, fmt.Errorf("struct %s has no member %q", t.Label, name)
+
+	case t.Encoding == StorageEncodingInplace && t.Base != "":
+		index, err := storageIndex(step)
+		if err != nil {
+			return StorageLocation{}, err
+		}
+		if length := staticArrayLength(t.Label); index.Cmp(big.NewInt(int64(length))) >= 0 {
+			return StorageLocation{}, fmt.Errorf("index %s out of range for %s", index, t.Label)
+		}
+		return l.element(loc.Slot.Big(), index, t.Base)
+	}
+	return StorageLocation{}, fmt.Errorf("cannot index into %s", t.Label)
+}
+
+// element 计算数组元素的位置（不超过 16 字节的元素会被打包到同一个槽中）
+func (l *StorageLayout) element(base, index *big.Int, elemTypeID string) (StorageLocation, error) {
+	elem, ok := l.Types[elemTypeID]
+	if !ok {
+		return StorageLocation{}, fmt.Errorf("unknown storage type %q", elemTypeID)
+	}
+	size := elem.size()
+	if size <= 0 {
+		return StorageLocation{}, fmt.Errorf("invalid size for %s", elem.Label)
+	}
+	if size <= 16 {
+		perSlot := big.NewInt(int64(32 / size))
+		slot, offset := new(big.Int).DivMod(index, perSlot, new(big.Int))
+		return l.location(slot.Add(slot, base), int(offset.Int64())*size, elemTypeID)
+	}
+	slots := big.NewInt(int64((size + 31) / 32))
+	slot := new(big.Int).Mul(index, slots)
+	return l.location(slot.Add(slot, base), 0, elemTypeID)
+}
+
+// staticArrayLength 从类型名（如 uint256[3]）解析定长数组长度
+func staticArrayLength(label string) int {
+	open := strings.LastIndex(label, "[")
+	if open < 0 || !strings.HasSuffix(label, "]") {
+		return 0
+	}
+	n, _ := strconv.Atoi(label[open+1 : len(label)-1])
+	return n
+}
+
+// storageIndex 将数组下标转换为 *big.Int
+func storageIndex(v interface{}) (*big.Int, error) {
+	n, err := storageInteger(v)
+	if err != nil {
+		return nil, err
+	}
+	if n.Sign() < 0 {
+		return nil, fmt.Errorf("negative array index %s", n)
+	}
+	return n, nil
+}
+
+// storageInteger 将常见整数类型转换为 *big.Int
+func storageInteger(v interface{}) (*big.Int, error) {
+	switch n := v.(type) {
+	case int:
+		return big.NewInt(int64(n)), nil
+	case int64:
+		return big.NewInt(n), nil
+	case uint64:
+		return new(big.Int).SetUint64(n), nil
+	case uint8:
+		return big.NewInt(int64(n)), nil
+	case *big.Int:
+		return new(big.Int).Set(n), nil
+	}
+	return nil, fmt.Errorf("expected integer, got %T", v)
+}
+
+// encodeStorageKey 按 Solidity 规则编码 mapping 的键（值类型填充到 32 字节，string/bytes 使用原始字节）
+func encodeStorageKey(keyType *StorageType, key interface{}) ([]byte, error) {
+	if keyType.Encoding == StorageEncodingBytes {
+		switch k := key.(type) {
+		case string:
+			return []byte(k), nil
+		case []byte:
+			return k, nil
+		}
+		return nil, fmt.Errorf("mapping key %s requires string or []byte, got %T", keyType.Label, key)
+	}
+
+	label := keyType.Label
+	switch {
+	case label == "address" || label == "address payable" || strings.HasPrefix(label, "contract "):
+		switch k := key.(type) {
+		case common.Address:
+			return common.LeftPadBytes(k.Bytes(), 32), nil
+		case string:
+			if common.IsHexAddress(k) {
+				return common.LeftPadBytes(common.HexToAddress(k).Bytes(), 32), nil
+			}
+		}
+		return nil, fmt.Errorf("invalid address key %v", key)
+	case label == "bool":
+		b, ok := key.(bool)
+		if !ok {
+			return nil, fmt.Errorf("mapping key bool requires bool, got %T", key)
+		}
+		if b {
+			return common.LeftPadBytes([]byte{1}, 32), nil
+		}
+		return make([]byte, 32), nil
+	case strings.HasPrefix(label, "bytes"):
+		var b []byte
+		switch k := key.(type) {
+		case []byte:
+			b = k
+		case common.Hash:
+			b = k.Bytes()
+		default:
+			return nil, fmt.Errorf("mapping key %s requires []byte, got %T", label, key)
+		}
+		if len(b) > 32 {
+			return nil, fmt.Errorf("mapping key %s too long", label)
+		}
+		return common.RightPadBytes(b, 32), nil
+	default:
+		// uintN / intN / enum，负数使用补码
+		n, err := storageInteger(key)
+		if err != nil {
+			return nil, err
+		}
+		return math.U256Bytes(n), nil
+	}
+}
+
+// StorageReader 基于存储布局读取合约状态变量（无需 view 方法，适用于调试和数据分析）
+type StorageReader struct {
+	Address common.Address // 合约地址（代理合约应使用代理地址 + 实现合约的存储布局）
+	Layout  *StorageLayout // 存储布局
+	Block   BlockRef       // 查询的区块（零值表示 latest）
+	ep      EtherProvider
+}
+
+// NewStorageReader 创建存储读取器
+// 参数说明：
+//   - ep: 以太坊提供者
+//   - address: 合约地址
+//   - layout: 合约的存储布局（通过 ParseStorageLayout 获取）
+func NewStorageReader(ep EtherProvider, address common.Address, layout *StorageLayout) *StorageReader {
+	return &StorageReader{Address: address, Layout: layout, ep: ep}
+}
+
+// AtBlock 返回在指定区块读取的副本
+func (r *StorageReader) AtBlock(block BlockRef) *StorageReader {
+	cp := *r
+	cp.Block = block
+	return &cp
+}
+
+// Read 读取并解码状态变量
+// 解码结果的类型：address / contract → common.Address；bool → bool；uintN / intN / enum → *big.Int；
+// bytesN / bytes → []byte；string → string；结构体 → map[string]interface{}；数组 → []interface{}
+// 结构体中的 mapping 成员会被跳过；读取整个动态数组会逐个查询元素，长数组应按下标读取
+// 参数说明：
+//   - ctx: 上下文对象
+//   - variable: 状态变量名
+//   - path: 访问路径（mapping 键、数组下标或结构体成员名）
+//
+// 返回：
+//   - interface{}: 解码后的值
+//   - error: 如果变量不存在、路径无效或查询失败则返回错误
+//
+// 使用示例：
+//
+//	layout, _ := ParseStorageLayout(artifactJSON)
+//	reader := NewStorageReader(provider, tokenAddr, layout)
+//	balance, err := reader.Read(ctx, "_balances", holder)
+//	owner, err := reader.Read(ctx, "config", "owner")
+func (r *StorageReader) Read(ctx context.Context, variable string, path ...interface{}) (interface{}, error) {
+	loc, err := r.Layout.Locate(variable, path...)
+	if err != nil {
+		return nil, err
+	}
+	words := map[common.Hash]common.Hash{}
+	return r.decode(ctx, loc, words)
+}
+
+// word 读取存储槽（同一次 Read 中缓存已读取的槽）
+func (r *StorageReader) word(ctx context.Context, slot common.Hash, words map[common.Hash]common.Hash) (common.Hash, error) {
+	if w, ok := words[slot]; ok {
+		return w, nil
+	}
+	var result hexutil.Bytes
+	if err := r.ep.GetRpcClient().CallContext(ctx, &result, "eth_getStorageAt", r.Address, slot, r.Block.rpcArg()); err != nil {
+		return common.Hash{}, err
+	}
+	w := common.BytesToHash(result)
+	words[slot] = w
+	return w, nil
+}
+
+// decode 按类型解码存储位置上的值
+func (r *StorageReader) decode(ctx context.Context, loc StorageLocation, words map[common.Hash]common.Hash) (interface{}, error) {
+	t := loc.Type
+	switch t.Encoding {
+	case StorageEncodingMapping:
+		return nil, fmt.Errorf("%s requires a key", t.Label)
+
+	case StorageEncodingBytes:
+		data, err := r.readBytes(ctx, loc.Slot, words)
+		if err != nil {
+			return nil, err
+		}
+		if t.Label == "string" {
+			return string(data), nil
+		}
+		return data, nil
+
+	case StorageEncodingDynamicArray:
+		w, err := r.word(ctx, loc.Slot, words)
+		if err != nil {
+			return nil, err
+		}
+		base := crypto.Keccak256Hash(loc.Slot.Bytes()).Big()
+		return r.decodeArray(ctx, base, w.Big(), t.Base, words)
+
+	case StorageEncodingInplace:
+		if len(t.Members) > 0 {
+			out := make(map[string]interface{}, len(t.Members))
+			for _, m := range t.Members {
+				member, err := r.Layout.step(loc, m.Label)
+				if err != nil {
+					return nil, err
+				}
+				if member.Type.Encoding == StorageEncodingMapping {
+					continue
+				}
+				if out[m.Label], err = r.decode(ctx, member, words); err != nil {
+					return nil, err
+				}
+			}
+			return out, nil
+		}
+		if t.Base != "" {
+			length := big.NewInt(int64(staticArrayLength(t.Label)))
+			return r.decodeArray(ctx, loc.Slot.Big(), length, t.Base, words)
+		}
+		w, err := r.word(ctx, loc.Slot, words)
+		if err != nil {
+			return nil, err
+		}
+		return decodeStorageValue(t, w, loc.Offset)
+	}
+	return nil, fmt.Errorf("unsupported storage encoding %q", t.Encoding)
+}
+
+// decodeArray 逐个解码数组元素
+func (r *StorageReader) decodeArray(ctx context.Context, base, length *big.Int, elemTypeID string, words map[common.Hash]common.Hash) ([]interface{}, error) {
+	if !length.IsInt64() {
+		return nil, fmt.Errorf("array length %s too large", length)
+	}
+	out := make([]interface{}, 0, length.Int64())
+	for i := int64(0); i < length.Int64(); i++ {
+		loc, err := r.Layout.element(base, big.NewInt(i), elemTypeID)
+		if err != nil {
+			return nil, err
+		}
+		v, err := r.decode(ctx, loc, words)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+// readBytes 读取 string / bytes：短值（<32 字节）与长度一起存放在槽中，长值存放在 keccak256(slot) 起的连续槽中
+func (r *StorageReader) readBytes(ctx context.Context, slot common.Hash, words map[common.Hash]common.Hash) ([]byte, error) {
+	w, err := r.word(ctx, slot, words)
+	if err != nil {
+		return nil, err
+	}
+	if w[31]&1 == 0 {
+		length := int(w[31] / 2)
+		return common.CopyBytes(w[:length]), nil
+	}
+	length := new(big.Int).Rsh(w.Big(), 1)
+	if !length.IsInt64() || length.Int64() > 1<<20 {
+		return nil, fmt.Errorf("bytes length %s too large", length)
+	}
+	n := int(length.Int64())
+	data := make([]byte, 0, n)
+	base := crypto.Keccak256Hash(slot.Bytes()).Big()
+	for i := 0; len(data) < n; i++ {
+		chunk, err := r.word(ctx, common.BigToHash(new(big.Int).Add(base, big.NewInt(int64(i)))), words)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, chunk[:min(32, n-len(data))]...)
+	}
+	return data, nil
+}
+
+// decodeStorageValue 从存储槽中取出值类型（值按低位对齐，offset 从低位开始计算）
+func decodeStorageValue(t *StorageType, w common.Hash, offset int) (interface{}, error) {
+	size := t.size()
+	if size <= 0 || offset < 0 || offset+size > 32 {
+		return nil, fmt.Errorf("invalid size %d / offset %d for %s", size, offset, t.Label)
+	}
+	raw := w[32-offset-size : 32-offset]
+	label := t.Label
+	switch {
+	case label == "address" || label == "address payable" || strings.HasPrefix(label, "contract "):
+		return common.BytesToAddress(raw), nil
+	case label == "bool":
+		return raw[len(raw)-1] != 0, nil
+	case strings.HasPrefix(label, "uint") || strings.HasPrefix(label, "enum "):
+		return new(big.Int).SetBytes(raw), nil
+	case strings.HasPrefix(label, "int"):
+		v := new(big.Int).SetBytes(raw)
+		if raw[0]&0x80 != 0 {
+			v.Sub(v, new(big.Int).Lsh(big.NewInt(1), uint(size*8)))
+		}
+		return v, nil
+	}
+	return common.CopyBytes(raw), nil
+}
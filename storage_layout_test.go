@@ -0,0 +1,177 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// testStorageLayout 对应以下合约的 solc 存储布局：
+//
+//	address owner; bool paused; uint8 decimals;           // slot 0
+//	mapping(address => uint256) balances;                 // slot 1
+//	string name;                                          // slot 2
+//	uint64[] times;                                       // slot 3
+//	struct Info { uint128 a; int128 b; mapping(uint256 => uint256) m; }
+//	Info info;                                            // slot 4-5
+//	mapping(address => Info) infos;                       // slot 6
+const testStorageLayout = `{"storageLayout": {
+	"storage": [
+		{"label": "owner", "offset": 0, "slot": "0", "type": "t_address"},
+		{"label": "paused", "offset": 20, "slot": "0", "type": "t_bool"},
+		{"label": "decimals", "offset": 21, "slot": "0", "type": "t_uint8"},
+		{"label": "balances", "offset": 0, "slot": "1", "type": "t_mapping(t_address,t_uint256)"},
+		{"label": "name", "offset": 0, "slot": "2", "type": "t_string_storage"},
+		{"label": "times", "offset": 0, "slot": "3", "type": "t_array(t_uint64)dyn_storage"},
+		{"label": "info", "offset": 0, "slot": "4", "type": "t_struct(Info)1_storage"},
+		{"label": "infos", "offset": 0, "slot": "6", "type": "t_mapping(t_address,t_struct(Info)1_storage)"}
+	],
+	"types": {
+		"t_address": {"encoding": "inplace", "label": "address", "numberOfBytes": "20"},
+		"t_bool": {"encoding": "inplace", "label": "bool", "numberOfBytes": "1"},
+		"t_uint8": {"encoding": "inplace", "label": "uint8", "numberOfBytes": "1"},
+		"t_uint64": {"encoding": "inplace", "label": "uint64", "numberOfBytes": "8"},
+		"t_uint128": {"encoding": "inplace", "label": "uint128", "numberOfBytes": "16"},
+		"t_int128": {"encoding": "inplace", "label": "int128", "numberOfBytes": "16"},
+		"t_uint256": {"encoding": "inplace", "label": "uint256", "numberOfBytes": "32"},
+		"t_string_storage": {"encoding": "bytes", "label": "string", "numberOfBytes": "32"},
+		"t_array(t_uint64)dyn_storage": {"encoding": "dynamic_array", "label": "uint64[]", "numberOfBytes": "32", "base": "t_uint64"},
+		"t_mapping(t_address,t_uint256)": {"encoding": "mapping", "label": "mapping(address => uint256)", "numberOfBytes": "32", "key": "t_address", "value": "t_uint256"},
+		"t_mapping(t_uint256,t_uint256)": {"encoding": "mapping", "label": "mapping(uint256 => uint256)", "numberOfBytes": "32", "key": "t_uint256", "value": "t_uint256"},
+		"t_mapping(t_address,t_struct(Info)1_storage)": {"encoding": "mapping", "label": "mapping(address => struct C.Info)", "numberOfBytes": "32", "key": "t_address", "value": "t_struct(Info)1_storage"},
+		"t_struct(Info)1_storage": {"encoding": "inplace", "label": "struct C.Info", "numberOfBytes": "64", "members": [
+			{"label": "a", "offset": 0, "slot": "0", "type": "t_uint128"},
+			{"label": "b", "offset": 16, "slot": "0", "type": "t_int128"},
+			{"label": "m", "offset": 0, "slot": "1", "type": "t_mapping(t_uint256,t_uint256)"}
+		]}
+	}
+}}`
+
+func TestStorageReader(t *testing.T) {
+	layout, err := ParseStorageLayout([]byte(testStorageLayout))
+	if err != nil {
+		t.Fatalf("ParseStorageLayout() failed: %v", err)
+	}
+
+	owner := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	holder := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	longName := strings.Repeat("long token name ", 3)
+
+	// 按 Solidity 存储规则构造各槽位的值
+	storage := map[common.Hash]common.Hash{}
+	slot0 := common.Hash{}
+	copy(slot0[12:], owner.Bytes())
+	slot0[11] = 1  // paused
+	slot0[10] = 18 // decimals
+	storage[common.BigToHash(big.NewInt(0))] = slot0
+	storage[crypto.Keccak256Hash(common.LeftPadBytes(holder.Bytes(), 32), common.LeftPadBytes([]byte{1}, 32))] = common.BigToHash(big.NewInt(5000))
+	storage[common.BigToHash(big.NewInt(2))] = common.BigToHash(big.NewInt(int64(len(longName)*2 + 1)))
+	nameBase := crypto.Keccak256Hash(common.LeftPadBytes([]byte{2}, 32)).Big()
+	for i := 0; i*32 < len(longName); i++ {
+		var chunk common.Hash
+		copy(chunk[:], longName[i*32:])
+		storage[common.BigToHash(new(big.Int).Add(nameBase, big.NewInt(int64(i))))] = chunk
+	}
+	storage[common.BigToHash(big.NewInt(3))] = common.BigToHash(big.NewInt(5)) // times.length
+	timesBase := crypto.Keccak256Hash(common.LeftPadBytes([]byte{3}, 32)).Big()
+	var times0, times1 common.Hash
+	for i := 0; i < 4; i++ {
+		times0[31-i*8] = byte(i + 1)
+	}
+	times1[31] = 5
+	storage[common.BigToHash(timesBase)] = times0
+	storage[common.BigToHash(new(big.Int).Add(timesBase, big.NewInt(1)))] = times1
+	var info common.Hash
+	info[31] = 7 // a = 7
+	for i := 0; i < 16; i++ {
+		info[i] = 0xff // b = -1
+	}
+	storage[common.BigToHash(big.NewInt(4))] = info
+
+	server := newMockRPCServer(t, map[string]mockRPCHandler{
+		"eth_getStorageAt": func(params []json.RawMessage) (interface{}, error) {
+			var slot common.Hash
+			if err := json.Unmarshal(params[1], &slot); err != nil {
+				return nil, err
+			}
+			return storage[slot], nil
+		},
+	})
+	provider, err := NewProvider(server.URL)
+	if err != nil {
+		t.Fatalf("NewProvider() failed: %v", err)
+	}
+	defer provider.Close()
+	reader := NewStorageReader(provider, common.HexToAddress("0x1234"), layout)
+	ctx := context.Background()
+
+	read := func(variable string, path ...interface{}) interface{} {
+		t.Helper()
+		v, err := reader.Read(ctx, variable, path...)
+		if err != nil {
+			t.Fatalf("Read(%s, %v) failed: %v", variable, path, err)
+		}
+		return v
+	}
+
+	if v := read("owner"); v != owner {
+		t.Errorf("owner = %v", v)
+	}
+	if v := read("paused"); v != true {
+		t.Errorf("paused = %v", v)
+	}
+	if v := read("decimals").(*big.Int); v.Int64() != 18 {
+		t.Errorf("decimals = %v", v)
+	}
+	if v := read("balances", holder).(*big.Int); v.Int64() != 5000 {
+		t.Errorf("balances[holder] = %v", v)
+	}
+	if v := read("name"); v != longName {
+		t.Errorf("name = %q", v)
+	}
+	times := read("times").([]interface{})
+	if len(times) != 5 || times[0].(*big.Int).Int64() != 1 || times[3].(*big.Int).Int64() != 4 || times[4].(*big.Int).Int64() != 5 {
+		t.Errorf("times = %v", times)
+	}
+	if v := read("times", 4).(*big.Int); v.Int64() != 5 {
+		t.Errorf("times[4] = %v", v)
+	}
+	infoValue := read("info").(map[string]interface{})
+	if infoValue["a"].(*big.Int).Int64() != 7 || infoValue["b"].(*big.Int).Int64() != -1 {
+		t.Errorf("info = %v", infoValue)
+	}
+	if _, ok := infoValue["m"]; ok {
+		t.Error("mapping member should be skipped")
+	}
+	if v := read("infos", holder, "a").(*big.Int); v.Sign() != 0 {
+		t.Errorf("infos[holder].a = %v", v)
+	}
+
+	// 路径错误
+	if _, err := reader.Read(ctx, "balances"); err == nil {
+		t.Error("Expected error when reading mapping without key")
+	}
+	if _, err := reader.Read(ctx, "info", "missing"); err == nil {
+		t.Error("Expected error for unknown struct member")
+	}
+	if _, err := reader.Read(ctx, "unknown"); err == nil {
+		t.Error("Expected error for unknown variable")
+	}
+
+	// mapping 中结构体成员的槽位
+	loc, err := layout.Locate("infos", holder, "m", 1)
+	if err != nil {
+		t.Fatalf("Locate() failed: %v", err)
+	}
+	structSlot := crypto.Keccak256Hash(common.LeftPadBytes(holder.Bytes(), 32), common.LeftPadBytes([]byte{6}, 32)).Big()
+	mSlot := common.BigToHash(new(big.Int).Add(structSlot, big.NewInt(1)))
+	expected := crypto.Keccak256Hash(common.LeftPadBytes([]byte{1}, 32), mSlot.Bytes())
+	if loc.Slot != expected {
+		t.Errorf("Locate() = %s, expected %s", loc.Slot.Hex(), expected.Hex())
+	}
+}
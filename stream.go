@@ -0,0 +1,229 @@
+package etherkit
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+//############ Streams ############
+
+// DefaultStreamBuffer 流式查询默认的缓冲区大小
+const DefaultStreamBuffer = 64
+
+// Stream 带有限缓冲区的流式查询结果
+// 生产者在缓冲区满时阻塞，由消费者的读取速度控制查询进度和内存占用
+//
+// 使用示例：
+//
+//	s := NewBlockStream(ctx, provider, 17000000, 17100000, 8, 32)
+//	for block := range s.C {
+//	    process(block)
+//	}
+//	if err := s.Err(); err != nil {
+//	    return err
+//	}
+type Stream[T any] struct {
+	C       <-chan T // 按顺序交付的结果，查询结束、失败或停止后关闭
+	cancel  context.CancelFunc
+	mu      sync.Mutex
+	err     error
+	stopped bool
+}
+
+// newStream 在独立的 goroutine 中运行 run，run 通过 emit 交付结果（缓冲区满时阻塞）
+func newStream[T any](ctx context.Context, buffer int, run func(ctx context.Context, emit func(T) error) error) *Stream[T] {
+	if buffer <= 0 {
+		buffer = DefaultStreamBuffer
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	ch := make(chan T, buffer)
+	s := &Stream[T]{C: ch, cancel: cancel}
+
+	go func() {
+		defer close(ch)
+		defer cancel()
+		err := run(ctx, func(value T) error {
+			select {
+			case ch <- value:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		s.mu.Lock()
+		if !s.stopped {
+			s.err = err
+		}
+		s.mu.Unlock()
+	}()
+	return s
+}
+
+// Err 返回导致流提前结束的错误（应在 C 关闭后调用；正常结束或调用 Stop 停止时为 nil）
+func (s *Stream[T]) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Stop 停止查询并丢弃缓冲区中剩余的结果（消费者提前退出时调用，避免生产者 goroutine 泄漏）
+func (s *Stream[T]) Stop() {
+	s.mu.Lock()
+	s.stopped = true
+	s.err = nil
+	s.mu.Unlock()
+	s.cancel()
+	for range s.C {
+	}
+}
+
+// NewBlockStream 并发查询 [from, to] 范围内的区块，按区块号顺序写入流
+// 参数说明：
+//   - ctx: 上下文对象（取消后流关闭，Err 返回取消原因）
+//   - ep: 以太坊提供者
+//   - from: 起始区块号（包含）
+//   - to: 结束区块号（包含）
+//   - concurrency: 并发数（<= 0 使用 DefaultBlockFetchConcurrency）
+//   - buffer: 缓冲区大小（<= 0 使用 DefaultStreamBuffer）
+//
+// 返回：
+//   - *Stream[*types.Block]: 区块流
+func NewBlockStream(ctx context.Context, ep EtherProvider, from, to uint64, concurrency, buffer int) *Stream[*types.Block] {
+	return newStream(ctx, buffer, func(ctx context.Context, emit func(*types.Block) error) error {
+		return StreamBlocks(ctx, ep, from, to, concurrency, emit)
+	})
+}
+
+// NewHeaderStream 并发查询 [from, to] 范围内的区块头，按区块号顺序写入流
+func NewHeaderStream(ctx context.Context, ep EtherProvider, from, to uint64, concurrency, buffer int) *Stream[*types.Header] {
+	return newStream(ctx, buffer, func(ctx context.Context, emit func(*types.Header) error) error {
+		return StreamHeaders(ctx, ep, from, to, concurrency, emit)
+	})
+}
+
+// StreamLogs 按分段并发查询 [from, to] 区块范围内的事件日志，按区块顺序写入流
+// 与 FetchLogs 不同，已查询但未被消费的分段最多缓存 2 × Concurrency 个，适合超大范围的回溯
+// 参数说明：
+//   - ctx: 上下文对象
+//   - query: 查询条件（地址和 topics，FromBlock/ToBlock/BlockHash 会被忽略）
+//   - from: 起始区块号（包含）
+//   - to: 结束区块号（包含）
+//   - buffer: 缓冲区大小（<= 0 使用 DefaultStreamBuffer）
+//
+// 返回：
+//   - *Stream[types.Log]: 事件日志流
+func (f *LogFetcher) StreamLogs(ctx context.Context, query ethereum.FilterQuery, from, to uint64, buffer int) *Stream[types.Log] {
+	return newStream(ctx, buffer, func(ctx context.Context, emit func(types.Log) error) error {
+		return f.streamChunks(ctx, query, from, to, func(chunks []logChunk) error {
+			for _, chunk := range chunks {
+				for _, log := range chunk.logs {
+					if err := emit(log); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		})
+	})
+}
+
+// streamChunks 将区块范围切分为固定大小的分段，并发查询并按顺序交付
+func (f *LogFetcher) streamChunks(ctx context.Context, query ethereum.FilterQuery, from, to uint64, deliver func([]logChunk) error) error {
+	if from > to {
+		return fmt.Errorf("invalid block range %d-%d", from, to)
+	}
+	state := &logRangeState{size: f.ChunkSize, minSize: f.MinChunkSize}
+	if state.size == 0 {
+		state.size = DefaultLogChunkSize
+	}
+	if state.minSize == 0 {
+		state.minSize = 1
+	}
+	size := state.size
+	concurrency := f.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultLogFetchConcurrency
+	}
+
+	lastChunk := (to - from) / size
+	fetch := func(ctx context.Context, n uint64) ([]logChunk, error) {
+		lo := from + n*size
+		hi := min(lo+size-1, to)
+		return f.fetchRange(ctx, query, lo, hi, state)
+	}
+	return fetchOrdered(ctx, 0, lastChunk, concurrency, fetch, deliver)
+}
+
+// TokenTransfer 解码后的 ERC20 Transfer 事件
+type TokenTransfer struct {
+	Token common.Address // 代币地址
+	From  common.Address // 转出地址
+	To    common.Address // 转入地址
+	Value *big.Int       // 转账数量（最小单位）
+	Log   types.Log      // 原始事件日志
+}
+
+// NewTokenTransferStream 查询 [from, to] 区块范围内的 ERC20 Transfer 事件，解码后按区块顺序写入流
+// 使用 LogFetcher 的默认参数分段查询；索引了 tokenId 的 ERC721 Transfer 事件会被跳过
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - tokens: 代币地址（nil 表示所有合约）
+//   - from: 起始区块号（包含）
+//   - to: 结束区块号（包含）
+//   - buffer: 缓冲区大小（<= 0 使用 DefaultStreamBuffer）
+//
+// 返回：
+//   - *Stream[TokenTransfer]: Transfer 事件流
+func NewTokenTransferStream(ctx context.Context, ep EtherProvider, tokens []common.Address, from, to uint64, buffer int) *Stream[TokenTransfer] {
+	transferTopic := erc20ABI.Events["Transfer"].ID
+	query := ethereum.FilterQuery{Addresses: tokens, Topics: [][]common.Hash{{transferTopic}}}
+	fetcher := NewLogFetcher(ep)
+
+	return newStream(ctx, buffer, func(ctx context.Context, emit func(TokenTransfer) error) error {
+		return fetcher.streamChunks(ctx, query, from, to, func(chunks []logChunk) error {
+			for _, chunk := range chunks {
+				for _, log := range chunk.logs {
+					transfer, ok := decodeTokenTransfer(log)
+					if !ok {
+						continue
+					}
+					if err := emit(transfer); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		})
+	})
+}
+
+// decodeTokenTransfer 解码 ERC20 Transfer 事件（ERC721 Transfer 等其他日志返回 false）
+func decodeTokenTransfer(log types.Log) (TokenTransfer, bool) {
+	if len(log.Topics) != 3 || log.Topics[0] != erc20ABI.Events["Transfer"].ID {
+		return TokenTransfer{}, false
+	}
+	return TokenTransfer{
+		Token: log.Address,
+		From:  common.BytesToAddress(log.Topics[1].Bytes()),
+		To:    common.BytesToAddress(log.Topics[2].Bytes()),
+		Value: new(big.Int).SetBytes(log.Data),
+		Log:   log,
+	}, true
+}
+
+// NewBlockStream 创建 [from, to] 范围内的区块流
+func (k *Kit) NewBlockStream(ctx context.Context, from, to uint64, concurrency, buffer int) *Stream[*types.Block] {
+	return NewBlockStream(ctx, k.EtherProvider, from, to, concurrency, buffer)
+}
+
+// NewTokenTransferStream 创建 [from, to] 区块范围内的 ERC20 Transfer 事件流
+func (k *Kit) NewTokenTransferStream(ctx context.Context, tokens []common.Address, from, to uint64, buffer int) *Stream[TokenTransfer] {
+	return NewTokenTransferStream(ctx, k.EtherProvider, tokens, from, to, buffer)
+}
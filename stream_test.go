@@ -0,0 +1,160 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestBlockStream(t *testing.T) {
+	server := newMockBlockServer(t, 0)
+	provider, err := NewProvider(server.URL)
+	if err != nil {
+		t.Fatalf("NewProvider() failed: %v", err)
+	}
+	defer provider.Close()
+
+	t.Run("ordered", func(t *testing.T) {
+		s := NewBlockStream(context.Background(), provider, 10, 60, 4, 2)
+		expected := uint64(10)
+		for block := range s.C {
+			if block.NumberU64() != expected {
+				t.Fatalf("block = %d, expected %d", block.NumberU64(), expected)
+			}
+			expected++
+		}
+		if err := s.Err(); err != nil {
+			t.Fatalf("Err() = %v", err)
+		}
+		if expected != 61 {
+			t.Errorf("received up to block %d, expected 60", expected-1)
+		}
+	})
+
+	t.Run("stop early", func(t *testing.T) {
+		s := NewBlockStream(context.Background(), provider, 1, 100000, 4, 2)
+		<-s.C
+		s.Stop()
+		if err := s.Err(); err != nil {
+			t.Errorf("Err() after Stop = %v, expected nil", err)
+		}
+		// 缓冲区有限，停止后不会继续查询整个范围
+		if calls := server.callCount("eth_getBlockByNumber"); calls > 1000 {
+			t.Errorf("eth_getBlockByNumber count = %d, expected backpressure to bound fetching", calls)
+		}
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		s := NewBlockStream(ctx, provider, 1, 100000, 4, 2)
+		<-s.C
+		cancel()
+		for range s.C {
+		}
+		if err := s.Err(); !errors.Is(err, context.Canceled) {
+			t.Errorf("Err() = %v, expected context.Canceled", err)
+		}
+	})
+}
+
+func TestBlockStreamError(t *testing.T) {
+	server := newMockBlockServer(t, 25)
+	provider, err := NewProvider(server.URL)
+	if err != nil {
+		t.Fatalf("NewProvider() failed: %v", err)
+	}
+	defer provider.Close()
+
+	s := NewBlockStream(context.Background(), provider, 1, 50, 4, 0)
+	count := 0
+	for range s.C {
+		count++
+	}
+	if s.Err() == nil {
+		t.Fatal("Err() expected error for unavailable block")
+	}
+	if count != 24 {
+		t.Errorf("received %d blocks before error, expected 24", count)
+	}
+}
+
+func TestLogStream(t *testing.T) {
+	server := newMockLogServer(t, 150)
+	provider, err := NewProvider(server.URL)
+	if err != nil {
+		t.Fatalf("NewProvider() failed: %v", err)
+	}
+	defer provider.Close()
+
+	fetcher := NewLogFetcher(provider)
+	fetcher.ChunkSize = 300
+	s := fetcher.StreamLogs(context.Background(), ethereum.FilterQuery{}, 1, 2000, 8)
+	count := 0
+	for log := range s.C {
+		count++
+		if log.BlockNumber != uint64(count)*10 {
+			t.Fatalf("log %d BlockNumber = %d, expected %d (results out of order)", count, log.BlockNumber, count*10)
+		}
+	}
+	if err := s.Err(); err != nil {
+		t.Fatalf("Err() = %v", err)
+	}
+	if count != 200 {
+		t.Errorf("received %d logs, expected 200", count)
+	}
+}
+
+func TestTokenTransferStream(t *testing.T) {
+	token := common.HexToAddress("0x1c7D4B196Cb0C7B01d743Fbc6116a902379C7238")
+	from := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	to := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	transferTopic := erc20ABI.Events["Transfer"].ID
+
+	server := newMockRPCServer(t, map[string]mockRPCHandler{
+		"eth_getLogs": func(params []json.RawMessage) (interface{}, error) {
+			var filter struct {
+				FromBlock hexutil.Uint64 `json:"fromBlock"`
+			}
+			if err := json.Unmarshal(params[0], &filter); err != nil {
+				return nil, err
+			}
+			n := uint64(filter.FromBlock)
+			return []types.Log{
+				// ERC20 Transfer
+				{Address: token, BlockNumber: n, Topics: []common.Hash{transferTopic, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())}, Data: common.BigToHash(big.NewInt(int64(n))).Bytes()},
+				// ERC721 Transfer（tokenId 被索引），应被跳过
+				{Address: token, BlockNumber: n, Topics: []common.Hash{transferTopic, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes()), common.BigToHash(big.NewInt(1))}, Data: []byte{}},
+			}, nil
+		},
+	})
+	provider, err := NewProvider(server.URL)
+	if err != nil {
+		t.Fatalf("NewProvider() failed: %v", err)
+	}
+	defer provider.Close()
+
+	s := NewTokenTransferStream(context.Background(), provider, []common.Address{token}, 1, 3*DefaultLogChunkSize, 0)
+	var transfers []TokenTransfer
+	for transfer := range s.C {
+		transfers = append(transfers, transfer)
+	}
+	if err := s.Err(); err != nil {
+		t.Fatalf("Err() = %v", err)
+	}
+	if len(transfers) != 3 {
+		t.Fatalf("received %d transfers, expected 3", len(transfers))
+	}
+	for i, transfer := range transfers {
+		start := uint64(1 + i*DefaultLogChunkSize)
+		if transfer.Token != token || transfer.From != from || transfer.To != to || transfer.Value.Uint64() != start {
+			t.Errorf("transfers[%d] = %+v", i, transfer)
+		}
+	}
+}
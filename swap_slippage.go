@@ -0,0 +1,239 @@
+package etherkit
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+//############ Swap Slippage ############
+
+// MaxSlippageBps 滑点的上限（10000 基点 = 100%）
+const MaxSlippageBps = 10000
+
+var bpsDenominator = big.NewInt(MaxSlippageBps)
+
+// checkSlippageArgs 检查报价金额和滑点是否有效
+func checkSlippageArgs(amount *big.Int, slippageBps uint64) error {
+	if amount == nil || amount.Sign() < 0 {
+		return fmt.Errorf("%w: quoted amount must be non-negative", ErrInvalidSlippage)
+	}
+	if slippageBps > MaxSlippageBps {
+		return fmt.Errorf("%w: %d bps exceeds %d", ErrInvalidSlippage, slippageBps, MaxSlippageBps)
+	}
+	return nil
+}
+
+// MinAmountOut 根据报价的输出数量和滑点计算最小可接受输出（用于 amountOutMin 一类参数）
+// 全程使用整数运算并向下取整，保证结果不会高于报价扣除滑点后的数量（避免浮点误差导致交易意外回滚或保护不足）
+// 参数说明：
+//   - quotedOut: 报价的输出数量（最小单位，如 getAmountsOut 的返回值）
+//   - slippageBps: 可接受的滑点（基点，50 = 0.5%，最大 MaxSlippageBps）
+//
+// 返回：
+//   - *big.Int: 最小输出数量 = quotedOut × (10000 - slippageBps) / 10000
+//   - error: 如果报价为 nil/负数或滑点超过 100% 则返回 ErrInvalidSlippage
+//
+// 使用示例：
+//
+//	minOut, err := MinAmountOut(quoted, 50) // 0.5% 滑点
+func MinAmountOut(quotedOut *big.Int, slippageBps uint64) (*big.Int, error) {
+	if err := checkSlippageArgs(quotedOut, slippageBps); err != nil {
+		return nil, err
+	}
+	out := new(big.Int).Mul(quotedOut, new(big.Int).SetUint64(MaxSlippageBps-slippageBps))
+	return out.Div(out, bpsDenominator), nil
+}
+
+// MaxAmountIn 根据报价的输入数量和滑点计算最大可接受输入（用于 exact-output 交换的 amountInMax 一类参数）
+// 全程使用整数运算并向上取整，保证结果不会低于报价加上滑点后的数量
+// 参数说明：
+//   - quotedIn: 报价的输入数量（最小单位，如 getAmountsIn 的返回值）
+//   - slippageBps: 可接受的滑点（基点，最大 MaxSlippageBps）
+//
+// 返回：
+//   - *big.Int: 最大输入数量 = ceil(quotedIn × (10000 + slippageBps) / 10000)
+//   - error: 如果报价为 nil/负数或滑点超过 100% 则返回 ErrInvalidSlippage
+func MaxAmountIn(quotedIn *big.Int, slippageBps uint64) (*big.Int, error) {
+	if err := checkSlippageArgs(quotedIn, slippageBps); err != nil {
+		return nil, err
+	}
+	in := new(big.Int).Mul(quotedIn, new(big.Int).SetUint64(MaxSlippageBps+slippageBps))
+	in.Add(in, new(big.Int).Sub(bpsDenominator, big.NewInt(1)))
+	return in.Div(in, bpsDenominator), nil
+}
+
+// SwapDeadline 计算 deadline 参数（Unix 秒）：最新区块时间 + ttl
+// 合约用 block.timestamp 比较 deadline，因此以链上时间而不是本地时钟为基准，避免本地时钟偏差导致交易立即失效
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - ttl: 交易的有效时长（如 20 * time.Minute）
+//
+// 返回：
+//   - *big.Int: deadline（Unix 秒，可直接作为 uint256 参数）
+//   - error: 如果 ttl 不为正数或查询最新区块失败则返回错误
+func SwapDeadline(ctx context.Context, ep EtherProvider, ttl time.Duration) (*big.Int, error) {
+	if ttl <= 0 {
+		return nil, fmt.Errorf("deadline ttl must be positive, got %s", ttl)
+	}
+	header, err := providerExt[BlockRefReader](ep).GetHeaderAt(ctx, BlockRef{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest block: %w", err)
+	}
+	deadline := new(big.Int).SetUint64(header.Time)
+	return deadline.Add(deadline, big.NewInt(int64(ttl/time.Second))), nil
+}
+
+// CheckSwapDeadline 检查 deadline 参数是否还剩至少 minRemaining 的有效时间（以最新区块时间为准）
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - deadline: deadline 参数（Unix 秒）
+//   - minRemaining: 要求的最短剩余时间（覆盖交易进入区块前的等待时间，0 表示只要求尚未过期）
+//
+// 返回：
+//   - error: 如果 deadline 已过期或剩余时间不足则返回 ErrDeadlinePassed，查询失败则返回其他错误
+func CheckSwapDeadline(ctx context.Context, ep EtherProvider, deadline *big.Int, minRemaining time.Duration) error {
+	if deadline == nil {
+		return fmt.Errorf("%w: deadline is nil", ErrDeadlinePassed)
+	}
+	header, err := providerExt[BlockRefReader](ep).GetHeaderAt(ctx, BlockRef{})
+	if err != nil {
+		return fmt.Errorf("failed to get latest block: %w", err)
+	}
+	earliest := new(big.Int).SetUint64(header.Time)
+	earliest.Add(earliest, big.NewInt(int64(minRemaining/time.Second)))
+	if deadline.Cmp(earliest) <= 0 {
+		return fmt.Errorf("%w: deadline %s, block %d time %d, required remaining %s",
+			ErrDeadlinePassed, deadline, header.Number.Uint64(), header.Time, minRemaining)
+	}
+	return nil
+}
+
+// swapMinOutNames 表示最小输出数量的常见参数名（比较时忽略大小写和下划线）
+var swapMinOutNames = []string{"amountoutmin", "amountoutminimum", "minamountout", "minreturn", "minreturnamount", "amountminout"}
+
+// swapParam 从调用数据中找到的 deadline 或最小输出参数
+type swapParam struct {
+	name  string
+	value *big.Int
+}
+
+// collectSwapParams 遍历参数（包括元组字段），收集 deadline 和最小输出参数
+func collectSwapParams(path string, typ abi.Type, v reflect.Value, deadlines, minOuts *[]swapParam) {
+	if typ.T == abi.TupleTy {
+		for i, elem := range typ.TupleElems {
+			collectSwapParams(path+"."+typ.TupleRawNames[i], *elem, v.Field(i), deadlines, minOuts)
+		}
+		return
+	}
+	if typ.T != abi.UintTy {
+		return
+	}
+	value, ok := v.Interface().(*big.Int)
+	if !ok {
+		// uint8 ~ uint64 解码为定长整数
+		value = new(big.Int).SetUint64(v.Convert(reflect.TypeOf(uint64(0))).Uint())
+	}
+	name := path[strings.LastIndex(path, ".")+1:]
+	normalized := strings.ToLower(strings.ReplaceAll(name, "_", ""))
+	if normalized == "deadline" {
+		*deadlines = append(*deadlines, swapParam{path, value})
+		return
+	}
+	for _, n := range swapMinOutNames {
+		if normalized == n {
+			*minOuts = append(*minOuts, swapParam{path, value})
+			return
+		}
+	}
+}
+
+// CheckSwapCalldata 在发送前检查交换类调用数据的 deadline 和最小输出参数
+// 按参数名识别：名为 deadline 的 uint 参数必须还剩至少 minRemaining 的有效时间；
+// amountOutMin / amountOutMinimum / minAmountOut / minReturn 等参数不能为 0（为 0 表示没有任何滑点保护，容易被三明治攻击）
+// 元组参数（如 Uniswap V3 exactInputSingle 的 params）中的字段同样会被检查
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - contractAbi: 合约 ABI
+//   - data: 调用数据（4 字节选择器 + 参数）
+//   - minRemaining: deadline 要求的最短剩余时间
+//
+// 返回：
+//   - error: deadline 不满足时返回 ErrDeadlinePassed，最小输出为 0 时返回 ErrInvalidSlippage，
+//     调用数据无法解码时返回 ErrInvalidABI
+//
+// 使用示例：
+//
+//	data, _ := BuildContractInputData(routerAbi, "swapExactTokensForTokens", amountIn, minOut, path, to, deadline)
+//	if err := CheckSwapCalldata(ctx, provider, routerAbi, data, time.Minute); err != nil {
+//	    return err
+//	}
+func CheckSwapCalldata(ctx context.Context, ep EtherProvider, contractAbi abi.ABI, data []byte, minRemaining time.Duration) error {
+	if len(data) < 4 {
+		return fmt.Errorf("%w: calldata shorter than selector", ErrInvalidABI)
+	}
+	method, err := contractAbi.MethodById(data[:4])
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidABI, err)
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return fmt.Errorf("%w: failed to decode %s arguments: %w", ErrInvalidABI, method.Name, err)
+	}
+	var deadlines, minOuts []swapParam
+	for i, input := range method.Inputs {
+		collectSwapParams(input.Name, input.Type, reflect.ValueOf(args[i]), &deadlines, &minOuts)
+	}
+	for _, p := range minOuts {
+		if p.value.Sign() == 0 {
+			return fmt.Errorf("%w: %s.%s is zero, swap has no slippage protection", ErrInvalidSlippage, method.Name, p.name)
+		}
+	}
+	for _, p := range deadlines {
+		if err := CheckSwapDeadline(ctx, ep, p.value, minRemaining); err != nil {
+			return fmt.Errorf("%s.%s: %w", method.Name, p.name, err)
+		}
+	}
+	return nil
+}
+
+// SwapDeadline 计算 deadline 参数（Unix 秒）：最新区块时间 + ttl
+func (k *Kit) SwapDeadline(ctx context.Context, ttl time.Duration) (*big.Int, error) {
+	return SwapDeadline(ctx, k.EtherProvider, ttl)
+}
+
+// BuildSwapInputData 构建交换类合约调用的调用数据，并检查 deadline 和最小输出参数（见 CheckSwapCalldata）
+// 参数说明：
+//   - ctx: 上下文对象
+//   - contractAbi: 合约 ABI
+//   - functionName: 函数名（如 "swapExactTokensForTokens"）
+//   - minRemaining: deadline 要求的最短剩余时间
+//   - params: 函数参数（按函数定义顺序传入）
+//
+// 返回：
+//   - []byte: 调用数据，可直接用于 SendTx 或 PrepareTx
+//   - error: 如果编码失败、deadline 不满足（ErrDeadlinePassed）或最小输出为 0（ErrInvalidSlippage）则返回错误
+//
+// 使用示例：
+//
+//	minOut, _ := MinAmountOut(quoted, 50)
+//	deadline, _ := kit.SwapDeadline(ctx, 20*time.Minute)
+//	data, err := kit.BuildSwapInputData(ctx, routerAbi, "swapExactTokensForTokens", time.Minute, amountIn, minOut, path, kit.GetAddress(), deadline)
+func (k *Kit) BuildSwapInputData(ctx context.Context, contractAbi abi.ABI, functionName string, minRemaining time.Duration, params ...interface{}) ([]byte, error) {
+	data, err := BuildContractInputData(contractAbi, functionName, params...)
+	if err != nil {
+		return nil, err
+	}
+	if err := CheckSwapCalldata(ctx, k.EtherProvider, contractAbi, data, minRemaining); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
@@ -0,0 +1,162 @@
+package etherkit
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestSlippageBounds(t *testing.T) {
+	tests := []struct {
+		name    string
+		quoted  *big.Int
+		bps     uint64
+		wantMin *big.Int
+		wantMax *big.Int
+		wantErr bool
+	}{
+		{name: "half percent", quoted: big.NewInt(1_000_000), bps: 50, wantMin: big.NewInt(995_000), wantMax: big.NewInt(1_005_000)},
+		// 995.0049... 向下取整，1004.9951... 向上取整
+		{name: "rounding", quoted: big.NewInt(999), bps: 3, wantMin: big.NewInt(998), wantMax: big.NewInt(1000)},
+		{name: "zero slippage", quoted: big.NewInt(12345), bps: 0, wantMin: big.NewInt(12345), wantMax: big.NewInt(12345)},
+		{name: "full slippage", quoted: big.NewInt(12345), bps: MaxSlippageBps, wantMin: big.NewInt(0), wantMax: big.NewInt(24690)},
+		{name: "large amount", quoted: new(big.Int).Lsh(big.NewInt(1), 200), bps: 100, wantMin: new(big.Int).Div(new(big.Int).Mul(new(big.Int).Lsh(big.NewInt(1), 200), big.NewInt(9900)), big.NewInt(10000)), wantMax: nil},
+		{name: "slippage over 100%", quoted: big.NewInt(1), bps: MaxSlippageBps + 1, wantErr: true},
+		{name: "negative amount", quoted: big.NewInt(-1), bps: 50, wantErr: true},
+		{name: "nil amount", quoted: nil, bps: 50, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			minOut, err := MinAmountOut(tt.quoted, tt.bps)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidSlippage) {
+					t.Errorf("MinAmountOut() error = %v, want ErrInvalidSlippage", err)
+				}
+				if _, err := MaxAmountIn(tt.quoted, tt.bps); !errors.Is(err, ErrInvalidSlippage) {
+					t.Errorf("MaxAmountIn() error = %v, want ErrInvalidSlippage", err)
+				}
+				return
+			}
+			if err != nil || minOut.Cmp(tt.wantMin) != 0 {
+				t.Errorf("MinAmountOut() = %v, %v, expected %s", minOut, err, tt.wantMin)
+			}
+			if tt.wantMax == nil {
+				return
+			}
+			if maxIn, err := MaxAmountIn(tt.quoted, tt.bps); err != nil || maxIn.Cmp(tt.wantMax) != 0 {
+				t.Errorf("MaxAmountIn() = %v, %v, expected %s", maxIn, err, tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestSwapDeadline(t *testing.T) {
+	// 最新区块时间为 1600120000
+	server := newMockTimedChainServer(t, time.Unix(1600000000, 0), 12, 10000)
+	kit := newMockKit(t, server)
+	ctx := context.Background()
+	head := int64(1600120000)
+
+	deadline, err := kit.SwapDeadline(ctx, 20*time.Minute)
+	if err != nil {
+		t.Fatalf("SwapDeadline() failed: %v", err)
+	}
+	if deadline.Int64() != head+1200 {
+		t.Errorf("SwapDeadline() = %s, expected %d", deadline, head+1200)
+	}
+	if _, err := kit.SwapDeadline(ctx, 0); err == nil {
+		t.Error("SwapDeadline() with zero ttl expected error")
+	}
+
+	tests := []struct {
+		name         string
+		deadline     *big.Int
+		minRemaining time.Duration
+		wantErr      bool
+	}{
+		{name: "valid", deadline: big.NewInt(head + 1200), minRemaining: time.Minute},
+		{name: "expired", deadline: big.NewInt(head - 1), wantErr: true},
+		{name: "equal to block time", deadline: big.NewInt(head), wantErr: true},
+		{name: "too close", deadline: big.NewInt(head + 30), minRemaining: time.Minute, wantErr: true},
+		{name: "nil", deadline: nil, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckSwapDeadline(ctx, kit.EtherProvider, tt.deadline, tt.minRemaining)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckSwapDeadline() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrDeadlinePassed) {
+				t.Errorf("error %v should wrap ErrDeadlinePassed", err)
+			}
+		})
+	}
+}
+
+func TestBuildSwapInputData(t *testing.T) {
+	server := newMockTimedChainServer(t, time.Unix(1600000000, 0), 12, 10000)
+	kit := newMockKit(t, server)
+	ctx := context.Background()
+	head := int64(1600120000)
+
+	routerAbi, err := GetABI(`[
+{"inputs":[{"name":"amountIn","type":"uint256"},{"name":"amountOutMin","type":"uint256"},{"name":"path","type":"address[]"},{"name":"to","type":"address"},{"name":"deadline","type":"uint256"}],"name":"swapExactTokensForTokens","outputs":[{"name":"amounts","type":"uint256[]"}],"stateMutability":"nonpayable","type":"function"},
+{"inputs":[{"components":[{"name":"tokenIn","type":"address"},{"name":"tokenOut","type":"address"},{"name":"fee","type":"uint24"},{"name":"recipient","type":"address"},{"name":"deadline","type":"uint256"},{"name":"amountIn","type":"uint256"},{"name":"amountOutMinimum","type":"uint256"},{"name":"sqrtPriceLimitX96","type":"uint160"}],"name":"params","type":"tuple"}],"name":"exactInputSingle","outputs":[{"name":"amountOut","type":"uint256"}],"stateMutability":"payable","type":"function"}
+]`)
+	if err != nil {
+		t.Fatalf("GetABI() failed: %v", err)
+	}
+	tokenIn := common.HexToAddress("0x00000000000000000000000000000000000000a1")
+	tokenOut := common.HexToAddress("0x00000000000000000000000000000000000000b2")
+	v3Params := func(deadline, minOut int64) interface{} {
+		return struct {
+			TokenIn           common.Address
+			TokenOut          common.Address
+			Fee               *big.Int
+			Recipient         common.Address
+			Deadline          *big.Int
+			AmountIn          *big.Int
+			AmountOutMinimum  *big.Int
+			SqrtPriceLimitX96 *big.Int
+		}{tokenIn, tokenOut, big.NewInt(3000), kit.GetAddress(), big.NewInt(deadline), big.NewInt(1000), big.NewInt(minOut), big.NewInt(0)}
+	}
+
+	tests := []struct {
+		name     string
+		function string
+		params   []interface{}
+		wantErr  error
+	}{
+		{name: "v2 valid", function: "swapExactTokensForTokens", params: []interface{}{big.NewInt(1000), big.NewInt(990), []common.Address{tokenIn, tokenOut}, kit.GetAddress(), big.NewInt(head + 600)}},
+		{name: "v2 expired deadline", function: "swapExactTokensForTokens", params: []interface{}{big.NewInt(1000), big.NewInt(990), []common.Address{tokenIn, tokenOut}, kit.GetAddress(), big.NewInt(head - 10)}, wantErr: ErrDeadlinePassed},
+		{name: "v2 zero minimum", function: "swapExactTokensForTokens", params: []interface{}{big.NewInt(1000), big.NewInt(0), []common.Address{tokenIn, tokenOut}, kit.GetAddress(), big.NewInt(head + 600)}, wantErr: ErrInvalidSlippage},
+		{name: "v3 tuple valid", function: "exactInputSingle", params: []interface{}{v3Params(head+600, 990)}},
+		{name: "v3 tuple deadline too close", function: "exactInputSingle", params: []interface{}{v3Params(head+30, 990)}, wantErr: ErrDeadlinePassed},
+		{name: "v3 tuple zero minimum", function: "exactInputSingle", params: []interface{}{v3Params(head+600, 0)}, wantErr: ErrInvalidSlippage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := kit.BuildSwapInputData(ctx, routerAbi, tt.function, time.Minute, tt.params...)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("BuildSwapInputData() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("BuildSwapInputData() failed: %v", err)
+			}
+			if len(data) < 4 || [4]byte(data[:4]) != [4]byte(routerAbi.Methods[tt.function].ID) {
+				t.Errorf("BuildSwapInputData() selector = %x", data[:4])
+			}
+		})
+	}
+
+	if err := CheckSwapCalldata(ctx, kit.EtherProvider, routerAbi, []byte{0x01, 0x02}, 0); !errors.Is(err, ErrInvalidABI) {
+		t.Errorf("CheckSwapCalldata() with short calldata error = %v, want ErrInvalidABI", err)
+	}
+}
@@ -0,0 +1,106 @@
+package etherkit
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	hdwallet "github.com/miguelmota/go-ethereum-hdwallet"
+)
+
+//############ Test Accounts ############
+
+// TestMnemonic hardhat / anvil 本地节点默认使用的公开助记词（任何人都知道这些私钥，切勿在真实网络上使用）
+const TestMnemonic = "test test test test test test test test test test test junk"
+
+// TestAccountCount 内置测试账户数量（hardhat 默认预置 20 个账户，anvil 默认预置前 10 个）
+const TestAccountCount = 20
+
+// TestAccount 由 TestMnemonic 派生的测试账户
+type TestAccount struct {
+	Index      int               // 账户索引（派生路径 m/44'/60'/0'/0/{Index}）
+	Address    common.Address    // 账户地址
+	PrivateKey *ecdsa.PrivateKey // 私钥
+}
+
+// HexPrivateKey 返回十六进制私钥（不带 0x 前缀，可用于 NewKit）
+func (a TestAccount) HexPrivateKey() string {
+	return GetHexPrivateKey(a.PrivateKey)
+}
+
+// testAccounts 派生一次后缓存的测试账户
+var testAccounts = sync.OnceValues(func() ([]TestAccount, error) {
+	wallet, err := hdwallet.NewFromMnemonic(TestMnemonic)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]TestAccount, TestAccountCount)
+	for i := range result {
+		path, err := accounts.ParseDerivationPath(fmt.Sprintf("m/44'/60'/0'/0/%d", i))
+		if err != nil {
+			return nil, err
+		}
+		account, err := wallet.Derive(path, false)
+		if err != nil {
+			return nil, err
+		}
+		pk, err := wallet.PrivateKey(account)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = TestAccount{Index: i, Address: account.Address, PrivateKey: pk}
+	}
+	return result, nil
+})
+
+// WellKnownTestAccounts 返回 hardhat / anvil 本地节点预置的测试账户（由 TestMnemonic 派生，顺序与节点一致）
+// 用于让本地节点上的示例和测试可复现：账户 0 为 0xf39F…2266，账户 1 为 0x7099…79C8，以此类推
+//
+// 返回：
+//   - []TestAccount: TestAccountCount 个测试账户
+func WellKnownTestAccounts() []TestAccount {
+	result, err := testAccounts()
+	if err != nil {
+		// 助记词和派生路径都是常量，不会失败
+		panic(fmt.Sprintf("failed to derive test accounts: %v", err))
+	}
+	return append([]TestAccount(nil), result...)
+}
+
+// GetTestAccount 返回第 i 个测试账户
+// 参数说明：
+//   - i: 账户索引（0 ~ TestAccountCount-1）
+//
+// 返回：
+//   - TestAccount: 测试账户
+//   - error: 如果索引超出范围则返回错误
+func GetTestAccount(i int) (TestAccount, error) {
+	if i < 0 || i >= TestAccountCount {
+		return TestAccount{}, fmt.Errorf("test account index %d out of range [0, %d)", i, TestAccountCount)
+	}
+	return WellKnownTestAccounts()[i], nil
+}
+
+// NewKitForTestAccount 使用第 i 个测试账户创建 Kit（用于连接 hardhat / anvil 等本地节点）
+// 参数说明：
+//   - i: 账户索引（0 ~ TestAccountCount-1）
+//   - rawUrl: 本地节点 RPC URL（如 "http://127.0.0.1:8545"）
+//   - opts: 可选配置
+//
+// 返回：
+//   - *Kit: 创建的 Kit 实例
+//   - error: 如果索引超出范围或连接节点失败则返回错误
+//
+// 使用示例：
+//
+//	deployer, err := NewKitForTestAccount(0, "http://127.0.0.1:8545")
+//	alice, err := NewKitForTestAccount(1, "http://127.0.0.1:8545")
+func NewKitForTestAccount(i int, rawUrl string, opts ...KitOption) (*Kit, error) {
+	account, err := GetTestAccount(i)
+	if err != nil {
+		return nil, err
+	}
+	return New(rawUrl, append([]KitOption{WithPrivateKey(account.PrivateKey)}, opts...)...)
+}
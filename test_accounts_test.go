@@ -0,0 +1,65 @@
+package etherkit
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestWellKnownTestAccounts(t *testing.T) {
+	accounts := WellKnownTestAccounts()
+	if len(accounts) != TestAccountCount {
+		t.Fatalf("WellKnownTestAccounts() returned %d accounts, expected %d", len(accounts), TestAccountCount)
+	}
+
+	// hardhat / anvil 启动时输出的账户
+	tests := []struct {
+		index   int
+		address string
+		key     string
+	}{
+		{0, "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"},
+		{1, "0x70997970C51812dc3A010C7d01b50e0d17dc79C8", "59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d"},
+		{19, "0x8626f6940E2eb28930eFb4CeF49B2d1F2C9C1199", "df57089febbacf7ba0bc227dafbffa9fc08a93fdc68e1e42411a14efcf23656e"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			account, err := GetTestAccount(tt.index)
+			if err != nil {
+				t.Fatalf("GetTestAccount() failed: %v", err)
+			}
+			if account.Address != common.HexToAddress(tt.address) || account.HexPrivateKey() != tt.key {
+				t.Errorf("GetTestAccount(%d) = %s / %s, expected %s / %s", tt.index, account.Address.Hex(), account.HexPrivateKey(), tt.address, tt.key)
+			}
+			if PrivateKeyToAddress(account.PrivateKey) != account.Address || account.Index != tt.index {
+				t.Errorf("GetTestAccount(%d) is inconsistent: %+v", tt.index, account)
+			}
+		})
+	}
+
+	if _, err := GetTestAccount(TestAccountCount); err == nil {
+		t.Error("GetTestAccount() expected error for out-of-range index")
+	}
+
+	// 修改返回的切片不影响后续调用
+	accounts[0] = TestAccount{}
+	if WellKnownTestAccounts()[0].Address != common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266") {
+		t.Error("WellKnownTestAccounts() returned a shared slice")
+	}
+}
+
+func TestNewKitForTestAccount(t *testing.T) {
+	server := newMockSendServer(t)
+	kit, err := NewKitForTestAccount(1, server.URL, WithChainID(31337))
+	if err != nil {
+		t.Fatalf("NewKitForTestAccount() failed: %v", err)
+	}
+	defer kit.Close()
+	if kit.GetAddress() != common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8") {
+		t.Errorf("GetAddress() = %s, expected test account 1", kit.GetAddress().Hex())
+	}
+	if _, err := NewKitForTestAccount(-1, server.URL); err == nil {
+		t.Error("NewKitForTestAccount() expected error for negative index")
+	}
+}
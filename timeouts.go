@@ -0,0 +1,131 @@
+package etherkit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+)
+
+//############ Default Timeouts ############
+
+// TimeoutPolicy 调用方的 context 没有设置截止时间时使用的默认超时（按操作类型区分，0 表示不限制）
+type TimeoutPolicy struct {
+	Read time.Duration // 查询类 RPC 请求（eth_call、eth_getBalance 等）
+	Send time.Duration // 广播交易（eth_sendRawTransaction）
+	Wait time.Duration // 等待交易收据（WaitForReceipt 等方法传入的 timeout <= 0 时使用）
+}
+
+// DefaultTimeoutPolicy 推荐的默认超时
+var DefaultTimeoutPolicy = TimeoutPolicy{
+	Read: 30 * time.Second,
+	Send: time.Minute,
+	Wait: 5 * time.Minute,
+}
+
+// WithDefaultTimeouts 为没有截止时间的请求设置默认超时（仅对 HTTP(S) 节点生效）
+// 与 WithCallTimeout 不同，调用方的 context 已设置截止时间时不会再缩短
+// 参数说明：
+//   - read: 查询类请求的默认超时（0 表示不限制）
+//   - send: 广播交易的默认超时（0 表示不限制）
+func WithDefaultTimeouts(read, send time.Duration) ProviderOption {
+	return func(c *providerConfig) {
+		c.readTimeout = read
+		c.sendTimeout = send
+	}
+}
+
+// WithTimeoutPolicy 设置 Kit 的默认超时策略
+// Read 和 Send 作用于 Provider 的 RPC 请求（仅在创建 Kit 时生效，使用 WithProvider 时请为 Provider 设置 WithDefaultTimeouts），
+// Wait 作用于 WaitForReceipt 等等待方法
+//
+// 使用示例：
+//
+//	kit, err := New(rpcUrl, WithPrivateKeyHex(hexPk), WithTimeoutPolicy(DefaultTimeoutPolicy))
+//	receipt, err := kit.WaitForReceipt(context.Background(), hash, 0) // 最多等待 5 分钟
+func WithTimeoutPolicy(policy TimeoutPolicy) KitOption {
+	return func(k *Kit) {
+		k.timeouts = policy
+		if k.setup != nil {
+			k.setup.providerOpts = append(k.setup.providerOpts, WithDefaultTimeouts(policy.Read, policy.Send))
+		}
+	}
+}
+
+// TimeoutPolicy 返回 Kit 的默认超时策略
+func (k *Kit) TimeoutPolicy() TimeoutPolicy {
+	return k.timeouts
+}
+
+// timeoutTransport 为没有截止时间的请求按 JSON-RPC 方法类型设置超时的传输层
+type timeoutTransport struct {
+	base http.RoundTripper
+	read time.Duration
+	send time.Duration
+}
+
+// RoundTrip 实现 http.RoundTripper 接口
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if _, ok := req.Context().Deadline(); ok || req.Body == nil {
+		return t.base.RoundTrip(req)
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	_ = req.Body.Close()
+
+	timeout := t.read
+	if isSendRequest(body) {
+		timeout = t.send
+	}
+	req = req.Clone(req.Context())
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	if timeout <= 0 {
+		return t.base.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), timeout)
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	// 响应体读取完成后才能取消 context
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// isSendRequest 判断 JSON-RPC 请求（单条或批量）是否包含广播交易
+func isSendRequest(body []byte) bool {
+	var calls []struct {
+		Method string `json:"method"`
+	}
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] != '[' {
+		body = append(append([]byte{'['}, trimmed...), ']')
+	}
+	if err := json.Unmarshal(body, &calls); err != nil {
+		return false
+	}
+	for _, call := range calls {
+		if call.Method == "eth_sendRawTransaction" || call.Method == "eth_sendTransaction" {
+			return true
+		}
+	}
+	return false
+}
+
+// cancelOnClose 关闭响应体时取消请求的 context
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close 关闭响应体并取消 context
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
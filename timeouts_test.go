@@ -0,0 +1,97 @@
+package etherkit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// newSlowServer 延迟 delay 后转发给模拟节点
+func newSlowServer(t *testing.T, m *mockRPCServer, delay time.Duration) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(delay):
+			m.serveHTTP(w, r)
+		case <-r.Context().Done():
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestWithDefaultTimeouts(t *testing.T) {
+	m := newMockSendServer(t)
+	m.handlers["eth_blockNumber"] = mockResult("0x10")
+	slow := newSlowServer(t, m, 200*time.Millisecond)
+
+	provider, err := NewProvider(slow.URL, WithDefaultTimeouts(50*time.Millisecond, 0))
+	if err != nil {
+		t.Fatalf("NewProvider() failed: %v", err)
+	}
+	defer provider.Close()
+
+	// 没有截止时间：使用默认超时
+	start := time.Now()
+	if _, err := provider.GetBlockNumber(context.Background()); err == nil {
+		t.Fatal("GetBlockNumber() expected default timeout error")
+	}
+	if elapsed := time.Since(start); elapsed > 150*time.Millisecond {
+		t.Errorf("call took %s, expected default read timeout of 50ms", elapsed)
+	}
+
+	// 调用方设置了截止时间：不再缩短
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if number, err := provider.GetBlockNumber(ctx); err != nil || number != 16 {
+		t.Errorf("GetBlockNumber() = %d, %v; expected caller deadline to be honored", number, err)
+	}
+}
+
+func TestIsSendRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want bool
+	}{
+		{"send", `{"jsonrpc":"2.0","id":1,"method":"eth_sendRawTransaction","params":["0x01"]}`, true},
+		{"read", `{"jsonrpc":"2.0","id":1,"method":"eth_call","params":[]}`, false},
+		{"batch with send", `[{"method":"eth_chainId"},{"method":"eth_sendRawTransaction"}]`, true},
+		{"batch reads", `[{"method":"eth_chainId"},{"method":"eth_blockNumber"}]`, false},
+		{"invalid", `not json`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isSendRequest([]byte(tt.body)); got != tt.want {
+				t.Errorf("isSendRequest() = %v, expected %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWaitTimeoutPolicy(t *testing.T) {
+	server := newMockSendServer(t)
+	server.handlers["eth_getTransactionReceipt"] = mockResult(nil)
+	pk, _ := BuildPrivateKeyFromHex("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	kit, err := New(server.URL, WithPrivateKey(pk), WithTimeoutPolicy(TimeoutPolicy{Wait: 50 * time.Millisecond}))
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	defer kit.CloseWallet()
+
+	// timeout 为 0 时使用策略中的等待超时
+	start := time.Now()
+	_, err = kit.WaitForReceipt(context.Background(), common.Hash{1}, 0)
+	if !errors.Is(err, ErrReceiptTimeout) {
+		t.Fatalf("WaitForReceipt() error = %v, want ErrReceiptTimeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("WaitForReceipt() took %s, expected about 50ms", elapsed)
+	}
+}
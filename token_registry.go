@@ -0,0 +1,199 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/guanzhenxing/go-evm-kit/contracts/erc20"
+	"github.com/shopspring/decimal"
+)
+
+//############ Token Registry ############
+
+// TokenMetadata 代币元数据
+type TokenMetadata struct {
+	ChainID  int64          `json:"chainId"`           // 链 ID
+	Address  common.Address `json:"address"`           // 代币合约地址
+	Name     string         `json:"name"`              // 代币名称
+	Symbol   string         `json:"symbol"`            // 代币符号
+	Decimals uint8          `json:"decimals"`          // 代币精度
+	LogoURI  string         `json:"logoURI,omitempty"` // 代币图标（来自代币列表）
+}
+
+// tokenKey 代币在注册表中的键（同一地址在不同链上是不同的代币）
+type tokenKey struct {
+	chainID int64
+	address common.Address
+}
+
+// TokenRegistry 代币元数据注册表（并发安全）
+// 按 (链 ID, 地址) 缓存 symbol/decimals/name，未命中时从链上查询并缓存
+type TokenRegistry struct {
+	mu     sync.RWMutex
+	tokens map[tokenKey]TokenMetadata
+}
+
+// DefaultTokenRegistry 默认的代币注册表，GetERC20Balance 等需要代币精度的方法都会使用它
+var DefaultTokenRegistry = NewTokenRegistry()
+
+// NewTokenRegistry 创建空的代币注册表
+func NewTokenRegistry() *TokenRegistry {
+	return &TokenRegistry{tokens: make(map[tokenKey]TokenMetadata)}
+}
+
+// Register 登记（或覆盖）代币元数据
+func (r *TokenRegistry) Register(tokens ...TokenMetadata) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, token := range tokens {
+		r.tokens[tokenKey{token.ChainID, token.Address}] = token
+	}
+}
+
+// Get 获取已缓存的代币元数据（不查询链上）
+func (r *TokenRegistry) Get(chainID int64, address common.Address) (TokenMetadata, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	token, ok := r.tokens[tokenKey{chainID, address}]
+	return token, ok
+}
+
+// Len 返回已缓存的代币数量
+func (r *TokenRegistry) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.tokens)
+}
+
+// tokenList Uniswap 代币列表格式（https://tokenlists.org）
+type tokenList struct {
+	Name   string          `json:"name"`
+	Tokens []TokenMetadata `json:"tokens"`
+}
+
+// LoadTokenList 从 Uniswap 代币列表 JSON 预加载代币元数据（包含所有链的代币）
+// 参数说明：
+//   - data: 代币列表 JSON
+//
+// 返回：
+//   - int: 加载的代币数量
+//   - error: 如果 JSON 格式无效则返回错误
+func (r *TokenRegistry) LoadTokenList(data []byte) (int, error) {
+	var list tokenList
+	if err := json.Unmarshal(data, &list); err != nil {
+		return 0, fmt.Errorf("invalid token list: %w", err)
+	}
+	r.Register(list.Tokens...)
+	return len(list.Tokens), nil
+}
+
+// LoadTokenListFromURL 下载并预加载代币列表（如 https://tokens.uniswap.org）
+// 参数说明：
+//   - ctx: 上下文对象
+//   - url: 代币列表地址
+//
+// 返回：
+//   - int: 加载的代币数量
+//   - error: 如果下载或解析失败则返回错误
+func (r *TokenRegistry) LoadTokenListFromURL(ctx context.Context, url string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to download token list: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("token list returned status %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+	return r.LoadTokenList(data)
+}
+
+// Lookup 获取代币元数据：优先使用缓存，未命中时从链上查询 decimals/symbol/name 并缓存
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 代币所在链的以太坊提供者
+//   - token: 代币合约地址
+//
+// 返回：
+//   - TokenMetadata: 代币元数据
+//   - error: 如果查询链 ID 或 decimals 失败则返回错误（symbol、name 不是 ERC20 强制要求的方法，查询失败时留空）
+func (r *TokenRegistry) Lookup(ctx context.Context, ep EtherProvider, token common.Address) (TokenMetadata, error) {
+	chainId, err := ep.GetChainID(ctx)
+	if err != nil {
+		return TokenMetadata{}, err
+	}
+	if meta, ok := r.Get(chainId.Int64(), token); ok {
+		return meta, nil
+	}
+
+	caller, err := erc20.NewIERC20Caller(token, ep.GetEthClient())
+	if err != nil {
+		return TokenMetadata{}, err
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	decimals, err := caller.Decimals(opts)
+	if err != nil {
+		return TokenMetadata{}, fmt.Errorf("failed to query decimals for token %s: %w", token.Hex(), err)
+	}
+	symbol, _ := caller.Symbol(opts)
+	name, _ := caller.Name(opts)
+
+	meta := TokenMetadata{ChainID: chainId.Int64(), Address: token, Name: name, Symbol: symbol, Decimals: decimals}
+	r.Register(meta)
+	return meta, nil
+}
+
+// GetTokenMetadata 通过 DefaultTokenRegistry 获取代币元数据
+func GetTokenMetadata(ctx context.Context, ep EtherProvider, token common.Address) (TokenMetadata, error) {
+	return DefaultTokenRegistry.Lookup(ctx, ep, token)
+}
+
+// ToTokenDecimal 将代币数量（最小单位）按代币精度换算为十进制数值
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - token: 代币合约地址
+//   - amount: 代币数量（最小单位）
+//
+// 返回：
+//   - decimal.Decimal: 换算后的数值（如 1500000 USDC 最小单位 → 1.5）
+//   - error: 如果获取代币精度失败则返回错误
+func ToTokenDecimal(ctx context.Context, ep EtherProvider, token common.Address, amount *big.Int) (decimal.Decimal, error) {
+	meta, err := GetTokenMetadata(ctx, ep, token)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return ToDecimal(amount, int(meta.Decimals)), nil
+}
+
+// ToTokenUnits 将十进制数值按代币精度换算为最小单位
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - token: 代币合约地址
+//   - amount: 数值（如 1.5 USDC）
+//
+// 返回：
+//   - *big.Int: 最小单位数量
+//   - error: 如果获取代币精度失败则返回错误
+func ToTokenUnits(ctx context.Context, ep EtherProvider, token common.Address, amount decimal.Decimal) (*big.Int, error) {
+	meta, err := GetTokenMetadata(ctx, ep, token)
+	if err != nil {
+		return nil, err
+	}
+	return ToWei(amount, int(meta.Decimals)), nil
+}
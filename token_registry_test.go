@@ -0,0 +1,96 @@
+package etherkit
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/shopspring/decimal"
+)
+
+func TestTokenRegistry(t *testing.T) {
+	usdc := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	dai := common.HexToAddress("0x6B175474E89094C44Da98b954EedeAC495271d0F")
+
+	registry := NewTokenRegistry()
+	n, err := registry.LoadTokenList([]byte(`{
+		"name": "Test List",
+		"tokens": [
+			{"chainId": 1, "address": "0x6B175474E89094C44Da98b954EedeAC495271d0F", "name": "Dai Stablecoin", "symbol": "DAI", "decimals": 18},
+			{"chainId": 137, "address": "0x6B175474E89094C44Da98b954EedeAC495271d0F", "name": "Polygon Token", "symbol": "PTK", "decimals": 8}
+		]
+	}`))
+	if err != nil || n != 2 {
+		t.Fatalf("LoadTokenList() = %d, %v", n, err)
+	}
+	if _, err := registry.LoadTokenList([]byte("not json")); err == nil {
+		t.Error("Expected error for invalid token list")
+	}
+
+	// 同一地址在不同链上是不同的代币
+	if meta, ok := registry.Get(MainnetChainID, dai); !ok || meta.Symbol != "DAI" || meta.Decimals != 18 {
+		t.Errorf("Get(mainnet, dai) = %+v, %v", meta, ok)
+	}
+	if meta, ok := registry.Get(PolygonChainID, dai); !ok || meta.Decimals != 8 {
+		t.Errorf("Get(polygon, dai) = %+v, %v", meta, ok)
+	}
+
+	server := newMockRPCServer(t, map[string]mockRPCHandler{
+		"eth_chainId": mockResult("0x1"),
+		"eth_call":    mockERC20Call(big.NewInt(2500000), 6, "USDC"),
+	})
+	provider, err := NewProvider(server.URL)
+	if err != nil {
+		t.Fatalf("NewProvider() failed: %v", err)
+	}
+	defer provider.Close()
+
+	// 预加载的代币不查询链上
+	if meta, err := registry.Lookup(context.Background(), provider, dai); err != nil || meta.Symbol != "DAI" {
+		t.Errorf("Lookup(dai) = %+v, %v", meta, err)
+	}
+	if calls := server.callCount("eth_call"); calls != 0 {
+		t.Errorf("eth_call count = %d, expected 0 for preloaded token", calls)
+	}
+
+	// 未命中时查询链上并缓存
+	for i := 0; i < 2; i++ {
+		meta, err := registry.Lookup(context.Background(), provider, usdc)
+		if err != nil {
+			t.Fatalf("Lookup(usdc) failed: %v", err)
+		}
+		if meta.Symbol != "USDC" || meta.Decimals != 6 || meta.ChainID != MainnetChainID {
+			t.Errorf("Lookup(usdc) = %+v", meta)
+		}
+	}
+	if calls := server.callCount("eth_call"); calls != 3 {
+		t.Errorf("eth_call count = %d, expected 3 (decimals, symbol, name once)", calls)
+	}
+	if registry.Len() != 3 {
+		t.Errorf("Len() = %d, expected 3", registry.Len())
+	}
+}
+
+func TestTokenUnitConversion(t *testing.T) {
+	token := common.HexToAddress("0x00000000000000000000000000000000000a11ce")
+	DefaultTokenRegistry.Register(TokenMetadata{ChainID: MainnetChainID, Address: token, Symbol: "TKN", Decimals: 6})
+
+	server := newMockRPCServer(t, map[string]mockRPCHandler{
+		"eth_chainId": mockResult("0x1"),
+	})
+	provider, err := NewProvider(server.URL)
+	if err != nil {
+		t.Fatalf("NewProvider() failed: %v", err)
+	}
+	defer provider.Close()
+
+	amount, err := ToTokenDecimal(context.Background(), provider, token, big.NewInt(1500000))
+	if err != nil || amount.String() != "1.5" {
+		t.Errorf("ToTokenDecimal() = %s, %v, expected 1.5", amount, err)
+	}
+	units, err := ToTokenUnits(context.Background(), provider, token, decimal.RequireFromString("2.25"))
+	if err != nil || units.Int64() != 2250000 {
+		t.Errorf("ToTokenUnits() = %v, %v, expected 2250000", units, err)
+	}
+}
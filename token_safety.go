@@ -0,0 +1,207 @@
+package etherkit
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+//############ Token Transfer Safety ############
+
+// TokenTransferResult SafeTransfer 的执行结果
+type TokenTransferResult struct {
+	TxHash    common.Hash    // 交易哈希
+	Receipt   *types.Receipt // 交易收据
+	Requested *big.Int       // 请求转账的数量（最小单位）
+	Received  *big.Int       // 收款人实际收到的数量（最小单位）
+	Fee       *big.Int       // 转账过程中被扣除的数量（Requested - Received）
+}
+
+// HasTransferFee 判断代币是否在转账时扣除了手续费（fee-on-transfer 代币）
+func (r *TokenTransferResult) HasTransferFee() bool {
+	return r.Fee != nil && r.Fee.Sign() > 0
+}
+
+// checkTokenReturnData 按 OpenZeppelin SafeERC20 的规则检查代币调用的返回数据
+// 无返回值（如 USDT）视为成功，有返回值时必须是 ABI 编码的 true
+func checkTokenReturnData(ret []byte) error {
+	if len(ret) == 0 {
+		return nil
+	}
+	if len(ret) != 32 {
+		return fmt.Errorf("%w: unexpected %d bytes return data", ErrTokenCallFailed, len(ret))
+	}
+	if new(big.Int).SetBytes(ret).Cmp(common.Big1) != 0 {
+		return fmt.Errorf("%w: returned %s", ErrTokenCallFailed, common.BytesToHash(ret).Hex())
+	}
+	return nil
+}
+
+// simulateTokenCall 以 Kit 账户身份模拟代币调用并检查返回数据
+// 代币地址没有合约代码时，空返回数据不代表成功，因此先检查合约是否存在
+func (k *Kit) simulateTokenCall(ctx context.Context, token common.Address, data []byte) error {
+	isContract, err := k.IsContract(ctx, token)
+	if err != nil {
+		return err
+	}
+	if !isContract {
+		return fmt.Errorf("%w: %s has no code", ErrInvalidContractAddress, token.Hex())
+	}
+	from := k.GetAddress()
+	ret, err := k.CallContractAt(ctx, ethereum.CallMsg{From: from, To: &token, Data: data}, BlockRef{})
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrContractCall, err)
+	}
+	return checkTokenReturnData(ret)
+}
+
+// erc20BalanceAt 查询代币在指定区块的余额
+func erc20BalanceAt(ctx context.Context, ep EtherProvider, token, owner common.Address, block BlockRef) (*big.Int, error) {
+	data, err := erc20ABI.Pack("balanceOf", owner)
+	if err != nil {
+		return nil, err
+	}
+	ret, err := providerExt[BlockRefReader](ep).CallContractAt(ctx, ethereum.CallMsg{To: &token, Data: data}, block)
+	if err != nil {
+		return nil, err
+	}
+	out, err := erc20ABI.Unpack("balanceOf", ret)
+	if err != nil {
+		return nil, err
+	}
+	return out[0].(*big.Int), nil
+}
+
+// receivedAmount 计算收款人在交易中实际收到的代币数量
+// 优先汇总收据中 from → to 的 Transfer 事件；没有匹配的事件时，比较收款人在交易所在区块前后的余额
+func receivedAmount(ctx context.Context, ep EtherProvider, token, from, to common.Address, receipt *types.Receipt) (*big.Int, error) {
+	transferTopic := erc20ABI.Events["Transfer"].ID
+	received := new(big.Int)
+	matched := false
+	for _, log := range receipt.Logs {
+		if log.Address != token || len(log.Topics) != 3 || log.Topics[0] != transferTopic {
+			continue
+		}
+		if common.BytesToAddress(log.Topics[1].Bytes()) != from || common.BytesToAddress(log.Topics[2].Bytes()) != to {
+			continue
+		}
+		received.Add(received, new(big.Int).SetBytes(log.Data))
+		matched = true
+	}
+	if matched {
+		return received, nil
+	}
+
+	block := receipt.BlockNumber.Uint64()
+	after, err := erc20BalanceAt(ctx, ep, token, to, BlockAtNumber(block))
+	if err != nil {
+		return nil, err
+	}
+	before, err := erc20BalanceAt(ctx, ep, token, to, BlockAtNumber(block-1))
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).Sub(after, before), nil
+}
+
+// SafeTransfer 安全地转账 ERC20 代币，兼容不返回 bool 的非标准代币（如 USDT）
+// 发送前模拟调用并检查返回数据，交易确认后根据 Transfer 事件（或余额变化）核对收款人实际到账数量，
+// 到账少于请求数量时通过 TokenTransferResult.Fee 反映（fee-on-transfer 代币）
+// 参数说明：
+//   - ctx: 上下文对象
+//   - token: 代币合约地址
+//   - to: 收款人地址
+//   - amount: 转账数量（最小单位）
+//   - timeout: 等待交易确认的超时时间
+//
+// 返回：
+//   - *TokenTransferResult: 转账结果（包含实际到账数量）
+//   - error: 如果模拟调用失败、代币返回 false（ErrTokenCallFailed）、交易失败（ErrReverted）或等待超时则返回错误
+func (k *Kit) SafeTransfer(ctx context.Context, token, to common.Address, amount *big.Int, timeout time.Duration) (*TokenTransferResult, error) {
+	data, err := erc20ABI.Pack("transfer", to, amount)
+	if err != nil {
+		return nil, err
+	}
+	if err := k.simulateTokenCall(ctx, token, data); err != nil {
+		return nil, err
+	}
+	hash, err := k.sendTx(ctx, token, 0, 0, nil, nil, data, &erc20ABI)
+	if err != nil {
+		return nil, err
+	}
+	receipt, err := k.WaitForReceipt(ctx, hash, timeout)
+	if err != nil {
+		return nil, err
+	}
+	if err := CheckReceiptStatus(receipt); err != nil {
+		return nil, fmt.Errorf("token transfer: %w", err)
+	}
+
+	received, err := receivedAmount(ctx, k.EtherProvider, token, k.GetAddress(), to, receipt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify received amount: %w", err)
+	}
+	return &TokenTransferResult{
+		TxHash:    hash,
+		Receipt:   receipt,
+		Requested: amount,
+		Received:  received,
+		Fee:       new(big.Int).Sub(amount, received),
+	}, nil
+}
+
+// SafeApprove 安全地设置 ERC20 授权额度，兼容不返回 bool 的代币以及要求先清零再授权的代币（如 USDT）
+// 当前额度不为零且新额度不为零时，先发送 approve(0) 并等待确认，再发送新的授权；
+// 当前额度已等于目标额度时不发送交易，返回空哈希
+// 参数说明：
+//   - ctx: 上下文对象
+//   - token: 代币合约地址
+//   - spender: 被授权地址
+//   - amount: 授权额度（最小单位）
+//   - timeout: 等待清零交易确认的超时时间
+//
+// 返回：
+//   - common.Hash: 最终授权交易的哈希
+//   - error: 如果模拟调用失败、代币返回 false（ErrTokenCallFailed）或发送失败则返回错误
+func (k *Kit) SafeApprove(ctx context.Context, token, spender common.Address, amount *big.Int, timeout time.Duration) (common.Hash, error) {
+	out, err := k.StaticCall(ctx, token, erc20ABI, "allowance", nil, nil, nil, k.GetAddress(), spender)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	current := out[0].(*big.Int)
+	if current.Cmp(amount) == 0 {
+		return common.Hash{}, nil
+	}
+
+	if current.Sign() > 0 && amount.Sign() > 0 {
+		hash, err := k.approve(ctx, token, spender, common.Big0)
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("failed to reset allowance: %w", err)
+		}
+		receipt, err := k.WaitForReceipt(ctx, hash, timeout)
+		if err != nil {
+			return common.Hash{}, err
+		}
+		if err := CheckReceiptStatus(receipt); err != nil {
+			return common.Hash{}, fmt.Errorf("allowance reset: %w", err)
+		}
+	}
+	return k.approve(ctx, token, spender, amount)
+}
+
+// approve 模拟并发送 approve 交易
+func (k *Kit) approve(ctx context.Context, token, spender common.Address, amount *big.Int) (common.Hash, error) {
+	data, err := erc20ABI.Pack("approve", spender, amount)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if err := k.simulateTokenCall(ctx, token, data); err != nil {
+		return common.Hash{}, err
+	}
+	return k.sendTx(ctx, token, 0, 0, nil, nil, data, &erc20ABI)
+}
@@ -0,0 +1,145 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestCheckTokenReturnData(t *testing.T) {
+	tests := []struct {
+		name    string
+		ret     []byte
+		wantErr bool
+	}{
+		{"no return data", nil, false},
+		{"true", common.LeftPadBytes([]byte{1}, 32), false},
+		{"false", make([]byte, 32), true},
+		{"malformed length", []byte{1}, true},
+		{"non boolean word", common.LeftPadBytes([]byte{2}, 32), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkTokenReturnData(tt.ret)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkTokenReturnData() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrTokenCallFailed) {
+				t.Errorf("error %v should wrap ErrTokenCallFailed", err)
+			}
+		})
+	}
+}
+
+// newMockTokenServer 模拟代币合约：transfer/approve 返回 writeResult，allowance 返回 allowance
+func newMockTokenServer(t *testing.T, writeResult []byte, allowance *big.Int, receiptLogs []*types.Log) *mockRPCServer {
+	t.Helper()
+	if receiptLogs == nil {
+		receiptLogs = []*types.Log{}
+	}
+	server := newMockSendServer(t)
+	server.handlers["eth_getCode"] = mockResult("0x6080")
+	server.handlers["eth_call"] = func(params []json.RawMessage) (interface{}, error) {
+		arg, err := parseMockCallArg(params)
+		if err != nil {
+			return nil, err
+		}
+		method, err := erc20ABI.MethodById(arg.calldata()[:4])
+		if err != nil {
+			return nil, err
+		}
+		switch method.Name {
+		case "transfer", "approve":
+			return hexutil.Bytes(writeResult), nil
+		case "allowance":
+			return packOutputs(method.Outputs, allowance)
+		}
+		return nil, errors.New("unsupported method " + method.Name)
+	}
+	server.handlers["eth_getTransactionReceipt"] = func(params []json.RawMessage) (interface{}, error) {
+		var hash common.Hash
+		if err := json.Unmarshal(params[0], &hash); err != nil {
+			return nil, err
+		}
+		return &types.Receipt{
+			Status:      types.ReceiptStatusSuccessful,
+			TxHash:      hash,
+			BlockNumber: big.NewInt(100),
+			Logs:        receiptLogs,
+		}, nil
+	}
+	return server
+}
+
+func TestSafeTransfer(t *testing.T) {
+	token := common.HexToAddress("0xdAC17F958D2ee523a2206206994597C13D831ec7")
+	recipient := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	sender := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266") // 测试私钥对应的地址
+
+	t.Run("fee on transfer token without return value", func(t *testing.T) {
+		// 请求转账 1000，Transfer 事件显示收款人只收到 990
+		logs := []*types.Log{{
+			Address: token,
+			Topics:  []common.Hash{erc20ABI.Events["Transfer"].ID, common.BytesToHash(sender.Bytes()), common.BytesToHash(recipient.Bytes())},
+			Data:    common.LeftPadBytes(big.NewInt(990).Bytes(), 32),
+		}}
+		kit := newMockKit(t, newMockTokenServer(t, nil, nil, logs))
+
+		result, err := kit.SafeTransfer(context.Background(), token, recipient, big.NewInt(1000), 5*time.Second)
+		if err != nil {
+			t.Fatalf("SafeTransfer() failed: %v", err)
+		}
+		if result.Received.Int64() != 990 || result.Fee.Int64() != 10 || !result.HasTransferFee() {
+			t.Errorf("Received = %s, Fee = %s, want 990 and 10", result.Received, result.Fee)
+		}
+	})
+
+	t.Run("token returns false", func(t *testing.T) {
+		server := newMockTokenServer(t, make([]byte, 32), nil, nil)
+		kit := newMockKit(t, server)
+
+		_, err := kit.SafeTransfer(context.Background(), token, recipient, big.NewInt(1000), 5*time.Second)
+		if !errors.Is(err, ErrTokenCallFailed) {
+			t.Fatalf("SafeTransfer() error = %v, want ErrTokenCallFailed", err)
+		}
+		if n := server.callCount("eth_sendRawTransaction"); n != 0 {
+			t.Errorf("sent %d transactions, want 0", n)
+		}
+	})
+}
+
+func TestSafeApprove(t *testing.T) {
+	token := common.HexToAddress("0xdAC17F958D2ee523a2206206994597C13D831ec7")
+	spender := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+
+	tests := []struct {
+		name      string
+		allowance int64
+		amount    int64
+		wantSends int
+	}{
+		{"resets non-zero allowance first", 50, 100, 2},
+		{"fresh approval", 0, 100, 1},
+		{"unchanged allowance", 100, 100, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newMockTokenServer(t, nil, big.NewInt(tt.allowance), nil)
+			kit := newMockKit(t, server)
+
+			if _, err := kit.SafeApprove(context.Background(), token, spender, big.NewInt(tt.amount), 5*time.Second); err != nil {
+				t.Fatalf("SafeApprove() failed: %v", err)
+			}
+			if n := server.callCount("eth_sendRawTransaction"); n != tt.wantSends {
+				t.Errorf("sent %d transactions, want %d", n, tt.wantSends)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package etherkit
+
+import (
+	"context"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/shopspring/decimal"
+)
+
+//############ Token Transfer History ############
+
+// TokenTransferEntry 地址相关的一条 ERC20 转账记录
+type TokenTransferEntry struct {
+	TokenTransfer
+	Symbol   string          // 代币符号（代币元数据不可查询时为空）
+	Decimals uint8           // 代币精度（代币元数据不可查询时为 0）
+	Amount   decimal.Decimal // 按代币精度换算后的数量
+	Incoming bool            // 是否为转入（向自己转账时同时是转出）
+}
+
+// GetTokenTransfers 扫描地址作为转出方或转入方的 ERC20 Transfer 事件
+// 使用 LogFetcher 分段查询（节点限制查询范围时自动拆分），结果按区块和日志顺序排列；
+// 代币精度通过 DefaultTokenRegistry 获取，不可查询的代币（如不规范的合约）按精度 0 处理
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - tokens: 代币地址（nil 表示所有合约）
+//   - address: 账户地址
+//   - from: 起始区块号（包含）
+//   - to: 结束区块号（包含）
+//
+// 返回：
+//   - []TokenTransferEntry: 转账记录
+//   - error: 如果查询日志失败则返回错误
+func GetTokenTransfers(ctx context.Context, ep EtherProvider, tokens []common.Address, address common.Address, from, to uint64) ([]TokenTransferEntry, error) {
+	fetcher := NewLogFetcher(ep)
+	transferEvent := erc20ABI.Events["Transfer"]
+
+	// 分别查询转出（topic 1）和转入（topic 2）
+	var logs []types.Log
+	for position := 1; position <= 2; position++ {
+		query, err := NewLogQuery().Addresses(tokens...).Event(transferEvent).AddressAt(position, address).Build()
+		if err != nil {
+			return nil, err
+		}
+		result, err := fetcher.FetchLogs(ctx, query, from, to)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, result...)
+	}
+	sort.SliceStable(logs, func(i, j int) bool {
+		if logs[i].BlockNumber != logs[j].BlockNumber {
+			return logs[i].BlockNumber < logs[j].BlockNumber
+		}
+		return logs[i].Index < logs[j].Index
+	})
+
+	type logKey struct {
+		txHash common.Hash
+		index  uint
+	}
+	seen := make(map[logKey]bool, len(logs))
+	metas := make(map[common.Address]TokenMetadata)
+	var entries []TokenTransferEntry
+	for _, log := range logs {
+		// 向自己转账的日志会被两次查询同时返回
+		key := logKey{log.TxHash, log.Index}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		transfer, ok := decodeTokenTransfer(log)
+		if !ok {
+			continue
+		}
+		meta, ok := metas[transfer.Token]
+		if !ok {
+			meta, _ = GetTokenMetadata(ctx, ep, transfer.Token)
+			metas[transfer.Token] = meta
+		}
+		entries = append(entries, TokenTransferEntry{
+			TokenTransfer: transfer,
+			Symbol:        meta.Symbol,
+			Decimals:      meta.Decimals,
+			Amount:        decimal.NewFromBigInt(transfer.Value, -int32(meta.Decimals)),
+			Incoming:      transfer.To == address,
+		})
+	}
+	return entries, nil
+}
+
+// WithTrackedTokens 设置 Kit 关注的代币（用于 GetTokenTransfers，未设置时扫描所有合约）
+func WithTrackedTokens(tokens ...common.Address) KitOption {
+	return func(k *Kit) {
+		k.trackedTokens = append(k.trackedTokens, tokens...)
+	}
+}
+
+// GetTokenTransfers 扫描地址在 Kit 关注的代币中的转账记录
+// 参数说明：
+//   - ctx: 上下文对象
+//   - address: 账户地址
+//   - from: 起始区块号（包含）
+//   - to: 结束区块号（包含）
+//
+// 返回：
+//   - []TokenTransferEntry: 转账记录
+//   - error: 如果查询日志失败则返回错误
+func (k *Kit) GetTokenTransfers(ctx context.Context, address common.Address, from, to uint64) ([]TokenTransferEntry, error) {
+	return GetTokenTransfers(ctx, k.EtherProvider, k.trackedTokens, address, from, to)
+}
@@ -0,0 +1,92 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestGetTokenTransfers(t *testing.T) {
+	token := common.HexToAddress("0x3c499c542cEF5E3811e1192ce70d8cC03d5c3359")
+	alice := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	bob := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	transferTopic := erc20ABI.Events["Transfer"].ID
+	transferLog := func(block uint64, index uint, from, to common.Address, value int64) types.Log {
+		return types.Log{
+			Address:     token,
+			Topics:      []common.Hash{transferTopic, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())},
+			Data:        common.BigToHash(big.NewInt(value)).Bytes(),
+			BlockNumber: block,
+			TxHash:      common.BigToHash(big.NewInt(int64(block))),
+			Index:       index,
+		}
+	}
+	nft := transferLog(9, 0, alice, bob, 0)
+	nft.Topics = append(nft.Topics, common.BigToHash(big.NewInt(1)))
+	chain := []types.Log{
+		nft, // ERC721 Transfer，应被跳过
+		transferLog(10, 0, alice, bob, 1500000),
+		transferLog(11, 0, alice, alice, 1000000), // 向自己转账
+		transferLog(12, 1, bob, alice, 2000000),
+		transferLog(13, 0, bob, bob, 7),
+	}
+
+	var addresses []common.Address
+	server := newMockSendServer(t)
+	server.handlers["eth_call"] = mockERC20Call(big.NewInt(0), 6, "USDC")
+	server.handlers["eth_getLogs"] = func(params []json.RawMessage) (interface{}, error) {
+		var filter struct {
+			Address []common.Address `json:"address"`
+			Topics  [][]common.Hash  `json:"topics"`
+		}
+		if err := json.Unmarshal(params[0], &filter); err != nil {
+			return nil, err
+		}
+		addresses = filter.Address
+		logs := []types.Log{}
+		for _, log := range chain {
+			match := true
+			for i, values := range filter.Topics {
+				if len(values) > 0 && (i >= len(log.Topics) || log.Topics[i] != values[0]) {
+					match = false
+				}
+			}
+			if match {
+				logs = append(logs, log)
+			}
+		}
+		return logs, nil
+	}
+	kit := newMockKit(t, server, WithTrackedTokens(token))
+
+	entries, err := kit.GetTokenTransfers(context.Background(), alice, 1, 100)
+	if err != nil {
+		t.Fatalf("GetTokenTransfers() failed: %v", err)
+	}
+	if len(addresses) != 1 || addresses[0] != token {
+		t.Errorf("eth_getLogs address = %v, expected tracked token", addresses)
+	}
+
+	want := []struct {
+		block    uint64
+		amount   string
+		incoming bool
+	}{
+		{10, "1.5", false},
+		{11, "1", true},
+		{12, "2", true},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("len(entries) = %d, expected %d", len(entries), len(want))
+	}
+	for i, w := range want {
+		entry := entries[i]
+		if entry.Log.BlockNumber != w.block || entry.Amount.String() != w.amount || entry.Incoming != w.incoming || entry.Symbol != "USDC" {
+			t.Errorf("entries[%d] = block %d amount %s incoming %v symbol %q, expected %+v", i, entry.Log.BlockNumber, entry.Amount, entry.Incoming, entry.Symbol, w)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package etherkit
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+//############ Indexed Topic Values ############
+
+// TopicHash 将事件 indexed 参数的值转换为日志中对应的 topic，用于按参数值过滤日志
+// 规则：值类型（address、bool、uintN/intN、bytesN）按标准 ABI 编码为 32 字节；
+// string、bytes 和数组在日志中只保存哈希，topic 为 keccak256(值的编码)
+// 未用 Packed 指定类型时按 Go 类型推断（规则同 EncodePacked），如 *big.Int → uint256、string → string、[]byte → bytes
+// 参数说明：
+//   - value: indexed 参数的值（可用 Packed("uint8", uint8(1)) 等指定 Solidity 类型）
+//
+// 返回：
+//   - common.Hash: topic 值
+//   - error: 如果值的类型不受支持（如结构体）或与指定的 Solidity 类型不匹配则返回错误
+//
+// 示例：
+//   - TopicHash(common.HexToAddress("0x...")) // 地址左侧补零
+//   - TopicHash("alice.eth")                  // keccak256("alice.eth")
+//   - TopicHash(Packed("bytes4", [4]byte{0x12, 0x34, 0x56, 0x78}))
+func TopicHash(value interface{}) (common.Hash, error) {
+	typed, ok := value.(PackedValue)
+	if !ok {
+		var err error
+		if typed, err = inferPackedValue(value); err != nil {
+			return common.Hash{}, err
+		}
+	}
+	typ, err := abi.NewType(typed.Type, "", nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	switch typ.T {
+	case abi.TupleTy:
+		return common.Hash{}, fmt.Errorf("indexed structs are not supported")
+	case abi.StringTy, abi.BytesTy, abi.SliceTy, abi.ArrayTy:
+		// 动态类型和数组的 topic 为编码后内容的哈希（数组元素补齐到 32 字节，与 encodePacked 规则相同）
+		encoded, err := encodePackedValue(typed)
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("%s: %w", typed.Type, err)
+		}
+		return crypto.Keccak256Hash(encoded), nil
+	}
+	encoded, err := abi.Arguments{{Type: typ}}.Pack(typed.Value)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("%s: %w", typed.Type, err)
+	}
+	return common.BytesToHash(encoded), nil
+}
+
+// TopicHashes 将多个值依次转换为 topic（同一位置的候选值，可直接传给 LogQuery.TopicAt 或 FilterLogsMulti）
+// 参数说明：
+//   - values: indexed 参数的候选值（规则同 TopicHash）
+//
+// 返回：
+//   - []common.Hash: topic 值
+//   - error: 如果任一值无法转换则返回错误
+func TopicHashes(values ...interface{}) ([]common.Hash, error) {
+	hashes := make([]common.Hash, len(values))
+	for i, value := range values {
+		hash, err := TopicHash(value)
+		if err != nil {
+			return nil, fmt.Errorf("value %d: %w", i, err)
+		}
+		hashes[i] = hash
+	}
+	return hashes, nil
+}
+
+// ValueAt 追加第 i 个 topic 的候选值，值按 TopicHash 的规则转换（如字符串参数自动计算哈希）
+// 参数说明：
+//   - i: topic 位置（1~3 依次对应事件的 indexed 参数）
+//   - values: 候选值
+func (q *LogQuery) ValueAt(i int, values ...interface{}) *LogQuery {
+	hashes, err := TopicHashes(values...)
+	if err != nil {
+		return q.fail(fmt.Errorf("topic %d: %w", i, err))
+	}
+	return q.TopicAt(i, hashes...)
+}
@@ -0,0 +1,69 @@
+package etherkit
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestTopicHash(t *testing.T) {
+	addr := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	word := func(b ...byte) []byte { return common.LeftPadBytes(b, 32) }
+
+	tests := []struct {
+		name    string
+		value   interface{}
+		want    common.Hash
+		wantErr bool
+	}{
+		{"address", addr, common.BytesToHash(addr.Bytes()), false},
+		{"uint256", big.NewInt(1000), common.BigToHash(big.NewInt(1000)), false},
+		{"negative int256", big.NewInt(-1), common.HexToHash("0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"), false},
+		{"uint8", uint8(7), common.BigToHash(big.NewInt(7)), false},
+		{"bool", true, common.BigToHash(big.NewInt(1)), false},
+		{"string", "hello", crypto.Keccak256Hash([]byte("hello")), false},
+		{"bytes", []byte{0x01, 0x02}, crypto.Keccak256Hash([]byte{0x01, 0x02}), false},
+		{"hash", common.HexToHash("0xabcd"), common.HexToHash("0xabcd"), false},
+		{"bytes4 left aligned", Packed("bytes4", [4]byte{0x12, 0x34, 0x56, 0x78}), common.HexToHash("0x1234567800000000000000000000000000000000000000000000000000000000"), false},
+		{"uint256 array", []*big.Int{big.NewInt(1), big.NewInt(2)}, crypto.Keccak256Hash(word(1), word(2)), false},
+		{"type mismatch", Packed("uint8", "1"), common.Hash{}, true},
+		{"struct", Packed("(uint256,bool)", nil), common.Hash{}, true},
+		{"unsupported go type", struct{}{}, common.Hash{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TopicHash(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TopicHash() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("TopicHash() = %s, expected %s", got.Hex(), tt.want.Hex())
+			}
+		})
+	}
+}
+
+func TestLogQueryValueAt(t *testing.T) {
+	addr := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	query, err := NewLogQuery().
+		Event("NameRegistered(string,address)").
+		ValueAt(1, "alice", "bob").
+		ValueAt(2, addr).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() failed: %v", err)
+	}
+	if len(query.Topics) != 3 || len(query.Topics[1]) != 2 {
+		t.Fatalf("Build() topics = %v, expected 3 positions with 2 name candidates", query.Topics)
+	}
+	if query.Topics[1][1] != crypto.Keccak256Hash([]byte("bob")) || query.Topics[2][0] != common.BytesToHash(addr.Bytes()) {
+		t.Errorf("Build() topics = %v", query.Topics)
+	}
+
+	if _, err := NewLogQuery().ValueAt(1, struct{}{}).Build(); err == nil {
+		t.Error("Build() expected error for unsupported value")
+	}
+}
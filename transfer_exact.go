@@ -0,0 +1,74 @@
+package etherkit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/shopspring/decimal"
+)
+
+//############ Exact Transfer ############
+
+// ToWeiExact 将十进制数值精确换算为最小单位，不做任何舍入
+// 与 ToWei 不同，小数位数超过 decimals 时返回错误而不是截断
+// 参数说明：
+//   - amount: 十进制数值
+//   - decimals: 小数位数（如以太币为 18，USDT 为 6）
+//
+// 返回：
+//   - *big.Int: 最小单位数量
+//   - error: 如果数值的精度超过 decimals 则返回错误
+//
+// 示例：
+//   - ToWeiExact(decimal.RequireFromString("0.123456789012345678"), 18) // 123456789012345678
+//   - ToWeiExact(decimal.RequireFromString("0.0000001"), 6)             // 返回错误
+func ToWeiExact(amount decimal.Decimal, decimals int) (*big.Int, error) {
+	shifted := amount.Shift(int32(decimals))
+	if !shifted.IsInteger() {
+		return nil, fmt.Errorf("amount %s has more than %d decimal places", amount.String(), decimals)
+	}
+	return shifted.BigInt(), nil
+}
+
+// TransferEtherExact 按十进制字符串转账以太币，金额不经过浮点数转换
+// 参数说明：
+//   - ctx: 上下文对象
+//   - to: 接收地址
+//   - amount: 转账金额（以 ETH 为单位的十进制字符串，如 "0.123456789012345678"）
+//
+// 返回：
+//   - common.Hash: 交易哈希
+//   - error: 如果金额格式无效、精度超过 18 位小数或转账失败则返回错误
+func (k *Kit) TransferEtherExact(ctx context.Context, to common.Address, amount string) (common.Hash, error) {
+	value, err := decimal.NewFromString(amount)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("invalid transfer amount %q: %w", amount, err)
+	}
+	return k.TransferEtherDecimal(ctx, to, value)
+}
+
+// TransferEtherDecimal 按 decimal.Decimal 转账以太币，金额不会被舍入
+// 参数说明：
+//   - ctx: 上下文对象
+//   - to: 接收地址
+//   - amount: 转账金额（以 ETH 为单位）
+//
+// 返回：
+//   - common.Hash: 交易哈希
+//   - error: 如果金额为负数、精度超过 18 位小数或转账失败则返回错误
+func (k *Kit) TransferEtherDecimal(ctx context.Context, to common.Address, amount decimal.Decimal) (common.Hash, error) {
+	if !IsValidAddress(to) {
+		return common.Hash{}, errors.New("invalid receiver address")
+	}
+	if amount.IsNegative() {
+		return common.Hash{}, errors.New("transfer amount cannot be negative")
+	}
+	value, err := ToWeiExact(amount, EthDecimals)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return k.SendTx(ctx, to, 0, 0, nil, value, nil)
+}
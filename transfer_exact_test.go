@@ -0,0 +1,128 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/shopspring/decimal"
+)
+
+func TestToWeiExact(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   string
+		decimals int
+		want     string
+		wantErr  bool
+	}{
+		{"full precision", "0.123456789012345678", 18, "123456789012345678", false},
+		{"integer", "3", 18, "3000000000000000000", false},
+		{"trailing zeros", "1.500000", 6, "1500000", false},
+		{"too precise", "0.0000001", 6, "", true},
+		{"too precise ether", "0.1234567890123456789", 18, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ToWeiExact(decimal.RequireFromString(tt.amount), tt.decimals)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ToWeiExact() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got.String() != tt.want {
+				t.Errorf("ToWeiExact() = %s, expected %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransferEtherExact(t *testing.T) {
+	recipient := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	var sent *types.Transaction
+	server := newMockSendServer(t)
+	server.handlers["eth_sendRawTransaction"] = func(params []json.RawMessage) (interface{}, error) {
+		var raw hexutil.Bytes
+		if err := json.Unmarshal(params[0], &raw); err != nil {
+			return nil, err
+		}
+		sent = new(types.Transaction)
+		if err := sent.UnmarshalBinary(raw); err != nil {
+			return nil, err
+		}
+		return sent.Hash(), nil
+	}
+	kit := newMockKit(t, server)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		send    func() error
+		want    *big.Int
+		wantErr bool
+	}{
+		{
+			name: "string",
+			send: func() error {
+				_, err := kit.TransferEtherExact(ctx, recipient, "0.123456789012345678")
+				return err
+			},
+			want: big.NewInt(123456789012345678),
+		},
+		{
+			// 0.1 + 0.2 使用浮点数时为 0.30000000000000004
+			name: "decimal sum",
+			send: func() error {
+				_, err := kit.TransferEtherDecimal(ctx, recipient, decimal.RequireFromString("0.1").Add(decimal.RequireFromString("0.2")))
+				return err
+			},
+			want: big.NewInt(300000000000000000),
+		},
+		{
+			name: "invalid string",
+			send: func() error {
+				_, err := kit.TransferEtherExact(ctx, recipient, "0.1.2")
+				return err
+			},
+			wantErr: true,
+		},
+		{
+			name: "too precise",
+			send: func() error {
+				_, err := kit.TransferEtherExact(ctx, recipient, "0.0000000000000000001")
+				return err
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative",
+			send: func() error {
+				_, err := kit.TransferEtherDecimal(ctx, recipient, decimal.NewFromInt(-1))
+				return err
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sent = nil
+			err := tt.send()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if sent != nil {
+					t.Error("transaction should not be sent")
+				}
+				return
+			}
+			if sent == nil || sent.Value().Cmp(tt.want) != 0 {
+				t.Errorf("sent value = %v, expected %s", sent, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package etherkit
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"time"
+)
+
+//############ HTTP Transport ############
+
+// TransportConfig HTTP(S) 节点连接池和传输层参数
+type TransportConfig struct {
+	MaxIdleConns        int           // 所有节点的最大空闲连接数（0 表示不限制）
+	MaxIdleConnsPerHost int           // 每个节点的最大空闲连接数（标准库默认只有 2，高并发时会频繁新建连接）
+	MaxConnsPerHost     int           // 每个节点的最大连接数（0 表示不限制）
+	IdleConnTimeout     time.Duration // 空闲连接保留时间（0 表示不限制）
+	KeepAlive           time.Duration // TCP keep-alive 探测间隔（0 使用系统默认，负数表示禁用）
+	DialTimeout         time.Duration // 建立 TCP 连接的超时时间（0 表示不限制）
+	TLSHandshakeTimeout time.Duration // TLS 握手超时时间（0 表示不限制）
+	DisableHTTP2        bool          // 禁用 HTTP/2（默认对 HTTPS 节点尝试 HTTP/2）
+}
+
+// DefaultTransportConfig 面向高 QPS 服务的推荐传输层参数
+var DefaultTransportConfig = TransportConfig{
+	MaxIdleConns:        256,
+	MaxIdleConnsPerHost: 64,
+	IdleConnTimeout:     90 * time.Second,
+	KeepAlive:           30 * time.Second,
+	DialTimeout:         10 * time.Second,
+	TLSHandshakeTimeout: 10 * time.Second,
+}
+
+// NewTransport 按配置创建 HTTP 传输层
+// 返回的传输层可以通过 WithTransport 在多个 Provider 之间共享，复用同一个连接池
+// 参数说明：
+//   - cfg: 传输层参数
+//
+// 返回：
+//   - *http.Transport: HTTP 传输层
+//
+// 使用示例：
+//
+//	transport := NewTransport(DefaultTransportConfig)
+//	mainnet, err := NewProvider(mainnetUrl, WithTransport(transport))
+//	arbitrum, err := NewProvider(arbitrumUrl, WithTransport(transport))
+func NewTransport(cfg TransportConfig) *http.Transport {
+	dialer := &net.Dialer{Timeout: cfg.DialTimeout, KeepAlive: cfg.KeepAlive}
+	transport := &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     !cfg.DisableHTTP2,
+		MaxIdleConns:          cfg.MaxIdleConns,
+		MaxIdleConnsPerHost:   cfg.MaxIdleConnsPerHost,
+		MaxConnsPerHost:       cfg.MaxConnsPerHost,
+		IdleConnTimeout:       cfg.IdleConnTimeout,
+		TLSHandshakeTimeout:   cfg.TLSHandshakeTimeout,
+		ExpectContinueTimeout: time.Second,
+	}
+	if cfg.DisableHTTP2 {
+		// 非 nil 的空映射会阻止标准库自动启用 HTTP/2
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+	return transport
+}
+
+// WithTransport 使用指定的 HTTP 传输层连接节点（仅对 HTTP(S) 节点生效，同时用于读节点和广播节点）
+// 多个 Provider 传入同一个传输层时共享连接池
+func WithTransport(transport http.RoundTripper) ProviderOption {
+	return func(c *providerConfig) {
+		c.transport = transport
+	}
+}
+
+// WithTransportConfig 按配置为该 Provider 创建独立的 HTTP 传输层（仅对 HTTP(S) 节点生效）
+func WithTransportConfig(cfg TransportConfig) ProviderOption {
+	return func(c *providerConfig) {
+		c.transport = NewTransport(cfg)
+	}
+}
+
+// WithCallTimeout 设置单次 HTTP 请求的超时时间（包含重试，仅对 HTTP(S) 节点生效）
+// 调用方的 context 没有设置截止时间时，可以避免请求无限期挂起
+func WithCallTimeout(timeout time.Duration) ProviderOption {
+	return func(c *providerConfig) {
+		c.callTimeout = timeout
+	}
+}
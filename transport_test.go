@@ -0,0 +1,108 @@
+package etherkit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingTransport 记录经过的请求数
+type countingTransport struct {
+	base  http.RoundTripper
+	count atomic.Int32
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.count.Add(1)
+	return t.base.RoundTrip(req)
+}
+
+func TestWithTransport(t *testing.T) {
+	transport := &countingTransport{base: NewTransport(DefaultTransportConfig)}
+	for _, server := range []*mockRPCServer{newMockSendServer(t), newMockSendServer(t)} {
+		provider, err := NewProvider(server.URL, WithTransport(transport))
+		if err != nil {
+			t.Fatalf("NewProvider() failed: %v", err)
+		}
+		if _, err := provider.GetSuggestGasPrice(context.Background()); err != nil {
+			t.Fatalf("GetSuggestGasPrice() failed: %v", err)
+		}
+		provider.Close()
+	}
+	// 两个 Provider 的请求都经过共享的传输层
+	if n := transport.count.Load(); n != 2 {
+		t.Errorf("shared transport saw %d requests, expected 2", n)
+	}
+}
+
+func TestWithCallTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(time.Second):
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+
+	provider, err := NewProvider(server.URL, WithCallTimeout(50*time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewProvider() failed: %v", err)
+	}
+	defer provider.Close()
+
+	start := time.Now()
+	if _, err := provider.GetBlockNumber(context.Background()); err == nil {
+		t.Fatal("GetBlockNumber() expected timeout error")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("call took %s, expected to time out after 50ms", elapsed)
+	}
+}
+
+func TestNewTransport(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       TransportConfig
+		wantHTTP2 bool
+	}{
+		{name: "default", cfg: DefaultTransportConfig, wantHTTP2: true},
+		{name: "http2 disabled", cfg: TransportConfig{MaxIdleConnsPerHost: 8, DisableHTTP2: true}, wantHTTP2: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := NewTransport(tt.cfg)
+			if transport.MaxIdleConnsPerHost != tt.cfg.MaxIdleConnsPerHost || transport.IdleConnTimeout != tt.cfg.IdleConnTimeout {
+				t.Errorf("transport pool settings do not match config")
+			}
+			if transport.ForceAttemptHTTP2 != tt.wantHTTP2 {
+				t.Errorf("ForceAttemptHTTP2 = %v, expected %v", transport.ForceAttemptHTTP2, tt.wantHTTP2)
+			}
+			if disabled := transport.TLSNextProto != nil; disabled == tt.wantHTTP2 {
+				t.Errorf("TLSNextProto disabled = %v, expected %v", disabled, !tt.wantHTTP2)
+			}
+		})
+	}
+}
+
+func TestWithTransportRetry(t *testing.T) {
+	// 自定义传输层与重试策略叠加使用
+	m := newMockSendServer(t)
+	flaky := newFlakyServer(t, m, 1)
+	transport := &countingTransport{base: http.DefaultTransport}
+	provider, err := NewProvider(flaky.URL, WithTransport(transport), WithReadRetry(RetryPolicy{MaxAttempts: 2, Backoff: time.Millisecond}))
+	if err != nil {
+		t.Fatalf("NewProvider() failed: %v", err)
+	}
+	defer provider.Close()
+
+	if _, err := provider.GetSuggestGasPrice(context.Background()); err != nil {
+		t.Fatalf("GetSuggestGasPrice() failed: %v", err)
+	}
+	if n := transport.count.Load(); n != 2 {
+		t.Errorf("transport saw %d requests, expected 2 (one retry)", n)
+	}
+}
@@ -0,0 +1,68 @@
+package etherkit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+//############ Transaction Logs ############
+
+// TxLog 交易产生的一条日志
+type TxLog struct {
+	Log   *types.Log    // 原始日志
+	Event *DecodedEvent // 按 ABI 解码后的事件（未提供 ABI、ABI 中没有定义或无法解码时为 nil）
+}
+
+// GetLogsByTransaction 获取单笔交易产生的日志（来自交易收据），可选按 ABI 预先解码
+// 比 eth_getLogs 按区块过滤或手动遍历收据更轻量
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - txHash: 交易哈希
+//   - contractAbi: 用于解码事件的合约 ABI（nil 表示不解码）
+//
+// 返回：
+//   - []TxLog: 交易日志（按日志顺序，可能来自多个合约）
+//   - error: 如果交易尚未打包（errors.Is(err, ethereum.NotFound)）或查询失败则返回错误
+//
+// 使用示例：
+//
+//	logs, err := GetLogsByTransaction(ctx, provider, txHash, &erc20Abi)
+//	for _, l := range logs {
+//	    if l.Event != nil && l.Event.Name == "Transfer" {
+//	        fmt.Println(l.Event.Args["value"])
+//	    }
+//	}
+func GetLogsByTransaction(ctx context.Context, ep EtherProvider, txHash common.Hash, contractAbi *abi.ABI) ([]TxLog, error) {
+	receipt, err := ep.GetTransactionReceipt(ctx, txHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get receipt of %s: %w", txHash.Hex(), err)
+	}
+	logs := make([]TxLog, len(receipt.Logs))
+	for i, log := range receipt.Logs {
+		logs[i].Log = log
+		if contractAbi != nil {
+			if event, err := DecodeEvent(*contractAbi, log); err == nil {
+				logs[i].Event = event
+			}
+		}
+	}
+	return logs, nil
+}
+
+// GetLogsByTransaction 获取单笔交易产生的日志，可选按 ABI 预先解码
+// 参数说明：
+//   - ctx: 上下文对象
+//   - txHash: 交易哈希
+//   - contractAbi: 用于解码事件的合约 ABI（nil 表示不解码）
+//
+// 返回：
+//   - []TxLog: 交易日志（按日志顺序）
+//   - error: 如果交易尚未打包或查询失败则返回错误
+func (k *Kit) GetLogsByTransaction(ctx context.Context, txHash common.Hash, contractAbi *abi.ABI) ([]TxLog, error) {
+	return GetLogsByTransaction(ctx, k.EtherProvider, txHash, contractAbi)
+}
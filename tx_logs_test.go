@@ -0,0 +1,68 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestGetLogsByTransaction(t *testing.T) {
+	token := common.HexToAddress("0x1c7D4B196Cb0C7B01d743Fbc6116a902379C7238")
+	from := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	to := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	minedHash := common.HexToHash("0x01")
+	// 第二条日志不是 ERC20 事件，解码时保留原始日志
+	unknown := &types.Log{Address: to, Topics: []common.Hash{common.HexToHash("0xdead")}, Data: []byte{}}
+
+	server := newMockRPCServer(t, map[string]mockRPCHandler{
+		"eth_chainId": mockResult("0x1"),
+		"eth_getTransactionReceipt": func(params []json.RawMessage) (interface{}, error) {
+			var hash common.Hash
+			_ = json.Unmarshal(params[0], &hash)
+			if hash != minedHash {
+				return nil, nil
+			}
+			return &types.Receipt{Status: 1, TxHash: hash, BlockNumber: big.NewInt(1), Logs: []*types.Log{transferLog(token, from, to, 1000), unknown}}, nil
+		},
+	})
+	kit := newMockKit(t, server)
+
+	tests := []struct {
+		name        string
+		abi         *abi.ABI
+		wantDecoded []bool
+	}{
+		{name: "raw logs", abi: nil, wantDecoded: []bool{false, false}},
+		{name: "decoded with ABI", abi: &erc20ABI, wantDecoded: []bool{true, false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs, err := kit.GetLogsByTransaction(context.Background(), minedHash, tt.abi)
+			if err != nil {
+				t.Fatalf("GetLogsByTransaction() failed: %v", err)
+			}
+			if len(logs) != len(tt.wantDecoded) {
+				t.Fatalf("got %d logs, expected %d", len(logs), len(tt.wantDecoded))
+			}
+			for i, log := range logs {
+				if log.Log == nil || (log.Event != nil) != tt.wantDecoded[i] {
+					t.Errorf("log %d = %+v, decoded expected %v", i, log, tt.wantDecoded[i])
+				}
+			}
+			if tt.abi != nil && logs[0].Event.Args["value"].(*big.Int).Int64() != 1000 {
+				t.Errorf("decoded value = %v, expected 1000", logs[0].Event.Args["value"])
+			}
+		})
+	}
+
+	if _, err := kit.GetLogsByTransaction(context.Background(), common.HexToHash("0x02"), nil); !errors.Is(err, ethereum.NotFound) {
+		t.Errorf("GetLogsByTransaction() of pending tx error = %v, want ethereum.NotFound", err)
+	}
+}
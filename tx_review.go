@@ -0,0 +1,309 @@
+package etherkit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/guanzhenxing/go-evm-kit/contracts/erc20"
+)
+
+//############ Transaction Review ############
+
+// ErrTxRejected 交易在签名前被审核回调拒绝
+var ErrTxRejected = errors.New("transaction rejected before signing")
+
+// TxReview 签名前交给审核回调的信息
+// Kind 为 AuditKindTransaction 时是一笔链上交易；消息、EIP-712 结构化数据和用户操作签名同样经过审核回调，
+// 此时 Tx 为 nil，Digest 为实际签名的摘要，能够识别资金去向时（如 Safe 交易、EIP-3009 授权、用户操作）会填充 To、Value、Data
+type TxReview struct {
+	Kind      AuditKind           // 签名类型
+	ChainID   *big.Int            // 链 ID（原始消息和哈希签名时为 nil）
+	From      common.Address      // 发送地址（用户操作为智能合约账户地址）
+	To        *common.Address     // 接收地址（合约创建或无法识别时为 nil）
+	Value     *big.Int            // 转账金额（单位为 Wei，消息类签名无法确定时为 nil）
+	GasLimit  uint64              // Gas 限制
+	GasPrice  *big.Int            // Gas 价格（动态费用交易为 maxFeePerGas）
+	MaxFee    *big.Int            // 最大手续费（GasLimit × GasPrice，单位为 Wei）
+	Data      []byte              // 原始调用数据
+	Method    *abi.Method         // 解码后的合约方法（无法解码时为 nil）
+	Args      []interface{}       // 解码后的方法参数（按方法定义顺序）
+	Tx        *types.Transaction  // 待签名的交易（非标准交易和消息类签名时为 nil）
+	Digest    common.Hash         // 消息类签名实际签名的 32 字节摘要
+	Message   []byte              // 待签名的原始消息（仅 AuditKindMessage）
+	TypedData *apitypes.TypedData // 待签名的 EIP-712 结构化数据（仅 AuditKindTypedData）
+	UserOp    *UserOperation      // 待签名的用户操作（仅 AuditKindUserOperation）
+	Intent    string              // 消息类签名的可读意图
+
+	settlers []func(signed bool) // 签名流程结束后的回调（见 onSettled）
+}
+
+// String 返回交易或签名的可读摘要（用于日志或交互式确认）
+func (r *TxReview) String() string {
+	if r.Kind != "" && r.Kind != AuditKindTransaction {
+		return fmt.Sprintf("%s signature by %s: %s", r.Kind, r.From.Hex(), r.Intent)
+	}
+	to := "<contract creation>"
+	if r.To != nil {
+		to = r.To.Hex()
+	}
+	summary := fmt.Sprintf("from %s to %s value %s wei, gas %d, max fee %s wei",
+		r.From.Hex(), to, bigOrZero(r.Value), r.GasLimit, bigOrZero(r.MaxFee))
+	if r.Method != nil {
+		summary += fmt.Sprintf(", call %s%v", r.Method.Name, r.Args)
+	} else if len(r.Data) > 0 {
+		summary += fmt.Sprintf(", %d bytes calldata", len(r.Data))
+	}
+	return summary
+}
+
+// ConfirmationHook 签名前的审核回调
+// Kit 的每一次签名（交易、原始消息、哈希、EIP-712 结构化数据、用户操作）都会先经过审核回调，可通过 review.Kind 区分
+// 返回 nil 表示批准签名，返回错误表示拒绝（错误会被 ErrTxRejected 包装后返回给调用方）
+// 可用于交互式确认、风控规则或托管审批流程
+type ConfirmationHook func(ctx context.Context, review *TxReview) error
+
+// WithConfirmationHook 注册签名前的交易审核回调
+// 可以多次使用，回调按注册顺序执行，任一回调拒绝即终止签名
+// 参数说明：
+//   - hook: 审核回调
+//
+// 使用示例：
+//
+//	kit, err := NewKit(pk, url, WithConfirmationHook(func(ctx context.Context, r *TxReview) error {
+//	    fmt.Println(r)
+//	    if !askUser() {
+//	        return errors.New("cancelled by user")
+//	    }
+//	    return nil
+//	}))
+func WithConfirmationHook(hook ConfirmationHook) KitOption {
+	return func(k *Kit) {
+		if hook != nil {
+			k.confirmationHooks = append(k.confirmationHooks, hook)
+		}
+	}
+}
+
+// erc20ABI 用于在未提供合约 ABI 时解码常见的 ERC20 调用
+var erc20ABI, _ = GetABI(erc20.IERC20MetaData.ABI)
+
+// decodeCallData 使用合约 ABI 解码调用数据，未提供 ABI 或解码失败时尝试按 ERC20 方法和 DefaultSelectorRegistry 解码
+func decodeCallData(data []byte, contractAbi *abi.ABI) (*abi.Method, []interface{}) {
+	if len(data) < 4 {
+		return nil, nil
+	}
+	for _, candidate := range []*abi.ABI{contractAbi, &erc20ABI} {
+		if candidate == nil {
+			continue
+		}
+		method, err := candidate.MethodById(data[:4])
+		if err != nil {
+			continue
+		}
+		args, err := method.Inputs.Unpack(data[4:])
+		if err != nil {
+			continue
+		}
+		return method, args
+	}
+	if method, ok := DefaultSelectorRegistry.Lookup(data); ok {
+		if args, err := method.Inputs.Unpack(data[4:]); err == nil {
+			return &method, args
+		}
+	}
+	return nil, nil
+}
+
+// newTxReview 根据待签名的交易构建审核信息
+func (k *Kit) newTxReview(chainId *big.Int, tx *types.Transaction, contractAbi *abi.ABI) *TxReview {
+	review := &TxReview{
+		Kind:     AuditKindTransaction,
+		ChainID:  chainId,
+		From:     k.GetAddress(),
+		To:       tx.To(),
+		Value:    tx.Value(),
+		GasLimit: tx.Gas(),
+		GasPrice: tx.GasFeeCap(),
+		MaxFee:   new(big.Int).Mul(tx.GasFeeCap(), new(big.Int).SetUint64(tx.Gas())),
+		Data:     tx.Data(),
+		Tx:       tx,
+	}
+	review.Method, review.Args = decodeCallData(tx.Data(), contractAbi)
+	return review
+}
+
+// newSignatureReview 构建消息类签名的审核信息
+func (k *Kit) newSignatureReview(kind AuditKind, chainId *big.Int, digest common.Hash, intent string) *TxReview {
+	return &TxReview{
+		Kind:    kind,
+		ChainID: chainId,
+		From:    k.GetAddress(),
+		Digest:  digest,
+		Intent:  intent,
+	}
+}
+
+// newTypedDataReview 构建 EIP-712 结构化数据签名的审核信息
+func (k *Kit) newTypedDataReview(typedData apitypes.TypedData, intent string) (*TxReview, error) {
+	hash, err := HashTypedData(typedData)
+	if err != nil {
+		return nil, err
+	}
+	review := k.newSignatureReview(AuditKindTypedData, (*big.Int)(typedData.Domain.ChainId), hash, intent)
+	review.TypedData = &typedData
+	return review, nil
+}
+
+// onSettled 注册签名流程结束后的回调（审核回调用于在最终签名或放弃签名时更新自身状态）
+func (r *TxReview) onSettled(fn func(signed bool)) {
+	r.settlers = append(r.settlers, fn)
+}
+
+// settle 通知审核回调签名流程已结束（signed 表示是否完成签名）
+func (r *TxReview) settle(signed bool) {
+	settlers := r.settlers
+	r.settlers = nil
+	for _, fn := range settlers {
+		fn(signed)
+	}
+}
+
+// setCall 填充消息类签名最终执行的调用（用于审核回调识别资金去向）
+func (r *TxReview) setCall(from, to common.Address, value *big.Int, data []byte) {
+	r.From = from
+	r.To = &to
+	r.Value = value
+	r.Data = data
+	r.Method, r.Args = decodeCallData(data, nil)
+}
+
+// signReviewed 消息类签名的统一流程：审核 → 签名 → 审计
+func (k *Kit) signReviewed(ctx context.Context, review *TxReview, sign func() ([]byte, error)) ([]byte, error) {
+	if k.IsReadOnly() {
+		return nil, ErrReadOnly
+	}
+	if err := k.reviewTx(ctx, review); err != nil {
+		return nil, err
+	}
+	signature, err := sign()
+	review.settle(err == nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := k.auditSignature(ctx, review, signature); err != nil {
+		return nil, err
+	}
+	return signature, nil
+}
+
+// reviewTx 依次执行审核回调
+func (k *Kit) reviewTx(ctx context.Context, review *TxReview) error {
+	for _, hook := range k.confirmationHooks {
+		if err := hook(ctx, review); err != nil {
+			review.settle(false)
+			return fmt.Errorf("%w: %w", ErrTxRejected, err)
+		}
+	}
+	return nil
+}
+
+// SignTx 对交易进行签名（签名前执行审核回调，签名后写入审计记录）
+// 参数说明：
+//   - ctx: 上下文对象
+//   - tx: 未签名的交易对象
+//
+// 返回：
+//   - *types.Transaction: 已签名的交易对象
+//   - error: 如果审核被拒绝（可用 errors.Is(err, ErrTxRejected) 判断）、签名失败或写入审计记录失败则返回错误
+func (k *Kit) SignTx(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	return k.signTx(ctx, tx, nil)
+}
+
+// BuildTxOpts 构建交易选项（用于 go-ethereum 的 bind 包）
+// 覆盖 Wallet.BuildTxOpts：返回的 Signer 与 SignTx 相同，签名前执行审核回调，签名后写入审计记录
+// 参数说明：
+//   - ctx: 上下文对象（同时传递给审核回调）
+//   - value: 转账金额（nil 表示不转账）
+//   - nonce: 交易 nonce（nil 或 <= 0 表示自动计算）
+//   - gasPrice: Gas 价格（nil 或 <= 0 表示自动获取）
+//
+// 返回：
+//   - *bind.TransactOpts: 交易选项，可用于合约交互
+//   - error: 如果构建失败则返回错误
+func (k *Kit) BuildTxOpts(ctx context.Context, value, nonce, gasPrice *big.Int) (*bind.TransactOpts, error) {
+	txOpts, err := k.Wallet.BuildTxOpts(ctx, value, nonce, gasPrice)
+	if err != nil {
+		return nil, err
+	}
+	from := txOpts.From
+	txOpts.Signer = func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		if address != from {
+			return nil, bind.ErrNotAuthorized
+		}
+		return k.signTx(ctx, tx, nil)
+	}
+	return txOpts, nil
+}
+
+// signTx 构建审核信息、执行审核回调后签名，并在返回前写入审计记录
+func (k *Kit) signTx(ctx context.Context, tx *types.Transaction, contractAbi *abi.ABI) (*types.Transaction, error) {
+	if k.IsReadOnly() {
+		return nil, ErrReadOnly
+	}
+	if len(k.confirmationHooks) == 0 && len(k.auditSinks) == 0 {
+		signedTx, err := k.Wallet.SignTx(ctx, tx)
+		if err != nil {
+			return nil, err
+		}
+		k.recordSigned(signedTx)
+		return signedTx, nil
+	}
+	chainId, err := k.GetChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	review := k.newTxReview(chainId, tx, contractAbi)
+	if err := k.reviewTx(ctx, review); err != nil {
+		return nil, err
+	}
+	signedTx, err := k.Wallet.SignTx(ctx, tx)
+	review.settle(err == nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := k.auditTx(ctx, review, signedTx); err != nil {
+		return nil, err
+	}
+	k.recordSigned(signedTx)
+	return signedTx, nil
+}
+
+// sendTx Kit 发送交易的统一流程：构建 → 审核 → 签名 → 审计 → 广播
+func (k *Kit) sendTx(ctx context.Context, to common.Address, nonce, gasLimit uint64, gasPrice, value *big.Int, data []byte, contractAbi *abi.ABI) (common.Hash, error) {
+	tx, err := k.buildTx(ctx, to, nonce, gasLimit, gasPrice, value, data)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	signedTx, err := k.signTx(ctx, tx, contractAbi)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	hash, err := k.SendSignedTx(ctx, signedTx)
+	if err != nil {
+		k.Logger().WarnContext(ctx, "failed to send transaction", "to", to.Hex(), "nonce", signedTx.Nonce(), "error", err)
+		return common.Hash{}, err
+	}
+	k.Logger().InfoContext(ctx, "transaction sent", "hash", hash.Hex(), "to", to.Hex(), "nonce", signedTx.Nonce(), "gasPrice", signedTx.GasPrice())
+	if k.counterparties != nil {
+		method, args := decodeCallData(data, contractAbi)
+		k.counterparties.Add(reviewDestinations(&TxReview{To: &to, Method: method, Args: args})...)
+	}
+	return hash, nil
+}
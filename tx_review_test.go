@@ -0,0 +1,164 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// newMockSendServer 创建支持构建和发送交易的模拟节点
+func newMockSendServer(t *testing.T) *mockRPCServer {
+	t.Helper()
+	return newMockRPCServer(t, map[string]mockRPCHandler{
+		"eth_chainId":             mockResult("0x1"),
+		"eth_getTransactionCount": mockResult("0x5"),
+		"eth_gasPrice":            mockResult("0x3b9aca00"),
+		"eth_estimateGas":         mockResult("0xfde8"),
+		"eth_getBalance":          mockResult("0xde0b6b3a7640000"),
+		"eth_sendRawTransaction": func(params []json.RawMessage) (interface{}, error) {
+			return common.Hash{}, nil
+		},
+	})
+}
+
+// newMockKit 创建连接到模拟节点的 Kit
+func newMockKit(t *testing.T, server *mockRPCServer, opts ...KitOption) *Kit {
+	t.Helper()
+	provider, err := NewProvider(server.URL)
+	if err != nil {
+		t.Fatalf("NewProvider() failed: %v", err)
+	}
+	t.Cleanup(provider.Close)
+	pk, _ := BuildPrivateKeyFromHex("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	kit, err := NewKitWithComponents(pk, provider, opts...)
+	if err != nil {
+		t.Fatalf("NewKitWithComponents() failed: %v", err)
+	}
+	return kit
+}
+
+func TestConfirmationHook(t *testing.T) {
+	token := common.HexToAddress("0x1c7D4B196Cb0C7B01d743Fbc6116a902379C7238")
+	recipient := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	data, _ := erc20ABI.Pack("transfer", recipient, big.NewInt(1000))
+
+	t.Run("approved", func(t *testing.T) {
+		server := newMockSendServer(t)
+		var reviewed *TxReview
+		kit := newMockKit(t, server, WithConfirmationHook(func(ctx context.Context, review *TxReview) error {
+			reviewed = review
+			return nil
+		}))
+
+		if _, err := kit.SendTx(context.Background(), token, 0, 0, nil, nil, data); err != nil {
+			t.Fatalf("SendTx() failed: %v", err)
+		}
+		if reviewed == nil {
+			t.Fatal("Confirmation hook was not called")
+		}
+		// 未提供 ABI 时按 ERC20 方法解码
+		if reviewed.Method == nil || reviewed.Method.Name != "transfer" {
+			t.Fatalf("Decoded method = %v, expected transfer", reviewed.Method)
+		}
+		if reviewed.Args[0].(common.Address) != recipient {
+			t.Errorf("Decoded recipient = %v, expected %s", reviewed.Args[0], recipient.Hex())
+		}
+		expectedFee := new(big.Int).Mul(big.NewInt(0x3b9aca00), big.NewInt(0xfde8))
+		if reviewed.MaxFee.Cmp(expectedFee) != 0 {
+			t.Errorf("MaxFee = %s, expected %s", reviewed.MaxFee, expectedFee)
+		}
+		if server.callCount("eth_sendRawTransaction") != 1 {
+			t.Error("Approved transaction should be broadcast")
+		}
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		server := newMockSendServer(t)
+		kit := newMockKit(t, server, WithConfirmationHook(func(ctx context.Context, review *TxReview) error {
+			return errors.New("over limit")
+		}))
+
+		_, err := kit.SendTxWithHexInput(context.Background(), token, 0, 0, nil, nil, hexutil.Encode(data))
+		if !errors.Is(err, ErrTxRejected) {
+			t.Fatalf("SendTxWithHexInput() error = %v, expected ErrTxRejected", err)
+		}
+		if server.callCount("eth_sendRawTransaction") != 0 {
+			t.Error("Rejected transaction must not be broadcast")
+		}
+	})
+}
+
+func TestConfirmationHookSignatures(t *testing.T) {
+	recipient := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	safe := &Safe{Address: common.HexToAddress("0x5afe"), ChainID: big.NewInt(1)}
+	executeData, _ := simpleAccountAbi.Pack("execute", recipient, big.NewInt(7), []byte{})
+
+	tests := []struct {
+		name     string
+		kind     AuditKind
+		wantTo   *common.Address
+		wantFrom common.Address
+		sign     func(kit *Kit) error
+	}{
+		{"message", AuditKindMessage, nil, common.Address{}, func(kit *Kit) error {
+			_, err := kit.SignMessage(context.Background(), []byte("hello"))
+			return err
+		}},
+		{"typed data", AuditKindTypedData, nil, common.Address{}, func(kit *Kit) error {
+			_, err := kit.SignTypedData(mailTypedData("Hello, Bob!"))
+			return err
+		}},
+		{"safe transaction", AuditKindTypedData, &recipient, safe.Address, func(kit *Kit) error {
+			pending, _ := NewPendingSafeTx(safe, &SafeTx{To: recipient, Value: big.NewInt(1), Nonce: big.NewInt(0)})
+			return kit.SignPendingSafeTx(context.Background(), pending)
+		}},
+		{"user operation", AuditKindUserOperation, &recipient, common.HexToAddress("0xacc0"), func(kit *Kit) error {
+			op := &UserOperation{Sender: common.HexToAddress("0xacc0"), Nonce: big.NewInt(0), CallData: executeData}
+			return kit.SignUserOperation(context.Background(), op, common.HexToAddress(EntryPointV06Address))
+		}},
+		{"zksync transaction", AuditKindTransaction, &recipient, common.Address{}, func(kit *Kit) error {
+			_, err := kit.SendSignedZkSyncTx(context.Background(), &ZkSyncTx{
+				ChainID: big.NewInt(ZkSyncSepoliaChainID), From: kit.GetAddress(), To: recipient,
+				Gas: 300000, GasFeeCap: big.NewInt(250000000), GasTipCap: big.NewInt(0), Value: big.NewInt(1000),
+			})
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newMockSendServer(t)
+			var reviewed *TxReview
+			kit := newMockKit(t, server, WithConfirmationHook(func(ctx context.Context, review *TxReview) error {
+				reviewed = review
+				return errors.New("not allowed")
+			}))
+			if err := tt.sign(kit); !errors.Is(err, ErrTxRejected) {
+				t.Fatalf("error = %v, expected ErrTxRejected", err)
+			}
+			if reviewed == nil || reviewed.Kind != tt.kind {
+				t.Fatalf("reviewed = %+v, expected kind %s", reviewed, tt.kind)
+			}
+			if reviewed.Digest == (common.Hash{}) {
+				t.Error("Digest should be set")
+			}
+			if tt.wantTo != nil && (reviewed.To == nil || *reviewed.To != *tt.wantTo) {
+				t.Errorf("To = %v, expected %s", reviewed.To, tt.wantTo.Hex())
+			}
+			wantFrom := tt.wantFrom
+			if wantFrom == (common.Address{}) {
+				wantFrom = kit.GetAddress()
+			}
+			if reviewed.From != wantFrom {
+				t.Errorf("From = %s, expected %s", reviewed.From.Hex(), wantFrom.Hex())
+			}
+			if server.callCount("eth_sendRawTransaction") != 0 {
+				t.Error("Rejected signature must not be broadcast")
+			}
+		})
+	}
+}
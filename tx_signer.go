@@ -0,0 +1,145 @@
+package etherkit
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+//############ Transaction Signer ############
+
+// SignerKind 签名交易时使用的签名器
+type SignerKind int
+
+const (
+	SignerAuto      SignerKind = iota // 根据交易类型自动选择（默认）：legacy 使用 EIP-155，EIP-2930/1559 使用 London，blob 使用 Cancun，EIP-7702 使用 Prague
+	SignerHomestead                   // 不带链 ID 的 legacy 签名（没有重放保护，仅用于不支持 EIP-155 的私有链）
+	SignerEIP155                      // EIP-155 legacy 签名（不支持类型化交易的链）
+	SignerLondon                      // 支持 legacy、EIP-2930 和 EIP-1559 交易
+	SignerCancun                      // 额外支持 EIP-4844 blob 交易
+	SignerPrague                      // 额外支持 EIP-7702 set code 交易
+)
+
+// String 返回签名器名称
+func (k SignerKind) String() string {
+	switch k {
+	case SignerAuto:
+		return "auto"
+	case SignerHomestead:
+		return "homestead"
+	case SignerEIP155:
+		return "eip155"
+	case SignerLondon:
+		return "london"
+	case SignerCancun:
+		return "cancun"
+	case SignerPrague:
+		return "prague"
+	default:
+		return fmt.Sprintf("SignerKind(%d)", int(k))
+	}
+}
+
+// NewTxSigner 创建指定类型的 go-ethereum 签名器
+// 参数说明：
+//   - kind: 签名器类型
+//   - chainID: 链 ID
+//   - tx: 待签名的交易（仅 SignerAuto 使用，根据交易类型选择签名器；nil 时等同于 SignerPrague）
+//
+// 返回：
+//   - types.Signer: 签名器
+//   - error: 如果签名器类型未知则返回错误
+func NewTxSigner(kind SignerKind, chainID *big.Int, tx *types.Transaction) (types.Signer, error) {
+	if kind == SignerAuto {
+		kind = SignerPrague
+		if tx != nil {
+			kind = minimalSignerKind(tx.Type())
+		}
+	}
+	switch kind {
+	case SignerHomestead:
+		return types.HomesteadSigner{}, nil
+	case SignerEIP155:
+		return types.NewEIP155Signer(chainID), nil
+	case SignerLondon:
+		return types.NewLondonSigner(chainID), nil
+	case SignerCancun:
+		return types.NewCancunSigner(chainID), nil
+	case SignerPrague:
+		return types.NewPragueSigner(chainID), nil
+	default:
+		return nil, fmt.Errorf("unknown signer kind %s", kind)
+	}
+}
+
+// minimalSignerKind 返回能够签名指定交易类型的最早的签名器
+func minimalSignerKind(txType uint8) SignerKind {
+	switch txType {
+	case types.LegacyTxType:
+		return SignerEIP155
+	case types.AccessListTxType, types.DynamicFeeTxType:
+		return SignerLondon
+	case types.BlobTxType:
+		return SignerCancun
+	default:
+		return SignerPrague
+	}
+}
+
+// SetSignerKind 设置签名交易时使用的签名器（默认 SignerAuto）
+// 链不支持某类交易时可以固定签名器，签名该类交易会直接报错而不是广播后被节点拒绝
+// 参数说明：
+//   - kind: 签名器类型（如 SignerEIP155 表示链只支持 legacy 交易）
+func (w *Wallet) SetSignerKind(kind SignerKind) {
+	w.signerKind = kind
+}
+
+// GetSignerKind 返回签名交易时使用的签名器
+func (w *Wallet) GetSignerKind() SignerKind {
+	return w.signerKind
+}
+
+// signTxWithKey 使用私钥和配置的签名器签名交易
+func (w *Wallet) signTxWithKey(chainId *big.Int, tx *types.Transaction) (*types.Transaction, error) {
+	signer, err := NewTxSigner(w.signerKind, chainId, tx)
+	if err != nil {
+		return nil, err
+	}
+	signedTx, err := types.SignTx(tx, signer, w.privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("%s signer cannot sign type %d transaction: %w", w.signerKind, tx.Type(), err)
+	}
+	return signedTx, nil
+}
+
+// keySigner 返回使用私钥和配置的签名器签名的 bind.SignerFn（用于 BuildTxOpts）
+func (w *Wallet) keySigner(chainId *big.Int) bind.SignerFn {
+	return func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		if address != w.address {
+			return nil, bind.ErrNotAuthorized
+		}
+		return w.signTxWithKey(chainId, tx)
+	}
+}
+
+// WithSignerKind 设置签名交易时使用的签名器（等同于创建后调用 SetSignerKind）
+func WithSignerKind(kind SignerKind) KitOption {
+	return func(k *Kit) {
+		if k.setup == nil {
+			k.Wallet.SetSignerKind(kind)
+			return
+		}
+		k.setup.signerKind = kind
+	}
+}
+
+// txSender 恢复已签名交易的发送者（兼容不带链 ID 的 Homestead 签名）
+func txSender(tx *types.Transaction) (common.Address, error) {
+	if !tx.Protected() {
+		return types.Sender(types.HomesteadSigner{}, tx)
+	}
+	return types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+}
@@ -0,0 +1,78 @@
+package etherkit
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/holiman/uint256"
+)
+
+func TestSignTxSignerKind(t *testing.T) {
+	to := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	legacy := types.NewTx(&types.LegacyTx{Nonce: 5, To: &to, Gas: 21000, GasPrice: big.NewInt(1e9), Value: big.NewInt(1)})
+	dynamic := types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(1), Nonce: 5, To: &to, Gas: 21000, GasFeeCap: big.NewInt(2e9), GasTipCap: big.NewInt(1e9)})
+	blob := types.NewTx(&types.BlobTx{ChainID: uint256.NewInt(1), Nonce: 5, To: to, Gas: 21000, GasFeeCap: uint256.NewInt(2e9), GasTipCap: uint256.NewInt(1e9), BlobFeeCap: uint256.NewInt(1), BlobHashes: []common.Hash{{0x01}}})
+
+	tests := []struct {
+		name          string
+		kind          SignerKind
+		tx            *types.Transaction
+		wantErr       bool
+		wantProtected bool
+	}{
+		{"auto legacy", SignerAuto, legacy, false, true},
+		{"auto dynamic fee", SignerAuto, dynamic, false, true},
+		{"auto blob", SignerAuto, blob, false, true},
+		{"homestead legacy", SignerHomestead, legacy, false, false},
+		{"eip155 legacy", SignerEIP155, legacy, false, true},
+		{"eip155 rejects dynamic fee", SignerEIP155, dynamic, true, false},
+		{"london rejects blob", SignerLondon, blob, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newMockSendServer(t)
+			kit := newMockKit(t, server, WithSignerKind(tt.kind))
+
+			signedTx, err := kit.SignTx(context.Background(), tt.tx)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SignTx() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if signedTx.Protected() != tt.wantProtected {
+				t.Errorf("Protected() = %v, expected %v", signedTx.Protected(), tt.wantProtected)
+			}
+			from, err := kit.GetFromAddress(signedTx)
+			if err != nil {
+				t.Fatalf("GetFromAddress() failed: %v", err)
+			}
+			if from != kit.GetAddress() {
+				t.Errorf("GetFromAddress() = %s, expected %s", from.Hex(), kit.GetAddress().Hex())
+			}
+		})
+	}
+}
+
+func TestBuildTxOptsSignerKind(t *testing.T) {
+	server := newMockSendServer(t)
+	kit := newMockKit(t, server)
+	kit.SetSignerKind(SignerEIP155)
+
+	opts, err := kit.BuildTxOpts(context.Background(), nil, nil, nil)
+	if err != nil {
+		t.Fatalf("BuildTxOpts() failed: %v", err)
+	}
+	to := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	dynamic := types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(1), To: &to, Gas: 21000, GasFeeCap: big.NewInt(2e9), GasTipCap: big.NewInt(1e9)})
+	if _, err := opts.Signer(kit.GetAddress(), dynamic); err == nil {
+		t.Error("Signer() expected error for dynamic fee transaction with eip155 signer")
+	}
+	if _, err := opts.Signer(to, dynamic); err == nil {
+		t.Error("Signer() expected error for foreign address")
+	}
+}
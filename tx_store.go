@@ -0,0 +1,498 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+//############ Pending Transaction Store ############
+
+// StoredTxStatus 持久化交易的状态
+type StoredTxStatus string
+
+const (
+	StoredTxPending  StoredTxStatus = "pending"  // 已广播，尚未确认打包
+	StoredTxMined    StoredTxStatus = "mined"    // 已被打包
+	StoredTxReplaced StoredTxStatus = "replaced" // 同一 nonce 已被其他交易占用（加速、取消或其他程序发送）
+)
+
+// StoredTx 持久化的已广播交易
+type StoredTx struct {
+	Hash         common.Hash       `json:"hash"`                   // 交易哈希
+	From         common.Address    `json:"from"`                   // 发送地址
+	Nonce        uint64            `json:"nonce"`                  // 交易 nonce
+	Raw          hexutil.Bytes     `json:"raw"`                    // 已签名的原始交易（用于重新广播）
+	Status       StoredTxStatus    `json:"status"`                 // 交易状态
+	Replacements int               `json:"replacements,omitempty"` // 广播时同一 nonce 已签名的替换交易数量（用于替换策略的 MaxAttempts）
+	SentAt       time.Time         `json:"sentAt"`                 // 首次广播时间
+	UpdatedAt    time.Time         `json:"updatedAt"`              // 状态最近更新时间
+	Metadata     map[string]string `json:"metadata,omitempty"`     // 业务附加信息（见 ContextWithTxMetadata）
+}
+
+// Transaction 解码原始交易
+func (s *StoredTx) Transaction() (*types.Transaction, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(s.Raw); err != nil {
+		return nil, fmt.Errorf("failed to decode stored transaction %s: %w", s.Hash.Hex(), err)
+	}
+	return tx, nil
+}
+
+// TxStore 已广播交易的持久化存储（实现需要并发安全）
+type TxStore interface {
+	// Save 保存交易记录（相同哈希的记录会被覆盖）
+	Save(ctx context.Context, tx *StoredTx) error
+	// Delete 删除交易记录（记录不存在时不返回错误）
+	Delete(ctx context.Context, hash common.Hash) error
+	// List 列出所有交易记录
+	List(ctx context.Context) ([]*StoredTx, error)
+}
+
+// memoryTxStore 内存中的交易存储
+type memoryTxStore struct {
+	mu  sync.Mutex
+	txs map[common.Hash]*StoredTx
+}
+
+// NewMemoryTxStore 创建内存中的交易存储（进程退出后丢失，适用于测试或只需要 ResumePending 语义的场景）
+func NewMemoryTxStore() TxStore {
+	return &memoryTxStore{txs: make(map[common.Hash]*StoredTx)}
+}
+
+// Save 实现 TxStore 接口
+func (s *memoryTxStore) Save(ctx context.Context, tx *StoredTx) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored := *tx
+	s.txs[tx.Hash] = &stored
+	return nil
+}
+
+// Delete 实现 TxStore 接口
+func (s *memoryTxStore) Delete(ctx context.Context, hash common.Hash) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.txs, hash)
+	return nil
+}
+
+// List 实现 TxStore 接口（按 nonce、首次广播时间升序）
+func (s *memoryTxStore) List(ctx context.Context) ([]*StoredTx, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	txs := make([]*StoredTx, 0, len(s.txs))
+	for _, tx := range s.txs {
+		stored := *tx
+		txs = append(txs, &stored)
+	}
+	sort.Slice(txs, func(i, j int) bool {
+		if txs[i].Nonce != txs[j].Nonce {
+			return txs[i].Nonce < txs[j].Nonce
+		}
+		return txs[i].SentAt.Before(txs[j].SentAt)
+	})
+	return txs, nil
+}
+
+// fileTxStore 以 JSON 文件保存的交易存储
+type fileTxStore struct {
+	memoryTxStore
+	path string
+}
+
+// NewFileTxStore 创建以 JSON 文件保存的交易存储（文件已存在时加载其中的记录）
+// 每次修改都会写入临时文件后原子替换，进程崩溃时不会留下损坏的文件
+// 参数说明：
+//   - path: 存储文件路径（不存在时在第一次保存时创建）
+//
+// 返回：
+//   - TxStore: 交易存储
+//   - error: 如果文件无法读取或格式错误则返回错误
+//
+// 使用示例：
+//
+//	store, err := NewFileTxStore("pending_txs.json")
+//	kit, err := NewKit(pk, url, WithTxStore(store))
+//	results, err := kit.ResumePending(ctx) // 重启后对账
+func NewFileTxStore(path string) (TxStore, error) {
+	s := &fileTxStore{memoryTxStore: memoryTxStore{txs: make(map[common.Hash]*StoredTx)}, path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read transaction store: %w", err)
+	}
+	var txs []*StoredTx
+	if err := json.Unmarshal(data, &txs); err != nil {
+		return nil, fmt.Errorf("failed to parse transaction store %s: %w", path, err)
+	}
+	for _, tx := range txs {
+		s.txs[tx.Hash] = tx
+	}
+	return s, nil
+}
+
+// Save 实现 TxStore 接口
+func (s *fileTxStore) Save(ctx context.Context, tx *StoredTx) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prev, existed := s.txs[tx.Hash]
+	stored := *tx
+	s.txs[tx.Hash] = &stored
+	if err := s.flush(); err != nil {
+		if existed {
+			s.txs[tx.Hash] = prev
+		} else {
+			delete(s.txs, tx.Hash)
+		}
+		return err
+	}
+	return nil
+}
+
+// Delete 实现 TxStore 接口
+func (s *fileTxStore) Delete(ctx context.Context, hash common.Hash) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prev, existed := s.txs[hash]
+	if !existed {
+		return nil
+	}
+	delete(s.txs, hash)
+	if err := s.flush(); err != nil {
+		s.txs[hash] = prev
+		return err
+	}
+	return nil
+}
+
+// flush 将所有记录写入临时文件后替换存储文件（调用方需持有锁）
+func (s *fileTxStore) flush() error {
+	txs := make([]*StoredTx, 0, len(s.txs))
+	for _, tx := range s.txs {
+		txs = append(txs, tx)
+	}
+	sort.Slice(txs, func(i, j int) bool { return txs[i].Nonce < txs[j].Nonce })
+	data, err := json.MarshalIndent(txs, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("failed to write transaction store: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write transaction store: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write transaction store: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write transaction store: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to write transaction store: %w", err)
+	}
+	return nil
+}
+
+// WithTxStore 持久化 Kit 广播的每一笔交易（SendSignedTx、SendTx、InvokeContract、SpeedUpTx、CancelTx、
+// BulkSender、SubmitPrepared 等），重启后可用 ResumePending 对账
+// 交易在通过余额检查后、广播前写入存储（写入失败时不会广播），节点明确拒绝的交易会被删除
+// 参数说明：
+//   - store: 交易存储（如 NewFileTxStore 或自定义的数据库实现）
+func WithTxStore(store TxStore) KitOption {
+	return func(k *Kit) {
+		k.txStore = store
+	}
+}
+
+// GetTxStore 获取 Kit 的交易存储（未配置 WithTxStore 时为 nil）
+func (k *Kit) GetTxStore() TxStore {
+	return k.txStore
+}
+
+// txMetadataKey 上下文中交易附加信息的键
+type txMetadataKey struct{}
+
+// ContextWithTxMetadata 返回携带交易附加信息的上下文，使用该上下文广播的交易会将附加信息一并持久化
+// 多次调用时合并，相同的键以后设置的为准
+// 参数说明：
+//   - ctx: 上下文对象
+//   - metadata: 附加信息（如订单号、业务类型）
+//
+// 使用示例：
+//
+//	ctx = ContextWithTxMetadata(ctx, map[string]string{"orderId": "1024"})
+//	hash, err := kit.SendTx(ctx, to, 0, 0, nil, value, nil)
+func ContextWithTxMetadata(ctx context.Context, metadata map[string]string) context.Context {
+	merged := make(map[string]string)
+	for key, value := range txMetadata(ctx) {
+		merged[key] = value
+	}
+	for key, value := range metadata {
+		merged[key] = value
+	}
+	return context.WithValue(ctx, txMetadataKey{}, merged)
+}
+
+// txMetadata 返回上下文中的交易附加信息
+func txMetadata(ctx context.Context) map[string]string {
+	metadata, _ := ctx.Value(txMetadataKey{}).(map[string]string)
+	return metadata
+}
+
+// storeTx 在广播前持久化交易（未配置交易存储时不做任何事）
+func (k *Kit) storeTx(ctx context.Context, signedTx *types.Transaction) error {
+	if k.txStore == nil {
+		return nil
+	}
+	from, err := txSender(signedTx)
+	if err != nil {
+		return err
+	}
+	raw, err := signedTx.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return k.storeRawTx(ctx, signedTx.Hash(), from, signedTx.Nonce(), raw)
+}
+
+// storeRawTx 在广播前持久化原始交易字节（用于无法表示为 types.Transaction 的交易，如 zkSync 0x71 交易）
+func (k *Kit) storeRawTx(ctx context.Context, hash common.Hash, from common.Address, nonce uint64, raw []byte) error {
+	if k.txStore == nil {
+		return nil
+	}
+	replacements := 0
+	if k.signed != nil && from == k.GetAddress() {
+		if record, ok := k.signed.Get(nonce); ok && record.tx.Hash() == hash {
+			replacements = record.replacements
+		}
+	}
+	now := time.Now()
+	stored := &StoredTx{
+		Hash:         hash,
+		From:         from,
+		Nonce:        nonce,
+		Raw:          raw,
+		Status:       StoredTxPending,
+		Replacements: replacements,
+		SentAt:       now,
+		UpdatedAt:    now,
+		Metadata:     txMetadata(ctx),
+	}
+	if err := k.txStore.Save(ctx, stored); err != nil {
+		return fmt.Errorf("failed to store transaction %s: %w", stored.Hash.Hex(), err)
+	}
+	return nil
+}
+
+// broadcastTx 持久化后广播交易（节点明确拒绝时删除记录；交易已在交易池中或错误原因不明确时保留，由 ResumePending 对账）
+func (k *Kit) broadcastTx(ctx context.Context, signedTx *types.Transaction) error {
+	if err := k.storeTx(ctx, signedTx); err != nil {
+		return err
+	}
+	return k.settleBroadcast(ctx, signedTx.Hash(), k.SendTransaction(ctx, signedTx))
+}
+
+// settleBroadcast 规范化广播错误，节点明确拒绝时删除已持久化的记录
+func (k *Kit) settleBroadcast(ctx context.Context, hash common.Hash, sendErr error) error {
+	err := NormalizeError(sendErr)
+	if err == nil || k.txStore == nil || errors.Is(err, ErrAlreadyKnown) {
+		return err
+	}
+	for _, rejected := range []error{ErrInsufficientFunds, ErrInvalidNonce, ErrInvalidGasPrice, ErrInvalidGasLimit, ErrTransactionFailed} {
+		if errors.Is(err, rejected) {
+			if deleteErr := k.txStore.Delete(ctx, hash); deleteErr != nil {
+				k.Logger().WarnContext(ctx, "failed to delete rejected transaction from store", "hash", hash.Hex(), "error", deleteErr)
+			}
+			break
+		}
+	}
+	return err
+}
+
+// sendRawTransaction 通过 eth_sendRawTransaction 广播原始交易字节
+func (k *Kit) sendRawTransaction(ctx context.Context, raw []byte) (common.Hash, error) {
+	var hash common.Hash
+	err := k.GetRpcClient().CallContext(ctx, &hash, "eth_sendRawTransaction", hexutil.Bytes(raw))
+	return hash, err
+}
+
+// SendSignedTx 发送已签名的交易（配置了 WithTxStore 时在广播前持久化）
+// 发送前会检查发送地址余额是否足以支付 value + gasLimit × gasFeeCap
+// 参数说明：
+//   - ctx: 上下文对象（可用 ContextWithTxMetadata 附加业务信息）
+//   - signedTx: 已签名的交易对象
+//
+// 返回：
+//   - common.Hash: 交易哈希
+//   - error: 如果持久化或发送失败则返回错误（余额不足时返回 *InsufficientFundsError）
+func (k *Kit) SendSignedTx(ctx context.Context, signedTx *types.Transaction) (common.Hash, error) {
+	from, err := txSender(signedTx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if err := CheckFunds(ctx, k.EtherProvider, from, signedTx); err != nil {
+		return common.Hash{}, err
+	}
+	if err := k.broadcastTx(ctx, signedTx); err != nil {
+		return common.Hash{}, err
+	}
+	return signedTx.Hash(), nil
+}
+
+// ResumeAction ResumePending 对一笔交易的处理结果
+type ResumeAction string
+
+const (
+	ResumeRebroadcast ResumeAction = "rebroadcast" // 尚未打包，已重新广播
+	ResumeMined       ResumeAction = "mined"       // 已被打包，标记为 StoredTxMined
+	ResumeReplaced    ResumeAction = "replaced"    // nonce 已被其他交易占用，标记为 StoredTxReplaced
+	ResumeBumped      ResumeAction = "bumped"      // 重新广播因 gas 价格过低被拒绝，已按替换策略加价替换，原交易标记为 StoredTxReplaced
+)
+
+// ResumeResult ResumePending 对一笔交易的对账结果
+type ResumeResult struct {
+	Tx          *StoredTx      // 交易记录（状态已更新）
+	Action      ResumeAction   // 处理结果
+	Receipt     *types.Receipt // 交易收据（仅 ResumeMined）
+	Replacement common.Hash    // 加价替换交易的哈希（仅 ResumeBumped）
+	Err         error          // 重新广播或更新存储失败的原因（nil 表示成功）
+}
+
+// ResumePending 重启后将交易存储中尚未确认的交易与链上状态对账
+//   - 已被打包：标记为 StoredTxMined
+//   - 没有收据但 nonce 已被确认：同一 nonce 的其他交易已打包，标记为 StoredTxReplaced
+//   - 其他情况：重新广播原始交易（节点返回 already known 视为成功），保持 StoredTxPending；
+//     节点因 gas 价格过低拒绝时按 Kit 默认替换策略（见 WithReplacementPolicy）加价替换，替换次数受 MaxAttempts 限制
+//
+// 参数说明：
+//   - ctx: 上下文对象
+//
+// 返回：
+//   - []ResumeResult: 每一笔待确认交易的对账结果（按 nonce 升序），单笔失败记录在 Err 中
+//   - error: 如果未配置交易存储、读取存储、查询 nonce 或收据失败则返回错误（同时返回已完成的对账结果）
+//
+// 使用示例：
+//
+//	results, err := kit.ResumePending(ctx)
+//	for _, r := range results {
+//	    if r.Action == ResumeReplaced {
+//	        log.Printf("tx %s (order %s) was replaced", r.Tx.Hash, r.Tx.Metadata["orderId"])
+//	    }
+//	}
+func (k *Kit) ResumePending(ctx context.Context) ([]ResumeResult, error) {
+	if k.txStore == nil {
+		return nil, errors.New("no transaction store configured (see WithTxStore)")
+	}
+	stored, err := k.txStore.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stored transactions: %w", err)
+	}
+	sort.SliceStable(stored, func(i, j int) bool { return stored[i].Nonce < stored[j].Nonce })
+
+	// 先查询 nonce 再查询收据：nonce 已确认但查不到收据时，可以确定是被其他交易替换
+	confirmed := make(map[common.Address]uint64)
+	var results []ResumeResult
+	for _, tx := range stored {
+		if tx.Status != StoredTxPending {
+			continue
+		}
+		if _, ok := confirmed[tx.From]; !ok {
+			nonce, err := k.GetEthClient().NonceAt(ctx, tx.From, nil)
+			if err != nil {
+				return results, fmt.Errorf("failed to get nonce of %s: %w", tx.From.Hex(), err)
+			}
+			confirmed[tx.From] = nonce
+		}
+		result, err := k.resumeTx(ctx, tx, confirmed[tx.From])
+		if err != nil {
+			return results, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// resumeTx 对账单笔交易并更新存储（查询收据失败时返回错误，避免把未知状态误判为被替换）
+func (k *Kit) resumeTx(ctx context.Context, tx *StoredTx, confirmedNonce uint64) (ResumeResult, error) {
+	result := ResumeResult{Tx: tx}
+	receipt, err := k.GetTransactionReceipt(ctx, tx.Hash)
+	if err != nil && !errors.Is(err, ethereum.NotFound) {
+		return result, fmt.Errorf("failed to get receipt of %s: %w", tx.Hash.Hex(), err)
+	}
+	if receipt != nil {
+		result.Action = ResumeMined
+		result.Receipt = receipt
+		tx.Status = StoredTxMined
+	} else if tx.Nonce < confirmedNonce {
+		result.Action = ResumeReplaced
+		tx.Status = StoredTxReplaced
+	} else {
+		result.Action = ResumeRebroadcast
+		var signedTx *types.Transaction
+		var sendErr error
+		if len(tx.Raw) > 0 && tx.Raw[0] == ZkSyncEIP712TxType {
+			// zkSync 0x71 交易无法解码为 types.Transaction，直接重新广播原始字节
+			_, sendErr = k.sendRawTransaction(ctx, tx.Raw)
+		} else {
+			if signedTx, err = tx.Transaction(); err != nil {
+				result.Err = err
+				return result, nil
+			}
+			sendErr = k.SendTransaction(ctx, signedTx)
+		}
+		err := NormalizeError(sendErr)
+		if err == nil || errors.Is(err, ErrAlreadyKnown) {
+			return result, nil
+		}
+		if signedTx == nil || !errors.Is(err, ErrInvalidGasPrice) || tx.From != k.GetAddress() || k.IsReadOnly() {
+			result.Err = fmt.Errorf("failed to rebroadcast %s: %w", tx.Hash.Hex(), err)
+			return result, nil
+		}
+		// 原交易的 gas 价格已不足以进入交易池（如 base fee 上涨），按 Kit 默认替换策略加价替换
+		replacement, bumpErr := k.resumeBump(ContextWithTxMetadata(ctx, tx.Metadata), signedTx)
+		if bumpErr != nil {
+			result.Err = fmt.Errorf("failed to rebroadcast %s: %w (replacement failed: %w)", tx.Hash.Hex(), err, bumpErr)
+			return result, nil
+		}
+		result.Action = ResumeBumped
+		result.Replacement = replacement
+		tx.Status = StoredTxReplaced
+	}
+	tx.UpdatedAt = time.Now()
+	if err := k.txStore.Save(ctx, tx); err != nil {
+		result.Err = fmt.Errorf("failed to update stored transaction %s: %w", tx.Hash.Hex(), err)
+	}
+	return result, nil
+}
+
+// resumeBump 按 Kit 默认替换策略签名并广播加价交易（替换次数累计到 MaxAttempts 后返回 ErrReplacementLimit）
+func (k *Kit) resumeBump(ctx context.Context, original *types.Transaction) (common.Hash, error) {
+	tx, attempt, err := k.bumpTx(ctx, original, k.ReplacementPolicy())
+	if err != nil {
+		return common.Hash{}, err
+	}
+	signedTx, err := k.signReplacement(ctx, tx, attempt)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return k.SendSignedTx(ctx, signedTx)
+}
@@ -0,0 +1,272 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestFileTxStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "txs.json")
+	store, err := NewFileTxStore(path)
+	if err != nil {
+		t.Fatalf("NewFileTxStore() failed: %v", err)
+	}
+	a := &StoredTx{Hash: common.HexToHash("0xa"), Nonce: 2, Raw: []byte{0x01}, Status: StoredTxPending, Metadata: map[string]string{"orderId": "1"}}
+	b := &StoredTx{Hash: common.HexToHash("0xb"), Nonce: 1, Raw: []byte{0x02}, Status: StoredTxPending}
+	for _, tx := range []*StoredTx{a, b} {
+		if err := store.Save(ctx, tx); err != nil {
+			t.Fatalf("Save() failed: %v", err)
+		}
+	}
+	a.Status = StoredTxMined
+	if err := store.Save(ctx, a); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+
+	// 重新打开文件，模拟进程重启
+	reopened, err := NewFileTxStore(path)
+	if err != nil {
+		t.Fatalf("NewFileTxStore() failed: %v", err)
+	}
+	txs, err := reopened.List(ctx)
+	if err != nil {
+		t.Fatalf("List() failed: %v", err)
+	}
+	if len(txs) != 2 || txs[0].Hash != b.Hash || txs[1].Status != StoredTxMined || txs[1].Metadata["orderId"] != "1" {
+		t.Fatalf("List() = %+v", txs)
+	}
+
+	if err := reopened.Delete(ctx, b.Hash); err != nil {
+		t.Fatalf("Delete() failed: %v", err)
+	}
+	if err := reopened.Delete(ctx, b.Hash); err != nil {
+		t.Errorf("Delete() of missing record failed: %v", err)
+	}
+	reopened, _ = NewFileTxStore(path)
+	if txs, _ := reopened.List(ctx); len(txs) != 1 || txs[0].Hash != a.Hash {
+		t.Errorf("List() after delete = %+v", txs)
+	}
+}
+
+func TestKitTxStore(t *testing.T) {
+	to := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	tests := []struct {
+		name      string
+		sendErr   error
+		wantErr   bool
+		wantSaved bool
+	}{
+		{name: "broadcast", wantSaved: true},
+		{name: "already known", sendErr: errors.New("already known"), wantSaved: true},
+		{name: "rejected", sendErr: errors.New("nonce too low"), wantErr: true},
+		{name: "ambiguous failure", sendErr: errors.New("bad gateway"), wantErr: true, wantSaved: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newMockSendServer(t)
+			server.handlers["eth_sendRawTransaction"] = func(params []json.RawMessage) (interface{}, error) {
+				return common.Hash{}, tt.sendErr
+			}
+			store := NewMemoryTxStore()
+			kit := newMockKit(t, server, WithTxStore(store))
+
+			ctx := ContextWithTxMetadata(context.Background(), map[string]string{"orderId": "1024"})
+			_, err := kit.SendTx(ctx, to, 0, 0, nil, big.NewInt(1), nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SendTx() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			txs, _ := store.List(context.Background())
+			if !tt.wantSaved {
+				if len(txs) != 0 {
+					t.Errorf("rejected transaction should not be stored: %+v", txs)
+				}
+				return
+			}
+			if len(txs) != 1 {
+				t.Fatalf("stored %d transactions, expected 1", len(txs))
+			}
+			stored := txs[0]
+			if stored.From != kit.GetAddress() || stored.Nonce != 5 || stored.Status != StoredTxPending || stored.Metadata["orderId"] != "1024" {
+				t.Errorf("stored = %+v", stored)
+			}
+			if tx, err := stored.Transaction(); err != nil || tx.Hash() != stored.Hash {
+				t.Errorf("Transaction() = %v, %v", tx, err)
+			}
+		})
+	}
+}
+
+func TestResumePending(t *testing.T) {
+	pk, _ := BuildPrivateKeyFromHex("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	signer := types.NewLondonSigner(big.NewInt(1))
+	to := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	newTx := func(nonce uint64, gasPrice int64) *types.Transaction {
+		return types.MustSignNewTx(pk, signer, &types.LegacyTx{Nonce: nonce, Gas: 21000, GasPrice: big.NewInt(gasPrice), To: &to})
+	}
+	mined := newTx(3, 1e9)
+	replaced := newTx(4, 1e9)
+	pending := newTx(5, 1e9)
+	done := newTx(2, 1e9)
+
+	server := newMockSendServer(t)
+	var rebroadcast []common.Hash
+	server.handlers["eth_sendRawTransaction"] = func(params []json.RawMessage) (interface{}, error) {
+		var raw hexutil.Bytes
+		if err := json.Unmarshal(params[0], &raw); err != nil {
+			return nil, err
+		}
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(raw); err != nil {
+			return nil, err
+		}
+		rebroadcast = append(rebroadcast, tx.Hash())
+		return nil, errors.New("already known")
+	}
+	server.handlers["eth_getTransactionReceipt"] = func(params []json.RawMessage) (interface{}, error) {
+		var hash common.Hash
+		_ = json.Unmarshal(params[0], &hash)
+		if hash == mined.Hash() {
+			return &types.Receipt{Status: 1, TxHash: hash, BlockNumber: big.NewInt(10), Logs: []*types.Log{}}, nil
+		}
+		return nil, nil
+	}
+
+	store := NewMemoryTxStore()
+	for _, tx := range []*types.Transaction{mined, replaced, pending, done} {
+		raw, _ := tx.MarshalBinary()
+		status := StoredTxPending
+		if tx == done {
+			status = StoredTxMined
+		}
+		_ = store.Save(context.Background(), &StoredTx{Hash: tx.Hash(), From: crypto.PubkeyToAddress(pk.PublicKey), Nonce: tx.Nonce(), Raw: raw, Status: status, SentAt: time.Now()})
+	}
+	kit := newMockKit(t, server, WithTxStore(store))
+
+	results, err := kit.ResumePending(context.Background())
+	if err != nil {
+		t.Fatalf("ResumePending() failed: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		tx         *types.Transaction
+		wantAction ResumeAction
+		wantStatus StoredTxStatus
+	}{
+		{name: "mined", tx: mined, wantAction: ResumeMined, wantStatus: StoredTxMined},
+		{name: "replaced", tx: replaced, wantAction: ResumeReplaced, wantStatus: StoredTxReplaced},
+		{name: "still pending", tx: pending, wantAction: ResumeRebroadcast, wantStatus: StoredTxPending},
+	}
+	if len(results) != len(tests) {
+		t.Fatalf("ResumePending() returned %d results, expected %d", len(results), len(tests))
+	}
+	stored, _ := store.List(context.Background())
+	statuses := make(map[common.Hash]StoredTxStatus)
+	for _, tx := range stored {
+		statuses[tx.Hash] = tx.Status
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := results[i]
+			if result.Tx.Hash != tt.tx.Hash() || result.Action != tt.wantAction || result.Err != nil {
+				t.Errorf("result = {%s, %s, %v}, expected {%s, %s}", result.Tx.Hash.Hex(), result.Action, result.Err, tt.tx.Hash().Hex(), tt.wantAction)
+			}
+			if statuses[tt.tx.Hash()] != tt.wantStatus {
+				t.Errorf("stored status = %s, expected %s", statuses[tt.tx.Hash()], tt.wantStatus)
+			}
+		})
+	}
+	if len(rebroadcast) != 1 || rebroadcast[0] != pending.Hash() {
+		t.Errorf("rebroadcast = %v, expected only %s", rebroadcast, pending.Hash().Hex())
+	}
+	if results[0].Receipt == nil || results[0].Receipt.BlockNumber.Int64() != 10 {
+		t.Errorf("mined result receipt = %+v", results[0].Receipt)
+	}
+
+	if _, err := newMockKit(t, server).ResumePending(context.Background()); err == nil {
+		t.Error("ResumePending() without store expected error")
+	}
+}
+
+func TestResumePendingBump(t *testing.T) {
+	pk, _ := BuildPrivateKeyFromHex("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	to := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	stuck := types.MustSignNewTx(pk, types.NewLondonSigner(big.NewInt(1)), &types.LegacyTx{Nonce: 5, Gas: 21000, GasPrice: big.NewInt(1e9), To: &to})
+
+	tests := []struct {
+		name         string
+		replacements int // 存储中已记录的替换次数
+		wantAction   ResumeAction
+		wantErr      error
+	}{
+		{name: "bumps underpriced transaction", wantAction: ResumeBumped},
+		{name: "stops at max attempts", replacements: 2, wantAction: ResumeRebroadcast, wantErr: ErrReplacementLimit},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newMockSendServer(t)
+			var sent []*types.Transaction
+			server.handlers["eth_sendRawTransaction"] = func(params []json.RawMessage) (interface{}, error) {
+				var raw hexutil.Bytes
+				_ = json.Unmarshal(params[0], &raw)
+				tx := new(types.Transaction)
+				if err := tx.UnmarshalBinary(raw); err != nil {
+					return nil, err
+				}
+				if tx.Hash() == stuck.Hash() {
+					return nil, errors.New("max fee per gas less than block base fee")
+				}
+				sent = append(sent, tx)
+				return tx.Hash(), nil
+			}
+			server.handlers["eth_getTransactionReceipt"] = mockResult(nil)
+			store := NewMemoryTxStore()
+			raw, _ := stuck.MarshalBinary()
+			_ = store.Save(context.Background(), &StoredTx{Hash: stuck.Hash(), From: crypto.PubkeyToAddress(pk.PublicKey), Nonce: 5, Raw: raw,
+				Status: StoredTxPending, Replacements: tt.replacements, Metadata: map[string]string{"orderId": "7"}})
+			kit := newMockKit(t, server, WithTxStore(store), WithReplacementPolicy(ReplacementPolicy{MaxAttempts: 2}))
+
+			results, err := kit.ResumePending(context.Background())
+			if err != nil || len(results) != 1 {
+				t.Fatalf("ResumePending() = %+v, %v", results, err)
+			}
+			result := results[0]
+			if result.Action != tt.wantAction || !errors.Is(result.Err, tt.wantErr) {
+				t.Fatalf("result = {%s, %v}, expected {%s, %v}", result.Action, result.Err, tt.wantAction, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if len(sent) != 0 {
+					t.Errorf("no replacement should be broadcast, got %d", len(sent))
+				}
+				return
+			}
+			if len(sent) != 1 || sent[0].Nonce() != 5 || sent[0].GasPrice().Int64() != 1.1e9 || result.Replacement != sent[0].Hash() {
+				t.Fatalf("replacement = %+v, result.Replacement = %s", sent, result.Replacement.Hex())
+			}
+			stored, _ := store.List(context.Background())
+			statuses := make(map[common.Hash]*StoredTx)
+			for _, tx := range stored {
+				statuses[tx.Hash] = tx
+			}
+			if statuses[stuck.Hash()].Status != StoredTxReplaced {
+				t.Errorf("original status = %s, expected replaced", statuses[stuck.Hash()].Status)
+			}
+			if bumped := statuses[result.Replacement]; bumped == nil || bumped.Replacements != 1 || bumped.Metadata["orderId"] != "7" {
+				t.Errorf("stored replacement = %+v", bumped)
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package etherkit
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+//############ Pending Transactions ############
+
+// MinReplacementBumpPercent 节点接受替换交易所需的最低加价比例（geth 默认 10%）
+const MinReplacementBumpPercent = 10
+
+// TxPoolContent 账户在节点交易池中的交易（按 nonce 索引）
+type TxPoolContent struct {
+	Pending map[uint64]*types.Transaction // 可执行的交易
+	Queued  map[uint64]*types.Transaction // 因 nonce 间隙等原因暂不可执行的交易
+}
+
+// GetTxPoolContentFrom 通过 txpool_contentFrom 查询账户在交易池中的交易
+// 并非所有节点都开放 txpool 命名空间（多数公共 RPC 不支持），调用方应准备好降级处理
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - address: 账户地址
+//
+// 返回：
+//   - *TxPoolContent: 交易池内容
+//   - error: 如果节点不支持或查询失败则返回错误
+func GetTxPoolContentFrom(ctx context.Context, ep EtherProvider, address common.Address) (*TxPoolContent, error) {
+	var raw map[string]map[string]*types.Transaction
+	if err := ep.GetRpcClient().CallContext(ctx, &raw, "txpool_contentFrom", address); err != nil {
+		return nil, fmt.Errorf("failed to query txpool: %w", err)
+	}
+	content := &TxPoolContent{}
+	var err error
+	if content.Pending, err = indexByNonce(raw["pending"]); err != nil {
+		return nil, err
+	}
+	if content.Queued, err = indexByNonce(raw["queued"]); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+// indexByNonce 将 txpool 返回的 nonce 字符串键转换为数字
+func indexByNonce(txs map[string]*types.Transaction) (map[uint64]*types.Transaction, error) {
+	indexed := make(map[uint64]*types.Transaction, len(txs))
+	for key, tx := range txs {
+		nonce, err := strconv.ParseUint(key, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid txpool nonce %q: %w", key, err)
+		}
+		indexed[nonce] = tx
+	}
+	return indexed, nil
+}
+
+// GetNonceRange 获取账户已确认的 nonce 和包含待处理交易的 nonce
+// [latest, pending) 区间内的 nonce 对应尚未打包的交易
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - address: 账户地址
+//
+// 返回：
+//   - latest: 最新区块中的 nonce（下一笔待确认交易的 nonce）
+//   - pending: 包含交易池中交易的 nonce（下一笔新交易的 nonce）
+//   - err: 如果查询失败则返回错误
+func GetNonceRange(ctx context.Context, ep EtherProvider, address common.Address) (latest, pending uint64, err error) {
+	latest, err = ep.GetEthClient().NonceAt(ctx, address, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	pending, err = ep.GetEthClient().PendingNonceAt(ctx, address)
+	if err != nil {
+		return 0, 0, err
+	}
+	return latest, pending, nil
+}
+
+// BumpGasPrice 按百分比提高 gas 价格（向上取整）
+// 参数说明：
+//   - price: 原 gas 价格
+//   - percent: 加价比例（如 20 表示 +20%）
+//
+// 返回：
+//   - *big.Int: 提高后的 gas 价格
+func BumpGasPrice(price *big.Int, percent int) *big.Int {
+	bumped := new(big.Int).Mul(price, big.NewInt(int64(100+percent)))
+	bumped.Add(bumped, big.NewInt(99))
+	return bumped.Div(bumped, big.NewInt(100))
+}
+
+// CancelResult 单个 nonce 的取消（或间隙填补）结果
+type CancelResult struct {
+	Nonce    uint64      // 被取消的 nonce
+	Replaced common.Hash // 被替换的原交易哈希（交易池不可查询时为空）
+	TxHash   common.Hash // 取消交易（向自己转账 0）的哈希
+	GasPrice *big.Int    // 取消交易的 gas 价格
+	Err      error       // 发送失败时的错误
+}
+
+// CancelAllPending 取消账户所有未打包的交易（用于应急处置，如私钥泄露或错误批量发送）
+// 通过 nonce 区间 [latest, pending) 确定待处理交易，节点支持 txpool 时还会覆盖 queued 中的交易；
+// 每个 nonce 使用向自己转账 0 的交易替换，gas 价格取原交易价格和当前建议价格中较高者再加价
+// 参数说明：
+//   - ctx: 上下文对象
+//   - feeBumpPercent: 加价比例（<= 0 时使用 Kit 替换策略的 BumpPercent，低于 MinReplacementBumpPercent 时按其处理）
+//
+// 返回：
+//   - []CancelResult: 每个 nonce 的取消结果（按 nonce 升序，单个失败不会中止其余 nonce；超过替换策略的 MaxTotalFee 时 Err 包装 ErrReplacementLimit）
+//   - error: 如果查询 nonce 或 gas 价格失败则返回错误
+func (k *Kit) CancelAllPending(ctx context.Context, feeBumpPercent int) ([]CancelResult, error) {
+	policy := k.ReplacementPolicy()
+	if feeBumpPercent > 0 {
+		policy.BumpPercent = feeBumpPercent
+	}
+	self := k.GetAddress()
+	latest, pending, err := GetNonceRange(ctx, k.EtherProvider, self)
+	if err != nil {
+		return nil, err
+	}
+	known := map[uint64]*types.Transaction{}
+	if pool, err := GetTxPoolContentFrom(ctx, k.EtherProvider, self); err == nil {
+		for nonce, tx := range pool.Queued {
+			known[nonce] = tx
+		}
+		for nonce, tx := range pool.Pending {
+			known[nonce] = tx
+		}
+	}
+
+	nonces := make([]uint64, 0, pending-latest)
+	for nonce := latest; nonce < pending; nonce++ {
+		nonces = append(nonces, nonce)
+	}
+	for nonce := range known {
+		if nonce >= pending {
+			nonces = append(nonces, nonce)
+		}
+	}
+	sort.Slice(nonces, func(i, j int) bool { return nonces[i] < nonces[j] })
+	if len(nonces) == 0 {
+		return nil, nil
+	}
+
+	suggested, err := k.suggestGasPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]CancelResult, 0, len(nonces))
+	for _, nonce := range nonces {
+		result := CancelResult{Nonce: nonce, GasPrice: suggested}
+		if original, ok := known[nonce]; ok {
+			result.Replaced = original.Hash()
+			if original.GasFeeCap().Cmp(suggested) > 0 {
+				result.GasPrice = original.GasFeeCap()
+			}
+		}
+		attempt := k.replacementAttempt(ctx, nonce)
+		gasPrice, err := policy.Bump(result.GasPrice, DefaultGasLimit, attempt)
+		if err != nil {
+			result.Err = err
+			results = append(results, result)
+			continue
+		}
+		result.GasPrice = gasPrice
+		result.TxHash, result.Err = k.sendNoopTx(ctx, nonce, result.GasPrice, attempt)
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// sendNoopTx 使用指定 nonce 发送向自己转账 0 的交易（attempt 为第几次替换，<= 0 表示不是替换交易）
+// 不经过 NewTx 的自动填充逻辑（nonce 为 0 时不会被替换为 pending nonce）
+func (k *Kit) sendNoopTx(ctx context.Context, nonce uint64, gasPrice *big.Int, attempt int) (common.Hash, error) {
+	tx, err := NewTx(k.GetAddress(), nonce, DefaultGasLimit, gasPrice, big.NewInt(0), nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	signedTx, err := k.signReplacement(ctx, tx, attempt)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return k.SendSignedTx(ctx, signedTx)
+}
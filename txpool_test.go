@@ -0,0 +1,91 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestBumpGasPrice(t *testing.T) {
+	tests := []struct {
+		price   int64
+		percent int
+		want    int64
+	}{
+		{1000000000, 10, 1100000000},
+		{1000000000, 0, 1000000000},
+		{101, 10, 112}, // 向上取整
+	}
+	for _, tt := range tests {
+		if got := BumpGasPrice(big.NewInt(tt.price), tt.percent); got.Int64() != tt.want {
+			t.Errorf("BumpGasPrice(%d, %d) = %s, want %d", tt.price, tt.percent, got, tt.want)
+		}
+	}
+}
+
+func TestCancelAllPending(t *testing.T) {
+	pk, _ := BuildPrivateKeyFromHex("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	signer := types.NewLondonSigner(big.NewInt(1))
+	stuck, _ := types.SignTx(types.NewTx(&types.LegacyTx{Nonce: 3, Gas: 21000, GasPrice: big.NewInt(5e9), Value: big.NewInt(1)}), signer, pk)
+	queued, _ := types.SignTx(types.NewTx(&types.LegacyTx{Nonce: 7, Gas: 21000, GasPrice: big.NewInt(1e9), Value: big.NewInt(1)}), signer, pk)
+
+	server := newMockSendServer(t)
+	server.handlers["eth_getTransactionCount"] = func(params []json.RawMessage) (interface{}, error) {
+		var tag string
+		_ = json.Unmarshal(params[1], &tag)
+		if tag == "pending" {
+			return "0x5", nil
+		}
+		return "0x3", nil
+	}
+	server.handlers["txpool_contentFrom"] = mockResult(map[string]map[string]*types.Transaction{
+		"pending": {"3": stuck},
+		"queued":  {"7": queued},
+	})
+	var sentNonces []uint64
+	server.handlers["eth_sendRawTransaction"] = func(params []json.RawMessage) (interface{}, error) {
+		var raw string
+		_ = json.Unmarshal(params[0], &raw)
+		tx, err := DecodeRawTxHex(raw[2:])
+		if err != nil {
+			return nil, err
+		}
+		sentNonces = append(sentNonces, tx.Nonce())
+		return tx.Hash(), nil
+	}
+	kit := newMockKit(t, server)
+
+	results, err := kit.CancelAllPending(context.Background(), 20)
+	if err != nil {
+		t.Fatalf("CancelAllPending() failed: %v", err)
+	}
+	want := []struct {
+		nonce    uint64
+		gasPrice int64
+		replaced common.Hash
+	}{
+		{3, 6e9, stuck.Hash()},    // 原交易 5 gwei 加价 20%
+		{4, 1.2e9, common.Hash{}}, // 交易池中没有记录，按建议价格加价
+		{7, 1.2e9, queued.Hash()}, // queued 交易同样被替换
+	}
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want %d", len(results), len(want))
+	}
+	for i, w := range want {
+		r := results[i]
+		if r.Err != nil {
+			t.Errorf("nonce %d: unexpected error %v", r.Nonce, r.Err)
+		}
+		if r.Nonce != w.nonce || r.GasPrice.Int64() != w.gasPrice || r.Replaced != w.replaced {
+			t.Errorf("result[%d] = {nonce %d, gasPrice %s, replaced %s}, want {%d, %d, %s}",
+				i, r.Nonce, r.GasPrice, r.Replaced.Hex(), w.nonce, w.gasPrice, w.replaced.Hex())
+		}
+	}
+	if len(sentNonces) != 3 || sentNonces[0] != 3 || sentNonces[2] != 7 {
+		t.Errorf("sent nonces = %v, want [3 4 7]", sentNonces)
+	}
+}
@@ -0,0 +1,98 @@
+package etherkit
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+//############ EIP-712 ############
+
+// EIP712DomainTypes 根据域中已设置的字段生成 EIP712Domain 类型定义
+// EIP-712 要求 EIP712Domain 只包含实际使用的字段，并按 name、version、chainId、verifyingContract、salt 的顺序排列
+// 参数说明：
+//   - domain: EIP-712 域
+//
+// 返回：
+//   - []apitypes.Type: EIP712Domain 的字段定义
+func EIP712DomainTypes(domain apitypes.TypedDataDomain) []apitypes.Type {
+	var fields []apitypes.Type
+	if domain.Name != "" {
+		fields = append(fields, apitypes.Type{Name: "name", Type: "string"})
+	}
+	if domain.Version != "" {
+		fields = append(fields, apitypes.Type{Name: "version", Type: "string"})
+	}
+	if domain.ChainId != nil {
+		fields = append(fields, apitypes.Type{Name: "chainId", Type: "uint256"})
+	}
+	if domain.VerifyingContract != "" {
+		fields = append(fields, apitypes.Type{Name: "verifyingContract", Type: "address"})
+	}
+	if domain.Salt != "" {
+		fields = append(fields, apitypes.Type{Name: "salt", Type: "bytes32"})
+	}
+	return fields
+}
+
+// HashTypedData 计算 EIP-712 结构化数据的签名哈希
+// 即 keccak256("\x19\x01" ‖ domainSeparator ‖ hashStruct(message))
+// 参数说明：
+//   - typedData: EIP-712 结构化数据（Types 中未包含 EIP712Domain 时会根据 Domain 自动补全）
+//
+// 返回：
+//   - common.Hash: 待签名的哈希
+//   - error: 如果类型定义或数据不匹配则返回错误
+func HashTypedData(typedData apitypes.TypedData) (common.Hash, error) {
+	if _, ok := typedData.Types["EIP712Domain"]; !ok {
+		types := make(apitypes.Types, len(typedData.Types)+1)
+		for name, fields := range typedData.Types {
+			types[name] = fields
+		}
+		types["EIP712Domain"] = EIP712DomainTypes(typedData.Domain)
+		typedData.Types = types
+	}
+
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(hash), nil
+}
+
+// SignTypedData 使用钱包私钥对 EIP-712 结构化数据进行签名
+// 参数说明：
+//   - typedData: EIP-712 结构化数据
+//
+// 返回：
+//   - []byte: 签名结果（65 字节，r ‖ s ‖ v，v 为 27 或 28，与 eth_signTypedData_v4 一致）
+//   - error: 如果哈希计算或签名失败则返回错误
+func (w *Wallet) SignTypedData(typedData apitypes.TypedData) ([]byte, error) {
+	hash, err := HashTypedData(typedData)
+	if err != nil {
+		return nil, err
+	}
+	return w.SignHash(hash)
+}
+
+// SignHash 直接对 32 字节哈希进行签名（不再做任何哈希处理）
+// 参数说明：
+//   - hash: 待签名的哈希
+//
+// 返回：
+//   - []byte: 签名结果（65 字节，r ‖ s ‖ v，v 为 27 或 28）
+//   - error: 如果签名失败则返回错误（节点管理的账户返回 ErrNodeAccountUnsupported）
+func (w *Wallet) SignHash(hash common.Hash) ([]byte, error) {
+	if w.IsReadOnly() {
+		return nil, ErrReadOnly
+	}
+	if w.nodeAccount {
+		return nil, ErrNodeAccountUnsupported
+	}
+	sig, err := crypto.Sign(hash.Bytes(), w.privateKey)
+	if err != nil {
+		return nil, err
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+	return sig, nil
+}
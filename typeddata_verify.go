@@ -0,0 +1,89 @@
+package etherkit
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+//############ Signature Verification ############
+
+// EIP1271MagicValue 合约钱包 isValidSignature(bytes32,bytes) 校验通过时返回的值（即该函数的选择器）
+var EIP1271MagicValue = [4]byte{0x16, 0x26, 0xba, 0x7e}
+
+// eip1271Args isValidSignature 的参数类型
+var eip1271Args = func() abi.Arguments {
+	bytes32Type, _ := abi.NewType("bytes32", "", nil)
+	bytesType, _ := abi.NewType("bytes", "", nil)
+	return abi.Arguments{{Type: bytes32Type}, {Type: bytesType}}
+}()
+
+// VerifyHashSignature 校验签名是否由指定账户对哈希签署
+// 先按 ECDSA 恢复签名者；不匹配且提供了 ep 时，再调用 expected 的 isValidSignature（EIP-1271），以支持 Safe 等合约钱包
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者（nil 表示只做离线的 ECDSA 校验）
+//   - hash: 被签名的哈希
+//   - signature: 签名
+//   - expected: 期望的签名者（EOA 或合约钱包地址）
+//
+// 返回：
+//   - error: 校验失败返回 ErrSignatureVerificationFailed，调用合约失败则返回相应错误
+func VerifyHashSignature(ctx context.Context, ep EtherProvider, hash common.Hash, signature []byte, expected common.Address) error {
+	recovered, recoverErr := RecoverAddress(hash, signature)
+	if recoverErr == nil && recovered == expected {
+		return nil
+	}
+	if ep == nil {
+		if recoverErr != nil {
+			return recoverErr
+		}
+		return fmt.Errorf("%w: signed by %s, expected %s", ErrSignatureVerificationFailed, recovered.Hex(), expected.Hex())
+	}
+
+	args, err := eip1271Args.Pack(hash, signature)
+	if err != nil {
+		return err
+	}
+	data := append(EIP1271MagicValue[:], args...)
+	res, err := providerExt[BlockRefReader](ep).CallContractAt(ctx, ethereum.CallMsg{To: &expected, Data: data}, BlockRef{})
+	if err != nil {
+		if revert := asRevertError(err); revert != nil {
+			return fmt.Errorf("%w: isValidSignature reverted: %w", ErrSignatureVerificationFailed, revert)
+		}
+		return err
+	}
+	// 返回值为 bytes4，ABI 编码后左对齐在 32 字节中；EOA 没有代码，返回空数据
+	if len(res) < 4 || !bytes.Equal(res[:4], EIP1271MagicValue[:]) {
+		return fmt.Errorf("%w: %s did not accept the signature", ErrSignatureVerificationFailed, expected.Hex())
+	}
+	return nil
+}
+
+// VerifyTypedDataSignature 校验 EIP-712 签名（与 SignTypedData 对应）
+// 按 EIP-712 计算哈希后交给 VerifyHashSignature 校验，支持 EOA 和 EIP-1271 合约钱包
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者（nil 表示只做离线的 ECDSA 校验）
+//   - typedData: EIP-712 结构化数据（domain、types、primaryType 和 message）
+//   - signature: 签名
+//   - expected: 期望的签名者
+//
+// 返回：
+//   - error: 如果哈希计算失败或校验失败（ErrSignatureVerificationFailed）则返回错误
+//
+// 使用示例：
+//
+//	err := VerifyTypedDataSignature(ctx, kit, auth.TypedData(TransferWithAuthorization, domain), signature, auth.From)
+func VerifyTypedDataSignature(ctx context.Context, ep EtherProvider, typedData apitypes.TypedData, signature []byte, expected common.Address) error {
+	hash, err := HashTypedData(typedData)
+	if err != nil {
+		return err
+	}
+	return VerifyHashSignature(ctx, ep, hash, signature, expected)
+}
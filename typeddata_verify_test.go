@@ -0,0 +1,115 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// mailTypedData 测试用的 EIP-712 数据（EIP-712 规范中的 Mail 示例）
+func mailTypedData(contents string) apitypes.TypedData {
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"Mail": []apitypes.Type{{Name: "from", Type: "address"}, {Name: "to", Type: "address"}, {Name: "contents", Type: "string"}},
+		},
+		PrimaryType: "Mail",
+		Domain: apitypes.TypedDataDomain{
+			Name:              "Ether Mail",
+			Version:           "1",
+			ChainId:           math.NewHexOrDecimal256(1),
+			VerifyingContract: "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC",
+		},
+		Message: apitypes.TypedDataMessage{
+			"from":     "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826",
+			"to":       "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB",
+			"contents": contents,
+		},
+	}
+}
+
+func TestVerifyTypedDataSignature(t *testing.T) {
+	account, _ := GetTestAccount(0)
+	other, _ := GetTestAccount(1)
+	wallet, _ := NewWalletWithComponents(account.PrivateKey, nil)
+	signature, err := wallet.SignTypedData(mailTypedData("Hello, Bob!"))
+	if err != nil {
+		t.Fatalf("SignTypedData() failed: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		typedData apitypes.TypedData
+		expected  common.Address
+		wantErr   error
+	}{
+		{"valid signer", mailTypedData("Hello, Bob!"), account.Address, nil},
+		{"tampered message", mailTypedData("Hello, Eve!"), account.Address, ErrSignatureVerificationFailed},
+		{"different signer", mailTypedData("Hello, Bob!"), other.Address, ErrSignatureVerificationFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyTypedDataSignature(context.Background(), nil, tt.typedData, signature, tt.expected)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("VerifyTypedDataSignature() error = %v, expected %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifyTypedDataSignatureEIP1271(t *testing.T) {
+	account, _ := GetTestAccount(0)
+	wallet, _ := NewWalletWithComponents(account.PrivateKey, nil)
+	signature, _ := wallet.SignTypedData(mailTypedData("Hello, Bob!"))
+	contractWallet := common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+
+	tests := []struct {
+		name    string
+		result  string
+		wantErr error
+	}{
+		{"magic value", "0x1626ba7e00000000000000000000000000000000000000000000000000000000", nil},
+		{"rejected", "0xffffffff00000000000000000000000000000000000000000000000000000000", ErrSignatureVerificationFailed},
+		{"no code", "0x", ErrSignatureVerificationFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotTo string
+			server := newMockRPCServer(t, map[string]mockRPCHandler{
+				"eth_call": func(params []json.RawMessage) (interface{}, error) {
+					var arg mockCallArg
+					_ = json.Unmarshal(params[0], &arg)
+					gotTo = arg.To
+					if !hasSelector(arg.calldata(), EIP1271MagicValue) {
+						t.Errorf("eth_call data = %x, expected isValidSignature call", arg.calldata())
+					}
+					return tt.result, nil
+				},
+			})
+			provider, err := NewProvider(server.URL)
+			if err != nil {
+				t.Fatalf("NewProvider() failed: %v", err)
+			}
+			defer provider.Close()
+
+			err = VerifyTypedDataSignature(context.Background(), provider, mailTypedData("Hello, Bob!"), signature, contractWallet)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("VerifyTypedDataSignature() error = %v, expected %v", err, tt.wantErr)
+			}
+			if !common.IsHexAddress(gotTo) || common.HexToAddress(gotTo) != contractWallet {
+				t.Errorf("eth_call to = %s, expected %s", gotTo, contractWallet.Hex())
+			}
+		})
+	}
+}
+
+// hasSelector 检查调用数据是否以指定选择器开头
+func hasSelector(data []byte, selector [4]byte) bool {
+	return len(data) >= 4 && [4]byte(data[:4]) == selector
+}
@@ -0,0 +1,563 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+//############ ERC-4337 UserOperation ############
+
+// EntryPointV06Address ERC-4337 EntryPoint v0.6 合约地址（所有链相同）
+const EntryPointV06Address = "0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789"
+
+// DummyUserOperationSignature 用于 gas 估算的占位签名
+// 格式合法（65 字节、s 值在低半区），可以让账户合约的签名校验走完完整路径而不提前 revert
+const DummyUserOperationSignature = "0xfffffffffffffffffffffffffffffff0000000000000000000000000000000007aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa1c"
+
+// entryPointABI EntryPoint 合约中用到的方法
+const entryPointABI = `[{"inputs":[{"internalType":"address","name":"sender","type":"address"},{"internalType":"uint192","name":"key","type":"uint192"}],"name":"getNonce","outputs":[{"internalType":"uint256","name":"nonce","type":"uint256"}],"stateMutability":"view","type":"function"}]`
+
+// simpleAccountABI SimpleAccount 的 execute 方法（用于审核时识别用户操作最终执行的调用）
+const simpleAccountABI = `[{"inputs":[{"internalType":"address","name":"dest","type":"address"},{"internalType":"uint256","name":"value","type":"uint256"},{"internalType":"bytes","name":"func","type":"bytes"}],"name":"execute","outputs":[],"stateMutability":"nonpayable","type":"function"}]`
+
+var simpleAccountAbi, _ = GetABI(simpleAccountABI)
+
+// UserOperation ERC-4337 用户操作（EntryPoint v0.6 格式）
+type UserOperation struct {
+	Sender               common.Address // 智能合约账户地址
+	Nonce                *big.Int       // 账户在 EntryPoint 中的 nonce（高 192 位为 key，低 64 位为序号）
+	InitCode             []byte         // 账户部署代码（账户已部署时为空）
+	CallData             []byte         // 账户执行的调用数据
+	CallGasLimit         *big.Int       // 执行阶段的 gas 限制
+	VerificationGasLimit *big.Int       // 验证阶段的 gas 限制
+	PreVerificationGas   *big.Int       // 支付给 bundler 的额外 gas（calldata 等开销）
+	MaxFeePerGas         *big.Int       // 最大 gas 费用
+	MaxPriorityFeePerGas *big.Int       // 最大优先费
+	PaymasterAndData     []byte         // paymaster 地址及其数据（无 paymaster 时为空）
+	Signature            []byte         // 账户签名
+}
+
+// userOperationJSON UserOperation 的 JSON-RPC 表示（所有数值为十六进制）
+type userOperationJSON struct {
+	Sender               common.Address `json:"sender"`
+	Nonce                *hexutil.Big   `json:"nonce"`
+	InitCode             hexutil.Bytes  `json:"initCode"`
+	CallData             hexutil.Bytes  `json:"callData"`
+	CallGasLimit         *hexutil.Big   `json:"callGasLimit"`
+	VerificationGasLimit *hexutil.Big   `json:"verificationGasLimit"`
+	PreVerificationGas   *hexutil.Big   `json:"preVerificationGas"`
+	MaxFeePerGas         *hexutil.Big   `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big   `json:"maxPriorityFeePerGas"`
+	PaymasterAndData     hexutil.Bytes  `json:"paymasterAndData"`
+	Signature            hexutil.Bytes  `json:"signature"`
+}
+
+// MarshalJSON 按 bundler RPC 要求的格式编码（nil 数值编码为 0x0，空字节编码为 0x）
+func (op *UserOperation) MarshalJSON() ([]byte, error) {
+	return json.Marshal(userOperationJSON{
+		Sender:               op.Sender,
+		Nonce:                (*hexutil.Big)(bigOrZero(op.Nonce)),
+		InitCode:             nonNilBytes(op.InitCode),
+		CallData:             nonNilBytes(op.CallData),
+		CallGasLimit:         (*hexutil.Big)(bigOrZero(op.CallGasLimit)),
+		VerificationGasLimit: (*hexutil.Big)(bigOrZero(op.VerificationGasLimit)),
+		PreVerificationGas:   (*hexutil.Big)(bigOrZero(op.PreVerificationGas)),
+		MaxFeePerGas:         (*hexutil.Big)(bigOrZero(op.MaxFeePerGas)),
+		MaxPriorityFeePerGas: (*hexutil.Big)(bigOrZero(op.MaxPriorityFeePerGas)),
+		PaymasterAndData:     nonNilBytes(op.PaymasterAndData),
+		Signature:            nonNilBytes(op.Signature),
+	})
+}
+
+// UnmarshalJSON 解析 bundler RPC 返回的 UserOperation
+func (op *UserOperation) UnmarshalJSON(input []byte) error {
+	var dec userOperationJSON
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	*op = UserOperation{
+		Sender:               dec.Sender,
+		Nonce:                (*big.Int)(dec.Nonce),
+		InitCode:             dec.InitCode,
+		CallData:             dec.CallData,
+		CallGasLimit:         (*big.Int)(dec.CallGasLimit),
+		VerificationGasLimit: (*big.Int)(dec.VerificationGasLimit),
+		PreVerificationGas:   (*big.Int)(dec.PreVerificationGas),
+		MaxFeePerGas:         (*big.Int)(dec.MaxFeePerGas),
+		MaxPriorityFeePerGas: (*big.Int)(dec.MaxPriorityFeePerGas),
+		PaymasterAndData:     dec.PaymasterAndData,
+		Signature:            dec.Signature,
+	}
+	return nil
+}
+
+// nonNilBytes 将 nil 字节切片转换为空切片（编码为 "0x" 而不是 null）
+func nonNilBytes(b []byte) hexutil.Bytes {
+	if b == nil {
+		return hexutil.Bytes{}
+	}
+	return b
+}
+
+// ABI 类型（用于 UserOperation 哈希编码）
+var (
+	abiAddressType, _ = abi.NewType("address", "", nil)
+	abiUint256Type, _ = abi.NewType("uint256", "", nil)
+	abiBytes32Type, _ = abi.NewType("bytes32", "", nil)
+)
+
+// userOpPackArgs UserOperation 哈希时使用的 ABI 编码参数
+var userOpPackArgs = abi.Arguments{
+	{Type: abiAddressType}, // sender
+	{Type: abiUint256Type}, // nonce
+	{Type: abiBytes32Type}, // keccak256(initCode)
+	{Type: abiBytes32Type}, // keccak256(callData)
+	{Type: abiUint256Type}, // callGasLimit
+	{Type: abiUint256Type}, // verificationGasLimit
+	{Type: abiUint256Type}, // preVerificationGas
+	{Type: abiUint256Type}, // maxFeePerGas
+	{Type: abiUint256Type}, // maxPriorityFeePerGas
+	{Type: abiBytes32Type}, // keccak256(paymasterAndData)
+}
+
+// userOpHashArgs abi.encode(keccak256(pack(op)), entryPoint, chainId)
+var userOpHashArgs = abi.Arguments{{Type: abiBytes32Type}, {Type: abiAddressType}, {Type: abiUint256Type}}
+
+// Pack 按 EntryPoint v0.6 的规则编码 UserOperation（不包含签名，用于计算哈希）
+// 返回：
+//   - []byte: ABI 编码后的数据
+//   - error: 如果编码失败则返回错误
+func (op *UserOperation) Pack() ([]byte, error) {
+	return userOpPackArgs.Pack(
+		op.Sender,
+		bigOrZero(op.Nonce),
+		crypto.Keccak256Hash(op.InitCode),
+		crypto.Keccak256Hash(op.CallData),
+		bigOrZero(op.CallGasLimit),
+		bigOrZero(op.VerificationGasLimit),
+		bigOrZero(op.PreVerificationGas),
+		bigOrZero(op.MaxFeePerGas),
+		bigOrZero(op.MaxPriorityFeePerGas),
+		crypto.Keccak256Hash(op.PaymasterAndData),
+	)
+}
+
+// Hash 计算 UserOperation 的哈希（与 EntryPoint.getUserOpHash 一致）
+// 参数说明：
+//   - entryPoint: EntryPoint 合约地址
+//   - chainID: 链 ID
+//
+// 返回：
+//   - common.Hash: UserOperation 哈希
+//   - error: 如果编码失败则返回错误
+func (op *UserOperation) Hash(entryPoint common.Address, chainID *big.Int) (common.Hash, error) {
+	packed, err := op.Pack()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	encoded, err := userOpHashArgs.Pack(crypto.Keccak256Hash(packed), entryPoint, bigOrZero(chainID))
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash(encoded), nil
+}
+
+// UserOperationGasEstimate bundler 返回的 gas 估算结果
+type UserOperationGasEstimate struct {
+	PreVerificationGas   *big.Int
+	VerificationGasLimit *big.Int
+	CallGasLimit         *big.Int
+}
+
+// userOperationGasEstimateJSON eth_estimateUserOperationGas 的返回结构
+// 部分 bundler 返回十进制数字而不是十六进制字符串，使用 rpcQuantity 兼容两种格式
+type userOperationGasEstimateJSON struct {
+	PreVerificationGas   rpcQuantity `json:"preVerificationGas"`
+	VerificationGasLimit rpcQuantity `json:"verificationGasLimit"`
+	CallGasLimit         rpcQuantity `json:"callGasLimit"`
+}
+
+// rpcQuantity 兼容十六进制字符串和 JSON 数字的整数
+type rpcQuantity big.Int
+
+// UnmarshalJSON 解析十六进制字符串、十进制字符串或 JSON 数字
+func (q *rpcQuantity) UnmarshalJSON(input []byte) error {
+	s := strings.Trim(string(input), `"`)
+	if s == "" || s == "null" {
+		return nil
+	}
+	var value *big.Int
+	var ok bool
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		value, ok = new(big.Int).SetString(s[2:], 16)
+	} else {
+		value, ok = new(big.Int).SetString(s, 10)
+	}
+	if !ok {
+		return fmt.Errorf("invalid quantity %s", input)
+	}
+	*q = rpcQuantity(*value)
+	return nil
+}
+
+// toBig 转换为 *big.Int
+func (q *rpcQuantity) toBig() *big.Int {
+	return new(big.Int).Set((*big.Int)(q))
+}
+
+// UserOperationReceipt 用户操作收据（eth_getUserOperationReceipt 的返回值）
+type UserOperationReceipt struct {
+	UserOpHash    common.Hash    `json:"userOpHash"`
+	EntryPoint    common.Address `json:"entryPoint"`
+	Sender        common.Address `json:"sender"`
+	Nonce         *hexutil.Big   `json:"nonce"`
+	Paymaster     common.Address `json:"paymaster"`
+	ActualGasCost *hexutil.Big   `json:"actualGasCost"`
+	ActualGasUsed *hexutil.Big   `json:"actualGasUsed"`
+	Success       bool           `json:"success"`
+	Reason        string         `json:"reason"`
+	Logs          []*types.Log   `json:"logs"`
+	Receipt       *types.Receipt `json:"receipt"` // 打包该用户操作的交易收据
+}
+
+// BundlerClient ERC-4337 bundler 的 JSON-RPC 客户端
+type BundlerClient struct {
+	rc         *rpc.Client
+	entryPoint common.Address
+}
+
+// NewBundlerClient 创建 bundler 客户端
+// 参数说明：
+//   - rawUrl: bundler RPC 地址
+//   - entryPoint: EntryPoint 合约地址（如 common.HexToAddress(EntryPointV06Address)）
+//
+// 返回：
+//   - *BundlerClient: bundler 客户端实例
+//   - error: 如果连接失败则返回错误
+func NewBundlerClient(rawUrl string, entryPoint common.Address) (*BundlerClient, error) {
+	rc, err := rpc.Dial(rawUrl)
+	if err != nil {
+		return nil, err
+	}
+	return NewBundlerClientWithRpcClient(rc, entryPoint), nil
+}
+
+// NewBundlerClientWithRpcClient 使用已有的 RPC 客户端创建 bundler 客户端
+// 适用于节点本身同时提供 bundler 接口的场景（如部分节点服务商）
+func NewBundlerClientWithRpcClient(rc *rpc.Client, entryPoint common.Address) *BundlerClient {
+	return &BundlerClient{rc: rc, entryPoint: entryPoint}
+}
+
+// EntryPoint 获取 bundler 客户端使用的 EntryPoint 地址
+func (b *BundlerClient) EntryPoint() common.Address {
+	return b.entryPoint
+}
+
+// Close 关闭 bundler 连接
+func (b *BundlerClient) Close() {
+	b.rc.Close()
+}
+
+// SupportedEntryPoints 获取 bundler 支持的 EntryPoint 地址列表
+func (b *BundlerClient) SupportedEntryPoints(ctx context.Context) ([]common.Address, error) {
+	var result []common.Address
+	err := b.rc.CallContext(ctx, &result, "eth_supportedEntryPoints")
+	return result, err
+}
+
+// EstimateUserOperationGas 估算用户操作的 gas 字段
+// 参数说明：
+//   - ctx: 上下文对象
+//   - op: 用户操作（签名可以使用 DummyUserOperationSignature）
+//
+// 返回：
+//   - *UserOperationGasEstimate: gas 估算结果
+//   - error: 如果估算失败则返回错误
+func (b *BundlerClient) EstimateUserOperationGas(ctx context.Context, op *UserOperation) (*UserOperationGasEstimate, error) {
+	var result userOperationGasEstimateJSON
+	if err := b.rc.CallContext(ctx, &result, "eth_estimateUserOperationGas", op, b.entryPoint); err != nil {
+		return nil, err
+	}
+	return &UserOperationGasEstimate{
+		PreVerificationGas:   result.PreVerificationGas.toBig(),
+		VerificationGasLimit: result.VerificationGasLimit.toBig(),
+		CallGasLimit:         result.CallGasLimit.toBig(),
+	}, nil
+}
+
+// SendUserOperation 将已签名的用户操作提交给 bundler
+// 参数说明：
+//   - ctx: 上下文对象
+//   - op: 已签名的用户操作
+//
+// 返回：
+//   - common.Hash: 用户操作哈希
+//   - error: 如果提交失败则返回错误
+func (b *BundlerClient) SendUserOperation(ctx context.Context, op *UserOperation) (common.Hash, error) {
+	var hash common.Hash
+	err := b.rc.CallContext(ctx, &hash, "eth_sendUserOperation", op, b.entryPoint)
+	return hash, err
+}
+
+// GetUserOperationReceipt 查询用户操作收据
+// 参数说明：
+//   - ctx: 上下文对象
+//   - userOpHash: 用户操作哈希
+//
+// 返回：
+//   - *UserOperationReceipt: 用户操作收据
+//   - error: 如果查询失败则返回错误；用户操作尚未上链时返回 ethereum.NotFound
+func (b *BundlerClient) GetUserOperationReceipt(ctx context.Context, userOpHash common.Hash) (*UserOperationReceipt, error) {
+	var receipt *UserOperationReceipt
+	if err := b.rc.CallContext(ctx, &receipt, "eth_getUserOperationReceipt", userOpHash); err != nil {
+		return nil, err
+	}
+	if receipt == nil {
+		return nil, ethereum.NotFound
+	}
+	return receipt, nil
+}
+
+// WaitForUserOperationReceipt 等待用户操作上链，带超时控制
+// 参数说明：
+//   - ctx: 上下文对象
+//   - userOpHash: 用户操作哈希
+//   - timeout: 超时时间
+//   - interval: 轮询间隔（小于 1 秒时使用 DefaultWaitInterval）
+//
+// 返回：
+//   - *UserOperationReceipt: 用户操作收据（需要检查 Success 字段判断执行是否成功）
+//   - error: 如果超时或查询失败则返回错误
+func (b *BundlerClient) WaitForUserOperationReceipt(ctx context.Context, userOpHash common.Hash, timeout, interval time.Duration) (*UserOperationReceipt, error) {
+	if interval < time.Second {
+		interval = DefaultWaitInterval
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+			receipt, err := b.GetUserOperationReceipt(ctx, userOpHash)
+			if err == nil {
+				return receipt, nil
+			}
+		}
+	}
+}
+
+// GetUserOperationNonce 通过 EntryPoint 查询智能合约账户的 nonce
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者
+//   - entryPoint: EntryPoint 合约地址
+//   - sender: 智能合约账户地址
+//   - key: nonce key（nil 表示 0，即默认的顺序 nonce）
+//
+// 返回：
+//   - *big.Int: 账户 nonce
+//   - error: 如果查询失败则返回错误
+func GetUserOperationNonce(ctx context.Context, ep EtherProvider, entryPoint, sender common.Address, key *big.Int) (*big.Int, error) {
+	parsed, err := GetABI(entryPointABI)
+	if err != nil {
+		return nil, err
+	}
+	data, err := parsed.Pack("getNonce", sender, bigOrZero(key))
+	if err != nil {
+		return nil, err
+	}
+	res, err := providerExt[BlockRefReader](ep).CallContractAt(ctx, ethereum.CallMsg{To: &entryPoint, Data: data}, BlockRef{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query entry point nonce: %w", err)
+	}
+	out, err := parsed.Unpack("getNonce", res)
+	if err != nil {
+		return nil, err
+	}
+	return abi.ConvertType(out[0], new(big.Int)).(*big.Int), nil
+}
+
+// SignUserOperation 使用钱包私钥对用户操作签名
+// 签名方式与 SimpleAccount 等常见账户实现一致：对 userOpHash 做 EIP-191 personal_sign
+// 参数说明：
+//   - op: 用户操作（签名会写入 op.Signature）
+//   - entryPoint: EntryPoint 合约地址
+//   - chainID: 链 ID
+//
+// 返回：
+//   - error: 如果签名失败则返回错误
+func (w *Wallet) SignUserOperation(op *UserOperation, entryPoint common.Address, chainID *big.Int) error {
+	hash, err := op.Hash(entryPoint, chainID)
+	if err != nil {
+		return err
+	}
+	sig, err := w.SignHash(common.BytesToHash(accounts.TextHash(hash.Bytes())))
+	if err != nil {
+		return err
+	}
+	op.Signature = sig
+	return nil
+}
+
+// BuildUserOperation 构建用户操作：通过 EntryPoint 获取 nonce，填充费用字段，并通过 bundler 估算 gas
+// 返回的用户操作尚未签名（Signature 为占位签名），需要调用 SignUserOperation 后再发送
+// 参数说明：
+//   - ctx: 上下文对象
+//   - bundler: bundler 客户端
+//   - sender: 智能合约账户地址
+//   - callData: 账户执行的调用数据（如 SimpleAccount.execute 的编码）
+//   - initCode: 账户部署代码（账户已部署时传 nil）
+//
+// 返回：
+//   - *UserOperation: 已填充 nonce 和 gas 字段的用户操作
+//   - error: 如果查询或估算失败则返回错误
+func (k *Kit) BuildUserOperation(ctx context.Context, bundler *BundlerClient, sender common.Address, callData, initCode []byte) (*UserOperation, error) {
+	return k.buildUserOperation(ctx, bundler, nil, sender, callData, initCode)
+}
+
+// buildUserOperation 构建用户操作，paymaster 不为 nil 时在 gas 估算后申请赞助
+func (k *Kit) buildUserOperation(ctx context.Context, bundler *BundlerClient, paymaster Paymaster, sender common.Address, callData, initCode []byte) (*UserOperation, error) {
+	if bundler == nil {
+		return nil, errors.New("bundler client cannot be nil")
+	}
+
+	nonce, err := GetUserOperationNonce(ctx, k.EtherProvider, bundler.EntryPoint(), sender, nil)
+	if err != nil {
+		return nil, err
+	}
+	fees, err := SuggestDynamicFees(ctx, k.EtherProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	op := &UserOperation{
+		Sender:               sender,
+		Nonce:                nonce,
+		InitCode:             initCode,
+		CallData:             callData,
+		MaxFeePerGas:         fees.MaxFeePerGas,
+		MaxPriorityFeePerGas: fees.MaxPriorityFeePerGas,
+		Signature:            common.FromHex(DummyUserOperationSignature),
+	}
+
+	// 估算时使用 paymaster 的占位数据，使验证阶段的 gas 包含 paymaster 的校验开销
+	if paymaster != nil {
+		stub, err := paymaster.StubPaymasterAndData(ctx, op, bundler.EntryPoint())
+		if err != nil {
+			return nil, fmt.Errorf("failed to build paymaster stub data: %w", err)
+		}
+		op.PaymasterAndData = stub
+	}
+
+	estimate, err := bundler.EstimateUserOperationGas(ctx, op)
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate user operation gas: %w", err)
+	}
+	op.PreVerificationGas = estimate.PreVerificationGas
+	op.VerificationGasLimit = estimate.VerificationGasLimit
+	op.CallGasLimit = estimate.CallGasLimit
+
+	if paymaster != nil {
+		sponsorship, err := paymaster.SponsorUserOperation(ctx, op, bundler.EntryPoint())
+		if err != nil {
+			return nil, fmt.Errorf("failed to sponsor user operation: %w", err)
+		}
+		sponsorship.apply(op)
+	}
+	return op, nil
+}
+
+// SignUserOperation 使用 Kit 的私钥对用户操作签名（链 ID 从 Provider 获取）
+// 参数说明：
+//   - ctx: 上下文对象
+//   - op: 用户操作（签名会写入 op.Signature）
+//   - entryPoint: EntryPoint 合约地址
+//
+// 返回：
+//   - error: 如果审核被拒绝、签名或写入审计记录失败则返回错误
+func (k *Kit) SignUserOperation(ctx context.Context, op *UserOperation, entryPoint common.Address) error {
+	chainId, err := k.GetChainID(ctx)
+	if err != nil {
+		return err
+	}
+	hash, err := op.Hash(entryPoint, chainId)
+	if err != nil {
+		return err
+	}
+	intent := fmt.Sprintf("user operation from %s via entry point %s, nonce %s", op.Sender.Hex(), entryPoint.Hex(), bigOrZero(op.Nonce))
+	review := k.newSignatureReview(AuditKindUserOperation, chainId, common.BytesToHash(accounts.TextHash(hash.Bytes())), intent)
+	review.UserOp = op
+	// SimpleAccount.execute(dest, value, func)：审核账户最终执行的调用
+	method, args := decodeCallData(op.CallData, &simpleAccountAbi)
+	if dest, ok := executeDest(method, args); ok {
+		value, _ := args[1].(*big.Int)
+		data, _ := args[2].([]byte)
+		review.setCall(op.Sender, dest, value, data)
+	} else {
+		// 无法识别账户执行的调用，转出金额未知（双人审批会要求第二审批）
+		review.setCall(op.Sender, op.Sender, nil, op.CallData)
+	}
+	signature, err := k.signReviewed(ctx, review, func() ([]byte, error) {
+		signed := *op
+		if err := k.Wallet.SignUserOperation(&signed, entryPoint, chainId); err != nil {
+			return nil, err
+		}
+		return signed.Signature, nil
+	})
+	if err != nil {
+		return err
+	}
+	op.Signature = signature
+	return nil
+}
+
+// executeDest 识别 SimpleAccount.execute(dest, value, func) 调用并返回目标地址
+func executeDest(method *abi.Method, args []interface{}) (common.Address, bool) {
+	if method == nil || method.Name != "execute" || len(args) != 3 {
+		return common.Address{}, false
+	}
+	dest, ok := args[0].(common.Address)
+	return dest, ok
+}
+
+// SendUserOperation 构建、签名并提交用户操作
+// 参数说明：
+//   - ctx: 上下文对象
+//   - bundler: bundler 客户端
+//   - sender: 智能合约账户地址（Kit 的私钥需要是该账户的 owner）
+//   - callData: 账户执行的调用数据
+//   - initCode: 账户部署代码（账户已部署时传 nil）
+//
+// 返回：
+//   - common.Hash: 用户操作哈希（可用于 WaitForUserOperationReceipt）
+//   - error: 如果任一步骤失败则返回错误
+func (k *Kit) SendUserOperation(ctx context.Context, bundler *BundlerClient, sender common.Address, callData, initCode []byte) (common.Hash, error) {
+	op, err := k.BuildUserOperation(ctx, bundler, sender, callData, initCode)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if err := k.SignUserOperation(ctx, op, bundler.EntryPoint()); err != nil {
+		return common.Hash{}, err
+	}
+	return bundler.SendUserOperation(ctx, op)
+}
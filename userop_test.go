@@ -0,0 +1,132 @@
+package etherkit
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestUserOperationJSON(t *testing.T) {
+	op := &UserOperation{
+		Sender:       common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8"),
+		Nonce:        big.NewInt(5),
+		CallData:     []byte{0xb6, 0x1d, 0x27, 0xf6},
+		CallGasLimit: big.NewInt(100000),
+	}
+
+	data, err := json.Marshal(op)
+	if err != nil {
+		t.Fatalf("json.Marshal() failed: %v", err)
+	}
+
+	var fields map[string]string
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("json.Unmarshal() failed: %v", err)
+	}
+	// 所有字段都必须存在，数值为十六进制，空字节为 0x
+	expected := map[string]string{
+		"nonce":            "0x5",
+		"initCode":         "0x",
+		"callData":         "0xb61d27f6",
+		"callGasLimit":     "0x186a0",
+		"maxFeePerGas":     "0x0",
+		"paymasterAndData": "0x",
+	}
+	for key, value := range expected {
+		if fields[key] != value {
+			t.Errorf("%s = %q, expected %q", key, fields[key], value)
+		}
+	}
+
+	var decoded UserOperation
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("UserOperation.UnmarshalJSON() failed: %v", err)
+	}
+	if decoded.Sender != op.Sender || decoded.Nonce.Cmp(op.Nonce) != 0 || decoded.CallGasLimit.Cmp(op.CallGasLimit) != 0 {
+		t.Errorf("Decoded user operation mismatch: %+v", decoded)
+	}
+}
+
+func TestSignUserOperation(t *testing.T) {
+	pk, _ := BuildPrivateKeyFromHex("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	wallet, err := NewWalletWithComponents(pk, nil)
+	if err != nil {
+		t.Fatalf("NewWalletWithComponents() failed: %v", err)
+	}
+
+	entryPoint := common.HexToAddress(EntryPointV06Address)
+	chainID := big.NewInt(SepoliaChainID)
+	op := &UserOperation{Sender: common.HexToAddress("0x1234"), Nonce: big.NewInt(1)}
+
+	if err := wallet.SignUserOperation(op, entryPoint, chainID); err != nil {
+		t.Fatalf("SignUserOperation() failed: %v", err)
+	}
+
+	// 签名应为 userOpHash 的 personal_sign 签名
+	hash, _ := op.Hash(entryPoint, chainID)
+	sig := common.CopyBytes(op.Signature)
+	sig[64] -= 27
+	pub, err := crypto.SigToPub(accounts.TextHash(hash.Bytes()), sig)
+	if err != nil {
+		t.Fatalf("SigToPub() failed: %v", err)
+	}
+	if crypto.PubkeyToAddress(*pub) != wallet.GetAddress() {
+		t.Error("Recovered signer does not match wallet address")
+	}
+
+	// 签名不参与哈希计算
+	hashAfter, _ := op.Hash(entryPoint, chainID)
+	if hash != hashAfter {
+		t.Error("User operation hash should not depend on signature")
+	}
+	// 不同链的哈希不同
+	otherHash, _ := op.Hash(entryPoint, big.NewInt(MainnetChainID))
+	if otherHash == hash {
+		t.Error("User operation hash should depend on chain id")
+	}
+}
+
+func TestBundlerClientEstimateAndSend(t *testing.T) {
+	userOpHash := common.HexToHash("0xabcdef")
+	server := newMockRPCServer(t, map[string]mockRPCHandler{
+		// 部分 bundler 返回十进制数字
+		"eth_estimateUserOperationGas": mockResult(json.RawMessage(`{"preVerificationGas":"0xc350","verificationGasLimit":150000,"callGasLimit":"0x2710"}`)),
+		"eth_sendUserOperation":        mockResult(userOpHash),
+		"eth_getUserOperationReceipt":  mockResult(nil),
+	})
+
+	bundler, err := NewBundlerClient(server.URL, common.HexToAddress(EntryPointV06Address))
+	if err != nil {
+		t.Fatalf("NewBundlerClient() failed: %v", err)
+	}
+	defer bundler.Close()
+
+	ctx := context.Background()
+	op := &UserOperation{Sender: common.HexToAddress("0x1234"), Signature: common.FromHex(DummyUserOperationSignature)}
+
+	estimate, err := bundler.EstimateUserOperationGas(ctx, op)
+	if err != nil {
+		t.Fatalf("EstimateUserOperationGas() failed: %v", err)
+	}
+	if estimate.PreVerificationGas.Int64() != 50000 || estimate.VerificationGasLimit.Int64() != 150000 || estimate.CallGasLimit.Int64() != 10000 {
+		t.Errorf("Unexpected gas estimate: %+v", estimate)
+	}
+
+	hash, err := bundler.SendUserOperation(ctx, op)
+	if err != nil {
+		t.Fatalf("SendUserOperation() failed: %v", err)
+	}
+	if hash != userOpHash {
+		t.Errorf("SendUserOperation() = %s, expected %s", hash.Hex(), userOpHash.Hex())
+	}
+
+	// 尚未上链的用户操作返回 NotFound
+	if _, err := bundler.GetUserOperationReceipt(ctx, hash); err == nil {
+		t.Error("Expected error for pending user operation")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"math/big"
+	"sync/atomic"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
@@ -34,6 +35,10 @@ type EtherWallet interface {
 	// 返回：
 	//   - *ecdsa.PrivateKey: ECDSA 私钥对象
 	GetPrivateKey() *ecdsa.PrivateKey
+	// IsReadOnly 判断钱包是否为只读（没有私钥，签名和发送交易会返回 ErrReadOnly）
+	IsReadOnly() bool
+	// IsNodeAccount 判断钱包是否使用节点管理的账户（交易由节点签名，GetPrivateKey 返回 nil）
+	IsNodeAccount() bool
 	// CloseWallet 关闭钱包连接
 	// 释放所有底层资源
 	CloseWallet()
@@ -136,7 +141,7 @@ type EtherWallet interface {
 	//   - signedTx: 已签名的交易对象
 	// 返回：
 	//   - common.Hash: 交易哈希
-	//   - error: 如果发送失败则返回错误
+	//   - error: 如果余额不足（*InsufficientFundsError）或发送失败则返回错误
 	SendSignedTx(ctx context.Context, signedTx *types.Transaction) (common.Hash, error)
 	// Signature 对数据进行签名
 	// 使用钱包的私钥对数据进行 ECDSA 签名
@@ -166,9 +171,14 @@ type EtherWallet interface {
 // Wallet 以太坊钱包实现
 // 封装了私钥、地址和提供者，提供钱包管理、交易构建、签名和发送等功能
 type Wallet struct {
-	privateKey *ecdsa.PrivateKey // ECDSA 私钥
-	address    common.Address    // 钱包地址（从私钥派生）
-	ep         EtherProvider     // 以太坊提供者
+	privateKey  *ecdsa.PrivateKey // ECDSA 私钥
+	address     common.Address    // 钱包地址（从私钥派生）
+	ep          EtherProvider     // 以太坊提供者
+	gasPricer   GasPricer         // gas 价格来源（nil 表示使用节点的 eth_gasPrice）
+	nodeAccount bool              // 账户由节点管理，交易通过 eth_signTransaction 签名（见 NewNodeWallet）
+	signerKind  SignerKind        // 签名交易时使用的签名器（见 SetSignerKind）
+	feeMode     FeeMode           // 构建交易时使用的费用类型（见 SetFeeMode）
+	eip1559     atomic.Int32      // 链是否支持 EIP-1559 的检测结果缓存
 }
 
 // NewWallet 创建新的钱包实例
@@ -245,6 +255,11 @@ func (w *Wallet) GetPrivateKey() *ecdsa.PrivateKey {
 	return w.privateKey
 }
 
+// IsReadOnly 判断钱包是否为只读（没有私钥且不是节点管理的账户，不能签名和发送交易）
+func (w *Wallet) IsReadOnly() bool {
+	return w.privateKey == nil && !w.nodeAccount
+}
+
 // CloseWallet 关闭钱包连接
 // 释放所有底层资源，包括 Provider 的连接
 // 建议在程序退出或不再使用时调用此方法
@@ -252,6 +267,22 @@ func (w *Wallet) CloseWallet() {
 	w.ep.Close()
 }
 
+// SetGasPricer 设置自动获取 gas 价格时使用的价格来源
+// 参数说明：
+//   - gasPricer: gas 价格来源（如 NewPolygonGasStation(...)，nil 表示恢复使用节点的 eth_gasPrice）
+func (w *Wallet) SetGasPricer(gasPricer GasPricer) {
+	w.gasPricer = gasPricer
+}
+
+// suggestGasPrice 获取建议的 gas 价格
+// 优先使用设置的 GasPricer，未设置时使用节点的 eth_gasPrice
+func (w *Wallet) suggestGasPrice(ctx context.Context) (*big.Int, error) {
+	if w.gasPricer != nil {
+		return w.gasPricer.SuggestGasPrice(ctx)
+	}
+	return w.GetEthProvider().GetSuggestGasPrice(ctx)
+}
+
 // GetNonce 获取账户的 nonce
 // nonce 用于防止交易重放，每个交易必须使用唯一的 nonce
 // 返回待处理状态的 nonce（pending nonce），即下一个可用的 nonce
@@ -262,6 +293,9 @@ func (w *Wallet) CloseWallet() {
 //   - uint64: 下一个可用的 nonce
 //   - error: 如果查询失败则返回错误
 func (w *Wallet) GetNonce(ctx context.Context) (uint64, error) {
+	if w.IsReadOnly() {
+		return 0, ErrReadOnly
+	}
 	return w.GetClient().PendingNonceAt(ctx, w.GetAddress())
 }
 
@@ -274,11 +308,15 @@ func (w *Wallet) GetNonce(ctx context.Context) (uint64, error) {
 //   - *big.Int: 余额（单位为 Wei）
 //   - error: 如果查询失败则返回错误
 func (w *Wallet) GetBalance(ctx context.Context) (*big.Int, error) {
+	if w.IsReadOnly() {
+		return nil, ErrReadOnly
+	}
 	return w.GetClient().BalanceAt(ctx, w.GetAddress(), nil)
 }
 
 // NewTx 构建一笔交易
 // 自动计算 nonce、gasLimit 和 gasPrice（如果未提供）
+// 费用类型由 SetFeeMode 决定：默认在支持 EIP-1559 的链上、未指定 gasPrice 时构建动态费用交易，否则构建 legacy 交易
 // 参数说明：
 //   - ctx: 上下文对象
 //   - to: 接收地址（合约地址或普通地址）
@@ -292,6 +330,9 @@ func (w *Wallet) GetBalance(ctx context.Context) (*big.Int, error) {
 //   - *types.Transaction: 交易对象（未签名）
 //   - error: 如果构建失败则返回错误
 func (w *Wallet) NewTx(ctx context.Context, to common.Address, nonce, gasLimit uint64, gasPrice, value *big.Int, data []byte) (*types.Transaction, error) {
+	if w.IsReadOnly() {
+		return nil, ErrReadOnly
+	}
 
 	if nonce == 0 {
 		var err error
@@ -301,9 +342,27 @@ func (w *Wallet) NewTx(ctx context.Context, to common.Address, nonce, gasLimit u
 		}
 	}
 
+	if w.useDynamicFees(ctx, gasPrice) {
+		chainId, err := w.ep.GetChainID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		fees, err := w.suggestFees(ctx, gasPrice)
+		if err != nil {
+			return nil, err
+		}
+		if gasLimit == 0 {
+			gasLimit, err = w.ep.EstimateGas(ctx, w.GetAddress(), to, nonce, nil, value, data)
+			if err != nil {
+				return nil, NormalizeError(err)
+			}
+		}
+		return NewDynamicFeeTx(chainId, to, nonce, gasLimit, fees.MaxFeePerGas, fees.MaxPriorityFeePerGas, value, data), nil
+	}
+
 	if gasPrice == nil || gasPrice.Sign() == 0 {
 		var err error
-		gasPrice, err = w.GetEthProvider().GetSuggestGasPrice(ctx)
+		gasPrice, err = w.suggestGasPrice(ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -313,7 +372,7 @@ func (w *Wallet) NewTx(ctx context.Context, to common.Address, nonce, gasLimit u
 		var err error
 		gasLimit, err = w.ep.EstimateGas(ctx, w.GetAddress(), to, nonce, gasPrice, value, data)
 		if err != nil {
-			return nil, err
+			return nil, NormalizeError(err)
 		}
 	}
 
@@ -405,20 +464,35 @@ func (w *Wallet) SendTxWithHexInput(ctx context.Context, to common.Address, nonc
 //   - *bind.TransactOpts: 交易选项，可用于合约交互
 //   - error: 如果构建失败则返回错误
 func (w *Wallet) BuildTxOpts(ctx context.Context, value, nonce, gasPrice *big.Int) (*bind.TransactOpts, error) {
+	if w.IsReadOnly() {
+		return nil, ErrReadOnly
+	}
 
 	chainId, err := w.ep.GetChainID(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	txOpts, _ := bind.NewKeyedTransactorWithChainID(w.privateKey, chainId)
+	var txOpts *bind.TransactOpts
+	if w.nodeAccount {
+		txOpts = &bind.TransactOpts{From: w.address, Signer: w.nodeSigner(ctx, chainId), Context: ctx}
+	} else {
+		txOpts, _ = bind.NewKeyedTransactorWithChainID(w.privateKey, chainId)
+		txOpts.Signer = w.keySigner(chainId)
+	}
 
 	txOpts.Value = value
 
-	if gasPrice != nil && gasPrice.Sign() == 1 {
+	if w.useDynamicFees(ctx, gasPrice) {
+		fees, err := w.suggestFees(ctx, gasPrice)
+		if err != nil {
+			return nil, err
+		}
+		txOpts.GasFeeCap, txOpts.GasTipCap = fees.MaxFeePerGas, fees.MaxPriorityFeePerGas
+	} else if gasPrice != nil && gasPrice.Sign() == 1 {
 		txOpts.GasPrice = gasPrice
 	} else {
-		_gasPrice, err := w.GetEthProvider().GetSuggestGasPrice(ctx)
+		_gasPrice, err := w.suggestGasPrice(ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -440,7 +514,7 @@ func (w *Wallet) BuildTxOpts(ctx context.Context, value, nonce, gasPrice *big.In
 }
 
 // SignTx 对交易进行签名
-// 使用钱包的私钥对交易进行 EIP-155 签名（伦敦签名）
+// 使用钱包的私钥对交易进行签名（签名器由 SetSignerKind 决定，默认根据交易类型自动选择）；节点管理的账户通过 eth_signTransaction 由节点签名
 // 参数说明：
 //   - ctx: 上下文对象
 //   - tx: 未签名的交易对象
@@ -449,36 +523,42 @@ func (w *Wallet) BuildTxOpts(ctx context.Context, value, nonce, gasPrice *big.In
 //   - *types.Transaction: 已签名的交易对象
 //   - error: 如果签名失败则返回错误
 func (w *Wallet) SignTx(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	if w.IsReadOnly() {
+		return nil, ErrReadOnly
+	}
 
 	chainId, err := w.ep.GetChainID(ctx)
 	if err != nil {
 		return nil, err
 	}
-
-	// 使用伦敦签名
-	signer := types.NewLondonSigner(chainId)
-	signedTx, err := types.SignTx(tx, signer, w.privateKey)
-	if err != nil {
-		return &types.Transaction{}, err
+	if w.nodeAccount {
+		return w.signTxOnNode(ctx, chainId, tx)
 	}
 
-	return signedTx, nil
+	return w.signTxWithKey(chainId, tx)
 }
 
 // SendSignedTx 发送已签名的交易
-// 将已签名的交易发送到网络
+// 将已签名的交易发送到网络，发送前会检查发送地址余额是否足以支付 value + gasLimit × gasFeeCap
 // 参数说明：
 //   - ctx: 上下文对象
 //   - signedTx: 已签名的交易对象
 //
 // 返回：
 //   - common.Hash: 交易哈希
-//   - error: 如果发送失败则返回错误（如余额不足、nonce 错误等）
+//   - error: 如果发送失败则返回错误（余额不足时返回 *InsufficientFundsError，包含具体缺口）
 func (w *Wallet) SendSignedTx(ctx context.Context, signedTx *types.Transaction) (common.Hash, error) {
-	err := w.GetClient().SendTransaction(ctx, signedTx)
+	from, err := txSender(signedTx)
 	if err != nil {
 		return [32]byte{}, err
 	}
+	if err := CheckFunds(ctx, w.ep, from, signedTx); err != nil {
+		return [32]byte{}, err
+	}
+	err = providerExt[TxBroadcaster](w.ep).SendTransaction(ctx, signedTx)
+	if err != nil {
+		return [32]byte{}, NormalizeError(err)
+	}
 	return signedTx.Hash(), nil
 }
 
@@ -490,8 +570,14 @@ func (w *Wallet) SendSignedTx(ctx context.Context, signedTx *types.Transaction)
 //
 // 返回：
 //   - []byte: 签名结果（65 字节，包含 r、s、v）
-//   - error: 如果签名失败则返回错误
+//   - error: 如果签名失败则返回错误（节点管理的账户返回 ErrNodeAccountUnsupported）
 func (w *Wallet) Signature(data []byte) ([]byte, error) {
+	if w.IsReadOnly() {
+		return nil, ErrReadOnly
+	}
+	if w.nodeAccount {
+		return nil, ErrNodeAccountUnsupported
+	}
 	hash := crypto.Keccak256Hash(data)
 	return crypto.Sign(hash.Bytes(), w.privateKey)
 }
@@ -537,7 +623,7 @@ func (w *Wallet) CallContract(ctx context.Context, blockNumber *big.Int, from *c
 	// 执行静态调用（blockNumber 为 nil 表示最新区块）
 	res, err := w.GetClient().CallContract(ctx, callMsg, blockNumber)
 	if err != nil {
-		return nil, err
+		return nil, NormalizeError(err)
 	}
 
 	response, err := contractAbi.Unpack(functionName, res)
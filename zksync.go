@@ -0,0 +1,444 @@
+package etherkit
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+//############ zkSync Era ############
+
+// zkSync Era 相关常量
+const (
+	// ZkSyncEIP712TxType zkSync EIP-712 交易类型（0x71）
+	ZkSyncEIP712TxType = 0x71
+	// ZkSyncDefaultGasPerPubdata 默认每字节 pubdata 的 gas 上限
+	ZkSyncDefaultGasPerPubdata = 50000
+)
+
+// zkSyncTransactionTypes zkSync EIP-712 交易的类型定义
+var zkSyncTransactionTypes = apitypes.Types{
+	"Transaction": {
+		{Name: "txType", Type: "uint256"},
+		{Name: "from", Type: "uint256"},
+		{Name: "to", Type: "uint256"},
+		{Name: "gasLimit", Type: "uint256"},
+		{Name: "gasPerPubdataByteLimit", Type: "uint256"},
+		{Name: "maxFeePerGas", Type: "uint256"},
+		{Name: "maxPriorityFeePerGas", Type: "uint256"},
+		{Name: "paymaster", Type: "uint256"},
+		{Name: "nonce", Type: "uint256"},
+		{Name: "value", Type: "uint256"},
+		{Name: "data", Type: "bytes"},
+		{Name: "factoryDeps", Type: "bytes32[]"},
+		{Name: "paymasterInput", Type: "bytes"},
+	},
+}
+
+// PaymasterParams zkSync 原生 paymaster 参数
+type PaymasterParams struct {
+	Paymaster      common.Address // paymaster 合约地址
+	PaymasterInput []byte         // 传给 paymaster 的输入数据（如 approvalBased / general 流程编码）
+}
+
+// ZkSyncTx zkSync Era 的 EIP-712 交易（类型 0x71）
+// 相比标准交易额外包含 gasPerPubdata、factoryDeps、自定义签名和 paymaster 参数
+type ZkSyncTx struct {
+	ChainID         *big.Int         // 链 ID
+	Nonce           uint64           // 交易 nonce
+	From            common.Address   // 发送地址（zkSync 交易显式包含 from 字段）
+	To              common.Address   // 接收地址
+	Gas             uint64           // Gas 限制
+	GasFeeCap       *big.Int         // maxFeePerGas
+	GasTipCap       *big.Int         // maxPriorityFeePerGas
+	Value           *big.Int         // 转账金额（nil 表示不转账）
+	Data            []byte           // 交易数据
+	GasPerPubdata   *big.Int         // 每字节 pubdata 的 gas 上限（nil 表示使用默认值）
+	FactoryDeps     [][]byte         // 部署合约时依赖的字节码
+	CustomSignature []byte           // 自定义签名（智能合约账户使用，nil 表示使用 ECDSA 签名）
+	Paymaster       *PaymasterParams // paymaster 参数（nil 表示自行支付费用）
+}
+
+// ZkSyncFee zks_estimateFee 返回的费用估算
+type ZkSyncFee struct {
+	GasLimit             *hexutil.Big `json:"gas_limit"`
+	GasPerPubdataLimit   *hexutil.Big `json:"gas_per_pubdata_limit"`
+	MaxFeePerGas         *hexutil.Big `json:"max_fee_per_gas"`
+	MaxPriorityFeePerGas *hexutil.Big `json:"max_priority_fee_per_gas"`
+}
+
+// IsZkSyncChain 判断链是否为 zkSync Era 网络（通过链注册表判断）
+// 参数说明：
+//   - chainID: 链 ID
+//
+// 返回：
+//   - bool: true 表示使用 zkSync 计费模型
+func IsZkSyncChain(chainID *big.Int) bool {
+	return getGasModel(chainID) == GasModelZkSync
+}
+
+// ZkSyncBytecodeHash 计算 zkSync 格式的字节码哈希
+// 格式：版本号（1 字节，固定为 1）‖ 0x00 ‖ 字节码长度（以 32 字节为单位，2 字节大端）‖ sha256(bytecode) 的后 28 字节
+// 参数说明：
+//   - bytecode: 合约字节码（长度必须是 32 的整数倍，且字数为奇数）
+//
+// 返回：
+//   - common.Hash: 字节码哈希
+//   - error: 如果字节码长度不符合要求则返回错误
+func ZkSyncBytecodeHash(bytecode []byte) (common.Hash, error) {
+	if len(bytecode)%32 != 0 {
+		return common.Hash{}, errors.New("zksync bytecode length must be divisible by 32")
+	}
+	words := len(bytecode) / 32
+	if words >= 1<<16 {
+		return common.Hash{}, errors.New("zksync bytecode is too long")
+	}
+	if words%2 == 0 {
+		return common.Hash{}, errors.New("zksync bytecode length in words must be odd")
+	}
+
+	sum := sha256.Sum256(bytecode)
+	var hash common.Hash
+	hash[0] = 1
+	hash[1] = 0
+	hash[2] = byte(words >> 8)
+	hash[3] = byte(words)
+	copy(hash[4:], sum[4:])
+	return hash, nil
+}
+
+// gasPerPubdata 获取每字节 pubdata 的 gas 上限（未设置时返回默认值）
+func (tx *ZkSyncTx) gasPerPubdata() *big.Int {
+	if tx.GasPerPubdata == nil {
+		return big.NewInt(ZkSyncDefaultGasPerPubdata)
+	}
+	return tx.GasPerPubdata
+}
+
+// TypedData 构建交易对应的 EIP-712 结构化数据
+// 返回：
+//   - apitypes.TypedData: 用于签名的结构化数据（域为 {name: "zkSync", version: "2", chainId}）
+//   - error: 如果计算 factoryDeps 的字节码哈希失败则返回错误
+func (tx *ZkSyncTx) TypedData() (apitypes.TypedData, error) {
+	factoryDeps := make([]interface{}, 0, len(tx.FactoryDeps))
+	for _, dep := range tx.FactoryDeps {
+		hash, err := ZkSyncBytecodeHash(dep)
+		if err != nil {
+			return apitypes.TypedData{}, err
+		}
+		factoryDeps = append(factoryDeps, hash.Hex())
+	}
+
+	paymaster := big.NewInt(0)
+	paymasterInput := []byte{}
+	if tx.Paymaster != nil {
+		paymaster = new(big.Int).SetBytes(tx.Paymaster.Paymaster.Bytes())
+		paymasterInput = tx.Paymaster.PaymasterInput
+	}
+
+	return apitypes.TypedData{
+		Types:       zkSyncTransactionTypes,
+		PrimaryType: "Transaction",
+		Domain: apitypes.TypedDataDomain{
+			Name:    "zkSync",
+			Version: "2",
+			ChainId: (*math.HexOrDecimal256)(tx.ChainID),
+		},
+		Message: apitypes.TypedDataMessage{
+			"txType":                 big.NewInt(ZkSyncEIP712TxType),
+			"from":                   new(big.Int).SetBytes(tx.From.Bytes()),
+			"to":                     new(big.Int).SetBytes(tx.To.Bytes()),
+			"gasLimit":               new(big.Int).SetUint64(tx.Gas),
+			"gasPerPubdataByteLimit": tx.gasPerPubdata(),
+			"maxFeePerGas":           bigOrZero(tx.GasFeeCap),
+			"maxPriorityFeePerGas":   bigOrZero(tx.GasTipCap),
+			"paymaster":              paymaster,
+			"nonce":                  new(big.Int).SetUint64(tx.Nonce),
+			"value":                  bigOrZero(tx.Value),
+			"data":                   hexutil.Bytes(tx.Data),
+			"factoryDeps":            factoryDeps,
+			"paymasterInput":         hexutil.Bytes(paymasterInput),
+		},
+	}, nil
+}
+
+// SigningHash 计算交易的 EIP-712 签名哈希
+func (tx *ZkSyncTx) SigningHash() (common.Hash, error) {
+	typedData, err := tx.TypedData()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return HashTypedData(typedData)
+}
+
+// Hash 计算已签名交易的哈希（与 zkSync 节点返回的交易哈希一致）
+// 哈希为 keccak256(signingHash ‖ keccak256(signature))，设置了 CustomSignature 时对自定义签名求哈希
+// 参数说明：
+//   - signature: EIP-712 签名（65 字节）
+//
+// 返回：
+//   - common.Hash: 交易哈希
+//   - error: 如果计算签名哈希失败则返回错误
+func (tx *ZkSyncTx) Hash(signature []byte) (common.Hash, error) {
+	signingHash, err := tx.SigningHash()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if len(tx.CustomSignature) > 0 {
+		signature = tx.CustomSignature
+	}
+	return crypto.Keccak256Hash(signingHash.Bytes(), crypto.Keccak256(signature)), nil
+}
+
+// Serialize 将已签名的交易序列化为原始交易字节（0x71 ‖ RLP(fields)）
+// 参数说明：
+//   - signature: EIP-712 签名（65 字节，v 为 27/28 或 0/1）
+//
+// 返回：
+//   - []byte: 原始交易字节，可通过 eth_sendRawTransaction 发送
+//   - error: 如果签名格式无效或编码失败则返回错误
+func (tx *ZkSyncTx) Serialize(signature []byte) ([]byte, error) {
+	if len(signature) != 65 {
+		return nil, ErrInvalidSignature
+	}
+	v := signature[64]
+	if v >= 27 {
+		v -= 27
+	}
+
+	customSignature := tx.CustomSignature
+	if len(customSignature) == 0 {
+		customSignature = signature
+	}
+
+	factoryDeps := tx.FactoryDeps
+	if factoryDeps == nil {
+		factoryDeps = [][]byte{}
+	}
+
+	var paymasterParams []interface{}
+	if tx.Paymaster != nil {
+		paymasterParams = []interface{}{tx.Paymaster.Paymaster, tx.Paymaster.PaymasterInput}
+	} else {
+		paymasterParams = []interface{}{}
+	}
+
+	fields := []interface{}{
+		tx.Nonce,
+		bigOrZero(tx.GasTipCap),
+		bigOrZero(tx.GasFeeCap),
+		tx.Gas,
+		tx.To,
+		bigOrZero(tx.Value),
+		tx.Data,
+		uint64(v),
+		new(big.Int).SetBytes(signature[:32]),
+		new(big.Int).SetBytes(signature[32:64]),
+		bigOrZero(tx.ChainID),
+		tx.From,
+		tx.gasPerPubdata(),
+		factoryDeps,
+		customSignature,
+		paymasterParams,
+	}
+
+	encoded, err := rlp.EncodeToBytes(fields)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{ZkSyncEIP712TxType}, encoded...), nil
+}
+
+// EstimateZkSyncFee 通过 zks_estimateFee 估算 zkSync 交易的费用
+// 参数说明：
+//   - ctx: 上下文对象
+//   - ep: 以太坊提供者（必须连接到 zkSync Era 网络）
+//   - tx: 待估算的交易（需要设置 From、To、Value、Data，以及可选的 Paymaster）
+//
+// 返回：
+//   - *ZkSyncFee: 费用估算（gas limit、gasPerPubdata、maxFeePerGas、maxPriorityFeePerGas）
+//   - error: 如果估算失败则返回错误
+func EstimateZkSyncFee(ctx context.Context, ep EtherProvider, tx *ZkSyncTx) (*ZkSyncFee, error) {
+	meta := map[string]interface{}{
+		"gasPerPubdata": (*hexutil.Big)(tx.gasPerPubdata()),
+	}
+	if tx.Paymaster != nil {
+		meta["paymasterParams"] = map[string]interface{}{
+			"paymaster":      tx.Paymaster.Paymaster,
+			"paymasterInput": hexutil.Bytes(tx.Paymaster.PaymasterInput),
+		}
+	}
+	if len(tx.FactoryDeps) > 0 {
+		deps := make([]hexutil.Bytes, len(tx.FactoryDeps))
+		for i, dep := range tx.FactoryDeps {
+			deps[i] = dep
+		}
+		meta["factoryDeps"] = deps
+	}
+
+	req := map[string]interface{}{
+		"from":       tx.From,
+		"to":         tx.To,
+		"data":       hexutil.Bytes(tx.Data),
+		"value":      (*hexutil.Big)(bigOrZero(tx.Value)),
+		"eip712Meta": meta,
+	}
+
+	var fee ZkSyncFee
+	if err := ep.GetRpcClient().CallContext(ctx, &fee, "zks_estimateFee", req); err != nil {
+		return nil, fmt.Errorf("failed to call zks_estimateFee: %w", err)
+	}
+	if fee.GasLimit == nil || fee.MaxFeePerGas == nil {
+		return nil, errors.New("zks_estimateFee returned incomplete fee")
+	}
+	return &fee, nil
+}
+
+// SendZkSyncTx 构建、签名并发送 zkSync EIP-712 交易（类型 0x71）
+// 自动获取 nonce，并通过 zks_estimateFee 获取 gas limit 与费用
+// 参数说明：
+//   - ctx: 上下文对象
+//   - to: 接收地址（合约地址或普通地址）
+//   - value: 转账金额（nil 表示不转账）
+//   - data: 交易数据（合约调用数据或 nil）
+//   - paymaster: paymaster 参数（nil 表示由 Kit 账户支付费用）
+//
+// 返回：
+//   - common.Hash: 交易哈希
+//   - error: 如果构建、签名或发送失败则返回错误
+func (k *Kit) SendZkSyncTx(ctx context.Context, to common.Address, value *big.Int, data []byte, paymaster *PaymasterParams) (common.Hash, error) {
+	chainId, err := k.GetChainID(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	nonce, err := k.GetNonce(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	tx := &ZkSyncTx{
+		ChainID:   chainId,
+		Nonce:     nonce,
+		From:      k.GetAddress(),
+		To:        to,
+		Value:     value,
+		Data:      data,
+		Paymaster: paymaster,
+	}
+
+	fee, err := EstimateZkSyncFee(ctx, k.EtherProvider, tx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	tx.Gas = fee.GasLimit.ToInt().Uint64()
+	tx.GasFeeCap = fee.MaxFeePerGas.ToInt()
+	tx.GasTipCap = fee.MaxPriorityFeePerGas.ToInt()
+	if fee.GasPerPubdataLimit != nil {
+		tx.GasPerPubdata = fee.GasPerPubdataLimit.ToInt()
+	}
+
+	return k.SendSignedZkSyncTx(ctx, tx)
+}
+
+// signZkSyncTx 按链上交易审核 zkSync 交易后进行 EIP-712 签名并序列化
+// 审计记录与普通交易一致：Hash 为交易哈希，Payload 为已签名的原始交易
+func (k *Kit) signZkSyncTx(ctx context.Context, tx *ZkSyncTx) (common.Hash, []byte, error) {
+	typedData, err := tx.TypedData()
+	if err != nil {
+		return common.Hash{}, nil, err
+	}
+	digest, err := HashTypedData(typedData)
+	if err != nil {
+		return common.Hash{}, nil, err
+	}
+	to := tx.To
+	review := &TxReview{
+		Kind:      AuditKindTransaction,
+		ChainID:   tx.ChainID,
+		From:      tx.From,
+		To:        &to,
+		Value:     bigOrZero(tx.Value),
+		GasLimit:  tx.Gas,
+		GasPrice:  tx.GasFeeCap,
+		MaxFee:    new(big.Int).Mul(bigOrZero(tx.GasFeeCap), new(big.Int).SetUint64(tx.Gas)),
+		Data:      tx.Data,
+		Digest:    digest,
+		TypedData: &typedData,
+	}
+	review.Method, review.Args = decodeCallData(tx.Data, nil)
+	review.Intent = review.String()
+
+	var hash common.Hash
+	raw, err := k.signReviewed(ctx, review, func() ([]byte, error) {
+		signature, err := k.Wallet.SignTypedData(typedData)
+		if err != nil {
+			return nil, err
+		}
+		raw, err := tx.Serialize(signature)
+		if err != nil {
+			return nil, err
+		}
+		if hash, err = tx.Hash(signature); err != nil {
+			return nil, err
+		}
+		review.Digest, review.Message, review.TypedData = hash, raw, nil
+		return raw, nil
+	})
+	if err != nil {
+		return common.Hash{}, nil, err
+	}
+	return hash, raw, nil
+}
+
+// SendSignedZkSyncTx 使用 Kit 的私钥对已构建好的 zkSync 交易签名并发送
+// 适用于需要自行控制 gas、nonce、factoryDeps 等字段的场景
+// 与普通交易相同：签名前执行审核回调并写入审计记录，广播前检查余额（使用 paymaster 时只检查转账金额），
+// 配置了 WithTxStore 时在广播前持久化（ResumePending 会重新广播原始交易），节点错误经 NormalizeError 规范化
+// 参数说明：
+//   - ctx: 上下文对象
+//   - tx: 已填充所有字段的 zkSync 交易
+//
+// 返回：
+//   - common.Hash: 交易哈希
+//   - error: 如果审核被拒绝、余额不足、签名、持久化或发送失败则返回错误
+func (k *Kit) SendSignedZkSyncTx(ctx context.Context, tx *ZkSyncTx) (common.Hash, error) {
+	hash, raw, err := k.signZkSyncTx(ctx, tx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	maxFee := new(big.Int)
+	if tx.Paymaster == nil {
+		maxFee.Mul(bigOrZero(tx.GasFeeCap), new(big.Int).SetUint64(tx.Gas))
+	}
+	if err := checkFunds(ctx, k.EtherProvider, tx.From, bigOrZero(tx.Value), maxFee); err != nil {
+		return common.Hash{}, err
+	}
+	if err := k.storeRawTx(ctx, hash, tx.From, tx.Nonce, raw); err != nil {
+		return common.Hash{}, err
+	}
+	txHash, err := k.sendRawTransaction(ctx, raw)
+	if err := k.settleBroadcast(ctx, hash, err); err != nil {
+		return common.Hash{}, err
+	}
+	if txHash != hash {
+		k.Logger().WarnContext(ctx, "zkSync transaction hash returned by node differs from local hash", "local", hash.Hex(), "node", txHash.Hex())
+	}
+	return txHash, nil
+}
+
+// bigOrZero nil 时返回 0，避免编码 nil 的 *big.Int
+func bigOrZero(v *big.Int) *big.Int {
+	if v == nil {
+		return big.NewInt(0)
+	}
+	return v
+}
@@ -0,0 +1,196 @@
+package etherkit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+func TestZkSyncBytecodeHash(t *testing.T) {
+	bytecode := bytes.Repeat([]byte{0xab}, 32*3)
+	hash, err := ZkSyncBytecodeHash(bytecode)
+	if err != nil {
+		t.Fatalf("ZkSyncBytecodeHash() failed: %v", err)
+	}
+
+	// 版本号和长度前缀
+	if hash[0] != 1 || hash[1] != 0 || hash[2] != 0 || hash[3] != 3 {
+		t.Errorf("Unexpected bytecode hash prefix: %x", hash[:4])
+	}
+
+	// 长度必须是 32 的整数倍
+	if _, err := ZkSyncBytecodeHash(make([]byte, 33)); err == nil {
+		t.Error("Expected error for bytecode not divisible by 32")
+	}
+	// 字数必须是奇数
+	if _, err := ZkSyncBytecodeHash(make([]byte, 64)); err == nil {
+		t.Error("Expected error for even word count")
+	}
+}
+
+func TestZkSyncTxSignAndSerialize(t *testing.T) {
+	pk, err := BuildPrivateKeyFromHex("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	if err != nil {
+		t.Fatalf("BuildPrivateKeyFromHex() failed: %v", err)
+	}
+	wallet, err := NewWalletWithComponents(pk, nil)
+	if err != nil {
+		t.Fatalf("NewWalletWithComponents() failed: %v", err)
+	}
+
+	tx := &ZkSyncTx{
+		ChainID:   big.NewInt(ZkSyncSepoliaChainID),
+		Nonce:     7,
+		From:      wallet.GetAddress(),
+		To:        common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8"),
+		Gas:       300000,
+		GasFeeCap: big.NewInt(250000000),
+		GasTipCap: big.NewInt(0),
+		Value:     big.NewInt(1000),
+		Paymaster: &PaymasterParams{
+			Paymaster:      common.HexToAddress("0x3cB2b87D10Ac01736A65688F3e0Fb1b070B4eea3"),
+			PaymasterInput: []byte{0x8c, 0x5a, 0x34, 0x45},
+		},
+	}
+
+	hash, err := tx.SigningHash()
+	if err != nil {
+		t.Fatalf("SigningHash() failed: %v", err)
+	}
+
+	typedData, err := tx.TypedData()
+	if err != nil {
+		t.Fatalf("TypedData() failed: %v", err)
+	}
+	signature, err := wallet.SignTypedData(typedData)
+	if err != nil {
+		t.Fatalf("SignTypedData() failed: %v", err)
+	}
+	if signature[64] != 27 && signature[64] != 28 {
+		t.Errorf("Signature v = %d, expected 27 or 28", signature[64])
+	}
+
+	// 验证签名可以恢复出发送地址
+	sig := make([]byte, 65)
+	copy(sig, signature)
+	sig[64] -= 27
+	pub, err := crypto.SigToPub(hash.Bytes(), sig)
+	if err != nil {
+		t.Fatalf("SigToPub() failed: %v", err)
+	}
+	if crypto.PubkeyToAddress(*pub) != wallet.GetAddress() {
+		t.Error("Recovered signer does not match wallet address")
+	}
+
+	raw, err := tx.Serialize(signature)
+	if err != nil {
+		t.Fatalf("Serialize() failed: %v", err)
+	}
+	if raw[0] != ZkSyncEIP712TxType {
+		t.Errorf("Raw tx type = %#x, expected %#x", raw[0], ZkSyncEIP712TxType)
+	}
+
+	var fields []rlp.RawValue
+	if err := rlp.DecodeBytes(raw[1:], &fields); err != nil {
+		t.Fatalf("Failed to decode RLP fields: %v", err)
+	}
+	if len(fields) != 16 {
+		t.Errorf("RLP field count = %d, expected 16", len(fields))
+	}
+
+	if _, err := tx.Serialize([]byte{1, 2, 3}); err == nil {
+		t.Error("Expected error for invalid signature length")
+	}
+}
+
+func TestSendSignedZkSyncTx(t *testing.T) {
+	to := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	newTx := func(kit *Kit, value *big.Int) *ZkSyncTx {
+		return &ZkSyncTx{
+			ChainID: big.NewInt(ZkSyncSepoliaChainID), Nonce: 5, From: kit.GetAddress(), To: to,
+			Gas: 300000, GasFeeCap: big.NewInt(250000000), GasTipCap: big.NewInt(0), Value: value,
+		}
+	}
+
+	t.Run("audits, stores and resumes", func(t *testing.T) {
+		server := newMockSendServer(t)
+		var sent []hexutil.Bytes
+		server.handlers["eth_sendRawTransaction"] = func(params []json.RawMessage) (interface{}, error) {
+			var raw hexutil.Bytes
+			_ = json.Unmarshal(params[0], &raw)
+			sent = append(sent, raw)
+			return nil, errors.New("already known")
+		}
+		server.handlers["eth_getTransactionReceipt"] = mockResult(nil)
+		var records []*AuditRecord
+		store := NewMemoryTxStore()
+		kit := newMockKit(t, server, WithTxStore(store), WithAuditSink(AuditSinkFunc(func(ctx context.Context, record *AuditRecord) error {
+			records = append(records, record)
+			return nil
+		})))
+
+		tx := newTx(kit, big.NewInt(1000))
+		if _, err := kit.SendSignedZkSyncTx(context.Background(), tx); !errors.Is(err, ErrAlreadyKnown) {
+			t.Fatalf("SendSignedZkSyncTx() error = %v, expected normalized ErrAlreadyKnown", err)
+		}
+		if len(sent) != 1 || sent[0][0] != ZkSyncEIP712TxType {
+			t.Fatalf("sent = %x, expected one 0x71 transaction", sent)
+		}
+		if len(records) != 1 || records[0].Kind != AuditKindTransaction || !bytes.Equal(records[0].Payload, sent[0]) {
+			t.Fatalf("audit records = %+v", records)
+		}
+		stored, _ := store.List(context.Background())
+		if len(stored) != 1 || stored[0].Hash != records[0].Hash || stored[0].Nonce != 5 || !bytes.Equal(stored[0].Raw, sent[0]) {
+			t.Fatalf("stored = %+v", stored)
+		}
+
+		results, err := kit.ResumePending(context.Background())
+		if err != nil || len(results) != 1 || results[0].Action != ResumeRebroadcast || results[0].Err != nil {
+			t.Fatalf("ResumePending() = %+v, %v", results, err)
+		}
+		if len(sent) != 2 || !bytes.Equal(sent[1], sent[0]) {
+			t.Error("ResumePending() should rebroadcast the raw zkSync transaction")
+		}
+	})
+
+	t.Run("insufficient funds", func(t *testing.T) {
+		server := newMockSendServer(t)
+		store := NewMemoryTxStore()
+		kit := newMockKit(t, server, WithTxStore(store))
+
+		value := new(big.Int).Mul(big.NewInt(2), big.NewInt(1e18))
+		if _, err := kit.SendSignedZkSyncTx(context.Background(), newTx(kit, value)); !errors.Is(err, ErrInsufficientFunds) {
+			t.Fatalf("SendSignedZkSyncTx() error = %v, expected ErrInsufficientFunds", err)
+		}
+		if server.callCount("eth_sendRawTransaction") != 0 {
+			t.Error("transaction should not be broadcast without funds")
+		}
+		if stored, _ := store.List(context.Background()); len(stored) != 0 {
+			t.Errorf("stored = %+v, expected none", stored)
+		}
+	})
+
+	t.Run("rejected transaction is removed from store", func(t *testing.T) {
+		server := newMockSendServer(t)
+		server.handlers["eth_sendRawTransaction"] = func(params []json.RawMessage) (interface{}, error) {
+			return nil, errors.New("nonce too low")
+		}
+		store := NewMemoryTxStore()
+		kit := newMockKit(t, server, WithTxStore(store))
+
+		if _, err := kit.SendSignedZkSyncTx(context.Background(), newTx(kit, nil)); !errors.Is(err, ErrInvalidNonce) {
+			t.Fatalf("SendSignedZkSyncTx() error = %v, expected ErrInvalidNonce", err)
+		}
+		if stored, _ := store.List(context.Background()); len(stored) != 0 {
+			t.Errorf("stored = %+v, expected none", stored)
+		}
+	})
+}